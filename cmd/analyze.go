@@ -0,0 +1,681 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/orchestrator"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/security/sandbox"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/security/validator"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/utils"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze [repository-url | archive]",
+	Short: "Run quality analysis and print the report",
+	Long: `Clone a repository (or extract a local .tar.gz, .tgz, .tar or .zip archive)
+and print the quality report as JSON.
+
+Examples:
+  # Analyze a GitHub repository
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git
+
+  # Analyze a source snapshot without git
+  repo-onboarding-copilot analyze ./snapshot.tar.gz -o report.json
+
+  # Stream findings as newline-delimited JSON for bulk loading
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --format ndjson > findings.ndjson
+
+  # Import anti-patterns and debt items into SonarQube (sonar.externalIssuesReportPaths)
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --format sonar -o sonar-issues.json
+
+  # Export the testing priority matrix for QA as CSV
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --format testing-csv -o testing.csv
+
+  # Print only the estimated days to onboard, with its formula and inputs
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --onboard-estimate
+
+  # Work through the findings one file at a time
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --group-by file -o by-file.json
+
+  # Write report.json, report.html and report.sarif from a single analysis
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --formats json,html,sarif -o report
+
+  # Reuse the clone of a large repository across runs, fetching it at most every 30 minutes
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --repo-cache-dir ~/.cache/repo-onboarding --repo-cache-ttl 30m
+
+  # Only list exported (public API) symbols in the report
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --public-only
+
+  # Focus technical debt on files changed in the last 90 days
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --since 90d
+
+  # Highlight the debt introduced since the last release
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --since-ref v1.4.0
+
+  # PR check: report only what got worse than the main branch report, and fail if anything did
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --baseline main.json --regressions-only --fail-on-regression
+
+  # Iterate on complexity and coverage only, skipping the other analyzers
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --only complexity,coverage
+
+  # Write a heatmap of the 10 most complex files for a slide
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --heatmap complexity.svg --heatmap-top 10
+
+  # Keep just title, files, effort and actions in recommendations, without the generic advice
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --recommendation-detail minimal
+
+  # Coverage sprint: weight the overall score toward coverage and list its recommendations first
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --focus coverage
+
+  # Grade in school letters throughout the report
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --grade-scale letter
+
+  # Fail instead of reporting scores when over 20% of the source could not be parsed
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --max-parse-failure-rate 0.2
+
+  # Compare scores against organization averages
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --benchmark baseline.json
+
+  # List known vulnerabilities of the dependencies from OSV (the only network lookup)
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --osv-lookup
+
+  # Check which files would be analyzed before a long run
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --dry-run
+
+  # See where the time went: clone, parse and each analyzer
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --timings
+
+  # Profile the analysis itself (inspect with go tool pprof)
+  repo-onboarding-copilot analyze https://github.com/owner/repo.git --profile cpu.prof --memprofile mem.prof`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputPath, _ := cmd.Flags().GetString("output")
+		formatName, _ := cmd.Flags().GetString("format")
+		formats, err := metrics.ParseReportFormats(formatName)
+		if err != nil {
+			return fmt.Errorf("invalid --format: %w", err)
+		}
+		if len(formats) > 1 {
+			return fmt.Errorf("--format takes a single format; use --formats for several")
+		}
+		multiFormat := cmd.Flags().Changed("formats")
+		if multiFormat {
+			if cmd.Flags().Changed("format") {
+				return fmt.Errorf("--format and --formats cannot be combined")
+			}
+			names, _ := cmd.Flags().GetString("formats")
+			if formats, err = metrics.ParseReportFormats(names); err != nil {
+				return fmt.Errorf("invalid --formats: %w", err)
+			}
+		}
+		compact, _ := cmd.Flags().GetBool("json-compact")
+		chartsDir, _ := cmd.Flags().GetString("charts-dir")
+		heatmapPath, _ := cmd.Flags().GetString("heatmap")
+		if heatmapPath != "" {
+			if err := metrics.ValidateHeatmapPath(heatmapPath); err != nil {
+				return fmt.Errorf("invalid --heatmap: %w", err)
+			}
+		}
+		heatmapTop, _ := cmd.Flags().GetInt("heatmap-top")
+		if heatmapTop < 1 {
+			return fmt.Errorf("--heatmap-top must be at least 1, got %d", heatmapTop)
+		}
+		languages, _ := cmd.Flags().GetBool("languages")
+		timings, _ := cmd.Flags().GetBool("timings")
+		publicOnly, _ := cmd.Flags().GetBool("public-only")
+		ownership, _ := cmd.Flags().GetBool("ownership")
+		sinceRef, _ := cmd.Flags().GetString("since-ref")
+		listGenerated, _ := cmd.Flags().GetBool("list-generated")
+		includeVendored, _ := cmd.Flags().GetBool("include-vendored")
+		benchmarkPath, _ := cmd.Flags().GetString("benchmark")
+		todoTags, _ := cmd.Flags().GetStringSlice("todo-tags")
+		criticalFiles, _ := cmd.Flags().GetStringSlice("critical-files")
+		testFilePatterns, _ := cmd.Flags().GetStringSlice("test-file-patterns")
+		testFramework, _ := cmd.Flags().GetString("test-framework")
+		switch testFramework {
+		case "", metrics.TestFrameworkJest, metrics.TestFrameworkVitest, metrics.TestFrameworkMocha, metrics.TestFrameworkPytest:
+		default:
+			return fmt.Errorf("--test-framework must be jest, vitest, mocha or pytest, got %q", testFramework)
+		}
+		advisoryCoverage, _ := cmd.Flags().GetBool("advisory-coverage-gate")
+		gateMinConfidence, _ := cmd.Flags().GetFloat64("gate-min-confidence")
+		severityOverrides, _ := cmd.Flags().GetStringToString("severity-override")
+		unknownTypes, err := metrics.SeverityOverrides(severityOverrides).Validate()
+		if err != nil {
+			return fmt.Errorf("invalid --severity-override: %w", err)
+		}
+		for _, findingType := range unknownTypes {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: --severity-override names unknown finding type %q\n", findingType)
+		}
+		grammars, _ := cmd.Flags().GetStringToString("grammar")
+		if err := ast.ValidateGrammars(grammars); err != nil {
+			return fmt.Errorf("invalid --grammar: %w", err)
+		}
+		if gateMinConfidence < 0 || gateMinConfidence > 1 {
+			return fmt.Errorf("--gate-min-confidence must be between 0 and 1, got %g", gateMinConfidence)
+		}
+		maxFindings, _ := cmd.Flags().GetInt("max-findings")
+		if maxFindings < 0 {
+			return fmt.Errorf("--max-findings must not be negative")
+		}
+		maxPerComponent, _ := cmd.Flags().GetInt("max-recommendations-per-component")
+		if maxPerComponent < 0 {
+			return fmt.Errorf("--max-recommendations-per-component must not be negative")
+		}
+		parseWorkers, _ := cmd.Flags().GetInt("parse-workers")
+		if parseWorkers < 0 {
+			return fmt.Errorf("--parse-workers must not be negative")
+		}
+		maxParseFailureRate, _ := cmd.Flags().GetFloat64("max-parse-failure-rate")
+		if maxParseFailureRate < 0 || maxParseFailureRate > 1 {
+			return fmt.Errorf("--max-parse-failure-rate must be between 0 and 1, got %g", maxParseFailureRate)
+		}
+		switchCaseThreshold, _ := cmd.Flags().GetInt("switch-case-threshold")
+		if switchCaseThreshold < 0 {
+			return fmt.Errorf("--switch-case-threshold must not be negative")
+		}
+		blameWorkers, _ := cmd.Flags().GetInt("blame-workers")
+		if blameWorkers < 0 {
+			return fmt.Errorf("--blame-workers must not be negative")
+		}
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		if maxDepth < 1 {
+			return fmt.Errorf("--max-depth must be at least 1, got %d", maxDepth)
+		}
+		var only []metrics.SectionName
+		if value, _ := cmd.Flags().GetString("only"); value != "" {
+			if only, err = metrics.ParseSectionNames(value); err != nil {
+				return fmt.Errorf("invalid --only: %w", err)
+			}
+		}
+		value, _ := cmd.Flags().GetString("recommendation-detail")
+		recommendationDetail, err := metrics.ParseRecommendationDetail(value)
+		if err != nil {
+			return fmt.Errorf("invalid --recommendation-detail: %w", err)
+		}
+		value, _ = cmd.Flags().GetString("grade-scale")
+		gradeScale, err := metrics.ParseGradeScale(value)
+		if err != nil {
+			return fmt.Errorf("invalid --grade-scale: %w", err)
+		}
+		var focus metrics.SectionName
+		if value, _ := cmd.Flags().GetString("focus"); value != "" {
+			if focus, err = metrics.ParseFocus(value); err != nil {
+				return fmt.Errorf("invalid --focus: %w", err)
+			}
+			selected := len(only) == 0 || focus == metrics.SectionSecurity
+			for _, section := range only {
+				selected = selected || section == focus
+			}
+			if !selected {
+				return fmt.Errorf("--focus %s is not among the --only analyzers", focus)
+			}
+		}
+
+		var measuredCoverage *float64
+		if cmd.Flags().Changed("measured-coverage") {
+			value, _ := cmd.Flags().GetFloat64("measured-coverage")
+			if value < 0 || value > 100 {
+				return fmt.Errorf("--measured-coverage must be between 0 and 100, got %.1f", value)
+			}
+			measuredCoverage = &value
+		}
+
+		var scorePrecision *int
+		if cmd.Flags().Changed("score-precision") {
+			value, _ := cmd.Flags().GetInt("score-precision")
+			if value < 0 || value > 6 {
+				return fmt.Errorf("--score-precision must be between 0 and 6, got %d", value)
+			}
+			scorePrecision = &value
+		}
+		maxRepoSize, err := parseMaxRepoSize(cmd)
+		if err != nil {
+			return err
+		}
+		var largeFileSize int64
+		if value, _ := cmd.Flags().GetString("large-file-size"); value != "" {
+			if largeFileSize, err = utils.ParseByteSize(value); err != nil {
+				return fmt.Errorf("invalid --large-file-size: %w", err)
+			}
+		}
+
+		var repoCache sandbox.RepoCache
+		if noCache, _ := cmd.Flags().GetBool("no-cache"); !noCache {
+			repoCache.Dir, _ = cmd.Flags().GetString("repo-cache-dir")
+		}
+		ttl, _ := cmd.Flags().GetString("repo-cache-ttl")
+		if repoCache.TTL, err = utils.ParseDuration(ttl); err != nil {
+			return fmt.Errorf("invalid --repo-cache-ttl: %w", err)
+		}
+
+		osvLookup, _ := cmd.Flags().GetBool("osv-lookup")
+		offline, _ := cmd.Flags().GetBool("offline")
+		timeout, _ := cmd.Flags().GetString("enrichment-timeout")
+		enrichmentTimeout, err := utils.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("invalid --enrichment-timeout: %w", err)
+		}
+
+		var since time.Duration
+		if value, _ := cmd.Flags().GetString("since"); value != "" {
+			if since, err = utils.ParseDuration(value); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+		}
+
+		var history []metrics.HistoricalDataPoint
+		historyPaths, _ := cmd.Flags().GetStringSlice("history")
+		for _, path := range historyPaths {
+			point, err := metrics.LoadHistoricalDataPoint(path)
+			if err != nil {
+				return err
+			}
+			history = append(history, point)
+		}
+
+		var dependencySizes *metrics.DependencySizes
+		if path, _ := cmd.Flags().GetString("dep-sizes"); path != "" {
+			if dependencySizes, err = metrics.LoadDependencySizes(path); err != nil {
+				return err
+			}
+		}
+
+		var recommendationTemplates *metrics.RecommendationTemplates
+		if dir, _ := cmd.Flags().GetString("recommendation-templates"); dir != "" {
+			if recommendationTemplates, err = metrics.LoadRecommendationTemplates(dir); err != nil {
+				return err
+			}
+		}
+
+		var baseline *metrics.BenchmarkBaseline
+		if benchmarkPath != "" {
+			loaded, err := metrics.LoadBenchmarkBaseline(benchmarkPath)
+			if err != nil {
+				return err
+			}
+			baseline = loaded
+		}
+
+		var baselineReport *metrics.QualityReport
+		if path, _ := cmd.Flags().GetString("baseline"); path != "" {
+			if baselineReport, err = metrics.LoadReport(path); err != nil {
+				return err
+			}
+		}
+		regressionsOnly, _ := cmd.Flags().GetBool("regressions-only")
+		failOnRegression, _ := cmd.Flags().GetBool("fail-on-regression")
+		if (regressionsOnly || failOnRegression) && baselineReport == nil {
+			return fmt.Errorf("--regressions-only and --fail-on-regression need a --baseline report")
+		}
+		if regressionsOnly && (multiFormat || formats[0] != metrics.FormatJSON) {
+			return fmt.Errorf("--regressions-only writes a JSON report; it cannot be combined with other formats")
+		}
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		switch groupBy {
+		case metrics.GroupByAnalyzer:
+		case metrics.GroupByFile:
+			if multiFormat || formats[0] != metrics.FormatJSON {
+				return fmt.Errorf("--group-by file writes a JSON report; it cannot be combined with other formats")
+			}
+			if regressionsOnly {
+				return fmt.Errorf("--group-by file and --regressions-only cannot be combined")
+			}
+		default:
+			return fmt.Errorf("--group-by must be analyzer or file, got %q", groupBy)
+		}
+		onboardEstimate, _ := cmd.Flags().GetBool("onboard-estimate")
+		if onboardEstimate && (multiFormat || formats[0] != metrics.FormatJSON || regressionsOnly || groupBy == metrics.GroupByFile) {
+			return fmt.Errorf("--onboard-estimate prints only the estimate; it cannot be combined with other formats, --regressions-only or --group-by file")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Keep stdout clean for the report; audit logs go to stderr
+		log := logger.New()
+		log.SetOutput(os.Stderr)
+
+		opts := orchestrator.Options{
+			RepoURL:            args[0],
+			Logger:             log,
+			MaxRepoSize:        maxRepoSize,
+			RepoCache:          repoCache,
+			LargeFileSize:      largeFileSize,
+			MaxDepth:           maxDepth,
+			Ownership:          ownership,
+			SinceRef:           sinceRef,
+			ListGeneratedFiles: listGenerated,
+			IncludeVendored:    includeVendored,
+			OSVLookup:          osvLookup,
+			Offline:            offline,
+			EnrichmentTimeout:  enrichmentTimeout,
+			GitCache:           metrics.GitDataCacheConfig{BlameWorkers: blameWorkers},
+			ToolVersion:        Version,
+			BuildDate:          BuildDate,
+			ReportConfig: metrics.QualityReportConfig{
+				ReportFormat:            metrics.FormatJSON,
+				IncludeExecutiveSummary: true,
+				PublicOnly:              publicOnly,
+				Benchmark:               baseline,
+				TodoTags:                todoTags,
+				CriticalFiles:           criticalFiles,
+				TestFramework:           testFramework,
+				TestFilePatterns:        testFilePatterns,
+				CoverageGateAdvisory:    advisoryCoverage,
+				GateMinConfidence:       gateMinConfidence,
+				SeverityOverrides:       severityOverrides,
+				Grammars:                grammars,
+				MeasuredCoverage:        measuredCoverage,
+				ScorePrecision:          scorePrecision,
+				History:                 history,
+				MaxFindingsPerCategory:  maxFindings,
+				MaxPerComponent:         maxPerComponent,
+				Since:                   since,
+				ParseWorkers:            parseWorkers,
+				DependencySizes:         dependencySizes,
+				RecommendationTemplates: recommendationTemplates,
+				Only:                    only,
+				RecommendationDetail:    recommendationDetail,
+				GradeScale:              gradeScale,
+				Focus:                   focus,
+				SwitchCaseThreshold:     switchCaseThreshold,
+			},
+		}
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			var selection *orchestrator.FileSelection
+			if validator.IsArchivePath(args[0]) {
+				selection, err = orchestrator.ListArchiveFiles(ctx, args[0], opts)
+			} else {
+				selection, err = orchestrator.ListFiles(ctx, opts)
+			}
+			if err != nil {
+				return err
+			}
+			return writeFileSelection(cmd.OutOrStdout(), selection)
+		}
+
+		cpuProfile, _ := cmd.Flags().GetString("profile")
+		memProfile, _ := cmd.Flags().GetString("memprofile")
+		stopProfiling, err := startProfiling(cpuProfile, memProfile, cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+
+		var report *metrics.QualityReport
+		if validator.IsArchivePath(args[0]) {
+			report, err = orchestrator.AnalyzeArchive(ctx, args[0], opts)
+		} else {
+			report, err = orchestrator.Analyze(ctx, opts)
+		}
+		if profileErr := stopProfiling(); profileErr != nil {
+			return profileErr
+		}
+		if (cpuProfile != "" || memProfile != "") && report != nil && report.Summary != nil && len(report.Summary.LargeFiles) > 0 {
+			var skipped int64
+			for _, file := range report.Summary.LargeFiles {
+				skipped += file.Bytes
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Profile: %d large files (%s) line-counted instead of loaded and parsed\n",
+				len(report.Summary.LargeFiles), formatMegabytes(uint64(skipped)))
+		}
+		if (cpuProfile != "" || memProfile != "") && report != nil && report.RunMetadata != nil && report.RunMetadata.GitDataMillis > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Profile: %s spent reading git history and blame\n",
+				time.Duration(report.RunMetadata.GitDataMillis)*time.Millisecond)
+		}
+		if errors.Is(err, metrics.ErrNoAnalyzableFiles) && report != nil {
+			// An empty repository is a valid outcome, not a failure
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", report.Message)
+		} else if err != nil {
+			return err
+		}
+
+		if len(report.ParseWarnings) > 0 {
+			counts := metrics.ParseWarningCounts(report.ParseWarnings)
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %d files did not parse cleanly (%d unsupported syntax, %d syntax errors, %d not parsed; %d recovered by a degraded parse); see parse_warnings in the report\n",
+				len(report.ParseWarnings), counts[metrics.ParseWarningUnsupportedSyntax], counts[metrics.ParseWarningSyntaxError], counts[metrics.ParseWarningFailed], counts[ast.ParseStatusDegraded])
+		}
+
+		if baselineReport != nil {
+			report.Regressions = metrics.FindRegressions(report, baselineReport)
+		}
+
+		if regressionsOnly {
+			if err := writeRegressions(cmd.OutOrStdout(), outputPath, report.Regressions); err != nil {
+				return err
+			}
+		} else if onboardEstimate {
+			if err := writeOnboardingEstimate(cmd.OutOrStdout(), outputPath, report.TimeToOnboard); err != nil {
+				return err
+			}
+		} else if groupBy == metrics.GroupByFile {
+			if err := writeFindingsByFile(cmd.OutOrStdout(), outputPath, compact, report); err != nil {
+				return err
+			}
+		} else if multiFormat {
+			base := outputBase(outputPath)
+			for _, format := range formats {
+				path := base + metrics.ReportExtension(format)
+				if err := writeReport(cmd.OutOrStdout(), path, format, compact, report); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Wrote report %s\n", path)
+			}
+		} else if err := writeReport(cmd.OutOrStdout(), outputPath, formats[0], compact, report); err != nil {
+			return err
+		}
+
+		if languages && report.LanguageStats != nil {
+			if err := metrics.WriteLanguageTable(cmd.ErrOrStderr(), report.LanguageStats); err != nil {
+				return err
+			}
+		}
+
+		if timings && report.RunMetadata != nil {
+			if err := metrics.WriteTimingTable(cmd.ErrOrStderr(), report.RunMetadata); err != nil {
+				return err
+			}
+		}
+
+		if chartsDir != "" {
+			paths, err := metrics.WriteReportCharts(chartsDir, report)
+			if err != nil {
+				return err
+			}
+			for _, path := range paths {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Wrote chart %s\n", path)
+			}
+		}
+
+		if heatmapPath != "" {
+			if err := metrics.WriteHeatmapFile(heatmapPath, report.DetailedMetrics.Complexity, heatmapTop); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote heatmap %s\n", heatmapPath)
+		}
+
+		if report.Summary != nil && report.Summary.TotalFiles > 0 {
+			failures := metrics.ParseFailures(report.ParseWarnings)
+			if rate := float64(failures) / float64(report.Summary.TotalFiles); rate > maxParseFailureRate {
+				return fmt.Errorf("%w: %d of %d source files (%.0f%%) could not be parsed, above --max-parse-failure-rate %g; the scores do not reflect the repository",
+					errParseFailureRate, failures, report.Summary.TotalFiles, rate*100, maxParseFailureRate)
+			}
+		}
+
+		if failOnRegression && report.Regressions.HasRegressions() {
+			return fmt.Errorf("%w: %d new findings, %d scores dropped since the baseline",
+				errRegression, len(report.Regressions.NewFindings), len(report.Regressions.ScoreDrops))
+		}
+		return nil
+	},
+}
+
+// parseMaxRepoSize reads the --max-repo-size flag; an empty value means the sandbox default
+func parseMaxRepoSize(cmd *cobra.Command) (int64, error) {
+	value, _ := cmd.Flags().GetString("max-repo-size")
+	if value == "" {
+		return 0, nil
+	}
+
+	size, err := utils.ParseByteSize(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --max-repo-size: %w", err)
+	}
+	return size, nil
+}
+
+// outputBase returns the path that --formats appends each format's extension to: the
+// --output value without a report extension, or "report" in the working directory
+func outputBase(outputPath string) string {
+	if outputPath == "" {
+		return "report"
+	}
+	// The longest extension wins, so report.sonar.json loses .sonar.json rather than .json
+	base := outputPath
+	for _, format := range metrics.OutputFormats() {
+		if trimmed, ok := strings.CutSuffix(outputPath, metrics.ReportExtension(format)); ok && trimmed != "" && len(trimmed) < len(base) {
+			base = trimmed
+		}
+	}
+	return base
+}
+
+// writeReport writes the report to outputPath, or to stdout when empty, in format; JSON is
+// indented unless compact
+func writeReport(stdout io.Writer, outputPath string, format metrics.ReportFormat, compact bool, report *metrics.QualityReport) error {
+	out := stdout
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return metrics.RenderReport(out, report, format, compact)
+}
+
+// writeFindingsByFile writes the findings grouped by file to outputPath, or to stdout when
+// empty
+func writeFindingsByFile(stdout io.Writer, outputPath string, compact bool, report *metrics.QualityReport) error {
+	out := stdout
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return metrics.WriteFindingsByFileJSON(out, report, compact)
+}
+
+// writeOnboardingEstimate writes the onboarding estimate to outputPath, or to stdout when
+// empty
+func writeOnboardingEstimate(stdout io.Writer, outputPath string, estimate *metrics.OnboardingEstimate) error {
+	out := stdout
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return metrics.WriteOnboardingEstimate(out, estimate)
+}
+
+// writeRegressions writes the regression report to outputPath, or to stdout when empty
+func writeRegressions(stdout io.Writer, outputPath string, regressions *metrics.RegressionReport) error {
+	out := stdout
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return metrics.WriteRegressionsJSON(out, regressions)
+}
+
+func init() {
+	analyzeCmd.Flags().StringP("output", "o", "", "Write the report to a file instead of stdout")
+	analyzeCmd.Flags().String("format", string(metrics.FormatJSON), "Output format: json (full report), ndjson (one finding per line), sonar (SonarQube generic issue data), html, sarif, testing-csv or testing-board (testing priority matrix as CSV or a Markdown board)")
+	analyzeCmd.Flags().String("formats", "", "Comma-separated output formats (e.g. json,html,sarif), each written to <output>.<ext> from one analysis; --output sets the base path (default report)")
+	analyzeCmd.Flags().String("group-by", metrics.GroupByAnalyzer, "Organize the JSON report by analyzer (the full report) or by file (every anti-pattern, debt item, recommendation and coverage gap listed under its file by line)")
+	analyzeCmd.Flags().Bool("onboard-estimate", false, "Print only the estimated days to onboard with its formula and inputs instead of the report (also in the report as time_to_onboard)")
+	analyzeCmd.Flags().Bool("json-compact", false, "Write the JSON report on a single line instead of indented")
+	analyzeCmd.Flags().String("charts-dir", "", "Also write SVG charts of the component scores and top debt files to this directory")
+	analyzeCmd.Flags().String("heatmap", "", "Also write a file-by-metric complexity heatmap to this path, as SVG (.svg) or HTML (.html)")
+	analyzeCmd.Flags().Int("heatmap-top", metrics.DefaultHeatmapTopN, "Files shown in the --heatmap, most complex first; the rest are summarized as +N more")
+	analyzeCmd.Flags().Bool("languages", false, "Also print a table of files, lines and share per language to stderr")
+	analyzeCmd.Flags().Bool("timings", false, "Also print how long each phase and analyzer took to stderr (always in run_metadata.phases)")
+	analyzeCmd.Flags().Bool("public-only", false, "Limit complexity, coverage and debt listings to exported symbols")
+	analyzeCmd.Flags().String("max-repo-size", "", "Abort clone or extraction once the source exceeds this size (e.g. 500MB, 2GB; default 10GB)")
+	analyzeCmd.Flags().String("repo-cache-dir", "", "Keep clones in this directory across runs, keyed by repository URL, and git fetch them instead of cloning again")
+	analyzeCmd.Flags().String("repo-cache-ttl", "1h", "Reuse a cached clone without fetching when it was fetched within this window (e.g. 30m, 1d; 0 always fetches)")
+	analyzeCmd.Flags().Bool("no-cache", false, "Clone afresh even when --repo-cache-dir is set")
+	analyzeCmd.Flags().String("large-file-size", "", "Line-count source files above this size (e.g. 512KB, 2MB) instead of loading and parsing them (default 1MB)")
+	analyzeCmd.Flags().Int("max-depth", 64, "Skip, with a warning, directories nested deeper than this below the repository root")
+	analyzeCmd.Flags().String("baseline", "", "Earlier JSON report of the repository; adds a regressions section with new findings and dropped scores")
+	analyzeCmd.Flags().Bool("regressions-only", false, "Write only the regressions versus --baseline instead of the full report")
+	analyzeCmd.Flags().Bool("fail-on-regression", false, "Exit with code 9 when anything got worse versus --baseline")
+	analyzeCmd.Flags().String("benchmark", "", "Compare component scores against an organization baseline JSON file")
+	analyzeCmd.Flags().Bool("advisory-coverage-gate", false, "Let estimated coverage warn but never fail the coverage quality gate")
+	analyzeCmd.Flags().Float64("max-parse-failure-rate", 1, "Exit with code 10 when more than this fraction (0-1) of the source files could not be parsed, whatever the score")
+	analyzeCmd.Flags().Float64("gate-min-confidence", 0, "Debt findings below this confidence (0-1) are reported as informational and never affect the quality gate")
+	analyzeCmd.Flags().StringToString("severity-override", nil, "Finding type=severity pairs replacing detected severities before scoring (critical, high, medium, low or info)")
+	analyzeCmd.Flags().StringToString("grammar", nil, "Extension=grammar pairs pinning the parser grammar (javascript, typescript or tsx), e.g. .js=typescript for Flow-typed JavaScript; see doctor for the feature level of each grammar")
+	analyzeCmd.Flags().Float64("measured-coverage", 0, "Line coverage percentage from a real test run; makes the coverage gate authoritative")
+	analyzeCmd.Flags().Int("score-precision", metrics.DefaultScorePrecision, "Decimal places of the overall, component and dashboard scores")
+	analyzeCmd.Flags().Int("max-recommendations-per-component", 0, "Keep at most this many recommendations per component (complexity, coverage, ...) before the overall limit of 20, which always keeps each component's best (0 = unlimited)")
+	analyzeCmd.Flags().Int("max-findings", 0, "Keep at most this many of the most severe untested paths, mock requirements and anti-patterns (0 = unlimited)")
+	analyzeCmd.Flags().String("only", "", "Comma-separated analyzers to run and report (complexity, duplication, technical_debt, coverage, performance, documentation, maintainability, api_surface, todo_inventory); the overall score is reweighted over the selected components")
+	analyzeCmd.Flags().Int("switch-case-threshold", 0, "Cases a switch or if/else-if chain on one value may have before a lookup-table refactor is suggested (0 = 8)")
+	analyzeCmd.Flags().Int("parse-workers", 0, "Files parsed concurrently (0 = one per CPU, GOMAXPROCS)")
+	analyzeCmd.Flags().Int("blame-workers", 0, "git blame processes run concurrently for --since-ref (0 = 4)")
+	analyzeCmd.Flags().StringSlice("history", nil, "Previous JSON reports; the executive summary then describes score changes since the latest one, and files with a falling maintainability index are flagged")
+	analyzeCmd.Flags().String("since-ref", "", "Git ref (branch, tag or commit) to diff against; debt on lines changed after it is listed as new in this change")
+	analyzeCmd.Flags().Bool("ownership", false, "Rank modules by author concentration (bus factor) from git history in an onboarding-risk section")
+	analyzeCmd.Flags().String("dep-sizes", "", "JSON file of heavy dependency sizes merged over the built-in table")
+	analyzeCmd.Flags().String("recommendation-templates", "", "Directory of YAML recommendation templates (text/template, by finding type) merged over the built-in wording")
+	analyzeCmd.Flags().String("recommendation-detail", string(metrics.DetailFull), "How much of each recommendation to report: minimal (title, files, effort and actions), standard (drops the generic process advice) or full")
+	analyzeCmd.Flags().String("focus", "", "Component to emphasize for this run (complexity, duplication, technical_debt, coverage, performance, maintainability, security): it gets half the overall score weight, the others share the rest in their configured proportions, and its recommendations come first; the report notes the focus and the default-weighted score")
+	analyzeCmd.Flags().String("grade-scale", string(metrics.GradeDescriptive), "Grading language of quality_grade, performance_grade and every rendering: descriptive (Excellent, Good, Fair, Poor), letter (A-F) or numeric (the score out of 100)")
+	analyzeCmd.Flags().Bool("list-generated", false, "List the generated files (linguist-generated in .gitattributes or a generated-code header) excluded from the report")
+	analyzeCmd.Flags().Bool("include-vendored", false, "Analyze and score likely-vendored third-party directories (vendor/, third_party/, or license-headed code that never imports the rest) instead of listing them under vendored_code")
+	analyzeCmd.Flags().Bool("osv-lookup", false, "Look the package.json dependencies up in the OSV vulnerability database and list the findings under vulnerabilities; they are not scored")
+	analyzeCmd.Flags().Bool("offline", false, "Keep the network enrichments (--osv-lookup) off the network; run_metadata.enrichments lists them as skipped")
+	analyzeCmd.Flags().String("enrichment-timeout", "15s", "Time each network enrichment may take before it is skipped and the analysis goes on without its data (e.g. 5s, 1m)")
+	analyzeCmd.Flags().String("since", "", "Limit technical debt to files changed within this window (e.g. 90d, 12w); older files are listed as stable")
+	analyzeCmd.Flags().StringSlice("critical-files", nil, "Globs of files that always get a deep-dive section with all their metrics (e.g. src/index.js,src/auth/**)")
+	analyzeCmd.Flags().String("profile", "", "Write a pprof CPU profile of the analysis run to this file")
+	analyzeCmd.Flags().String("memprofile", "", "Write a pprof heap profile taken after the analysis run to this file")
+	analyzeCmd.Flags().String("test-framework", "", "Framework testing recommendations are written for: jest, vitest, mocha or pytest (default detected from config files, package.json and test imports)")
+	analyzeCmd.Flags().StringSlice("test-file-patterns", nil, "Globs marking test files, replacing the defaults (*.test.*, *.spec.*, **/__tests__/**, test_*.py, ...)")
+	analyzeCmd.Flags().Bool("dry-run", false, "Clone or extract the source, apply every file filter and print the files an analysis would read, without analyzing them")
+	analyzeCmd.Flags().StringSlice("todo-tags", nil, "Comment markers collected in the TODO inventory (default TODO,FIXME,HACK,XXX)")
+
+	// REPO_ONBOARDING_FORMAT, REPO_ONBOARDING_OUTPUT and the like predate the per-command names
+	analyzeCmd.Annotations = map[string]string{unprefixedEnvAnnotation: ""}
+
+	rootCmd.AddCommand(analyzeCmd)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestArchive writes files into a .tar.gz source snapshot and returns its path
+func writeTestArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	path := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	return path
+}
+
+// resetFlags puts every flag of cmd and its subcommands back to its default, as the
+// commands are package globals shared by the tests
+func resetFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			var values []string
+			if def := strings.Trim(flag.DefValue, "[]"); def != "" {
+				values = strings.Split(def, ",")
+			}
+			_ = slice.Replace(values)
+		} else {
+			_ = flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	})
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// runCommand executes the CLI with args and returns its stderr
+func runCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs(args)
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+		resetFlags(rootCmd)
+	})
+	err := rootCmd.Execute()
+	return stderr.String(), err
+}
+
+func TestAnalyze_Heatmap(t *testing.T) {
+	archive := writeTestArchive(t, map[string]string{
+		"src/simple.js":  "export function add(a, b) {\n  return a + b;\n}\n",
+		"src/branchy.js": "export function pick(x) {\n  if (x > 1) { return 1; }\n  if (x > 2) { return 2; }\n  return 0;\n}\n",
+	})
+	out := t.TempDir()
+	heatmap := filepath.Join(out, "complexity.svg")
+
+	stderr, err := runCommand(t, "analyze", archive, "--offline", "-o", filepath.Join(out, "report.json"), "--heatmap", heatmap, "--heatmap-top", "1")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Wrote heatmap "+heatmap)
+
+	svg, err := os.ReadFile(heatmap)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(svg), "<svg"))
+	assert.Contains(t, string(svg), "src/branchy.js", "the most complex file is shown")
+	assert.NotContains(t, string(svg), "src/simple.js")
+	assert.Contains(t, string(svg), "+1 more")
+}
+
+func TestAnalyze_HeatmapRejectsUnknownExtension(t *testing.T) {
+	_, err := runCommand(t, "analyze", "https://github.com/owner/repo.git", "--heatmap", "complexity.png")
+	assert.ErrorContains(t, err, `invalid --heatmap: "complexity.png" must end in .svg, .html or .htm`)
+}
+
+func TestOutputBase(t *testing.T) {
+	assert.Equal(t, "report", outputBase(""))
+	assert.Equal(t, "out/report", outputBase("out/report.json"))
+	assert.Equal(t, "out/report", outputBase("out/report.sonar.json"))
+	assert.Equal(t, "out/report", outputBase("out/report.html"))
+	assert.Equal(t, "out/report", outputBase("out/report"))
+	assert.Equal(t, ".json", outputBase(".json"))
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard <reports-dir>",
+	Short: "Build a cross-repository overview page from a directory of JSON reports",
+	Long: `Summarize every JSON report written by analyze in a directory into one HTML page: a
+sortable table of repository, grade, overall score, technical debt hours and score trend,
+linking to each full report.
+
+Reports are grouped by project name. The latest report of each repository is listed and
+the one before it, when present, gives the trend. Links point to the HTML report written
+next to a JSON report (--formats json,html), or to the JSON report itself.
+
+Examples:
+  repo-onboarding-copilot dashboard reports/
+  repo-onboarding-copilot dashboard reports/ -o site/index.html`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputPath, _ := cmd.Flags().GetString("output")
+		if outputPath == "" {
+			outputPath = filepath.Join(args[0], "index.html")
+		}
+
+		dashboard, err := metrics.LoadRepoDashboard(args[0], time.Now())
+		if err != nil {
+			return err
+		}
+		for _, path := range dashboard.Skipped {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipped %s: not a quality report\n", path)
+		}
+		if len(dashboard.Entries) == 0 {
+			return fmt.Errorf("no reports found in %s", args[0])
+		}
+
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create dashboard: %w", err)
+		}
+		defer file.Close()
+
+		if err := metrics.WriteRepoDashboardHTML(file, dashboard, filepath.Dir(outputPath)); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Wrote dashboard of %d repositories to %s\n", len(dashboard.Entries), outputPath)
+		return nil
+	},
+}
+
+func init() {
+	dashboardCmd.Flags().StringP("output", "o", "", "Dashboard file (default <reports-dir>/index.html)")
+
+	rootCmd.AddCommand(dashboardCmd)
+}
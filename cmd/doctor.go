@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/api"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/security/sandbox"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/config"
+)
+
+// doctorNetworkHosts are dialed to check that clones can reach the common forges
+var doctorNetworkHosts = []string{"github.com:443", "gitlab.com:443", "bitbucket.org:443"}
+
+// doctorCheck is the outcome of one environment check
+type doctorCheck struct {
+	name     string
+	required bool // a failure makes doctor exit non-zero
+	detail   string
+	err      error
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the environment can run an analysis",
+	Long: `Check the environment before running a full analysis: a working git binary,
+write access to the temporary directory, and network reachability of common Git hosts.
+The resolved configuration is printed afterwards.
+
+doctor exits non-zero when a required check fails. Network checks only warn, since
+local archives can be analyzed offline.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true, // the check list already explains the failure
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath, _ := cmd.Flags().GetString("config")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout*time.Duration(len(doctorNetworkHosts)+1))
+		defer cancel()
+
+		checks := []doctorCheck{checkGit(ctx), checkTempDir()}
+		for _, host := range doctorNetworkHosts {
+			checks = append(checks, checkNetwork(ctx, host, timeout))
+		}
+
+		cfg, err := config.Load(configPath)
+		configCheck := doctorCheck{name: "config", required: true, err: err, detail: "built-in defaults"}
+		if configPath != "" {
+			configCheck.detail = configPath
+		}
+		checks = append(checks, configCheck)
+
+		out := cmd.OutOrStdout()
+		failed := printDoctorChecks(out, checks)
+		if cfg != nil {
+			printResolvedConfig(out, cfg, configCheck.detail)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d required check(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+// checkGit verifies that git is on PATH and runs
+func checkGit(ctx context.Context) doctorCheck {
+	check := doctorCheck{name: "git", required: true}
+
+	path, err := exec.LookPath("git")
+	if err != nil {
+		check.err = fmt.Errorf("git not found in PATH; install git to clone repositories")
+		return check
+	}
+
+	output, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		check.err = fmt.Errorf("%s --version failed: %w", path, err)
+		return check
+	}
+
+	check.detail = fmt.Sprintf("%s (%s)", strings.TrimSpace(string(output)), path)
+	return check
+}
+
+// checkTempDir verifies that clones and archive extraction can write to the temporary directory
+func checkTempDir() doctorCheck {
+	dir := os.TempDir()
+	check := doctorCheck{name: "temp dir", required: true, detail: dir}
+
+	file, err := os.CreateTemp(dir, "repo-onboarding-doctor-*")
+	if err != nil {
+		check.err = fmt.Errorf("cannot write to %s: %w", dir, err)
+		return check
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if _, err := file.WriteString("ok"); err != nil {
+		check.err = fmt.Errorf("cannot write to %s: %w", dir, err)
+	}
+	return check
+}
+
+// checkNetwork dials host, reporting how long the TCP handshake took
+func checkNetwork(ctx context.Context, host string, timeout time.Duration) doctorCheck {
+	check := doctorCheck{name: "network " + host}
+
+	start := time.Now()
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		check.err = fmt.Errorf("unreachable: %w", err)
+		return check
+	}
+	conn.Close()
+
+	check.detail = fmt.Sprintf("reachable in %s", time.Since(start).Round(time.Millisecond))
+	return check
+}
+
+// printDoctorChecks writes one line per check and returns the number of failed required checks
+func printDoctorChecks(w io.Writer, checks []doctorCheck) int {
+	failed := 0
+	for _, check := range checks {
+		switch {
+		case check.err == nil:
+			fmt.Fprintf(w, "✓ %s: %s\n", check.name, check.detail)
+		case check.required:
+			failed++
+			fmt.Fprintf(w, "✗ %s: %v\n", check.name, check.err)
+		default:
+			fmt.Fprintf(w, "! %s: %v (warning)\n", check.name, check.err)
+		}
+	}
+	return failed
+}
+
+// printResolvedConfig writes the settings an analysis would run with
+func printResolvedConfig(w io.Writer, cfg *config.Config, source string) {
+	authToken := "not set"
+	if os.Getenv(serveAuthTokenEnv) != "" {
+		authToken = "set"
+	}
+
+	rows := [][2]string{
+		{"version", fmt.Sprintf("%s (built %s)", Version, BuildDate)},
+		{"config source", source},
+		{"log level", fmt.Sprintf("%s (%s)", cfg.Logging.Level, cfg.Logging.Format)},
+		{"allowed schemes", strings.Join(cfg.Security.AllowedSchemes, ", ")},
+		{"max URL length", fmt.Sprintf("%d", cfg.Security.MaxURLLength)},
+		{"temp dir", os.TempDir()},
+		{"max repo size", fmt.Sprintf("%s for analyze, %s for serve (override with --max-repo-size)",
+			formatSizeLimit(sandbox.DefaultMaxRepoSize), formatSizeLimit(api.DefaultServerMaxRepoSize))},
+		{serveAuthTokenEnv, authToken},
+	}
+	for _, grammar := range ast.Grammars() {
+		rows = append(rows, [2]string{
+			"grammar " + string(grammar.Grammar),
+			fmt.Sprintf("%s (%s)", grammar.FeatureLevel, strings.Join(grammar.Extensions, ", ")),
+		})
+	}
+
+	fmt.Fprintf(w, "\nResolved configuration:\n")
+	for _, row := range rows {
+		fmt.Fprintf(w, "  %-20s %s\n", row[0]+":", row[1])
+	}
+}
+
+// formatSizeLimit renders a size limit the way --max-repo-size takes it, in whole GB when it
+// divides evenly and in MB otherwise
+func formatSizeLimit(bytes int64) string {
+	if bytes%(1<<30) == 0 {
+		return fmt.Sprintf("%dGB", bytes>>30)
+	}
+	return fmt.Sprintf("%dMB", bytes>>20)
+}
+
+func init() {
+	doctorCmd.Flags().String("config", "", "Configuration file to validate and resolve (default: built-in defaults)")
+	doctorCmd.Flags().Duration("timeout", 5*time.Second, "Timeout for each network reachability check")
+
+	rootCmd.AddCommand(doctorCmd)
+}
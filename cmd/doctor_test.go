@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/config"
+)
+
+func TestPrintResolvedConfig(t *testing.T) {
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	printResolvedConfig(&out, cfg, "built-in defaults")
+
+	assert.Contains(t, out.String(), "config source:       built-in defaults")
+	assert.Contains(t, out.String(), "max repo size:       10GB for analyze, 2GB for serve (override with --max-repo-size)")
+}
+
+func TestFormatSizeLimit(t *testing.T) {
+	assert.Equal(t, "10GB", formatSizeLimit(10<<30))
+	assert.Equal(t, "512MB", formatSizeLimit(512<<20))
+}
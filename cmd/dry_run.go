@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/orchestrator"
+)
+
+// writeFileSelection prints the files a --dry-run resolved: the files to analyze with their
+// sizes, the large files that are only line-counted, the generated files left out, and a
+// closing line with the counts and total size
+func writeFileSelection(w io.Writer, selection *orchestrator.FileSelection) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, file := range selection.Files {
+		fmt.Fprintf(tw, "%s\t%s\n", file.Path, formatBytes(file.Bytes))
+	}
+	if len(selection.LargeFiles) > 0 {
+		fmt.Fprintln(tw, "\nLarge files (line-counted, not parsed):")
+		for _, file := range selection.LargeFiles {
+			fmt.Fprintf(tw, "  %s\t%s\t%d lines\n", file.Path, formatBytes(file.Bytes), file.Lines)
+		}
+	}
+	if len(selection.Generated) > 0 {
+		fmt.Fprintln(tw, "\nGenerated files (excluded):")
+		for _, path := range selection.Generated {
+			fmt.Fprintf(tw, "  %s\n", path)
+		}
+	}
+	fmt.Fprintf(tw, "\n%d files to analyze (%s), %d large files, %d generated files excluded\n",
+		len(selection.Files), formatBytes(selection.TotalBytes), len(selection.LargeFiles), len(selection.Generated))
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write file list: %w", err)
+	}
+	return nil
+}
+
+// formatBytes renders a file size in B, KB or MB
+func formatBytes(bytes int64) string {
+	switch {
+	case bytes < 1<<10:
+		return fmt.Sprintf("%dB", bytes)
+	case bytes < 1<<20:
+		return fmt.Sprintf("%.1fKB", float64(bytes)/(1<<10))
+	default:
+		return formatMegabytes(uint64(bytes))
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// envPrefix starts the environment variable of every flag, followed by the subcommand:
+// analyze --format is read from REPO_ONBOARDING_ANALYZE_FORMAT and serve --timeout from
+// REPO_ONBOARDING_SERVE_TIMEOUT, so same-named flags of different commands never share one
+const envPrefix = "REPO_ONBOARDING_"
+
+// flagEnvAnnotation names the environment variable of a flag that already had its own
+// before flags were bound to REPO_ONBOARDING_ variables; the flag is read from that one only
+const flagEnvAnnotation = "repo-onboarding-env"
+
+// unprefixedEnvAnnotation marks a command whose flags were read from REPO_ONBOARDING_<FLAG>,
+// without the subcommand, before variables were named after their command. Its flags still
+// fall back to those names so existing setups keep working.
+const unprefixedEnvAnnotation = "repo-onboarding-unprefixed-env"
+
+// flagEnvName returns the environment variable bound to a flag of cmd. Flags inherited
+// from the root command are named without a subcommand.
+func flagEnvName(cmd *cobra.Command, flag *pflag.Flag) string {
+	if names := flag.Annotations[flagEnvAnnotation]; len(names) > 0 {
+		return names[0]
+	}
+	name := flag.Name
+	if cmd.HasParent() && cmd.LocalFlags().Lookup(flag.Name) != nil {
+		path := strings.Fields(cmd.CommandPath())[1:] // without the root command
+		name = strings.Join(append(path, name), "_")
+	}
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// flagEnvFallback returns the unprefixed variable a flag of cmd is also read from when
+// flagEnvName is unset; "" unless cmd is marked with unprefixedEnvAnnotation
+func flagEnvFallback(cmd *cobra.Command, flag *pflag.Flag) string {
+	if _, ok := cmd.Annotations[unprefixedEnvAnnotation]; !ok || len(flag.Annotations[flagEnvAnnotation]) > 0 {
+		return ""
+	}
+	if cmd.LocalFlags().Lookup(flag.Name) == nil {
+		return ""
+	}
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag.Name, "-", "_"))
+}
+
+// applyFlagEnv sets every flag of cmd that was not given on the command line from its
+// environment variable, so flags take precedence over the environment and the environment
+// over configuration files and defaults. Values set this way count as given (Changed).
+func applyFlagEnv(cmd *cobra.Command, lookup func(string) (string, bool)) error {
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" || flag.Name == "version" {
+			return
+		}
+		name := flagEnvName(cmd, flag)
+		value, ok := lookup(name)
+		if fallback := flagEnvFallback(cmd, flag); !ok && fallback != "" {
+			name = fallback
+			value, ok = lookup(name)
+		}
+		if !ok {
+			return
+		}
+		if setErr := cmd.Flags().Set(flag.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid %s=%q: %w", name, value, setErr)
+		}
+	})
+	return err
+}
+
+func init() {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return applyFlagEnv(cmd, os.LookupEnv)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envTestCommands builds a root command with two subcommands sharing a flag name
+func envTestCommands() (root, serve, doctor *cobra.Command) {
+	root = &cobra.Command{Use: "repo-onboarding-copilot"}
+	serve = &cobra.Command{Use: "serve", Run: func(*cobra.Command, []string) {}}
+	serve.Flags().Duration("timeout", time.Minute, "")
+	serve.Flags().String("auth-token", "", "")
+	_ = serve.Flags().SetAnnotation("auth-token", flagEnvAnnotation, []string{"COPILOT_AUTH_TOKEN"})
+	doctor = &cobra.Command{Use: "doctor", Run: func(*cobra.Command, []string) {}}
+	doctor.Flags().Duration("timeout", time.Second, "")
+	root.AddCommand(serve, doctor)
+	return root, serve, doctor
+}
+
+// envLookup serves variables from env
+func envLookup(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func TestFlagEnvName(t *testing.T) {
+	_, serve, doctor := envTestCommands()
+
+	assert.Equal(t, "REPO_ONBOARDING_SERVE_TIMEOUT", flagEnvName(serve, serve.Flags().Lookup("timeout")))
+	assert.Equal(t, "REPO_ONBOARDING_DOCTOR_TIMEOUT", flagEnvName(doctor, doctor.Flags().Lookup("timeout")))
+	assert.Equal(t, "COPILOT_AUTH_TOKEN", flagEnvName(serve, serve.Flags().Lookup("auth-token")), "a flag's own variable replaces the generated name")
+}
+
+func TestApplyFlagEnv_PerCommand(t *testing.T) {
+	_, serve, doctor := envTestCommands()
+	env := envLookup(map[string]string{"REPO_ONBOARDING_SERVE_TIMEOUT": "5m", "REPO_ONBOARDING_SERVE_AUTH_TOKEN": "ignored", "COPILOT_AUTH_TOKEN": "secret"})
+
+	require.NoError(t, applyFlagEnv(serve, env))
+	require.NoError(t, applyFlagEnv(doctor, env))
+
+	timeout, _ := serve.Flags().GetDuration("timeout")
+	assert.Equal(t, 5*time.Minute, timeout)
+	assert.True(t, serve.Flags().Changed("timeout"), "values from the environment count as given")
+	token, _ := serve.Flags().GetString("auth-token")
+	assert.Equal(t, "secret", token, "the token has one variable only")
+
+	timeout, _ = doctor.Flags().GetDuration("timeout")
+	assert.Equal(t, time.Second, timeout, "serve's variable leaves doctor alone")
+	assert.False(t, doctor.Flags().Changed("timeout"))
+}
+
+func TestApplyFlagEnv_FlagBeatsEnvironment(t *testing.T) {
+	_, serve, _ := envTestCommands()
+	require.NoError(t, serve.Flags().Set("timeout", "30s"))
+
+	require.NoError(t, applyFlagEnv(serve, envLookup(map[string]string{"REPO_ONBOARDING_SERVE_TIMEOUT": "5m"})))
+
+	timeout, _ := serve.Flags().GetDuration("timeout")
+	assert.Equal(t, 30*time.Second, timeout)
+}
+
+func TestApplyFlagEnv_InvalidValue(t *testing.T) {
+	_, serve, _ := envTestCommands()
+
+	err := applyFlagEnv(serve, envLookup(map[string]string{"REPO_ONBOARDING_SERVE_TIMEOUT": "soon"}))
+
+	assert.ErrorContains(t, err, `invalid REPO_ONBOARDING_SERVE_TIMEOUT="soon"`)
+}
+
+func TestApplyFlagEnv_UnprefixedFallback(t *testing.T) {
+	root, serve, _ := envTestCommands()
+	analyze := &cobra.Command{Use: "analyze", Annotations: map[string]string{unprefixedEnvAnnotation: ""}, Run: func(*cobra.Command, []string) {}}
+	analyze.Flags().String("format", "json", "")
+	analyze.Flags().String("output", "", "")
+	root.AddCommand(analyze)
+
+	env := envLookup(map[string]string{
+		"REPO_ONBOARDING_FORMAT":         "sarif",
+		"REPO_ONBOARDING_OUTPUT":         "old.sarif",
+		"REPO_ONBOARDING_ANALYZE_OUTPUT": "new.sarif",
+		"REPO_ONBOARDING_TIMEOUT":        "5m",
+	})
+	require.NoError(t, applyFlagEnv(analyze, env))
+	require.NoError(t, applyFlagEnv(serve, env))
+
+	format, _ := analyze.Flags().GetString("format")
+	assert.Equal(t, "sarif", format, "the old unprefixed name still works")
+	output, _ := analyze.Flags().GetString("output")
+	assert.Equal(t, "new.sarif", output, "the per-command name wins")
+	assert.False(t, serve.Flags().Changed("timeout"), "only marked commands fall back")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+// Process exit codes, so scripts can tell failure kinds apart
+const (
+	exitOK             = 0
+	exitFailure        = 1
+	exitInvalidInput   = 2
+	exitCloneFailed    = 3
+	exitNoSourceFiles  = 4
+	exitParseFailed    = 5
+	exitAnalysisFailed = 6
+	exitTimeout        = 7
+	exitTooLarge       = 8
+	exitRegression     = 9   // --fail-on-regression found something worse than the baseline
+	exitParseFailures  = 10  // more files failed to parse than --max-parse-failure-rate allows
+	exitCanceled       = 130 // conventional code for SIGINT
+)
+
+// errRegression fails analyze --fail-on-regression when the report got worse than its baseline
+var errRegression = errors.New("regressions found")
+
+// errParseFailureRate fails analyze --max-parse-failure-rate when too much of the source
+// could not be parsed for the scores to be trusted
+var errParseFailureRate = errors.New("too many files failed to parse")
+
+// exitCode maps a pipeline error to the process exit code
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errRegression):
+		return exitRegression
+	case errors.Is(err, errParseFailureRate):
+		return exitParseFailures
+	case errors.Is(err, types.ErrAnalysisTimeout):
+		return exitTimeout
+	case errors.Is(err, types.ErrAnalysisCanceled):
+		return exitCanceled
+	case errors.Is(err, types.ErrInvalidInput):
+		return exitInvalidInput
+	case errors.Is(err, types.ErrRepositoryTooLarge):
+		return exitTooLarge
+	case errors.Is(err, types.ErrCloneFailed), errors.Is(err, types.ErrExtractFailed):
+		return exitCloneFailed
+	case errors.Is(err, types.ErrNoAnalyzableFiles):
+		return exitNoSourceFiles
+	case errors.Is(err, types.ErrParseFailed):
+		return exitParseFailed
+	case errors.Is(err, types.ErrAnalysisFailed):
+		return exitAnalysisFailed
+	default:
+		return exitFailure
+	}
+}
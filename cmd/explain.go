@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <report.json> <finding-id>",
+	Short: "Explain why a single finding was reported",
+	Long: `Print the full detail behind one finding of a JSON report written by analyze: what
+the rule checks, the evidence and metric values that triggered it, the recommended
+actions, and the effort and ROI rationale.
+
+IDs are those of recommendations (COMPLEX-3, PERF-1), technical debt items
+(code_smell_0), coverage gaps (gap_2) and anti-patterns (anti_pattern_0, numbered as in
+--format ndjson).
+
+Examples:
+  repo-onboarding-copilot explain report.json COMPLEX-3
+  repo-onboarding-copilot explain report.json code_smell_0 --json`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		report, err := metrics.LoadReport(args[0])
+		if err != nil {
+			return err
+		}
+
+		explanation, err := metrics.Explain(report, args[1])
+		if err != nil {
+			return fmt.Errorf("%w (list IDs with: repo-onboarding-copilot analyze --format ndjson, or query '.recommendations[].id')", err)
+		}
+
+		if asJSON {
+			data, err := json.MarshalIndent(explanation, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode explanation: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+		return metrics.WriteExplanation(cmd.OutOrStdout(), explanation)
+	},
+}
+
+func init() {
+	explainCmd.Flags().Bool("json", false, "Print the explanation as JSON")
+
+	rootCmd.AddCommand(explainCmd)
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"runtime"
 
 	"github.com/spf13/cobra"
 	"github.com/yenhunghuang/repo-onboarding-copilot/internal/security/validator"
@@ -17,7 +19,7 @@ var (
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "repo-onboarding-copilot [repository-url]",
+	Use:   "repo-onboarding-copilot [repository-url | archive]",
 	Short: "Repository onboarding analysis tool",
 	Long: `Repo Onboarding Copilot is a CLI tool that analyzes Git repositories
 to generate comprehensive onboarding documentation and identify key patterns,
@@ -29,6 +31,9 @@ Examples:
   
   # Analyze using SSH URL
   repo-onboarding-copilot git@github.com:owner/repo.git
+
+  # Validate a source archive (.tar.gz, .tgz, .tar, .zip)
+  repo-onboarding-copilot ./snapshot.tar.gz
   
   # Show version information
   repo-onboarding-copilot --version`,
@@ -42,12 +47,26 @@ Examples:
 		// Initialize logger
 		log := logger.New()
 
+		// Local source archives are validated by extension and content instead of as URLs
+		if validator.IsArchivePath(args[0]) {
+			archive, err := validator.New().ValidateArchive(args[0])
+			if err != nil {
+				log.Error(fmt.Sprintf("Invalid archive: %v", err))
+				os.Exit(exitInvalidInput)
+			}
+
+			fmt.Printf("✓ Archive validated successfully\n")
+			fmt.Printf("✓ Format: %s, Size: %d bytes\n", archive.Format, archive.Size)
+			fmt.Printf("Run 'repo-onboarding-copilot analyze %s' to generate the quality report.\n", args[0])
+			return
+		}
+
 		// Validate repository URL
 		validator := validator.New()
 		validatedURL, err := validator.ValidateRepositoryURL(args[0])
 		if err != nil {
 			log.Error(fmt.Sprintf("Invalid repository URL: %v", err))
-			os.Exit(1)
+			os.Exit(exitInvalidInput)
 		}
 
 		log.Info(fmt.Sprintf("Starting analysis of repository: %s", validatedURL.Raw))
@@ -64,18 +83,33 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("help", "h", false, "Show help information")
 
 	// Version command
-	rootCmd.AddCommand(&cobra.Command{
+	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print version information",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("Repo Onboarding Copilot %s (built %s)\n", Version, BuildDate)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(versionInfo{Version: Version, BuildDate: BuildDate, GoVersion: runtime.Version()})
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Repo Onboarding Copilot %s (built %s, %s)\n", Version, BuildDate, runtime.Version())
+			return nil
 		},
-	})
+	}
+	versionCmd.Flags().Bool("json", false, "Print version, build_date and go_version as JSON")
+	rootCmd.AddCommand(versionCmd)
+}
+
+// versionInfo is the machine-readable output of version --json
+type versionInfo struct {
+	Version   string `json:"version"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfiling starts a pprof CPU profile written to cpuPath and arranges for a heap
+// profile at memPath; either path may be empty to skip that profile. The returned stop
+// function ends the CPU profile, writes the heap profile and prints the heap high-water
+// mark to stats, and must be called once the work being profiled is done.
+func startProfiling(cpuPath, memPath string, stats io.Writer) (func() error, error) {
+	var cpuFile *os.File
+	if cpuPath != "" {
+		file, err := os.Create(cpuPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		cpuFile = file
+	}
+
+	return func() error {
+		if cpuPath == "" && memPath == "" {
+			return nil
+		}
+
+		// HeapSys only grows while the process runs, so it bounds the peak heap size
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		fmt.Fprintf(stats, "Profile: peak heap %s, %s allocated in total\n",
+			formatMegabytes(memStats.HeapSys), formatMegabytes(memStats.TotalAlloc))
+
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			if err := cpuFile.Close(); err != nil {
+				return fmt.Errorf("failed to write CPU profile: %w", err)
+			}
+		}
+
+		if memPath != "" {
+			file, err := os.Create(memPath)
+			if err != nil {
+				return fmt.Errorf("failed to create memory profile: %w", err)
+			}
+			defer file.Close()
+
+			runtime.GC() // report up-to-date live allocations
+			if err := pprof.WriteHeapProfile(file); err != nil {
+				return fmt.Errorf("failed to write memory profile: %w", err)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// formatMegabytes formats a byte count in megabytes
+func formatMegabytes(bytes uint64) string {
+	return fmt.Sprintf("%.1fMB", float64(bytes)/(1<<20))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/query"
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query <report.json> <expression>",
+	Short: "Evaluate a jq-style expression against a JSON report",
+	Long: `Evaluate a jq-style expression against a JSON report written by analyze, for
+scripting in environments without jq. Each result is printed as JSON on its own line.
+
+Supported: paths (.a.b, .["key"], .[0], .[-1], .[]), pipes (|), select(...),
+comparisons (==, !=, <, <=, >, >=), and/or/not, length, keys and literals.
+
+Examples:
+  repo-onboarding-copilot query report.json '.component_scores.complexity'
+  repo-onboarding-copilot query report.json '.recommendations[] | select(.priority == "critical") | .title'
+  repo-onboarding-copilot query report.json '.recommendations | length'`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		raw, _ := cmd.Flags().GetBool("raw-output")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		q, err := query.Compile(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid expression: %w", err)
+		}
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read report: %w", err)
+		}
+		var report interface{}
+		if err := json.Unmarshal(data, &report); err != nil {
+			return fmt.Errorf("failed to parse report %s: %w", args[0], err)
+		}
+
+		results, err := q.Run(report)
+		if err != nil {
+			return err
+		}
+		return printQueryResults(cmd.OutOrStdout(), results, raw, compact)
+	},
+}
+
+// printQueryResults writes one JSON value per result; with raw, strings are written unquoted
+func printQueryResults(w io.Writer, results []interface{}, raw, compact bool) error {
+	for _, result := range results {
+		if s, ok := result.(string); ok && raw {
+			fmt.Fprintln(w, s)
+			continue
+		}
+
+		var data []byte
+		var err error
+		if compact {
+			data, err = json.Marshal(result)
+		} else {
+			data, err = json.MarshalIndent(result, "", "  ")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+	}
+	return nil
+}
+
+func init() {
+	queryCmd.Flags().BoolP("raw-output", "r", false, "Print string results without JSON quotes")
+	queryCmd.Flags().BoolP("compact", "c", false, "Print each result on a single line")
+
+	rootCmd.AddCommand(queryCmd)
+}
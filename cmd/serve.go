@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/api"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+)
+
+// serveAuthTokenEnv is the environment variable of --auth-token, keeping the token out of
+// process listings; it takes the place of a REPO_ONBOARDING_SERVE_AUTH_TOKEN variable
+const serveAuthTokenEnv = "COPILOT_AUTH_TOKEN"
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run an HTTP server for on-demand analysis",
+	Long: `Start an HTTP server that runs repository analysis on demand.
+
+Endpoints:
+  POST /analyze   {"repo_url": "...", "format": "json"} -> quality report JSON
+  GET  /healthz   liveness probe (no authentication)
+
+Requests to /analyze must carry the shared token in the ` + api.AuthHeader + ` header.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		token, _ := cmd.Flags().GetString("auth-token")
+		maxConcurrent, _ := cmd.Flags().GetInt("max-concurrent")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		maxRepoSize, err := parseMaxRepoSize(cmd)
+		if err != nil {
+			return err
+		}
+
+		if token == "" {
+			return fmt.Errorf("an auth token is required: pass --auth-token or set %s", serveAuthTokenEnv)
+		}
+
+		server, err := api.NewServer(api.ServerConfig{
+			Addr:           addr,
+			AuthToken:      token,
+			MaxConcurrent:  maxConcurrent,
+			RequestTimeout: timeout,
+			MaxRepoSize:    maxRepoSize,
+			ToolVersion:    Version,
+			BuildDate:      BuildDate,
+		}, logger.New())
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		return server.ListenAndServe(ctx)
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("addr", ":8080", "Address to listen on")
+	serveCmd.Flags().String("auth-token", "", "Shared token required in the "+api.AuthHeader+" header (default $"+serveAuthTokenEnv+")")
+	_ = serveCmd.Flags().SetAnnotation("auth-token", flagEnvAnnotation, []string{serveAuthTokenEnv})
+	serveCmd.Flags().Int("max-concurrent", 2, "Maximum number of analyses running at once")
+	serveCmd.Flags().Duration("timeout", 10*time.Minute, "Per-request analysis timeout")
+	serveCmd.Flags().String("max-repo-size", "2GB", "Abort clones whose working tree grows past this size (e.g. 500MB, 2GB)")
+
+	rootCmd.AddCommand(serveCmd)
+}
@@ -502,7 +502,7 @@ func (a *Analyzer) generateComponentMap() error {
 func (a *Analyzer) identifyComponent(filePath string) string {
 	// Simple component identification based on directory structure
 	relativePath, _ := filepath.Rel(a.config.ProjectRoot, filePath)
-	parts := strings.Split(relativePath, string(filepath.Separator))
+	parts := strings.Split(NormalizePath(relativePath), "/")
 
 	if len(parts) > 1 {
 		return parts[0] // Use top-level directory as component
@@ -2,7 +2,7 @@ package ast
 
 import (
 	"fmt"
-	"path/filepath"
+	"path"
 	"strings"
 )
 
@@ -99,7 +99,7 @@ func NewDependencyTracker() *DependencyTracker {
 // AddParseResult adds a parse result for dependency analysis
 func (dt *DependencyTracker) AddParseResult(filePath string, result *ParseResult) error {
 	// Normalize file path
-	normalizedPath := filepath.Clean(filePath)
+	normalizedPath := NormalizePath(filePath)
 	dt.fileResults[normalizedPath] = result
 
 	// Extract dependencies from imports
@@ -255,7 +255,7 @@ func (dt *DependencyTracker) BuildModuleGraph() (*ModuleGraph, error) {
 
 // GetDependencies returns dependencies for a specific file
 func (dt *DependencyTracker) GetDependencies(filePath string) ([]Dependency, bool) {
-	deps, exists := dt.dependencies[filepath.Clean(filePath)]
+	deps, exists := dt.dependencies[NormalizePath(filePath)]
 	return deps, exists
 }
 
@@ -303,21 +303,22 @@ func (dt *DependencyTracker) trackExternalPackage(imp ImportInfo, filePath strin
 }
 
 func (dt *DependencyTracker) resolveInternalDependency(targetModule, sourceFile, projectRoot string) (bool, string) {
-	basePath := filepath.Dir(sourceFile)
+	basePath := path.Dir(NormalizePath(sourceFile))
+	targetModule = ToSlash(targetModule)
 
 	// Try different resolution strategies
 	candidatePaths := []string{
-		filepath.Join(basePath, targetModule),
-		filepath.Join(basePath, targetModule+".js"),
-		filepath.Join(basePath, targetModule+".ts"),
-		filepath.Join(basePath, targetModule+".jsx"),
-		filepath.Join(basePath, targetModule+".tsx"),
-		filepath.Join(basePath, targetModule, "index.js"),
-		filepath.Join(basePath, targetModule, "index.ts"),
+		path.Join(basePath, targetModule),
+		path.Join(basePath, targetModule+".js"),
+		path.Join(basePath, targetModule+".ts"),
+		path.Join(basePath, targetModule+".jsx"),
+		path.Join(basePath, targetModule+".tsx"),
+		path.Join(basePath, targetModule, "index.js"),
+		path.Join(basePath, targetModule, "index.ts"),
 	}
 
 	for _, candidatePath := range candidatePaths {
-		normalizedPath := filepath.Clean(candidatePath)
+		normalizedPath := path.Clean(candidatePath)
 		if _, exists := dt.fileResults[normalizedPath]; exists {
 			return true, normalizedPath
 		}
@@ -401,7 +402,7 @@ func (dt *DependencyTracker) getNodeID(path string, isExternal bool) string {
 }
 
 func (dt *DependencyTracker) getModuleName(filePath string) string {
-	return filepath.Base(strings.TrimSuffix(filePath, filepath.Ext(filePath)))
+	return path.Base(strings.TrimSuffix(filePath, path.Ext(filePath)))
 }
 
 func (dt *DependencyTracker) getPackageName(source string) string {
@@ -126,6 +126,28 @@ func TestDependencyTracker_ResolveDependencies(t *testing.T) {
 	assert.Equal(t, "src/helper.js", helperDep.ResolvedPath)
 }
 
+func TestDependencyTracker_ResolveDependencies_BackslashPaths(t *testing.T) {
+	tracker := NewDependencyTracker()
+
+	tracker.AddParseResult(`src\lib\helper.js`, &ParseResult{FilePath: `src\lib\helper.js`, Language: "javascript"})
+	tracker.AddParseResult(`src\app\main.js`, &ParseResult{
+		FilePath: `src\app\main.js`,
+		Language: "javascript",
+		Imports: []ImportInfo{
+			{Source: `..\lib\helper`, ImportType: "default", LocalName: "helper", StartLine: 1},
+		},
+	})
+
+	err := tracker.ResolveDependencies(`C:\project`)
+	require.NoError(t, err)
+
+	deps, exists := tracker.GetDependencies("src/app/main.js")
+	require.True(t, exists)
+	require.Len(t, deps, 1)
+	assert.True(t, deps[0].IsResolved)
+	assert.Equal(t, "src/lib/helper.js", deps[0].ResolvedPath)
+}
+
 func TestDependencyTracker_BuildModuleGraph(t *testing.T) {
 	tracker := NewDependencyTracker()
 
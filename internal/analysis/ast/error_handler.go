@@ -18,6 +18,7 @@ type ErrorConfig struct {
 	ErrorThreshold     float64 `json:"error_threshold"`      // Error rate threshold (0.0-1.0)
 	EnableRecovery     bool    `json:"enable_recovery"`      // Enable error recovery attempts
 	EnablePartialParse bool    `json:"enable_partial_parse"` // Allow partial parsing results
+	EnableFallback     bool    `json:"enable_fallback"`      // Reparse files with syntax errors with the failing code blanked out (degraded parse)
 	LogLevel           string  `json:"log_level"`            // error, warn, info, debug
 }
 
@@ -2,6 +2,8 @@ package ast
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	sitter "github.com/smacker/go-tree-sitter"
 )
@@ -89,6 +91,21 @@ func (p *Parser) walkNode(node *sitter.Node, content []byte, result *ParseResult
 			})
 		}
 
+	case "comment":
+		result.Comments = append(result.Comments, CommentInfo{
+			Text:      p.getNodeText(node, content),
+			StartLine: int(node.StartPoint().Row) + 1,
+			EndLine:   int(node.EndPoint().Row) + 1,
+		})
+
+	case "call_expression":
+		if query, ok := p.extractDOMQuery(node, content); ok {
+			result.DOMQueries = append(result.DOMQueries, query)
+		}
+		if call, ok := p.extractCall(node, content); ok {
+			result.Calls = append(result.Calls, call)
+		}
+
 	case "export_statement":
 		if err := p.extractExport(node, content, result); err != nil {
 			result.Errors = append(result.Errors, ParseError{
@@ -143,6 +160,12 @@ func (p *Parser) extractFunction(node *sitter.Node, content []byte, result *Pars
 
 	// Check if exported
 	function.IsExported = p.isExported(node)
+	function.HasDocComment = p.hasLeadingComment(node)
+	function.Branches = p.extractBranches(node, content)
+	function.BranchChains = p.extractBranchChains(node, content)
+	function.CallbackDepth, function.DeepestCallbackLine = p.callbackDepth(node)
+	function.UnhandledAsync = p.unhandledAsync(node, content)
+	function.AsyncStyles = p.asyncStyles(node, content, function.IsAsync)
 
 	// Add metadata
 	function.Metadata["node_type"] = node.Type()
@@ -198,6 +221,7 @@ func (p *Parser) extractClass(node *sitter.Node, content []byte, result *ParseRe
 
 	// Check if exported
 	class.IsExported = p.isExported(node)
+	class.HasDocComment = p.hasLeadingComment(node)
 
 	class.Metadata["node_type"] = node.Type()
 
@@ -318,3 +342,687 @@ func (p *Parser) isExported(node *sitter.Node) bool {
 	}
 	return false
 }
+
+// maxConditionLength bounds the condition text kept on BranchInfo, in bytes
+const maxConditionLength = 80
+
+// shortenCondition cuts text to maxConditionLength bytes, ending it with "..." when it is
+// longer. The cut backs off to a rune boundary so non-ASCII source stays valid UTF-8.
+func shortenCondition(text string) string {
+	if len(text) <= maxConditionLength {
+		return text
+	}
+	cut := maxConditionLength - 3
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "..."
+}
+
+// functionNodeTypes are node types that start a new function scope
+var functionNodeTypes = map[string]bool{
+	"function_declaration":           true,
+	"function_expression":            true,
+	"function":                       true,
+	"arrow_function":                 true,
+	"method_definition":              true,
+	"generator_function_declaration": true,
+	"generator_function":             true,
+}
+
+// extractBranches collects the if statements, switch cases, ternaries and catch clauses in a
+// function body. Nested functions are skipped since they are reported as functions of their own.
+func (p *Parser) extractBranches(node *sitter.Node, content []byte) []BranchInfo {
+	branches := []BranchInfo{}
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			if functionNodeTypes[child.Type()] {
+				continue
+			}
+
+			var kind string
+			var condition *sitter.Node
+			var bodies []*sitter.Node
+			switch child.Type() {
+			case "if_statement":
+				kind, condition = "if", child.ChildByFieldName("condition")
+				bodies = append(bodies, child.ChildByFieldName("consequence"))
+				// An else-if is reported as its own branch; only a plain else belongs to this one
+				if alternative := child.ChildByFieldName("alternative"); alternative != nil && p.findChildByType(alternative, "if_statement") == nil {
+					bodies = append(bodies, alternative)
+				}
+			case "switch_case":
+				kind, condition = "case", child.ChildByFieldName("value")
+				bodies = append(bodies, child)
+			case "switch_default":
+				kind = "case"
+				bodies = append(bodies, child)
+			case "ternary_expression":
+				kind, condition = "ternary", child.ChildByFieldName("condition")
+				bodies = append(bodies, child.ChildByFieldName("consequence"), child.ChildByFieldName("alternative"))
+			case "catch_clause":
+				kind, condition = "catch", child.ChildByFieldName("parameter")
+				bodies = append(bodies, child.ChildByFieldName("body"))
+			}
+
+			if kind != "" {
+				text := "default"
+				if condition != nil {
+					text = strings.Join(strings.Fields(p.getNodeText(condition, content)), " ")
+				} else if kind == "catch" {
+					text = "catch"
+				}
+				text = shortenCondition(text)
+
+				branch := BranchInfo{
+					Kind:      kind,
+					Condition: text,
+					StartLine: int(child.StartPoint().Row) + 1,
+					EndLine:   int(child.EndPoint().Row) + 1,
+					IsGuard:   kind == "if" && p.isGuardClause(child),
+				}
+				for _, body := range bodies {
+					p.collectBranchFacts(body, content, &branch)
+				}
+				branches = append(branches, branch)
+			}
+
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return branches
+}
+
+// extractBranchChains collects the switch statements and if/else-if chains in a function
+// body. An else-if is counted in the chain it continues rather than as a chain of its own;
+// nested functions are skipped since they are reported as functions of their own.
+func (p *Parser) extractBranchChains(node *sitter.Node, content []byte) []BranchChainInfo {
+	var chains []BranchChainInfo
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			if functionNodeTypes[child.Type()] {
+				continue
+			}
+
+			switch child.Type() {
+			case "switch_statement":
+				chain := BranchChainInfo{Kind: "switch"}
+				if value := child.ChildByFieldName("value"); value != nil {
+					chain.Subject = p.chainSubjectText(unwrapParentheses(value), content)
+				}
+				if body := child.ChildByFieldName("body"); body != nil {
+					chain.Cases = len(p.findChildrenByType(body, "switch_case")) + len(p.findChildrenByType(body, "switch_default"))
+				}
+				chains = append(chains, p.spanChain(chain, child))
+			case "if_statement":
+				// A chain starts at an if that is not itself an else-if and has an else-if to follow
+				parent, alternative := child.Parent(), child.ChildByFieldName("alternative")
+				continued := parent != nil && parent.Type() == "else_clause"
+				if !continued && alternative != nil && p.findChildByType(alternative, "if_statement") != nil {
+					chains = append(chains, p.spanChain(p.ifElseChain(child, content), child))
+				}
+			}
+
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return chains
+}
+
+// ifElseChain counts the conditions of the if/else-if chain starting at node, plus its final
+// else, and finds the expression they all compare against
+func (p *Parser) ifElseChain(node *sitter.Node, content []byte) BranchChainInfo {
+	chain := BranchChainInfo{Kind: "if_else"}
+	sameSubject := true
+	for current := node; current != nil; {
+		chain.Cases++
+		subject := p.equalitySubject(current.ChildByFieldName("condition"), content)
+		if subject == "" || (chain.Subject != "" && subject != chain.Subject) {
+			sameSubject = false
+		}
+		chain.Subject = subject
+
+		alternative := current.ChildByFieldName("alternative")
+		if alternative == nil {
+			break
+		}
+		current = p.findChildByType(alternative, "if_statement")
+		if current == nil {
+			chain.Cases++ // a plain else
+		}
+	}
+	if !sameSubject {
+		chain.Subject = ""
+	}
+	return chain
+}
+
+// equalitySubject returns the left side of a condition of the form x === value or x == value,
+// "" for any other condition
+func (p *Parser) equalitySubject(condition *sitter.Node, content []byte) string {
+	if condition == nil {
+		return ""
+	}
+	condition = unwrapParentheses(condition)
+	if condition.Type() != "binary_expression" {
+		return ""
+	}
+	operator := condition.ChildByFieldName("operator")
+	left := condition.ChildByFieldName("left")
+	if operator == nil || left == nil {
+		return ""
+	}
+	if op := operator.Type(); op != "===" && op != "==" {
+		return ""
+	}
+	return p.chainSubjectText(left, content)
+}
+
+// chainSubjectText returns the source text of a chain subject on one line, shortened to
+// maxConditionLength
+func (p *Parser) chainSubjectText(node *sitter.Node, content []byte) string {
+	return shortenCondition(strings.Join(strings.Fields(p.getNodeText(node, content)), " "))
+}
+
+// spanChain sets the lines of chain to those of node
+func (p *Parser) spanChain(chain BranchChainInfo, node *sitter.Node) BranchChainInfo {
+	chain.StartLine = int(node.StartPoint().Row) + 1
+	chain.EndLine = int(node.EndPoint().Row) + 1
+	return chain
+}
+
+// unwrapParentheses returns the expression inside any parentheses around node
+func unwrapParentheses(node *sitter.Node) *sitter.Node {
+	for node.Type() == "parenthesized_expression" && node.NamedChildCount() == 1 {
+		node = node.NamedChild(0)
+	}
+	return node
+}
+
+// callbackDepth returns how deeply functions nest inside node, e.g. 2 for a callback
+// passed inside another callback, and the start line of the innermost one (0 if none)
+func (p *Parser) callbackDepth(node *sitter.Node) (int, int) {
+	maxDepth, deepestLine := 0, 0
+
+	var walk func(n *sitter.Node, depth int)
+	walk = func(n *sitter.Node, depth int) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			childDepth := depth
+			if functionNodeTypes[child.Type()] {
+				childDepth++
+				if childDepth > maxDepth {
+					maxDepth, deepestLine = childDepth, int(child.StartPoint().Row)+1
+				}
+			}
+			walk(child, childDepth)
+		}
+	}
+	walk(node, 0)
+
+	return maxDepth, deepestLine
+}
+
+// unhandledAsync finds the awaits and promise chains in a function body whose rejection
+// nothing handles. An await is handled inside the body of a try statement that has a catch
+// clause, or when the awaited promise ends in .catch() or .then(onFulfilled, onRejected).
+// A .then() chain is only reported as a statement of its own: a chain that is returned,
+// awaited or stored is left to whoever receives the promise. Nested functions are skipped
+// since they are reported as functions of their own.
+func (p *Parser) unhandledAsync(node *sitter.Node, content []byte) []AsyncCallInfo {
+	var calls []AsyncCallInfo
+
+	var walk func(n *sitter.Node, guarded bool)
+	walk = func(n *sitter.Node, guarded bool) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			if functionNodeTypes[child.Type()] {
+				continue
+			}
+
+			switch child.Type() {
+			case "try_statement":
+				body, handler := child.ChildByFieldName("body"), child.ChildByFieldName("handler")
+				if body != nil && handler != nil {
+					// Only the try block is protected; catch and finally blocks are not
+					walk(body, true)
+					walk(handler, guarded)
+					if finalizer := child.ChildByFieldName("finalizer"); finalizer != nil {
+						walk(finalizer, guarded)
+					}
+					continue
+				}
+			case "await_expression":
+				awaited := child.NamedChild(0)
+				for awaited != nil && awaited.Type() == "parenthesized_expression" {
+					awaited = awaited.NamedChild(0)
+				}
+				if !guarded && !p.handlesRejection(awaited, content) {
+					calls = append(calls, AsyncCallInfo{
+						Kind:   "await",
+						Callee: p.asyncCallee(awaited, content),
+						Line:   int(child.StartPoint().Row) + 1,
+					})
+				}
+			case "expression_statement":
+				if expression := child.NamedChild(0); expression != nil && p.isPromiseChain(expression, content) && !p.handlesRejection(expression, content) {
+					calls = append(calls, AsyncCallInfo{
+						Kind:   "then",
+						Callee: p.asyncCallee(expression, content),
+						Line:   int(child.StartPoint().Row) + 1,
+					})
+				}
+			}
+
+			walk(child, guarded)
+		}
+	}
+	walk(node, false)
+
+	return calls
+}
+
+// promiseMethod returns the method name of a call such as p.then(...), "" for other nodes
+func (p *Parser) promiseMethod(call *sitter.Node, content []byte) string {
+	if call == nil || call.Type() != "call_expression" {
+		return ""
+	}
+	callee := call.ChildByFieldName("function")
+	if callee == nil || callee.Type() != "member_expression" {
+		return ""
+	}
+	if property := callee.ChildByFieldName("property"); property != nil {
+		return p.getNodeText(property, content)
+	}
+	return ""
+}
+
+// isPromiseChain reports whether expression is a call chain containing .then()
+func (p *Parser) isPromiseChain(expression *sitter.Node, content []byte) bool {
+	for call := expression; call != nil && call.Type() == "call_expression"; call = call.ChildByFieldName("function").ChildByFieldName("object") {
+		switch p.promiseMethod(call, content) {
+		case "then":
+			return true
+		case "":
+			return false
+		}
+	}
+	return false
+}
+
+// handlesRejection reports whether a call chain ends in .catch() or a two-argument .then()
+// somewhere along it
+func (p *Parser) handlesRejection(expression *sitter.Node, content []byte) bool {
+	for call := expression; call != nil && call.Type() == "call_expression"; call = call.ChildByFieldName("function").ChildByFieldName("object") {
+		switch p.promiseMethod(call, content) {
+		case "catch":
+			return true
+		case "then":
+			if args := call.ChildByFieldName("arguments"); args != nil && args.NamedChildCount() >= 2 {
+				return true
+			}
+		case "":
+			return false
+		}
+	}
+	return false
+}
+
+// asyncCallee returns the call target at the root of a promise chain, e.g. "fetch" for
+// fetch(url).then(parse), or an awaited identifier; "" for other expressions
+func (p *Parser) asyncCallee(expression *sitter.Node, content []byte) string {
+	if expression == nil {
+		return ""
+	}
+	if expression.Type() == "identifier" {
+		return p.getNodeText(expression, content)
+	}
+
+	target := ""
+	for call := expression; call != nil && call.Type() == "call_expression"; {
+		callee := call.ChildByFieldName("function")
+		if callee == nil {
+			break
+		}
+		target = strings.Join(strings.Fields(p.getNodeText(callee, content)), " ")
+		method := p.promiseMethod(call, content)
+		if method != "then" && method != "catch" && method != "finally" {
+			break
+		}
+		call = callee.ChildByFieldName("object")
+	}
+	return shortenCondition(target)
+}
+
+// errorFirstParameters and callbackParameters are the parameter names that mark the
+// callback style: a callback taking an error first, or a function handed its callback last
+var (
+	errorFirstParameters = map[string]bool{"err": true, "error": true, "er": true}
+	callbackParameters   = map[string]bool{"callback": true, "cb": true, "done": true}
+)
+
+// asyncStyles classifies the asynchronous styles used in a function body: async/await,
+// promise chains and construction, and callbacks, either passed error-first as the last
+// argument of a call outside a promise chain or taken as a trailing callback parameter. The executor of a new Promise is the adapter between styles
+// and is left unclassified. Nested functions are skipped since they are classified as
+// functions of their own.
+func (p *Parser) asyncStyles(node *sitter.Node, content []byte, isAsync bool) []string {
+	if p.isPromiseExecutor(node, content) {
+		return nil
+	}
+
+	used := map[string]bool{AsyncStyleAwait: isAsync}
+	if names := p.parameterNames(node, content); len(names) > 0 && callbackParameters[names[len(names)-1]] {
+		used[AsyncStyleCallback] = true
+	}
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			if functionNodeTypes[child.Type()] {
+				continue
+			}
+
+			switch child.Type() {
+			case "await_expression":
+				used[AsyncStyleAwait] = true
+			case "new_expression":
+				if constructor := child.ChildByFieldName("constructor"); constructor != nil && p.getNodeText(constructor, content) == "Promise" {
+					used[AsyncStylePromise] = true
+				}
+			case "call_expression":
+				promiseHandler := false
+				switch p.promiseMethod(child, content) {
+				case "then", "catch", "finally":
+					used[AsyncStylePromise] = true
+					promiseHandler = true
+				}
+				if callee := child.ChildByFieldName("function"); callee != nil && callee.Type() == "member_expression" {
+					if object := callee.ChildByFieldName("object"); object != nil && p.getNodeText(object, content) == "Promise" {
+						used[AsyncStylePromise] = true
+					}
+				}
+				// Only the last argument of a call outside a promise chain is a callback;
+				// .catch(err => ...) takes an error first without being one
+				if args := child.ChildByFieldName("arguments"); args != nil && !promiseHandler && args.NamedChildCount() > 0 {
+					last := args.NamedChild(int(args.NamedChildCount()) - 1)
+					if functionNodeTypes[last.Type()] {
+						if names := p.parameterNames(last, content); len(names) > 0 && errorFirstParameters[names[0]] {
+							used[AsyncStyleCallback] = true
+						}
+					}
+				}
+			}
+
+			walk(child)
+		}
+	}
+	walk(node)
+
+	var styles []string
+	for _, style := range []string{AsyncStyleAwait, AsyncStylePromise, AsyncStyleCallback} {
+		if used[style] {
+			styles = append(styles, style)
+		}
+	}
+	return styles
+}
+
+// isPromiseExecutor reports whether node is the function passed to new Promise(...)
+func (p *Parser) isPromiseExecutor(node *sitter.Node, content []byte) bool {
+	args := node.Parent()
+	if args == nil || args.Type() != "arguments" || args.Parent() == nil || args.Parent().Type() != "new_expression" {
+		return false
+	}
+	constructor := args.Parent().ChildByFieldName("constructor")
+	return constructor != nil && p.getNodeText(constructor, content) == "Promise"
+}
+
+// parameterNames returns the parameter names of a function node, including the bare
+// parameter of an arrow function such as err => ...
+func (p *Parser) parameterNames(node *sitter.Node, content []byte) []string {
+	if parameter := node.ChildByFieldName("parameter"); parameter != nil {
+		return []string{p.getNodeText(parameter, content)}
+	}
+	paramsNode := p.findChildByType(node, "formal_parameters")
+	if paramsNode == nil {
+		return nil
+	}
+	var names []string
+	for _, parameter := range p.extractParameters(paramsNode, content) {
+		names = append(names, parameter.Name)
+	}
+	return names
+}
+
+// collectBranchFacts records error handling, awaits and call targets found under node,
+// without descending into nested functions
+func (p *Parser) collectBranchFacts(node *sitter.Node, content []byte, branch *BranchInfo) {
+	if node == nil || functionNodeTypes[node.Type()] {
+		return
+	}
+
+	switch node.Type() {
+	case "throw_statement", "try_statement":
+		branch.HasErrorHandling = true
+	case "await_expression":
+		branch.HasAwait = true
+	case "call_expression":
+		callee := node.ChildByFieldName("function")
+		if callee != nil && callee.Type() == "member_expression" {
+			if property := callee.ChildByFieldName("property"); property != nil && p.getNodeText(property, content) == "catch" {
+				branch.HasErrorHandling = true
+			}
+		}
+		if root := p.calleeRoot(callee, content); root != "" {
+			if root == "reject" {
+				branch.HasErrorHandling = true
+			}
+			known := false
+			for _, existing := range branch.Callees {
+				known = known || existing == root
+			}
+			if !known {
+				branch.Callees = append(branch.Callees, root)
+			}
+		}
+	}
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		p.collectBranchFacts(node.NamedChild(i), content, branch)
+	}
+}
+
+// calleeRoot returns the leftmost identifier of a call target: "axios" for axios.get(...),
+// "db" for this.db.query(...)
+func (p *Parser) calleeRoot(callee *sitter.Node, content []byte) string {
+	for callee != nil {
+		switch callee.Type() {
+		case "identifier":
+			return p.getNodeText(callee, content)
+		case "member_expression":
+			object := callee.ChildByFieldName("object")
+			if object != nil && object.Type() == "this" {
+				if property := callee.ChildByFieldName("property"); property != nil {
+					return p.getNodeText(property, content)
+				}
+				return ""
+			}
+			callee = object
+		case "call_expression":
+			callee = callee.ChildByFieldName("function")
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// domQueryMethods are the DOM lookup methods recorded in ParseResult.DOMQueries
+var domQueryMethods = map[string]bool{
+	"querySelector":          true,
+	"querySelectorAll":       true,
+	"getElementById":         true,
+	"getElementsByClassName": true,
+	"getElementsByTagName":   true,
+	"getElementsByName":      true,
+}
+
+// extractDOMQuery recognizes calls like document.querySelector(".item") or el.getElementById(id)
+func (p *Parser) extractDOMQuery(node *sitter.Node, content []byte) (DOMQueryInfo, bool) {
+	callee := node.ChildByFieldName("function")
+	if callee == nil || callee.Type() != "member_expression" {
+		return DOMQueryInfo{}, false
+	}
+	property := callee.ChildByFieldName("property")
+	if property == nil || !domQueryMethods[p.getNodeText(property, content)] {
+		return DOMQueryInfo{}, false
+	}
+
+	query := DOMQueryInfo{
+		Method:   p.getNodeText(property, content),
+		Receiver: p.calleeRoot(callee.ChildByFieldName("object"), content),
+		Line:     int(node.StartPoint().Row) + 1,
+	}
+	if args := node.ChildByFieldName("arguments"); args != nil && args.NamedChildCount() > 0 {
+		argument := args.NamedChild(0)
+		query.Selector = p.getNodeText(argument, content)
+		if argument.Type() == "string" || argument.Type() == "template_string" && argument.NamedChildCount() == 0 {
+			query.Selector = strings.Trim(query.Selector, "'\"`")
+		}
+	}
+
+	return query, true
+}
+
+// extractCall records the name called by a call expression: "helper" for helper(x),
+// "save" for this.repo.save(x). Calls of computed targets such as IIFEs are skipped.
+func (p *Parser) extractCall(node *sitter.Node, content []byte) (CallInfo, bool) {
+	callee := node.ChildByFieldName("function")
+	if callee == nil {
+		return CallInfo{}, false
+	}
+
+	call := CallInfo{Line: int(node.StartPoint().Row) + 1}
+	switch callee.Type() {
+	case "identifier":
+		call.Callee = p.getNodeText(callee, content)
+	case "member_expression":
+		property := callee.ChildByFieldName("property")
+		if property == nil {
+			return CallInfo{}, false
+		}
+		call.Callee = p.getNodeText(property, content)
+		call.Receiver = p.calleeRoot(callee.ChildByFieldName("object"), content)
+	default:
+		return CallInfo{}, false
+	}
+	call.InLoop = p.inLoop(node, content)
+
+	return call, call.Callee != ""
+}
+
+// loopNodeTypes are statements that run their body once per iteration
+var loopNodeTypes = map[string]bool{
+	"for_statement":    true,
+	"for_in_statement": true, // also for...of
+	"while_statement":  true,
+	"do_statement":     true,
+}
+
+// iterationMethods are array methods that call their callback once per element
+var iterationMethods = map[string]bool{
+	"forEach": true, "map": true, "flatMap": true, "filter": true, "reduce": true,
+	"some": true, "every": true, "find": true, "findIndex": true,
+}
+
+// inLoop reports whether node runs once per iteration of a loop of its enclosing function:
+// inside a loop statement's body, or inside a callback passed to an array iteration method.
+// A loop's header (its condition, update or iterable) and loops around the enclosing
+// function's own definition do not count.
+func (p *Parser) inLoop(node *sitter.Node, content []byte) bool {
+	for child, parent := node, node.Parent(); parent != nil; child, parent = parent, parent.Parent() {
+		switch {
+		case loopNodeTypes[parent.Type()]:
+			if body := parent.ChildByFieldName("body"); body != nil && body.Equal(child) {
+				return true
+			}
+		case functionNodeTypes[parent.Type()]:
+			return p.isIterationCallback(parent, content)
+		}
+	}
+	return false
+}
+
+// isIterationCallback reports whether a function node is passed straight to an array
+// iteration method, as in items.forEach(item => ...)
+func (p *Parser) isIterationCallback(function *sitter.Node, content []byte) bool {
+	arguments := function.Parent()
+	if arguments == nil || arguments.Type() != "arguments" {
+		return false
+	}
+	call := arguments.Parent()
+	if call == nil || call.Type() != "call_expression" {
+		return false
+	}
+	callee := call.ChildByFieldName("function")
+	if callee == nil || callee.Type() != "member_expression" {
+		return false
+	}
+	property := callee.ChildByFieldName("property")
+	return property != nil && iterationMethods[p.getNodeText(property, content)]
+}
+
+// isGuardClause reports whether an if statement has no else and its body is a single
+// return, throw, break or continue
+func (p *Parser) isGuardClause(node *sitter.Node) bool {
+	if node.ChildByFieldName("alternative") != nil {
+		return false
+	}
+
+	body := node.ChildByFieldName("consequence")
+	if body != nil && body.Type() == "statement_block" {
+		if body.NamedChildCount() != 1 {
+			return false
+		}
+		body = body.NamedChild(0)
+	}
+	if body == nil {
+		return false
+	}
+
+	switch body.Type() {
+	case "return_statement", "throw_statement", "break_statement", "continue_statement":
+		return true
+	}
+	return false
+}
+
+// hasLeadingComment reports whether a comment ends on the line directly above the
+// declaration (or above its export statement). Decorators in between are skipped.
+func (p *Parser) hasLeadingComment(node *sitter.Node) bool {
+	target := node
+	if parent := node.Parent(); parent != nil && (parent.Type() == "export_statement" || parent.Type() == "export_declaration") {
+		target = parent
+	}
+
+	startRow := target.StartPoint().Row
+	prev := target.PrevSibling()
+	for prev != nil && prev.Type() == "decorator" {
+		startRow = prev.StartPoint().Row
+		prev = prev.PrevSibling()
+	}
+
+	return prev != nil && prev.Type() == "comment" && prev.EndPoint().Row+1 >= startRow
+}
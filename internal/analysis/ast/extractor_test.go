@@ -2,7 +2,9 @@ package ast
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -136,6 +138,59 @@ export default main;
 	assert.Equal(t, "main", defaultExport.Name)
 }
 
+func TestExtractExport_DefaultKind(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	cases := map[string]struct {
+		code    string
+		kind    string
+		members []string
+	}{
+		"object":     {code: "const a = 1;\nexport default { a, 'b': 2, c() {}, ...rest };", kind: "object", members: []string{"a", "b", "c"}},
+		"class":      {code: "export default class Api {}", kind: "class"},
+		"function":   {code: "export default function render() {}", kind: "function"},
+		"arrow":      {code: "export default () => 1;", kind: "function"},
+		"identifier": {code: "const main = 1;\nexport default main;", kind: "identifier"},
+		"expression": {code: "export default createStore(reducer);", kind: "expression"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			result, err := parser.ParseFile(context.Background(), "test.js", []byte(tc.code))
+			require.NoError(t, err)
+			require.Len(t, result.Exports, 1)
+			assert.Equal(t, "default", result.Exports[0].ExportType)
+			assert.Equal(t, tc.kind, result.Exports[0].DefaultKind)
+			if tc.members != nil {
+				assert.Equal(t, tc.members, result.Exports[0].Specifiers)
+			}
+		})
+	}
+}
+
+func TestExtractExport_NamedDeclaration(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `
+export function format() {}
+export const a = 1, b = 2;
+`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	require.Len(t, result.Exports, 2)
+	assert.Equal(t, "named", result.Exports[0].ExportType)
+	assert.Equal(t, []string{"format"}, result.Exports[0].Specifiers)
+	assert.Equal(t, "named", result.Exports[1].ExportType)
+	assert.Equal(t, []string{"a", "b"}, result.Exports[1].Specifiers)
+	assert.Empty(t, result.Exports[1].DefaultKind)
+}
+
 func TestExtractFunction_WithParameters(t *testing.T) {
 	parser, err := NewParser()
 	require.NoError(t, err)
@@ -295,6 +350,7 @@ func TestIsExternalImport(t *testing.T) {
 		{"../config", false},
 		{"/absolute/path", false},
 		{"./utils.js", false},
+		{`..\lib\db`, false},   // Windows-style relative path
 		{"utils/helper", true}, // Package-like path
 	}
 
@@ -332,3 +388,518 @@ function test() {
 	assert.True(t, ok)
 	assert.GreaterOrEqual(t, maxDepth, 0)
 }
+
+func TestExtractDeclarations_DocComments(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `/**
+ * Loads a user.
+ */
+export function load(id) { return id; }
+
+export function bare() { return 1; }
+
+// Stale note
+
+export function separated() { return 2; }
+
+// Stores records
+export class Store {
+  /** Saves a record. */
+  save(record) { return record; }
+
+  clear() { return null; }
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	documented := make(map[string]bool)
+	for _, function := range result.Functions {
+		documented[function.Name] = function.HasDocComment
+	}
+	assert.True(t, documented["load"])
+	assert.False(t, documented["bare"])
+	assert.False(t, documented["separated"])
+
+	require.Len(t, result.Classes, 1)
+	class := result.Classes[0]
+	assert.True(t, class.HasDocComment)
+
+	methods := make(map[string]bool)
+	for _, method := range class.Methods {
+		methods[method.Name] = method.HasDocComment
+	}
+	assert.True(t, methods["save"])
+	assert.False(t, methods["clear"])
+}
+
+func TestExtractComments(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `// first
+// second
+function run() {
+  /* inline
+     block */
+  return 1;
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	require.Len(t, result.Comments, 3)
+	assert.Equal(t, "// first", result.Comments[0].Text)
+	assert.Equal(t, 1, result.Comments[0].StartLine)
+	assert.Equal(t, 2, result.Comments[1].StartLine)
+	assert.Equal(t, 4, result.Comments[2].StartLine)
+	assert.Equal(t, 5, result.Comments[2].EndLine)
+}
+
+func TestExtractDOMQueries(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `function init(id) {
+  const nav = document.querySelector('#nav');
+  document.getElementById(id).addEventListener('click', () => {
+    this.root.querySelectorAll(".item");
+  });
+  db.query('SELECT 1');
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	require.Len(t, result.DOMQueries, 3)
+	assert.Equal(t, DOMQueryInfo{Method: "querySelector", Receiver: "document", Selector: "#nav", Line: 2}, result.DOMQueries[0])
+	assert.Equal(t, DOMQueryInfo{Method: "getElementById", Receiver: "document", Selector: "id", Line: 3}, result.DOMQueries[1])
+	assert.Equal(t, DOMQueryInfo{Method: "querySelectorAll", Receiver: "root", Selector: ".item", Line: 4}, result.DOMQueries[2])
+}
+
+func TestExtractCalls(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `function save(user) {
+  validate(user);
+  return this.repo.persist(normalize(user));
+}
+(function () {})();`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	assert.Equal(t, []CallInfo{
+		{Callee: "validate", Line: 2},
+		{Callee: "persist", Receiver: "repo", Line: 3},
+		{Callee: "normalize", Line: 3},
+	}, result.Calls)
+}
+
+func TestExtractCalls_InLoop(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `function sync(ids) {
+  for (const id of ids) {
+    load(id);
+  }
+  ids.forEach(id => remove(id));
+  while (pending()) { flush(); }
+  const handler = () => notify();
+  return done();
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	inLoop := make(map[string]bool)
+	for _, call := range result.Calls {
+		inLoop[call.Callee] = call.InLoop
+	}
+	assert.Equal(t, map[string]bool{
+		"load":    true,
+		"forEach": false,
+		"remove":  true,  // iteration callback
+		"pending": false, // loop condition
+		"flush":   true,
+		"notify":  false, // a callback that is not an iteration
+		"done":    false,
+	}, inLoop)
+}
+
+func TestExtractCalls_LoopHeaderIsNotInLoop(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `function sync(items) {
+  for (let i = start(); i < items.count(); i = next(i)) {
+    load(items[i]);
+  }
+  for (const item of pending()) {
+    save(item);
+  }
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	inLoop := make(map[string]bool)
+	for _, call := range result.Calls {
+		inLoop[call.Callee] = call.InLoop
+	}
+	assert.Equal(t, map[string]bool{
+		"start":   false,
+		"count":   false,
+		"next":    false,
+		"load":    true,
+		"pending": false, // for...of iterable
+		"save":    true,
+	}, inLoop)
+}
+
+func TestExtractBranches(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `function route(req) {
+  if (req.method === 'GET') {
+    return handle(req);
+  } else if (req.method === 'POST') {
+    return save(req);
+  }
+  switch (req.kind) {
+    case 'a':
+      return 1;
+    default:
+      return req.ok ? 2 : 3;
+  }
+  const inner = () => { if (x) { return 4; } };
+}
+
+function plain() { return 1; }`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	route := findFunctionByName(result.Functions, "route")
+	require.NotNil(t, route)
+
+	kinds := make([]string, 0, len(route.Branches))
+	for _, branch := range route.Branches {
+		kinds = append(kinds, branch.Kind)
+	}
+	assert.Equal(t, []string{"if", "if", "case", "case", "ternary"}, kinds)
+
+	assert.Equal(t, "(req.method === 'GET')", route.Branches[0].Condition)
+	assert.Equal(t, 2, route.Branches[0].StartLine)
+	assert.Equal(t, 6, route.Branches[0].EndLine)
+	assert.Equal(t, []string{"handle"}, route.Branches[0].Callees, "the else-if body belongs to its own branch")
+	assert.Equal(t, 4, route.Branches[1].StartLine)
+	assert.Equal(t, "'a'", route.Branches[2].Condition)
+	assert.Equal(t, "default", route.Branches[3].Condition)
+	assert.Equal(t, "req.ok", route.Branches[4].Condition)
+	assert.Equal(t, 11, route.Branches[4].StartLine)
+
+	plain := findFunctionByName(result.Functions, "plain")
+	require.NotNil(t, plain)
+	assert.Empty(t, plain.Branches)
+}
+
+func TestExtractBranches_NonASCIIConditionStaysValidUTF8(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	label := strings.Repeat("界", 30) // 3 bytes each, so the byte limit falls inside a character
+	code := "function greet(names) {\n  if (names === '" + label + "') {\n    return 1;\n  }\n}\n"
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	greet := findFunctionByName(result.Functions, "greet")
+	require.NotNil(t, greet)
+	require.Len(t, greet.Branches, 1)
+	condition := greet.Branches[0].Condition
+	assert.True(t, utf8.ValidString(condition), "cut inside a character: %q", condition)
+	assert.True(t, strings.HasSuffix(condition, "界..."))
+	assert.LessOrEqual(t, len(condition), maxConditionLength)
+}
+
+func TestExtractBranches_Semantics(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `async function sync(id) {
+  if (!id) return null;
+  if (id.length > 10) {
+    const user = await this.db.find(id);
+    if (!user) {
+      throw new Error('missing');
+    }
+  }
+  try {
+    await fetch('/sync').then(r => r.json()).catch(log);
+  } catch (err) {
+    reject(err);
+  }
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	sync := findFunctionByName(result.Functions, "sync")
+	require.NotNil(t, sync)
+	require.Len(t, sync.Branches, 4)
+
+	guard := sync.Branches[0]
+	assert.True(t, guard.IsGuard)
+	assert.False(t, guard.HasErrorHandling)
+
+	lookup := sync.Branches[1]
+	assert.False(t, lookup.IsGuard)
+	assert.True(t, lookup.HasAwait)
+	assert.True(t, lookup.HasErrorHandling, "nested throw counts as error handling")
+	assert.Equal(t, []string{"db"}, lookup.Callees, "constructors are not calls")
+
+	missing := sync.Branches[2]
+	assert.True(t, missing.IsGuard)
+	assert.True(t, missing.HasErrorHandling)
+
+	catch := sync.Branches[3]
+	assert.Equal(t, "catch", catch.Kind)
+	assert.Equal(t, "err", catch.Condition)
+	assert.True(t, catch.HasErrorHandling, "reject() counts as error handling")
+	assert.Equal(t, []string{"reject"}, catch.Callees)
+}
+
+func TestExtractBranchChains(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `function reduce(state, action) {
+  switch (action.type) {
+    case 'add':
+      return add(state);
+    case 'remove':
+      return remove(state);
+    default:
+      return state;
+  }
+}
+
+function label(status) {
+  if (status === 'new') {
+    return 'New';
+  } else if (status == 'open') {
+    return 'Open';
+  } else if ((status === 'done')) {
+    return 'Done';
+  } else {
+    return '?';
+  }
+}
+
+function mixed(a, b) {
+  if (a > 1) {
+    return 1;
+  } else if (b === 2) {
+    return 2;
+  }
+  if (a) {
+    return 3;
+  } else {
+    return 4;
+  }
+  const inner = () => { switch (a) { case 1: return 5; } };
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	reduce := findFunctionByName(result.Functions, "reduce")
+	require.NotNil(t, reduce)
+	assert.Equal(t, []BranchChainInfo{{Kind: "switch", Subject: "action.type", Cases: 3, StartLine: 2, EndLine: 9}}, reduce.BranchChains)
+
+	label := findFunctionByName(result.Functions, "label")
+	require.NotNil(t, label)
+	assert.Equal(t, []BranchChainInfo{{Kind: "if_else", Subject: "status", Cases: 4, StartLine: 13, EndLine: 21}}, label.BranchChains,
+		"the else-ifs and the final else count in the chain they continue")
+
+	mixed := findFunctionByName(result.Functions, "mixed")
+	require.NotNil(t, mixed)
+	assert.Equal(t, []BranchChainInfo{{Kind: "if_else", Cases: 2, StartLine: 25, EndLine: 29}}, mixed.BranchChains,
+		"unrelated conditions have no subject; an if/else is no chain and nested functions are their own")
+}
+
+func TestExtractFunction_CallbackDepth(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `function load(id, done) {
+  db.get(id, function (err, user) {
+    fs.readFile(user.path, (err, data) => {
+      parse(data, (err, doc) => {
+        done(doc);
+      });
+    });
+  });
+}
+
+function flat() {
+  return [1, 2].map(x => x * 2);
+}
+
+function plain() { return 1; }`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	load := findFunctionByName(result.Functions, "load")
+	require.NotNil(t, load)
+	assert.Equal(t, 3, load.CallbackDepth)
+	assert.Equal(t, 4, load.DeepestCallbackLine)
+
+	flat := findFunctionByName(result.Functions, "flat")
+	require.NotNil(t, flat)
+	assert.Equal(t, 1, flat.CallbackDepth)
+	assert.Equal(t, 12, flat.DeepestCallbackLine)
+
+	plain := findFunctionByName(result.Functions, "plain")
+	require.NotNil(t, plain)
+	assert.Equal(t, 0, plain.CallbackDepth)
+	assert.Equal(t, 0, plain.DeepestCallbackLine)
+}
+
+func TestExtractFunction_UnhandledAsync(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `async function load(id) {
+  const user = await db.get(id);
+  try {
+    await audit.log(user);
+  } catch (err) {
+    await alerts.send(err);
+  }
+  await cache.set(id).catch(ignore);
+  fetch(url).then(parse).then(render);
+  fetch(url).then(parse).catch(report);
+  fetch(url).then(parse, report);
+  const later = fetch(url).then(parse);
+  items.forEach(async (item) => {
+    await save(item);
+  });
+  return fetch(url).then(parse);
+}
+
+async function guarded() {
+  try {
+    await step();
+  } finally {
+    cleanup();
+  }
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	load := findFunctionByName(result.Functions, "load")
+	require.NotNil(t, load)
+	assert.Equal(t, []AsyncCallInfo{
+		{Kind: "await", Callee: "db.get", Line: 2},
+		{Kind: "await", Callee: "alerts.send", Line: 6},
+		{Kind: "then", Callee: "fetch", Line: 9},
+	}, load.UnhandledAsync, "the nested callback's await belongs to the callback")
+
+	guarded := findFunctionByName(result.Functions, "guarded")
+	require.NotNil(t, guarded)
+	assert.Equal(t, []AsyncCallInfo{{Kind: "await", Callee: "step", Line: 21}}, guarded.UnhandledAsync,
+		"try without catch does not handle the rejection")
+}
+
+func TestExtractFunction_AsyncStyles(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	code := `async function loadUser(id) {
+  return await db.get(id);
+}
+
+function loadAll(ids) {
+  return Promise.all(ids.map(loadUser)).then(render);
+}
+
+function readConfig(path, callback) {
+  fs.readFile(path, (err, data) => callback(err, data && parse(data)));
+}
+
+function readLegacy(path) {
+  fs.stat(path, err => log(err));
+}
+
+function readAsync(path) {
+  return new Promise((resolve, reject) => {
+    fs.readFile(path, (err, data) => (err ? reject(err) : resolve(data)));
+  });
+}
+
+async function refresh() {
+  await sync();
+  return fetch(url).then(parse);
+}
+
+function plain(items) {
+  return items.map((item) => item.id);
+}
+
+function fetchData() {
+  return api.get('/x').then(r => r.data).catch(err => { report(err); });
+}
+
+function notLast(list) {
+  list.sort((error, other) => error - other, true);
+}`
+
+	result, err := parser.ParseFile(context.Background(), "test.js", []byte(code))
+	require.NoError(t, err)
+
+	styles := func(name string) []string {
+		function := findFunctionByName(result.Functions, name)
+		require.NotNil(t, function, name)
+		return function.AsyncStyles
+	}
+	assert.Equal(t, []string{AsyncStyleAwait}, styles("loadUser"))
+	assert.Equal(t, []string{AsyncStylePromise}, styles("loadAll"))
+	assert.Equal(t, []string{AsyncStyleCallback}, styles("readConfig"), "a trailing callback parameter")
+	assert.Equal(t, []string{AsyncStyleCallback}, styles("readLegacy"), "an error-first arrow callback without parentheses")
+	assert.Equal(t, []string{AsyncStylePromise}, styles("readAsync"))
+	assert.Equal(t, []string{AsyncStyleAwait, AsyncStylePromise}, styles("refresh"))
+	assert.Empty(t, styles("plain"), "array callbacks are not asynchronous")
+	assert.Equal(t, []string{AsyncStylePromise}, styles("fetchData"), "a .catch handler is not an error-first callback")
+	assert.Empty(t, styles("notLast"), "only a call's last argument is its callback")
+
+	var executor *FunctionInfo
+	for i, function := range result.Functions {
+		if function.Name == "" && function.StartLine == 18 {
+			executor = &result.Functions[i]
+		}
+	}
+	require.NotNil(t, executor)
+	assert.Empty(t, executor.AsyncStyles, "the promise executor adapts between styles")
+}
@@ -48,6 +48,13 @@ func (p *Parser) extractExport(node *sitter.Node, content []byte, result *ParseR
 		if identifier := p.findChildByType(node, "identifier"); identifier != nil {
 			exportInfo.Name = p.getNodeText(identifier, content)
 		}
+		p.classifyDefaultExport(node, content, &exportInfo)
+	} else if declaration := node.ChildByFieldName("declaration"); declaration != nil {
+		exportInfo.ExportType = "named"
+		exportInfo.Specifiers = p.declaredNames(declaration, content)
+		if len(exportInfo.Specifiers) > 0 {
+			exportInfo.Name = exportInfo.Specifiers[0]
+		}
 	} else if clauseNode := p.findChildByType(node, "export_clause"); clauseNode != nil {
 		exportInfo.ExportType = "named"
 		// Extract specifiers from export_clause
@@ -72,6 +79,59 @@ func (p *Parser) extractExport(node *sitter.Node, content []byte, result *ParseR
 	return nil
 }
 
+// classifyDefaultExport records what a default export exports, and the property names
+// of an exported object literal
+func (p *Parser) classifyDefaultExport(node *sitter.Node, content []byte, exportInfo *ExportInfo) {
+	value := node.ChildByFieldName("declaration")
+	if value == nil {
+		value = node.ChildByFieldName("value")
+	}
+	if value == nil {
+		return
+	}
+
+	switch value.Type() {
+	case "object":
+		exportInfo.DefaultKind = "object"
+		for i := 0; i < int(value.NamedChildCount()); i++ {
+			property := value.NamedChild(i)
+			if property.Type() == "spread_element" || property.Type() == "comment" {
+				continue
+			}
+			key := property
+			if name := property.ChildByFieldName("key"); name != nil {
+				key = name
+			} else if name := property.ChildByFieldName("name"); name != nil {
+				key = name
+			}
+			exportInfo.Specifiers = append(exportInfo.Specifiers, strings.Trim(p.getNodeText(key, content), `"'`))
+		}
+	case "class", "class_declaration", "abstract_class_declaration":
+		exportInfo.DefaultKind = "class"
+	case "function", "function_expression", "function_declaration", "arrow_function",
+		"generator_function", "generator_function_declaration":
+		exportInfo.DefaultKind = "function"
+	case "identifier":
+		exportInfo.DefaultKind = "identifier"
+	default:
+		exportInfo.DefaultKind = "expression"
+	}
+}
+
+// declaredNames lists the names an exported declaration introduces
+func (p *Parser) declaredNames(declaration *sitter.Node, content []byte) []string {
+	if name := declaration.ChildByFieldName("name"); name != nil {
+		return []string{p.getNodeText(name, content)}
+	}
+	names := []string{}
+	for _, declarator := range p.findChildrenByType(declaration, "variable_declarator") {
+		if name := declarator.ChildByFieldName("name"); name != nil && name.Type() == "identifier" {
+			names = append(names, p.getNodeText(name, content))
+		}
+	}
+	return names
+}
+
 // extractImportClause extracts import specifiers and types
 func (p *Parser) extractImportClause(clauseNode *sitter.Node, content []byte, importInfo *ImportInfo) {
 	// Default import
@@ -190,6 +250,12 @@ func (p *Parser) extractMethodFromClass(node *sitter.Node, content []byte) Funct
 		method.Metadata["protected"] = "true"
 	}
 
+	method.HasDocComment = p.hasLeadingComment(node)
+	method.Branches = p.extractBranches(node, content)
+	method.BranchChains = p.extractBranchChains(node, content)
+	method.CallbackDepth, method.DeepestCallbackLine = p.callbackDepth(node)
+	method.UnhandledAsync = p.unhandledAsync(node, content)
+	method.AsyncStyles = p.asyncStyles(node, content, method.IsAsync)
 	method.Metadata["node_type"] = node.Type()
 
 	return method
@@ -299,8 +365,8 @@ func (p *Parser) extractParameters(paramsNode *sitter.Node, content []byte) []Pa
 
 // isExternalImport determines if an import is from an external package
 func (p *Parser) isExternalImport(source string) bool {
-	// External if doesn't start with . or / (relative paths)
-	if strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") {
+	// External if it isn't a relative or absolute path (including Windows forms)
+	if IsLocalImport(source) {
 		return false
 	}
 
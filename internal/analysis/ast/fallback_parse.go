@@ -0,0 +1,123 @@
+package ast
+
+import (
+	"context"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// ParseStatusDegraded marks a parse result recovered by the fallback parse. The file was
+// reparsed leniently, with a grammar that understands type annotations or with the code no
+// grammar could parse blanked out, so its structure is analyzed but the blanked code is not.
+const ParseStatusDegraded = "degraded"
+
+// maxFallbackPasses bounds the blank-and-reparse rounds; blanking one construct can leave
+// a remnant (a type parameter list emptied of its variance annotation) that fails in turn
+const maxFallbackPasses = 3
+
+// declarationNodes are the node types counted to make sure a fallback parse did not lose
+// functions or classes the original tree still had
+var declarationNodes = map[string]bool{
+	"function_declaration":           true,
+	"generator_function_declaration": true,
+	"function":                       true,
+	"function_expression":            true,
+	"arrow_function":                 true,
+	"method_definition":              true,
+	"class_declaration":              true,
+	"class":                          true,
+}
+
+// fallbackParse leniently reparses a file whose tree has errors. JavaScript is first
+// reparsed with the TSX grammar, which accepts Flow-style and TypeScript annotations; then
+// the source of every remaining error node is blanked out, keeping offsets and line breaks
+// so lines and columns still match the file. The first clean tree with at least as many
+// declarations as the original is returned with the source and grammar it was parsed with;
+// the caller owns the tree. It returns nil when no clean tree is found.
+func (p *Parser) fallbackParse(ctx context.Context, grammar Grammar, tree *sitter.Tree, content []byte) (*sitter.Tree, []byte, Grammar) {
+	declarations := countDeclarations(tree.RootNode())
+
+	if grammar == GrammarJavaScript {
+		reparsed, err := p.tsxParser.ParseCtx(ctx, nil, content)
+		if err == nil {
+			if clean, stripped := blankAndReparse(ctx, p.tsxParser, reparsed, content, declarations); clean != nil {
+				return clean, stripped, GrammarTSX
+			}
+		}
+	}
+
+	if clean, stripped := blankAndReparse(ctx, p.parserFor(grammar), nil, content, declarations); clean != nil {
+		return clean, stripped, grammar
+	}
+	return nil, nil, ""
+}
+
+// blankAndReparse blanks the error nodes of tree and reparses the result until it is clean,
+// for up to maxFallbackPasses rounds. A nil tree is parsed from content first. Every tree
+// other than the returned one is closed, tree included.
+func blankAndReparse(ctx context.Context, parser *sitter.Parser, tree *sitter.Tree, content []byte, declarations int) (*sitter.Tree, []byte) {
+	if tree == nil {
+		var err error
+		if tree, err = parser.ParseCtx(ctx, nil, content); err != nil {
+			return nil, nil
+		}
+	}
+
+	stripped := make([]byte, len(content))
+	copy(stripped, content)
+	for pass := 0; ; pass++ {
+		if !tree.RootNode().HasError() {
+			if countDeclarations(tree.RootNode()) >= declarations {
+				return tree, stripped
+			}
+			break
+		}
+		if pass == maxFallbackPasses || !blankErrorNodes(tree.RootNode(), stripped) {
+			break
+		}
+		next, err := parser.ParseCtx(ctx, nil, stripped)
+		if err != nil {
+			break
+		}
+		tree.Close()
+		tree = next
+	}
+	tree.Close()
+	return nil, nil
+}
+
+// blankErrorNodes replaces the source of every outermost error node with spaces, keeping
+// line breaks, and reports whether anything was blanked. MISSING nodes have no source.
+func blankErrorNodes(node *sitter.Node, content []byte) bool {
+	if node.IsError() {
+		blanked := false
+		for i := node.StartByte(); i < node.EndByte() && int(i) < len(content); i++ {
+			if content[i] != '\n' && content[i] != '\r' && content[i] != ' ' {
+				content[i] = ' '
+				blanked = true
+			}
+		}
+		return blanked
+	}
+
+	blanked := false
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if child := node.Child(i); child.HasError() {
+			blanked = blankErrorNodes(child, content) || blanked
+		}
+	}
+	return blanked
+}
+
+// countDeclarations counts the function and class nodes of a tree, including those inside
+// error nodes
+func countDeclarations(node *sitter.Node) int {
+	count := 0
+	if declarationNodes[node.Type()] {
+		count++
+	}
+	for i := 0; i < int(node.ChildCount()); i++ {
+		count += countDeclarations(node.Child(i))
+	}
+	return count
+}
@@ -0,0 +1,88 @@
+package ast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const flowTypedSource = `type User = { name: string, age: number };
+export function greet(u: User): string {
+  if (u.age > 18) { return hello(u.name) }
+  return hi(u.name)
+}
+class Service {
+  users: Array<User> = [];
+  find(id: string): ?User { if (id) { return lookup(id) } return null }
+}
+`
+
+func TestParser_FallbackParseRecoversStructure(t *testing.T) {
+	strict, err := NewParserWithConfig(ErrorConfig{EnablePartialParse: true})
+	require.NoError(t, err)
+	defer strict.Close()
+	lenient, err := NewParser()
+	require.NoError(t, err)
+	defer lenient.Close()
+
+	partial, err := strict.ParseFile(context.Background(), "service.js", []byte(flowTypedSource))
+	require.NoError(t, err)
+	assert.Equal(t, "partial_with_errors", partial.Metadata["parse_status"])
+
+	degraded, err := lenient.ParseFile(context.Background(), "service.js", []byte(flowTypedSource))
+	require.NoError(t, err)
+	assert.Equal(t, ParseStatusDegraded, degraded.Metadata["parse_status"])
+	assert.Equal(t, 9, degraded.LineCount)
+
+	require.Len(t, degraded.Errors, 1)
+	parseError := degraded.Errors[0]
+	assert.True(t, parseError.Recoverable)
+	assert.Equal(t, ParseStatusDegraded, parseError.Metadata["parse_status"])
+	assert.Equal(t, "javascript", parseError.Metadata["grammar"])
+	assert.Equal(t, "tsx", parseError.Metadata["fallback_grammar"])
+
+	methods := func(result *ParseResult) int {
+		count := 0
+		for _, class := range result.Classes {
+			count += len(class.Methods)
+		}
+		return count
+	}
+	assert.Greater(t, len(degraded.Functions)+methods(degraded), len(partial.Functions)+methods(partial))
+}
+
+func TestParser_FallbackParseBlanksUnsupportedSyntax(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	content := "export function make<in out T>(x: T): T {\n  if (x) { return x }\n  return x\n}\n"
+	result, err := parser.ParseFile(context.Background(), "make.ts", []byte(content))
+	require.NoError(t, err)
+
+	assert.Equal(t, ParseStatusDegraded, result.Metadata["parse_status"])
+	require.Len(t, result.Functions, 1)
+	assert.Equal(t, "make", result.Functions[0].Name)
+	assert.Equal(t, 1, result.Functions[0].StartLine)
+	assert.Equal(t, 4, result.Functions[0].EndLine)
+	assert.Equal(t, ParseErrorUnsupportedSyntax, result.Errors[0].Type)
+}
+
+func TestBlankErrorNodesKeepsOffsets(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	content := []byte("import data from './data.json' assert { type: 'json' };\nexport default data;\n")
+	tree, err := parser.tsParser.ParseCtx(context.Background(), nil, content)
+	require.NoError(t, err)
+	defer tree.Close()
+
+	stripped := append([]byte(nil), content...)
+	require.True(t, blankErrorNodes(tree.RootNode(), stripped))
+	assert.Len(t, stripped, len(content))
+	assert.NotContains(t, string(stripped), "assert")
+	assert.Contains(t, string(stripped), "\nexport default data;\n")
+}
@@ -0,0 +1,268 @@
+package ast
+
+import (
+	"fmt"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Grammar names a tree-sitter grammar compiled into the parser
+type Grammar string
+
+// Grammars the parser can parse a file with
+const (
+	GrammarJavaScript Grammar = "javascript"
+	GrammarTypeScript Grammar = "typescript"
+	GrammarTSX        Grammar = "tsx"
+)
+
+// grammarModule is the Go module the grammars are compiled from
+const grammarModule = "github.com/smacker/go-tree-sitter"
+
+// Parse error types of syntax the grammar does not understand, as opposed to plain
+// syntax errors in the source
+const (
+	ParseErrorSyntax            = "syntax"
+	ParseErrorUnsupportedSyntax = "unsupported_syntax"
+)
+
+// GrammarInfo describes the language feature level a grammar parses. Grammars are compiled
+// in, so the level only moves with the grammar module version.
+type GrammarInfo struct {
+	Grammar      Grammar  `json:"grammar"`
+	Module       string   `json:"module"` // Go module and version the grammar was built from
+	FeatureLevel string   `json:"feature_level"`
+	Unsupported  []string `json:"unsupported,omitempty"` // known syntax the grammar fails on
+	Extensions   []string `json:"extensions"`            // file extensions parsed with it by default
+}
+
+// defaultGrammars maps each supported file extension to the grammar it is parsed with
+var defaultGrammars = map[string]Grammar{
+	".js":  GrammarJavaScript,
+	".jsx": GrammarJavaScript,
+	".ts":  GrammarTypeScript,
+	".tsx": GrammarTSX,
+}
+
+// grammarFeatureLevels and grammarUnsupported describe the compiled-in grammar revision;
+// update them together with the grammar module version
+var grammarFeatureLevels = map[Grammar]string{
+	GrammarJavaScript: "ES2024 with JSX, decorators and import attributes",
+	GrammarTypeScript: "TypeScript 5.3 (satisfies, const type parameters, decorators, accessor, using declarations, import attributes)",
+	GrammarTSX:        "TypeScript 5.3 with JSX",
+}
+
+var grammarUnsupported = map[Grammar][]string{
+	GrammarJavaScript: {featureImportAssertions, featureTypeAnnotations},
+	GrammarTypeScript: {featureImportAssertions, featureVarianceAnnotations},
+	GrammarTSX:        {featureImportAssertions, featureVarianceAnnotations},
+}
+
+// Syntax features the compiled-in grammars fail on
+const (
+	featureImportAssertions    = "import assertions (assert { ... }); use import attributes (with { ... })"
+	featureVarianceAnnotations = "variance annotations on type parameters (in/out)"
+	featureTypeAnnotations     = "type annotations in a JavaScript file (Flow or TypeScript syntax)"
+)
+
+// unsupportedSyntax recognizes the syntax of a feature at a parse error
+type unsupportedSyntax struct {
+	feature  string
+	grammars []Grammar
+	pattern  *regexp.Regexp // matched against the line of the error
+}
+
+var unsupportedSyntaxPatterns = []unsupportedSyntax{
+	{
+		feature:  featureImportAssertions,
+		grammars: []Grammar{GrammarJavaScript, GrammarTypeScript, GrammarTSX},
+		pattern:  regexp.MustCompile(`\b(?:import|export)\b.*\bassert\s*\{`),
+	},
+	{
+		feature:  featureVarianceAnnotations,
+		grammars: []Grammar{GrammarTypeScript, GrammarTSX},
+		pattern:  regexp.MustCompile(`<(?:[^<>]*,)?\s*(?:in|out|in\s+out)\s+[A-Za-z_$]`),
+	},
+	{
+		feature:  featureTypeAnnotations,
+		grammars: []Grammar{GrammarJavaScript},
+		pattern: regexp.MustCompile(`^\s*(?:export\s+)?(?:type|interface|opaque\s+type|declare)\s+[A-Za-z_$]|` +
+			`\bfunction\b[^(]*\([^)]*[A-Za-z_$][\w$]*\??\s*:\s*[A-Za-z_$]|` +
+			`\b(?:const|let|var)\s+[A-Za-z_$][\w$]*\s*:\s*[A-Za-z_$]|` +
+			`\)\s*:\s*[A-Za-z_$][\w$.]*(?:<[^>]*>)?\s*(?:\{|=>)`),
+	},
+}
+
+// flowPragma marks a JavaScript file as Flow-typed, so any parse error in it is put down
+// to its type annotations
+var flowPragma = regexp.MustCompile(`(?m)^\s*(?://|/\*+)\s*@flow\b`)
+
+// Grammars describes every compiled-in grammar
+func Grammars() []GrammarInfo {
+	module := grammarModule
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == grammarModule {
+				module += "@" + dep.Version
+			}
+		}
+	}
+
+	extensions := make(map[Grammar][]string)
+	for ext, grammar := range defaultGrammars {
+		extensions[grammar] = append(extensions[grammar], ext)
+	}
+
+	var grammars []GrammarInfo
+	for _, grammar := range []Grammar{GrammarJavaScript, GrammarTypeScript, GrammarTSX} {
+		sort.Strings(extensions[grammar])
+		grammars = append(grammars, GrammarInfo{
+			Grammar:      grammar,
+			Module:       module,
+			FeatureLevel: grammarFeatureLevels[grammar],
+			Unsupported:  grammarUnsupported[grammar],
+			Extensions:   extensions[grammar],
+		})
+	}
+	return grammars
+}
+
+// ParseGrammar validates a grammar name
+func ParseGrammar(name string) (Grammar, error) {
+	grammar := Grammar(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := grammarFeatureLevels[grammar]; !ok {
+		return "", fmt.Errorf("unknown grammar %q (want javascript, typescript or tsx)", name)
+	}
+	return grammar, nil
+}
+
+// SetGrammar pins the grammar files with extension ext are parsed with, for instance
+// ".js" to typescript for JavaScript files carrying type annotations
+func (p *Parser) SetGrammar(ext string, grammar Grammar) error {
+	ext, grammar, err := grammarOverride(ext, string(grammar))
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.grammars == nil {
+		p.grammars = make(map[string]Grammar)
+	}
+	p.grammars[ext] = grammar
+	return nil
+}
+
+// ValidateGrammars checks grammar names by file extension, as accepted by SetGrammar
+func ValidateGrammars(grammars map[string]string) error {
+	exts := make([]string, 0, len(grammars))
+	for ext := range grammars {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	for _, ext := range exts {
+		if _, _, err := grammarOverride(ext, grammars[ext]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grammarOverride normalizes and validates the extension and grammar of a pinned grammar
+func grammarOverride(ext, name string) (string, Grammar, error) {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	if _, ok := defaultGrammars[ext]; !ok {
+		return "", "", fmt.Errorf("unsupported file extension %q (want .js, .jsx, .ts or .tsx)", ext)
+	}
+	grammar, err := ParseGrammar(name)
+	if err != nil {
+		return "", "", err
+	}
+	return ext, grammar, nil
+}
+
+// grammarFor returns the grammar files with extension ext are parsed with
+func (p *Parser) grammarFor(ext string) Grammar {
+	if grammar, ok := p.grammars[ext]; ok {
+		return grammar
+	}
+	return defaultGrammars[ext]
+}
+
+// syntaxError describes the first error node of a tree that failed to parse cleanly. An
+// error on syntax the grammar is known not to support is reported as unsupported_syntax
+// naming the feature, so newer language features are not mistaken for broken source.
+func syntaxError(root *sitter.Node, content []byte, filePath string, grammar Grammar) ParseError {
+	parseError := ParseError{
+		Type:     ParseErrorSyntax,
+		Message:  "Syntax errors detected in parsed tree",
+		FilePath: filePath,
+		Severity: "warning",
+		Context:  "Tree contains error nodes",
+		Metadata: map[string]string{"grammar": string(grammar)},
+	}
+
+	node := firstErrorNode(root)
+	if node == nil {
+		return parseError
+	}
+	parseError.Line = int(node.StartPoint().Row) + 1
+	parseError.Column = int(node.StartPoint().Column) + 1
+
+	lines := strings.Split(string(content), "\n")
+	if parseError.Line > len(lines) {
+		return parseError
+	}
+	line := lines[parseError.Line-1]
+	parseError.Context = strings.TrimSpace(line)
+
+	flow := grammar == GrammarJavaScript && flowPragma.Match(content)
+	for _, syntax := range unsupportedSyntaxPatterns {
+		if !containsGrammar(syntax.grammars, grammar) {
+			continue
+		}
+		if !syntax.pattern.MatchString(line) && !(flow && syntax.feature == featureTypeAnnotations) {
+			continue
+		}
+		parseError.Type = ParseErrorUnsupportedSyntax
+		parseError.Message = fmt.Sprintf("The %s grammar does not support %s", grammar, syntax.feature)
+		parseError.Metadata["feature"] = syntax.feature
+		if syntax.feature == featureTypeAnnotations {
+			parseError.Suggestions = []string{"Parse .js files with the typescript grammar"}
+		}
+		break
+	}
+	return parseError
+}
+
+// firstErrorNode returns the first ERROR or MISSING node of a tree in source order
+func firstErrorNode(node *sitter.Node) *sitter.Node {
+	if node.IsError() || node.IsMissing() {
+		return node
+	}
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if child.HasError() || child.IsMissing() {
+			if found := firstErrorNode(child); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+func containsGrammar(grammars []Grammar, grammar Grammar) bool {
+	for _, g := range grammars {
+		if g == grammar {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package ast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_SyntaxErrorClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		errorType string
+		feature   string
+		line      int
+	}{
+		{
+			name:      "import assertion",
+			file:      "config.ts",
+			content:   "import data from './data.json' assert { type: 'json' };\n",
+			errorType: ParseErrorUnsupportedSyntax,
+			feature:   featureImportAssertions,
+			line:      1,
+		},
+		{
+			name:      "variance annotation",
+			file:      "types.ts",
+			content:   "export const x = 1;\ninterface Producer<in out T> { make(): T }\n",
+			errorType: ParseErrorUnsupportedSyntax,
+			feature:   featureVarianceAnnotations,
+			line:      2,
+		},
+		{
+			name:      "flow annotations",
+			file:      "math.js",
+			content:   "// @flow\nfunction add(a: number, b: number): number { return a + b }\n",
+			errorType: ParseErrorUnsupportedSyntax,
+			feature:   featureTypeAnnotations,
+			line:      2,
+		},
+		{
+			name:      "broken source",
+			file:      "broken.ts",
+			content:   "const total = 1 +;\n",
+			errorType: ParseErrorSyntax,
+			line:      1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := NewParser()
+			require.NoError(t, err)
+			defer parser.Close()
+
+			result, err := parser.ParseFile(context.Background(), tt.file, []byte(tt.content))
+			require.NoError(t, err)
+			require.Len(t, result.Errors, 1)
+
+			parseError := result.Errors[0]
+			assert.Equal(t, tt.errorType, parseError.Type)
+			assert.Equal(t, tt.feature, parseError.Metadata["feature"])
+			assert.Equal(t, tt.line, parseError.Line)
+		})
+	}
+}
+
+func TestParser_SetGrammar(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	content := []byte("function add(a: number, b: number): number { return a + b }\n")
+
+	result, err := parser.ParseFile(context.Background(), "math.js", content)
+	require.NoError(t, err)
+	assert.Equal(t, "javascript", result.Metadata["grammar"])
+	assert.NotEmpty(t, result.Errors)
+
+	require.NoError(t, parser.SetGrammar(".js", GrammarTypeScript))
+	result, err = parser.ParseFile(context.Background(), "math.js", content)
+	require.NoError(t, err)
+	assert.Equal(t, "javascript", result.Language)
+	assert.Equal(t, "typescript", result.Metadata["grammar"])
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "success", result.Metadata["parse_status"])
+
+	assert.Error(t, parser.SetGrammar(".py", GrammarTypeScript))
+	assert.Error(t, parser.SetGrammar(".js", Grammar("flow")))
+
+	assert.NoError(t, ValidateGrammars(map[string]string{"js": "TypeScript"}))
+	assert.Error(t, ValidateGrammars(map[string]string{".vue": "typescript"}))
+}
+
+func TestGrammars(t *testing.T) {
+	grammars := Grammars()
+	require.Len(t, grammars, 3)
+	for _, grammar := range grammars {
+		assert.NotEmpty(t, grammar.FeatureLevel, grammar.Grammar)
+		assert.Contains(t, grammar.Module, grammarModule)
+	}
+	assert.Equal(t, []string{".js", ".jsx"}, grammars[0].Extensions)
+
+	grammar, err := ParseGrammar(" TypeScript ")
+	require.NoError(t, err)
+	assert.Equal(t, GrammarTypeScript, grammar)
+}
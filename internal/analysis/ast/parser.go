@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"path/filepath"
@@ -18,6 +19,7 @@ type Parser struct {
 	jsParser     *sitter.Parser
 	tsParser     *sitter.Parser
 	tsxParser    *sitter.Parser
+	grammars     map[string]Grammar // grammars pinned by file extension, see SetGrammar
 	errorHandler *ErrorHandler
 	mu           sync.RWMutex
 }
@@ -32,20 +34,77 @@ type ParseResult struct {
 	Variables  []VariableInfo         `json:"variables"`
 	Imports    []ImportInfo           `json:"imports"`
 	Exports    []ExportInfo           `json:"exports"`
+	Comments   []CommentInfo          `json:"comments"`
+	DOMQueries []DOMQueryInfo         `json:"dom_queries"`
+	Calls      []CallInfo             `json:"calls"`
+	LineCount  int                    `json:"line_count"` // physical lines in the file
 	Errors     []ParseError           `json:"errors"`
 	Metadata   map[string]interface{} `json:"metadata"`
 }
 
 // FunctionInfo represents a parsed function
 type FunctionInfo struct {
-	Name       string            `json:"name"`
-	Parameters []ParameterInfo   `json:"parameters"`
-	ReturnType string            `json:"return_type"`
-	IsAsync    bool              `json:"is_async"`
-	IsExported bool              `json:"is_exported"`
-	StartLine  int               `json:"start_line"`
-	EndLine    int               `json:"end_line"`
-	Metadata   map[string]string `json:"metadata"`
+	Name          string            `json:"name"`
+	Parameters    []ParameterInfo   `json:"parameters"`
+	ReturnType    string            `json:"return_type"`
+	IsAsync       bool              `json:"is_async"`
+	IsExported    bool              `json:"is_exported"`
+	StartLine     int               `json:"start_line"`
+	EndLine       int               `json:"end_line"`
+	Metadata      map[string]string `json:"metadata"`
+	HasDocComment bool              `json:"has_doc_comment"` // a comment directly precedes the declaration
+	Branches      []BranchInfo      `json:"branches"`        // decision points in the body, excluding nested functions
+
+	// BranchChains lists the switch statements and if/else-if chains in the function's own body
+	BranchChains []BranchChainInfo `json:"branch_chains,omitempty"`
+
+	// CallbackDepth is the deepest chain of functions nested inside this one (callbacks,
+	// closures), and DeepestCallbackLine the line where the innermost of them starts
+	CallbackDepth       int `json:"callback_depth"`
+	DeepestCallbackLine int `json:"deepest_callback_line,omitempty"`
+
+	// UnhandledAsync lists awaits outside a try/catch and promise chains whose rejection is
+	// never handled, in the function's own body
+	UnhandledAsync []AsyncCallInfo `json:"unhandled_async,omitempty"`
+
+	// AsyncStyles lists the asynchronous styles the function's own body uses, of
+	// AsyncStyleAwait, AsyncStylePromise and AsyncStyleCallback, in that order
+	AsyncStyles []string `json:"async_styles,omitempty"`
+}
+
+// Asynchronous styles a function body can use
+const (
+	AsyncStyleAwait    = "async_await" // an async function, or one that awaits
+	AsyncStylePromise  = "promise"     // .then()/.catch() chains, new Promise or Promise.all and friends
+	AsyncStyleCallback = "callback"    // error-first callbacks, or a trailing callback parameter
+)
+
+// AsyncCallInfo is an await or promise chain inside a function body
+type AsyncCallInfo struct {
+	Kind   string `json:"kind"`   // await, then
+	Callee string `json:"callee"` // awaited or chained call target source text, e.g. "db.query"; "" for other expressions
+	Line   int    `json:"line"`
+}
+
+// BranchInfo represents a decision point inside a function body
+type BranchInfo struct {
+	Kind             string   `json:"kind"`      // if, case, ternary, catch
+	Condition        string   `json:"condition"` // condition, case value or catch parameter source text
+	StartLine        int      `json:"start_line"`
+	EndLine          int      `json:"end_line"`
+	HasErrorHandling bool     `json:"has_error_handling"` // throws, nests a try, or calls .catch()/reject()
+	HasAwait         bool     `json:"has_await"`
+	Callees          []string `json:"callees,omitempty"` // root identifiers of calls made in the branch, e.g. "fetch", "db"
+	IsGuard          bool     `json:"is_guard"`          // if without else whose body only returns, throws, breaks or continues
+}
+
+// BranchChainInfo is a switch statement or an if/else-if chain, counted as a whole
+type BranchChainInfo struct {
+	Kind      string `json:"kind"`    // switch, if_else
+	Subject   string `json:"subject"` // switch discriminant, or the expression every if compares with === or ==; "" when the ifs test unrelated conditions
+	Cases     int    `json:"cases"`   // case and default clauses, or if conditions plus a final else
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
 }
 
 // ParameterInfo represents function parameters
@@ -58,15 +117,16 @@ type ParameterInfo struct {
 
 // ClassInfo represents a parsed class
 type ClassInfo struct {
-	Name       string            `json:"name"`
-	Extends    string            `json:"extends"`
-	Implements []string          `json:"implements"`
-	Methods    []FunctionInfo    `json:"methods"`
-	Properties []PropertyInfo    `json:"properties"`
-	IsExported bool              `json:"is_exported"`
-	StartLine  int               `json:"start_line"`
-	EndLine    int               `json:"end_line"`
-	Metadata   map[string]string `json:"metadata"`
+	Name          string            `json:"name"`
+	Extends       string            `json:"extends"`
+	Implements    []string          `json:"implements"`
+	Methods       []FunctionInfo    `json:"methods"`
+	Properties    []PropertyInfo    `json:"properties"`
+	IsExported    bool              `json:"is_exported"`
+	StartLine     int               `json:"start_line"`
+	EndLine       int               `json:"end_line"`
+	Metadata      map[string]string `json:"metadata"`
+	HasDocComment bool              `json:"has_doc_comment"` // a comment directly precedes the declaration
 }
 
 // PropertyInfo represents class properties
@@ -115,8 +175,35 @@ type ExportInfo struct {
 	Name       string   `json:"name"`
 	ExportType string   `json:"export_type"` // default, named, all
 	Source     string   `json:"source"`      // re-export source
-	Specifiers []string `json:"specifiers"`
+	Specifiers []string `json:"specifiers"`  // for a default exported object, its property names
 	StartLine  int      `json:"start_line"`
+
+	// DefaultKind is what a default export exports: object, class, function, identifier
+	// or expression. An object bundles its members so bundlers cannot drop unused ones.
+	DefaultKind string `json:"default_kind,omitempty"`
+}
+
+// CommentInfo represents a single comment node (a // line or a /* */ block)
+type CommentInfo struct {
+	Text      string `json:"text"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+}
+
+// DOMQueryInfo represents a DOM lookup call such as document.querySelector("#nav")
+type DOMQueryInfo struct {
+	Method   string `json:"method"`   // querySelector, getElementById, ...
+	Receiver string `json:"receiver"` // root identifier of the queried object, e.g. "document"
+	Selector string `json:"selector"` // string literal argument, or its source text when dynamic
+	Line     int    `json:"line"`
+}
+
+// CallInfo represents a call site of a named function or method, e.g. formatDate(d) or api.fetchUser(id)
+type CallInfo struct {
+	Callee   string `json:"callee"`   // called function or method name
+	Receiver string `json:"receiver"` // root identifier of the method receiver, "" for plain calls
+	Line     int    `json:"line"`
+	InLoop   bool   `json:"in_loop,omitempty"` // made once per iteration of a loop or array iteration callback
 }
 
 // Note: ParseError is now defined in error_handler.go
@@ -128,6 +215,7 @@ func NewParser() (*Parser, error) {
 		ErrorThreshold:     0.5,
 		EnableRecovery:     true,
 		EnablePartialParse: true,
+		EnableFallback:     true,
 		LogLevel:           "error",
 	})
 }
@@ -158,11 +246,14 @@ func (p *Parser) ParseFile(ctx context.Context, filePath string, content []byte)
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
+	// Report paths use forward slashes on every OS
+	filePath = NormalizePath(filePath)
+
 	// Update error handler statistics
 	p.errorHandler.stats.TotalFiles++
 
 	// Determine language and parser
-	language, parser := p.getParserForFile(filePath)
+	language, grammar, parser := p.getParserForFile(filePath)
 	if parser == nil {
 		err := fmt.Errorf("unsupported file type: %s", filePath)
 		p.errorHandler.HandleParseError(err, filePath, content)
@@ -182,8 +273,12 @@ func (p *Parser) ParseFile(ctx context.Context, filePath string, content []byte)
 		Variables:  []VariableInfo{},
 		Imports:    []ImportInfo{},
 		Exports:    []ExportInfo{},
+		Comments:   []CommentInfo{},
+		DOMQueries: []DOMQueryInfo{},
+		Calls:      []CallInfo{},
+		LineCount:  countLines(content),
 		Errors:     []ParseError{},
-		Metadata:   make(map[string]interface{}),
+		Metadata:   map[string]interface{}{"grammar": string(grammar)},
 	}
 
 	// Parse the content with error handling
@@ -207,21 +302,28 @@ func (p *Parser) ParseFile(ctx context.Context, filePath string, content []byte)
 		p.errorHandler.stats.FailedFiles++
 		return nil, fmt.Errorf("failed to parse file %s: %w", filePath, err)
 	}
-	defer tree.Close()
+	defer func() { tree.Close() }()
 
 	// Check for syntax errors in the parsed tree
 	if tree.RootNode().HasError() {
 		// Tree has syntax errors but parsing succeeded partially
-		syntaxError := &ParseError{
-			Type:     "syntax",
-			Message:  "Syntax errors detected in parsed tree",
-			FilePath: filePath,
-			Severity: "warning",
-			Context:  "Tree contains error nodes",
+		syntaxError := syntaxError(tree.RootNode(), content, filePath, grammar)
+		result.Metadata["parse_status"] = "partial_with_errors"
+
+		// Error recovery can swallow whole declarations into an error node, so reparse the
+		// file leniently and analyze whatever that recovers instead
+		if p.errorHandler.config.EnableFallback {
+			if fallback, stripped, fallbackGrammar := p.fallbackParse(ctx, grammar, tree, content); fallback != nil {
+				tree.Close()
+				tree, content = fallback, stripped
+				syntaxError.Recoverable = true
+				syntaxError.Metadata["parse_status"] = ParseStatusDegraded
+				syntaxError.Metadata["fallback_grammar"] = string(fallbackGrammar)
+				result.Metadata["parse_status"] = ParseStatusDegraded
+			}
 		}
-		result.Errors = append(result.Errors, *syntaxError)
+		result.Errors = append(result.Errors, syntaxError)
 		p.errorHandler.stats.PartialFiles++
-		result.Metadata["parse_status"] = "partial_with_errors"
 	} else {
 		result.Metadata["parse_status"] = "success"
 	}
@@ -249,19 +351,48 @@ func (p *Parser) ParseFile(ctx context.Context, filePath string, content []byte)
 	return result, nil
 }
 
-// getParserForFile determines the appropriate parser based on file extension
-func (p *Parser) getParserForFile(filePath string) (string, *sitter.Parser) {
+// countLines returns the number of physical lines in content; a trailing newline does not start a new line
+func countLines(content []byte) int {
+	if len(content) == 0 {
+		return 0
+	}
+	lines := bytes.Count(content, []byte("\n"))
+	if content[len(content)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// getParserForFile determines the language of a file from its extension, and the grammar
+// and parser it is parsed with
+func (p *Parser) getParserForFile(filePath string) (string, Grammar, *sitter.Parser) {
 	ext := strings.ToLower(filepath.Ext(filePath))
 
+	var language string
 	switch ext {
 	case ".js", ".jsx":
-		return "javascript", p.jsParser
+		language = "javascript"
 	case ".ts":
-		return "typescript", p.tsParser
+		language = "typescript"
 	case ".tsx":
-		return "tsx", p.tsxParser
+		language = "tsx"
+	default:
+		return "", "", nil
+	}
+
+	grammar := p.grammarFor(ext)
+	return language, grammar, p.parserFor(grammar)
+}
+
+// parserFor returns the parser of a grammar
+func (p *Parser) parserFor(grammar Grammar) *sitter.Parser {
+	switch grammar {
+	case GrammarTypeScript:
+		return p.tsParser
+	case GrammarTSX:
+		return p.tsxParser
 	default:
-		return "", nil
+		return p.jsParser
 	}
 }
 
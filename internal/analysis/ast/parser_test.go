@@ -290,6 +290,31 @@ func TestParser_ParseFile_UnsupportedFile(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported file type")
 }
 
+func TestParser_ParseFile_LineCount(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected int
+	}{
+		{"trailing newline", "const a = 1;\nconst b = 2;\n", 2},
+		{"no trailing newline", "const a = 1;\nconst b = 2;", 2},
+		{"trailing blank lines", "const a = 1;\n\n\n", 3},
+		{"empty", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.ParseFile(context.Background(), "test.js", []byte(tt.content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.LineCount)
+		})
+	}
+}
+
 // Helper functions for tests
 func findFunctionByName(functions []FunctionInfo, name string) *FunctionInfo {
 	for i := range functions {
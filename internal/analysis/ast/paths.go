@@ -0,0 +1,38 @@
+package ast
+
+import (
+	"path"
+	"strings"
+)
+
+// ToSlash converts backslash separators to forward slashes. Unlike filepath.ToSlash it does
+// so on every OS, so paths and import specifiers from a Windows checkout are handled the
+// same way when the report is produced elsewhere.
+func ToSlash(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+}
+
+// NormalizePath returns the forward-slash, cleaned form of a file path. All analyzers key
+// files by this form so report paths are identical regardless of the host OS.
+func NormalizePath(p string) string {
+	if p == "" {
+		return p
+	}
+	return path.Clean(ToSlash(p))
+}
+
+// IsLocalImport reports whether an import specifier refers to a project file rather than a
+// package: "./x", "../x", "/x" and their Windows forms ".\x", "..\x", "C:\x".
+func IsLocalImport(source string) bool {
+	source = ToSlash(source)
+	return strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || hasDriveLetter(source)
+}
+
+// hasDriveLetter reports whether p starts with a Windows drive such as "C:/"
+func hasDriveLetter(p string) bool {
+	if len(p) < 3 || p[1] != ':' || p[2] != '/' {
+		return false
+	}
+	letter := p[0] | 0x20 // lower-case ASCII letters
+	return letter >= 'a' && letter <= 'z'
+}
@@ -0,0 +1,49 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`src\components\Button.tsx`, "src/components/Button.tsx"},
+		{`.\src\index.js`, "src/index.js"},
+		{`C:\repo\src\app.ts`, "C:/repo/src/app.ts"},
+		{"src/utils/../helper.js", "src/helper.js"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizePath(tt.input))
+		})
+	}
+}
+
+func TestIsLocalImport(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected bool
+	}{
+		{"./utils", true},
+		{"../config", true},
+		{"/absolute/path", true},
+		{`.\utils`, true},
+		{`..\lib\db`, true},
+		{`C:\repo\src\db`, true},
+		{"react", false},
+		{"@types/node", false},
+		{"lodash/fp", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsLocalImport(tt.source))
+		})
+	}
+}
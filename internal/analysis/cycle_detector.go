@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
 )
 
 // CycleDetector detects circular dependencies and dependency cycles in the codebase
@@ -91,6 +93,8 @@ func NewCycleDetector(componentIdentifier *ComponentIdentifier) *CycleDetector {
 
 // DetectCycles performs comprehensive cycle detection analysis
 func (cd *CycleDetector) DetectCycles(filePath, content string) error {
+	filePath = ast.NormalizePath(filePath)
+
 	// Extract dependencies from the file
 	dependencies, err := cd.extractDependencies(filePath, content)
 	if err != nil {
@@ -103,6 +107,12 @@ func (cd *CycleDetector) DetectCycles(filePath, content string) error {
 	return nil
 }
 
+// AddDependencies records the already resolved dependencies of a file, in the order the
+// search should follow them, for callers that resolve imports themselves
+func (cd *CycleDetector) AddDependencies(filePath string, dependencies []string) {
+	cd.dependencyGraph[ast.NormalizePath(filePath)] = append([]string{}, dependencies...)
+}
+
 // AnalyzeCycles performs cycle detection using DFS algorithm
 func (cd *CycleDetector) AnalyzeCycles() error {
 	// Reset analysis state
@@ -119,8 +129,13 @@ func (cd *CycleDetector) AnalyzeCycles() error {
 		}
 	}
 
-	// Perform DFS from each unvisited node
+	// Perform DFS from each unvisited node, in path order so the cycles found are stable
+	nodes := make([]string, 0, len(allNodes))
 	for file := range allNodes {
+		nodes = append(nodes, file)
+	}
+	sort.Strings(nodes)
+	for _, file := range nodes {
 		if !cd.visitedNodes[file] {
 			cd.dfsDetectCycles(file, []string{})
 		}
@@ -252,6 +267,7 @@ func (cd *CycleDetector) extractFromClause(line string, currentFile string) stri
 
 	path := strings.TrimSpace(parts[1])
 	path = strings.Trim(path, "\"'`;")
+	path = ast.ToSlash(path)
 
 	// Only track relative imports for cycle detection
 	if strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") {
@@ -276,6 +292,7 @@ func (cd *CycleDetector) extractRequirePath(line string, currentFile string) str
 
 	path := strings.TrimSpace(line[start : start+end])
 	path = strings.Trim(path, "\"'`")
+	path = ast.ToSlash(path)
 
 	// Only track relative imports for cycle detection
 	if strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") {
@@ -300,6 +317,7 @@ func (cd *CycleDetector) extractDynamicImportPath(line string, currentFile strin
 
 	path := strings.TrimSpace(line[start : start+end])
 	path = strings.Trim(path, "\"'`")
+	path = ast.ToSlash(path)
 
 	// Only track relative imports for cycle detection
 	if strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") {
@@ -702,8 +720,12 @@ func (cd *CycleDetector) GetCycleStats() CycleStats {
 		fileCounts = append(fileCounts, fileCount{file, count})
 	}
 
+	// Most cycles first, ties by path so the top files do not depend on map order
 	sort.Slice(fileCounts, func(i, j int) bool {
-		return fileCounts[i].count > fileCounts[j].count
+		if fileCounts[i].count != fileCounts[j].count {
+			return fileCounts[i].count > fileCounts[j].count
+		}
+		return fileCounts[i].file < fileCounts[j].file
 	})
 
 	// Get top 5 most problematic files
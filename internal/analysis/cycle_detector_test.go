@@ -760,3 +760,24 @@ func TestCycleDetector_ResolutionStrategies(t *testing.T) {
 		})
 	}
 }
+
+func TestCycleDetector_AddDependencies(t *testing.T) {
+	cd := NewCycleDetector(nil)
+	// Resolved by the caller, so files sharing a base name are still distinct modules
+	cd.AddDependencies("src/a/index.ts", []string{"src/b/index.ts"})
+	cd.AddDependencies("src/b/index.ts", []string{"src/a/index.ts"})
+	cd.AddDependencies("src/c.ts", []string{"src/a/index.ts"})
+
+	if err := cd.AnalyzeCycles(); err != nil {
+		t.Fatalf("AnalyzeCycles() error: %v", err)
+	}
+
+	cycles := cd.GetCycles()
+	if len(cycles) != 1 {
+		t.Fatalf("Expected 1 cycle, got %d", len(cycles))
+	}
+	expected := []string{"src/a/index.ts", "src/b/index.ts", "src/a/index.ts"}
+	if strings.Join(cycles[0].Files, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected cycle %v, got %v", expected, cycles[0].Files)
+	}
+}
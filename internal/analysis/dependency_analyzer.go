@@ -10,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/httpclient"
 )
 
 // DependencyAnalyzer analyzes project dependencies from package management files
@@ -36,6 +38,10 @@ type DependencyAnalyzerConfig struct {
 	BundleSizeThreshold       int64    `json:"bundle_size_threshold"`   // bytes
 	PerformanceThreshold      int      `json:"performance_threshold"`   // ms
 	CriticalVulnThreshold     float64  `json:"critical_vuln_threshold"` // CVSS score
+
+	// HTTP configures the client shared by vulnerability, license, update and size lookups;
+	// the zero value uses httpclient.DefaultConfig
+	HTTP httpclient.Config `json:"http"`
 }
 
 // PackageManifest represents parsed package.json data
@@ -169,14 +175,21 @@ func NewDependencyAnalyzer(config DependencyAnalyzerConfig) (*DependencyAnalyzer
 		config.CriticalVulnThreshold = 7.0 // CVSS 7.0+ considered critical
 	}
 
+	if config.HTTP == (httpclient.Config{}) {
+		config.HTTP = httpclient.DefaultConfig()
+	}
+
 	analyzer := &DependencyAnalyzer{
 		projectRoot: config.ProjectRoot,
 		config:      config,
 	}
 
+	// One client for every integration so they share the cache and the rate limit
+	client := httpclient.New(config.HTTP)
+
 	// Initialize sub-components if enabled
 	if config.EnableVulnScanning {
-		vulnDB, err := NewVulnerabilityDatabase()
+		vulnDB, err := NewVulnerabilityDatabaseWithClient(client)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize vulnerability database: %w", err)
 		}
@@ -184,7 +197,7 @@ func NewDependencyAnalyzer(config DependencyAnalyzerConfig) (*DependencyAnalyzer
 	}
 
 	if config.EnableLicenseChecking {
-		licenseChecker, err := NewLicenseChecker()
+		licenseChecker, err := NewLicenseCheckerWithClient(client)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize license checker: %w", err)
 		}
@@ -192,7 +205,7 @@ func NewDependencyAnalyzer(config DependencyAnalyzerConfig) (*DependencyAnalyzer
 	}
 
 	if config.EnableUpdateChecking {
-		updateChecker, err := NewUpdateChecker()
+		updateChecker, err := NewUpdateCheckerWithClient(client)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize update checker: %w", err)
 		}
@@ -200,7 +213,7 @@ func NewDependencyAnalyzer(config DependencyAnalyzerConfig) (*DependencyAnalyzer
 	}
 
 	if config.EnablePerformanceAnalysis {
-		analyzer.performanceAnalyzer = NewPerformanceAnalyzer()
+		analyzer.performanceAnalyzer = NewPerformanceAnalyzerWithClient(client)
 	}
 
 	if config.EnableBundleAnalysis {
@@ -119,9 +119,12 @@ func (gb *GraphBuilder) identifyCriticalNodes() {
 		scores = append(scores, nodeScore{nodeID: nodeID, score: score})
 	}
 
-	// Sort by score (descending)
+	// Sort by score (descending), ties by node ID so the cut below does not depend on map order
 	sort.Slice(scores, func(i, j int) bool {
-		return scores[i].score > scores[j].score
+		if scores[i].score != scores[j].score {
+			return scores[i].score > scores[j].score
+		}
+		return scores[i].nodeID < scores[j].nodeID
 	})
 
 	// Take top 10% or at least top 5 as critical nodes
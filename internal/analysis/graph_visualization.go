@@ -150,9 +150,12 @@ func (ge *GraphExporter) getSortedNodeIDs() []string {
 		weights = append(weights, nodeWeight{id: id, weight: node.Weight})
 	}
 
-	// Sort by weight (descending)
+	// Sort by weight (descending), ties by ID so the order does not depend on map order
 	sort.Slice(weights, func(i, j int) bool {
-		return weights[i].weight > weights[j].weight
+		if weights[i].weight != weights[j].weight {
+			return weights[i].weight > weights[j].weight
+		}
+		return weights[i].id < weights[j].id
 	})
 
 	result := make([]string, len(weights))
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/httpclient"
 )
 
 // LicenseChecker analyzes package licenses and compatibility
@@ -151,9 +153,11 @@ type licenseCacheEntry struct {
 
 // NewLicenseChecker creates a new license checker
 func NewLicenseChecker() (*LicenseChecker, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	return NewLicenseCheckerWithClient(httpclient.New(httpclient.DefaultConfig()))
+}
+
+// NewLicenseCheckerWithClient creates a license checker that queries through client
+func NewLicenseCheckerWithClient(client *http.Client) (*LicenseChecker, error) {
 
 	// Initialize compatibility matrix with common licenses
 	matrix := initializeCompatibilityMatrix()
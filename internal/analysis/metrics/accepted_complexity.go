@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// acceptComplexityPattern matches "repo-onboarding-accept-complexity: reason" in a comment
+var acceptComplexityPattern = regexp.MustCompile(`repo-onboarding-accept-complexity:(.*)`)
+
+// AcceptedComplexity is a function whose complexity was accepted with an annotation. It is
+// still measured, but no longer recommended for refactoring and never affects the gate.
+type AcceptedComplexity struct {
+	Name            string `json:"name"`
+	FilePath        string `json:"file_path"`
+	StartLine       int    `json:"start_line"`
+	CyclomaticValue int    `json:"cyclomatic_value"`
+	CognitiveValue  int    `json:"cognitive_value"`
+	Reason          string `json:"reason"` // text after the annotation, "" when none was given
+}
+
+// acceptedComplexityReason returns the reason of the accept-complexity annotation covering
+// function, if any: a comment ending on the line above it, or starting on its first line
+func acceptedComplexityReason(function ast.FunctionInfo, comments []ast.CommentInfo) (string, bool) {
+	for _, comment := range comments {
+		if comment.EndLine != function.StartLine-1 && comment.StartLine != function.StartLine {
+			continue
+		}
+		if match := acceptComplexityPattern.FindStringSubmatch(comment.Text); match != nil {
+			return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(match[1]), "*/")), true
+		}
+	}
+	return "", false
+}
+
+// collectAcceptedComplexity lists the accepted functions of metrics once each, in report
+// order; class methods are measured both as functions and as methods
+func collectAcceptedComplexity(metrics *ComplexityMetrics) []AcceptedComplexity {
+	var accepted []AcceptedComplexity
+	seen := make(map[string]bool)
+	for _, function := range metrics.FunctionMetrics {
+		key := fmt.Sprintf("%s:%d", function.FilePath, function.StartLine)
+		if !function.Accepted || seen[key] {
+			continue
+		}
+		seen[key] = true
+		accepted = append(accepted, AcceptedComplexity{
+			Name:            function.Name,
+			FilePath:        function.FilePath,
+			StartLine:       function.StartLine,
+			CyclomaticValue: function.CyclomaticValue,
+			CognitiveValue:  function.CognitiveValue,
+			Reason:          function.AcceptedReason,
+		})
+	}
+	return accepted
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestAcceptedComplexityReason(t *testing.T) {
+	comments := []ast.CommentInfo{
+		{Text: "// repo-onboarding-accept-complexity: mirrors the protocol state machine", StartLine: 4, EndLine: 4},
+		{Text: "/* repo-onboarding-accept-complexity: */", StartLine: 20, EndLine: 20},
+		{Text: "// repo-onboarding-accept-complexity generated", StartLine: 30, EndLine: 30}, // missing colon
+	}
+
+	reason, ok := acceptedComplexityReason(ast.FunctionInfo{StartLine: 5}, comments)
+	assert.True(t, ok)
+	assert.Equal(t, "mirrors the protocol state machine", reason)
+
+	reason, ok = acceptedComplexityReason(ast.FunctionInfo{StartLine: 20}, comments)
+	assert.True(t, ok, "a comment on the function's first line covers it")
+	assert.Empty(t, reason)
+
+	_, ok = acceptedComplexityReason(ast.FunctionInfo{StartLine: 6}, comments)
+	assert.False(t, ok, "only the function directly below the comment is covered")
+	_, ok = acceptedComplexityReason(ast.FunctionInfo{StartLine: 31}, comments)
+	assert.False(t, ok)
+}
+
+func TestGenerateQualityReport_AcceptedComplexity(t *testing.T) {
+	files := map[string]string{
+		"src/protocol.js": "// repo-onboarding-accept-complexity: one case per protocol state\n" + switchSource("step", 4),
+		"src/reducer.js":  switchSource("reduce", 4),
+	}
+	report, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	complexity := report.DetailedMetrics.Complexity
+	metrics := map[string]FunctionComplexity{}
+	for _, function := range complexity.FunctionMetrics {
+		metrics[function.Name] = function
+	}
+	require.Contains(t, metrics, "step", "the accepted function is still measured")
+	assert.True(t, metrics["step"].Accepted)
+	assert.Equal(t, "one case per protocol state", metrics["step"].AcceptedReason)
+	assert.False(t, metrics["reduce"].Accepted)
+
+	require.Len(t, complexity.Accepted, 1)
+	assert.Equal(t, AcceptedComplexity{
+		Name:            "step",
+		FilePath:        "src/protocol.js",
+		StartLine:       2,
+		CyclomaticValue: metrics["step"].CyclomaticValue,
+		CognitiveValue:  metrics["step"].CognitiveValue,
+		Reason:          "one case per protocol state",
+	}, complexity.Accepted[0])
+}
+
+func TestAcceptedComplexity_ExcludedFromRecommendations(t *testing.T) {
+	complexity := &ComplexityMetrics{FunctionMetrics: []FunctionComplexity{
+		{Name: "step", FilePath: "src/protocol.js", StartLine: 2, EndLine: 60, CyclomaticValue: 22, WeightedScore: 30, SeverityLevel: "severe", Accepted: true},
+		{Name: "route", FilePath: "src/router.js", StartLine: 1, EndLine: 40, CyclomaticValue: 22, WeightedScore: 30, SeverityLevel: "severe"},
+	}}
+	analyzer := NewComplexityAnalyzer()
+	for i := range complexity.FunctionMetrics {
+		analyzer.categorizeFunction(&complexity.FunctionMetrics[i], &complexity.ComplexityByLevel)
+	}
+	complexity.Accepted = collectAcceptedComplexity(complexity)
+	require.Len(t, complexity.Accepted, 1)
+
+	analyzer.generateRecommendations(complexity)
+	require.Len(t, complexity.Recommendations, 2)
+	assert.Equal(t, []string{"route"}, complexity.Recommendations[0].Functions)
+	assert.Equal(t, []string{"route (src/router.js:1)"}, complexity.Recommendations[1].Functions)
+
+	recommendations := NewQualityReporter(QualityReportConfig{}).generateComplexityRecommendations(complexity)
+	require.Len(t, recommendations, 1)
+	assert.Equal(t, []string{"src/router.js"}, recommendations[0].Files)
+
+	items := NewDebtScorer().convertComplexityToDebt(complexity)
+	require.Len(t, items, 2, "accepted complexity still counts as debt")
+	assert.True(t, items[0].Accepted)
+	assert.False(t, items[1].Accepted)
+}
+
+func TestEvaluateQualityGate_AcceptedComplexity(t *testing.T) {
+	technicalDebt := &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+		"Complexity Debt": {Items: []TechnicalDebtItem{
+			{ID: "complexity_debt_3000", Type: "high_complexity", Severity: "critical", Accepted: true},
+		}},
+	}}
+	coverage := &CoverageMetrics{Summary: CoverageSummary{QualityGate: QualityGatePass}}
+
+	reporter := NewQualityReporter(QualityReportConfig{})
+	assert.Equal(t, QualityGatePass, reporter.evaluateQualityGate(technicalDebt, coverage, &PerformanceMetrics{}, ComponentScores{}))
+
+	technicalDebt.Categories["Complexity Debt"].Items[0].Accepted = false
+	assert.Equal(t, QualityGateFail, reporter.evaluateQualityGate(technicalDebt, coverage, &PerformanceMetrics{}, ComponentScores{}))
+}
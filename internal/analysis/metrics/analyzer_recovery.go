@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// errAnalyzerPanicked marks an analyzer that panicked; the report is still produced
+// with that component marked unavailable
+var errAnalyzerPanicked = errors.New("analyzer panicked")
+
+// recoverAnalyzer runs analyze, turning a panic into an error wrapping errAnalyzerPanicked
+func recoverAnalyzer(analyze func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", errAnalyzerPanicked, r)
+		}
+	}()
+	return analyze()
+}
+
+// scoredComponents lists the components that make up the overall score, keyed like
+// the dashboard's component health
+var scoredComponents = []SectionName{
+	SectionComplexity, SectionDuplication, SectionTechnicalDebt,
+	SectionCoverage, SectionPerformance, SectionMaintainability, SectionSecurity,
+}
+
+// unavailableComponents returns the scored components marked in missing, sorted
+func unavailableComponents(missing map[string]bool) []string {
+	var components []string
+	for _, component := range scoredComponents {
+		if missing[string(component)] {
+			components = append(components, string(component))
+		}
+	}
+	sort.Strings(components)
+	return components
+}
+
+// orEmptyMetrics returns metrics, or empty metrics with component marked in missing when nil
+func orEmptyMetrics[T any](metrics *T, component SectionName, missing map[string]bool) *T {
+	if metrics == nil {
+		missing[string(component)] = true
+		return new(T)
+	}
+	return metrics
+}
+
+// isAvailable reports whether component has metrics of its own
+func (scores ComponentScores) isAvailable(component string) bool {
+	return !contains(scores.Unavailable, component)
+}
+
+// unavailableHealthIndicator describes a component whose analyzer did not complete
+func unavailableHealthIndicator(description string) HealthIndicator {
+	return HealthIndicator{
+		Status:      "unavailable",
+		Color:       "gray",
+		Icon:        "⛔",
+		Description: description + " (analysis failed)",
+	}
+}
+
+// notScannedHealthIndicator describes a component whose scan results were not supplied
+func notScannedHealthIndicator(description string) HealthIndicator {
+	return HealthIndicator{
+		Status:      "unavailable",
+		Color:       "gray",
+		Icon:        "⛔",
+		Description: description + " (not scanned)",
+	}
+}
+
+// dropUnavailableAlerts removes alerts raised from the empty metrics of unavailable components
+func dropUnavailableAlerts(alerts []QualityAlert, scores ComponentScores) []QualityAlert {
+	if len(scores.Unavailable) == 0 {
+		return alerts
+	}
+
+	kept := alerts[:0]
+	for _, alert := range alerts {
+		if scores.isAvailable(alert.Component) {
+			kept = append(kept, alert)
+		}
+	}
+	return kept
+}
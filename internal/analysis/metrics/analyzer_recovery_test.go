@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverAnalyzer(t *testing.T) {
+	err := recoverAnalyzer(func() error { panic("unexpected node") })
+	require.ErrorIs(t, err, errAnalyzerPanicked)
+	assert.Contains(t, err.Error(), "unexpected node")
+
+	plain := errors.New("bad input")
+	assert.Equal(t, plain, recoverAnalyzer(func() error { return plain }), "ordinary errors pass through")
+	assert.NoError(t, recoverAnalyzer(func() error { return nil }))
+}
+
+func TestGenerateQualityReport_AnalyzerPanic(t *testing.T) {
+	files := map[string]string{
+		"src/a.js": "function a(x) {\n  if (x) {\n    return 1;\n  }\n  return 2;\n}\n",
+	}
+	baseline, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	reporter := NewQualityReporter(QualityReportConfig{})
+	reporter.performanceAnalyzer = nil // panics on its first field access
+
+	var sections []SectionName
+	report, err := reporter.GenerateQualityReportWithSections(context.Background(), files, func(section ReportSection) {
+		sections = append(sections, section.Name)
+	})
+	require.NoError(t, err)
+
+	require.Contains(t, report.AnalyzerErrors, string(SectionPerformance))
+	assert.Contains(t, report.AnalyzerErrors[string(SectionPerformance)], "analyzer panicked")
+	assert.Len(t, report.AnalyzerErrors, 1)
+	assert.Equal(t, []string{string(SectionPerformance), string(SectionSecurity)}, report.ComponentScores.Unavailable,
+		"security is unavailable without scan results")
+	assert.Equal(t, "unavailable", report.Dashboard.ComponentHealth["performance"].Status)
+	for _, alert := range report.Dashboard.AlertsAndWarnings {
+		assert.NotEqual(t, "performance", alert.Component)
+	}
+
+	// The other components are unaffected and the overall score is reweighted over them
+	assert.Equal(t, baseline.ComponentScores.Complexity, report.ComponentScores.Complexity)
+	assert.Equal(t, baseline.ComponentScores.Maintainability, report.ComponentScores.Maintainability)
+	assert.Contains(t, sections, SectionMaintainability)
+	assert.NotContains(t, sections, SectionPerformance)
+
+	weights := reporter.config.WeightingFactors
+	scores := report.ComponentScores
+	expected := (scores.Complexity*weights.Complexity + scores.Duplication*weights.Duplication +
+		scores.TechnicalDebt*weights.TechnicalDebt + scores.Coverage*weights.Coverage +
+		scores.Maintainability*weights.Maintainability) / (1 - weights.Performance)
+	assert.InDelta(t, RoundScore(expected, DefaultScorePrecision), report.OverallScore, 1e-9)
+}
+
+func TestCalculateOverallScore_MissingComponents(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{})
+	scores := ComponentScores{Complexity: 80, Duplication: 80, TechnicalDebt: 80, Coverage: 0, Performance: 0, Maintainability: 80}
+
+	assert.Less(t, reporter.calculateOverallScore(scores), 80.0, "zero scores drag the total down")
+
+	scores.Unavailable = []string{string(SectionCoverage), string(SectionSecurity)}
+	assert.InDelta(t, 80*0.7/0.8, reporter.calculateOverallScore(scores), 0.01, "one missing component")
+
+	scores.Unavailable = []string{string(SectionCoverage), string(SectionPerformance), string(SectionSecurity)}
+	assert.InDelta(t, 80.0, reporter.calculateOverallScore(scores), 0.01, "two missing components")
+	assert.Equal(t, scores.Unavailable, reporter.excludedFromScore(scores))
+
+	keep := NewQualityReporter(QualityReportConfig{KeepMissingWeights: true})
+	assert.InDelta(t, 80*0.7, keep.calculateOverallScore(scores), 0.01, "missing components count as 0")
+	assert.Equal(t, []string{string(SectionSecurity)}, keep.excludedFromScore(scores), "unscanned security never counts as 0")
+}
+
+func TestGenerateQualityReport_TwoMissingComponents(t *testing.T) {
+	files := map[string]string{"src/a.js": "function a() {\n  return 1;\n}\n"}
+
+	reporter := NewQualityReporter(QualityReportConfig{})
+	reporter.coverageAnalyzer = nil
+	reporter.performanceAnalyzer = nil
+	report, err := reporter.GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	excluded := []string{string(SectionCoverage), string(SectionPerformance), string(SectionSecurity)}
+	assert.Equal(t, excluded, report.ComponentScores.Unavailable)
+	assert.Equal(t, excluded, report.ExcludedFromScore)
+
+	scores := report.ComponentScores
+	expected := (scores.Complexity*0.20 + scores.Duplication*0.15 + scores.TechnicalDebt*0.25 + scores.Maintainability*0.10) / 0.70
+	assert.InDelta(t, RoundScore(expected, DefaultScorePrecision), report.OverallScore, 1e-9)
+}
+
+func TestOrEmptyMetrics(t *testing.T) {
+	missing := map[string]bool{}
+	coverage := &CoverageMetrics{OverallScore: 50}
+
+	assert.Same(t, coverage, orEmptyMetrics(coverage, SectionCoverage, missing))
+	assert.Empty(t, missing)
+
+	assert.NotNil(t, orEmptyMetrics[CoverageMetrics](nil, SectionCoverage, missing))
+	assert.Equal(t, []string{string(SectionCoverage)}, unavailableComponents(missing))
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SelectableSections are the analyzers QualityReportConfig.Only can choose from, in the
+// order they run
+var SelectableSections = []SectionName{
+	SectionComplexity, SectionDuplication, SectionTechnicalDebt, SectionCoverage,
+	SectionPerformance, SectionDocumentation, SectionMaintainability,
+	SectionAPISurface, SectionTodoInventory,
+}
+
+// sectionInputs lists the analyzers whose metrics each analyzer reads. They run whenever a
+// section that needs them is selected, but are only reported when selected themselves.
+var sectionInputs = map[SectionName][]SectionName{
+	SectionTechnicalDebt:   {SectionComplexity, SectionDuplication},
+	SectionCoverage:        {SectionComplexity},
+	SectionPerformance:     {SectionComplexity},
+	SectionDocumentation:   {SectionComplexity},
+	SectionMaintainability: {SectionComplexity, SectionDocumentation},
+	SectionAPISurface:      {SectionComplexity},
+}
+
+// ParseSectionNames parses a comma-separated list of analyzer names such as
+// "complexity,coverage"; see SelectableSections
+func ParseSectionNames(value string) ([]SectionName, error) {
+	var sections []SectionName
+	seen := make(map[SectionName]bool)
+	for _, name := range strings.Split(value, ",") {
+		section := SectionName(strings.ToLower(strings.TrimSpace(name)))
+		if !containsSection(SelectableSections, section) {
+			supported := make([]string, 0, len(SelectableSections))
+			for _, s := range SelectableSections {
+				supported = append(supported, string(s))
+			}
+			return nil, fmt.Errorf("unknown analyzer %q: expected %s", section, strings.Join(supported, ", "))
+		}
+		if seen[section] {
+			return nil, fmt.Errorf("analyzer %q listed twice", section)
+		}
+		seen[section] = true
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+// selected reports whether the section is reported: every section is when Only is empty
+func (qr *QualityReporter) selected(section SectionName) bool {
+	return len(qr.config.Only) == 0 || containsSection(qr.config.Only, section)
+}
+
+// needed reports whether the section's analyzer has to run, because it is selected or a
+// selected analyzer reads its metrics
+func (qr *QualityReporter) needed(section SectionName) bool {
+	if qr.selected(section) {
+		return true
+	}
+	for _, selected := range qr.config.Only {
+		if dependsOn(selected, section) {
+			return true
+		}
+	}
+	return false
+}
+
+// skippedComponents returns the scored components left out by Only, sorted. Security is
+// never skipped: its scan results come from the caller.
+func (qr *QualityReporter) skippedComponents() []string {
+	var skipped []string
+	for _, component := range scoredComponents {
+		if component != SectionSecurity && !qr.selected(component) {
+			skipped = append(skipped, string(component))
+		}
+	}
+	sort.Strings(skipped)
+	return skipped
+}
+
+// dependsOn reports whether section reads input's metrics, directly or through another analyzer
+func dependsOn(section, input SectionName) bool {
+	for _, direct := range sectionInputs[section] {
+		if direct == input || dependsOn(direct, input) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsSection reports whether sections includes section
+func containsSection(sections []SectionName, section SectionName) bool {
+	for _, s := range sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// reportedMetrics returns metrics, or nil when Only leaves their section out of the report
+func reportedMetrics[T any](qr *QualityReporter, metrics *T, section SectionName) *T {
+	if !qr.selected(section) {
+		return nil
+	}
+	return metrics
+}
+
+// skippedHealthIndicator describes a component whose analyzer was not selected
+func skippedHealthIndicator(description string) HealthIndicator {
+	return HealthIndicator{
+		Status:      "skipped",
+		Color:       "gray",
+		Icon:        "⏭️",
+		Description: description + " (not selected)",
+	}
+}
@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSectionNames(t *testing.T) {
+	sections, err := ParseSectionNames("complexity, Coverage")
+	require.NoError(t, err)
+	assert.Equal(t, []SectionName{SectionComplexity, SectionCoverage}, sections)
+
+	_, err = ParseSectionNames("complexity,security")
+	assert.ErrorContains(t, err, `unknown analyzer "security"`)
+
+	_, err = ParseSectionNames("coverage,coverage")
+	assert.ErrorContains(t, err, "listed twice")
+}
+
+func TestQualityReporter_NeededSections(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{Only: []SectionName{SectionTechnicalDebt}})
+	assert.True(t, reporter.needed(SectionComplexity), "debt reads complexity")
+	assert.True(t, reporter.needed(SectionDuplication), "debt reads duplication")
+	assert.False(t, reporter.selected(SectionComplexity))
+	assert.False(t, reporter.needed(SectionCoverage))
+
+	reporter = NewQualityReporter(QualityReportConfig{Only: []SectionName{SectionMaintainability}})
+	assert.True(t, reporter.needed(SectionDocumentation))
+	assert.True(t, reporter.needed(SectionComplexity), "through documentation")
+	assert.False(t, reporter.needed(SectionDuplication))
+
+	reporter = NewQualityReporter(QualityReportConfig{})
+	for _, section := range SelectableSections {
+		assert.True(t, reporter.selected(section))
+	}
+}
+
+func TestGenerateQualityReport_Only(t *testing.T) {
+	files := map[string]string{
+		"src/a.js": "function a(x) {\n  if (x) {\n    return 1;\n  }\n  return 2;\n}\n",
+	}
+	full, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	reporter := NewQualityReporter(QualityReportConfig{Only: []SectionName{SectionComplexity, SectionCoverage}})
+	var sections []SectionName
+	report, err := reporter.GenerateQualityReportWithSections(context.Background(), files, func(section ReportSection) {
+		sections = append(sections, section.Name)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []SectionName{SectionComplexity, SectionCoverage}, sections)
+	assert.Equal(t, []string{"duplication", "maintainability", "performance", "technical_debt"}, report.ComponentScores.Skipped)
+	assert.Equal(t, "skipped", report.Dashboard.ComponentHealth["duplication"].Status)
+	assert.Nil(t, report.DetailedMetrics.Duplication)
+	assert.Nil(t, report.DetailedMetrics.TechnicalDebt)
+	assert.Nil(t, report.DetailedMetrics.Documentation)
+	assert.Nil(t, report.APISurface)
+	assert.Nil(t, report.TodoInventory)
+	require.NotNil(t, report.DetailedMetrics.Complexity)
+	require.NotNil(t, report.DetailedMetrics.Coverage)
+
+	// Selected components score as in a full run; the overall score is renormalized over them
+	assert.Equal(t, full.ComponentScores.Complexity, report.ComponentScores.Complexity)
+	assert.Equal(t, full.ComponentScores.Coverage, report.ComponentScores.Coverage)
+	weights := reporter.config.WeightingFactors
+	expected := (report.ComponentScores.Complexity*weights.Complexity + report.ComponentScores.Coverage*weights.Coverage) /
+		(weights.Complexity + weights.Coverage)
+	assert.InDelta(t, RoundScore(expected, DefaultScorePrecision), report.OverallScore, 1e-9)
+
+	for _, recommendation := range report.Recommendations {
+		assert.Contains(t, []string{"complexity", "coverage"}, recommendation.Component)
+	}
+}
+
+func TestExcludedFromScore_KeepMissingWeightsStillSkips(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{KeepMissingWeights: true, Only: []SectionName{SectionComplexity}})
+	scores := ComponentScores{Unavailable: []string{"coverage", "security"}, Skipped: []string{"coverage"}}
+	assert.Equal(t, []string{"coverage", "security"}, reporter.excludedFromScore(scores))
+}
+
+func TestGenerateQualityReport_OnlySummaryNamesScoredComponents(t *testing.T) {
+	files := map[string]string{
+		"src/a.js": "function a(x) {\n  if (x) {\n    return 1;\n  }\n  return 2;\n}\n",
+	}
+
+	report, err := NewQualityReporter(QualityReportConfig{Only: []SectionName{SectionComplexity}, IncludeExecutiveSummary: true}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	assessment := report.ExecutiveSummary.OverallAssessment
+	assert.Contains(t, assessment, "The only area scored is complexity")
+	for _, skipped := range []string{"coverage", "duplication", "maintainability", "performance", "technical debt"} {
+		assert.NotContains(t, assessment, skipped, "a skipped analyzer is never the weakest area")
+	}
+
+	report, err = NewQualityReporter(QualityReportConfig{Only: []SectionName{SectionComplexity, SectionDuplication}, IncludeExecutiveSummary: true}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+	assert.Regexp(t, `The strongest area is (complexity|duplication) \(\d+\.\d\), while (complexity|duplication) \(\d+\.\d\) requires the most attention`, report.ExecutiveSummary.OverallAssessment)
+}
+
+func TestGenerateOverallAssessment_SkipsUnscoredComponents(t *testing.T) {
+	scores := ComponentScores{Complexity: 80, Coverage: 0, Duplication: 90, Maintainability: 70, Performance: 60, Security: 65, TechnicalDebt: 0,
+		Unavailable: []string{"coverage", "technical_debt"}, Skipped: []string{"coverage"}}
+
+	assessment := NewQualityReporter(QualityReportConfig{}).generateOverallAssessment(75, "Good", scores)
+
+	assert.Contains(t, assessment, "The strongest area is duplication (90.0), while performance (60.0) requires the most attention")
+}
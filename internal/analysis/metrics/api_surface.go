@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// APISurface is an index of every exported function, class and class method,
+// answering "what can I call" for new consumers of a library
+type APISurface struct {
+	TotalExports      int        `json:"total_exports"`
+	UndocumentedCount int        `json:"undocumented_count"`
+	Entries           []APIEntry `json:"entries"`
+}
+
+// APIEntry describes a single exported symbol
+type APIEntry struct {
+	Module     string `json:"module"` // file path without extension
+	Name       string `json:"name"`
+	Kind       string `json:"kind"` // function, class, method
+	Signature  string `json:"signature"`
+	FilePath   string `json:"file_path"`
+	StartLine  int    `json:"start_line"`
+	Complexity int    `json:"complexity"` // cyclomatic; summed over methods for classes
+	Documented bool   `json:"documented"`
+}
+
+// API entry kinds
+const (
+	APIKindFunction = "function"
+	APIKindClass    = "class"
+	APIKindMethod   = "method"
+)
+
+// BuildAPISurface collects the exported symbols of parseResults, sorted by module, line and name
+func BuildAPISurface(parseResults []*ast.ParseResult, complexity *ComplexityMetrics) *APISurface {
+	functionComplexity := make(map[string]int)
+	classComplexity := make(map[string]int)
+	if complexity != nil {
+		for _, function := range complexity.FunctionMetrics {
+			functionComplexity[function.FilePath+"#"+function.Name] = function.CyclomaticValue
+		}
+		for _, class := range complexity.ClassMetrics {
+			classComplexity[class.FilePath+"#"+class.Name] = class.TotalComplexity
+			for _, method := range class.Methods {
+				functionComplexity[class.FilePath+"#"+class.Name+"."+method.Name] = method.CyclomaticValue
+			}
+		}
+	}
+
+	surface := &APISurface{Entries: []APIEntry{}}
+	for _, result := range parseResults {
+		module := strings.TrimSuffix(result.FilePath, path.Ext(result.FilePath))
+
+		for _, function := range result.Functions {
+			if !function.IsExported || function.Name == "" {
+				continue
+			}
+			surface.Entries = append(surface.Entries, APIEntry{
+				Module:     module,
+				Name:       function.Name,
+				Kind:       APIKindFunction,
+				Signature:  functionSignature(function.Name, function),
+				FilePath:   result.FilePath,
+				StartLine:  function.StartLine,
+				Complexity: functionComplexity[result.FilePath+"#"+function.Name],
+				Documented: function.HasDocComment,
+			})
+		}
+
+		for _, class := range result.Classes {
+			if !class.IsExported || class.Name == "" {
+				continue
+			}
+			surface.Entries = append(surface.Entries, APIEntry{
+				Module:     module,
+				Name:       class.Name,
+				Kind:       APIKindClass,
+				Signature:  classSignature(class),
+				FilePath:   result.FilePath,
+				StartLine:  class.StartLine,
+				Complexity: classComplexity[result.FilePath+"#"+class.Name],
+				Documented: class.HasDocComment,
+			})
+
+			for _, method := range class.Methods {
+				if method.Name == "" || strings.HasPrefix(method.Name, "#") {
+					continue // ECMAScript private methods are not callable from outside
+				}
+				qualified := class.Name + "." + method.Name
+				surface.Entries = append(surface.Entries, APIEntry{
+					Module:     module,
+					Name:       qualified,
+					Kind:       APIKindMethod,
+					Signature:  functionSignature(qualified, method),
+					FilePath:   result.FilePath,
+					StartLine:  method.StartLine,
+					Complexity: functionComplexity[result.FilePath+"#"+qualified],
+					Documented: method.HasDocComment,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(surface.Entries, func(i, j int) bool {
+		a, b := surface.Entries[i], surface.Entries[j]
+		if a.Module != b.Module {
+			return a.Module < b.Module
+		}
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		return a.Name < b.Name
+	})
+
+	surface.TotalExports = len(surface.Entries)
+	for _, entry := range surface.Entries {
+		if !entry.Documented {
+			surface.UndocumentedCount++
+		}
+	}
+
+	return surface
+}
+
+// functionSignature renders a TypeScript-style signature, e.g. "async load(id: string, opts?): Promise<User>"
+func functionSignature(name string, function ast.FunctionInfo) string {
+	params := make([]string, 0, len(function.Parameters))
+	for _, param := range function.Parameters {
+		rendered := param.Name
+		if param.IsOptional && param.DefaultValue == "" {
+			rendered += "?"
+		}
+		if param.Type != "" {
+			rendered += ": " + annotationType(param.Type)
+		}
+		if param.DefaultValue != "" {
+			rendered += " = " + param.DefaultValue
+		}
+		params = append(params, rendered)
+	}
+
+	signature := fmt.Sprintf("%s(%s)", name, strings.Join(params, ", "))
+	if function.IsAsync {
+		signature = "async " + signature
+	}
+	if function.ReturnType != "" {
+		signature += ": " + annotationType(function.ReturnType)
+	}
+	return signature
+}
+
+// annotationType strips the leading colon the parser keeps on TypeScript type annotations
+func annotationType(annotation string) string {
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(annotation), ":"))
+}
+
+// classSignature renders a class header, e.g. "class Store extends Base implements Persistable"
+func classSignature(class ast.ClassInfo) string {
+	signature := "class " + class.Name
+	if class.Extends != "" {
+		signature += " extends " + class.Extends
+	}
+	if len(class.Implements) > 0 {
+		signature += " implements " + strings.Join(class.Implements, ", ")
+	}
+	return signature
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAPISurface(t *testing.T) {
+	files := map[string]string{
+		"src/store.ts": `/**
+ * Persists records.
+ */
+export class Store {
+  save(record: string): boolean {
+    return record.length > 0;
+  }
+}
+
+function internalHelper() {
+  return 1;
+}
+`,
+		"src/api/client.ts": `export async function load(id: string, retries = 3): Promise<string> {
+  if (retries > 0) {
+    return id;
+  }
+  return '';
+}
+
+// Formats a record for display
+export function format(value) {
+  return String(value);
+}
+`,
+	}
+
+	reporter := NewQualityReporter(QualityReportConfig{})
+	parseResults, _, err := reporter.parseFiles(files)
+	require.NoError(t, err)
+	complexity, err := reporter.complexityAnalyzer.AnalyzeComplexity(context.Background(), parseResults)
+	require.NoError(t, err)
+
+	surface := BuildAPISurface(parseResults, complexity)
+
+	var names []string
+	for _, entry := range surface.Entries {
+		names = append(names, entry.Name)
+	}
+	// Sorted by module, then declaration order; private helpers are excluded
+	assert.Equal(t, []string{"load", "format", "Store", "Store.save"}, names)
+	assert.Equal(t, 4, surface.TotalExports)
+
+	load := surface.Entries[0]
+	assert.Equal(t, "src/api/client", load.Module)
+	assert.Equal(t, APIKindFunction, load.Kind)
+	assert.Contains(t, load.Signature, "async load(id: string")
+	assert.Contains(t, load.Signature, "Promise<string>")
+	assert.Greater(t, load.Complexity, 1)
+	assert.False(t, load.Documented)
+
+	assert.True(t, surface.Entries[1].Documented)
+	assert.True(t, surface.Entries[2].Documented)
+	assert.Equal(t, "class Store", surface.Entries[2].Signature)
+	assert.Equal(t, APIKindMethod, surface.Entries[3].Kind)
+	assert.Equal(t, 2, surface.UndocumentedCount)
+}
+
+func TestGenerateAPISurfaceRecommendations(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{})
+
+	assert.Empty(t, reporter.generateAPISurfaceRecommendations(&APISurface{TotalExports: 1}))
+
+	recs := reporter.generateAPISurfaceRecommendations(&APISurface{
+		TotalExports:      2,
+		UndocumentedCount: 1,
+		Entries: []APIEntry{
+			{Name: "load", FilePath: "src/client.ts"},
+			{Name: "format", FilePath: "src/client.ts", Documented: true},
+		},
+	})
+	require.Len(t, recs, 1)
+	assert.Equal(t, PriorityLow, recs[0].Priority)
+	assert.Equal(t, []string{"src/client.ts"}, recs[0].Files)
+	assert.Contains(t, recs[0].Description, "load")
+}
@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// MixedAsyncFile is a file whose functions use more than one asynchronous style, with the
+// number of functions using each. A function mixing styles itself counts toward each.
+type MixedAsyncFile struct {
+	FilePath   string `json:"file_path"`
+	AsyncAwait int    `json:"async_await"`
+	Promise    int    `json:"promise"`
+	Callback   int    `json:"callback"`
+	Dominant   string `json:"dominant"`   // the style most functions use, which the others could convert to
+	ToConvert  int    `json:"to_convert"` // functions using a style other than the dominant one
+}
+
+// styles returns how many of the asynchronous styles the file uses
+func (f MixedAsyncFile) styles() int {
+	styles := 0
+	for _, count := range []int{f.AsyncAwait, f.Promise, f.Callback} {
+		if count > 0 {
+			styles++
+		}
+	}
+	return styles
+}
+
+// mixedAsyncFile counts the asynchronous styles of parseResult's functions and reports
+// whether it mixes more than one
+func mixedAsyncFile(parseResult *ast.ParseResult) (MixedAsyncFile, bool) {
+	file := MixedAsyncFile{FilePath: parseResult.FilePath}
+	asyncFunctions := 0
+	for _, function := range parseResult.Functions {
+		for _, style := range function.AsyncStyles {
+			switch style {
+			case ast.AsyncStyleAwait:
+				file.AsyncAwait++
+			case ast.AsyncStylePromise:
+				file.Promise++
+			case ast.AsyncStyleCallback:
+				file.Callback++
+			}
+		}
+		if len(function.AsyncStyles) > 0 {
+			asyncFunctions++
+		}
+	}
+	if file.styles() < 2 {
+		return file, false
+	}
+
+	// Ties go to async/await, the style the others are usually converted to
+	file.Dominant = ast.AsyncStyleAwait
+	dominantCount := file.AsyncAwait
+	if file.Promise > dominantCount {
+		file.Dominant, dominantCount = ast.AsyncStylePromise, file.Promise
+	}
+	if file.Callback > dominantCount {
+		file.Dominant, dominantCount = ast.AsyncStyleCallback, file.Callback
+	}
+	file.ToConvert = asyncFunctions - dominantCount
+	return file, true
+}
+
+// analyzeMixedAsyncStyles reports each file mixing async/await, promise chains and
+// callbacks. Newcomers have to switch mental models between functions, and errors slip
+// through where one style hands off to another.
+func (ds *DebtScorer) analyzeMixedAsyncStyles(parseResults []*ast.ParseResult) []TechnicalDebtItem {
+	items := []TechnicalDebtItem{}
+	itemID := 0
+
+	for _, parseResult := range parseResults {
+		file, mixed := mixedAsyncFile(parseResult)
+		if !mixed {
+			continue
+		}
+
+		severity := "medium"
+		if file.styles() == 3 {
+			severity = "high"
+		}
+
+		var counts []string
+		for _, style := range []struct {
+			count int
+			label string
+		}{{file.AsyncAwait, "async/await"}, {file.Promise, "promise"}, {file.Callback, "callback"}} {
+			if style.count > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", style.count, style.label))
+			}
+		}
+
+		items = append(items, TechnicalDebtItem{
+			ID:             fmt.Sprintf("mixed_async_styles_%d", itemID),
+			Type:           "mixed_async_styles",
+			Category:       "Code Smells",
+			FilePath:       file.FilePath,
+			StartLine:      1,
+			EndLine:        ds.fileLineCount(parseResult),
+			Description:    fmt.Sprintf("File '%s' mixes asynchronous styles across its functions: %s", file.FilePath, strings.Join(counts, ", ")),
+			Severity:       severity,
+			EstimatedHours: 0.5*float64(file.ToConvert) + 0.5, // half an hour per function to convert
+			RemediationSteps: []string{
+				fmt.Sprintf("Settle on one style for the file; most of its functions use %s", asyncStyleLabel(file.Dominant)),
+				"Wrap callback APIs with util.promisify or a small new Promise adapter",
+				"Convert .then() chains to async/await, keeping a try/catch where a .catch() was",
+				"Verify errors still reach the caller at every converted hand-off",
+			},
+			Metadata: map[string]interface{}{
+				"async_await": file.AsyncAwait,
+				"promise":     file.Promise,
+				"callback":    file.Callback,
+				"dominant":    file.Dominant,
+				"to_convert":  file.ToConvert,
+			},
+		})
+		itemID++
+	}
+
+	return items
+}
+
+// asyncStyleLabel names an asynchronous style for a remediation step
+func asyncStyleLabel(style string) string {
+	switch style {
+	case ast.AsyncStylePromise:
+		return "promise chains"
+	case ast.AsyncStyleCallback:
+		return "callbacks"
+	}
+	return "async/await"
+}
+
+// mixedAsyncFilesFromItems lists the files among items that mix asynchronous styles, worst
+// first: those using all three styles, then those with the most functions to convert
+func mixedAsyncFilesFromItems(items []TechnicalDebtItem) []MixedAsyncFile {
+	var files []MixedAsyncFile
+	for _, item := range items {
+		if item.Type != "mixed_async_styles" {
+			continue
+		}
+		file := MixedAsyncFile{FilePath: item.FilePath}
+		file.AsyncAwait, _ = item.Metadata["async_await"].(int)
+		file.Promise, _ = item.Metadata["promise"].(int)
+		file.Callback, _ = item.Metadata["callback"].(int)
+		file.Dominant, _ = item.Metadata["dominant"].(string)
+		file.ToConvert, _ = item.Metadata["to_convert"].(int)
+		files = append(files, file)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].styles() != files[j].styles() {
+			return files[i].styles() > files[j].styles()
+		}
+		if files[i].ToConvert != files[j].ToConvert {
+			return files[i].ToConvert > files[j].ToConvert
+		}
+		return files[i].FilePath < files[j].FilePath
+	})
+	return files
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// fileWithAsyncStyles builds a parse result with one function per entry of styles
+func fileWithAsyncStyles(path string, styles ...[]string) *ast.ParseResult {
+	result := &ast.ParseResult{FilePath: path, LineCount: 10 * len(styles)}
+	for i, functionStyles := range styles {
+		result.Functions = append(result.Functions, ast.FunctionInfo{Name: "f", StartLine: 10*i + 1, EndLine: 10*i + 9, AsyncStyles: functionStyles})
+	}
+	return result
+}
+
+var (
+	awaits    = []string{ast.AsyncStyleAwait}
+	chains    = []string{ast.AsyncStylePromise}
+	callbacks = []string{ast.AsyncStyleCallback}
+)
+
+func TestMixedAsyncFile(t *testing.T) {
+	file, mixed := mixedAsyncFile(fileWithAsyncStyles("src/api.js", awaits, awaits, awaits, chains, []string{ast.AsyncStyleAwait, ast.AsyncStylePromise}, nil))
+	assert.True(t, mixed)
+	assert.Equal(t, MixedAsyncFile{FilePath: "src/api.js", AsyncAwait: 4, Promise: 2, Dominant: ast.AsyncStyleAwait, ToConvert: 1}, file,
+		"a function mixing styles counts toward each; only functions without the dominant style need converting")
+
+	file, mixed = mixedAsyncFile(fileWithAsyncStyles("src/legacy.js", callbacks, callbacks, chains))
+	assert.True(t, mixed)
+	assert.Equal(t, ast.AsyncStyleCallback, file.Dominant)
+	assert.Equal(t, 1, file.ToConvert)
+
+	_, mixed = mixedAsyncFile(fileWithAsyncStyles("src/db.js", awaits, awaits, nil))
+	assert.False(t, mixed, "one style throughout")
+}
+
+func TestMixedAsyncFile_PromiseChainWithCatch(t *testing.T) {
+	parser, err := ast.NewParser()
+	require.NoError(t, err)
+	defer parser.Close()
+
+	result, err := parser.ParseFile(context.Background(), "src/api.js", []byte(`function load() {
+  return api.get('/x').then(r => r.data).catch(err => {
+    console.error(err);
+  });
+}`))
+	require.NoError(t, err)
+
+	_, mixed := mixedAsyncFile(result)
+	assert.False(t, mixed, "a .catch(err => ...) handler is part of the promise chain, not a callback")
+}
+
+func TestAnalyzeMixedAsyncStyles(t *testing.T) {
+	parseResults := []*ast.ParseResult{
+		fileWithAsyncStyles("src/api.js", awaits, chains),
+		fileWithAsyncStyles("src/db.js", awaits, awaits),
+		fileWithAsyncStyles("src/legacy.js", callbacks, callbacks, chains, awaits),
+	}
+
+	items := NewDebtScorer().analyzeMixedAsyncStyles(parseResults)
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "mixed_async_styles", items[0].Type)
+	assert.Equal(t, "Code Smells", items[0].Category)
+	assert.Equal(t, "src/api.js", items[0].FilePath)
+	assert.Equal(t, "medium", items[0].Severity)
+	assert.Equal(t, "File 'src/api.js' mixes asynchronous styles across its functions: 1 async/await, 1 promise", items[0].Description)
+
+	assert.Equal(t, "high", items[1].Severity, "all three styles")
+	assert.Equal(t, 2, items[1].Metadata["callback"])
+	assert.Equal(t, 2, items[1].Metadata["to_convert"])
+	assert.Contains(t, items[1].RemediationSteps[0], "most of its functions use callbacks")
+}
+
+func TestAnalyzeDebt_MixedAsyncFilesOnDashboard(t *testing.T) {
+	parseResults := []*ast.ParseResult{
+		fileWithAsyncStyles("src/api.js", awaits, awaits, chains),
+		fileWithAsyncStyles("src/jobs.js", awaits, chains, chains, chains, awaits),
+		fileWithAsyncStyles("src/legacy.js", callbacks, chains, awaits),
+	}
+
+	metrics, err := NewDebtScorer().AnalyzeDebt(context.Background(), parseResults, &ComplexityMetrics{}, &DuplicationMetrics{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []MixedAsyncFile{
+		{FilePath: "src/legacy.js", AsyncAwait: 1, Promise: 1, Callback: 1, Dominant: ast.AsyncStyleAwait, ToConvert: 2},
+		{FilePath: "src/jobs.js", AsyncAwait: 2, Promise: 3, Dominant: ast.AsyncStylePromise, ToConvert: 2},
+		{FilePath: "src/api.js", AsyncAwait: 2, Promise: 1, Dominant: ast.AsyncStyleAwait, ToConvert: 1},
+	}, metrics.Dashboard.MixedAsyncFiles, "worst offenders first")
+}
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// BenchmarkBaseline holds organization-wide reference scores to compare a report against.
+// Component keys match the JSON names of ComponentScores (complexity, technical_debt, ...).
+type BenchmarkBaseline struct {
+	Name              string               `yaml:"name" json:"name"`
+	OverallAverage    float64              `yaml:"overall_average" json:"overall_average,omitempty"`
+	ComponentAverages map[string]float64   `yaml:"component_averages" json:"component_averages"`
+	ComponentSamples  map[string][]float64 `yaml:"component_samples" json:"component_samples,omitempty"` // per-repo scores, enables percentiles
+}
+
+// OrgBenchmark compares the report's scores with a BenchmarkBaseline
+type OrgBenchmark struct {
+	Baseline   string               `json:"baseline"`
+	Overall    *ComponentBenchmark  `json:"overall,omitempty"`
+	Components []ComponentBenchmark `json:"components"`
+}
+
+// ComponentBenchmark compares one component score with the organization average
+type ComponentBenchmark struct {
+	Component  string   `json:"component"`
+	Score      float64  `json:"score"`
+	OrgAverage float64  `json:"org_average"`
+	Delta      float64  `json:"delta"`
+	Percentile *float64 `json:"percentile,omitempty"` // only when the baseline has samples
+	Summary    string   `json:"summary"`              // e.g. "complexity: 68, -12 vs org avg"
+}
+
+// benchmarkComponents lists component keys in report order with their score accessors
+var benchmarkComponents = []struct {
+	key   string
+	score func(ComponentScores) float64
+}{
+	{"complexity", func(s ComponentScores) float64 { return s.Complexity }},
+	{"duplication", func(s ComponentScores) float64 { return s.Duplication }},
+	{"technical_debt", func(s ComponentScores) float64 { return s.TechnicalDebt }},
+	{"coverage", func(s ComponentScores) float64 { return s.Coverage }},
+	{"performance", func(s ComponentScores) float64 { return s.Performance }},
+	{"maintainability", func(s ComponentScores) float64 { return s.Maintainability }},
+	{"security", func(s ComponentScores) float64 { return s.Security }},
+}
+
+// LoadBenchmarkBaseline reads and validates a baseline JSON file
+func LoadBenchmarkBaseline(path string) (*BenchmarkBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read benchmark baseline: %w", err)
+	}
+
+	var baseline BenchmarkBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse benchmark baseline: %w", err)
+	}
+
+	if err := baseline.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid benchmark baseline: %w", err)
+	}
+
+	return &baseline, nil
+}
+
+// Validate checks that the baseline only names known components and holds 0-100 scores
+func (b *BenchmarkBaseline) Validate() error {
+	if len(b.ComponentAverages) == 0 && b.OverallAverage == 0 {
+		return fmt.Errorf("baseline has no component averages")
+	}
+
+	known := make(map[string]bool, len(benchmarkComponents))
+	for _, component := range benchmarkComponents {
+		known[component.key] = true
+	}
+
+	for _, key := range sortedKeys(b.ComponentAverages) {
+		if !known[key] {
+			return fmt.Errorf("unknown component %q", key)
+		}
+		if value := b.ComponentAverages[key]; value < 0 || value > 100 {
+			return fmt.Errorf("average for %s must be between 0 and 100, got %.1f", key, value)
+		}
+	}
+	for _, key := range sortedKeys(b.ComponentSamples) {
+		if !known[key] {
+			return fmt.Errorf("unknown component %q in samples", key)
+		}
+	}
+	if b.OverallAverage < 0 || b.OverallAverage > 100 {
+		return fmt.Errorf("overall average must be between 0 and 100, got %.1f", b.OverallAverage)
+	}
+
+	return nil
+}
+
+// compareWithBaseline builds the benchmark section for the given scores. Components missing
+// from the baseline, or unavailable in this report (e.g. security without a scan), are left
+// out rather than compared against zero.
+func compareWithBaseline(baseline *BenchmarkBaseline, overallScore float64, scores ComponentScores) *OrgBenchmark {
+	if baseline == nil {
+		return nil
+	}
+
+	benchmark := &OrgBenchmark{
+		Baseline:   baseline.Name,
+		Components: []ComponentBenchmark{},
+	}
+
+	if baseline.OverallAverage > 0 {
+		overall := newComponentBenchmark("overall", overallScore, baseline.OverallAverage, nil)
+		benchmark.Overall = &overall
+	}
+
+	for _, component := range benchmarkComponents {
+		average, ok := baseline.ComponentAverages[component.key]
+		if !ok || !scores.isAvailable(component.key) {
+			continue
+		}
+		benchmark.Components = append(benchmark.Components,
+			newComponentBenchmark(component.key, component.score(scores), average, baseline.ComponentSamples[component.key]))
+	}
+
+	return benchmark
+}
+
+// newComponentBenchmark computes delta, optional percentile and the summary line for one component
+func newComponentBenchmark(name string, score, average float64, samples []float64) ComponentBenchmark {
+	delta := math.Round((score-average)*10) / 10
+
+	benchmark := ComponentBenchmark{
+		Component:  name,
+		Score:      score,
+		OrgAverage: average,
+		Delta:      delta,
+		Summary:    fmt.Sprintf("%s: %.0f, %+.0f vs org avg", name, score, delta),
+	}
+
+	if len(samples) > 0 {
+		percentile := scorePercentile(score, samples)
+		benchmark.Percentile = &percentile
+		benchmark.Summary += fmt.Sprintf(" (p%.0f)", percentile)
+	}
+
+	return benchmark
+}
+
+// scorePercentile returns the percentile rank of score within samples, counting ties as half
+func scorePercentile(score float64, samples []float64) float64 {
+	below, equal := 0, 0
+	for _, sample := range samples {
+		switch {
+		case sample < score:
+			below++
+		case sample == score:
+			equal++
+		}
+	}
+	return math.Round((float64(below)+0.5*float64(equal))/float64(len(samples))*1000) / 10
+}
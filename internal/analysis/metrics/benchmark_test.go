@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBaseline(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadBenchmarkBaseline(t *testing.T) {
+	path := writeBaseline(t, `{
+  "name": "acme",
+  "overall_average": 75,
+  "component_averages": {"complexity": 80, "coverage": 60},
+  "component_samples": {"complexity": [60, 70, 80, 90]}
+}`)
+
+	baseline, err := LoadBenchmarkBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", baseline.Name)
+	assert.Equal(t, 80.0, baseline.ComponentAverages["complexity"])
+	assert.Len(t, baseline.ComponentSamples["complexity"], 4)
+}
+
+func TestLoadBenchmarkBaseline_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown component": `{"component_averages": {"readability": 50}}`,
+		"out of range":      `{"component_averages": {"coverage": 150}}`,
+		"empty":             `{"name": "acme"}`,
+		"malformed":         `{`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadBenchmarkBaseline(writeBaseline(t, content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := LoadBenchmarkBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestCompareWithBaseline(t *testing.T) {
+	baseline := &BenchmarkBaseline{
+		Name:              "acme",
+		OverallAverage:    70,
+		ComponentAverages: map[string]float64{"complexity": 80, "coverage": 60},
+		ComponentSamples:  map[string][]float64{"complexity": {60, 68, 80, 90}},
+	}
+	scores := ComponentScores{Complexity: 68, Coverage: 65, Duplication: 90}
+
+	benchmark := compareWithBaseline(baseline, 72, scores)
+	require.NotNil(t, benchmark)
+	assert.Equal(t, "acme", benchmark.Baseline)
+
+	require.NotNil(t, benchmark.Overall)
+	assert.Equal(t, 2.0, benchmark.Overall.Delta)
+
+	// Only components present in the baseline, in report order
+	require.Len(t, benchmark.Components, 2)
+	complexity := benchmark.Components[0]
+	assert.Equal(t, "complexity", complexity.Component)
+	assert.Equal(t, -12.0, complexity.Delta)
+	require.NotNil(t, complexity.Percentile)
+	assert.Equal(t, 37.5, *complexity.Percentile)
+	assert.Equal(t, "complexity: 68, -12 vs org avg (p38)", complexity.Summary)
+
+	coverage := benchmark.Components[1]
+	assert.Equal(t, "coverage: 65, +5 vs org avg", coverage.Summary)
+	assert.Nil(t, coverage.Percentile)
+
+	assert.Nil(t, compareWithBaseline(nil, 72, scores))
+}
+
+func TestCompareWithBaseline_SkipsUnavailableComponents(t *testing.T) {
+	baseline := &BenchmarkBaseline{
+		Name:              "acme",
+		ComponentAverages: map[string]float64{"complexity": 80, "coverage": 60, "security": 85},
+		ComponentSamples:  map[string][]float64{"security": {70, 90}},
+	}
+	scores := ComponentScores{Complexity: 68, Unavailable: []string{"coverage", "security"}}
+
+	benchmark := compareWithBaseline(baseline, 72, scores)
+	require.NotNil(t, benchmark)
+	require.Len(t, benchmark.Components, 1, "unscored components are not compared as zero")
+	assert.Equal(t, "complexity", benchmark.Components[0].Component)
+}
+
+func TestGenerateQualityReport_Benchmark(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{
+		Benchmark: &BenchmarkBaseline{Name: "acme", ComponentAverages: map[string]float64{"maintainability": 50}},
+	})
+
+	report, err := reporter.GenerateQualityReport(context.Background(), createQualityReportFixture())
+	require.NoError(t, err)
+
+	require.NotNil(t, report.Benchmark)
+	require.Len(t, report.Benchmark.Components, 1)
+	assert.Equal(t, report.ComponentScores.Maintainability, report.Benchmark.Components[0].Score)
+}
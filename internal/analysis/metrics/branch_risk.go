@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// Branch risk scoring for untested conditional paths. Each branch starts at 1 point and gains:
+//
+//	+3  catch block
+//	+2  handles errors (throws, nests a try, calls .catch() or reject())
+//	+2  calls an imported binding or an I/O global (fetch, axios, require, ...)
+//	+1  awaits
+//	+1  lives in an exported function
+//
+// Totals map to levels: 6+ critical, 4-5 high, 2-3 medium, 1 low. A guard clause (an if
+// without else that only returns, throws, breaks or continues) is always low. Every point
+// awarded is listed in UntestedPath.RiskFactors.
+const (
+	branchRiskCritical = 6
+	branchRiskHigh     = 4
+	branchRiskMedium   = 2
+)
+
+// ioGlobals are callees that perform I/O without being imported
+var ioGlobals = map[string]bool{
+	"fetch":          true,
+	"XMLHttpRequest": true,
+	"WebSocket":      true,
+	"require":        true,
+	"axios":          true,
+}
+
+// assessBranchRisk derives a risk level and its contributing factors from the branch itself
+func assessBranchRisk(branch ast.BranchInfo, function ast.FunctionInfo, parseResult *ast.ParseResult) (string, []string) {
+	if branch.IsGuard {
+		return "low", []string{"guard clause"}
+	}
+
+	score := 1
+	factors := []string{}
+	add := func(points int, factor string) {
+		score += points
+		factors = append(factors, fmt.Sprintf("+%d %s", points, factor))
+	}
+
+	if branch.Kind == "catch" {
+		add(3, "catch block")
+	}
+	if branch.HasErrorHandling {
+		add(2, "handles errors")
+	}
+	if callee := externalCallee(branch.Callees, parseResult); callee != "" {
+		add(2, fmt.Sprintf("external call (%s)", callee))
+	}
+	if branch.HasAwait {
+		add(1, "awaits")
+	}
+	if function.IsExported {
+		add(1, "exported function")
+	}
+
+	switch {
+	case score >= branchRiskCritical:
+		return "critical", factors
+	case score >= branchRiskHigh:
+		return "high", factors
+	case score >= branchRiskMedium:
+		return "medium", factors
+	default:
+		return "low", factors
+	}
+}
+
+// externalCallee returns the first callee that is imported into the file or is an I/O global
+func externalCallee(callees []string, parseResult *ast.ParseResult) string {
+	imported := make(map[string]bool)
+	for _, imp := range parseResult.Imports {
+		if imp.LocalName != "" {
+			imported[imp.LocalName] = true
+		}
+		for _, specifier := range imp.Specifiers {
+			imported[specifier] = true
+		}
+	}
+
+	for _, callee := range callees {
+		if imported[callee] || ioGlobals[callee] {
+			return callee
+		}
+	}
+	return ""
+}
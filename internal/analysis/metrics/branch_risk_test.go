@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestAssessBranchRisk(t *testing.T) {
+	parseResult := &ast.ParseResult{
+		FilePath: "src/api.js",
+		Imports:  []ast.ImportInfo{{Source: "./db", Specifiers: []string{"db"}}},
+	}
+	internal := ast.FunctionInfo{Name: "helper"}
+	exported := ast.FunctionInfo{Name: "load", IsExported: true}
+
+	tests := []struct {
+		name     string
+		branch   ast.BranchInfo
+		function ast.FunctionInfo
+		level    string
+		factors  []string
+	}{
+		{
+			name:     "guard clause",
+			branch:   ast.BranchInfo{Kind: "if", IsGuard: true, HasErrorHandling: true},
+			function: exported,
+			level:    "low",
+			factors:  []string{"guard clause"},
+		},
+		{
+			name:     "plain branch",
+			branch:   ast.BranchInfo{Kind: "if", Callees: []string{"format"}},
+			function: internal,
+			level:    "low",
+			factors:  []string{},
+		},
+		{
+			name:     "exported branch",
+			branch:   ast.BranchInfo{Kind: "ternary"},
+			function: exported,
+			level:    "medium",
+			factors:  []string{"+1 exported function"},
+		},
+		{
+			name:     "catch block",
+			branch:   ast.BranchInfo{Kind: "catch"},
+			function: internal,
+			level:    "high",
+			factors:  []string{"+3 catch block"},
+		},
+		{
+			name:     "awaited imported call",
+			branch:   ast.BranchInfo{Kind: "if", HasAwait: true, Callees: []string{"log", "db"}},
+			function: exported,
+			level:    "high",
+			factors:  []string{"+2 external call (db)", "+1 awaits", "+1 exported function"},
+		},
+		{
+			name:     "catch that calls out",
+			branch:   ast.BranchInfo{Kind: "catch", HasErrorHandling: true, Callees: []string{"fetch"}},
+			function: exported,
+			level:    "critical",
+			factors:  []string{"+3 catch block", "+2 handles errors", "+2 external call (fetch)", "+1 exported function"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, factors := assessBranchRisk(tt.branch, tt.function, parseResult)
+			assert.Equal(t, tt.level, level)
+			assert.Equal(t, tt.factors, factors)
+		})
+	}
+}
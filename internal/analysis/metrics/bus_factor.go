@@ -0,0 +1,254 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// BusFactorAnalyzer measures how concentrated authorship is per file and per module
+type BusFactorAnalyzer struct {
+	history *GitHistoryAnalyzer
+	cache   *GitDataCache // when set, authorship is read from the cached history
+	config  BusFactorConfig
+}
+
+// BusFactorConfig defines settings for ownership analysis
+type BusFactorConfig struct {
+	OwnershipShare float64 `yaml:"ownership_share" json:"ownership_share"` // share of commits the bus-factor authors must cover, 0-1
+	ReportTopN     int     `yaml:"report_top_n" json:"report_top_n"`       // modules and files listed, riskiest first
+}
+
+// OnboardingRisk ranks modules and files by how few people know them
+type OnboardingRisk struct {
+	RiskyModules int               `json:"risky_modules"` // modules with a bus factor of 1
+	Modules      []ModuleOwnership `json:"modules"`
+	Files        []FileOwnership   `json:"files"`
+}
+
+// Ownership is the author concentration of a file or module
+type Ownership struct {
+	Commits        int     `json:"commits"`
+	Authors        int     `json:"authors"`
+	TopAuthor      string  `json:"top_author"`
+	TopAuthorShare float64 `json:"top_author_share"` // 0-100
+	BusFactor      int     `json:"bus_factor"`       // fewest authors covering OwnershipShare of commits
+	OnboardingRisk bool    `json:"onboarding_risk"`  // bus factor of 1
+}
+
+// FileOwnership is the author concentration of a single file
+type FileOwnership struct {
+	FilePath string `json:"file_path"`
+	Ownership
+}
+
+// ModuleOwnership is the author concentration of a directory
+type ModuleOwnership struct {
+	Module string `json:"module"`
+	Files  int    `json:"files"`
+	Ownership
+	Summary string `json:"summary"` // e.g. "src/billing: 92% of 48 commits by alice"
+}
+
+// NewBusFactorAnalyzer creates a bus factor analyzer for the repository at repoPath with default configuration
+func NewBusFactorAnalyzer(repoPath string) *BusFactorAnalyzer {
+	return &BusFactorAnalyzer{
+		history: NewGitHistoryAnalyzer(repoPath),
+		config: BusFactorConfig{
+			OwnershipShare: 0.8,
+			ReportTopN:     20,
+		},
+	}
+}
+
+// NewBusFactorAnalyzerWithConfig creates a bus factor analyzer with custom configuration
+func NewBusFactorAnalyzerWithConfig(repoPath string, config BusFactorConfig) *BusFactorAnalyzer {
+	return &BusFactorAnalyzer{
+		history: NewGitHistoryAnalyzer(repoPath),
+		config:  config,
+	}
+}
+
+// NewBusFactorAnalyzerWithCache creates a bus factor analyzer reading the history held by
+// cache, with default configuration
+func NewBusFactorAnalyzerWithCache(cache *GitDataCache) *BusFactorAnalyzer {
+	analyzer := NewBusFactorAnalyzer(cache.history.repoPath)
+	analyzer.cache = cache
+	return analyzer
+}
+
+// AnalyzeBusFactor counts commits per author for each of files (slash-separated paths relative
+// to the repository root) and aggregates them per directory. Authorship is taken from the full
+// commit history, so a shallow clone makes every file look single-owner.
+func (bf *BusFactorAnalyzer) AnalyzeBusFactor(ctx context.Context, files []string) (*OnboardingRisk, error) {
+	commits, err := bf.authorCommits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bf.assessOwnership(commits, files), nil
+}
+
+// authorCommits returns commit counts per file and author, from the cache when there is one
+func (bf *BusFactorAnalyzer) authorCommits(ctx context.Context) (map[string]map[string]int, error) {
+	if bf.cache != nil {
+		return bf.cache.authorCommits(ctx)
+	}
+
+	output, err := bf.history.log(ctx, "--format=author %aN")
+	if err != nil {
+		return nil, err
+	}
+	return parseGitLogAuthors(output)
+}
+
+// parseGitLogAuthors reads "author <name>" headers each followed by changed file names
+// and returns commit counts per file and author
+func parseGitLogAuthors(output []byte) (map[string]map[string]int, error) {
+	commits := make(map[string]map[string]int)
+	author := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(line, "author "); ok {
+			author = strings.TrimSpace(name)
+			continue
+		}
+		if author == "" {
+			return nil, fmt.Errorf("file %q listed before any author", line)
+		}
+
+		file := ast.NormalizePath(line)
+		if commits[file] == nil {
+			commits[file] = make(map[string]int)
+		}
+		commits[file][author]++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git log output: %w", err)
+	}
+
+	return commits, nil
+}
+
+// assessOwnership builds the ranked onboarding-risk section. Files without history
+// (e.g. generated in the working tree) are left out.
+func (bf *BusFactorAnalyzer) assessOwnership(commits map[string]map[string]int, files []string) *OnboardingRisk {
+	risk := &OnboardingRisk{
+		Modules: []ModuleOwnership{},
+		Files:   []FileOwnership{},
+	}
+
+	moduleCommits := make(map[string]map[string]int)
+	moduleFiles := make(map[string]int)
+
+	for _, file := range files {
+		file = ast.NormalizePath(file)
+		authors, ok := commits[file]
+		if !ok {
+			continue
+		}
+
+		risk.Files = append(risk.Files, FileOwnership{FilePath: file, Ownership: bf.ownership(authors)})
+
+		module := path.Dir(file)
+		if moduleCommits[module] == nil {
+			moduleCommits[module] = make(map[string]int)
+		}
+		for author, count := range authors {
+			moduleCommits[module][author] += count
+		}
+		moduleFiles[module]++
+	}
+
+	for _, module := range sortedKeys(moduleCommits) {
+		ownership := bf.ownership(moduleCommits[module])
+		if ownership.OnboardingRisk {
+			risk.RiskyModules++
+		}
+		risk.Modules = append(risk.Modules, ModuleOwnership{
+			Module:    module,
+			Files:     moduleFiles[module],
+			Ownership: ownership,
+			Summary: fmt.Sprintf("%s: %.0f%% of %d commits by %s",
+				module, ownership.TopAuthorShare, ownership.Commits, ownership.TopAuthor),
+		})
+	}
+
+	sort.SliceStable(risk.Modules, func(i, j int) bool {
+		return ownershipRiskier(risk.Modules[i].Ownership, risk.Modules[j].Ownership)
+	})
+	sort.SliceStable(risk.Files, func(i, j int) bool {
+		return ownershipRiskier(risk.Files[i].Ownership, risk.Files[j].Ownership)
+	})
+
+	if bf.config.ReportTopN > 0 {
+		if len(risk.Modules) > bf.config.ReportTopN {
+			risk.Modules = risk.Modules[:bf.config.ReportTopN]
+		}
+		if len(risk.Files) > bf.config.ReportTopN {
+			risk.Files = risk.Files[:bf.config.ReportTopN]
+		}
+	}
+
+	return risk
+}
+
+// ownership computes author concentration from commit counts per author
+func (bf *BusFactorAnalyzer) ownership(authors map[string]int) Ownership {
+	names := sortedKeys(authors)
+	sort.SliceStable(names, func(i, j int) bool {
+		return authors[names[i]] > authors[names[j]]
+	})
+
+	total := 0
+	for _, count := range authors {
+		total += count
+	}
+
+	ownership := Ownership{
+		Commits: total,
+		Authors: len(names),
+	}
+	if total == 0 {
+		return ownership
+	}
+
+	ownership.TopAuthor = names[0]
+	ownership.TopAuthorShare = float64(authors[names[0]]) / float64(total) * 100
+
+	covered := 0
+	for _, name := range names {
+		covered += authors[name]
+		ownership.BusFactor++
+		if float64(covered) >= bf.config.OwnershipShare*float64(total) {
+			break
+		}
+	}
+	ownership.OnboardingRisk = ownership.BusFactor == 1
+
+	return ownership
+}
+
+// ownershipRiskier orders by bus factor, then by top-author share and commit volume,
+// so heavily changed single-owner code comes first
+func ownershipRiskier(a, b Ownership) bool {
+	if a.BusFactor != b.BusFactor {
+		return a.BusFactor < b.BusFactor
+	}
+	if a.TopAuthorShare != b.TopAuthorShare {
+		return a.TopAuthorShare > b.TopAuthorShare
+	}
+	return a.Commits > b.Commits
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitLogAuthors(t *testing.T) {
+	output := []byte("author alice\n\nsrc/a.js\nsrc/b.js\n\nauthor bob\n\nsrc/a.js\n\nauthor alice\n\nsrc/a.js\n")
+
+	commits, err := parseGitLogAuthors(output)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"alice": 2, "bob": 1}, commits["src/a.js"])
+	assert.Equal(t, map[string]int{"alice": 1}, commits["src/b.js"])
+
+	_, err = parseGitLogAuthors([]byte("src/orphan.js\n"))
+	assert.Error(t, err)
+}
+
+func TestAssessOwnership_RanksSingleOwnerModulesFirst(t *testing.T) {
+	commits := map[string]map[string]int{
+		"src/billing/invoice.js": {"alice": 9, "bob": 1},
+		"src/billing/tax.js":     {"alice": 3},
+		"src/ui/button.js":       {"alice": 2, "bob": 2, "carol": 2},
+		"src/ui/form.js":         {"bob": 3, "carol": 1},
+	}
+	files := []string{"src/billing/invoice.js", "src/billing/tax.js", "src/ui/button.js", "src/ui/form.js", "src/generated.js"}
+
+	risk := NewBusFactorAnalyzer("").assessOwnership(commits, files)
+
+	require.Len(t, risk.Modules, 2)
+	assert.Equal(t, 1, risk.RiskyModules)
+
+	billing := risk.Modules[0]
+	assert.Equal(t, "src/billing", billing.Module)
+	assert.Equal(t, 2, billing.Files)
+	assert.Equal(t, 13, billing.Commits)
+	assert.Equal(t, "alice", billing.TopAuthor)
+	assert.Equal(t, 1, billing.BusFactor)
+	assert.True(t, billing.OnboardingRisk)
+	assert.Equal(t, "src/billing: 92% of 13 commits by alice", billing.Summary)
+
+	ui := risk.Modules[1]
+	assert.Equal(t, 2, ui.BusFactor, "bob and carol made 8 of 10 commits")
+	assert.False(t, ui.OnboardingRisk)
+
+	require.Len(t, risk.Files, 4, "files without history are skipped")
+	assert.Equal(t, "src/billing/tax.js", risk.Files[0].FilePath)
+	assert.Equal(t, 100.0, risk.Files[0].TopAuthorShare)
+}
+
+func TestBusFactorAnalyzer_AnalyzeBusFactor(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", repo).Run())
+
+	date := time.Now().AddDate(0, -1, 0)
+	commitFileAs(t, repo, "alice", "core/engine.js", "var a = 1;\n", date)
+	commitFileAs(t, repo, "alice", "core/engine.js", "var a = 2;\n", date)
+	commitFileAs(t, repo, "bob", "web/app.js", "var b = 1;\n", date)
+	commitFileAs(t, repo, "carol", "web/app.js", "var b = 2;\n", date)
+
+	risk, err := NewBusFactorAnalyzer(repo).AnalyzeBusFactor(context.Background(), []string{"core/engine.js", "web/app.js"})
+	require.NoError(t, err)
+
+	require.Len(t, risk.Modules, 2)
+	assert.Equal(t, "core", risk.Modules[0].Module)
+	assert.Equal(t, 1, risk.Modules[0].BusFactor)
+	assert.Equal(t, "web", risk.Modules[1].Module)
+	assert.Equal(t, 2, risk.Modules[1].BusFactor)
+	assert.Equal(t, 1, risk.RiskyModules)
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// loopCall is the call that makes a function run once per iteration of a loop
+type loopCall struct {
+	Caller string // function making the call, "" at the top level of the file
+	Line   int
+	Via    bool // the caller is itself called in a loop rather than looping around the call
+}
+
+// evidence describes how the function is reached from a loop
+func (call loopCall) evidence() string {
+	caller := "the top level"
+	if call.Caller != "" {
+		caller = call.Caller
+	}
+	if call.Via {
+		return fmt.Sprintf("called by %s (line %d), which itself runs in a loop", caller, call.Line)
+	}
+	return fmt.Sprintf("called in a loop in %s (line %d)", caller, call.Line)
+}
+
+// fileCallGraph is the call graph between the functions declared in one file. Calls are
+// resolved by name only; calls into other files are not followed.
+type fileCallGraph struct {
+	callSites map[string]int      // in-file call sites per declared function
+	loopCalls map[string]loopCall // declared functions reached from a loop, with the call that does it
+}
+
+// graphFunction is a named function of the file with its line span
+type graphFunction struct {
+	name       string
+	start, end int
+}
+
+// buildFileCallGraph resolves the calls of a parse result to the functions and methods the
+// file declares, and marks every function called inside a loop, or called by a function
+// that is, as running in a loop
+func buildFileCallGraph(result *ast.ParseResult) *fileCallGraph {
+	graph := &fileCallGraph{callSites: make(map[string]int), loopCalls: make(map[string]loopCall)}
+
+	var functions []graphFunction
+	declared := make(map[string]bool)
+	add := func(function ast.FunctionInfo) {
+		// An arrow function has no name of its own: the identifier of x => ... is its parameter
+		if function.Name != "" && function.Metadata["node_type"] != "arrow_function" {
+			functions = append(functions, graphFunction{name: function.Name, start: function.StartLine, end: function.EndLine})
+			declared[function.Name] = true
+		}
+	}
+	for _, function := range result.Functions {
+		add(function)
+	}
+	for _, class := range result.Classes {
+		for _, method := range class.Methods {
+			add(method)
+		}
+	}
+
+	// callsBy lists the in-file calls made inside each function, nested functions included
+	callsBy := make(map[string][]ast.CallInfo)
+	var queue []string
+	for _, call := range result.Calls {
+		// Plain and this.method() calls; calls on other receivers reach other objects
+		if !declared[call.Callee] || call.Receiver != "" {
+			continue
+		}
+		graph.callSites[call.Callee]++
+
+		caller := enclosingFunction(functions, call.Line)
+		if caller != "" {
+			callsBy[caller] = append(callsBy[caller], call)
+		}
+		if _, marked := graph.loopCalls[call.Callee]; call.InLoop && !marked && call.Callee != caller {
+			graph.loopCalls[call.Callee] = loopCall{Caller: caller, Line: call.Line}
+			queue = append(queue, call.Callee)
+		}
+	}
+
+	// Whatever a function running in a loop calls runs in the loop too
+	for len(queue) > 0 {
+		caller := queue[0]
+		queue = queue[1:]
+		for _, call := range callsBy[caller] {
+			if _, marked := graph.loopCalls[call.Callee]; !marked && call.Callee != caller {
+				graph.loopCalls[call.Callee] = loopCall{Caller: caller, Line: call.Line, Via: true}
+				queue = append(queue, call.Callee)
+			}
+		}
+	}
+
+	return graph
+}
+
+// enclosingFunction returns the innermost named function spanning line, "" outside of any
+func enclosingFunction(functions []graphFunction, line int) string {
+	name, span := "", -1
+	for _, function := range functions {
+		if line < function.start || line > function.end {
+			continue
+		}
+		if span < 0 || function.end-function.start < span {
+			name, span = function.name, function.end-function.start
+		}
+	}
+	return name
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectNPlusOneQueriesAST_CallGraph(t *testing.T) {
+	files := map[string]string{"src/orders.js": `function findOrderById(id) {
+  return db.query(id);
+}
+
+function renderOrders(list) {
+  return list.map(id => findOrderById(id));
+}
+
+function findUserById(id) {
+  return db.users.get(id);
+}
+
+function showUser(id) {
+  return findUserById(id);
+}
+
+function syncAll(entries) {
+  for (const entry of entries) {
+    enrich(entry);
+  }
+}
+
+function enrich(entry) {
+  return fetchPrice(entry);
+}
+
+function fetchPrice(entry) {
+  return api.get(entry.sku);
+}
+`}
+
+	parseResults, _, err := NewQualityReporter(QualityReportConfig{}).parseFiles(files)
+	require.NoError(t, err)
+	require.Len(t, parseResults, 1)
+
+	graph := buildFileCallGraph(parseResults[0])
+	assert.Equal(t, loopCall{Caller: "renderOrders", Line: 6}, graph.loopCalls["findOrderById"])
+	assert.Equal(t, loopCall{Caller: "enrich", Line: 24, Via: true}, graph.loopCalls["fetchPrice"])
+	assert.NotContains(t, graph.loopCalls, "findUserById")
+
+	metrics := &PerformanceMetrics{AntiPatterns: []AntiPattern{}}
+	NewPerformanceAnalyzer().detectNPlusOneQueriesAST(parseResults[0], metrics)
+
+	evidence := make(map[string]string)
+	for _, antiPattern := range metrics.AntiPatterns {
+		if antiPattern.Type == "n_plus_one_query" {
+			evidence[antiPattern.Description] = antiPattern.Evidence
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"Potential N+1 query pattern in function 'findOrderById'": "Function findOrderById contains query patterns and is called in a loop in renderOrders (line 6)",
+		"Potential N+1 query pattern in function 'fetchPrice'":    "Function fetchPrice contains query patterns and is called by enrich (line 24), which itself runs in a loop",
+	}, evidence, "findUserById is only called outside loops, whatever its name suggests")
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// defaultCallbackDepthThreshold is used when DebtScoringConfig.CallbackDepthThreshold is unset
+const defaultCallbackDepthThreshold = 3
+
+// analyzeCallbackPyramids reports functions whose callbacks and closures nest deeper than
+// the threshold. Only the outermost function of a pyramid is reported; the callbacks inside
+// it would otherwise be flagged again at each level.
+func (ds *DebtScorer) analyzeCallbackPyramids(parseResults []*ast.ParseResult) []TechnicalDebtItem {
+	threshold := ds.config.CallbackDepthThreshold
+	if threshold <= 0 {
+		threshold = defaultCallbackDepthThreshold
+	}
+
+	items := []TechnicalDebtItem{}
+	itemID := 0
+
+	for _, parseResult := range parseResults {
+		var pyramids []ast.FunctionInfo
+		for _, function := range parseResult.Functions {
+			if function.CallbackDepth > threshold {
+				pyramids = append(pyramids, function)
+			}
+		}
+
+		for _, function := range pyramids {
+			if nestedInAny(function, pyramids) {
+				continue
+			}
+
+			name := function.Name
+			if name == "" {
+				name = "anonymous function"
+			}
+
+			severity := "medium"
+			if function.CallbackDepth > threshold+2 {
+				severity = "high"
+			}
+
+			items = append(items, TechnicalDebtItem{
+				ID:             fmt.Sprintf("callback_pyramid_%d", itemID),
+				Type:           "callback_pyramid",
+				Category:       "Code Smells",
+				FilePath:       parseResult.FilePath,
+				FunctionName:   function.Name,
+				StartLine:      function.StartLine,
+				EndLine:        function.EndLine,
+				Description:    fmt.Sprintf("'%s' nests callbacks %d levels deep (deepest at line %d)", name, function.CallbackDepth, function.DeepestCallbackLine),
+				Severity:       severity,
+				EstimatedHours: float64(function.CallbackDepth-threshold) + 1.0, // 1 hour plus 1 per level over the threshold
+				RemediationSteps: []string{
+					"Convert callback-based APIs to promises (util.promisify or a small wrapper)",
+					"Rewrite the nested callbacks as sequential async/await steps",
+					"Extract the inner callbacks into named functions",
+					"Verify error handling still covers every step",
+				},
+				Metadata: map[string]interface{}{
+					"depth":         function.CallbackDepth,
+					"deepest_line":  function.DeepestCallbackLine,
+					"threshold":     threshold,
+					"is_async":      function.IsAsync,
+					"function_name": name,
+				},
+			})
+			itemID++
+		}
+	}
+
+	return items
+}
+
+// nestedInAny reports whether function lies strictly inside one of functions
+func nestedInAny(function ast.FunctionInfo, functions []ast.FunctionInfo) bool {
+	for _, outer := range functions {
+		if outer.StartLine == function.StartLine && outer.EndLine == function.EndLine {
+			continue
+		}
+		if outer.StartLine <= function.StartLine && outer.EndLine >= function.EndLine {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestAnalyzeCallbackPyramids(t *testing.T) {
+	parseResults := []*ast.ParseResult{{
+		FilePath: "src/sync.js",
+		Functions: []ast.FunctionInfo{
+			{Name: "syncAll", StartLine: 1, EndLine: 30, CallbackDepth: 6, DeepestCallbackLine: 12},
+			{StartLine: 3, EndLine: 28, CallbackDepth: 5, DeepestCallbackLine: 12}, // first callback inside syncAll
+			{Name: "shallow", StartLine: 40, EndLine: 50, CallbackDepth: 3, DeepestCallbackLine: 44},
+			{StartLine: 60, EndLine: 80, CallbackDepth: 4, DeepestCallbackLine: 70},
+		},
+	}}
+
+	items := NewDebtScorer().analyzeCallbackPyramids(parseResults)
+
+	require.Len(t, items, 2, "nested callbacks are reported once at the outermost function")
+	assert.Equal(t, "callback_pyramid", items[0].Type)
+	assert.Equal(t, "Code Smells", items[0].Category)
+	assert.Equal(t, "syncAll", items[0].FunctionName)
+	assert.Equal(t, "high", items[0].Severity)
+	assert.Equal(t, 6, items[0].Metadata["depth"])
+	assert.Equal(t, 12, items[0].Metadata["deepest_line"])
+	assert.Contains(t, items[0].Description, "6 levels deep (deepest at line 12)")
+
+	assert.Equal(t, "medium", items[1].Severity)
+	assert.Contains(t, items[1].Description, "'anonymous function'")
+}
+
+func TestAnalyzeCallbackPyramids_Threshold(t *testing.T) {
+	parseResults := []*ast.ParseResult{{
+		FilePath:  "src/a.js",
+		Functions: []ast.FunctionInfo{{Name: "f", StartLine: 1, EndLine: 10, CallbackDepth: 3}},
+	}}
+
+	assert.Empty(t, NewDebtScorer().analyzeCallbackPyramids(parseResults))
+	assert.Len(t, NewDebtScorerWithConfig(DebtScoringConfig{CallbackDepthThreshold: 2}).analyzeCallbackPyramids(parseResults), 1)
+}
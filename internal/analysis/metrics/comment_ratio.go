@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// maxRewardedCommentRatio caps the comment ratio the maintainability index rewards, so a
+// function buried in comments scores no better than a well-commented one
+const maxRewardedCommentRatio = 0.25
+
+// undocumentedComplexity is the cyclomatic complexity above which a function without a
+// single comment line is flagged as undocumented
+const undocumentedComplexity = 10
+
+// functionComments counts the comment lines that document a function: its doc comment and
+// the comments in its body
+type functionComments struct {
+	commentLines      int // prose comment lines; blank and separator lines are not counted
+	commentedOutLines int // lines of commented-out code, which never count as documentation
+	codeLines         int // lines of the function not covered by comments
+}
+
+// ratio is the number of comment lines per line of code
+func (fc functionComments) ratio() float64 {
+	if fc.codeLines <= 0 {
+		return 0
+	}
+	return float64(fc.commentLines) / float64(fc.codeLines)
+}
+
+// countFunctionComments counts the comment lines of function among the comments of its
+// file, which are in source order. Blocks that read as commented-out code are counted apart
+// with the rules of the commented-out code detector, so disabled code is not mistaken for
+// documentation.
+func countFunctionComments(function ast.FunctionInfo, comments []ast.CommentInfo) functionComments {
+	var own []ast.CommentInfo
+	coveredLines := 0
+
+	// The doc comment is the run of comments ending on the line above the function
+	leadingStart := function.StartLine
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+		if comment.EndLine == leadingStart-1 {
+			own = append(own, comment)
+			leadingStart = comment.StartLine
+		}
+	}
+	for _, comment := range comments {
+		if comment.StartLine >= function.StartLine && comment.EndLine <= function.EndLine {
+			own = append(own, comment)
+			coveredLines += comment.EndLine - comment.StartLine + 1
+		}
+	}
+
+	counts := functionComments{codeLines: function.EndLine - function.StartLine + 1 - coveredLines}
+	if counts.codeLines < 1 {
+		counts.codeLines = 1
+	}
+
+	var inline []ast.CommentInfo
+	for _, comment := range own {
+		text := strings.TrimSpace(comment.Text)
+		if !strings.HasPrefix(text, "/**") {
+			inline = append(inline, comment)
+			continue
+		}
+		// Doc comments hold code samples legitimately; every worded line documents
+		for _, line := range strings.Split(text, "\n") {
+			if hasWords(line) {
+				counts.commentLines++
+			}
+		}
+	}
+	sort.SliceStable(inline, func(i, j int) bool { return inline[i].StartLine < inline[j].StartLine })
+
+	for _, block := range groupCommentBlocks(inline) {
+		worded := 0
+		for _, line := range block.lines {
+			if hasWords(line) {
+				worded++
+			}
+		}
+		if _, commentedOut := commentedCodeLines(block, 1); commentedOut {
+			counts.commentedOutLines += worded
+		} else {
+			counts.commentLines += worded
+		}
+	}
+
+	return counts
+}
+
+// hasWords reports whether a comment line has any letter or digit, unlike blank and
+// separator lines such as "// -----"
+func hasWords(line string) bool {
+	return strings.IndexFunc(line, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0
+}
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// defaultCommentedCodeMinLines is used when DebtScoringConfig.CommentedCodeMinLines is unset
+const defaultCommentedCodeMinLines = 3
+
+// commentedCodeRatio is the share of non-blank lines in a comment block that must look like code
+const commentedCodeRatio = 0.6
+
+// codeLinePatterns match a single uncommented line that reads as JavaScript/TypeScript rather than prose
+var codeLinePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[;{]\s*$`),                                              // statement or block terminator
+	regexp.MustCompile(`^[})\]]+[;,)]*\s*$`),                                    // closing braces
+	regexp.MustCompile(`^(const|let|var)\s+[\w${}\[\], ]+\s*(:[^=]+)?=`),        // declarations
+	regexp.MustCompile(`^(if|for|while|switch|catch)\s*\(`),                     // control flow
+	regexp.MustCompile(`^(return|throw|await|yield)\b.*[;)]\s*$`),               // statements
+	regexp.MustCompile(`^(import|export)\s.*(from\s+['"]|[{=]|function|class)`), // module syntax
+	regexp.MustCompile(`^(async\s+)?function\b\s*[\w$]*\s*\(`),                  // function header
+	regexp.MustCompile(`^[\w$]+(\.[\w$]+|\[[^\]]*\])*\s*[-+*/|&]?=\s*[^=\s]`),   // assignment
+	regexp.MustCompile(`^[\w$]+(\.[\w$]+)*\([^)]*\)?[;,]?\s*$`),                 // call
+	regexp.MustCompile(`=>`),                                                    // arrow function
+}
+
+// licenseMarkers identify license and copyright headers, which are never commented-out code
+var licenseMarkers = []string{"copyright", "license", "spdx-", "all rights reserved"}
+
+// commentBlock is a run of adjacent comment lines treated as one unit
+type commentBlock struct {
+	startLine int
+	endLine   int
+	lines     []string // comment text with the comment markers removed
+}
+
+// analyzeCommentedOutCode reports comment blocks that are mostly code. Doc comments
+// (/** ... */) and license headers are skipped since they legitimately contain code samples.
+func (ds *DebtScorer) analyzeCommentedOutCode(parseResults []*ast.ParseResult) []TechnicalDebtItem {
+	minLines := ds.config.CommentedCodeMinLines
+	if minLines <= 0 {
+		minLines = defaultCommentedCodeMinLines
+	}
+
+	items := []TechnicalDebtItem{}
+	itemID := 0
+
+	for _, parseResult := range parseResults {
+		for _, block := range groupCommentBlocks(parseResult.Comments) {
+			codeLines, ok := commentedCodeLines(block, minLines)
+			if !ok {
+				continue
+			}
+
+			severity := "low"
+			if codeLines >= minLines*4 {
+				severity = "medium"
+			}
+
+			items = append(items, TechnicalDebtItem{
+				ID:             fmt.Sprintf("commented_code_%d", itemID),
+				Type:           "commented_out_code",
+				Category:       "Code Smells",
+				FilePath:       parseResult.FilePath,
+				StartLine:      block.startLine,
+				EndLine:        block.endLine,
+				Description:    fmt.Sprintf("%d lines of commented-out code (lines %d-%d)", codeLines, block.startLine, block.endLine),
+				Severity:       severity,
+				EstimatedHours: 0.25, // deleting is quick; version control keeps the history
+				RemediationSteps: []string{
+					"Confirm the code is not needed or is preserved in version control",
+					"Delete the commented-out block",
+					"Move any still-relevant explanation into a regular comment or issue",
+				},
+				Metadata: map[string]interface{}{
+					"code_lines":  codeLines,
+					"block_lines": block.endLine - block.startLine + 1,
+				},
+			})
+			itemID++
+		}
+	}
+
+	return items
+}
+
+// groupCommentBlocks merges consecutive // comments on adjacent lines into blocks.
+// A /* */ comment forms its own block; /** */ doc comments are dropped.
+func groupCommentBlocks(comments []ast.CommentInfo) []commentBlock {
+	var blocks []commentBlock
+	var current *commentBlock
+
+	for _, comment := range comments {
+		text := strings.TrimSpace(comment.Text)
+
+		if strings.HasPrefix(text, "//") {
+			line := strings.TrimPrefix(text, "//")
+			if current != nil && comment.StartLine == current.endLine+1 {
+				current.endLine = comment.EndLine
+				current.lines = append(current.lines, line)
+				continue
+			}
+			blocks = append(blocks, commentBlock{startLine: comment.StartLine, endLine: comment.EndLine, lines: []string{line}})
+			current = &blocks[len(blocks)-1]
+			continue
+		}
+
+		current = nil
+		if strings.HasPrefix(text, "/**") {
+			continue
+		}
+
+		body := strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+		var lines []string
+		for _, line := range strings.Split(body, "\n") {
+			lines = append(lines, strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		}
+		blocks = append(blocks, commentBlock{startLine: comment.StartLine, endLine: comment.EndLine, lines: lines})
+	}
+
+	return blocks
+}
+
+// commentedCodeLines counts code-like lines in block and reports whether the block
+// qualifies as commented-out code
+func commentedCodeLines(block commentBlock, minLines int) (int, bool) {
+	nonBlank, codeLines := 0, 0
+	for _, line := range block.lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		lower := strings.ToLower(line)
+		for _, marker := range licenseMarkers {
+			if strings.Contains(lower, marker) {
+				return 0, false
+			}
+		}
+
+		nonBlank++
+		if isCodeLine(line) {
+			codeLines++
+		}
+	}
+
+	if codeLines < minLines || float64(codeLines) < float64(nonBlank)*commentedCodeRatio {
+		return codeLines, false
+	}
+	return codeLines, true
+}
+
+// isCodeLine reports whether a single comment line parses as code
+func isCodeLine(line string) bool {
+	for _, pattern := range codeLinePatterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
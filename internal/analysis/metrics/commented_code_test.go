@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func lineComments(startLine int, lines ...string) []ast.CommentInfo {
+	comments := make([]ast.CommentInfo, 0, len(lines))
+	for i, line := range lines {
+		comments = append(comments, ast.CommentInfo{Text: "// " + line, StartLine: startLine + i, EndLine: startLine + i})
+	}
+	return comments
+}
+
+func TestAnalyzeCommentedOutCode_FlagsCodeBlocks(t *testing.T) {
+	comments := lineComments(10,
+		"const user = await loadUser(id);",
+		"if (!user) {",
+		"  throw new Error('missing');",
+		"}",
+		"cache.set(id, user);",
+	)
+	comments = append(comments, ast.CommentInfo{
+		Text:      "/*\nfunction legacy(a, b) {\n  return a + b;\n}\n*/",
+		StartLine: 30,
+		EndLine:   34,
+	})
+
+	items := NewDebtScorer().analyzeCommentedOutCode([]*ast.ParseResult{{FilePath: "src/user.js", Comments: comments}})
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "commented_out_code", items[0].Type)
+	assert.Equal(t, "Code Smells", items[0].Category)
+	assert.Equal(t, "src/user.js", items[0].FilePath)
+	assert.Equal(t, 10, items[0].StartLine)
+	assert.Equal(t, 14, items[0].EndLine)
+	assert.Equal(t, 5, items[0].Metadata["code_lines"])
+	assert.Equal(t, 30, items[1].StartLine)
+}
+
+func TestAnalyzeCommentedOutCode_IgnoresProse(t *testing.T) {
+	comments := lineComments(1,
+		"This module keeps the session cache warm.",
+		"If the user is not logged in we fall back to the guest profile,",
+		"which is cheaper to build and never expires.",
+		"Return early when the request was canceled.",
+	)
+
+	items := NewDebtScorer().analyzeCommentedOutCode([]*ast.ParseResult{{FilePath: "src/session.js", Comments: comments}})
+
+	assert.Empty(t, items)
+}
+
+func TestAnalyzeCommentedOutCode_IgnoresLicenseHeaders(t *testing.T) {
+	header := ast.CommentInfo{
+		Text: `/*
+ * Copyright (c) 2024 Example Corp.
+ * Licensed under the Apache License, Version 2.0;
+ * you may not use this file except in compliance with the License.
+ * var x = require('license-checker');
+ */`,
+		StartLine: 1,
+		EndLine:   6,
+	}
+	spdx := lineComments(8,
+		"SPDX-License-Identifier: MIT",
+		"const a = 1;",
+		"const b = 2;",
+		"const c = 3;",
+	)
+
+	items := NewDebtScorer().analyzeCommentedOutCode([]*ast.ParseResult{{
+		FilePath: "src/index.js",
+		Comments: append([]ast.CommentInfo{header}, spdx...),
+	}})
+
+	assert.Empty(t, items)
+}
+
+func TestAnalyzeCommentedOutCode_RespectsThresholdAndDocComments(t *testing.T) {
+	comments := lineComments(1, "counter++;", "save(counter);")
+	comments = append(comments, ast.CommentInfo{
+		Text:      "/**\n * @example\n * const a = add(1, 2);\n * const b = add(a, 3);\n * console.log(b);\n */",
+		StartLine: 5,
+		EndLine:   10,
+	})
+
+	scorer := NewDebtScorer()
+	assert.Empty(t, scorer.analyzeCommentedOutCode([]*ast.ParseResult{{FilePath: "a.js", Comments: comments}}))
+
+	scorer = NewDebtScorerWithConfig(DebtScoringConfig{CommentedCodeMinLines: 2})
+	items := scorer.analyzeCommentedOutCode([]*ast.ParseResult{{FilePath: "a.js", Comments: comments}})
+	require.Len(t, items, 1)
+	assert.Equal(t, 1, items[0].StartLine)
+}
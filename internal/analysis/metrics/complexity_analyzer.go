@@ -24,6 +24,11 @@ type ComplexityConfig struct {
 	TrendPeriods    int     `yaml:"trend_periods" json:"trend_periods"`
 	EnableTrends    bool    `yaml:"enable_trends" json:"enable_trends"`
 	WeightFactors   Weights `yaml:"weight_factors" json:"weight_factors"`
+	FanInWeight     float64 `yaml:"fan_in_weight" json:"fan_in_weight"` // priority boost per doubling of callers; 0 ranks by complexity alone
+
+	// TestFilePatterns mark the files whose calls count as test fan-in; empty uses
+	// DefaultTestFilePatterns
+	TestFilePatterns []string `yaml:"test_file_patterns" json:"test_file_patterns,omitempty"`
 }
 
 // Weights for different complexity factors
@@ -48,6 +53,7 @@ type ComplexityMetrics struct {
 	TrendAnalysis     *ComplexityTrend           `json:"trend_analysis,omitempty"`
 	Recommendations   []ComplexityRecommendation `json:"recommendations"`
 	Summary           ComplexitySummary          `json:"summary"`
+	Accepted          []AcceptedComplexity       `json:"accepted_complexity,omitempty"` // functions whose complexity was accepted, for auditing
 }
 
 // ComplexityBreakdown categorizes functions by complexity level
@@ -80,7 +86,14 @@ type FunctionComplexity struct {
 	Recommendations   []string               `json:"recommendations"`
 	RefactoringRisk   string                 `json:"refactoring_risk"`
 	TestingDifficulty string                 `json:"testing_difficulty"`
+	FanIn             int                    `json:"fan_in"`        // calls from non-test code, matched by name
+	TestFanIn         int                    `json:"test_fan_in"`   // calls from test files
+	EntryPoint        bool                   `json:"entry_point"`   // exported or in an entry module, with no callers in the repository
+	CommentLines      int                    `json:"comment_lines"` // doc and inline comment lines, commented-out code excluded
+	CommentRatio      float64                `json:"comment_ratio"` // comment lines per line of code
 	Metadata          map[string]interface{} `json:"metadata"`
+	Accepted          bool                   `json:"accepted,omitempty"`        // annotated with repo-onboarding-accept-complexity
+	AcceptedReason    string                 `json:"accepted_reason,omitempty"` // reason given with the annotation
 }
 
 // ComplexityFactors details what contributes to complexity
@@ -161,6 +174,7 @@ func NewComplexityAnalyzer() *ComplexityAnalyzer {
 			ReportTopN:      20,
 			TrendPeriods:    5,
 			EnableTrends:    true,
+			FanInWeight:     0.5,
 			WeightFactors: Weights{
 				Cyclomatic:   0.4,
 				Cognitive:    0.3,
@@ -205,8 +219,12 @@ func (ca *ComplexityAnalyzer) AnalyzeComplexity(ctx context.Context, parseResult
 		}
 	}
 
+	// Count callers across files so widely used functions can be prioritized
+	ca.attachFanIn(metrics, parseResults)
+
 	// Calculate aggregate metrics
 	ca.calculateAggregateMetrics(metrics)
+	metrics.Accepted = collectAcceptedComplexity(metrics)
 
 	// Generate recommendations
 	ca.generateRecommendations(metrics)
@@ -303,6 +321,11 @@ func (ca *ComplexityAnalyzer) analyzeFunctionComplexity(function ast.FunctionInf
 	complexity.CognitiveValue = ca.calculateCognitiveComplexity(function)
 	complexity.NestingDepth = ca.calculateNestingDepth(function)
 
+	// Comment lines per code line, counted as the maintainability index counts them
+	comments := countFunctionComments(function, parseResult.Comments)
+	complexity.CommentLines = comments.commentLines
+	complexity.CommentRatio = comments.ratio()
+
 	// Calculate weighted score
 	complexity.WeightedScore = ca.calculateWeightedScore(complexity)
 
@@ -313,8 +336,13 @@ func (ca *ComplexityAnalyzer) analyzeFunctionComplexity(function ast.FunctionInf
 	complexity.RefactoringRisk = ca.assessRefactoringRisk(complexity)
 	complexity.TestingDifficulty = ca.assessTestingDifficulty(complexity)
 
+	// Accepted complexity is still measured but no longer recommended for refactoring
+	complexity.AcceptedReason, complexity.Accepted = acceptedComplexityReason(function, parseResult.Comments)
+
 	// Generate recommendations
-	complexity.Recommendations = ca.generateFunctionRecommendations(complexity)
+	if !complexity.Accepted {
+		complexity.Recommendations = ca.generateFunctionRecommendations(complexity)
+	}
 
 	// Detect anti-patterns
 	complexity.ComplexityFactors.AntiPatterns = ca.detectAntiPatterns(function, complexity)
@@ -568,12 +596,12 @@ func (ca *ComplexityAnalyzer) calculateAggregateMetrics(metrics *ComplexityMetri
 
 // generateRecommendations creates prioritized improvement recommendations
 func (ca *ComplexityAnalyzer) generateRecommendations(metrics *ComplexityMetrics) {
-	// Sort functions by complexity for targeted recommendations
 	functions := make([]FunctionComplexity, len(metrics.FunctionMetrics))
 	copy(functions, metrics.FunctionMetrics)
 
-	sort.Slice(functions, func(i, j int) bool {
-		return functions[i].WeightedScore > functions[j].WeightedScore
+	// Sort functions by complexity weighted by fan-in for targeted recommendations
+	sort.SliceStable(functions, func(i, j int) bool {
+		return functions[i].WeightedScore*ca.fanInMultiplier(functions[i]) > functions[j].WeightedScore*ca.fanInMultiplier(functions[j])
 	})
 
 	// Generate high-priority recommendations for most complex functions
@@ -585,7 +613,7 @@ func (ca *ComplexityAnalyzer) generateRecommendations(metrics *ComplexityMetrics
 	if topN > 0 {
 		criticalFunctions := []string{}
 		for i := 0; i < topN && i < len(functions); i++ {
-			if functions[i].SeverityLevel == "severe" || functions[i].SeverityLevel == "high" {
+			if !functions[i].Accepted && (functions[i].SeverityLevel == "severe" || functions[i].SeverityLevel == "high") {
 				criticalFunctions = append(criticalFunctions, functions[i].Name)
 			}
 		}
@@ -606,7 +634,17 @@ func (ca *ComplexityAnalyzer) generateRecommendations(metrics *ComplexityMetrics
 	}
 
 	// Add testing recommendations
-	if metrics.ComplexityByLevel.High.Count+metrics.ComplexityByLevel.Severe.Count > 0 {
+	accepted := make(map[string]bool)
+	for _, function := range metrics.Accepted {
+		accepted[fmt.Sprintf("%s (%s:%d)", function.Name, function.FilePath, function.StartLine)] = true
+	}
+	untested := []string{}
+	for _, name := range append(metrics.ComplexityByLevel.High.Functions, metrics.ComplexityByLevel.Severe.Functions...) {
+		if !accepted[name] {
+			untested = append(untested, name)
+		}
+	}
+	if len(untested) > 0 {
 		metrics.Recommendations = append(metrics.Recommendations, ComplexityRecommendation{
 			Priority:       "high",
 			Category:       "testing",
@@ -614,9 +652,9 @@ func (ca *ComplexityAnalyzer) generateRecommendations(metrics *ComplexityMetrics
 			Description:    "Add comprehensive tests for functions with high/severe complexity",
 			Impact:         "high",
 			Effort:         "medium",
-			Functions:      append(metrics.ComplexityByLevel.High.Functions, metrics.ComplexityByLevel.Severe.Functions...),
+			Functions:      untested,
 			Techniques:     []string{"unit_testing", "edge_case_testing", "integration_testing"},
-			EstimatedHours: (metrics.ComplexityByLevel.High.Count + metrics.ComplexityByLevel.Severe.Count) * 2,
+			EstimatedHours: len(untested) * 2,
 		})
 	}
 }
@@ -774,3 +774,20 @@ func BenchmarkAnalyzeComplexity(b *testing.B) {
 		}
 	}
 }
+
+func TestAnalyzeFunctionComplexity_CommentRatio(t *testing.T) {
+	analyzer := NewComplexityAnalyzer()
+	function := ast.FunctionInfo{Name: "total", StartLine: 2, EndLine: 11}
+	parseResult := &ast.ParseResult{FilePath: "order.js", Comments: []ast.CommentInfo{
+		{Text: "// Totals the order", StartLine: 1, EndLine: 1},
+		{Text: "// Discounts apply before tax", StartLine: 4, EndLine: 4},
+		{Text: "// const legacy = total * 2;", StartLine: 7, EndLine: 7},
+		{Text: "// return legacy;", StartLine: 8, EndLine: 8},
+	}}
+
+	complexity, err := analyzer.analyzeFunctionComplexity(function, parseResult)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, complexity.CommentLines, "commented-out code is not documentation")
+	assert.InDelta(t, 2.0/7.0, complexity.CommentRatio, 1e-9, "10 lines minus the 3 comment lines in the body")
+}
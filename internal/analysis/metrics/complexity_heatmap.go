@@ -0,0 +1,253 @@
+package metrics
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultHeatmapTopN is the number of files shown when no explicit limit is given
+const DefaultHeatmapTopN = 25
+
+// heatmapColumns are the per-file metrics rendered as heatmap columns
+var heatmapColumns = []string{"Cyclomatic", "Cognitive", "LOC"}
+
+// ComplexityHeatmap is a file × metric grid highlighting where complexity concentrates
+type ComplexityHeatmap struct {
+	Columns     []string     `json:"columns"`
+	Rows        []HeatmapRow `json:"rows"`
+	HiddenFiles int          `json:"hidden_files"` // files beyond the top N, summarized as "+M more"
+}
+
+// HeatmapRow holds one file's aggregated metrics and their color intensities
+type HeatmapRow struct {
+	FilePath    string    `json:"file_path"`
+	Values      []int     `json:"values"`
+	Intensities []float64 `json:"intensities"` // 0.0 (coolest) to 1.0 (hottest), normalized per column
+}
+
+// BuildComplexityHeatmap aggregates function metrics per file and keeps the topN files
+// with the highest total cyclomatic complexity. A topN of zero or less uses DefaultHeatmapTopN.
+func BuildComplexityHeatmap(metrics *ComplexityMetrics, topN int) *ComplexityHeatmap {
+	if topN <= 0 {
+		topN = DefaultHeatmapTopN
+	}
+
+	heatmap := &ComplexityHeatmap{
+		Columns: heatmapColumns,
+		Rows:    []HeatmapRow{},
+	}
+	if metrics == nil {
+		return heatmap
+	}
+
+	// Aggregate cyclomatic, cognitive and LOC per file
+	totals := make(map[string][]int)
+	for _, function := range metrics.FunctionMetrics {
+		values, exists := totals[function.FilePath]
+		if !exists {
+			values = make([]int, len(heatmapColumns))
+			totals[function.FilePath] = values
+		}
+		values[0] += function.CyclomaticValue
+		values[1] += function.CognitiveValue
+		if function.EndLine >= function.StartLine {
+			values[2] += function.EndLine - function.StartLine + 1
+		}
+	}
+
+	for _, filePath := range sortedKeys(totals) {
+		heatmap.Rows = append(heatmap.Rows, HeatmapRow{
+			FilePath: filePath,
+			Values:   totals[filePath],
+		})
+	}
+
+	// Highest total complexity first; file path order is kept for ties
+	sort.SliceStable(heatmap.Rows, func(i, j int) bool {
+		return heatmap.Rows[i].Values[0] > heatmap.Rows[j].Values[0]
+	})
+
+	if len(heatmap.Rows) > topN {
+		heatmap.HiddenFiles = len(heatmap.Rows) - topN
+		heatmap.Rows = heatmap.Rows[:topN]
+	}
+
+	// Normalize intensities against the hottest visible cell of each column
+	maxValues := make([]int, len(heatmapColumns))
+	for _, row := range heatmap.Rows {
+		for col, value := range row.Values {
+			if value > maxValues[col] {
+				maxValues[col] = value
+			}
+		}
+	}
+	for i := range heatmap.Rows {
+		heatmap.Rows[i].Intensities = make([]float64, len(heatmapColumns))
+		for col, value := range heatmap.Rows[i].Values {
+			if maxValues[col] > 0 {
+				heatmap.Rows[i].Intensities[col] = float64(value) / float64(maxValues[col])
+			}
+		}
+	}
+
+	return heatmap
+}
+
+// heatmapColor maps an intensity to a color between pale yellow and deep red
+func heatmapColor(intensity float64) string {
+	if intensity < 0 {
+		intensity = 0
+	} else if intensity > 1 {
+		intensity = 1
+	}
+
+	// Interpolate #fff5cc -> #c0392b
+	r := 255 - int(intensity*float64(255-192))
+	g := 245 - int(intensity*float64(245-57))
+	b := 204 - int(intensity*float64(204-43))
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// heatmapTemplateFuncs are the helpers available to the HTML template
+var heatmapTemplateFuncs = template.FuncMap{
+	"color": heatmapColor,
+	"add":   func(a, b int) int { return a + b },
+}
+
+const heatmapHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Complexity Heatmap</title>
+<style>
+body { font-family: Arial, sans-serif; margin: 20px; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ddd; padding: 6px 12px; }
+th { background: #f5f5f5; text-align: left; }
+td.value { text-align: right; font-variant-numeric: tabular-nums; }
+tfoot td { font-style: italic; color: #666; }
+</style>
+</head>
+<body>
+<h1>Complexity Heatmap</h1>
+<table>
+<thead>
+<tr><th>File</th>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+{{- $row := .}}
+<tr><td>{{.FilePath}}</td>{{range $i, $v := .Values}}<td class="value" style="background: {{color (index $row.Intensities $i)}}">{{$v}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+{{- if .HiddenFiles}}
+<tfoot>
+<tr><td colspan="{{add (len .Columns) 1}}">+{{.HiddenFiles}} more</td></tr>
+</tfoot>
+{{- end}}
+</table>
+</body>
+</html>
+`
+
+// WriteHTML renders the heatmap as a standalone HTML table
+func (h *ComplexityHeatmap) WriteHTML(w io.Writer) error {
+	tmpl, err := template.New("heatmap").Funcs(heatmapTemplateFuncs).Parse(heatmapHTMLTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse heatmap template: %w", err)
+	}
+
+	if err := tmpl.Execute(w, h); err != nil {
+		return fmt.Errorf("failed to render heatmap HTML: %w", err)
+	}
+	return nil
+}
+
+// SVG layout, in pixels
+const (
+	heatmapLabelWidth = 360
+	heatmapCellWidth  = 100
+	heatmapCellHeight = 24
+)
+
+// WriteSVG renders the heatmap as a standalone SVG image
+func (h *ComplexityHeatmap) WriteSVG(w io.Writer) error {
+	rows := len(h.Rows) + 1 // header
+	if h.HiddenFiles > 0 {
+		rows++
+	}
+	width := heatmapLabelWidth + heatmapCellWidth*len(h.Columns)
+	height := heatmapCellHeight * rows
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="Arial, sans-serif" font-size="12">`+"\n", width, height)
+
+	// File paths come from the analyzed repository, so text content is always escaped
+	writeText := func(x, y int, anchor, text string) {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="%s" dominant-baseline="middle">%s</text>`+"\n", x, y, anchor, template.HTMLEscapeString(text))
+	}
+
+	writeText(4, heatmapCellHeight/2, "start", "File")
+	for col, name := range h.Columns {
+		writeText(heatmapLabelWidth+col*heatmapCellWidth+heatmapCellWidth/2, heatmapCellHeight/2, "middle", name)
+	}
+
+	for i, row := range h.Rows {
+		y := (i + 1) * heatmapCellHeight
+		writeText(4, y+heatmapCellHeight/2, "start", row.FilePath)
+		for col, value := range row.Values {
+			x := heatmapLabelWidth + col*heatmapCellWidth
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="#ffffff"/>`+"\n",
+				x, y, heatmapCellWidth, heatmapCellHeight, heatmapColor(row.Intensities[col]))
+			writeText(x+heatmapCellWidth/2, y+heatmapCellHeight/2, "middle", fmt.Sprintf("%d", value))
+		}
+	}
+
+	if h.HiddenFiles > 0 {
+		writeText(4, (len(h.Rows)+1)*heatmapCellHeight+heatmapCellHeight/2, "start", fmt.Sprintf("+%d more", h.HiddenFiles))
+	}
+	b.WriteString("</svg>\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write heatmap SVG: %w", err)
+	}
+	return nil
+}
+
+// ValidateHeatmapPath checks that path names a file type the heatmap can be written as:
+// .svg, .html or .htm
+func ValidateHeatmapPath(path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg", ".html", ".htm":
+		return nil
+	}
+	return fmt.Errorf("%q must end in .svg, .html or .htm", path)
+}
+
+// WriteHeatmapFile writes the heatmap of the topN most complex files to path, as SVG or
+// HTML by its extension
+func WriteHeatmapFile(path string, complexity *ComplexityMetrics, topN int) error {
+	if err := ValidateHeatmapPath(path); err != nil {
+		return err
+	}
+	heatmap := BuildComplexityHeatmap(complexity, topN)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create heatmap file: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		err = heatmap.WriteSVG(file)
+	} else {
+		err = heatmap.WriteHTML(file)
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write %s: %w", path, closeErr)
+	}
+	return err
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createHeatmapComplexityMetrics() *ComplexityMetrics {
+	return &ComplexityMetrics{
+		FunctionMetrics: []FunctionComplexity{
+			{Name: "a1", FilePath: "src/a.js", StartLine: 1, EndLine: 10, CyclomaticValue: 2, CognitiveValue: 1},
+			{Name: "a2", FilePath: "src/a.js", StartLine: 12, EndLine: 21, CyclomaticValue: 3, CognitiveValue: 4},
+			{Name: "b1", FilePath: "src/b.js", StartLine: 1, EndLine: 40, CyclomaticValue: 12, CognitiveValue: 20},
+			{Name: "c1", FilePath: "src/c.js", StartLine: 1, EndLine: 5, CyclomaticValue: 5, CognitiveValue: 2},
+			{Name: "d1", FilePath: "src/<d>.js", StartLine: 1, EndLine: 3, CyclomaticValue: 1, CognitiveValue: 0},
+		},
+	}
+}
+
+func TestBuildComplexityHeatmap(t *testing.T) {
+	heatmap := BuildComplexityHeatmap(createHeatmapComplexityMetrics(), 0)
+
+	require.Len(t, heatmap.Rows, 4)
+	assert.Equal(t, []string{"Cyclomatic", "Cognitive", "LOC"}, heatmap.Columns)
+	assert.Equal(t, 0, heatmap.HiddenFiles)
+
+	// Rows are ordered by total cyclomatic complexity; a.js and c.js tie at 5 and keep path order
+	var order []string
+	for _, row := range heatmap.Rows {
+		order = append(order, row.FilePath)
+	}
+	assert.Equal(t, []string{"src/b.js", "src/a.js", "src/c.js", "src/<d>.js"}, order)
+
+	assert.Equal(t, []int{5, 5, 20}, heatmap.Rows[1].Values)
+	assert.Equal(t, []float64{1, 1, 1}, heatmap.Rows[0].Intensities)
+	assert.InDelta(t, 0.5, heatmap.Rows[1].Intensities[2], 0.001)
+}
+
+func TestBuildComplexityHeatmap_CapsRows(t *testing.T) {
+	heatmap := BuildComplexityHeatmap(createHeatmapComplexityMetrics(), 2)
+
+	assert.Len(t, heatmap.Rows, 2)
+	assert.Equal(t, 2, heatmap.HiddenFiles)
+}
+
+func TestBuildComplexityHeatmap_NilMetrics(t *testing.T) {
+	heatmap := BuildComplexityHeatmap(nil, 10)
+
+	assert.Empty(t, heatmap.Rows)
+	assert.Equal(t, 0, heatmap.HiddenFiles)
+}
+
+func TestComplexityHeatmap_WriteHTML(t *testing.T) {
+	heatmap := BuildComplexityHeatmap(createHeatmapComplexityMetrics(), 3)
+
+	var buf bytes.Buffer
+	require.NoError(t, heatmap.WriteHTML(&buf))
+	output := buf.String()
+
+	assert.Contains(t, output, "<th>Cyclomatic</th>")
+	assert.Contains(t, output, "src/b.js")
+	assert.Contains(t, output, "+1 more")
+	assert.Contains(t, output, heatmapColor(1))
+	assert.NotContains(t, output, "src/<d>.js")
+}
+
+func TestComplexityHeatmap_WriteSVG(t *testing.T) {
+	heatmap := BuildComplexityHeatmap(createHeatmapComplexityMetrics(), 0)
+
+	var buf bytes.Buffer
+	require.NoError(t, heatmap.WriteSVG(&buf))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, "<svg"))
+	assert.Contains(t, output, "src/&lt;d&gt;.js")
+	assert.Equal(t, len(heatmap.Rows)*len(heatmap.Columns), strings.Count(output, "<rect"))
+	assert.Contains(t, output, fmt.Sprintf(`fill="%s"`, heatmapColor(1)))
+}
+
+// failingWriter rejects every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestComplexityHeatmap_WriteSVG_WriteError(t *testing.T) {
+	heatmap := BuildComplexityHeatmap(createHeatmapComplexityMetrics(), 0)
+	assert.ErrorContains(t, heatmap.WriteSVG(failingWriter{}), "disk full")
+}
+
+func TestHeatmapColor(t *testing.T) {
+	assert.Equal(t, "#fff5cc", heatmapColor(0))
+	assert.Equal(t, "#c0392b", heatmapColor(1))
+	assert.Equal(t, heatmapColor(1), heatmapColor(2))
+}
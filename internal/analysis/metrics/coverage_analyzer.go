@@ -41,19 +41,44 @@ type CoverageConfig struct {
 	ExternalDependencyThreshold int `yaml:"external_dependency_threshold" default:"2"`
 	DatabaseCallThreshold       int `yaml:"database_call_threshold" default:"1"`
 	NetworkCallThreshold        int `yaml:"network_call_threshold" default:"1"`
+
+	// Quality gate behaviour
+	EstimatedGateAdvisory bool     `yaml:"estimated_gate_advisory" default:"false"` // estimated coverage may warn but never fail the gate
+	MeasuredCoverage      *float64 `yaml:"measured_coverage"`                       // line coverage from a real test run, 0-100
+
+	// Testing effort estimation; unset hours use DefaultTestingEffortCalibration
+	TestingEffort TestingEffortCalibration `yaml:"testing_effort"`
+
+	// Test framework recommendations: TestFramework overrides detection (jest, vitest, mocha,
+	// pytest), which reads Dependencies (package.json) and the framework config files among
+	// ProjectFiles and the analyzed files. TestFilePatterns mark test files, empty for
+	// DefaultTestFilePatterns.
+	TestFramework    string            `yaml:"test_framework"`
+	TestFilePatterns []string          `yaml:"test_file_patterns"`
+	Dependencies     map[string]string `yaml:"-"`
+	ProjectFiles     []string          `yaml:"-"`
 }
 
+// Coverage sources reported in CoverageMetrics and CoverageSummary
+const (
+	CoverageSourceEstimated = "estimated" // inferred from testability heuristics
+	CoverageSourceMeasured  = "measured"  // supplied from a real test run
+)
+
 // CoverageMetrics contains comprehensive coverage analysis results
 type CoverageMetrics struct {
 	OverallScore           float64                    `json:"overall_score"`      // 0-100
 	EstimatedCoverage      float64                    `json:"estimated_coverage"` // 0-100
-	TestabilityScore       float64                    `json:"testability_score"`  // 0-100
+	MeasuredCoverage       *float64                   `json:"measured_coverage,omitempty"`
+	CoverageSource         string                     `json:"coverage_source"`   // estimated, measured
+	TestabilityScore       float64                    `json:"testability_score"` // 0-100
 	FunctionAnalysis       []FunctionTestability      `json:"function_analysis"`
 	FileAnalysis           map[string]FileTestability `json:"file_analysis"`
 	UntestedPaths          []UntestedPath             `json:"untested_paths"`
 	MockRequirements       []MockRequirement          `json:"mock_requirements"`
 	TestingRecommendations []TestingRecommendation    `json:"testing_recommendations"`
 	CoverageGaps           []CoverageGap              `json:"coverage_gaps"`
+	TestFramework          TestFramework              `json:"test_framework"` // what recommendations and tools are written for
 	TestingStrategy        TestingStrategy            `json:"testing_strategy"`
 	PriorityMatrix         TestingPriorityMatrix      `json:"priority_matrix"`
 	Summary                CoverageSummary            `json:"summary"`
@@ -77,6 +102,7 @@ type FunctionTestability struct {
 	TestingEffort       int                    `json:"testing_effort"` // hours
 	RecommendedApproach string                 `json:"recommended_approach"`
 	CoverageGaps        []string               `json:"coverage_gaps"`
+	Accepted            bool                   `json:"accepted,omitempty"` // complexity accepted with an annotation: never counted as high risk in the gate
 	Metadata            map[string]interface{} `json:"metadata"`
 }
 
@@ -103,7 +129,8 @@ type UntestedPath struct {
 	StartLine       int      `json:"start_line"`
 	EndLine         int      `json:"end_line"`
 	Condition       string   `json:"condition"`
-	RiskLevel       string   `json:"risk_level"` // low, medium, high, critical
+	RiskLevel       string   `json:"risk_level"`             // low, medium, high, critical
+	RiskFactors     []string `json:"risk_factors,omitempty"` // why a conditional path got its risk level
 	TestingStrategy string   `json:"testing_strategy"`
 	RequiredSetup   []string `json:"required_setup"`
 	ExpectedOutcome string   `json:"expected_outcome"`
@@ -232,7 +259,9 @@ type CoverageSummary struct {
 	MockingComplexity     string  `json:"mocking_complexity"` // low, medium, high, very_high
 	EstimatedTestingWeeks int     `json:"estimated_testing_weeks"`
 	RecommendedFocus      string  `json:"recommended_focus"`
-	QualityGate           string  `json:"quality_gate"` // pass, warning, fail
+	QualityGate           string  `json:"quality_gate"`            // pass, warning, fail
+	CoverageSource        string  `json:"coverage_source"`         // estimated, measured
+	GateAdvisory          bool    `json:"gate_advisory,omitempty"` // a fail was downgraded to warning because coverage is estimated
 }
 
 // NewCoverageAnalyzer creates a new coverage analyzer with default configuration
@@ -254,12 +283,14 @@ func NewCoverageAnalyzer() *CoverageAnalyzer {
 			ExternalDependencyThreshold: 2,
 			DatabaseCallThreshold:       1,
 			NetworkCallThreshold:        1,
+			TestingEffort:               DefaultTestingEffortCalibration(),
 		},
 	}
 }
 
 // NewCoverageAnalyzerWithConfig creates a new coverage analyzer with custom configuration
 func NewCoverageAnalyzerWithConfig(config CoverageConfig) *CoverageAnalyzer {
+	config.TestingEffort = config.TestingEffort.withDefaults()
 	return &CoverageAnalyzer{
 		config: config,
 	}
@@ -269,13 +300,16 @@ func NewCoverageAnalyzerWithConfig(config CoverageConfig) *CoverageAnalyzer {
 func (ca *CoverageAnalyzer) AnalyzeCoverage(ctx context.Context, parseResults []*ast.ParseResult, complexityMetrics *ComplexityMetrics) (*CoverageMetrics, error) {
 	if len(parseResults) == 0 {
 		return &CoverageMetrics{
+			CoverageSource: ca.coverageSource(),
 			Summary: CoverageSummary{
-				QualityGate: "pass",
+				QualityGate:    "pass",
+				CoverageSource: ca.coverageSource(),
 			},
 		}, nil
 	}
 
 	metrics := &CoverageMetrics{
+		TestFramework:          ca.testFramework(parseResults),
 		FunctionAnalysis:       []FunctionTestability{},
 		FileAnalysis:           make(map[string]FileTestability),
 		UntestedPaths:          []UntestedPath{},
@@ -399,6 +433,7 @@ func (ca *CoverageAnalyzer) analyzeFunctionTestability(function ast.FunctionInfo
 
 	// Assess risk level
 	testability.RiskLevel = ca.assessFunctionRiskLevel(testability)
+	_, testability.Accepted = acceptedComplexityReason(function, parseResult.Comments)
 
 	// Estimate testing effort
 	testability.TestingEffort = ca.estimateTestingEffort(testability)
@@ -605,9 +640,9 @@ func (ca *CoverageAnalyzer) identifyRequiredMocks(function ast.FunctionInfo, par
 func (ca *CoverageAnalyzer) identifyFunctionUntestedPaths(function ast.FunctionInfo) []string {
 	paths := []string{}
 
-	// Estimate paths based on function characteristics
-	if function.IsAsync {
-		paths = append(paths, "async_error_handling", "promise_rejection")
+	// Awaits and promise chains whose rejection nothing handles
+	for _, call := range function.UnhandledAsync {
+		paths = append(paths, fmt.Sprintf("unhandled_%s_line_%d", call.Kind, call.Line))
 	}
 
 	// Estimate based on parameter count (more parameters = more edge cases)
@@ -640,29 +675,24 @@ func (ca *CoverageAnalyzer) assessFunctionRiskLevel(testability FunctionTestabil
 	}
 }
 
-// estimateTestingEffort calculates estimated effort in hours
+// estimateTestingEffort calculates estimated effort in hours from the configured
+// calibration, see TestingEffortCalibration
 func (ca *CoverageAnalyzer) estimateTestingEffort(testability FunctionTestability) int {
-	baseEffort := 2 // Base 2 hours per function
+	calibration := ca.config.TestingEffort
 
-	// Adjust based on testability score
-	if testability.TestabilityScore < 40 {
-		baseEffort += 6 // Very difficult functions
-	} else if testability.TestabilityScore < 60 {
-		baseEffort += 4 // Difficult functions
-	} else if testability.TestabilityScore < 80 {
-		baseEffort += 2 // Moderate functions
-	}
+	// Base hours by testing difficulty
+	effort := calibration.BaseHours[ca.determineTestingDifficulty(testability)]
 
 	// Add effort for mocks
-	baseEffort += len(testability.RequiredMocks) * 1
+	effort += len(testability.RequiredMocks) * *calibration.HoursPerMock
 
 	// Add effort for untested paths
-	baseEffort += len(testability.UntestedPaths) * 1
+	effort += len(testability.UntestedPaths) * *calibration.HoursPerUntestedPath
 
 	// Add effort for coverage gaps
-	baseEffort += len(testability.CoverageGaps) * 1
+	effort += len(testability.CoverageGaps) * *calibration.HoursPerCoverageGap
 
-	return baseEffort
+	return effort
 }
 
 // recommendTestingApproach suggests the best testing approach
@@ -683,7 +713,7 @@ func (ca *CoverageAnalyzer) identifyFunctionCoverageGaps(function ast.FunctionIn
 	gaps := []string{}
 
 	// Error handling gaps
-	if function.IsAsync {
+	if len(function.UnhandledAsync) > 0 {
 		gaps = append(gaps, "async_error_scenarios")
 	}
 
@@ -721,8 +751,8 @@ func (ca *CoverageAnalyzer) isExternalDependency(source string) bool {
 		}
 	}
 
-	// Check if it's a relative import
-	return !strings.HasPrefix(source, ".") && !strings.HasPrefix(source, "/")
+	// Check if it's a relative import, including Windows-style paths
+	return !ast.IsLocalImport(source)
 }
 
 // calculateFileTestingComplexity calculates testing complexity at file level
@@ -773,9 +803,7 @@ func (ca *CoverageAnalyzer) countCoverageGaps(parseResult *ast.ParseResult) int
 
 	// Count functions with complex patterns
 	for _, function := range parseResult.Functions {
-		if function.IsAsync {
-			gaps++ // Async functions have error handling gaps
-		}
+		gaps += len(function.UnhandledAsync) // Awaits and promise chains without error handling
 		if len(function.Parameters) > 3 {
 			gaps++ // Complex parameter handling
 		}
@@ -860,25 +888,21 @@ func (ca *CoverageAnalyzer) identifyUntestedPaths(parseResults []*ast.ParseResul
 	}
 }
 
-// analyzeConditionalPaths identifies untested conditional branches
+// analyzeConditionalPaths reports each if statement, switch case and ternary the parser found
+// in the function body. Functions without branch information yield no conditional paths.
 func (ca *CoverageAnalyzer) analyzeConditionalPaths(function ast.FunctionInfo, parseResult *ast.ParseResult, metrics *CoverageMetrics, pathID *int) {
-	// Estimate conditional paths based on function complexity
-	lineCount := function.EndLine - function.StartLine
-	paramCount := len(function.Parameters)
-
-	// More parameters and larger functions likely have more conditionals
-	estimatedConditionals := paramCount + lineCount/10
-
-	for i := 0; i < estimatedConditionals; i++ {
+	for _, branch := range function.Branches {
+		riskLevel, riskFactors := assessBranchRisk(branch, function, parseResult)
 		path := UntestedPath{
 			ID:              fmt.Sprintf("conditional_%d", *pathID),
 			FilePath:        parseResult.FilePath,
 			FunctionName:    function.Name,
 			PathType:        "conditional",
-			StartLine:       function.StartLine + i*2,
-			EndLine:         function.StartLine + i*2 + 1,
-			Condition:       fmt.Sprintf("conditional_branch_%d", i+1),
-			RiskLevel:       ca.assessPathRiskLevel("conditional", function),
+			StartLine:       branch.StartLine,
+			EndLine:         branch.EndLine,
+			Condition:       fmt.Sprintf("%s %s", branch.Kind, branch.Condition),
+			RiskLevel:       riskLevel,
+			RiskFactors:     riskFactors,
 			TestingStrategy: "branch_coverage_testing",
 			RequiredSetup:   []string{"setup_test_data", "mock_dependencies"},
 			ExpectedOutcome: "verify_branch_execution",
@@ -932,7 +956,8 @@ func (ca *CoverageAnalyzer) analyzeExceptionPaths(function ast.FunctionInfo, par
 	}
 }
 
-// analyzeAsyncPaths identifies untested async execution paths
+// analyzeAsyncPaths identifies untested async execution paths: the success path of an async
+// function, and a rejection path at each await or promise chain that has no error handling
 func (ca *CoverageAnalyzer) analyzeAsyncPaths(function ast.FunctionInfo, parseResult *ast.ParseResult, metrics *CoverageMetrics, pathID *int) {
 	if function.IsAsync {
 		// Success path
@@ -951,16 +976,18 @@ func (ca *CoverageAnalyzer) analyzeAsyncPaths(function ast.FunctionInfo, parseRe
 		}
 		metrics.UntestedPaths = append(metrics.UntestedPaths, successPath)
 		*pathID++
+	}
 
-		// Error path
+	// Error paths, one per unhandled rejection
+	for _, call := range function.UnhandledAsync {
 		errorPath := UntestedPath{
 			ID:              fmt.Sprintf("async_error_%d", *pathID),
 			FilePath:        parseResult.FilePath,
 			FunctionName:    function.Name,
 			PathType:        "async",
-			StartLine:       function.StartLine,
-			EndLine:         function.EndLine,
-			Condition:       "async_error_scenario",
+			StartLine:       call.Line,
+			EndLine:         call.Line,
+			Condition:       unhandledAsyncCondition(call),
 			RiskLevel:       "high",
 			TestingStrategy: "async_error_testing",
 			RequiredSetup:   []string{"setup_promise_rejection", "mock_async_errors"},
@@ -1114,7 +1141,7 @@ func (ca *CoverageAnalyzer) analyzeFunctionMockRequirements(function ast.Functio
 			DependencyName:      "function_parameters",
 			MockingComplexity:   "moderate",
 			MockingStrategy:     "parameter_object_mocking",
-			RequiredMockLibrary: "jest_or_sinon",
+			RequiredMockLibrary: mockLibrary(metrics.TestFramework.Name),
 			SetupComplexity:     3,
 			MaintenanceOverhead: "low",
 			Alternatives:        []string{"builder_pattern", "factory_functions", "default_parameters"},
@@ -1165,7 +1192,7 @@ func (ca *CoverageAnalyzer) generateTestingRecommendations(metrics *CoverageMetr
 				EstimatedEffort: funcTestability.TestingEffort,
 				ExpectedBenefit: ca.calculateExpectedBenefit(funcTestability),
 				TestingApproach: ca.generateTestingApproach(funcTestability),
-				RequiredTools:   ca.identifyRequiredTools(funcTestability),
+				RequiredTools:   ca.identifyRequiredTools(funcTestability, metrics.TestFramework.Name),
 				RiskReduction:   ca.calculateRiskReduction(funcTestability),
 				Metadata: map[string]interface{}{
 					"testability_score": funcTestability.TestabilityScore,
@@ -1279,8 +1306,8 @@ func (ca *CoverageAnalyzer) generateTestingApproach(funcTestability FunctionTest
 }
 
 // identifyRequiredTools identifies testing tools needed
-func (ca *CoverageAnalyzer) identifyRequiredTools(funcTestability FunctionTestability) []string {
-	tools := []string{"jest", "testing_framework"}
+func (ca *CoverageAnalyzer) identifyRequiredTools(funcTestability FunctionTestability, framework string) []string {
+	tools := []string{framework, "testing_framework"}
 
 	// Add tools based on mock requirements
 	for _, mock := range funcTestability.RequiredMocks {
@@ -1337,7 +1364,8 @@ func (ca *CoverageAnalyzer) generateCoverageGapRecommendations(metrics *Coverage
 	}
 
 	// Generate recommendations for each path type
-	for pathType, paths := range pathTypes {
+	for _, pathType := range sortedKeys(pathTypes) {
+		paths := pathTypes[pathType]
 		if len(paths) > 2 { // Only recommend if multiple instances
 			gap := CoverageGap{
 				ID:              fmt.Sprintf("gap_%d", gapID),
@@ -1366,7 +1394,7 @@ func (ca *CoverageAnalyzer) generateCoverageGapRecommendations(metrics *Coverage
 				EstimatedEffort: gap.EstimatedEffort,
 				ExpectedBenefit: "Improved code coverage and reliability",
 				TestingApproach: []string{gap.TestingStrategy},
-				RequiredTools:   ca.getStrategyTools(pathType),
+				RequiredTools:   ca.getStrategyTools(pathType, metrics.TestFramework.Name),
 				RiskReduction:   float64(len(paths)) * 5.0, // 5% per path
 				Metadata: map[string]interface{}{
 					"gap_type":  pathType,
@@ -1487,18 +1515,18 @@ func (ca *CoverageAnalyzer) getGapPrerequisites(pathType string) []string {
 }
 
 // getStrategyTools gets tools needed for testing strategies
-func (ca *CoverageAnalyzer) getStrategyTools(pathType string) []string {
+func (ca *CoverageAnalyzer) getStrategyTools(pathType, framework string) []string {
 	switch pathType {
 	case "exception":
-		return []string{"jest", "error_testing_library", "mock_error_conditions"}
+		return []string{framework, "error_testing_library", "mock_error_conditions"}
 	case "async":
-		return []string{"jest", "async_testing_utilities", "promise_testing"}
+		return []string{framework, "async_testing_utilities", "promise_testing"}
 	case "loop":
-		return []string{"jest", "property_based_testing", "data_generators"}
+		return []string{framework, "property_based_testing", "data_generators"}
 	case "conditional":
-		return []string{"jest", "coverage_reporting", "branch_coverage_tool"}
+		return []string{framework, "coverage_reporting", "branch_coverage_tool"}
 	default:
-		return []string{"jest", "testing_utilities"}
+		return []string{framework, "testing_utilities"}
 	}
 }
 
@@ -1612,8 +1640,8 @@ func (ca *CoverageAnalyzer) generateTestingStrategy(metrics *CoverageMetrics) {
 		overallApproach = "comprehensive_coverage_driven_testing"
 	}
 
-	// Recommend testing framework
-	recommendedFramework := "jest_with_comprehensive_mocking"
+	// Recommend the detected or configured testing framework
+	recommendedFramework := metrics.TestFramework.Name + "_with_comprehensive_mocking"
 
 	// Calculate testing pyramid distribution
 	pyramid := TestingPyramid{
@@ -1725,9 +1753,13 @@ func (ca *CoverageAnalyzer) calculateTotalDeveloperHours(metrics *CoverageMetric
 
 // calculateOverallMetrics calculates final metrics and summary
 func (ca *CoverageAnalyzer) calculateOverallMetrics(metrics *CoverageMetrics) {
+	metrics.CoverageSource = ca.coverageSource()
+	metrics.MeasuredCoverage = ca.config.MeasuredCoverage
+
 	if len(metrics.FunctionAnalysis) == 0 {
 		metrics.Summary = CoverageSummary{
-			QualityGate: "pass",
+			QualityGate:    "pass",
+			CoverageSource: metrics.CoverageSource,
 		}
 		return
 	}
@@ -1747,7 +1779,8 @@ func (ca *CoverageAnalyzer) calculateOverallMetrics(metrics *CoverageMetrics) {
 			testedFunctions++
 		}
 
-		if funcTestability.RiskLevel == "high" || funcTestability.RiskLevel == "critical" {
+		// Accepted complexity stays out of the gate here as in the complexity gate
+		if !funcTestability.Accepted && (funcTestability.RiskLevel == "high" || funcTestability.RiskLevel == "critical") {
 			highRiskFunctions++
 		}
 	}
@@ -1782,25 +1815,51 @@ func (ca *CoverageAnalyzer) calculateOverallMetrics(metrics *CoverageMetrics) {
 		recommendedFocus = "refactoring_for_testability"
 	}
 
-	// Determine quality gate
+	// Determine quality gate; measured coverage replaces the testability estimate when
+	// available, and then alone decides it: high-risk counts are estimates too
+	coveragePercentage := metrics.EstimatedCoverage
+	gateScore := metrics.OverallScore
+	gateHighRisk := highRiskFunctions
+	if metrics.MeasuredCoverage != nil {
+		coveragePercentage = *metrics.MeasuredCoverage
+		gateScore = coveragePercentage
+		gateHighRisk = 0
+	}
+
 	qualityGate := "pass"
-	if metrics.OverallScore < 40 || highRiskFunctions > totalFunctions/2 {
+	if gateScore < 40 || gateHighRisk > totalFunctions/2 {
 		qualityGate = "fail"
-	} else if metrics.OverallScore < 60 || highRiskFunctions > totalFunctions/4 {
+	} else if gateScore < 60 || gateHighRisk > totalFunctions/4 {
 		qualityGate = "warning"
 	}
 
+	gateAdvisory := false
+	if qualityGate == "fail" && metrics.CoverageSource == CoverageSourceEstimated && ca.config.EstimatedGateAdvisory {
+		qualityGate = "warning"
+		gateAdvisory = true
+	}
+
 	// Create summary
 	metrics.Summary = CoverageSummary{
 		TotalFunctions:        totalFunctions,
 		TestedFunctions:       testedFunctions,
 		UntestedFunctions:     totalFunctions - testedFunctions,
-		CoveragePercentage:    metrics.EstimatedCoverage,
+		CoveragePercentage:    coveragePercentage,
 		TestabilityScore:      metrics.TestabilityScore,
 		HighRiskFunctions:     highRiskFunctions,
 		MockingComplexity:     mockingComplexity,
 		EstimatedTestingWeeks: estimatedWeeks,
 		RecommendedFocus:      recommendedFocus,
 		QualityGate:           qualityGate,
+		CoverageSource:        metrics.CoverageSource,
+		GateAdvisory:          gateAdvisory,
+	}
+}
+
+// coverageSource reports whether coverage figures come from a real test run
+func (ca *CoverageAnalyzer) coverageSource() string {
+	if ca.config.MeasuredCoverage != nil {
+		return CoverageSourceMeasured
 	}
+	return CoverageSourceEstimated
 }
@@ -295,3 +295,156 @@ func createMockComplexityMetricsForCoverage() *ComplexityMetrics {
 		ClassMetrics: []ClassComplexity{},
 	}
 }
+
+func poorlyTestableMetrics() *CoverageMetrics {
+	return &CoverageMetrics{
+		FunctionAnalysis: []FunctionTestability{
+			{Name: "a", TestabilityScore: 20, EstimatedCoverage: 15, RiskLevel: "low"},
+			{Name: "b", TestabilityScore: 30, EstimatedCoverage: 25, RiskLevel: "low"},
+		},
+	}
+}
+
+func TestCalculateOverallMetrics_EstimatedCoverageGate(t *testing.T) {
+	authoritative := NewCoverageAnalyzer()
+	metrics := poorlyTestableMetrics()
+	authoritative.calculateOverallMetrics(metrics)
+
+	assert.Equal(t, CoverageSourceEstimated, metrics.CoverageSource)
+	assert.Equal(t, CoverageSourceEstimated, metrics.Summary.CoverageSource)
+	assert.Equal(t, "fail", metrics.Summary.QualityGate)
+	assert.False(t, metrics.Summary.GateAdvisory)
+
+	advisory := NewCoverageAnalyzer()
+	advisory.config.EstimatedGateAdvisory = true
+	metrics = poorlyTestableMetrics()
+	advisory.calculateOverallMetrics(metrics)
+
+	assert.Equal(t, "warning", metrics.Summary.QualityGate)
+	assert.True(t, metrics.Summary.GateAdvisory)
+}
+
+func TestCalculateOverallMetrics_MeasuredCoverageGate(t *testing.T) {
+	measured := 35.0
+	analyzer := NewCoverageAnalyzer()
+	analyzer.config.EstimatedGateAdvisory = true
+	analyzer.config.MeasuredCoverage = &measured
+
+	metrics := poorlyTestableMetrics()
+	analyzer.calculateOverallMetrics(metrics)
+
+	// Measured coverage is authoritative even in advisory mode
+	assert.Equal(t, CoverageSourceMeasured, metrics.Summary.CoverageSource)
+	assert.Equal(t, 35.0, metrics.Summary.CoveragePercentage)
+	assert.Equal(t, "fail", metrics.Summary.QualityGate)
+	assert.False(t, metrics.Summary.GateAdvisory)
+
+	measured = 85.0
+	metrics = poorlyTestableMetrics()
+	analyzer.calculateOverallMetrics(metrics)
+	assert.Equal(t, "pass", metrics.Summary.QualityGate)
+
+	// Estimated high-risk functions are reported but do not decide a measured gate
+	metrics = poorlyTestableMetrics()
+	for i := range metrics.FunctionAnalysis {
+		metrics.FunctionAnalysis[i].RiskLevel = "critical"
+	}
+	analyzer.calculateOverallMetrics(metrics)
+	assert.Equal(t, 2, metrics.Summary.HighRiskFunctions)
+	assert.Equal(t, "pass", metrics.Summary.QualityGate)
+}
+
+func TestCalculateOverallMetrics_AcceptedFunctionsAreNotHighRisk(t *testing.T) {
+	analyzer := NewCoverageAnalyzer()
+	metrics := &CoverageMetrics{
+		FunctionAnalysis: []FunctionTestability{
+			{Name: "parse", TestabilityScore: 70, RiskLevel: "critical", Accepted: true},
+			{Name: "step", TestabilityScore: 70, RiskLevel: "high", Accepted: true},
+			{Name: "render", TestabilityScore: 70, RiskLevel: "low"},
+		},
+	}
+	analyzer.calculateOverallMetrics(metrics)
+	assert.Equal(t, 0, metrics.Summary.HighRiskFunctions)
+	assert.Equal(t, "pass", metrics.Summary.QualityGate)
+
+	metrics.FunctionAnalysis[0].Accepted = false
+	metrics.FunctionAnalysis[1].Accepted = false
+	analyzer.calculateOverallMetrics(metrics)
+	assert.Equal(t, "fail", metrics.Summary.QualityGate, "the same functions fail the gate unless accepted")
+}
+
+func TestAnalyzeFunctionTestability_Accepted(t *testing.T) {
+	parseResult := &ast.ParseResult{FilePath: "src/parser.js", Comments: []ast.CommentInfo{
+		{Text: "// repo-onboarding-accept-complexity: mirrors the grammar", StartLine: 9, EndLine: 9},
+	}}
+	analyzer := NewCoverageAnalyzer()
+
+	assert.True(t, analyzer.analyzeFunctionTestability(ast.FunctionInfo{Name: "parse", StartLine: 10, EndLine: 40}, parseResult, nil).Accepted)
+	assert.False(t, analyzer.analyzeFunctionTestability(ast.FunctionInfo{Name: "lex", StartLine: 50, EndLine: 60}, parseResult, nil).Accepted)
+}
+
+func TestAnalyzeConditionalPaths_UsesRealBranches(t *testing.T) {
+	analyzer := NewCoverageAnalyzer()
+	parseResult := &ast.ParseResult{FilePath: "src/route.js"}
+
+	branching := ast.FunctionInfo{
+		Name:       "route",
+		StartLine:  1,
+		EndLine:    40,
+		Parameters: []ast.ParameterInfo{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		Branches: []ast.BranchInfo{
+			{Kind: "if", Condition: "(req.ok)", StartLine: 3, EndLine: 7},
+			{Kind: "ternary", Condition: "cached", StartLine: 12, EndLine: 12},
+		},
+	}
+	metrics := &CoverageMetrics{}
+	pathID := 1
+	analyzer.analyzeConditionalPaths(branching, parseResult, metrics, &pathID)
+
+	require.Len(t, metrics.UntestedPaths, 2)
+	assert.Equal(t, 3, metrics.UntestedPaths[0].StartLine)
+	assert.Equal(t, 7, metrics.UntestedPaths[0].EndLine)
+	assert.Equal(t, "if (req.ok)", metrics.UntestedPaths[0].Condition)
+	assert.Equal(t, "ternary cached", metrics.UntestedPaths[1].Condition)
+
+	// A long function with many parameters but no branch information yields nothing
+	straight := ast.FunctionInfo{Name: "straight", StartLine: 1, EndLine: 120, Parameters: branching.Parameters}
+	metrics = &CoverageMetrics{}
+	analyzer.analyzeConditionalPaths(straight, parseResult, metrics, &pathID)
+	assert.Empty(t, metrics.UntestedPaths)
+}
+
+func TestIsExternalDependency_WindowsPaths(t *testing.T) {
+	analyzer := NewCoverageAnalyzer()
+
+	assert.False(t, analyzer.isExternalDependency(`.\utils\format`))
+	assert.False(t, analyzer.isExternalDependency(`..\components\Button`))
+	assert.False(t, analyzer.isExternalDependency(`C:\repo\src\helpers`))
+	assert.False(t, analyzer.isExternalDependency("./utils/format"))
+	assert.True(t, analyzer.isExternalDependency("lodash"))
+	assert.True(t, analyzer.isExternalDependency(`..\services\database`)) // matches an I/O pattern
+}
+
+func TestEstimateTestingEffort_Calibration(t *testing.T) {
+	testability := FunctionTestability{
+		TestabilityScore: 50, // difficult
+		RequiredMocks:    []string{"database", "http"},
+		UntestedPaths:    []string{"if", "catch", "loop"},
+		CoverageGaps:     []string{"edge_cases"},
+	}
+
+	assert.Equal(t, 6+2+3+1, NewCoverageAnalyzer().estimateTestingEffort(testability), "defaults")
+
+	perMock, perGap := 3, 0
+	calibrated := NewCoverageAnalyzerWithConfig(CoverageConfig{
+		TestingEffort: TestingEffortCalibration{
+			BaseHours:           map[string]int{"difficult": 10},
+			HoursPerMock:        &perMock,
+			HoursPerCoverageGap: &perGap,
+		},
+	})
+	assert.Equal(t, 10+2*3+3+0, calibrated.estimateTestingEffort(testability), "unset values keep their defaults and 0 is kept")
+
+	testability.TestabilityScore = 90
+	assert.Equal(t, 2+2*3+3+0, calibrated.estimateTestingEffort(testability))
+}
@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"path"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// CriticalFilesSection is the deep-dive into the files matched by
+// QualityReportConfig.CriticalFiles
+type CriticalFilesSection struct {
+	Files             []CriticalFile `json:"files"`
+	UnmatchedPatterns []string       `json:"unmatched_patterns,omitempty"` // patterns that matched no analyzed file, usually a typo or a moved file
+}
+
+// CriticalFile gathers every metric of one critical file. Entries are taken before
+// --max-findings and public-only filtering, so nothing about these files is left out.
+type CriticalFile struct {
+	Path            string               `json:"path"`
+	Pattern         string               `json:"pattern"` // first pattern that matched
+	Complexity      *FileComplexity      `json:"complexity,omitempty"`
+	Functions       []FunctionComplexity `json:"functions,omitempty"`
+	Maintainability *FileMaintainability `json:"maintainability,omitempty"`
+	Debt            *FileDebt            `json:"debt,omitempty"`
+	DebtItems       []TechnicalDebtItem  `json:"debt_items,omitempty"`
+	Testability     *FileTestability     `json:"testability,omitempty"`
+	UntestedPaths   []UntestedPath       `json:"untested_paths,omitempty"`
+	CoverageGaps    []CoverageGap        `json:"coverage_gaps,omitempty"`
+	Duplication     *FileDuplication     `json:"duplication,omitempty"`
+	AntiPatterns    []AntiPattern        `json:"anti_patterns,omitempty"`
+	Documentation   *FileDocumentation   `json:"documentation,omitempty"`
+}
+
+// BuildCriticalFiles collects the metrics of the files matching patterns from details.
+// files are the analyzed file paths; a pattern without a slash matches file names at any
+// depth, otherwise it matches the whole path and "**" stands for any number of directories.
+// It returns nil without patterns.
+func BuildCriticalFiles(patterns []string, files []string, details DetailedMetrics) *CriticalFilesSection {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	section := &CriticalFilesSection{Files: []CriticalFile{}}
+	matched := make(map[string]bool)
+	for _, file := range files {
+		file = ast.NormalizePath(file)
+		for _, pattern := range patterns {
+			if matchFileGlob(pattern, file) {
+				matched[pattern] = true
+				section.Files = append(section.Files, criticalFile(file, pattern, details))
+				break
+			}
+		}
+	}
+	for _, pattern := range patterns {
+		if !matched[pattern] {
+			section.UnmatchedPatterns = append(section.UnmatchedPatterns, pattern)
+		}
+	}
+	return section
+}
+
+// criticalFile picks the entries of file out of every analyzer's metrics
+func criticalFile(file, pattern string, details DetailedMetrics) CriticalFile {
+	critical := CriticalFile{Path: file, Pattern: pattern}
+	is := func(filePath string) bool { return ast.NormalizePath(filePath) == file }
+
+	if complexity := details.Complexity; complexity != nil {
+		for key, fileComplexity := range complexity.FileMetrics {
+			if is(key) {
+				critical.Complexity = &fileComplexity
+			}
+		}
+		for _, function := range complexity.FunctionMetrics {
+			if is(function.FilePath) {
+				critical.Functions = append(critical.Functions, function)
+			}
+		}
+	}
+
+	if maintainability := details.Maintainability; maintainability != nil {
+		for key, fileMaintainability := range maintainability.FileMetrics {
+			if is(key) {
+				critical.Maintainability = &fileMaintainability
+			}
+		}
+	}
+
+	if debt := details.TechnicalDebt; debt != nil {
+		for key, fileDebt := range debt.FileDebtScores {
+			if is(key) {
+				critical.Debt = &fileDebt
+			}
+		}
+		for _, name := range sortedKeys(debt.Categories) {
+			for _, item := range debt.Categories[name].Items {
+				if is(item.FilePath) {
+					critical.DebtItems = append(critical.DebtItems, item)
+				}
+			}
+		}
+	}
+
+	if coverage := details.Coverage; coverage != nil {
+		for key, testability := range coverage.FileAnalysis {
+			if is(key) {
+				critical.Testability = &testability
+			}
+		}
+		for _, untested := range coverage.UntestedPaths {
+			if is(untested.FilePath) {
+				critical.UntestedPaths = append(critical.UntestedPaths, untested)
+			}
+		}
+		for _, gap := range coverage.CoverageGaps {
+			if is(gap.FilePath) {
+				critical.CoverageGaps = append(critical.CoverageGaps, gap)
+			}
+		}
+	}
+
+	if duplication := details.Duplication; duplication != nil {
+		for key, fileDuplication := range duplication.DuplicationByFile {
+			if is(key) {
+				critical.Duplication = &fileDuplication
+			}
+		}
+	}
+
+	if performance := details.Performance; performance != nil {
+		for _, antiPattern := range performance.AntiPatterns {
+			if is(antiPattern.FilePath) {
+				critical.AntiPatterns = append(critical.AntiPatterns, antiPattern)
+			}
+		}
+	}
+
+	if documentation := details.Documentation; documentation != nil {
+		for key, fileDocumentation := range documentation.FileCoverage {
+			if is(key) {
+				critical.Documentation = &fileDocumentation
+			}
+		}
+	}
+
+	return critical
+}
+
+// matchFileGlob reports whether the slash-separated filePath matches pattern, see
+// BuildCriticalFiles
+func matchFileGlob(pattern, filePath string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(filePath))
+		return ok
+	}
+	return matchGlobSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(filePath, "/"))
+}
+
+// matchGlobSegments matches path segments one by one, letting "**" absorb zero or more
+func matchGlobSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlobSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pattern[0], segments[0])
+	return ok && matchGlobSegments(pattern[1:], segments[1:])
+}
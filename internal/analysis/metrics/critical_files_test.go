@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchFileGlob(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"main.js", "main.js", true},
+		{"main.js", "src/main.js", true},
+		{"*.auth.js", "src/login.auth.js", true},
+		{"src/payments/*.js", "src/payments/handler.js", true},
+		{"src/payments/*.js", "src/payments/stripe/handler.js", false},
+		{"src/**/auth.js", "src/auth.js", true},
+		{"src/**/auth.js", "src/api/v1/auth.js", true},
+		{"/src/index.js", "src/index.js", true},
+		{"lib/**", "lib/a/b.js", true},
+		{"lib/**", "src/lib/b.js", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchFileGlob(tt.pattern, tt.path), "%s vs %s", tt.pattern, tt.path)
+	}
+}
+
+func TestBuildCriticalFiles(t *testing.T) {
+	details := DetailedMetrics{
+		Complexity: &ComplexityMetrics{
+			FileMetrics:     map[string]FileComplexity{"src/auth.js": {FilePath: "src/auth.js", TotalComplexity: 12}},
+			FunctionMetrics: []FunctionComplexity{{Name: "login", FilePath: "src/auth.js"}, {Name: "other", FilePath: "src/util.js"}},
+		},
+		Performance: &PerformanceMetrics{AntiPatterns: []AntiPattern{
+			{Type: "nested_loops", FilePath: "src/auth.js"},
+			{Type: "sync_in_loop", FilePath: "src/auth.js"},
+			{Type: "nested_loops", FilePath: "src/util.js"},
+		}},
+		TechnicalDebt: &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+			"Code Smells": {Items: []TechnicalDebtItem{{ID: "code_smell_0", FilePath: "src/auth.js"}}},
+		}},
+	}
+
+	assert.Nil(t, BuildCriticalFiles(nil, []string{"src/auth.js"}, details))
+
+	section := BuildCriticalFiles([]string{"auth.js", "src/payments/**"}, []string{"src/auth.js", "src/util.js"}, details)
+	require.Len(t, section.Files, 1)
+	file := section.Files[0]
+	assert.Equal(t, "src/auth.js", file.Path)
+	assert.Equal(t, "auth.js", file.Pattern)
+	assert.Equal(t, 12, file.Complexity.TotalComplexity)
+	assert.Len(t, file.Functions, 1)
+	assert.Len(t, file.AntiPatterns, 2)
+	assert.Len(t, file.DebtItems, 1)
+	assert.Nil(t, file.Testability, "no coverage metrics")
+	assert.Equal(t, []string{"src/payments/**"}, section.UnmatchedPatterns)
+}
+
+func TestGenerateQualityReport_CriticalFilesIgnoreLimits(t *testing.T) {
+	files := map[string]string{
+		"src/auth.js": "function login(users, ids) {\n  for (const u of users) {\n    if (u.admin) { return u; }\n  }\n  for (const i of ids) {\n    if (i > 2) { return i; }\n  }\n  return null;\n}\n",
+		"src/util.js": "function add(a, b) {\n  return a + b;\n}\n",
+	}
+	config := QualityReportConfig{CriticalFiles: []string{"auth.js"}, MaxFindingsPerCategory: 1}
+
+	report, err := NewQualityReporter(config).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	require.NotNil(t, report.CriticalFiles)
+	require.Len(t, report.CriticalFiles.Files, 1)
+	auth := report.CriticalFiles.Files[0]
+	assert.NotNil(t, auth.Complexity)
+	assert.NotNil(t, auth.Maintainability)
+	assert.Greater(t, len(auth.UntestedPaths), 1, "not capped by MaxFindingsPerCategory")
+	assert.Len(t, report.DetailedMetrics.Coverage.UntestedPaths, 1)
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Data file kinds
+const (
+	DataFileLockfile = "lockfile"
+	DataFileSnapshot = "snapshot"
+	DataFileFixture  = "fixture"
+)
+
+// LargeJSONBytes is the size from which a JSON file is treated as data rather than config
+const LargeJSONBytes = 100 * 1024
+
+// lockfileNames are package manager lockfiles, generated and never read by hand
+var lockfileNames = map[string]bool{
+	"package-lock.json":   true,
+	"npm-shrinkwrap.json": true,
+	"yarn.lock":           true,
+	"pnpm-lock.yaml":      true,
+	"bun.lockb":           true,
+	"composer.lock":       true,
+	"Gemfile.lock":        true,
+	"Cargo.lock":          true,
+	"poetry.lock":         true,
+	"Pipfile.lock":        true,
+	"go.sum":              true,
+}
+
+// fixtureDirectories hold test data; JSON inside them is data whatever its size
+var fixtureDirectories = map[string]bool{
+	"fixtures":     true,
+	"__fixtures__": true,
+	"testdata":     true,
+}
+
+// DataFile is a file kept out of the code metrics because it is data, not code a
+// newcomer needs to read
+type DataFile struct {
+	Path  string `json:"path"`
+	Kind  string `json:"kind"` // lockfile, snapshot or fixture
+	Bytes int64  `json:"bytes"`
+	Lines int    `json:"lines"`
+}
+
+// LargeFile is a source file above the large-file threshold. It is counted in the language
+// statistics by streaming its lines but never loaded or parsed, so it has no code metrics.
+type LargeFile struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+	Lines int    `json:"lines"`
+}
+
+// VendoredDirectory is a directory of third-party code copied into the repository. Its
+// files are left out of the analysis so someone else's code does not grade the host.
+type VendoredDirectory struct {
+	Path    string   `json:"path"`
+	Files   int      `json:"files"`
+	Lines   int      `json:"lines"`
+	Reasons []string `json:"reasons"` // the heuristic signals that classified it
+}
+
+// ClassifyDataFile returns the data file kind of a slash-separated path of size bytes, or
+// "" for files that count as code or config: lockfiles by name, test snapshots, and JSON
+// that lives in a fixtures directory or exceeds LargeJSONBytes
+func ClassifyDataFile(filePath string, size int64) string {
+	base := path.Base(filePath)
+	if lockfileNames[base] {
+		return DataFileLockfile
+	}
+	if path.Ext(base) == ".snap" {
+		return DataFileSnapshot
+	}
+
+	if !strings.EqualFold(path.Ext(base), ".json") {
+		return ""
+	}
+	if size > LargeJSONBytes {
+		return DataFileFixture
+	}
+	for _, dir := range strings.Split(path.Dir(filePath), "/") {
+		if fixtureDirectories[dir] {
+			return DataFileFixture
+		}
+	}
+	return ""
+}
+
+// sortedDataFiles orders data files largest first, then by path
+func sortedDataFiles(files []DataFile) []DataFile {
+	if len(files) == 0 {
+		return nil
+	}
+	sorted := append([]DataFile(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Bytes != sorted[j].Bytes {
+			return sorted[i].Bytes > sorted[j].Bytes
+		}
+		return sorted[i].Path < sorted[j].Path
+	})
+	return sorted
+}
+
+// sortedLargeFiles orders large files largest first, then by path
+func sortedLargeFiles(files []LargeFile) []LargeFile {
+	if len(files) == 0 {
+		return nil
+	}
+	sorted := append([]LargeFile(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Bytes != sorted[j].Bytes {
+			return sorted[i].Bytes > sorted[j].Bytes
+		}
+		return sorted[i].Path < sorted[j].Path
+	})
+	return sorted
+}
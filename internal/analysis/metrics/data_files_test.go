@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyDataFile(t *testing.T) {
+	tests := []struct {
+		path string
+		size int64
+		want string
+	}{
+		{"package-lock.json", 10, DataFileLockfile},
+		{"web/yarn.lock", 10, DataFileLockfile},
+		{"src/__snapshots__/app.test.js.snap", 10, DataFileSnapshot},
+		{"test/fixtures/users.json", 10, DataFileFixture},
+		{"data/countries.json", LargeJSONBytes + 1, DataFileFixture},
+		{"package.json", 2048, ""},
+		{"tsconfig.json", 512, ""},
+		{"src/fixtures.js", 10, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ClassifyDataFile(tt.path, tt.size), tt.path)
+	}
+}
+
+func TestGenerateQualityReport_DataFiles(t *testing.T) {
+	config := QualityReportConfig{
+		OtherFileLines: map[string]int{"package.json": 20, "dist/bundle.js": 40000}, // the orchestrator adds large files here,
+		LargeFiles: []LargeFile{
+			{Path: "vendor/lib.js", Bytes: 2 << 20, Lines: 30000},
+			{Path: "dist/bundle.js", Bytes: 4 << 20, Lines: 40000},
+		},
+		DataFiles: []DataFile{
+			{Path: "test/fixtures/users.json", Kind: DataFileFixture, Bytes: 4096, Lines: 200},
+			{Path: "package-lock.json", Kind: DataFileLockfile, Bytes: 512000, Lines: 15000},
+		},
+	}
+	files := map[string]string{"src/a.js": "function a() {\n  return 1;\n}\n"}
+
+	report, err := NewQualityReporter(config).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	require.NotNil(t, report.Summary)
+	assert.Equal(t, []string{"package-lock.json", "test/fixtures/users.json"},
+		[]string{report.Summary.DataFiles[0].Path, report.Summary.DataFiles[1].Path}, "largest first")
+	assert.Equal(t, 1, report.Summary.TotalFiles, "data files are not counted as code")
+	assert.Equal(t, 20, report.LanguageStats[LanguageConfig].Lines)
+
+	assert.Equal(t, "dist/bundle.js", report.Summary.LargeFiles[0].Path, "largest first")
+	assert.Equal(t, 40003, report.LanguageStats["JavaScript"].Lines, "large files still count towards the language shares")
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// uncommittedSHA is the commit git blame reports for lines changed in the working tree
+const uncommittedSHA = "0000000000000000000000000000000000000000"
+
+// DebtChange is the "new in this change" subsection of the debt report: the debt items
+// with at least one line last modified by a commit after SinceRef or not committed yet
+type DebtChange struct {
+	SinceRef  string              `json:"since_ref"`
+	Commits   int                 `json:"commits"` // commits between SinceRef and HEAD
+	DebtHours float64             `json:"debt_hours"`
+	Items     []TechnicalDebtItem `json:"items"`
+}
+
+// CommitsSince returns the SHAs of the commits reachable from HEAD but not from ref, i.e.
+// the commits of a branch when ref is its base
+func (gh *GitHistoryAnalyzer) CommitsSince(ctx context.Context, ref string) (map[string]bool, error) {
+	output, err := gh.git(ctx, "rev-list", ref+"..HEAD", "--")
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make(map[string]bool)
+	for _, sha := range strings.Fields(string(output)) {
+		commits[sha] = true
+	}
+	return commits, nil
+}
+
+// blame returns the commit that last modified each line of file in the working tree,
+// indexed by line number - 1
+func (gh *GitHistoryAnalyzer) blame(ctx context.Context, file string) ([]string, error) {
+	output, err := gh.git(ctx, "blame", "--porcelain", "--", file)
+	if err != nil {
+		return nil, err
+	}
+	return parseGitBlame(output)
+}
+
+// git runs a git command in the repository and returns its standard output
+func (gh *GitHistoryAnalyzer) git(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", gh.repoPath}, args...)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+// parseGitBlame reads git blame --porcelain output: every source line is preceded by a
+// "<sha> <original line> <final line>" header and optional commit details, and is itself
+// prefixed with a tab
+func parseGitBlame(output []byte) ([]string, error) {
+	var commits []string
+	header := true
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			header = true
+		case header:
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("invalid blame header %q", line)
+			}
+			commits = append(commits, fields[0])
+			header = false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git blame output: %w", err)
+	}
+	return commits, nil
+}
+
+// NewDebtSince marks the debt items introduced after ref as NewInChange and returns them
+// as the "new in this change" subsection. An item is new when any line of its range was
+// last modified by a commit after ref or is not committed yet. Files git cannot blame,
+// such as untracked ones, are skipped.
+func (gh *GitHistoryAnalyzer) NewDebtSince(ctx context.Context, ref string, technicalDebt *TechnicalDebtMetrics) (*DebtChange, error) {
+	commits, err := gh.CommitsSince(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	blames := make(map[string][]string)
+	for _, file := range debtFiles(technicalDebt) {
+		blames[file], _ = gh.blame(ctx, file)
+	}
+	return markNewDebt(ref, commits, technicalDebt, blames), nil
+}
+
+// debtFiles returns the files holding debt items, in the order the items are visited
+func debtFiles(technicalDebt *TechnicalDebtMetrics) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, name := range sortedKeys(technicalDebt.Categories) {
+		for _, item := range technicalDebt.Categories[name].Items {
+			if !seen[item.FilePath] {
+				seen[item.FilePath] = true
+				files = append(files, item.FilePath)
+			}
+		}
+	}
+	return files
+}
+
+// markNewDebt flags the debt items with a line last modified by one of commits, given the
+// blamed commit of every line per file, and collects them into the subsection
+func markNewDebt(ref string, commits map[string]bool, technicalDebt *TechnicalDebtMetrics, blames map[string][]string) *DebtChange {
+	change := &DebtChange{SinceRef: ref, Commits: len(commits), Items: []TechnicalDebtItem{}}
+	for _, name := range sortedKeys(technicalDebt.Categories) {
+		items := technicalDebt.Categories[name].Items
+		for i := range items {
+			item := &items[i]
+			item.NewInChange = changedInRange(blames[item.FilePath], item.StartLine, item.EndLine, commits)
+			if item.NewInChange {
+				change.Items = append(change.Items, *item)
+				change.DebtHours += item.EstimatedHours
+			}
+		}
+	}
+	return change
+}
+
+// changedInRange reports whether any of the 1-based lines start..end was last modified by
+// one of commits or is uncommitted
+func changedInRange(lines []string, start, end int, commits map[string]bool) bool {
+	if start < 1 {
+		start = 1
+	}
+	if end < start {
+		end = start
+	}
+	for line := start; line <= end && line <= len(lines); line++ {
+		if sha := lines[line-1]; sha == uncommittedSHA || commits[sha] {
+			return true
+		}
+	}
+	return false
+}
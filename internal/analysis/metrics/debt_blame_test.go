@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHistoryAnalyzer_NewDebtSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", repo).Run())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	commitFile(t, repo, "src/app.js", "line 1\nline 2\nline 3\nline 4\n", now.AddDate(0, 0, -30))
+	require.NoError(t, exec.Command("git", "-C", repo, "tag", "base").Run())
+	commitFile(t, repo, "src/app.js", "line 1\nline 2\nchanged 3\nline 4\n", now.AddDate(0, 0, -1))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "src/app.js"), []byte("line 1\nline 2\nchanged 3\nedited 4\n"), 0644))
+
+	technicalDebt := &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+		"Code Smells": {Items: []TechnicalDebtItem{
+			{ID: "old", FilePath: "src/app.js", StartLine: 1, EndLine: 2, EstimatedHours: 1},
+			{ID: "committed", FilePath: "src/app.js", StartLine: 2, EndLine: 3, EstimatedHours: 2},
+			{ID: "uncommitted", FilePath: "src/app.js", StartLine: 4, EstimatedHours: 0.5},
+			{ID: "untracked", FilePath: "src/missing.js", StartLine: 1, EstimatedHours: 3},
+		}},
+	}}
+
+	change, err := NewGitHistoryAnalyzer(repo).NewDebtSince(context.Background(), "base", technicalDebt)
+	require.NoError(t, err)
+
+	assert.Equal(t, "base", change.SinceRef)
+	assert.Equal(t, 1, change.Commits)
+	assert.Equal(t, 2.5, change.DebtHours)
+	require.Len(t, change.Items, 2)
+	assert.Equal(t, "committed", change.Items[0].ID)
+	assert.Equal(t, "uncommitted", change.Items[1].ID)
+
+	items := technicalDebt.Categories["Code Smells"].Items
+	assert.False(t, items[0].NewInChange)
+	assert.True(t, items[1].NewInChange)
+	assert.True(t, items[2].NewInChange)
+	assert.False(t, items[3].NewInChange, "files git cannot blame are skipped")
+
+	_, err = NewGitHistoryAnalyzer(repo).NewDebtSince(context.Background(), "no-such-ref", technicalDebt)
+	assert.Error(t, err)
+}
+
+func TestParseGitBlame(t *testing.T) {
+	output := []byte("aaaa 1 1 2\nauthor test\nsummary first\nfilename a.js\n\tline 1\n" +
+		"aaaa 2 2\n\tline 2\n" +
+		"bbbb 3 3 1\nauthor other\nfilename a.js\n\tline 3\n")
+
+	commits, err := parseGitBlame(output)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aaaa", "aaaa", "bbbb"}, commits)
+
+	_, err = parseGitBlame([]byte("garbage\n"))
+	assert.Error(t, err)
+}
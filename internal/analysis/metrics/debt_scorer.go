@@ -31,6 +31,20 @@ type DebtScoringConfig struct {
 	TrendAnalysisPeriod int     `yaml:"trend_analysis_period" json:"trend_analysis_period"` // days
 	PriorityCategories  int     `yaml:"priority_categories" json:"priority_categories"`
 	MinConfidenceScore  float64 `yaml:"min_confidence_score" json:"min_confidence_score"`
+
+	CommentedCodeMinLines  int      `yaml:"commented_code_min_lines" json:"commented_code_min_lines"` // code lines before a comment block is flagged
+	TodoDebtTags           []string `yaml:"todo_debt_tags" json:"todo_debt_tags"`                     // marker comments reported as debt
+	CallbackDepthThreshold int      `yaml:"callback_depth_threshold" json:"callback_depth_threshold"` // nested callback levels before a function is flagged
+	SwitchCaseThreshold    int      `yaml:"switch_case_threshold" json:"switch_case_threshold"`       // switch or if/else-if cases before a chain is flagged
+
+	FunctionSize FunctionSizeThresholds `yaml:"function_size" json:"function_size"` // unset values use DefaultFunctionSizeThresholds
+	GodFile      GodFileThresholds      `yaml:"god_file" json:"god_file"`           // unset values use DefaultGodFileThresholds
+
+	SeverityOverrides SeverityOverrides `yaml:"severity_overrides" json:"severity_overrides,omitempty"` // severity by item type, replacing the detected one
+
+	// ActiveFiles, when non-nil, limits debt items, file scores and the remediation plan
+	// to these files (e.g. those changed recently); analyzers still see the whole codebase
+	ActiveFiles map[string]bool `yaml:"-" json:"-"`
 }
 
 // TechnicalDebtMetrics contains comprehensive technical debt analysis
@@ -47,6 +61,9 @@ type TechnicalDebtMetrics struct {
 
 	Dashboard TechnicalDebtDashboard `json:"dashboard"`
 	Summary   DebtSummary            `json:"summary"`
+
+	SuppressedFindings map[string]int `json:"suppressed_findings,omitempty"` // debt items silenced by ignore comments, by type
+	NewInChange        *DebtChange    `json:"new_in_change,omitempty"`       // debt introduced since a base ref, from git blame
 }
 
 // DebtCategory represents a category of technical debt
@@ -80,6 +97,9 @@ type TechnicalDebtItem struct {
 	ChangeFrequency float64 `json:"change_frequency"`
 	ImpactScore     float64 `json:"impact_score"`
 	ConfidenceScore float64 `json:"confidence_score"`
+	Informational   bool    `json:"informational,omitempty"` // below the gate's minimum confidence: reported, never gating
+	NewInChange     bool    `json:"new_in_change,omitempty"` // lines modified after the --since-ref base, see DebtChange
+	Accepted        bool    `json:"accepted,omitempty"`      // complexity accepted with an annotation: reported, never gating
 
 	RemediationSteps []string               `json:"remediation_steps"`
 	RelatedIssues    []string               `json:"related_issues"`
@@ -142,6 +162,8 @@ type TechnicalDebtDashboard struct {
 	QuickWins           []RemediationItem  `json:"quick_wins"`
 	LongTermInitiatives []RemediationItem  `json:"long_term_initiatives"`
 	MonthlyTrend        []TrendDataPoint   `json:"monthly_trend"`
+	GodFiles            []GodFile          `json:"god_files,omitempty"`         // high-priority refactor targets, longest first
+	MixedAsyncFiles     []MixedAsyncFile   `json:"mixed_async_files,omitempty"` // files mixing async styles, worst first
 }
 
 // FileRanking represents file ranking by debt score
@@ -189,6 +211,14 @@ func NewDebtScorer() *DebtScorer {
 			TrendAnalysisPeriod: 30,
 			PriorityCategories:  4,
 			MinConfidenceScore:  0.60,
+
+			CommentedCodeMinLines: defaultCommentedCodeMinLines,
+			TodoDebtTags:          []string{"FIXME", "HACK"},
+
+			CallbackDepthThreshold: defaultCallbackDepthThreshold,
+			SwitchCaseThreshold:    defaultSwitchCaseThreshold,
+			FunctionSize:           DefaultFunctionSizeThresholds(),
+			GodFile:                DefaultGodFileThresholds(),
 		},
 	}
 }
@@ -236,6 +266,12 @@ func (ds *DebtScorer) AnalyzeDebt(ctx context.Context, parseResults []*ast.Parse
 	// Combine all debt items
 	allDebtItems := []TechnicalDebtItem{}
 	allDebtItems = append(allDebtItems, codeSmellItems...)
+	allDebtItems = append(allDebtItems, ds.analyzeCommentedOutCode(parseResults)...)
+	allDebtItems = append(allDebtItems, ds.analyzeCallbackPyramids(parseResults)...)
+	allDebtItems = append(allDebtItems, ds.analyzeLargeSwitches(parseResults)...)
+	allDebtItems = append(allDebtItems, ds.analyzeUnhandledAsync(parseResults)...)
+	allDebtItems = append(allDebtItems, ds.analyzeMixedAsyncStyles(parseResults)...)
+	allDebtItems = append(allDebtItems, ds.analyzeTodoComments(parseResults)...)
 	allDebtItems = append(allDebtItems, architectureItems...)
 	allDebtItems = append(allDebtItems, performanceItems...)
 
@@ -245,6 +281,24 @@ func (ds *DebtScorer) AnalyzeDebt(ctx context.Context, parseResults []*ast.Parse
 	allDebtItems = append(allDebtItems, complexityItems...)
 	allDebtItems = append(allDebtItems, duplicationItems...)
 
+	// Drop items silenced by repo-onboarding-ignore comments
+	suppressed := make(map[string]int)
+	allDebtItems = newSuppressionIndex(parseResults).filterDebtItems(allDebtItems, suppressed)
+	metrics.SuppressedFindings = mergeSuppressedCounts(suppressed)
+
+	for i := range allDebtItems {
+		allDebtItems[i].Severity = ds.config.SeverityOverrides.severity(allDebtItems[i].Type, allDebtItems[i].Severity)
+	}
+
+	// Import cycles need the whole graph, so they are found before the change-window filter
+	cycles := findImportCycles(buildImportGraph(parseResults))
+
+	if ds.config.ActiveFiles != nil {
+		allDebtItems = activeDebtItems(allDebtItems, ds.config.ActiveFiles)
+		parseResults = activeParseResults(parseResults, ds.config.ActiveFiles)
+		cycles = activeImportCycles(cycles, ds.config.ActiveFiles)
+	}
+
 	// Calculate debt scores and prioritization
 	ds.calculateDebtScores(allDebtItems)
 	ds.calculatePriorities(allDebtItems)
@@ -268,7 +322,7 @@ func (ds *DebtScorer) AnalyzeDebt(ctx context.Context, parseResults []*ast.Parse
 	metrics.TrendDirection = ds.calculateTrendDirection(allDebtItems)
 
 	// Generate dashboard
-	metrics.Dashboard = ds.generateDashboard(allDebtItems, metrics.FileDebtScores)
+	metrics.Dashboard = ds.generateDashboard(allDebtItems, metrics.FileDebtScores, cycles)
 
 	// Generate summary
 	metrics.Summary = ds.generateSummary(parseResults, allDebtItems, metrics.FileDebtScores)
@@ -423,7 +477,7 @@ func (ds *DebtScorer) analyzeArchitectureViolations(parseResults []*ast.ParseRes
 				Category:       "Architecture Violations",
 				FilePath:       parseResult.FilePath,
 				StartLine:      1,
-				EndLine:        ds.estimateFileLineCount(parseResult),
+				EndLine:        ds.fileLineCount(parseResult),
 				Description:    fmt.Sprintf("File '%s' has circular dependency patterns that violate clean architecture", parseResult.FilePath),
 				Severity:       "high",
 				EstimatedHours: 4.0,
@@ -444,31 +498,9 @@ func (ds *DebtScorer) analyzeArchitectureViolations(parseResults []*ast.ParseRes
 			itemID++
 		}
 
-		// Analyze God Object pattern
-		if ds.hasGodObjectPattern(parseResult) {
-			item := TechnicalDebtItem{
-				ID:             fmt.Sprintf("arch_violation_%d", itemID),
-				Type:           "god_object",
-				Category:       "Architecture Violations",
-				FilePath:       parseResult.FilePath,
-				StartLine:      1,
-				EndLine:        ds.estimateFileLineCount(parseResult),
-				Description:    fmt.Sprintf("File '%s' contains god object with excessive responsibilities", parseResult.FilePath),
-				Severity:       "high",
-				EstimatedHours: 6.0,
-				RemediationSteps: []string{
-					"Identify distinct responsibilities",
-					"Extract functionality into focused services",
-					"Apply single responsibility principle",
-					"Create proper abstraction layers",
-					"Implement dependency injection",
-				},
-				Metadata: map[string]interface{}{
-					"function_count": len(parseResult.Functions),
-					"class_count":    len(parseResult.Classes),
-				},
-			}
-			items = append(items, item)
+		// Analyze god files
+		if godFile, ok := ds.godFile(parseResult); ok {
+			items = append(items, godFileItem(itemID, godFile))
 			itemID++
 		}
 
@@ -480,7 +512,7 @@ func (ds *DebtScorer) analyzeArchitectureViolations(parseResults []*ast.ParseRes
 				Category:       "Architecture Violations",
 				FilePath:       parseResult.FilePath,
 				StartLine:      1,
-				EndLine:        ds.estimateFileLineCount(parseResult),
+				EndLine:        ds.fileLineCount(parseResult),
 				Description:    fmt.Sprintf("File '%s' exhibits tight coupling with other modules", parseResult.FilePath),
 				Severity:       "medium",
 				EstimatedHours: 3.0,
@@ -507,7 +539,7 @@ func (ds *DebtScorer) analyzeArchitectureViolations(parseResults []*ast.ParseRes
 				Category:       "Architecture Violations",
 				FilePath:       parseResult.FilePath,
 				StartLine:      1,
-				EndLine:        ds.estimateFileLineCount(parseResult),
+				EndLine:        ds.fileLineCount(parseResult),
 				Description:    fmt.Sprintf("File '%s' violates architectural layering principles", parseResult.FilePath),
 				Severity:       "medium",
 				EstimatedHours: 2.5,
@@ -654,22 +686,24 @@ func (ds *DebtScorer) analyzePerformanceIssues(parseResults []*ast.ParseResult)
 
 // Helper functions for debt analysis
 func (ds *DebtScorer) isLongMethod(function ast.FunctionInfo) bool {
-	lineCount := function.EndLine - function.StartLine + 1
-	return lineCount > 30 // Threshold for long method
+	return functionLineCount(function) > ds.config.FunctionSize.withDefaults().Smell
 }
 
+// determineLongMethodSeverity is high past the critical size and medium past the
+// midpoint between the smell and critical sizes
 func (ds *DebtScorer) determineLongMethodSeverity(function ast.FunctionInfo) string {
-	lineCount := function.EndLine - function.StartLine + 1
-	if lineCount > 100 {
+	thresholds := ds.config.FunctionSize.withDefaults()
+	lineCount := functionLineCount(function)
+	if lineCount > thresholds.Critical {
 		return "high"
-	} else if lineCount > 50 {
+	} else if lineCount > (thresholds.Smell+thresholds.Critical)/2 {
 		return "medium"
 	}
 	return "low"
 }
 
 func (ds *DebtScorer) estimateLongMethodRemediationEffort(function ast.FunctionInfo) float64 {
-	lineCount := function.EndLine - function.StartLine + 1
+	lineCount := functionLineCount(function)
 	baseHours := 2.0
 	return baseHours + (float64(lineCount)/50.0)*1.0 // Add 1 hour per 50 lines
 }
@@ -724,12 +758,6 @@ func (ds *DebtScorer) hasCircularDependencies(parseResult *ast.ParseResult) bool
 	return len(parseResult.Imports) > 10 && len(parseResult.Exports) > 5
 }
 
-func (ds *DebtScorer) hasGodObjectPattern(parseResult *ast.ParseResult) bool {
-	// Check for excessive functionality in a single file
-	totalComplexity := len(parseResult.Functions) + len(parseResult.Classes)*3
-	return totalComplexity > 50
-}
-
 func (ds *DebtScorer) hasTightCoupling(parseResult *ast.ParseResult) bool {
 	// Heuristic based on import/export ratios
 	return len(parseResult.Imports) > 15
@@ -849,7 +877,13 @@ func (ds *DebtScorer) hasExcessiveImports(parseResult *ast.ParseResult) bool {
 	return len(parseResult.Imports) > 20
 }
 
-func (ds *DebtScorer) estimateFileLineCount(parseResult *ast.ParseResult) int {
+// fileLineCount returns the physical line count of the file, used as EndLine for file-level items
+func (ds *DebtScorer) fileLineCount(parseResult *ast.ParseResult) int {
+	if parseResult.LineCount > 0 {
+		return parseResult.LineCount
+	}
+
+	// Parse results built without source content: fall back to the last declaration
 	maxLine := 0
 
 	for _, function := range parseResult.Functions {
@@ -865,7 +899,7 @@ func (ds *DebtScorer) estimateFileLineCount(parseResult *ast.ParseResult) int {
 	}
 
 	if maxLine == 0 {
-		maxLine = 100 // Default estimate
+		maxLine = 1
 	}
 
 	return maxLine
@@ -890,6 +924,7 @@ func (ds *DebtScorer) convertComplexityToDebt(complexityMetrics *ComplexityMetri
 				Severity:         functionMetric.SeverityLevel,
 				EstimatedHours:   ds.estimateComplexityRemediationEffort(functionMetric.WeightedScore),
 				RemediationSteps: functionMetric.Recommendations,
+				Accepted:         functionMetric.Accepted,
 				Metadata: map[string]interface{}{
 					"complexity_score": functionMetric.WeightedScore,
 					"cyclomatic":       functionMetric.CyclomaticValue,
@@ -973,6 +1008,11 @@ func (ds *DebtScorer) calculatePriorities(items []TechnicalDebtItem) {
 			(item.ChangeFrequency * ds.config.ChangeFrequencyWeight)
 
 		item.Priority = ds.scoreToPriority(priorityScore)
+
+		// God files are where newcomers get lost, so they are always a high-priority target
+		if item.Type == "god_object" && item.Priority != "critical" {
+			item.Priority = "high"
+		}
 	}
 }
 
@@ -987,7 +1027,8 @@ func (ds *DebtScorer) organizeByCategories(items []TechnicalDebtItem) map[string
 	}
 
 	// Create category summaries
-	for categoryName, catItems := range categoryItems {
+	for _, categoryName := range sortedKeys(categoryItems) {
+		catItems := categoryItems[categoryName]
 		category := DebtCategory{
 			Name:  categoryName,
 			Items: catItems,
@@ -1053,13 +1094,14 @@ func (ds *DebtScorer) calculateFileDebtScores(parseResults []*ast.ParseResult, i
 
 	// Calculate priorities and remediation order
 	fileList := make([]FileDebt, 0, len(fileDebts))
-	for _, debt := range fileDebts {
+	for _, filePath := range sortedKeys(fileDebts) {
+		debt := fileDebts[filePath]
 		debt.Priority = ds.scoreToFilePriority(debt.OverallScore)
 		fileList = append(fileList, debt)
 	}
 
 	// Sort by overall score for remediation order
-	sort.Slice(fileList, func(i, j int) bool {
+	sort.SliceStable(fileList, func(i, j int) bool {
 		return fileList[i].OverallScore > fileList[j].OverallScore
 	})
 
@@ -1080,7 +1122,7 @@ func (ds *DebtScorer) generateRemediationPlan(items []TechnicalDebtItem) []Remed
 	sortedItems := make([]TechnicalDebtItem, len(items))
 	copy(sortedItems, items)
 
-	sort.Slice(sortedItems, func(i, j int) bool {
+	sort.SliceStable(sortedItems, func(i, j int) bool {
 		return sortedItems[i].ImpactScore > sortedItems[j].ImpactScore
 	})
 
@@ -1103,10 +1145,7 @@ func (ds *DebtScorer) generateRemediationPlan(items []TechnicalDebtItem) []Remed
 			affectedFiles[item.FilePath] = true
 		}
 
-		files := make([]string, 0, len(affectedFiles))
-		for file := range affectedFiles {
-			files = append(files, file)
-		}
+		files := sortedKeys(affectedFiles)
 
 		remediationItem := RemediationItem{
 			ID:               fmt.Sprintf("remediation_%d", i),
@@ -1133,7 +1172,8 @@ func (ds *DebtScorer) generateRecommendations(items []TechnicalDebtItem, categor
 	recommendations := []DebtRecommendation{}
 
 	// Analyze category patterns for strategic recommendations
-	for categoryName, category := range categories {
+	for _, categoryName := range sortedKeys(categories) {
+		category := categories[categoryName]
 		if category.Score > 50.0 { // Significant debt in this category
 			recommendation := ds.generateCategoryRecommendation(categoryName, category)
 			recommendations = append(recommendations, recommendation)
@@ -1168,12 +1208,16 @@ func (ds *DebtScorer) mapPriorityToSeverity(priority string) string {
 
 func (ds *DebtScorer) severityToScore(severity string) float64 {
 	switch severity {
+	case "critical":
+		return 15.0
 	case "high":
 		return 10.0
 	case "medium":
 		return 6.0
 	case "low":
 		return 3.0
+	case "info":
+		return 0.0
 	default:
 		return 1.0
 	}
@@ -1352,7 +1396,7 @@ func (ds *DebtScorer) calculateTrendDirection(items []TechnicalDebtItem) string
 	return "stable"
 }
 
-func (ds *DebtScorer) generateDashboard(items []TechnicalDebtItem, fileDebts map[string]FileDebt) TechnicalDebtDashboard {
+func (ds *DebtScorer) generateDashboard(items []TechnicalDebtItem, fileDebts map[string]FileDebt, cycles []importCycle) TechnicalDebtDashboard {
 	dashboard := TechnicalDebtDashboard{
 		CategoryBreakdown:   make(map[string]float64),
 		FileRankings:        []FileRanking{},
@@ -1381,7 +1425,8 @@ func (ds *DebtScorer) generateDashboard(items []TechnicalDebtItem, fileDebts map
 
 	// Generate file rankings
 	fileList := make([]FileRanking, 0, len(fileDebts))
-	for _, debt := range fileDebts {
+	for _, filePath := range sortedKeys(fileDebts) {
+		debt := fileDebts[filePath]
 		if debt.OverallScore > 0 {
 			ranking := FileRanking{
 				FilePath:   debt.FilePath,
@@ -1395,7 +1440,7 @@ func (ds *DebtScorer) generateDashboard(items []TechnicalDebtItem, fileDebts map
 	}
 
 	// Sort by debt score
-	sort.Slice(fileList, func(i, j int) bool {
+	sort.SliceStable(fileList, func(i, j int) bool {
 		return fileList[i].DebtScore > fileList[j].DebtScore
 	})
 
@@ -1409,6 +1454,14 @@ func (ds *DebtScorer) generateDashboard(items []TechnicalDebtItem, fileDebts map
 	// Generate quick wins and long-term initiatives
 	dashboard.QuickWins, dashboard.LongTermInitiatives = ds.categorizeRemediationEfforts(items)
 
+	// Short import cycles are cheap to break and make the module structure easier to follow
+	cycleQuickWins, cycleLongTerm := ds.cycleRemediationItems(cycles)
+	dashboard.QuickWins = append(dashboard.QuickWins, cycleQuickWins...)
+	dashboard.LongTermInitiatives = append(dashboard.LongTermInitiatives, cycleLongTerm...)
+
+	dashboard.GodFiles = godFilesFromItems(items)
+	dashboard.MixedAsyncFiles = mixedAsyncFilesFromItems(items)
+
 	return dashboard
 }
 
@@ -1422,7 +1475,8 @@ func (ds *DebtScorer) generateSummary(parseResults []*ast.ParseResult, items []T
 		totalDebtScore := 0.0
 		maxDebtScore := 0.0
 
-		for _, debt := range fileDebts {
+		for _, filePath := range sortedKeys(fileDebts) {
+			debt := fileDebts[filePath]
 			totalDebtScore += debt.OverallScore
 			if debt.OverallScore > maxDebtScore {
 				maxDebtScore = debt.OverallScore
@@ -1528,7 +1582,8 @@ func (ds *DebtScorer) determineRecommendedFocus(items []TechnicalDebtItem) strin
 	maxScore := 0.0
 	recommendedCategory := "Code Quality"
 
-	for category, score := range categoryScores {
+	for _, category := range sortedKeys(categoryScores) {
+		score := categoryScores[category]
 		if score > maxScore {
 			maxScore = score
 			recommendedCategory = category
@@ -1570,7 +1625,8 @@ func (ds *DebtScorer) groupRelatedItems(items []TechnicalDebtItem) [][]Technical
 	}
 
 	result := [][]TechnicalDebtItem{}
-	for _, group := range groups {
+	for _, category := range sortedKeys(groups) {
+		group := groups[category]
 		if len(group) > 0 {
 			result = append(result, group)
 		}
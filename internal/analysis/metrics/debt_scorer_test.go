@@ -245,6 +245,21 @@ func TestIsLargeClass(t *testing.T) {
 	}
 }
 
+func TestAnalyzeArchitectureViolations_UsesFileLineCount(t *testing.T) {
+	scorer := NewDebtScorer()
+
+	parseResult := createMockParseResultWithImportsExports("complex.js", 15, 8)
+	parseResult.LineCount = 412
+
+	items, err := scorer.analyzeArchitectureViolations([]*ast.ParseResult{parseResult})
+	require.NoError(t, err)
+	require.NotEmpty(t, items)
+	for _, item := range items {
+		assert.Equal(t, 1, item.StartLine)
+		assert.Equal(t, 412, item.EndLine)
+	}
+}
+
 func TestHasCircularDependencies(t *testing.T) {
 	scorer := NewDebtScorer()
 
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+//go:embed data/dependency_sizes.json
+var defaultDependencySizesJSON []byte
+
+// DependencySizes maps npm package names to estimated minified bundle sizes. The built-in
+// table ships as data/dependency_sizes.json; LoadDependencySizes layers a user file on top.
+type DependencySizes struct {
+	Libraries map[string]DependencySize `yaml:"libraries" json:"libraries"`
+}
+
+// DependencySize is the estimated cost of bundling one package
+type DependencySize struct {
+	SizeKB       int            `yaml:"size_kb" json:"size_kb"`
+	Versions     map[string]int `yaml:"versions" json:"versions,omitempty"` // size by major version, e.g. {"4": 2000, "5": 1200}
+	Alternatives []string       `yaml:"alternatives" json:"alternatives,omitempty"`
+}
+
+var (
+	defaultDependencySizesOnce sync.Once
+	defaultDependencySizes     *DependencySizes
+)
+
+// DefaultDependencySizes returns the built-in size table. Callers must not modify it.
+func DefaultDependencySizes() *DependencySizes {
+	defaultDependencySizesOnce.Do(func() {
+		sizes, err := parseDependencySizes(defaultDependencySizesJSON)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded dependency sizes: %v", err))
+		}
+		defaultDependencySizes = sizes
+	})
+	return defaultDependencySizes
+}
+
+// LoadDependencySizes reads a size table from a JSON file and merges it over the built-in
+// one: packages in the file replace the built-in entries, all others are kept
+func LoadDependencySizes(path string) (*DependencySizes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dependency sizes: %w", err)
+	}
+
+	overrides, err := parseDependencySizes(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dependency sizes in %s: %w", path, err)
+	}
+
+	merged := &DependencySizes{Libraries: make(map[string]DependencySize)}
+	for name, size := range DefaultDependencySizes().Libraries {
+		merged.Libraries[name] = size
+	}
+	for name, size := range overrides.Libraries {
+		merged.Libraries[name] = size
+	}
+	return merged, nil
+}
+
+// parseDependencySizes decodes and validates a size table
+func parseDependencySizes(data []byte) (*DependencySizes, error) {
+	var sizes DependencySizes
+	if err := json.Unmarshal(data, &sizes); err != nil {
+		return nil, fmt.Errorf("failed to parse dependency sizes: %w", err)
+	}
+	if err := sizes.Validate(); err != nil {
+		return nil, err
+	}
+	return &sizes, nil
+}
+
+// Validate checks that every package has a positive size
+func (d *DependencySizes) Validate() error {
+	if len(d.Libraries) == 0 {
+		return fmt.Errorf("no libraries listed")
+	}
+	for _, name := range sortedKeys(d.Libraries) {
+		size := d.Libraries[name]
+		if size.SizeKB <= 0 {
+			return fmt.Errorf("size_kb for %s must be positive, got %d", name, size.SizeKB)
+		}
+		for _, major := range sortedKeys(size.Versions) {
+			if size.Versions[major] <= 0 {
+				return fmt.Errorf("size_kb for %s@%s must be positive, got %d", name, major, size.Versions[major])
+			}
+		}
+	}
+	return nil
+}
+
+// Lookup returns the package imported by source ("lodash/debounce" is lodash) and its size,
+// using the size of the major version in versions (package name -> package.json range)
+// when the table lists it
+func (d *DependencySizes) Lookup(source string, versions map[string]string) (string, int, bool) {
+	name := packageName(source)
+	size, ok := d.Libraries[name]
+	if !ok {
+		return name, 0, false
+	}
+
+	if sizeKB, ok := size.Versions[majorVersion(versions[name])]; ok {
+		return name, sizeKB, true
+	}
+	return name, size.SizeKB, true
+}
+
+// packageName returns the npm package of an import specifier: "@scope/pkg" for
+// "@scope/pkg/sub", "pkg" for "pkg/sub". Relative specifiers have no package.
+func packageName(source string) string {
+	source = strings.ToLower(source)
+	if source == "" || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") {
+		return ""
+	}
+
+	segments := strings.SplitN(source, "/", 3)
+	if strings.HasPrefix(source, "@") && len(segments) > 1 {
+		return segments[0] + "/" + segments[1]
+	}
+	return segments[0]
+}
+
+// majorVersion extracts the major version from a package.json range such as "^4.17.21"
+// or "~5.0"; ranges without a leading number (tags, URLs, workspaces) yield ""
+func majorVersion(version string) string {
+	version = strings.TrimLeft(strings.TrimSpace(version), "^~>=<v ")
+	end := 0
+	for end < len(version) && version[end] >= '0' && version[end] <= '9' {
+		end++
+	}
+	return version[:end]
+}
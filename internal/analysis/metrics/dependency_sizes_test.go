@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestDefaultDependencySizes(t *testing.T) {
+	sizes := DefaultDependencySizes()
+	require.NoError(t, sizes.Validate())
+	assert.Contains(t, sizes.Libraries, "lodash")
+	assert.Contains(t, sizes.Libraries, "@mui/material")
+}
+
+func TestDependencySizesLookup(t *testing.T) {
+	sizes := DefaultDependencySizes()
+
+	name, sizeKB, ok := sizes.Lookup("lodash/debounce", nil)
+	require.True(t, ok)
+	assert.Equal(t, "lodash", name)
+	assert.Equal(t, sizes.Libraries["lodash"].SizeKB, sizeKB)
+
+	_, sizeKB, ok = sizes.Lookup("antd", map[string]string{"antd": "^4.24.0"})
+	require.True(t, ok)
+	assert.Equal(t, 2000, sizeKB)
+
+	_, sizeKB, ok = sizes.Lookup("antd", map[string]string{"antd": "^9.0.0"})
+	require.True(t, ok)
+	assert.Equal(t, sizes.Libraries["antd"].SizeKB, sizeKB, "unknown major versions use the default size")
+
+	name, _, ok = sizes.Lookup("@mui/material/Button", nil)
+	require.True(t, ok)
+	assert.Equal(t, "@mui/material", name)
+
+	_, _, ok = sizes.Lookup("lodash-like", nil)
+	assert.False(t, ok, "packages are matched by name, not substring")
+	_, _, ok = sizes.Lookup("./moment", nil)
+	assert.False(t, ok)
+}
+
+func TestLoadDependencySizes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sizes.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"libraries": {
+		"lodash": {"size_kb": 5},
+		"left-pad": {"size_kb": 300, "alternatives": ["String.prototype.padStart"]}
+	}}`), 0o644))
+
+	sizes, err := LoadDependencySizes(path)
+	require.NoError(t, err)
+	assert.Equal(t, 5, sizes.Libraries["lodash"].SizeKB)
+	assert.Equal(t, 300, sizes.Libraries["left-pad"].SizeKB)
+	assert.Equal(t, DefaultDependencySizes().Libraries["moment"], sizes.Libraries["moment"])
+	assert.NotEqual(t, 5, DefaultDependencySizes().Libraries["lodash"].SizeKB, "built-in table is not modified")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"libraries": {"lodash": {"size_kb": 0}}}`), 0o644))
+	_, err = LoadDependencySizes(path)
+	assert.ErrorContains(t, err, "size_kb for lodash must be positive")
+
+	_, err = LoadDependencySizes(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestPackageNameAndMajorVersion(t *testing.T) {
+	assert.Equal(t, "lodash", packageName("lodash/fp"))
+	assert.Equal(t, "@angular/core", packageName("@angular/core/testing"))
+	assert.Equal(t, "", packageName("../lib/lodash"))
+
+	assert.Equal(t, "4", majorVersion("^4.17.21"))
+	assert.Equal(t, "5", majorVersion("~5.0"))
+	assert.Equal(t, "12", majorVersion(">=12.1.0 <13"))
+	assert.Equal(t, "", majorVersion("latest"))
+}
+
+func TestAnalyzeBundleSize_ConfiguredSizes(t *testing.T) {
+	analyzer := NewPerformanceAnalyzer()
+	analyzer.config.DependencyVersions = map[string]string{"antd": "^4.24.0"}
+	analyzer.config.DependencySizes = &DependencySizes{Libraries: map[string]DependencySize{
+		"antd":     {SizeKB: 1200, Versions: map[string]int{"4": 2000}},
+		"left-pad": {SizeKB: 300, Alternatives: []string{"String.prototype.padStart"}},
+	}}
+	metrics := &PerformanceMetrics{}
+
+	analyzer.analyzeBundleSize([]*ast.ParseResult{{
+		FilePath: "app.js",
+		Imports: []ast.ImportInfo{
+			{Source: "antd", ImportType: "named", Specifiers: []string{"Button"}},
+			{Source: "left-pad", ImportType: "default"},
+			{Source: "lodash", ImportType: "default"},
+		},
+	}}, metrics)
+
+	deps := metrics.BundleAnalysis.HeavyDependencies
+	require.Len(t, deps, 2, "libraries missing from the configured table are not heavy")
+	assert.Equal(t, "antd", deps[0].Name)
+	assert.Equal(t, 2000, deps[0].EstimatedSizeKB)
+	assert.Equal(t, "^4.24.0", deps[0].Version)
+	assert.Equal(t, []string{"String.prototype.padStart"}, deps[1].Alternatives)
+}
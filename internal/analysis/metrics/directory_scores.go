@@ -0,0 +1,209 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// DirectoryScore aggregates the per-file metrics of every file at or below a directory.
+// Scores holds the components that can be measured per file, keyed like ComponentScores
+// (complexity, duplication, coverage, maintainability); a component is absent when no
+// file below the directory has data for it.
+type DirectoryScore struct {
+	Path         string             `json:"path"` // slash-separated, "." for the repository root
+	Depth        int                `json:"depth"`
+	Files        int                `json:"files"`
+	OverallScore float64            `json:"overall_score"` // Scores weighted like the repository score
+	Scores       map[string]float64 `json:"scores"`
+	DebtHours    float64            `json:"debt_hours"`
+}
+
+// DirectoryScoreMap holds the DirectoryScore of every directory, keyed by path
+type DirectoryScoreMap map[string]DirectoryScore
+
+// directoryTotals accumulates the per-file metrics of one directory before they are
+// turned into scores
+type directoryTotals struct {
+	files                    int
+	complexity, functions    float64 // cyclomatic complexity and function count, for the average
+	duplicationRatio         float64 // sum of file ratios, averaged over files
+	coverage, coverageWeight float64
+	maintainability          float64
+	maintainabilityWeight    float64
+	debtHours                float64
+	hasComplexity            bool
+	hasDuplication           bool
+	hasCoverage              bool
+	hasMaintainability       bool
+}
+
+// directoryScores rolls the per-file metrics up to every directory containing a file, so
+// "src" includes "src/api" and "." includes everything. Averages are weighted by function
+// count and use the same formulas as the repository-wide component scores; debt hours add up.
+func (qr *QualityReporter) directoryScores(
+	complexity *ComplexityMetrics,
+	duplication *DuplicationMetrics,
+	technicalDebt *TechnicalDebtMetrics,
+	coverage *CoverageMetrics,
+	maintainability *MaintainabilityMetrics,
+	scores ComponentScores,
+) DirectoryScoreMap {
+	totals := make(map[string]*directoryTotals)
+	files := make(map[string]bool)
+	add := func(file string, update func(t *directoryTotals)) {
+		file = ast.NormalizePath(file)
+		first := !files[file]
+		files[file] = true
+		for _, dir := range ancestorDirectories(file) {
+			t, ok := totals[dir]
+			if !ok {
+				t = &directoryTotals{}
+				totals[dir] = t
+			}
+			if first {
+				t.files++
+			}
+			update(t)
+		}
+	}
+
+	if scores.isAvailable(string(SectionComplexity)) {
+		for file, metrics := range complexity.FileMetrics {
+			add(file, func(t *directoryTotals) {
+				t.complexity += float64(metrics.TotalComplexity)
+				t.functions += float64(metrics.FunctionCount)
+				t.hasComplexity = t.hasComplexity || metrics.FunctionCount > 0
+			})
+		}
+	}
+	if scores.isAvailable(string(SectionCoverage)) {
+		for file, metrics := range coverage.FileAnalysis {
+			weight := math.Max(1, float64(metrics.TestedFunctions+metrics.UntestedFunctions))
+			add(file, func(t *directoryTotals) {
+				t.coverage += metrics.OverallScore * weight
+				t.coverageWeight += weight
+				t.hasCoverage = true
+			})
+		}
+	}
+	if scores.isAvailable(string(SectionMaintainability)) {
+		for file, metrics := range maintainability.FileMetrics {
+			weight := math.Max(1, float64(metrics.FunctionCount))
+			add(file, func(t *directoryTotals) {
+				t.maintainability += metrics.OverallIndex * weight
+				t.maintainabilityWeight += weight
+				t.hasMaintainability = true
+			})
+		}
+	}
+	if scores.isAvailable(string(SectionTechnicalDebt)) {
+		for file, metrics := range technicalDebt.FileDebtScores {
+			add(file, func(t *directoryTotals) { t.debtHours += metrics.DebtHours })
+		}
+	}
+	// Files without duplicates have no entry, so duplication is averaged over every file
+	// seen above rather than over the files listed here
+	if scores.isAvailable(string(SectionDuplication)) {
+		for file, metrics := range duplication.DuplicationByFile {
+			add(file, func(t *directoryTotals) { t.duplicationRatio += metrics.DuplicationRatio })
+		}
+		for _, t := range totals {
+			t.hasDuplication = true
+		}
+	}
+
+	if len(totals) == 0 {
+		return nil
+	}
+	result := make(DirectoryScoreMap, len(totals))
+	for dir, t := range totals {
+		result[dir] = qr.directoryScore(dir, t)
+	}
+	return result
+}
+
+// directoryScore turns the totals of one directory into its scores
+func (qr *QualityReporter) directoryScore(dir string, t *directoryTotals) DirectoryScore {
+	score := DirectoryScore{
+		Path:      dir,
+		Depth:     directoryDepth(dir),
+		Files:     t.files,
+		Scores:    make(map[string]float64),
+		DebtHours: qr.roundScore(t.debtHours),
+	}
+
+	weights := qr.weights()
+	var weighted, totalWeight float64
+	set := func(component SectionName, value, weight float64) {
+		value = qr.normalizeScore(value)
+		score.Scores[string(component)] = qr.roundScore(value)
+		weighted += value * weight
+		totalWeight += weight
+	}
+	if t.hasComplexity {
+		set(SectionComplexity, 100-(t.complexity/t.functions)*5, weights.Complexity)
+	}
+	if t.hasDuplication && t.files > 0 {
+		set(SectionDuplication, 100*(1-(t.duplicationRatio/float64(t.files))*2), weights.Duplication)
+	}
+	if t.hasCoverage {
+		set(SectionCoverage, t.coverage/t.coverageWeight, weights.Coverage)
+	}
+	if t.hasMaintainability {
+		set(SectionMaintainability, t.maintainability/t.maintainabilityWeight, weights.Maintainability)
+	}
+	if totalWeight > 0 {
+		score.OverallScore = qr.roundScore(weighted / totalWeight)
+	}
+	return score
+}
+
+// ancestorDirectories returns the directories containing file, from the root "." down to
+// its own directory
+func ancestorDirectories(file string) []string {
+	dirs := []string{"."}
+	parts := strings.Split(file, "/")
+	for i := 1; i < len(parts); i++ {
+		dirs = append(dirs, strings.Join(parts[:i], "/"))
+	}
+	return dirs
+}
+
+// directoryDepth is 0 for the root, 1 for top-level directories and so on
+func directoryDepth(dir string) int {
+	if dir == "." {
+		return 0
+	}
+	return strings.Count(dir, "/") + 1
+}
+
+// DirectoryTree returns the directory scores in tree order: every directory directly
+// followed by its subdirectories, siblings sorted by name
+func DirectoryTree(scores DirectoryScoreMap) []DirectoryScore {
+	tree := make([]DirectoryScore, 0, len(scores))
+	for _, score := range scores {
+		tree = append(tree, score)
+	}
+	sort.Slice(tree, func(i, j int) bool {
+		return directoryPathLess(tree[i].Path, tree[j].Path)
+	})
+	return tree
+}
+
+// directoryPathLess orders paths segment by segment, so "a/b" sorts before "a-c" and the
+// root before everything
+func directoryPathLess(a, b string) bool {
+	if a == "." || b == "." {
+		return a == "." && b != "."
+	}
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] != bs[i] {
+			return as[i] < bs[i]
+		}
+	}
+	return len(as) < len(bs)
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryScores_RollUp(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{})
+	complexity := &ComplexityMetrics{FileMetrics: map[string]FileComplexity{
+		"src/api/handler.js": {TotalComplexity: 20, FunctionCount: 2}, // average 10
+		"src/util.js":        {TotalComplexity: 4, FunctionCount: 2},  // average 2
+		"index.js":           {TotalComplexity: 0, FunctionCount: 0},
+	}}
+	duplication := &DuplicationMetrics{DuplicationByFile: map[string]FileDuplication{
+		"src/api/handler.js": {DuplicationRatio: 0.2},
+	}}
+	technicalDebt := &TechnicalDebtMetrics{FileDebtScores: map[string]FileDebt{
+		"src/api/handler.js": {DebtHours: 3},
+		"src/util.js":        {DebtHours: 1.5},
+	}}
+	coverage := &CoverageMetrics{FileAnalysis: map[string]FileTestability{
+		"src/api/handler.js": {OverallScore: 40, UntestedFunctions: 2},
+	}}
+	maintainability := &MaintainabilityMetrics{}
+
+	scores := reporter.directoryScores(complexity, duplication, technicalDebt, coverage, maintainability,
+		ComponentScores{Unavailable: []string{"maintainability"}})
+	require.Len(t, scores, 3)
+
+	api := scores["src/api"]
+	assert.Equal(t, 2, api.Depth)
+	assert.Equal(t, 1, api.Files)
+	assert.Equal(t, map[string]float64{"complexity": 50, "duplication": 60, "coverage": 40}, api.Scores)
+	assert.Equal(t, 3.0, api.DebtHours)
+
+	src := scores["src"]
+	assert.Equal(t, 2, src.Files)
+	assert.Equal(t, 70.0, src.Scores["complexity"], "complexity averages over all functions below src")
+	assert.Equal(t, 80.0, src.Scores["duplication"], "files without duplicates count as 0%")
+	assert.Equal(t, 4.5, src.DebtHours)
+
+	root := scores["."]
+	assert.Equal(t, 3, root.Files)
+	assert.Equal(t, 0, root.Depth)
+	assert.NotContains(t, root.Scores, "maintainability", "unavailable components are not rolled up")
+}
+
+func TestDirectoryTree_Order(t *testing.T) {
+	scores := DirectoryScoreMap{
+		"a-c": {Path: "a-c"},
+		"a/b": {Path: "a/b"},
+		"a":   {Path: "a"},
+		".":   {Path: "."},
+	}
+
+	var paths []string
+	for _, dir := range DirectoryTree(scores) {
+		paths = append(paths, dir.Path)
+	}
+	assert.Equal(t, []string{".", "a", "a/b", "a-c"}, paths)
+}
+
+func TestWriteReportHTML_DirectoryTree(t *testing.T) {
+	report := explainFixture()
+	report.DirectoryScores = DirectoryScoreMap{
+		".":       {Path: ".", Files: 2, OverallScore: 75, Scores: map[string]float64{"complexity": 75}},
+		"src":     {Path: "src", Depth: 1, Files: 2, OverallScore: 75, Scores: map[string]float64{"complexity": 75}},
+		"src/api": {Path: "src/api", Depth: 2, Files: 1, OverallScore: 60.5, Scores: map[string]float64{"complexity": 60.5}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReportHTML(&out, report))
+	html := out.String()
+
+	assert.Contains(t, html, "<h2>Directory Scores</h2>")
+	assert.Contains(t, html, `<td style="padding-left: 52px">api</td>`)
+	assert.Contains(t, html, `<td class="value">60.5</td><td class="value">-</td>`)
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// DocumentationAnalyzer measures how much of the public API carries a JSDoc/TSDoc comment
+type DocumentationAnalyzer struct {
+	config DocumentationConfig
+}
+
+// DocumentationConfig defines settings for documentation analysis
+type DocumentationConfig struct {
+	ReportTopN int `yaml:"report_top_n" json:"report_top_n"` // worst-documented APIs to report
+}
+
+// DocumentationMetrics contains documentation coverage results for exported symbols
+type DocumentationMetrics struct {
+	Score           float64                      `json:"score"` // 0-100, equal to overall coverage
+	OverallCoverage float64                      `json:"overall_coverage"`
+	PublicAPIs      int                          `json:"public_apis"`
+	DocumentedAPIs  int                          `json:"documented_apis"`
+	FileCoverage    map[string]FileDocumentation `json:"file_coverage"`
+	WorstDocumented []UndocumentedAPI            `json:"worst_documented"`
+}
+
+// FileDocumentation contains documentation coverage for a single file
+type FileDocumentation struct {
+	FilePath         string   `json:"file_path"`
+	PublicAPIs       int      `json:"public_apis"`
+	DocumentedAPIs   int      `json:"documented_apis"`
+	Coverage         float64  `json:"coverage"`
+	UndocumentedAPIs []string `json:"undocumented_apis"`
+}
+
+// UndocumentedAPI identifies an exported symbol without a doc comment
+type UndocumentedAPI struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	FilePath   string `json:"file_path"`
+	StartLine  int    `json:"start_line"`
+	Complexity int    `json:"complexity"`
+}
+
+// NewDocumentationAnalyzer creates a new documentation analyzer with default configuration
+func NewDocumentationAnalyzer() *DocumentationAnalyzer {
+	return &DocumentationAnalyzer{
+		config: DocumentationConfig{
+			ReportTopN: 10,
+		},
+	}
+}
+
+// NewDocumentationAnalyzerWithConfig creates a documentation analyzer with custom configuration
+func NewDocumentationAnalyzerWithConfig(config DocumentationConfig) *DocumentationAnalyzer {
+	return &DocumentationAnalyzer{
+		config: config,
+	}
+}
+
+// AnalyzeDocumentation computes per-file and overall documentation coverage of exported
+// functions, classes and class methods. Undocumented APIs are ranked by complexity since
+// those are the hardest to learn without docs.
+func (da *DocumentationAnalyzer) AnalyzeDocumentation(ctx context.Context, parseResults []*ast.ParseResult, complexityMetrics *ComplexityMetrics) (*DocumentationMetrics, error) {
+	metrics := &DocumentationMetrics{
+		Score:           100.0, // nothing public means nothing left undocumented
+		OverallCoverage: 100.0,
+		FileCoverage:    make(map[string]FileDocumentation),
+		WorstDocumented: []UndocumentedAPI{},
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	surface := BuildAPISurface(parseResults, complexityMetrics)
+	var undocumented []UndocumentedAPI
+
+	for _, entry := range surface.Entries {
+		file := metrics.FileCoverage[entry.FilePath]
+		file.FilePath = entry.FilePath
+		file.PublicAPIs++
+		if entry.Documented {
+			file.DocumentedAPIs++
+		} else {
+			file.UndocumentedAPIs = append(file.UndocumentedAPIs, entry.Name)
+			undocumented = append(undocumented, UndocumentedAPI{
+				Name:       entry.Name,
+				Kind:       entry.Kind,
+				FilePath:   entry.FilePath,
+				StartLine:  entry.StartLine,
+				Complexity: entry.Complexity,
+			})
+		}
+		metrics.FileCoverage[entry.FilePath] = file
+	}
+
+	for _, filePath := range sortedKeys(metrics.FileCoverage) {
+		file := metrics.FileCoverage[filePath]
+		file.Coverage = float64(file.DocumentedAPIs) / float64(file.PublicAPIs) * 100
+		metrics.FileCoverage[filePath] = file
+	}
+
+	metrics.PublicAPIs = surface.TotalExports
+	metrics.DocumentedAPIs = surface.TotalExports - surface.UndocumentedCount
+	if metrics.PublicAPIs > 0 {
+		metrics.OverallCoverage = float64(metrics.DocumentedAPIs) / float64(metrics.PublicAPIs) * 100
+		metrics.Score = metrics.OverallCoverage
+	}
+
+	// Entries arrive in module order, so the stable sort keeps ties deterministic
+	sort.SliceStable(undocumented, func(i, j int) bool {
+		return undocumented[i].Complexity > undocumented[j].Complexity
+	})
+	if da.config.ReportTopN > 0 && len(undocumented) > da.config.ReportTopN {
+		undocumented = undocumented[:da.config.ReportTopN]
+	}
+	if undocumented != nil {
+		metrics.WorstDocumented = undocumented
+	}
+
+	return metrics, nil
+}
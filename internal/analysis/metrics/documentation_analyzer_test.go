@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func createMockParseResultsForDocumentation() []*ast.ParseResult {
+	return []*ast.ParseResult{
+		{
+			FilePath: "src/a.js",
+			Functions: []ast.FunctionInfo{
+				{Name: "documented", IsExported: true, HasDocComment: true, StartLine: 1},
+				{Name: "simple", IsExported: true, StartLine: 5},
+				{Name: "private", StartLine: 9},
+			},
+		},
+		{
+			FilePath: "src/b.js",
+			Functions: []ast.FunctionInfo{
+				{Name: "complex", IsExported: true, StartLine: 1},
+			},
+		},
+	}
+}
+
+func TestAnalyzeDocumentation(t *testing.T) {
+	analyzer := NewDocumentationAnalyzer()
+	complexity := &ComplexityMetrics{
+		FunctionMetrics: []FunctionComplexity{
+			{Name: "simple", FilePath: "src/a.js", CyclomaticValue: 2},
+			{Name: "complex", FilePath: "src/b.js", CyclomaticValue: 12},
+		},
+	}
+
+	metrics, err := analyzer.AnalyzeDocumentation(context.Background(), createMockParseResultsForDocumentation(), complexity)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, metrics.PublicAPIs)
+	assert.Equal(t, 1, metrics.DocumentedAPIs)
+	assert.InDelta(t, 33.33, metrics.OverallCoverage, 0.01)
+	assert.Equal(t, metrics.OverallCoverage, metrics.Score)
+
+	assert.InDelta(t, 50.0, metrics.FileCoverage["src/a.js"].Coverage, 0.001)
+	assert.Equal(t, []string{"simple"}, metrics.FileCoverage["src/a.js"].UndocumentedAPIs)
+	assert.Equal(t, 0.0, metrics.FileCoverage["src/b.js"].Coverage)
+
+	// Most complex undocumented API first
+	require.Len(t, metrics.WorstDocumented, 2)
+	assert.Equal(t, "complex", metrics.WorstDocumented[0].Name)
+	assert.Equal(t, "simple", metrics.WorstDocumented[1].Name)
+}
+
+func TestAnalyzeDocumentation_NoExports(t *testing.T) {
+	analyzer := NewDocumentationAnalyzerWithConfig(DocumentationConfig{ReportTopN: 1})
+
+	metrics, err := analyzer.AnalyzeDocumentation(context.Background(), []*ast.ParseResult{
+		{FilePath: "src/a.js", Functions: []ast.FunctionInfo{{Name: "private"}}},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, metrics.PublicAPIs)
+	assert.Equal(t, 100.0, metrics.Score)
+	assert.Empty(t, metrics.WorstDocumented)
+	assert.Empty(t, metrics.FileCoverage)
+}
+
+func TestAnalyzeMaintainabilityWithDocumentation(t *testing.T) {
+	calculator := NewMaintainabilityCalculator()
+	parseResults := createMockParseResultsForMaintainability()
+	complexityMetrics := createMockComplexityMetricsForMaintainability()
+
+	base, err := calculator.AnalyzeMaintainability(context.Background(), parseResults, complexityMetrics)
+	require.NoError(t, err)
+	assert.Nil(t, base.DocumentationScore)
+
+	documentation := &DocumentationMetrics{Score: 0, PublicAPIs: 4}
+	blended, err := calculator.AnalyzeMaintainabilityWithDocumentation(context.Background(), parseResults, complexityMetrics, documentation)
+	require.NoError(t, err)
+
+	require.NotNil(t, blended.DocumentationScore)
+	assert.Equal(t, 0.0, *blended.DocumentationScore)
+	assert.InDelta(t, base.OverallIndex*0.9, blended.OverallIndex, 0.001)
+}
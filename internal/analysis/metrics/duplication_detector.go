@@ -45,10 +45,12 @@ type DuplicationWeights struct {
 type DuplicationMetrics struct {
 	OverallScore         float64                     `json:"overall_score"`
 	TotalDuplicatedLines int                         `json:"total_duplicated_lines"`
+	EliminableLines      int                         `json:"eliminable_lines"` // duplicated lines consolidation would remove, counting overlapping clusters once
 	DuplicationRatio     float64                     `json:"duplication_ratio"`
 	ExactDuplicates      []DuplicationCluster        `json:"exact_duplicates"`
 	StructuralDuplicates []DuplicationCluster        `json:"structural_duplicates"`
 	TokenDuplicates      []DuplicationCluster        `json:"token_duplicates"`
+	RankedClusters       []RankedDuplication         `json:"ranked_clusters"` // top ReportTopN clusters of every type, most wasted lines first
 	CrossFileDuplicates  []CrossFileDuplication      `json:"cross_file_duplicates"`
 	DuplicationByFile    map[string]FileDuplication  `json:"duplication_by_file"`
 	ConsolidationOps     []ConsolidationOpportunity  `json:"consolidation_opportunities"`
@@ -64,6 +66,8 @@ type DuplicationCluster struct {
 	Instances         []DuplicationInstance `json:"instances"`
 	SimilarityScore   float64               `json:"similarity_score"`
 	LineCount         int                   `json:"line_count"`
+	WastedLines       int                   `json:"wasted_lines"` // LineCount × (instances - 1)
+	FileCount         int                   `json:"file_count"`   // distinct files the instances live in
 	TokenCount        int                   `json:"token_count"`
 	MaintenanceBurden float64               `json:"maintenance_burden"`
 	RefactoringEffort string                `json:"refactoring_effort"`
@@ -213,6 +217,7 @@ func (dd *DuplicationDetector) DetectDuplication(ctx context.Context, parseResul
 		ExactDuplicates:      []DuplicationCluster{},
 		StructuralDuplicates: []DuplicationCluster{},
 		TokenDuplicates:      []DuplicationCluster{},
+		RankedClusters:       []RankedDuplication{},
 		CrossFileDuplicates:  []CrossFileDuplication{},
 		DuplicationByFile:    make(map[string]FileDuplication),
 		ConsolidationOps:     []ConsolidationOpportunity{},
@@ -237,6 +242,9 @@ func (dd *DuplicationDetector) DetectDuplication(ctx context.Context, parseResul
 	tokenDuplicates := dd.findTokenDuplicates(codeBlocks)
 	metrics.TokenDuplicates = dd.clusterDuplicates(tokenDuplicates, "token")
 
+	// Rank the clusters by the lines consolidating them would save
+	dd.rankDuplication(metrics)
+
 	// Analyze cross-file duplication if enabled
 	if dd.config.EnableCrossFile {
 		metrics.CrossFileDuplicates = dd.analyzeCrossFileDuplication(parseResults, metrics)
@@ -413,7 +421,8 @@ func (dd *DuplicationDetector) findExactDuplicates(blocks []DuplicationInstance)
 	}
 
 	// Extract clusters with multiple instances
-	for _, instances := range duplicateMap {
+	for _, content := range sortedKeys(duplicateMap) {
+		instances := duplicateMap[content]
 		if len(instances) > 1 {
 			clusters = append(clusters, instances)
 		}
@@ -433,7 +442,8 @@ func (dd *DuplicationDetector) findStructuralDuplicates(blocks []DuplicationInst
 	}
 
 	// Extract clusters with multiple instances and high similarity
-	for _, instances := range hashGroups {
+	for _, hash := range sortedKeys(hashGroups) {
+		instances := hashGroups[hash]
 		if len(instances) > 1 {
 			// Verify similarity threshold
 			validCluster := dd.validateStructuralCluster(instances)
@@ -648,6 +658,8 @@ func (dd *DuplicationDetector) clusterDuplicates(duplicateGroups [][]Duplication
 			LineCount:       group[0].EndLine - group[0].StartLine + 1,
 			Recommendations: []string{},
 		}
+		cluster.WastedLines = cluster.wastedLines()
+		cluster.FileCount = dd.countCrossFileInstances(group)
 
 		// Calculate similarity score
 		cluster.SimilarityScore = dd.calculateClusterSimilarity(group)
@@ -708,9 +720,11 @@ func (dd *DuplicationDetector) estimateTokenCount(content string) int {
 	return len(tokens) + len(symbols)
 }
 
-// calculateMaintenanceBurden assesses the maintenance impact of duplication
+// calculateMaintenanceBurden assesses the maintenance impact of duplication; a block
+// copy-pasted across files weighs more than the same repetition within one file
 func (dd *DuplicationDetector) calculateMaintenanceBurden(cluster DuplicationCluster) float64 {
 	burden := float64(len(cluster.Instances)) * float64(cluster.LineCount)
+	burden *= dd.config.WeightFactors.crossFileWeight(cluster.FileCount)
 
 	// Apply weight factors
 	switch cluster.Type {
@@ -740,6 +754,30 @@ func (dd *DuplicationDetector) assessRefactoringEffort(cluster DuplicationCluste
 	return "low"
 }
 
+// maxCrossFileWeight caps crossFileWeight, so one cluster copy-pasted across dozens of
+// files cannot outweigh the rest of the codebase on its own
+const maxCrossFileWeight = 3.0
+
+// crossFileWeight scales the weight of a cluster spanning files: CrossFileImpact-1 more per
+// file beyond the first, up to maxCrossFileWeight, so repetition within one file keeps
+// weight 1. A zero CrossFileImpact, as in configs that predate it, weighs every cluster alike.
+func (w DuplicationWeights) crossFileWeight(files int) float64 {
+	if w.CrossFileImpact <= 0 || files < 2 {
+		return 1
+	}
+	weight := 1 + (w.CrossFileImpact-1)*float64(files-1)
+	return math.Max(0, math.Min(weight, maxCrossFileWeight))
+}
+
+// CopyPasteFlag is "copy-paste across N files" for a cluster spanning several files, and
+// empty for repetition within one file
+func (cluster DuplicationCluster) CopyPasteFlag() string {
+	if cluster.FileCount < 2 {
+		return ""
+	}
+	return fmt.Sprintf("copy-paste across %d files", cluster.FileCount)
+}
+
 // countCrossFileInstances counts instances across different files
 func (dd *DuplicationDetector) countCrossFileInstances(instances []DuplicationInstance) int {
 	files := make(map[string]bool)
@@ -828,10 +866,7 @@ func (dd *DuplicationDetector) analyzeCrossFileCluster(cluster DuplicationCluste
 	}
 
 	// Analyze all file pairs
-	files := make([]string, 0, len(fileGroups))
-	for file := range fileGroups {
-		files = append(files, file)
-	}
+	files := sortedKeys(fileGroups)
 
 	for i := 0; i < len(files); i++ {
 		for j := i + 1; j < len(files); j++ {
@@ -877,7 +912,8 @@ func (dd *DuplicationDetector) identifySharedFunctionality(cluster DuplicationCl
 	// Return most common functionality type
 	maxCount := 0
 	mostCommon := "utility"
-	for funcType, count := range functionTypes {
+	for _, funcType := range sortedKeys(functionTypes) {
+		count := functionTypes[funcType]
 		if count > maxCount {
 			maxCount = count
 			mostCommon = funcType
@@ -1113,12 +1149,7 @@ func (dd *DuplicationDetector) extractAffectedFiles(instances []DuplicationInsta
 		fileSet[instance.FilePath] = true
 	}
 
-	files := make([]string, 0, len(fileSet))
-	for file := range fileSet {
-		files = append(files, file)
-	}
-
-	return files
+	return sortedKeys(fileSet)
 }
 
 // extractAffectedFunctions gets function names from instances
@@ -1134,12 +1165,7 @@ func (dd *DuplicationDetector) extractAffectedFunctions(instances []DuplicationI
 		}
 	}
 
-	functions := make([]string, 0, len(functionSet))
-	for function := range functionSet {
-		functions = append(functions, function)
-	}
-
-	return functions
+	return sortedKeys(functionSet)
 }
 
 // analyzeImpact performs comprehensive impact analysis
@@ -1197,7 +1223,8 @@ func (dd *DuplicationDetector) generateHotspotAnalysis(metrics *DuplicationMetri
 	hotspots := []DuplicationHotspot{}
 
 	// Analyze file-level hotspots
-	for filePath, fileDuplication := range metrics.DuplicationByFile {
+	for _, filePath := range sortedKeys(metrics.DuplicationByFile) {
+		fileDuplication := metrics.DuplicationByFile[filePath]
 		if fileDuplication.HotspotScore > 20 { // More than 20% duplication
 			hotspot := DuplicationHotspot{
 				Location:          filePath,
@@ -1211,7 +1238,7 @@ func (dd *DuplicationDetector) generateHotspotAnalysis(metrics *DuplicationMetri
 	}
 
 	// Sort by duplication score
-	sort.Slice(hotspots, func(i, j int) bool {
+	sort.SliceStable(hotspots, func(i, j int) bool {
 		return hotspots[i].DuplicationScore > hotspots[j].DuplicationScore
 	})
 
@@ -1251,11 +1278,14 @@ func (dd *DuplicationDetector) calculateAggregateMetrics(metrics *DuplicationMet
 	totalDuplicatedLines := 0
 	totalLines := 0
 
-	// Calculate totals from all clusters
+	// Calculate totals from all clusters; the score weighs lines copy-pasted across files
+	// above repetition within one file
+	weightedLines := 0.0
 	allClusters := append(append(metrics.ExactDuplicates, metrics.StructuralDuplicates...), metrics.TokenDuplicates...)
 	for _, cluster := range allClusters {
 		clusterLines := cluster.LineCount * len(cluster.Instances)
 		totalDuplicatedLines += clusterLines
+		weightedLines += float64(clusterLines) * dd.config.WeightFactors.crossFileWeight(cluster.FileCount)
 	}
 
 	// Estimate total lines from file metrics
@@ -1271,12 +1301,14 @@ func (dd *DuplicationDetector) calculateAggregateMetrics(metrics *DuplicationMet
 
 	metrics.TotalDuplicatedLines = totalDuplicatedLines
 
+	weightedRatio := 0.0
 	if totalLines > 0 {
 		metrics.DuplicationRatio = float64(totalDuplicatedLines) / float64(totalLines)
+		weightedRatio = weightedLines / float64(totalLines)
 	}
 
-	// Calculate overall score (inverse of duplication ratio)
-	metrics.OverallScore = math.Max(0, 100*(1-metrics.DuplicationRatio*2))
+	// Calculate overall score (inverse of the weighted duplication ratio)
+	metrics.OverallScore = math.Max(0, 100*(1-weightedRatio*2))
 }
 
 // generateRecommendations creates prioritized improvement recommendations
@@ -0,0 +1,87 @@
+package metrics
+
+import "sort"
+
+// clusterTypeOrder breaks ties between equally wasteful clusters: an exact duplicate is
+// the surest consolidation
+var clusterTypeOrder = map[string]int{"exact": 0, "structural": 1, "token": 2}
+
+// RankedDuplication is a duplication cluster ranked by the lines its consolidation saves
+type RankedDuplication struct {
+	ClusterID   string                `json:"cluster_id"`
+	Type        string                `json:"type"` // exact, structural, token
+	LineCount   int                   `json:"line_count"`
+	WastedLines int                   `json:"wasted_lines"` // LineCount × (instances - 1)
+	FileCount   int                   `json:"file_count"`
+	CopyPaste   string                `json:"copy_paste,omitempty"` // "copy-paste across N files" when the block spans files
+	Instances   []DuplicationLocation `json:"instances"`
+}
+
+// DuplicationLocation is where one instance of a ranked cluster lives
+type DuplicationLocation struct {
+	FilePath     string `json:"file_path"`
+	StartLine    int    `json:"start_line"`
+	EndLine      int    `json:"end_line"`
+	FunctionName string `json:"function_name,omitempty"`
+}
+
+// wastedLines is the number of lines consolidating the cluster into one copy removes
+func (cluster DuplicationCluster) wastedLines() int {
+	if len(cluster.Instances) < 2 {
+		return 0
+	}
+	return cluster.LineCount * (len(cluster.Instances) - 1)
+}
+
+// rankDuplication ranks the clusters of every type by wasted lines and totals the lines
+// that could be eliminated. The same blocks often form an exact, a structural and a token
+// cluster at once, so a cluster overlapping a higher-ranked one is left out of both.
+func (dd *DuplicationDetector) rankDuplication(metrics *DuplicationMetrics) {
+	var clusters []DuplicationCluster
+	clusters = append(clusters, metrics.ExactDuplicates...)
+	clusters = append(clusters, metrics.StructuralDuplicates...)
+	clusters = append(clusters, metrics.TokenDuplicates...)
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		a, b := clusters[i], clusters[j]
+		if a.wastedLines() != b.wastedLines() {
+			return a.wastedLines() > b.wastedLines()
+		}
+		if clusterTypeOrder[a.Type] != clusterTypeOrder[b.Type] {
+			return clusterTypeOrder[a.Type] < clusterTypeOrder[b.Type]
+		}
+		return a.ID < b.ID
+	})
+
+	var kept [][]DuplicationInstance
+	metrics.RankedClusters = []RankedDuplication{}
+	metrics.EliminableLines = 0
+	for _, cluster := range clusters {
+		if cluster.wastedLines() == 0 || dd.clusterExists(kept, cluster.Instances) {
+			continue
+		}
+		kept = append(kept, cluster.Instances)
+		metrics.EliminableLines += cluster.wastedLines()
+
+		if dd.config.ReportTopN > 0 && len(metrics.RankedClusters) >= dd.config.ReportTopN {
+			continue
+		}
+		ranked := RankedDuplication{
+			ClusterID:   cluster.ID,
+			Type:        cluster.Type,
+			LineCount:   cluster.LineCount,
+			WastedLines: cluster.wastedLines(),
+			FileCount:   cluster.FileCount,
+			CopyPaste:   cluster.CopyPasteFlag(),
+		}
+		for _, instance := range cluster.Instances {
+			ranked.Instances = append(ranked.Instances, DuplicationLocation{
+				FilePath:     instance.FilePath,
+				StartLine:    instance.StartLine,
+				EndLine:      instance.EndLine,
+				FunctionName: instance.FunctionName,
+			})
+		}
+		metrics.RankedClusters = append(metrics.RankedClusters, ranked)
+	}
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// duplicateCluster builds a cluster of lineCount-line blocks starting at the given lines of file
+func duplicateCluster(id, clusterType, file string, lineCount int, startLines ...int) DuplicationCluster {
+	cluster := DuplicationCluster{ID: id, Type: clusterType, LineCount: lineCount}
+	for _, start := range startLines {
+		cluster.Instances = append(cluster.Instances, DuplicationInstance{FilePath: file, StartLine: start, EndLine: start + lineCount - 1})
+	}
+	return cluster
+}
+
+func TestRankDuplication(t *testing.T) {
+	metrics := &DuplicationMetrics{
+		ExactDuplicates: []DuplicationCluster{
+			duplicateCluster("exact_0", "exact", "src/a.js", 20, 1, 40),        // wastes 20
+			duplicateCluster("exact_1", "exact", "src/b.js", 8, 1, 20, 40, 60), // wastes 24
+		},
+		StructuralDuplicates: []DuplicationCluster{
+			duplicateCluster("structural_0", "structural", "src/a.js", 20, 1, 40), // same blocks as exact_0
+			duplicateCluster("structural_1", "structural", "src/c.js", 6, 1, 30),  // wastes 6
+		},
+	}
+
+	NewDuplicationDetector().rankDuplication(metrics)
+
+	require.Len(t, metrics.RankedClusters, 3)
+	assert.Equal(t, "exact_1", metrics.RankedClusters[0].ClusterID, "most wasted lines first")
+	assert.Equal(t, 24, metrics.RankedClusters[0].WastedLines)
+	assert.Len(t, metrics.RankedClusters[0].Instances, 4)
+	assert.Equal(t, "exact_0", metrics.RankedClusters[1].ClusterID, "exact wins the tie with its structural twin")
+	assert.Equal(t, DuplicationLocation{FilePath: "src/a.js", StartLine: 40, EndLine: 59}, metrics.RankedClusters[1].Instances[1])
+	assert.Equal(t, "structural_1", metrics.RankedClusters[2].ClusterID)
+	assert.Equal(t, 50, metrics.EliminableLines, "overlapping clusters count once")
+}
+
+func TestRankDuplication_TopN(t *testing.T) {
+	metrics := &DuplicationMetrics{ExactDuplicates: []DuplicationCluster{
+		duplicateCluster("exact_0", "exact", "src/a.js", 10, 1, 20),
+		duplicateCluster("exact_1", "exact", "src/b.js", 10, 1, 20),
+		duplicateCluster("exact_2", "exact", "src/c.js", 12, 1, 20),
+	}}
+
+	detector := NewDuplicationDetector()
+	detector.config.ReportTopN = 2
+	detector.rankDuplication(metrics)
+
+	require.Len(t, metrics.RankedClusters, 2)
+	assert.Equal(t, "exact_2", metrics.RankedClusters[0].ClusterID)
+	assert.Equal(t, 32, metrics.EliminableLines, "the headline counts clusters beyond the top N")
+}
+
+func TestWriteReportHTML_DuplicationClusters(t *testing.T) {
+	report := explainFixture()
+	report.DetailedMetrics.Duplication = &DuplicationMetrics{
+		EliminableLines: 24,
+		RankedClusters: []RankedDuplication{{
+			ClusterID: "exact_1", Type: "exact", LineCount: 8, WastedLines: 8, FileCount: 2, CopyPaste: "copy-paste across 2 files",
+			Instances: []DuplicationLocation{
+				{FilePath: "src/b.js", StartLine: 1, EndLine: 8, FunctionName: "load"},
+				{FilePath: "src/c.js", StartLine: 20, EndLine: 27},
+			},
+		}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReportHTML(&out, report))
+	html := out.String()
+
+	assert.Contains(t, html, "<strong>24</strong> duplicated lines could be eliminated")
+	assert.Contains(t, html, "<td>src/b.js:1-8 (load)<br>src/c.js:20-27</td>")
+	assert.Contains(t, html, "<td>exact, copy-paste across 2 files</td>")
+}
+
+func TestDuplicationCrossFileWeight(t *testing.T) {
+	detector := NewDuplicationDetector()
+
+	withinFile := duplicateCluster("exact_0", "exact", "src/a.js", 12, 1, 20, 40, 60, 80)
+	withinFile.FileCount = detector.countCrossFileInstances(withinFile.Instances)
+	acrossFiles := withinFile
+	acrossFiles.Instances = nil
+	for _, file := range []string{"src/a.js", "src/b.js", "src/c.js", "src/d.js", "src/e.js"} {
+		acrossFiles.Instances = append(acrossFiles.Instances, DuplicationInstance{FilePath: file, StartLine: 1, EndLine: 12})
+	}
+	acrossFiles.FileCount = detector.countCrossFileInstances(acrossFiles.Instances)
+
+	assert.Empty(t, withinFile.CopyPasteFlag())
+	assert.Equal(t, "copy-paste across 5 files", acrossFiles.CopyPasteFlag())
+	assert.Greater(t, detector.calculateMaintenanceBurden(acrossFiles), detector.calculateMaintenanceBurden(withinFile))
+
+	score := func(cluster DuplicationCluster) float64 {
+		metrics := &DuplicationMetrics{ExactDuplicates: []DuplicationCluster{cluster}, DuplicationByFile: map[string]FileDuplication{"src/a.js": {InternalDuplication: 60, DuplicationRatio: 0.06}}} // 1000 lines
+		detector.calculateAggregateMetrics(metrics)
+		return metrics.OverallScore
+	}
+	assert.Less(t, score(acrossFiles), score(withinFile), "the same lines copy-pasted across files score worse")
+
+	metrics := &DuplicationMetrics{ExactDuplicates: []DuplicationCluster{acrossFiles}}
+	detector.rankDuplication(metrics)
+	assert.Equal(t, 5, metrics.RankedClusters[0].FileCount)
+	assert.Equal(t, "copy-paste across 5 files", metrics.RankedClusters[0].CopyPaste)
+}
+
+func TestDuplicationCrossFileWeight_ManyFiles(t *testing.T) {
+	weights := NewDuplicationDetector().config.WeightFactors
+	assert.Equal(t, 1.0, weights.crossFileWeight(1))
+	assert.InDelta(t, 1.2, weights.crossFileWeight(2), 1e-9)
+	assert.InDelta(t, 1.8, weights.crossFileWeight(5), 1e-9, "linear in the file count")
+	assert.Equal(t, maxCrossFileWeight, weights.crossFileWeight(30), "capped")
+
+	detector := NewDuplicationDetector()
+	cluster := duplicateCluster("exact_0", "exact", "src/a.js", 6, 1)
+	cluster.Instances = nil
+	byFile := make(map[string]FileDuplication)
+	for i := 0; i < 30; i++ {
+		file := fmt.Sprintf("src/file%02d.js", i)
+		cluster.Instances = append(cluster.Instances, DuplicationInstance{FilePath: file, StartLine: 1, EndLine: 6})
+		byFile[file] = FileDuplication{ExternalDuplication: 6, DuplicationRatio: 0.06} // 100 lines each
+	}
+	cluster.FileCount = detector.countCrossFileInstances(cluster.Instances)
+
+	metrics := &DuplicationMetrics{ExactDuplicates: []DuplicationCluster{cluster}, DuplicationByFile: byFile}
+	detector.calculateAggregateMetrics(metrics)
+	assert.Greater(t, metrics.OverallScore, 0.0, "light duplication across 30 files does not zero the score")
+}
+
+func TestGenerateDuplicationRecommendations_CrossFileRanksHigher(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{})
+	withinFile := duplicateCluster("exact_0", "exact", "src/a.js", 12, 1, 20)
+	withinFile.FileCount = 1
+	acrossFiles := duplicateCluster("exact_1", "exact", "src/b.js", 12, 1)
+	acrossFiles.Instances = append(acrossFiles.Instances, DuplicationInstance{FilePath: "src/c.js", StartLine: 1, EndLine: 12})
+	acrossFiles.FileCount = 2
+
+	recommendations := reporter.generateDuplicationRecommendations(&DuplicationMetrics{ExactDuplicates: []DuplicationCluster{withinFile, acrossFiles}})
+	require.Len(t, recommendations, 2)
+	assert.Greater(t, recommendations[1].ROI, recommendations[0].ROI)
+	assert.NotContains(t, recommendations[0].Description, "copy-paste")
+	assert.Contains(t, recommendations[1].Description, "copy-paste across 2 files")
+}
@@ -0,0 +1,259 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrFindingNotFound reports an ID that matches no finding in the report
+var ErrFindingNotFound = errors.New("no finding with this ID")
+
+// ruleDescriptions explains what each rule and recommendation component looks for
+var ruleDescriptions = map[string]string{
+	// Recommendation components
+	"complexity":      "Functions whose cyclomatic complexity exceeds 15 have too many independent paths to reason about and test exhaustively.",
+	"duplication":     "Blocks of code repeated across the repository must be kept in sync by hand; every copy is another place for a fix to be missed.",
+	"technical_debt":  "Accumulated code smells, architecture violations and performance issues, weighed by the hours needed to fix them.",
+	"coverage":        "Code paths without tests, ranked by the risk of a regression going unnoticed.",
+	"performance":     "Patterns that cost CPU, memory or network time at runtime, detected from the structure of the code.",
+	"maintainability": "The maintainability index combines complexity, size and documentation into a single 0-100 estimate of how easy the code is to change.",
+	"security":        "Vulnerable dependencies and hard-coded secrets, deducted from 100 by severity.",
+
+	// Technical debt items
+	"long_method":         "Functions longer than the long-method threshold mix several responsibilities and are hard to read and test.",
+	"too_many_parameters": "Functions with many parameters are easy to call incorrectly; related values usually belong in an object.",
+	"large_class":         "Classes with very many methods or lines usually hold several responsibilities that could be split.",
+	"too_many_methods":    "Classes with many methods tend to accumulate unrelated behavior.",
+	"circular_dependency": "Modules that import each other cannot be understood, tested or loaded independently.",
+	"god_object":          "Files with too many functions or classes across too many lines hold several responsibilities; newcomers get lost in them and every change touches them.",
+	"tight_coupling":      "Modules with many imports depend on the internals of too many others.",
+	"layering_violation":  "Imports that cross architectural layers in the wrong direction erode the intended structure.",
+	"nested_loops":        "Loops nested inside loops grow quadratically (or worse) with input size.",
+	"sync_in_async":       "Synchronous, blocking calls inside async code stall the event loop.",
+	"memory_leak_risk":    "Resources acquired without a matching release keep memory alive longer than intended.",
+	"excessive_imports":   "Files importing many modules are likely doing too much and slow down bundling.",
+	"high_complexity":     "Files whose combined complexity makes them risky to change.",
+	"exact_duplication":   "Identical code blocks found in more than one place.",
+	"commented_out_code":  "Blocks of commented-out code are dead weight that readers must mentally skip; version control keeps history.",
+	"callback_pyramid":    "Callbacks nested several levels deep (callback hell) are hard to follow and to handle errors in; async/await flattens them.",
+	"large_switch":        "A switch or if/else-if chain with many cases on one value buries a mapping in control flow; a lookup table or strategy map states it as data.",
+	"unhandled_async":     "An await outside try/catch or a .then() chain without .catch() lets a rejection escape unhandled, crashing the process or vanishing silently.",
+	"mixed_async_styles":  "A file mixing async/await, promise chains and callbacks makes readers switch models between functions, and errors get lost where one style hands off to another.",
+
+	// Performance anti-patterns
+	"n_plus_one_query":             "A query issued once per item of a collection instead of once for the whole collection.",
+	"sequential_async_queries":     "Independent async operations awaited one after another instead of in parallel.",
+	"sync_in_loop":                 "Awaiting inside a loop serializes work that could run concurrently.",
+	"nested_iteration":             "Iterating over a collection inside an iteration over another collection.",
+	"potential_memory_leak":        "Effects or subscriptions set up without cleanup.",
+	"event_listener_risk":          "Event listeners added without being removed keep their handlers and closures alive.",
+	"large_function":               "Very large functions are slow to parse and hard to optimize for the JavaScript engine.",
+	"repeated_dom_queries":         "The same DOM query run repeatedly instead of caching the element.",
+	"string_concatenation_in_loop": "Building strings with + inside loops creates a new string per iteration.",
+	"blocking_operation":           "Synchronous file, network or CPU-heavy work that blocks the main thread.",
+	"bundle_optimization":          "Heavy dependencies that inflate the bundle and slow down page loads.",
+	"high_complexity_function":     "Functions with high cyclomatic complexity take many branches on hot paths and resist engine optimization.",
+	"high_coupling":                "Files that depend on many others break whenever one of their dependencies changes.",
+}
+
+// Explanation is the full detail behind one finding, for the explain command
+type Explanation struct {
+	ID              string                 `json:"id"`
+	Kind            string                 `json:"kind"` // see the Finding* kinds
+	Title           string                 `json:"title"`
+	Rule            string                 `json:"rule"`
+	RuleDescription string                 `json:"rule_description,omitempty"`
+	Severity        string                 `json:"severity"`
+	Location        string                 `json:"location,omitempty"`
+	Evidence        []string               `json:"evidence,omitempty"`
+	Metrics         map[string]interface{} `json:"metrics,omitempty"` // values that triggered the finding
+	Actions         []string               `json:"actions,omitempty"`
+	Effort          string                 `json:"effort,omitempty"`
+	Rationale       string                 `json:"rationale,omitempty"` // why the effort is worth it
+}
+
+// Explain looks up id among the report's recommendations, technical debt items, coverage
+// gaps and anti-patterns (numbered anti_pattern_N as in Findings) and explains it
+func Explain(report *QualityReport, id string) (*Explanation, error) {
+	for _, finding := range Findings(report) {
+		if finding.ID != id {
+			continue
+		}
+
+		switch data := finding.Data.(type) {
+		case QualityRecommendation:
+			return explainRecommendation(data), nil
+		case TechnicalDebtItem:
+			return explainDebtItem(data), nil
+		case CoverageGap:
+			return explainCoverageGap(data), nil
+		case AntiPattern:
+			explanation := explainAntiPattern(data)
+			explanation.ID = id
+			return explanation, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrFindingNotFound, id)
+}
+
+func explainRecommendation(recommendation QualityRecommendation) *Explanation {
+	explanation := &Explanation{
+		ID:              recommendation.ID,
+		Kind:            FindingRecommendation,
+		Title:           recommendation.Title,
+		Rule:            recommendation.Component,
+		RuleDescription: ruleDescriptions[recommendation.Component],
+		Severity:        string(recommendation.Priority),
+		Location:        strings.Join(recommendation.Files, ", "),
+		Evidence:        []string{recommendation.Description},
+		Metrics: map[string]interface{}{
+			"category":     recommendation.Category,
+			"impact":       recommendation.Impact,
+			"effort_hours": recommendation.EffortHours,
+			"roi":          recommendation.ROI,
+		},
+		Effort: fmt.Sprintf("%s, about %.1f hours (%s)", recommendation.Effort, recommendation.EffortHours, recommendation.Timeline),
+		Rationale: fmt.Sprintf("ROI %.2f is the expected benefit per hour of effort; recommendations are ranked by it. Impact is %s.",
+			recommendation.ROI, recommendation.Impact),
+	}
+	for _, action := range recommendation.Actions {
+		explanation.Actions = append(explanation.Actions, fmt.Sprintf("%s (%.1fh)", action.Description, action.EstimatedHours))
+	}
+	for _, benefit := range recommendation.Benefits {
+		explanation.Rationale += " Benefit: " + benefit + "."
+	}
+	for _, risk := range recommendation.Risks {
+		explanation.Rationale += " Risk: " + risk + "."
+	}
+	return explanation
+}
+
+func explainDebtItem(item TechnicalDebtItem) *Explanation {
+	metrics := map[string]interface{}{
+		"debt_score":       item.DebtScore,
+		"impact_score":     item.ImpactScore,
+		"confidence_score": item.ConfidenceScore,
+	}
+	for key, value := range item.Metadata {
+		metrics[key] = value
+	}
+
+	return &Explanation{
+		ID:              item.ID,
+		Kind:            FindingDebtItem,
+		Title:           item.Description,
+		Rule:            item.Type,
+		RuleDescription: ruleDescriptions[item.Type],
+		Severity:        item.Severity,
+		Location:        location(item.FilePath, item.StartLine, item.EndLine),
+		Evidence:        []string{item.Description},
+		Metrics:         metrics,
+		Actions:         item.RemediationSteps,
+		Effort:          fmt.Sprintf("about %.1f hours, %s priority", item.EstimatedHours, item.Priority),
+		Rationale:       fmt.Sprintf("Listed under %s; the debt score weighs severity against the estimated fix time.", item.Category),
+	}
+}
+
+func explainCoverageGap(gap CoverageGap) *Explanation {
+	explanation := &Explanation{
+		ID:              gap.ID,
+		Kind:            FindingCoverageGap,
+		Title:           gap.Impact,
+		Rule:            gap.Type,
+		RuleDescription: ruleDescriptions["coverage"],
+		Severity:        gap.Severity,
+		Location:        location(gap.FilePath, 0, 0),
+		Evidence:        []string{gap.Impact},
+		Actions:         append([]string{gap.TestingStrategy}, gap.Prerequisites...),
+		Effort:          fmt.Sprintf("about %d hours", gap.EstimatedEffort),
+		Rationale:       gap.RiskAssessment,
+	}
+	if gap.Location != "" {
+		explanation.Evidence = append(explanation.Evidence, "Location: "+gap.Location)
+	}
+	return explanation
+}
+
+func explainAntiPattern(antiPattern AntiPattern) *Explanation {
+	return &Explanation{
+		Kind:            FindingAntiPattern,
+		Title:           antiPattern.Description,
+		Rule:            antiPattern.Type,
+		RuleDescription: ruleDescriptions[antiPattern.Type],
+		Severity:        antiPattern.Severity,
+		Location:        location(antiPattern.FilePath, antiPattern.StartLine, antiPattern.EndLine),
+		Evidence:        []string{antiPattern.Evidence},
+		Metrics: map[string]interface{}{
+			"impact_score":   antiPattern.Impact.Score,
+			"impact":         antiPattern.Impact.Category,
+			"affected_areas": antiPattern.Impact.AffectedAreas,
+		},
+		Actions:   []string{new(PerformanceAnalyzer).getImplementationFromAntiPattern(antiPattern)},
+		Rationale: antiPattern.Impact.Description,
+	}
+}
+
+// location formats a file with an optional line range
+func location(filePath string, startLine, endLine int) string {
+	switch {
+	case filePath == "" || startLine <= 0:
+		return filePath
+	case endLine > startLine:
+		return fmt.Sprintf("%s:%d-%d", filePath, startLine, endLine)
+	default:
+		return fmt.Sprintf("%s:%d", filePath, startLine)
+	}
+}
+
+// WriteExplanation prints an explanation as plain text sections
+func WriteExplanation(w io.Writer, explanation *Explanation) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s\n", explanation.ID, explanation.Title)
+	fmt.Fprintf(&b, "Kind:     %s\n", explanation.Kind)
+	fmt.Fprintf(&b, "Rule:     %s\n", explanation.Rule)
+	fmt.Fprintf(&b, "Severity: %s\n", explanation.Severity)
+	if explanation.Location != "" {
+		fmt.Fprintf(&b, "Location: %s\n", explanation.Location)
+	}
+
+	if explanation.RuleDescription != "" {
+		fmt.Fprintf(&b, "\nWhat the rule checks\n  %s\n", explanation.RuleDescription)
+	}
+	writeList := func(heading string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s\n", heading)
+		for _, line := range lines {
+			if line != "" {
+				fmt.Fprintf(&b, "  - %s\n", line)
+			}
+		}
+	}
+	writeList("Evidence", explanation.Evidence)
+
+	if len(explanation.Metrics) > 0 {
+		b.WriteString("\nMetrics\n")
+		for _, key := range sortedKeys(explanation.Metrics) {
+			fmt.Fprintf(&b, "  %s: %v\n", key, explanation.Metrics[key])
+		}
+	}
+	writeList("Recommended actions", explanation.Actions)
+
+	if explanation.Effort != "" || explanation.Rationale != "" {
+		b.WriteString("\nEffort and rationale\n")
+		if explanation.Effort != "" {
+			fmt.Fprintf(&b, "  Effort: %s\n", explanation.Effort)
+		}
+		if explanation.Rationale != "" {
+			fmt.Fprintf(&b, "  %s\n", explanation.Rationale)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write explanation: %w", err)
+	}
+	return nil
+}
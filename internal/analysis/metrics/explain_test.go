@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func explainFixture() *QualityReport {
+	return &QualityReport{
+		Recommendations: []QualityRecommendation{{
+			ID:          "COMPLEX-3",
+			Title:       "Reduce complexity in parseOrder",
+			Description: "parseOrder has cyclomatic complexity 24",
+			Category:    CategoryQuickWins,
+			Priority:    PriorityHigh,
+			Impact:      ImpactHigh,
+			Effort:      EffortMedium,
+			EffortHours: 4,
+			ROI:         2.5,
+			Component:   "complexity",
+			Files:       []string{"src/orders.js"},
+			Actions:     []RecommendationAction{{Description: "Extract validation into helpers", EstimatedHours: 2}},
+			Benefits:    []string{"Easier to test"},
+			Timeline:    "1 week",
+		}},
+		DetailedMetrics: DetailedMetrics{
+			Performance: &PerformanceMetrics{AntiPatterns: []AntiPattern{{
+				Type:        "nested_loops",
+				Description: "Nested loop over orders",
+				Severity:    "high",
+				FilePath:    "src/orders.js",
+				StartLine:   10,
+				EndLine:     18,
+				Evidence:    "for (...) { for (...) { ... } }",
+				Impact:      PerformanceImpact{Score: 7, Category: "cpu"},
+			}}},
+			TechnicalDebt: &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+				"Code Smells": {Items: []TechnicalDebtItem{{
+					ID:               "code_smell_0",
+					Type:             "long_method",
+					Description:      "Function parseOrder is 120 lines long",
+					Severity:         "medium",
+					FilePath:         "src/orders.js",
+					StartLine:        40,
+					EstimatedHours:   1.5,
+					RemediationSteps: []string{"Split into smaller functions"},
+					Metadata:         map[string]interface{}{"line_count": 120},
+				}}},
+			}},
+			Coverage: &CoverageMetrics{CoverageGaps: []CoverageGap{{
+				ID:              "gap_0",
+				Type:            "function",
+				FilePath:        "src/orders.js",
+				Severity:        "high",
+				Impact:          "parseOrder is untested",
+				TestingStrategy: "Add unit tests for each order type",
+				EstimatedEffort: 3,
+			}}},
+		},
+	}
+}
+
+func TestExplain(t *testing.T) {
+	report := explainFixture()
+
+	recommendation, err := Explain(report, "COMPLEX-3")
+	require.NoError(t, err)
+	assert.Equal(t, FindingRecommendation, recommendation.Kind)
+	assert.Equal(t, ruleDescriptions["complexity"], recommendation.RuleDescription)
+	assert.Equal(t, []string{"Extract validation into helpers (2.0h)"}, recommendation.Actions)
+	assert.Equal(t, 2.5, recommendation.Metrics["roi"])
+	assert.Contains(t, recommendation.Rationale, "Easier to test")
+
+	debt, err := Explain(report, "code_smell_0")
+	require.NoError(t, err)
+	assert.Equal(t, "src/orders.js:40", debt.Location)
+	assert.Equal(t, 120, debt.Metrics["line_count"], "metadata values are reported as metrics")
+	assert.Equal(t, []string{"Split into smaller functions"}, debt.Actions)
+
+	gap, err := Explain(report, "gap_0")
+	require.NoError(t, err)
+	assert.Equal(t, FindingCoverageGap, gap.Kind)
+	assert.Equal(t, "Add unit tests for each order type", gap.Actions[0])
+
+	antiPattern, err := Explain(report, "anti_pattern_0")
+	require.NoError(t, err)
+	assert.Equal(t, "anti_pattern_0", antiPattern.ID)
+	assert.Equal(t, "src/orders.js:10-18", antiPattern.Location)
+	assert.NotEmpty(t, antiPattern.Actions)
+
+	_, err = Explain(report, "COMPLEX-99")
+	assert.ErrorIs(t, err, ErrFindingNotFound)
+	assert.ErrorContains(t, err, "COMPLEX-99")
+}
+
+func TestWriteExplanation(t *testing.T) {
+	explanation, err := Explain(explainFixture(), "code_smell_0")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteExplanation(&out, explanation))
+	for _, section := range []string{"code_smell_0: ", "What the rule checks", "Evidence", "Metrics", "  line_count: 120", "Recommended actions", "Effort and rationale"} {
+		assert.Contains(t, out.String(), section)
+	}
+}
+
+func TestLoadReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	var out bytes.Buffer
+	require.NoError(t, WriteReportJSON(&out, explainFixture(), false))
+	require.NoError(t, os.WriteFile(path, out.Bytes(), 0o644))
+
+	report, err := LoadReport(path)
+	require.NoError(t, err)
+	explanation, err := Explain(report, "COMPLEX-3")
+	require.NoError(t, err)
+	assert.Equal(t, "Reduce complexity in parseOrder", explanation.Title)
+
+	_, err = LoadReport(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// entryFileNames are module names that are run or loaded by tooling rather than called
+var entryFileNames = map[string]bool{
+	"index": true, "main": true, "server": true, "app": true, "cli": true,
+}
+
+// callSite is one call of a function name
+type callSite struct {
+	filePath string
+	line     int
+	test     bool
+}
+
+// fanInIndex resolves call sites to function definitions by name. Calls are matched
+// without following imports: a call resolves to a definition in the calling file when
+// there is one, and otherwise to every definition of that name elsewhere.
+type fanInIndex struct {
+	calls        map[string][]callSite
+	definedIn    map[string]map[string]bool // function name -> files defining it
+	exported     map[string]bool            // file#name#line of exported functions and methods of exported classes
+	testPatterns []string                   // see isTestFile
+}
+
+// newFanInIndex collects the call sites and definitions of parseResults; calls from files
+// matching testPatterns count as test calls
+func newFanInIndex(parseResults []*ast.ParseResult, testPatterns []string) *fanInIndex {
+	index := &fanInIndex{
+		calls:        make(map[string][]callSite),
+		definedIn:    make(map[string]map[string]bool),
+		exported:     make(map[string]bool),
+		testPatterns: testPatterns,
+	}
+
+	define := func(filePath string, function ast.FunctionInfo, exported bool) {
+		if function.Name == "" {
+			return
+		}
+		if index.definedIn[function.Name] == nil {
+			index.definedIn[function.Name] = make(map[string]bool)
+		}
+		index.definedIn[function.Name][filePath] = true
+		if exported {
+			index.exported[functionKey(filePath, function.Name, function.StartLine)] = true
+		}
+	}
+
+	for _, result := range parseResults {
+		test := isTestFile(result.FilePath, testPatterns)
+		for _, call := range result.Calls {
+			index.calls[call.Callee] = append(index.calls[call.Callee], callSite{
+				filePath: result.FilePath,
+				line:     call.Line,
+				test:     test,
+			})
+		}
+		for _, function := range result.Functions {
+			define(result.FilePath, function, function.IsExported)
+		}
+		for _, class := range result.Classes {
+			for _, method := range class.Methods {
+				define(result.FilePath, method, class.IsExported)
+			}
+		}
+	}
+
+	return index
+}
+
+// attach sets the fan-in fields of function. Recursive calls from inside the function
+// itself are not counted, and calls from test files are counted separately.
+func (index *fanInIndex) attach(function *FunctionComplexity) {
+	if function.Name == "" {
+		return
+	}
+
+	for _, site := range index.calls[function.Name] {
+		if site.filePath == function.FilePath {
+			if site.line >= function.StartLine && site.line <= function.EndLine {
+				continue
+			}
+		} else if index.definedIn[function.Name][site.filePath] {
+			continue // the caller has its own definition of the name
+		}
+
+		if site.test {
+			function.TestFanIn++
+		} else {
+			function.FanIn++
+		}
+	}
+
+	if function.FanIn == 0 && !isTestFile(function.FilePath, index.testPatterns) {
+		function.EntryPoint = index.exported[functionKey(function.FilePath, function.Name, function.StartLine)] ||
+			isEntryFile(function.FilePath)
+	}
+}
+
+// attachFanIn sets caller counts on every function and method of metrics
+func (ca *ComplexityAnalyzer) attachFanIn(metrics *ComplexityMetrics, parseResults []*ast.ParseResult) {
+	index := newFanInIndex(parseResults, ca.config.TestFilePatterns)
+	for i := range metrics.FunctionMetrics {
+		index.attach(&metrics.FunctionMetrics[i])
+	}
+	for i := range metrics.ClassMetrics {
+		for j := range metrics.ClassMetrics[i].Methods {
+			index.attach(&metrics.ClassMetrics[i].Methods[j])
+		}
+	}
+}
+
+// fanInMultiplier scales a function's refactoring priority by how widely it is called:
+// 1 for uncalled functions and entry points, growing logarithmically with fan-in so a
+// handful of hot functions do not drown out everything else
+func (ca *ComplexityAnalyzer) fanInMultiplier(function FunctionComplexity) float64 {
+	if ca.config.FanInWeight <= 0 || function.FanIn <= 0 {
+		return 1
+	}
+	return 1 + ca.config.FanInWeight*math.Log2(1+float64(function.FanIn))
+}
+
+// functionKey identifies a function definition
+func functionKey(filePath, name string, startLine int) string {
+	return fmt.Sprintf("%s#%s#%d", filePath, name, startLine)
+}
+
+// isEntryFile reports whether filePath is a module entry point such as src/index.ts
+func isEntryFile(filePath string) bool {
+	base := path.Base(ast.ToSlash(filePath))
+	return entryFileNames[strings.TrimSuffix(base, path.Ext(base))]
+}
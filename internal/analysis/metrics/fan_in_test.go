@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func fanInTestResults() []*ast.ParseResult {
+	return []*ast.ParseResult{
+		{
+			FilePath: "src/format.js",
+			Functions: []ast.FunctionInfo{
+				{Name: "formatDate", StartLine: 1, EndLine: 10, IsExported: true},
+				{Name: "pad", StartLine: 12, EndLine: 20},
+			},
+			Calls: []ast.CallInfo{
+				{Callee: "pad", Line: 3},
+				{Callee: "pad", Line: 15}, // recursive
+			},
+		},
+		{
+			FilePath: "src/orders.js",
+			Functions: []ast.FunctionInfo{
+				{Name: "render", StartLine: 1, EndLine: 5, IsExported: true},
+				{Name: "pad", StartLine: 7, EndLine: 9},
+			},
+			Calls: []ast.CallInfo{
+				{Callee: "formatDate", Line: 2},
+				{Callee: "formatDate", Line: 3},
+				{Callee: "pad", Line: 4}, // resolves to the local pad
+			},
+		},
+		{
+			FilePath: "src/__tests__/format.test.js",
+			Calls: []ast.CallInfo{
+				{Callee: "formatDate", Line: 4},
+				{Callee: "render", Line: 8},
+			},
+		},
+	}
+}
+
+func findFunction(t *testing.T, metrics *ComplexityMetrics, filePath, name string) FunctionComplexity {
+	t.Helper()
+	for _, function := range metrics.FunctionMetrics {
+		if function.FilePath == filePath && function.Name == name {
+			return function
+		}
+	}
+	require.Failf(t, "function not found", "%s in %s", name, filePath)
+	return FunctionComplexity{}
+}
+
+func TestAnalyzeComplexity_FanIn(t *testing.T) {
+	metrics, err := NewComplexityAnalyzer().AnalyzeComplexity(context.Background(), fanInTestResults())
+	require.NoError(t, err)
+
+	formatDate := findFunction(t, metrics, "src/format.js", "formatDate")
+	assert.Equal(t, 2, formatDate.FanIn)
+	assert.Equal(t, 1, formatDate.TestFanIn)
+	assert.False(t, formatDate.EntryPoint)
+
+	pad := findFunction(t, metrics, "src/format.js", "pad")
+	assert.Equal(t, 1, pad.FanIn, "recursive and shadowed calls are not counted")
+
+	localPad := findFunction(t, metrics, "src/orders.js", "pad")
+	assert.Equal(t, 1, localPad.FanIn)
+
+	render := findFunction(t, metrics, "src/orders.js", "render")
+	assert.Equal(t, 0, render.FanIn)
+	assert.Equal(t, 1, render.TestFanIn, "test-only usage")
+	assert.True(t, render.EntryPoint, "exported functions without callers are entry points")
+}
+
+func TestFanInMultiplier(t *testing.T) {
+	analyzer := NewComplexityAnalyzer()
+	assert.Equal(t, 1.0, analyzer.fanInMultiplier(FunctionComplexity{}))
+	assert.InDelta(t, 1.5, analyzer.fanInMultiplier(FunctionComplexity{FanIn: 1}), 0.001)
+	assert.InDelta(t, 2.5, analyzer.fanInMultiplier(FunctionComplexity{FanIn: 7}), 0.001)
+
+	unweighted := NewComplexityAnalyzerWithConfig(ComplexityConfig{})
+	assert.Equal(t, 1.0, unweighted.fanInMultiplier(FunctionComplexity{FanIn: 7}))
+}
+
+func TestGenerateRecommendations_RanksByFanIn(t *testing.T) {
+	analyzer := NewComplexityAnalyzer()
+	analyzer.config.ReportTopN = 1
+
+	metrics := &ComplexityMetrics{
+		FunctionMetrics: []FunctionComplexity{
+			{Name: "rarelyUsed", WeightedScore: 12, SeverityLevel: "high"},
+			{Name: "usedEverywhere", WeightedScore: 10, SeverityLevel: "high", FanIn: 15},
+		},
+	}
+	analyzer.generateRecommendations(metrics)
+
+	require.NotEmpty(t, metrics.Recommendations)
+	assert.Equal(t, []string{"usedEverywhere"}, metrics.Recommendations[0].Functions)
+}
+
+func TestIsTestFile(t *testing.T) {
+	assert.True(t, isTestFile("src/format.test.ts", nil))
+	assert.True(t, isTestFile("src/format.spec.js", nil))
+	assert.True(t, isTestFile("src/__tests__/format.js", nil))
+	assert.True(t, isTestFile(`test\helpers.js`, nil))
+	assert.True(t, isTestFile("tools/test_build.py", nil))
+	assert.False(t, isTestFile("src/testing/format.js", nil))
+	assert.True(t, isTestFile("src/testing/format.js", []string{"src/testing/**"}), "configured patterns replace the defaults")
+	assert.False(t, isTestFile("src/format.test.ts", []string{"src/testing/**"}))
+	assert.True(t, isEntryFile("src/index.ts"))
+	assert.False(t, isEntryFile("src/format.ts"))
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// Dispositions of a discovered file: analyzed, or why it was not
+const (
+	DispositionAnalyzed            = "analyzed"
+	DispositionExcludedByPattern   = "excluded_by_pattern"  // in an excluded or vendored directory
+	DispositionTooLarge            = "too_large"            // above the file size limit, or line-counted only above the large-file threshold
+	DispositionGenerated           = "generated"            // generated code or a bundle
+	DispositionParseFailed         = "parse_failed"         // dropped after the parser failed on it
+	DispositionUnsupportedLanguage = "unsupported_language" // no parser for the language; still counted in language_stats
+)
+
+// FileDisposition records what the analysis did with one discovered file. An excluded
+// directory is recorded once under its own path rather than file by file.
+type FileDisposition struct {
+	Path        string `json:"path"`
+	Disposition string `json:"disposition"`
+	Detail      string `json:"detail,omitempty"`
+}
+
+// FileDispositions answers why a file was or was not analyzed: every discovered file by
+// path, with the count of each disposition
+type FileDispositions struct {
+	Counts map[string]int    `json:"counts"`
+	Files  []FileDisposition `json:"files"`
+}
+
+// buildFileDispositions combines the files left out before parsing with the outcome of
+// parsing fileContents; nil when no file was discovered
+func buildFileDispositions(fileContents map[string]string, warnings []ParseWarning, excluded []FileDisposition) *FileDispositions {
+	if len(fileContents) == 0 && len(excluded) == 0 {
+		return nil
+	}
+
+	warningsByFile := make(map[string]ParseWarning, len(warnings))
+	for _, warning := range warnings {
+		warningsByFile[warning.File] = warning
+	}
+
+	files := make([]FileDisposition, 0, len(fileContents)+len(excluded))
+	files = append(files, excluded...)
+	for _, file := range sortedKeys(fileContents) {
+		path := ast.NormalizePath(file)
+		disposition := FileDisposition{Path: path, Disposition: DispositionAnalyzed}
+		if warning, ok := warningsByFile[path]; ok {
+			if warning.Kind == ParseWarningFailed {
+				disposition.Disposition = DispositionParseFailed
+				disposition.Detail = warning.Message
+			} else {
+				disposition.Detail = fmt.Sprintf("analyzed from the parts that parsed (%s)", warning.Kind)
+			}
+		}
+		files = append(files, disposition)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	counts := make(map[string]int)
+	for _, file := range files {
+		counts[file.Disposition]++
+	}
+	return &FileDispositions{Counts: counts, Files: files}
+}
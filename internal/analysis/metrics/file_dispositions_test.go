@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFileDispositions(t *testing.T) {
+	fileContents := map[string]string{
+		"src/app.js":    "export const app = 1;\n",
+		"src/broken.js": "export const = ;\n",
+		"src/new.ts":    "export const x = 1;\n",
+	}
+	warnings := []ParseWarning{
+		{File: "src/broken.js", Kind: ParseWarningFailed, Message: "parser returned no tree"},
+		{File: "src/new.ts", Kind: ParseWarningUnsupportedSyntax, Message: "unsupported syntax"},
+	}
+	excluded := []FileDisposition{{Path: "docs/guide.md", Disposition: DispositionUnsupportedLanguage, Detail: "Markdown"}}
+
+	dispositions := buildFileDispositions(fileContents, warnings, excluded)
+
+	assert.Equal(t, []FileDisposition{
+		{Path: "docs/guide.md", Disposition: DispositionUnsupportedLanguage, Detail: "Markdown"},
+		{Path: "src/app.js", Disposition: DispositionAnalyzed},
+		{Path: "src/broken.js", Disposition: DispositionParseFailed, Detail: "parser returned no tree"},
+		{Path: "src/new.ts", Disposition: DispositionAnalyzed, Detail: "analyzed from the parts that parsed (unsupported_syntax)"},
+	}, dispositions.Files)
+	assert.Equal(t, map[string]int{DispositionAnalyzed: 2, DispositionParseFailed: 1, DispositionUnsupportedLanguage: 1}, dispositions.Counts)
+
+	assert.Nil(t, buildFileDispositions(nil, nil, nil))
+}
+
+func TestGenerateQualityReport_NoSourceFilesKeepsDispositions(t *testing.T) {
+	excluded := []FileDisposition{{Path: "main.py", Disposition: DispositionUnsupportedLanguage, Detail: "Python"}}
+
+	report, err := NewQualityReporter(QualityReportConfig{ExcludedFiles: excluded}).GenerateQualityReport(context.Background(), nil)
+	require.ErrorIs(t, err, ErrNoAnalyzableFiles)
+
+	require.NotNil(t, report.FileDispositions, "the stub report still says why nothing was analyzed")
+	assert.Equal(t, map[string]int{DispositionUnsupportedLanguage: 1}, report.FileDispositions.Counts)
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import "sort"
+
+// Keys of QualityReport.TruncatedCounts
+const (
+	TruncatedUntestedPaths    = "coverage.untested_paths"
+	TruncatedMockRequirements = "coverage.mock_requirements"
+	TruncatedAntiPatterns     = "performance.anti_patterns"
+)
+
+// severityRank orders risk and severity levels from least to most severe
+var severityRank = map[string]int{
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// mockingComplexityRank orders mocking complexity levels from simplest to hardest
+var mockingComplexityRank = map[string]int{
+	"simple":       1,
+	"moderate":     2,
+	"complex":      3,
+	"very_complex": 4,
+}
+
+// limitFindings keeps the max highest-ranked items, most severe first, and returns the
+// number of dropped items. Items of equal rank keep their original order. A max of zero
+// or less disables the limit.
+func limitFindings[T any](items []T, max int, rank func(T) int) ([]T, int) {
+	if max <= 0 || len(items) <= max {
+		return items, 0
+	}
+
+	ranked := make([]T, len(items))
+	copy(ranked, items)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return rank(ranked[i]) > rank(ranked[j])
+	})
+
+	return ranked[:max], len(items) - max
+}
+
+// limitCoverageFindings returns a copy of coverage whose untested paths and mock
+// requirements are capped at max entries, recording dropped counts in truncated
+func limitCoverageFindings(coverage *CoverageMetrics, max int, truncated map[string]int) *CoverageMetrics {
+	if coverage == nil || max <= 0 {
+		return coverage
+	}
+
+	limited := *coverage
+	var dropped int
+
+	limited.UntestedPaths, dropped = limitFindings(coverage.UntestedPaths, max, func(path UntestedPath) int {
+		return severityRank[path.RiskLevel]
+	})
+	if dropped > 0 {
+		truncated[TruncatedUntestedPaths] = dropped
+	}
+
+	limited.MockRequirements, dropped = limitFindings(coverage.MockRequirements, max, func(mock MockRequirement) int {
+		return mockingComplexityRank[mock.MockingComplexity]
+	})
+	if dropped > 0 {
+		truncated[TruncatedMockRequirements] = dropped
+	}
+
+	return &limited
+}
+
+// limitPerformanceFindings returns a copy of performance whose anti-patterns are capped
+// at max entries, recording the dropped count in truncated
+func limitPerformanceFindings(performance *PerformanceMetrics, max int, truncated map[string]int) *PerformanceMetrics {
+	if performance == nil || max <= 0 {
+		return performance
+	}
+
+	limited := *performance
+	var dropped int
+
+	limited.AntiPatterns, dropped = limitFindings(performance.AntiPatterns, max, func(antiPattern AntiPattern) int {
+		return severityRank[antiPattern.Severity]
+	})
+	if dropped > 0 {
+		truncated[TruncatedAntiPatterns] = dropped
+	}
+
+	return &limited
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitFindings_KeepsMostSevere(t *testing.T) {
+	paths := []UntestedPath{
+		{ID: "a", RiskLevel: "low"},
+		{ID: "b", RiskLevel: "critical"},
+		{ID: "c", RiskLevel: "medium"},
+		{ID: "d", RiskLevel: "high"},
+		{ID: "e", RiskLevel: "critical"},
+	}
+	rank := func(path UntestedPath) int { return severityRank[path.RiskLevel] }
+
+	limited, dropped := limitFindings(paths, 3, rank)
+	require.Len(t, limited, 3)
+	assert.Equal(t, 2, dropped)
+	assert.Equal(t, []string{"b", "e", "d"}, []string{limited[0].ID, limited[1].ID, limited[2].ID})
+	assert.Equal(t, "a", paths[0].ID, "input slice must not be reordered")
+
+	unchanged, dropped := limitFindings(paths, 0, rank)
+	assert.Equal(t, paths, unchanged)
+	assert.Zero(t, dropped)
+
+	unchanged, dropped = limitFindings(paths, 10, rank)
+	assert.Equal(t, paths, unchanged)
+	assert.Zero(t, dropped)
+}
+
+func TestLimitCoverageAndPerformanceFindings(t *testing.T) {
+	coverage := &CoverageMetrics{
+		UntestedPaths: []UntestedPath{{ID: "p1", RiskLevel: "low"}, {ID: "p2", RiskLevel: "high"}},
+		MockRequirements: []MockRequirement{
+			{ID: "m1", MockingComplexity: "simple"},
+			{ID: "m2", MockingComplexity: "very_complex"},
+			{ID: "m3", MockingComplexity: "moderate"},
+		},
+	}
+	performance := &PerformanceMetrics{
+		AntiPatterns: []AntiPattern{{Type: "a", Severity: "medium"}},
+	}
+
+	truncated := make(map[string]int)
+	limitedCoverage := limitCoverageFindings(coverage, 1, truncated)
+	limitedPerformance := limitPerformanceFindings(performance, 1, truncated)
+
+	assert.Equal(t, "p2", limitedCoverage.UntestedPaths[0].ID)
+	assert.Equal(t, "m2", limitedCoverage.MockRequirements[0].ID)
+	assert.Len(t, coverage.UntestedPaths, 2, "analyzer output is left intact")
+	assert.Len(t, limitedPerformance.AntiPatterns, 1)
+	assert.Equal(t, map[string]int{
+		TruncatedUntestedPaths:    1,
+		TruncatedMockRequirements: 2,
+	}, truncated)
+}
+
+func TestGenerateQualityReport_MaxFindingsPerCategory(t *testing.T) {
+	files := map[string]string{
+		"src/app.js": `
+function a(x) { if (x) { return fetch('/a'); } return null; }
+function b(x) { if (x) { return fetch('/b'); } return null; }
+function c(x) { if (x) { return fetch('/c'); } return null; }
+`,
+	}
+
+	unlimited, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+	require.Greater(t, len(unlimited.DetailedMetrics.Coverage.UntestedPaths), 1)
+	assert.Nil(t, unlimited.TruncatedCounts)
+
+	limited, err := NewQualityReporter(QualityReportConfig{MaxFindingsPerCategory: 1}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+	assert.Len(t, limited.DetailedMetrics.Coverage.UntestedPaths, 1)
+	assert.Equal(t, len(unlimited.DetailedMetrics.Coverage.UntestedPaths)-1, limited.TruncatedCounts[TruncatedUntestedPaths])
+	assert.Equal(t, unlimited.ComponentScores, limited.ComponentScores, "scores are computed before truncation")
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Finding kinds emitted in the NDJSON stream
+const (
+	FindingAntiPattern    = "anti_pattern"
+	FindingDebtItem       = "debt_item"
+	FindingRecommendation = "recommendation"
+	FindingCoverageGap    = "coverage_gap"
+)
+
+// Finding is one flattened report entry. The shared fields let findings of every kind be
+// loaded into a single table; Data holds the original entry for kind-specific columns.
+type Finding struct {
+	Kind        string      `json:"kind"`
+	ID          string      `json:"id"`
+	Project     string      `json:"project"`
+	GeneratedAt time.Time   `json:"generated_at"`
+	File        string      `json:"file,omitempty"`
+	Line        int         `json:"line,omitempty"`
+	Severity    string      `json:"severity"`
+	Category    string      `json:"category,omitempty"`
+	Message     string      `json:"message"`
+	Data        interface{} `json:"data"`
+}
+
+// Findings flattens the report's anti-patterns, debt items, recommendations and coverage
+// gaps, in that order. Anti-patterns have no ID of their own and are numbered in report order.
+func Findings(report *QualityReport) []Finding {
+	findings := []Finding{}
+	add := func(finding Finding) {
+		finding.Project = report.ProjectName
+		finding.GeneratedAt = report.GeneratedAt
+		findings = append(findings, finding)
+	}
+
+	details := report.DetailedMetrics
+
+	if details.Performance != nil {
+		for i, antiPattern := range details.Performance.AntiPatterns {
+			add(Finding{
+				Kind:     FindingAntiPattern,
+				ID:       fmt.Sprintf("anti_pattern_%d", i),
+				File:     antiPattern.FilePath,
+				Line:     antiPattern.StartLine,
+				Severity: antiPattern.Severity,
+				Category: antiPattern.Type,
+				Message:  antiPattern.Description,
+				Data:     antiPattern,
+			})
+		}
+	}
+
+	if details.TechnicalDebt != nil {
+		for _, name := range sortedKeys(details.TechnicalDebt.Categories) {
+			for _, item := range details.TechnicalDebt.Categories[name].Items {
+				add(Finding{
+					Kind:     FindingDebtItem,
+					ID:       item.ID,
+					File:     item.FilePath,
+					Line:     item.StartLine,
+					Severity: item.reportedSeverity(),
+					Category: item.Category,
+					Message:  item.Description,
+					Data:     item,
+				})
+			}
+		}
+	}
+
+	for _, recommendation := range report.Recommendations {
+		finding := Finding{
+			Kind:     FindingRecommendation,
+			ID:       recommendation.ID,
+			Severity: string(recommendation.Priority),
+			Category: string(recommendation.Category),
+			Message:  recommendation.Title,
+			Data:     recommendation,
+		}
+		if len(recommendation.Files) == 1 {
+			finding.File = recommendation.Files[0]
+		}
+		add(finding)
+	}
+
+	if details.Coverage != nil {
+		for _, gap := range details.Coverage.CoverageGaps {
+			add(Finding{
+				Kind:     FindingCoverageGap,
+				ID:       gap.ID,
+				File:     gap.FilePath,
+				Severity: gap.Severity,
+				Category: gap.Type,
+				Message:  gap.Impact,
+				Data:     gap,
+			})
+		}
+	}
+
+	return findings
+}
+
+// WriteFindingsNDJSON writes each finding as one JSON object per line
+func WriteFindingsNDJSON(w io.Writer, report *QualityReport) error {
+	encoder := json.NewEncoder(w)
+	for _, finding := range Findings(report) {
+		if err := encoder.Encode(finding); err != nil {
+			return fmt.Errorf("failed to write finding %s: %w", finding.ID, err)
+		}
+	}
+	return nil
+}
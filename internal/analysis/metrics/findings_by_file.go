@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Report groupings for --group-by
+const (
+	GroupByAnalyzer = "analyzer" // the full report, one section per analyzer
+	GroupByFile     = "file"     // every finding listed under its file, see GroupFindingsByFile
+)
+
+// FileFindings lists the findings of one file by line, file-level findings first
+type FileFindings struct {
+	File           string         `json:"file"`
+	SeverityCounts map[string]int `json:"severity_counts"`
+	Findings       []Finding      `json:"findings"`
+}
+
+// FindingsByFile is the report reorganized for working through the code one file at a time
+type FindingsByFile struct {
+	Project     string         `json:"project"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	QualityGate string         `json:"quality_gate,omitempty"`
+	Files       []FileFindings `json:"files"`
+	Unscoped    []Finding      `json:"unscoped,omitempty"` // findings naming no file, such as repository-wide recommendations
+}
+
+// GroupFindingsByFile regroups the findings of the report under the file each one is in,
+// files sorted by path. A recommendation spanning several files is listed under each of them.
+func GroupFindingsByFile(report *QualityReport) *FindingsByFile {
+	grouped := &FindingsByFile{
+		Project:     report.ProjectName,
+		GeneratedAt: report.GeneratedAt,
+		QualityGate: report.QualityGate,
+		Files:       []FileFindings{},
+	}
+
+	byFile := make(map[string][]Finding)
+	for _, finding := range Findings(report) {
+		files := []string{finding.File}
+		if recommendation, ok := finding.Data.(QualityRecommendation); ok && len(recommendation.Files) > 1 {
+			files = recommendation.Files
+		}
+		if files[0] == "" {
+			grouped.Unscoped = append(grouped.Unscoped, finding)
+			continue
+		}
+		for _, file := range files {
+			finding.File = file
+			byFile[file] = append(byFile[file], finding)
+		}
+	}
+
+	for _, file := range sortedKeys(byFile) {
+		findings := byFile[file]
+		sort.SliceStable(findings, func(i, j int) bool { return findings[i].Line < findings[j].Line })
+
+		counts := make(map[string]int)
+		for _, finding := range findings {
+			counts[finding.Severity]++
+		}
+		grouped.Files = append(grouped.Files, FileFindings{File: file, SeverityCounts: counts, Findings: findings})
+	}
+
+	return grouped
+}
+
+// WriteFindingsByFileJSON writes the report grouped by file as a single JSON document,
+// indented unless compact
+func WriteFindingsByFileJSON(w io.Writer, report *QualityReport, compact bool) error {
+	encoder := json.NewEncoder(w)
+	if !compact {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(GroupFindingsByFile(report)); err != nil {
+		return fmt.Errorf("failed to write findings by file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupFindingsByFile(t *testing.T) {
+	report := &QualityReport{
+		ProjectName: "acme/web",
+		QualityGate: QualityGateWarning,
+		Recommendations: []QualityRecommendation{
+			{ID: "rec_1", Title: "Split the router", Priority: PriorityHigh, Files: []string{"src/a.js", "src/b.js"}},
+			{ID: "rec_2", Title: "Adopt a debt process", Priority: PriorityLow},
+		},
+		DetailedMetrics: DetailedMetrics{
+			Performance: &PerformanceMetrics{AntiPatterns: []AntiPattern{
+				{Type: "nested_loops", Description: "Nested loop", Severity: "high", FilePath: "src/a.js", StartLine: 12},
+			}},
+			TechnicalDebt: &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+				"Complexity Debt": {Items: []TechnicalDebtItem{
+					{ID: "complexity_debt_3000", Category: "Complexity Debt", FilePath: "src/a.js", StartLine: 3, Severity: "high"},
+				}},
+			}},
+			Coverage: &CoverageMetrics{CoverageGaps: []CoverageGap{
+				{ID: "gap_0", Type: "branch", FilePath: "src/b.js", Severity: "low", Impact: "Untested branches"},
+			}},
+		},
+	}
+
+	grouped := GroupFindingsByFile(report)
+	assert.Equal(t, "acme/web", grouped.Project)
+	assert.Equal(t, QualityGateWarning, grouped.QualityGate)
+	require.Len(t, grouped.Files, 2)
+
+	a := grouped.Files[0]
+	assert.Equal(t, "src/a.js", a.File)
+	ids := []string{}
+	for _, finding := range a.Findings {
+		ids = append(ids, finding.ID)
+	}
+	assert.Equal(t, []string{"rec_1", "complexity_debt_3000", "anti_pattern_0"}, ids, "file-level findings first, then by line")
+	assert.Equal(t, map[string]int{"high": 3}, a.SeverityCounts)
+
+	b := grouped.Files[1]
+	assert.Equal(t, "src/b.js", b.File)
+	require.Len(t, b.Findings, 2)
+	assert.Equal(t, "rec_1", b.Findings[0].ID, "a recommendation spanning files is listed under each")
+	assert.Equal(t, "src/b.js", b.Findings[0].File)
+	assert.Equal(t, "gap_0", b.Findings[1].ID)
+
+	require.Len(t, grouped.Unscoped, 1)
+	assert.Equal(t, "rec_2", grouped.Unscoped[0].ID)
+}
+
+func TestWriteFindingsByFileJSON(t *testing.T) {
+	report := &QualityReport{DetailedMetrics: DetailedMetrics{
+		Performance: &PerformanceMetrics{AntiPatterns: []AntiPattern{
+			{Type: "nested_loops", Severity: "high", FilePath: "src/a.js", StartLine: 12},
+		}},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteFindingsByFileJSON(&out, report, true))
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("\n")), "compact output is one line")
+
+	var decoded struct {
+		Files []struct {
+			File     string `json:"file"`
+			Findings []struct {
+				Kind string `json:"kind"`
+				Line int    `json:"line"`
+			} `json:"findings"`
+		} `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Len(t, decoded.Files, 1)
+	assert.Equal(t, "src/a.js", decoded.Files[0].File)
+	assert.Equal(t, FindingAntiPattern, decoded.Files[0].Findings[0].Kind)
+	assert.Equal(t, 12, decoded.Files[0].Findings[0].Line)
+}
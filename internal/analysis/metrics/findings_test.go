@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindings_FlattensReportInKindOrder(t *testing.T) {
+	report := &QualityReport{
+		ProjectName: "acme/web",
+		GeneratedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Recommendations: []QualityRecommendation{
+			{ID: "rec_1", Title: "Reduce complexity", Priority: PriorityHigh, Category: CategoryQuickWins, Files: []string{"src/a.js"}},
+		},
+		DetailedMetrics: DetailedMetrics{
+			Performance: &PerformanceMetrics{AntiPatterns: []AntiPattern{
+				{Type: "nested_loops", Description: "Nested loop", Severity: "high", FilePath: "src/a.js", StartLine: 12},
+			}},
+			TechnicalDebt: &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+				"Code Smells": {Items: []TechnicalDebtItem{
+					{ID: "code_smell_0", Category: "Code Smells", FilePath: "src/b.js", StartLine: 3, Severity: "medium", Description: "Long method"},
+				}},
+			}},
+			Coverage: &CoverageMetrics{CoverageGaps: []CoverageGap{
+				{ID: "gap_0", Type: "branch", FilePath: "src/c.js", Severity: "low", Impact: "Untested branches"},
+			}},
+		},
+	}
+
+	findings := Findings(report)
+	require.Len(t, findings, 4)
+
+	kinds := []string{}
+	for _, finding := range findings {
+		kinds = append(kinds, finding.Kind)
+		assert.Equal(t, "acme/web", finding.Project)
+		assert.Equal(t, report.GeneratedAt, finding.GeneratedAt)
+	}
+	assert.Equal(t, []string{FindingAntiPattern, FindingDebtItem, FindingRecommendation, FindingCoverageGap}, kinds)
+
+	assert.Equal(t, "anti_pattern_0", findings[0].ID)
+	assert.Equal(t, 12, findings[0].Line)
+	assert.Equal(t, "src/b.js", findings[1].File)
+	assert.Equal(t, "high", findings[2].Severity)
+	assert.Equal(t, "src/a.js", findings[2].File)
+	assert.Equal(t, "gap_0", findings[3].ID)
+}
+
+func TestWriteFindingsNDJSON(t *testing.T) {
+	files := map[string]string{
+		"src/app.js": `
+export function load(items) {
+  for (const a of items) { for (const b of items) { if (a === b) { console.log(a); } } }
+}
+`,
+	}
+	report, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteFindingsNDJSON(&out, report))
+
+	lines := 0
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var finding map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &finding), "each line is a standalone JSON object")
+		assert.Contains(t, finding, "kind")
+		assert.Contains(t, finding, "id")
+		assert.Contains(t, finding, "severity")
+		lines++
+	}
+	assert.Equal(t, len(Findings(report)), lines)
+	assert.Greater(t, lines, 0)
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FocusWeightShare is the share of the total weight the focus component gets; the other
+// components split the rest in their configured proportions
+const FocusWeightShare = 0.5
+
+// FocusComponents are the components a focus can boost: those weighted in the overall score
+var FocusComponents = []SectionName{
+	SectionComplexity,
+	SectionDuplication,
+	SectionTechnicalDebt,
+	SectionCoverage,
+	SectionPerformance,
+	SectionMaintainability,
+	SectionSecurity,
+}
+
+// ReportFocus notes the focus area of a run. The overall score is weighted toward the
+// focus component, so DefaultScore keeps the score with the configured weights for
+// comparison with other runs.
+type ReportFocus struct {
+	Component    SectionName `json:"component"`
+	Weight       float64     `json:"weight"`        // share of the total weight given to the component, 0-1
+	DefaultScore float64     `json:"default_score"` // overall score with the configured weights
+}
+
+// DefaultWeightedScore is the overall score with the configured weights: Focus.DefaultScore
+// for a focused run, so comparisons across runs are not skewed by a focus reweighting
+func (r *QualityReport) DefaultWeightedScore() float64 {
+	if r.Focus != nil {
+		return r.Focus.DefaultScore
+	}
+	return r.OverallScore
+}
+
+// ParseFocus parses a focus component such as "coverage"
+func ParseFocus(value string) (SectionName, error) {
+	component := SectionName(strings.ToLower(strings.TrimSpace(value)))
+	if !containsSection(FocusComponents, component) {
+		supported := make([]string, 0, len(FocusComponents))
+		for _, c := range FocusComponents {
+			supported = append(supported, string(c))
+		}
+		return "", fmt.Errorf("unknown component %q: expected %s", value, strings.Join(supported, ", "))
+	}
+	return component, nil
+}
+
+// focused returns the weights with component raised to FocusWeightShare of the total and
+// the others kept, so they keep their proportions among themselves
+func (w QualityWeights) focused(component SectionName) QualityWeights {
+	weights := map[SectionName]*float64{
+		SectionComplexity:      &w.Complexity,
+		SectionDuplication:     &w.Duplication,
+		SectionTechnicalDebt:   &w.TechnicalDebt,
+		SectionCoverage:        &w.Coverage,
+		SectionPerformance:     &w.Performance,
+		SectionMaintainability: &w.Maintainability,
+		SectionSecurity:        &w.Security,
+	}
+	focus, ok := weights[component]
+	if !ok {
+		return w
+	}
+
+	others := 0.0
+	for name, weight := range weights {
+		if name != component {
+			others += *weight
+		}
+	}
+	*focus = others * FocusWeightShare / (1 - FocusWeightShare)
+	return w
+}
+
+// weights returns the weights of the overall score, boosted toward the focus component
+func (qr *QualityReporter) weights() QualityWeights {
+	if qr.config.Focus == "" {
+		return qr.config.WeightingFactors
+	}
+	return qr.config.WeightingFactors.focused(qr.config.Focus)
+}
+
+// reportFocus notes the active focus with the score it replaced, or returns nil without one
+func (qr *QualityReporter) reportFocus(scores ComponentScores) *ReportFocus {
+	if qr.config.Focus == "" {
+		return nil
+	}
+	return &ReportFocus{
+		Component:    qr.config.Focus,
+		Weight:       FocusWeightShare,
+		DefaultScore: qr.weightedScore(scores, qr.config.WeightingFactors),
+	}
+}
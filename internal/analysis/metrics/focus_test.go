@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFocus(t *testing.T) {
+	focus, err := ParseFocus(" Coverage ")
+	require.NoError(t, err)
+	assert.Equal(t, SectionCoverage, focus)
+
+	_, err = ParseFocus("documentation")
+	assert.EqualError(t, err, `unknown component "documentation": expected complexity, duplication, technical_debt, coverage, performance, maintainability, security`)
+}
+
+func TestQualityWeights_Focused(t *testing.T) {
+	weights := QualityWeights{Complexity: 0.2, Duplication: 0.15, TechnicalDebt: 0.25, Coverage: 0.2, Performance: 0.1, Maintainability: 0.1}
+
+	focused := weights.focused(SectionCoverage)
+
+	assert.InDelta(t, 0.8, focused.Coverage, 1e-9, "as much as all the others together")
+	assert.Equal(t, weights.Complexity, focused.Complexity, "the others keep their proportions")
+	assert.Equal(t, weights.TechnicalDebt, focused.TechnicalDebt)
+	assert.Equal(t, 0.2, weights.Coverage, "the configured weights are left alone")
+}
+
+func TestGenerateQualityReport_Focus(t *testing.T) {
+	files := map[string]string{
+		"src/math.js": "export function add(a, b) {\n  return a + b;\n}\n",
+		"src/app.js":  "export function run(x) {\n  if (x > 1) { return 1; }\n  if (x > 2) { return 2; }\n  return 0;\n}\n",
+	}
+
+	base, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+	assert.Nil(t, base.Focus)
+
+	report, err := NewQualityReporter(QualityReportConfig{Focus: SectionTechnicalDebt, IncludeExecutiveSummary: true}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	require.NotNil(t, report.Focus)
+	assert.Equal(t, SectionTechnicalDebt, report.Focus.Component)
+	assert.Equal(t, FocusWeightShare, report.Focus.Weight)
+	assert.Equal(t, base.OverallScore, report.Focus.DefaultScore)
+	assert.Less(t, report.OverallScore, base.OverallScore, "technical debt is the weakest component here")
+	assert.Contains(t, report.ExecutiveSummary.OverallAssessment, "weighted toward technical debt for this run")
+
+	require.NotEmpty(t, report.Recommendations)
+	assert.Equal(t, "maintainability", base.Recommendations[0].Component)
+	assert.Equal(t, "technical_debt", report.Recommendations[0].Component, "the focus component's recommendations come first")
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// FunctionSizeThresholds defines, in lines, when a function is too long. The debt scorer's
+// long_method smell and the performance analyzer's large_function anti-pattern both read
+// it, so one function is judged the same way in every section of the report.
+type FunctionSizeThresholds struct {
+	Smell    int `yaml:"smell" json:"smell"`       // longer functions are long_method debt
+	Critical int `yaml:"critical" json:"critical"` // longer functions are large_function anti-patterns and high-severity debt
+}
+
+// DefaultFunctionSizeThresholds returns the thresholds used when none are configured
+func DefaultFunctionSizeThresholds() FunctionSizeThresholds {
+	return FunctionSizeThresholds{Smell: 30, Critical: 100}
+}
+
+// withDefaults fills unset thresholds from DefaultFunctionSizeThresholds
+func (t FunctionSizeThresholds) withDefaults() FunctionSizeThresholds {
+	defaults := DefaultFunctionSizeThresholds()
+	if t.Smell <= 0 {
+		t.Smell = defaults.Smell
+	}
+	if t.Critical <= 0 {
+		t.Critical = defaults.Critical
+	}
+	return t
+}
+
+// Validate checks that the smell threshold, defaults filled in, is below the critical one;
+// otherwise every large_function would be reported before the long_method it grew from
+func (t FunctionSizeThresholds) Validate() error {
+	resolved := t.withDefaults()
+	if resolved.Smell >= resolved.Critical {
+		return fmt.Errorf("smell threshold (%d lines) must be below the critical threshold (%d lines)", resolved.Smell, resolved.Critical)
+	}
+	return nil
+}
+
+// functionLineCount returns the number of lines a function spans, inclusive
+func functionLineCount(function ast.FunctionInfo) int {
+	return function.EndLine - function.StartLine + 1
+}
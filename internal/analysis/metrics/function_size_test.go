@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestFunctionSizeThresholds_WithDefaults(t *testing.T) {
+	assert.Equal(t, DefaultFunctionSizeThresholds(), FunctionSizeThresholds{}.withDefaults())
+	assert.Equal(t, FunctionSizeThresholds{Smell: 20, Critical: 100}, FunctionSizeThresholds{Smell: 20}.withDefaults())
+}
+
+func TestFunctionSizeThresholds_Validate(t *testing.T) {
+	assert.NoError(t, FunctionSizeThresholds{}.Validate())
+	assert.NoError(t, FunctionSizeThresholds{Smell: 50, Critical: 80}.Validate())
+	assert.Error(t, FunctionSizeThresholds{Smell: 80, Critical: 80}.Validate())
+	assert.Error(t, FunctionSizeThresholds{Smell: 150}.Validate(), "the default critical threshold is 100")
+}
+
+func TestFunctionSizeThresholds_SharedByAnalyzers(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{
+		FunctionSize: FunctionSizeThresholds{Smell: 20, Critical: 40},
+	})
+	function := ast.FunctionInfo{Name: "process", StartLine: 1, EndLine: 45}
+
+	assert.True(t, reporter.debtScorer.isLongMethod(function))
+	assert.Equal(t, "high", reporter.debtScorer.determineLongMethodSeverity(function))
+
+	metrics := &PerformanceMetrics{AntiPatterns: []AntiPattern{}}
+	reporter.performanceAnalyzer.detectLargeFunctions(&ast.ParseResult{
+		FilePath:  "src/process.js",
+		Functions: []ast.FunctionInfo{function, {Name: "short", StartLine: 50, EndLine: 75}},
+	}, metrics)
+
+	require.Len(t, metrics.AntiPatterns, 1)
+	assert.Contains(t, metrics.AntiPatterns[0].Description, "'process' is too large (45 lines)")
+	assert.Equal(t, "high", metrics.AntiPatterns[0].Severity)
+
+	short := ast.FunctionInfo{Name: "short", StartLine: 50, EndLine: 75}
+	assert.True(t, reporter.debtScorer.isLongMethod(short), "26 lines is a smell but not critical")
+	assert.Equal(t, "low", reporter.debtScorer.determineLongMethodSeverity(short))
+}
@@ -0,0 +1,71 @@
+package metrics
+
+// Quality gate outcomes of a report
+const (
+	QualityGatePass    = "pass"
+	QualityGateWarning = "warning"
+	QualityGateFail    = "fail"
+)
+
+// gatingSeverities maps the debt severities that affect the quality gate to the outcome
+// they lead to
+var gatingSeverities = map[string]string{
+	"critical": QualityGateFail,
+	"high":     QualityGateWarning,
+}
+
+// markInformational flags the debt items whose confidence is below GateMinConfidence.
+// They stay in the report but no longer count toward the quality gate.
+func (qr *QualityReporter) markInformational(technicalDebt *TechnicalDebtMetrics) {
+	for _, category := range technicalDebt.Categories {
+		for i := range category.Items {
+			category.Items[i].Informational = category.Items[i].ConfidenceScore < qr.config.GateMinConfidence
+		}
+	}
+}
+
+// gating reports whether a debt item counts toward the gate; informational and accepted
+// items are reported but never gate
+func (item TechnicalDebtItem) gating() bool {
+	return !item.Informational && !item.Accepted
+}
+
+// reportedSeverity is the severity the SARIF, NDJSON and Sonar exports give a debt item:
+// "info" for an informational or accepted one, so tools reading them do not act on it either
+func (item TechnicalDebtItem) reportedSeverity() string {
+	if !item.gating() {
+		return "info"
+	}
+	return item.Severity
+}
+
+// evaluateQualityGate combines the coverage gate with the anti-patterns and debt items that
+// count toward the gate: a critical finding fails it and a high one warns. Informational
+// debt items and accepted complexity are left out.
+func (qr *QualityReporter) evaluateQualityGate(technicalDebt *TechnicalDebtMetrics, coverage *CoverageMetrics, performance *PerformanceMetrics, scores ComponentScores) string {
+	gate := QualityGatePass
+	raise := func(outcome string) {
+		if outcome == QualityGateFail || (outcome == QualityGateWarning && gate == QualityGatePass) {
+			gate = outcome
+		}
+	}
+
+	if scores.isAvailable(string(SectionCoverage)) {
+		raise(coverage.Summary.QualityGate)
+	}
+	if scores.isAvailable(string(SectionPerformance)) {
+		for _, antiPattern := range performance.AntiPatterns {
+			raise(gatingSeverities[antiPattern.Severity])
+		}
+	}
+	if scores.isAvailable(string(SectionTechnicalDebt)) {
+		for _, category := range technicalDebt.Categories {
+			for _, item := range category.Items {
+				if item.gating() {
+					raise(gatingSeverities[item.Severity])
+				}
+			}
+		}
+	}
+	return gate
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gateFixture() (*TechnicalDebtMetrics, *CoverageMetrics) {
+	technicalDebt := &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+		"Code Smells": {Items: []TechnicalDebtItem{
+			{ID: "smell_1", Severity: "critical", ConfidenceScore: 0.5},
+			{ID: "smell_2", Severity: "high", ConfidenceScore: 0.9},
+			{ID: "smell_3", Severity: "medium", ConfidenceScore: 0.9},
+		}},
+	}}
+	coverage := &CoverageMetrics{Summary: CoverageSummary{QualityGate: QualityGatePass}}
+	return technicalDebt, coverage
+}
+
+func TestEvaluateQualityGate_MinConfidence(t *testing.T) {
+	technicalDebt, coverage := gateFixture()
+	reporter := NewQualityReporter(QualityReportConfig{})
+	reporter.markInformational(technicalDebt)
+	assert.Equal(t, QualityGateFail, reporter.evaluateQualityGate(technicalDebt, coverage, &PerformanceMetrics{}, ComponentScores{}),
+		"without a minimum every critical finding fails the gate")
+
+	reporter = NewQualityReporter(QualityReportConfig{GateMinConfidence: 0.6})
+	reporter.markInformational(technicalDebt)
+	items := technicalDebt.Categories["Code Smells"].Items
+	assert.True(t, items[0].Informational)
+	assert.False(t, items[1].Informational)
+	assert.Equal(t, QualityGateWarning, reporter.evaluateQualityGate(technicalDebt, coverage, &PerformanceMetrics{}, ComponentScores{}),
+		"the low-confidence critical finding is informational; the high one still warns")
+}
+
+func TestEvaluateQualityGate_Coverage(t *testing.T) {
+	technicalDebt, coverage := gateFixture()
+	reporter := NewQualityReporter(QualityReportConfig{GateMinConfidence: 1})
+	reporter.markInformational(technicalDebt)
+	assert.Equal(t, QualityGatePass, reporter.evaluateQualityGate(technicalDebt, coverage, &PerformanceMetrics{}, ComponentScores{}))
+
+	coverage.Summary.QualityGate = QualityGateFail
+	assert.Equal(t, QualityGateFail, reporter.evaluateQualityGate(technicalDebt, coverage, &PerformanceMetrics{}, ComponentScores{}))
+	assert.Equal(t, QualityGatePass, reporter.evaluateQualityGate(technicalDebt, coverage, &PerformanceMetrics{}, ComponentScores{Unavailable: []string{"coverage"}}),
+		"an unavailable coverage analysis has no gate")
+}
+
+// informationalReport is a report whose first debt item, a critical one, is informational
+func informationalReport() *QualityReport {
+	technicalDebt, _ := gateFixture()
+	technicalDebt.Categories["Code Smells"].Items[0].Informational = true
+	for i := range technicalDebt.Categories["Code Smells"].Items {
+		technicalDebt.Categories["Code Smells"].Items[i].FilePath = "src/a.js"
+	}
+	return &QualityReport{DetailedMetrics: DetailedMetrics{TechnicalDebt: technicalDebt}}
+}
+
+func TestWriteSARIF_InformationalFindingsAreNotes(t *testing.T) {
+	log := BuildSARIF(informationalReport())
+	assert.Equal(t, "note", log.Runs[0].Results[0].Level)
+	assert.Equal(t, "error", log.Runs[0].Results[1].Level)
+}
+
+func TestExports_InformationalFindingsAreInfo(t *testing.T) {
+	report := informationalReport()
+
+	findings := Findings(report)
+	assert.Equal(t, "info", findings[0].Severity)
+	assert.Equal(t, "high", findings[1].Severity)
+
+	issues := BuildSonarIssues(report).Issues
+	assert.Equal(t, "INFO", issues[0].Severity)
+	assert.Equal(t, "MAJOR", issues[1].Severity)
+}
+
+func TestExports_AcceptedFindingsAreInfo(t *testing.T) {
+	report := informationalReport()
+	items := report.DetailedMetrics.TechnicalDebt.Categories["Code Smells"].Items
+	items[0].Informational = false
+	items[1].Accepted = true
+
+	assert.Equal(t, "error", BuildSARIF(report).Runs[0].Results[0].Level)
+	assert.Equal(t, "note", BuildSARIF(report).Runs[0].Results[1].Level)
+	assert.Equal(t, "info", Findings(report)[1].Severity)
+	assert.Equal(t, "INFO", BuildSonarIssues(report).Issues[1].Severity)
+}
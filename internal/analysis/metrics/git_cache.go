@@ -0,0 +1,327 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// defaultBlameWorkers is how many git blame processes a GitDataCache runs at once
+const defaultBlameWorkers = 4
+
+// GitDataCacheConfig defines settings for git data caching
+type GitDataCacheConfig struct {
+	BlameWorkers int `yaml:"blame_workers" json:"blame_workers"` // concurrent git blame processes; 0 uses 4
+}
+
+// FileHistory is the commit history of one file
+type FileHistory struct {
+	Commits      int            `json:"commits"`
+	LinesAdded   int            `json:"lines_added"`
+	LinesDeleted int            `json:"lines_deleted"`
+	LastModified time.Time      `json:"last_modified"`
+	Authors      map[string]int `json:"authors"` // commits per author
+}
+
+// Churn is the number of lines added and deleted over the file's history
+func (h *FileHistory) Churn() int {
+	return h.LinesAdded + h.LinesDeleted
+}
+
+// GitDataCache serves git history from memory: the full git log --numstat is read once
+// and answers every churn, last-modified, authorship and change-window question, and each
+// file is blamed at most once. Its methods are safe for concurrent use, so the analyzers
+// of a run can share one cache. The history is loaded with the context of the first call.
+type GitDataCache struct {
+	history *GitHistoryAnalyzer
+	config  GitDataCacheConfig
+
+	loadOnce sync.Once
+	files    map[string]*FileHistory
+	loadErr  error
+
+	mu           sync.Mutex
+	head         string
+	blames       map[string]*cachedBlame
+	commitsSince map[string]map[string]bool
+	elapsed      time.Duration
+}
+
+// cachedBlame is the blame of one file, computed once
+type cachedBlame struct {
+	once  sync.Once
+	lines []string
+	err   error
+}
+
+// NewGitDataCache creates a cache for the repository at repoPath with default configuration
+func NewGitDataCache(repoPath string) *GitDataCache {
+	return NewGitDataCacheWithConfig(repoPath, GitDataCacheConfig{BlameWorkers: defaultBlameWorkers})
+}
+
+// NewGitDataCacheWithConfig creates a cache with custom configuration
+func NewGitDataCacheWithConfig(repoPath string, config GitDataCacheConfig) *GitDataCache {
+	if config.BlameWorkers <= 0 {
+		config.BlameWorkers = defaultBlameWorkers
+	}
+	return &GitDataCache{
+		history:      NewGitHistoryAnalyzer(repoPath),
+		config:       config,
+		blames:       make(map[string]*cachedBlame),
+		commitsSince: make(map[string]map[string]bool),
+	}
+}
+
+// Elapsed is the wall time spent running git for the cache so far
+func (gc *GitDataCache) Elapsed() time.Duration {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	return gc.elapsed
+}
+
+// track adds the time since start to Elapsed
+func (gc *GitDataCache) track(start time.Time) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	gc.elapsed += time.Since(start)
+}
+
+// load reads the full history on first use
+func (gc *GitDataCache) load(ctx context.Context) (map[string]*FileHistory, error) {
+	gc.loadOnce.Do(func() {
+		defer gc.track(time.Now())
+		output, err := gc.history.numstatLog(ctx)
+		if err != nil {
+			gc.loadErr = err
+			return
+		}
+		gc.files, gc.loadErr = parseGitNumstat(output)
+	})
+	return gc.files, gc.loadErr
+}
+
+// FileHistory returns the history of file, a slash-separated path relative to the repository
+// root; ok is false for files without commits, such as untracked ones
+func (gc *GitDataCache) FileHistory(ctx context.Context, file string) (history FileHistory, ok bool, err error) {
+	files, err := gc.load(ctx)
+	if err != nil {
+		return FileHistory{}, false, err
+	}
+	if h, ok := files[ast.NormalizePath(file)]; ok {
+		return *h, true, nil
+	}
+	return FileHistory{}, false, nil
+}
+
+// ChangedSince is GitHistoryAnalyzer.ChangedSince answered from the cached history
+func (gc *GitDataCache) ChangedSince(ctx context.Context, cutoff time.Time) (map[string]time.Time, error) {
+	files, err := gc.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]time.Time)
+	for file, h := range files {
+		if h.LastModified.After(cutoff) {
+			changes[file] = h.LastModified
+		}
+	}
+	return changes, nil
+}
+
+// authorCommits returns commit counts per file and author, the input of the bus factor
+func (gc *GitDataCache) authorCommits(ctx context.Context) (map[string]map[string]int, error) {
+	files, err := gc.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make(map[string]map[string]int, len(files))
+	for file, h := range files {
+		commits[file] = h.Authors
+	}
+	return commits, nil
+}
+
+// HeadCommit is GitHistoryAnalyzer.HeadCommit, run once
+func (gc *GitDataCache) HeadCommit(ctx context.Context) (string, error) {
+	gc.mu.Lock()
+	head := gc.head
+	gc.mu.Unlock()
+	if head != "" {
+		return head, nil
+	}
+
+	start := time.Now()
+	head, err := gc.history.HeadCommit(ctx)
+	gc.track(start)
+	if err != nil {
+		return "", err
+	}
+
+	gc.mu.Lock()
+	gc.head = head
+	gc.mu.Unlock()
+	return head, nil
+}
+
+// CommitsSince is GitHistoryAnalyzer.CommitsSince, run once per ref
+func (gc *GitDataCache) CommitsSince(ctx context.Context, ref string) (map[string]bool, error) {
+	gc.mu.Lock()
+	commits, ok := gc.commitsSince[ref]
+	gc.mu.Unlock()
+	if ok {
+		return commits, nil
+	}
+
+	start := time.Now()
+	commits, err := gc.history.CommitsSince(ctx, ref)
+	gc.track(start)
+	if err != nil {
+		return nil, err
+	}
+
+	gc.mu.Lock()
+	gc.commitsSince[ref] = commits
+	gc.mu.Unlock()
+	return commits, nil
+}
+
+// Blame returns the commit that last modified each line of files, indexed by line number - 1
+// and keyed by file. Files not blamed yet are blamed by up to BlameWorkers concurrent git
+// processes; files git cannot blame, such as untracked ones, are left out.
+func (gc *GitDataCache) Blame(ctx context.Context, files []string) map[string][]string {
+	entries := make(map[string]*cachedBlame, len(files))
+	gc.mu.Lock()
+	for _, file := range files {
+		entry, ok := gc.blames[file]
+		if !ok {
+			entry = &cachedBlame{}
+			gc.blames[file] = entry
+		}
+		entries[file] = entry
+	}
+	gc.mu.Unlock()
+
+	start := time.Now()
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < min(gc.config.BlameWorkers, len(entries)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range queue {
+				entry := entries[file]
+				entry.once.Do(func() {
+					entry.lines, entry.err = gc.history.blame(ctx, file)
+				})
+			}
+		}()
+	}
+	for file := range entries {
+		queue <- file
+	}
+	close(queue)
+	wg.Wait()
+	gc.track(start)
+
+	blames := make(map[string][]string, len(entries))
+	for file, entry := range entries {
+		if entry.err == nil {
+			blames[file] = entry.lines
+		}
+	}
+	return blames
+}
+
+// NewDebtSince is GitHistoryAnalyzer.NewDebtSince with the debt files blamed as one batch
+func (gc *GitDataCache) NewDebtSince(ctx context.Context, ref string, technicalDebt *TechnicalDebtMetrics) (*DebtChange, error) {
+	commits, err := gc.CommitsSince(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return markNewDebt(ref, commits, technicalDebt, gc.Blame(ctx, debtFiles(technicalDebt))), nil
+}
+
+// numstatLog runs git log over the full history, listing the lines added and deleted per
+// file after a "commit <sha> <unix time> <author>" header line
+func (gh *GitHistoryAnalyzer) numstatLog(ctx context.Context) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", gh.repoPath,
+		"-c", "core.quotePath=false",
+		"log", "--no-renames", "--numstat", "--format=commit %H %ct %aN")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+// parseGitNumstat reads numstatLog output into per-file histories. git log lists newest
+// commits first, so the first commit seen for a file is its last modification. Binary
+// files report "-" for their line counts and only count commits.
+func parseGitNumstat(output []byte) (map[string]*FileHistory, error) {
+	files := make(map[string]*FileHistory)
+	var committed time.Time
+	author := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if header, ok := strings.CutPrefix(line, "commit "); ok {
+			fields := strings.SplitN(header, " ", 3)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("invalid commit header %q", line)
+			}
+			seconds, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid commit time %q: %w", fields[1], err)
+			}
+			committed = time.Unix(seconds, 0).UTC()
+			author = ""
+			if len(fields) == 3 {
+				author = strings.TrimSpace(fields[2])
+			}
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid numstat line %q", line)
+		}
+		file := ast.NormalizePath(fields[2])
+		h, ok := files[file]
+		if !ok {
+			h = &FileHistory{LastModified: committed, Authors: make(map[string]int)}
+			files[file] = h
+		}
+		h.Commits++
+		h.Authors[author]++
+		if added, err := strconv.Atoi(fields[0]); err == nil {
+			h.LinesAdded += added
+		}
+		if deleted, err := strconv.Atoi(fields[1]); err == nil {
+			h.LinesDeleted += deleted
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git log output: %w", err)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitNumstat(t *testing.T) {
+	output := []byte("commit bbb 1700000100 Bob Smith\n\n3\t1\tsrc/app.js\n-\t-\tassets/logo.png\n" +
+		"commit aaa 1700000000 alice\n\n10\t0\tsrc/app.js\n")
+
+	files, err := parseGitNumstat(output)
+	require.NoError(t, err)
+
+	require.Contains(t, files, "src/app.js")
+	app := files["src/app.js"]
+	assert.Equal(t, 2, app.Commits)
+	assert.Equal(t, 14, app.Churn())
+	assert.Equal(t, time.Unix(1700000100, 0).UTC(), app.LastModified, "newest commit first")
+	assert.Equal(t, map[string]int{"Bob Smith": 1, "alice": 1}, app.Authors)
+	assert.Equal(t, 0, files["assets/logo.png"].Churn(), "binary files only count commits")
+
+	_, err = parseGitNumstat([]byte("commit aaa soon alice\n"))
+	assert.Error(t, err)
+}
+
+func TestGitDataCache(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", repo).Run())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	commitFileAs(t, repo, "alice", "src/legacy.js", "var a = 1;\n", now.AddDate(-1, 0, 0))
+	commitFileAs(t, repo, "alice", "src/app.js", "var b = 1;\n", now.AddDate(0, 0, -200))
+	commitFileAs(t, repo, "bob", "src/app.js", "var b = 2;\nvar c = 3;\n", now.AddDate(0, 0, -10))
+
+	cache := NewGitDataCacheWithConfig(repo, GitDataCacheConfig{BlameWorkers: 2})
+	ctx := context.Background()
+
+	history, ok, err := cache.FileHistory(ctx, "src/app.js")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 2, history.Commits)
+	assert.Equal(t, 4, history.Churn(), "1 line added, then 1 changed and 1 added")
+	assert.Equal(t, now.AddDate(0, 0, -10), history.LastModified)
+
+	_, ok, err = cache.FileHistory(ctx, "src/untracked.js")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	cutoff := now.AddDate(0, 0, -90)
+	cached, err := cache.ChangedSince(ctx, cutoff)
+	require.NoError(t, err)
+	direct, err := NewGitHistoryAnalyzer(repo).ChangedSince(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, direct, cached)
+
+	// Concurrent analyzers share one blame per file
+	var wg sync.WaitGroup
+	results := make([]map[string][]string, 4)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = cache.Blame(ctx, []string{"src/app.js", "src/legacy.js", "src/untracked.js"})
+		}(i)
+	}
+	wg.Wait()
+	for _, blames := range results {
+		assert.Len(t, blames, 2, "files git cannot blame are left out")
+		assert.Len(t, blames["src/app.js"], 2)
+		assert.Equal(t, results[0]["src/app.js"], blames["src/app.js"])
+	}
+	assert.Len(t, cache.blames, 3)
+	assert.Positive(t, cache.Elapsed())
+
+	risk, err := NewBusFactorAnalyzerWithCache(cache).AnalyzeBusFactor(ctx, []string{"src/app.js", "src/legacy.js"})
+	require.NoError(t, err)
+	require.Len(t, risk.Modules, 1)
+	assert.Equal(t, 3, risk.Modules[0].Commits)
+	assert.Equal(t, 2, risk.Modules[0].Authors)
+}
+
+func TestGitDataCache_NotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	cache := NewGitDataCache(t.TempDir())
+	_, err := cache.ChangedSince(context.Background(), time.Now())
+	assert.Error(t, err)
+	_, _, err = cache.FileHistory(context.Background(), "src/app.js")
+	assert.Error(t, err, "the load error is kept")
+}
@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// GitHistoryAnalyzer reads file change history from a git working copy
+type GitHistoryAnalyzer struct {
+	repoPath string
+}
+
+// ChangeWindow describes a report restricted to recently changed files
+type ChangeWindow struct {
+	Since       string    `json:"since"`  // e.g. "90d"
+	Cutoff      time.Time `json:"cutoff"` // files last changed before this are stable
+	ActiveFiles int       `json:"active_files"`
+	StableFiles []string  `json:"stable_files"` // untouched within the window; excluded from technical debt
+}
+
+// NewGitHistoryAnalyzer creates a history analyzer for the repository at repoPath
+func NewGitHistoryAnalyzer(repoPath string) *GitHistoryAnalyzer {
+	return &GitHistoryAnalyzer{
+		repoPath: repoPath,
+	}
+}
+
+// ChangedSince returns the most recent commit time of every file changed after cutoff,
+// keyed by slash-separated path relative to the repository root. The repository needs
+// commit history back to cutoff; a shallow clone only sees its latest commit.
+func (gh *GitHistoryAnalyzer) ChangedSince(ctx context.Context, cutoff time.Time) (map[string]time.Time, error) {
+	output, err := gh.log(ctx, "--format=commit %ct", fmt.Sprintf("--since=%d", cutoff.Unix()))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseGitLogChanges(output)
+}
+
+// HeadCommit returns the full SHA of the checked-out commit
+func (gh *GitHistoryAnalyzer) HeadCommit(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", gh.repoPath, "rev-parse", "HEAD")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// log runs git log listing the files changed by each commit after a header line in format
+func (gh *GitHistoryAnalyzer) log(ctx context.Context, format string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", gh.repoPath,
+		"-c", "core.quotePath=false",
+		"log", "--no-renames", "--name-only", format}, args...)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+// parseGitLogChanges reads "commit <unix time>" headers each followed by changed file names.
+// git log lists newest commits first, so the first time seen for a file is its latest change.
+func parseGitLogChanges(output []byte) (map[string]time.Time, error) {
+	changes := make(map[string]time.Time)
+	var current time.Time
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if timestamp, ok := strings.CutPrefix(line, "commit "); ok {
+			seconds, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid commit time %q: %w", timestamp, err)
+			}
+			current = time.Unix(seconds, 0).UTC()
+			continue
+		}
+
+		file := ast.NormalizePath(line)
+		if _, seen := changes[file]; !seen {
+			changes[file] = current
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git log output: %w", err)
+	}
+
+	return changes, nil
+}
+
+// FormatWindow renders a change window as days when it is a whole number of days ("90d")
+func FormatWindow(window time.Duration) string {
+	const day = 24 * time.Hour
+	if window > 0 && window%day == 0 {
+		return fmt.Sprintf("%dd", window/day)
+	}
+	return window.String()
+}
+
+// newChangeWindow splits the analyzed files into active and stable ones
+func newChangeWindow(window time.Duration, cutoff time.Time, files []string, active map[string]bool) *ChangeWindow {
+	changeWindow := &ChangeWindow{
+		Since:       FormatWindow(window),
+		Cutoff:      cutoff,
+		StableFiles: []string{},
+	}
+
+	for _, file := range files {
+		if active[file] {
+			changeWindow.ActiveFiles++
+		} else {
+			changeWindow.StableFiles = append(changeWindow.StableFiles, file)
+		}
+	}
+	sort.Strings(changeWindow.StableFiles)
+
+	return changeWindow
+}
+
+// activeDebtItems keeps the debt items located in active files
+func activeDebtItems(items []TechnicalDebtItem, active map[string]bool) []TechnicalDebtItem {
+	kept := []TechnicalDebtItem{}
+	for _, item := range items {
+		if active[item.FilePath] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// activeParseResults keeps the parse results of active files
+func activeParseResults(parseResults []*ast.ParseResult, active map[string]bool) []*ast.ParseResult {
+	kept := []*ast.ParseResult{}
+	for _, result := range parseResults {
+		if active[result.FilePath] {
+			kept = append(kept, result)
+		}
+	}
+	return kept
+}
+
+// activeImportCycles keeps the import cycles that pass through at least one active file
+func activeImportCycles(cycles []importCycle, active map[string]bool) []importCycle {
+	kept := []importCycle{}
+	for _, cycle := range cycles {
+		for _, module := range cycle.Modules {
+			if active[module] {
+				kept = append(kept, cycle)
+				break
+			}
+		}
+	}
+	return kept
+}
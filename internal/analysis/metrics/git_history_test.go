@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitFile writes content to relPath in repo and commits it with the given commit date
+func commitFile(t *testing.T, repo, relPath, content string, date time.Time) {
+	t.Helper()
+	commitFileAs(t, repo, "test", relPath, content, date)
+}
+
+// commitFileAs is commitFile with the given author name
+func commitFileAs(t *testing.T, repo, author, relPath, content string, date time.Time) {
+	t.Helper()
+	path := filepath.Join(repo, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	stamp := date.Format(time.RFC3339)
+	for _, args := range [][]string{
+		{"add", relPath},
+		{"-c", "user.name=" + author, "-c", "user.email=" + author + "@example.com", "commit", "-q", "-m", "update " + relPath},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+stamp, "GIT_COMMITTER_DATE="+stamp)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+}
+
+func TestGitHistoryAnalyzer_ChangedSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", repo).Run())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	commitFile(t, repo, "src/legacy.js", "var a = 1;\n", now.AddDate(-1, 0, 0))
+	commitFile(t, repo, "src/app.js", "var b = 1;\n", now.AddDate(0, 0, -200))
+	commitFile(t, repo, "src/app.js", "var b = 2;\n", now.AddDate(0, 0, -10))
+	commitFile(t, repo, "src/new.js", "var c = 1;\n", now.AddDate(0, 0, -2))
+
+	changes, err := NewGitHistoryAnalyzer(repo).ChangedSince(context.Background(), now.AddDate(0, 0, -90))
+	require.NoError(t, err)
+
+	assert.Len(t, changes, 2)
+	assert.Equal(t, now.AddDate(0, 0, -10), changes["src/app.js"], "latest change wins")
+	assert.Equal(t, now.AddDate(0, 0, -2), changes["src/new.js"])
+	assert.NotContains(t, changes, "src/legacy.js")
+}
+
+func TestGitHistoryAnalyzer_NotARepository(t *testing.T) {
+	_, err := NewGitHistoryAnalyzer(t.TempDir()).ChangedSince(context.Background(), time.Now())
+	assert.Error(t, err)
+}
+
+func TestParseGitLogChanges(t *testing.T) {
+	output := []byte("commit 1700000200\n\nsrc/a.js\nsrc/b.js\n\ncommit 1700000100\n\nsrc/a.js\n")
+
+	changes, err := parseGitLogChanges(output)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Unix(1700000200, 0).UTC(), changes["src/a.js"])
+	assert.Equal(t, time.Unix(1700000200, 0).UTC(), changes["src/b.js"])
+
+	_, err = parseGitLogChanges([]byte("commit yesterday\n"))
+	assert.Error(t, err)
+}
+
+func TestFormatWindow(t *testing.T) {
+	assert.Equal(t, "90d", FormatWindow(90*24*time.Hour))
+	assert.Equal(t, "36h0m0s", FormatWindow(36*time.Hour))
+}
+
+func TestGenerateQualityReport_ActiveFilesLimitDebt(t *testing.T) {
+	files := map[string]string{
+		"src/active.js": `
+function process(a, b, c, d, e, f, g) {
+  if (a) { if (b) { if (c) { if (d) { return e; } } } }
+  return f + g;
+}
+`,
+		"src/legacy.js": `
+function legacy(a, b, c, d, e, f, g) {
+  if (a) { if (b) { if (c) { if (d) { return e; } } } }
+  return f + g;
+}
+`,
+	}
+
+	full, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+	assert.Nil(t, full.ChangeWindow)
+	require.Contains(t, full.DetailedMetrics.TechnicalDebt.FileDebtScores, "src/legacy.js")
+
+	windowed, err := NewQualityReporter(QualityReportConfig{
+		Since:       90 * 24 * time.Hour,
+		ActiveFiles: map[string]bool{"src/active.js": true},
+	}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	require.NotNil(t, windowed.ChangeWindow)
+	assert.Equal(t, "90d", windowed.ChangeWindow.Since)
+	assert.Equal(t, 1, windowed.ChangeWindow.ActiveFiles)
+	assert.Equal(t, []string{"src/legacy.js"}, windowed.ChangeWindow.StableFiles)
+
+	debt := windowed.DetailedMetrics.TechnicalDebt
+	assert.NotContains(t, debt.FileDebtScores, "src/legacy.js")
+	for _, item := range debt.RemediationPlan {
+		assert.NotContains(t, item.AffectedFiles, "src/legacy.js")
+	}
+	assert.Contains(t, windowed.DetailedMetrics.Complexity.FileMetrics, "src/legacy.js", "other analyzers still see every file")
+}
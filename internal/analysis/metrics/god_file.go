@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// GodFileThresholds defines when a file holds too many responsibilities. A file is a god
+// file when it has more functions or more classes than allowed and is also longer than
+// Lines, so a long data table or a short file of one-liners is never flagged.
+type GodFileThresholds struct {
+	Functions int `yaml:"functions" json:"functions"` // functions and methods
+	Classes   int `yaml:"classes" json:"classes"`
+	Lines     int `yaml:"lines" json:"lines"`
+}
+
+// DefaultGodFileThresholds returns the thresholds used when none are configured
+func DefaultGodFileThresholds() GodFileThresholds {
+	return GodFileThresholds{Functions: 30, Classes: 5, Lines: 500}
+}
+
+// withDefaults fills unset thresholds from DefaultGodFileThresholds
+func (t GodFileThresholds) withDefaults() GodFileThresholds {
+	defaults := DefaultGodFileThresholds()
+	if t.Functions <= 0 {
+		t.Functions = defaults.Functions
+	}
+	if t.Classes <= 0 {
+		t.Classes = defaults.Classes
+	}
+	if t.Lines <= 0 {
+		t.Lines = defaults.Lines
+	}
+	return t
+}
+
+// GodFile is a file over the god file thresholds, with the counts that put it there
+type GodFile struct {
+	FilePath  string `json:"file_path"`
+	Functions int    `json:"functions"`
+	Classes   int    `json:"classes"`
+	Lines     int    `json:"lines"`
+}
+
+// godFile measures parseResult against the configured thresholds
+func (ds *DebtScorer) godFile(parseResult *ast.ParseResult) (GodFile, bool) {
+	thresholds := ds.config.GodFile.withDefaults()
+	file := GodFile{
+		FilePath:  parseResult.FilePath,
+		Functions: len(parseResult.Functions),
+		Classes:   len(parseResult.Classes),
+		Lines:     ds.fileLineCount(parseResult),
+	}
+	tooMany := file.Functions > thresholds.Functions || file.Classes > thresholds.Classes
+	return file, tooMany && file.Lines > thresholds.Lines
+}
+
+// godFileItem reports a god file as god_object debt; the counts go into the metadata so
+// the dashboard can list the file without parsing it again
+func godFileItem(id int, file GodFile) TechnicalDebtItem {
+	return TechnicalDebtItem{
+		ID:        fmt.Sprintf("arch_violation_%d", id),
+		Type:      "god_object",
+		Category:  "Architecture Violations",
+		FilePath:  file.FilePath,
+		StartLine: 1,
+		EndLine:   file.Lines,
+		Description: fmt.Sprintf("File '%s' is a god file with %d functions and %d classes in %d lines",
+			file.FilePath, file.Functions, file.Classes, file.Lines),
+		Severity:       "high",
+		EstimatedHours: 6.0,
+		RemediationSteps: []string{
+			"Identify distinct responsibilities",
+			"Extract functionality into focused services",
+			"Apply single responsibility principle",
+			"Create proper abstraction layers",
+			"Implement dependency injection",
+		},
+		Metadata: map[string]interface{}{
+			"function_count": file.Functions,
+			"class_count":    file.Classes,
+			"line_count":     file.Lines,
+		},
+	}
+}
+
+// godFilesFromItems lists the god files among items, longest first
+func godFilesFromItems(items []TechnicalDebtItem) []GodFile {
+	var files []GodFile
+	for _, item := range items {
+		if item.Type != "god_object" {
+			continue
+		}
+		functions, _ := item.Metadata["function_count"].(int)
+		classes, _ := item.Metadata["class_count"].(int)
+		lines, _ := item.Metadata["line_count"].(int)
+		files = append(files, GodFile{FilePath: item.FilePath, Functions: functions, Classes: classes, Lines: lines})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].Lines != files[j].Lines {
+			return files[i].Lines > files[j].Lines
+		}
+		return files[i].FilePath < files[j].FilePath
+	})
+	return files
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// fileWith builds a parse result with the given number of functions and classes
+func fileWith(path string, functions, classes, lines int) *ast.ParseResult {
+	result := &ast.ParseResult{FilePath: path, LineCount: lines}
+	for i := 0; i < functions; i++ {
+		result.Functions = append(result.Functions, ast.FunctionInfo{Name: "f", StartLine: i + 1, EndLine: i + 1})
+	}
+	for i := 0; i < classes; i++ {
+		result.Classes = append(result.Classes, ast.ClassInfo{Name: "C"})
+	}
+	return result
+}
+
+func TestGodFile(t *testing.T) {
+	scorer := NewDebtScorer()
+
+	file, ok := scorer.godFile(fileWith("src/app.js", 31, 0, 501))
+	assert.True(t, ok)
+	assert.Equal(t, GodFile{FilePath: "src/app.js", Functions: 31, Lines: 501}, file)
+
+	_, ok = scorer.godFile(fileWith("src/models.js", 2, 6, 600))
+	assert.True(t, ok, "too many classes")
+
+	_, ok = scorer.godFile(fileWith("src/helpers.js", 80, 0, 200))
+	assert.False(t, ok, "many short functions in a short file")
+
+	_, ok = scorer.godFile(fileWith("src/table.js", 3, 0, 5000))
+	assert.False(t, ok, "long but with few responsibilities")
+
+	scorer.config.GodFile = GodFileThresholds{Functions: 5}
+	_, ok = scorer.godFile(fileWith("src/helpers.js", 6, 0, 501))
+	assert.True(t, ok, "unset thresholds keep their defaults")
+}
+
+func TestAnalyzeDebt_GodFilesOnDashboard(t *testing.T) {
+	parseResults := []*ast.ParseResult{
+		fileWith("src/small.js", 1, 0, 10),
+		fileWith("src/app.js", 40, 0, 800),
+		fileWith("src/legacy.js", 35, 2, 1200),
+	}
+
+	metrics, err := NewDebtScorer().AnalyzeDebt(context.Background(), parseResults, &ComplexityMetrics{}, &DuplicationMetrics{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []GodFile{
+		{FilePath: "src/legacy.js", Functions: 35, Classes: 2, Lines: 1200},
+		{FilePath: "src/app.js", Functions: 40, Lines: 800},
+	}, metrics.Dashboard.GodFiles)
+
+	var godItems []TechnicalDebtItem
+	for _, item := range metrics.Categories["Architecture Violations"].Items {
+		if item.Type == "god_object" {
+			godItems = append(godItems, item)
+		}
+	}
+	require.Len(t, godItems, 2)
+	for _, item := range godItems {
+		assert.Contains(t, []string{"high", "critical"}, item.Priority, "god files are never a low priority")
+		if item.FilePath == "src/legacy.js" {
+			assert.Contains(t, item.Description, "35 functions and 2 classes in 1200 lines")
+			assert.Equal(t, 1200, item.Metadata["line_count"])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GradeScale is the grading vocabulary every grade in the report is written in
+type GradeScale string
+
+const (
+	// GradeDescriptive grades Excellent, Good, Fair or Poor at the configured Thresholds
+	GradeDescriptive GradeScale = "descriptive"
+	// GradeLetter grades A (90 and up), B (80), C (70), D (60) or F
+	GradeLetter GradeScale = "letter"
+	// GradeNumeric grades with the score itself, rounded to a whole number out of 100
+	GradeNumeric GradeScale = "numeric"
+)
+
+// GradeScales are the accepted grade scales
+var GradeScales = []GradeScale{GradeDescriptive, GradeLetter, GradeNumeric}
+
+// letterGrades are the lowest score of each letter grade, best first
+var letterGrades = []struct {
+	letter string
+	min    float64
+}{{"A", 90}, {"B", 80}, {"C", 70}, {"D", 60}, {"F", 0}}
+
+// ParseGradeScale parses a grade scale such as "letter"
+func ParseGradeScale(value string) (GradeScale, error) {
+	scale := GradeScale(strings.ToLower(strings.TrimSpace(value)))
+	for _, s := range GradeScales {
+		if s == scale {
+			return scale, nil
+		}
+	}
+	return "", fmt.Errorf("unknown grade scale %q: expected descriptive, letter or numeric", value)
+}
+
+// Grade maps a 0-100 score to a grade on scale; thresholds place the descriptive grades and
+// an empty scale is descriptive
+func Grade(score float64, scale GradeScale, thresholds QualityThresholds) string {
+	switch scale {
+	case GradeLetter:
+		for _, grade := range letterGrades {
+			if score >= grade.min {
+				return grade.letter
+			}
+		}
+		return "F"
+	case GradeNumeric:
+		return fmt.Sprintf("%.0f", math.Round(score))
+	}
+
+	switch gradeTier(score, thresholds) {
+	case 0:
+		return "Excellent"
+	case 1:
+		return "Good"
+	case 2:
+		return "Fair"
+	default:
+		return "Poor"
+	}
+}
+
+// gradeTier places score in the descriptive tiers whatever the scale: 0 for excellent,
+// 1 good, 2 fair and 3 poor
+func gradeTier(score float64, thresholds QualityThresholds) int {
+	switch {
+	case score >= thresholds.Excellent:
+		return 0
+	case score >= thresholds.Good:
+		return 1
+	case score >= thresholds.Fair:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// grade maps score to a grade on the configured scale; every grade the report carries goes
+// through it so the report speaks one grading language
+func (qr *QualityReporter) grade(score float64) string {
+	return Grade(score, qr.config.GradeScale, qr.config.Thresholds)
+}
+
+// gradeMaintainability regrades the maintainability classifications, which the calculator
+// writes as Good, Fair or Poor at its own index thresholds, on a letter or numeric scale
+func (qr *QualityReporter) gradeMaintainability(metrics *MaintainabilityMetrics) {
+	if metrics == nil || qr.config.GradeScale == "" || qr.config.GradeScale == GradeDescriptive {
+		return
+	}
+	metrics.Classification = qr.grade(metrics.OverallIndex)
+	for i := range metrics.FunctionMetrics {
+		metrics.FunctionMetrics[i].Classification = qr.grade(metrics.FunctionMetrics[i].MaintainabilityIndex)
+	}
+	for path, file := range metrics.FileMetrics {
+		file.Classification = qr.grade(file.OverallIndex)
+		metrics.FileMetrics[path] = file
+	}
+	if metrics.TrendAnalysis != nil {
+		for i := range metrics.TrendAnalysis.PeriodAnalysis {
+			period := &metrics.TrendAnalysis.PeriodAnalysis[i]
+			period.Classification = qr.grade(period.AverageIndex)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrade(t *testing.T) {
+	thresholds := QualityThresholds{Excellent: 90, Good: 75, Fair: 60}
+	tests := []struct {
+		score                        float64
+		descriptive, letter, numeric string
+	}{
+		{95, "Excellent", "A", "95"},
+		{82.4, "Good", "B", "82"},
+		{74.6, "Fair", "C", "75"},
+		{61, "Fair", "D", "61"},
+		{12, "Poor", "F", "12"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.descriptive, Grade(tt.score, GradeDescriptive, thresholds))
+		assert.Equal(t, tt.descriptive, Grade(tt.score, "", thresholds), "an empty scale is descriptive")
+		assert.Equal(t, tt.letter, Grade(tt.score, GradeLetter, thresholds))
+		assert.Equal(t, tt.numeric, Grade(tt.score, GradeNumeric, thresholds))
+	}
+}
+
+func TestParseGradeScale(t *testing.T) {
+	scale, err := ParseGradeScale(" Letter ")
+	require.NoError(t, err)
+	assert.Equal(t, GradeLetter, scale)
+
+	_, err = ParseGradeScale("stars")
+	assert.EqualError(t, err, `unknown grade scale "stars": expected descriptive, letter or numeric`)
+}
+
+func TestGenerateQualityReport_GradeScale(t *testing.T) {
+	files := map[string]string{"src/math.js": "export function add(a, b) {\n  return a + b;\n}\n"}
+
+	for _, scale := range GradeScales {
+		report, err := NewQualityReporter(QualityReportConfig{GradeScale: scale, IncludeExecutiveSummary: true}).GenerateQualityReport(context.Background(), files)
+		require.NoError(t, err)
+
+		thresholds := QualityThresholds{Excellent: 90, Good: 75, Fair: 60}
+		assert.Equal(t, Grade(report.OverallScore, scale, thresholds), report.QualityGrade, scale)
+		require.NotNil(t, report.DetailedMetrics.Performance)
+		assert.Equal(t, Grade(report.DetailedMetrics.Performance.OverallScore, scale, thresholds), report.DetailedMetrics.Performance.PerformanceGrade, scale)
+		assert.Contains(t, report.ExecutiveSummary.OverallAssessment, "(grade "+report.QualityGrade+")")
+		if scale != GradeDescriptive {
+			maintainability := report.DetailedMetrics.Maintainability
+			require.NotNil(t, maintainability)
+			assert.Equal(t, Grade(maintainability.OverallIndex, scale, thresholds), maintainability.Classification, scale)
+		}
+	}
+}
+
+func TestGradeMaintainability(t *testing.T) {
+	metrics := &MaintainabilityMetrics{
+		OverallIndex:    82,
+		Classification:  "Fair",
+		FunctionMetrics: []FunctionMaintainability{{MaintainabilityIndex: 95, Classification: "Good"}},
+		FileMetrics:     map[string]FileMaintainability{"src/app.js": {OverallIndex: 55, Classification: "Poor"}},
+		TrendAnalysis:   &MaintainabilityTrend{PeriodAnalysis: []MaintainabilityPeriod{{AverageIndex: 71, Classification: "Fair"}}},
+	}
+
+	NewQualityReporter(QualityReportConfig{GradeScale: GradeLetter}).gradeMaintainability(metrics)
+	assert.Equal(t, "B", metrics.Classification)
+	assert.Equal(t, "A", metrics.FunctionMetrics[0].Classification)
+	assert.Equal(t, "F", metrics.FileMetrics["src/app.js"].Classification)
+	assert.Equal(t, "C", metrics.TrendAnalysis.PeriodAnalysis[0].Classification)
+
+	descriptive := &MaintainabilityMetrics{OverallIndex: 82, Classification: "Fair"}
+	NewQualityReporter(QualityReportConfig{}).gradeMaintainability(descriptive)
+	assert.Equal(t, "Fair", descriptive.Classification, "descriptive grades keep the calculator's thresholds")
+}
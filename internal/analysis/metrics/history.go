@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// LoadHistoricalDataPoint reads a previously written JSON quality report and returns
+// its timestamp, component scores and per-file maintainability as a history snapshot
+func LoadHistoricalDataPoint(path string) (HistoricalDataPoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return HistoricalDataPoint{}, fmt.Errorf("failed to read previous report: %w", err)
+	}
+
+	var report QualityReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return HistoricalDataPoint{}, fmt.Errorf("failed to parse previous report %s: %w", path, err)
+	}
+	if report.GeneratedAt.IsZero() {
+		return HistoricalDataPoint{}, fmt.Errorf("previous report %s has no generated_at timestamp", path)
+	}
+	if report.QualityGate == QualityGateNotApplicable {
+		return HistoricalDataPoint{}, fmt.Errorf("previous report %s has no scores", path)
+	}
+
+	point := HistoricalDataPoint{
+		Timestamp: report.GeneratedAt,
+		Scores:    report.ComponentScores,
+		Events:    []QualityEvent{},
+	}
+	if maintainability := report.DetailedMetrics.Maintainability; maintainability != nil && len(maintainability.FileMetrics) > 0 {
+		point.FileMaintainability = make(map[string]float64, len(maintainability.FileMetrics))
+		for filePath, file := range maintainability.FileMetrics {
+			point.FileMaintainability[filePath] = file.OverallIndex
+		}
+	}
+	return point, nil
+}
+
+// latestSnapshot returns the most recent snapshot taken before now, if any
+func latestSnapshot(history []HistoricalDataPoint, now time.Time) (HistoricalDataPoint, bool) {
+	var latest HistoricalDataPoint
+	found := false
+	for _, point := range history {
+		if point.Timestamp.After(now) {
+			continue
+		}
+		if !found || point.Timestamp.After(latest.Timestamp) {
+			latest = point
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// generateChangeNarrative summarizes component score movement since the latest snapshot,
+// e.g. "Technical debt improved 8 points; coverage regressed 5 points since last week."
+// It returns an empty string when there is no history.
+func (qr *QualityReporter) generateChangeNarrative(scores ComponentScores) string {
+	now := qr.now()
+	previous, ok := latestSnapshot(qr.config.History, now)
+	if !ok {
+		return ""
+	}
+
+	var changes []string
+	for _, component := range benchmarkComponents {
+		if !scores.isAvailable(component.key) || !previous.Scores.isAvailable(component.key) {
+			continue
+		}
+		delta := math.Round(component.score(scores) - component.score(previous.Scores))
+		if delta == 0 {
+			continue
+		}
+
+		movement := "improved"
+		if delta < 0 {
+			movement = "regressed"
+		}
+		changes = append(changes, fmt.Sprintf("%s %s %s", strings.ReplaceAll(component.key, "_", " "), movement, pluralPoints(math.Abs(delta))))
+	}
+
+	since := sincePhrase(now.Sub(previous.Timestamp))
+	if len(changes) == 0 {
+		return fmt.Sprintf("All component scores held steady %s.", since)
+	}
+
+	narrative := strings.Join(changes, "; ")
+	return strings.ToUpper(narrative[:1]) + narrative[1:] + " " + since + "."
+}
+
+// pluralPoints renders a whole number of score points
+func pluralPoints(points float64) string {
+	if points == 1 {
+		return "1 point"
+	}
+	return fmt.Sprintf("%.0f points", points)
+}
+
+// sincePhrase describes how long ago the previous snapshot was taken
+func sincePhrase(elapsed time.Duration) string {
+	days := int(elapsed.Hours() / 24)
+	switch {
+	case days < 1:
+		return "since the last run"
+	case days == 1:
+		return "since yesterday"
+	case days < 7:
+		return fmt.Sprintf("over the last %d days", days)
+	case days < 14:
+		return "since last week"
+	case days < 60:
+		return fmt.Sprintf("over the last %d weeks", days/7)
+	default:
+		return fmt.Sprintf("over the last %d months", days/30)
+	}
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateChangeNarrative(t *testing.T) {
+	now := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	current := ComponentScores{Complexity: 80, Duplication: 90, TechnicalDebt: 68, Coverage: 55, Performance: 70, Maintainability: 75}
+
+	reporter := NewQualityReporter(QualityReportConfig{
+		History: []HistoricalDataPoint{
+			{Timestamp: now.AddDate(0, 0, -14), Scores: ComponentScores{TechnicalDebt: 40}},
+			{Timestamp: now.AddDate(0, 0, -7), Scores: ComponentScores{Complexity: 80, Duplication: 90, TechnicalDebt: 60, Coverage: 60, Performance: 69.8, Maintainability: 74}},
+		},
+	})
+	reporter.now = func() time.Time { return now }
+
+	assert.Equal(t,
+		"Technical debt improved 8 points; coverage regressed 5 points; maintainability improved 1 point since last week.",
+		reporter.generateChangeNarrative(current))
+}
+
+func TestGenerateChangeNarrative_NoHistory(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{})
+	assert.Empty(t, reporter.generateChangeNarrative(ComponentScores{Complexity: 80}))
+
+	summary := reporter.generateExecutiveSummary(80, "B", ComponentScores{Complexity: 80}, nil)
+	assert.Empty(t, summary.ChangeNarrative)
+}
+
+func TestGenerateChangeNarrative_Steady(t *testing.T) {
+	now := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	scores := ComponentScores{Complexity: 80, Coverage: 60}
+
+	reporter := NewQualityReporter(QualityReportConfig{
+		History: []HistoricalDataPoint{{Timestamp: now.Add(-26 * time.Hour), Scores: scores}},
+	})
+	reporter.now = func() time.Time { return now }
+
+	assert.Equal(t, "All component scores held steady since yesterday.", reporter.generateChangeNarrative(scores))
+}
+
+func TestSincePhrase(t *testing.T) {
+	day := 24 * time.Hour
+	assert.Equal(t, "since the last run", sincePhrase(3*time.Hour))
+	assert.Equal(t, "since yesterday", sincePhrase(day))
+	assert.Equal(t, "over the last 3 days", sincePhrase(3*day))
+	assert.Equal(t, "since last week", sincePhrase(8*day))
+	assert.Equal(t, "over the last 3 weeks", sincePhrase(21*day))
+	assert.Equal(t, "over the last 3 months", sincePhrase(95*day))
+}
+
+func TestLoadHistoricalDataPoint(t *testing.T) {
+	dir := t.TempDir()
+	generatedAt := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+
+	data, err := json.Marshal(&QualityReport{
+		GeneratedAt:     generatedAt,
+		ComponentScores: ComponentScores{Complexity: 72, Coverage: 61},
+	})
+	require.NoError(t, err)
+	path := filepath.Join(dir, "previous.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	point, err := LoadHistoricalDataPoint(path)
+	require.NoError(t, err)
+	assert.True(t, generatedAt.Equal(point.Timestamp))
+	assert.Equal(t, 72.0, point.Scores.Complexity)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"component_scores":{}}`), 0o644))
+	_, err = LoadHistoricalDataPoint(path)
+	assert.Error(t, err)
+
+	_, err = LoadHistoricalDataPoint(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
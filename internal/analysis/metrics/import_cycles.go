@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// maxQuickWinCycleLength is the longest import cycle, in modules, listed as a quick win;
+// longer cycles become long-term initiatives
+const maxQuickWinCycleLength = 3
+
+// importResolutionExtensions are tried, in order, when an import specifier omits the extension
+var importResolutionExtensions = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs"}
+
+// importEdge is a resolved import of one analyzed file by another
+type importEdge struct {
+	from, to string
+	symbols  []string // imported names; empty for side-effect and namespace imports
+}
+
+// importCycle is a closed chain of imports, rotated to start at its smallest path:
+// Modules[0] imports Modules[1], ..., and the last module imports Modules[0]
+type importCycle struct {
+	Modules []string
+	Edges   []importEdge // Edges[i] goes from Modules[i] to the next module
+}
+
+// importGraph maps each analyzed file to its resolved imports of other analyzed files
+type importGraph map[string][]importEdge
+
+// buildImportGraph resolves the relative imports of parseResults against the analyzed files.
+// Imports of packages and of files outside the analysis are left out.
+func buildImportGraph(parseResults []*ast.ParseResult) importGraph {
+	files := make(map[string]bool, len(parseResults))
+	for _, result := range parseResults {
+		files[result.FilePath] = true
+	}
+
+	graph := make(importGraph, len(parseResults))
+	for _, result := range parseResults {
+		seen := make(map[string]int)
+		for _, imp := range result.Imports {
+			target, ok := resolveImport(result.FilePath, imp.Source, files)
+			if !ok || target == result.FilePath {
+				continue
+			}
+			if i, dup := seen[target]; dup {
+				graph[result.FilePath][i].symbols = append(graph[result.FilePath][i].symbols, imp.Specifiers...)
+				continue
+			}
+			seen[target] = len(graph[result.FilePath])
+			graph[result.FilePath] = append(graph[result.FilePath], importEdge{
+				from:    result.FilePath,
+				to:      target,
+				symbols: append([]string{}, imp.Specifiers...),
+			})
+		}
+		sort.SliceStable(graph[result.FilePath], func(i, j int) bool {
+			return graph[result.FilePath][i].to < graph[result.FilePath][j].to
+		})
+	}
+
+	return graph
+}
+
+// resolveImport maps a relative import specifier to one of files, trying the specifier as
+// written, with each known extension, and as a directory index. A ".js" specifier may name
+// a TypeScript source, as ESM TypeScript projects write it.
+func resolveImport(fromFile, source string, files map[string]bool) (string, bool) {
+	if !ast.IsLocalImport(source) {
+		return "", false
+	}
+	source = ast.ToSlash(source)
+
+	var base string
+	if strings.HasPrefix(source, "/") {
+		base = path.Clean(strings.TrimPrefix(source, "/"))
+	} else {
+		base = path.Join(path.Dir(fromFile), source)
+	}
+
+	candidates := []string{base}
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	for _, ext := range importResolutionExtensions {
+		candidates = append(candidates, base+ext, stem+ext)
+	}
+	for _, ext := range importResolutionExtensions {
+		candidates = append(candidates, base+"/index"+ext)
+	}
+
+	for _, candidate := range candidates {
+		if files[candidate] {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// findImportCycles runs the dependency cycle detector over graph and pairs each cycle with
+// the imports closing it. Files and imports are visited in path order, so the result is
+// deterministic and every strongly connected group of files yields at least one cycle.
+func findImportCycles(graph importGraph) []importCycle {
+	detector := analysis.NewCycleDetector(nil)
+	for _, file := range sortedKeys(graph) {
+		targets := make([]string, 0, len(graph[file]))
+		for _, edge := range graph[file] {
+			targets = append(targets, edge.to)
+		}
+		detector.AddDependencies(file, targets)
+	}
+	if err := detector.AnalyzeCycles(); err != nil {
+		return nil
+	}
+
+	var cycles []importCycle
+	for _, found := range detector.GetCycles() {
+		// Files starts at the smallest path and repeats it at the end to close the cycle
+		cycle := importCycle{Modules: found.Files[:len(found.Files)-1]}
+		for i, from := range cycle.Modules {
+			to := found.Files[i+1]
+			for _, edge := range graph[from] {
+				if edge.to == to {
+					cycle.Edges = append(cycle.Edges, edge)
+					break
+				}
+			}
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// weakestEdge returns the import carrying the fewest symbols, the cheapest one to invert
+// or move behind a shared module. Namespace and side-effect imports count as one symbol.
+func (c importCycle) weakestEdge() importEdge {
+	weakest := c.Edges[0]
+	for _, edge := range c.Edges[1:] {
+		if max(len(edge.symbols), 1) < max(len(weakest.symbols), 1) {
+			weakest = edge
+		}
+	}
+	return weakest
+}
+
+// describe renders the cycle as "a.js -> b.js -> a.js"
+func (c importCycle) describe() string {
+	return strings.Join(append(append([]string{}, c.Modules...), c.Modules[0]), " -> ")
+}
+
+// cycleRemediationItems turns import cycles into dashboard entries: cycles of up to
+// maxQuickWinCycleLength modules are quick wins, longer ones long-term initiatives
+func (ds *DebtScorer) cycleRemediationItems(cycles []importCycle) ([]RemediationItem, []RemediationItem) {
+	quickWins := []RemediationItem{}
+	longTerm := []RemediationItem{}
+
+	for i, cycle := range cycles {
+		weakest := cycle.weakestEdge()
+		uses := "which has no named imports"
+		moved := "the shared code"
+		if len(weakest.symbols) > 0 {
+			moved = strings.Join(weakest.symbols, ", ")
+			uses = "which only uses " + moved
+		}
+
+		item := RemediationItem{
+			Title:         fmt.Sprintf("Break import cycle between %d modules", len(cycle.Modules)),
+			Description:   fmt.Sprintf("Import cycle: %s", cycle.describe()),
+			Category:      "Architecture Violations",
+			AffectedFiles: append([]string{}, cycle.Modules...),
+			RemediationSteps: []string{
+				fmt.Sprintf("Invert the import %s -> %s, %s", weakest.from, weakest.to, uses),
+				fmt.Sprintf("Move %s into a module every file in the cycle can import, or pass it into %s as a parameter", moved, weakest.from),
+				"Re-run the analysis to confirm the cycle is gone",
+			},
+			SuccessMetrics: []string{"No import cycle among " + strings.Join(cycle.Modules, ", ")},
+		}
+
+		quickWin := len(cycle.Modules) <= maxQuickWinCycleLength
+		if quickWin {
+			item.ID = fmt.Sprintf("quickwin_import_cycle_%d", i+1)
+			item.Priority = "high"
+			item.EstimatedEffort = float64(len(cycle.Modules))
+			item.ImpactScore = 7.0
+		} else {
+			item.ID = fmt.Sprintf("longterm_import_cycle_%d", i+1)
+			item.Priority = "medium"
+			item.EstimatedEffort = 4.0 * float64(len(cycle.Modules))
+			item.ImpactScore = 8.0
+		}
+		item.ExpectedROI = item.ImpactScore / item.EstimatedEffort
+
+		if quickWin {
+			quickWins = append(quickWins, item)
+		} else {
+			longTerm = append(longTerm, item)
+		}
+	}
+
+	return quickWins, longTerm
+}
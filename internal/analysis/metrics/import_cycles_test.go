@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func importingFile(filePath string, imports ...ast.ImportInfo) *ast.ParseResult {
+	return &ast.ParseResult{FilePath: filePath, Imports: imports}
+}
+
+func importFrom(source string, specifiers ...string) ast.ImportInfo {
+	return ast.ImportInfo{Source: source, Specifiers: specifiers}
+}
+
+func cycleTestResults() []*ast.ParseResult {
+	return []*ast.ParseResult{
+		// Short cycle: orders <-> format
+		importingFile("src/orders.ts", importFrom("./format", "formatDate", "formatPrice"), importFrom("react", "useState")),
+		importingFile("src/format.ts", importFrom("./orders.js", "OrderStatus")),
+		// Long cycle: a -> b -> c -> d -> a
+		importingFile("src/a/index.ts", importFrom("../b/index", "b1", "b2")),
+		importingFile("src/b/index.ts", importFrom("../c", "c1")),
+		importingFile("src/c/index.ts", importFrom("/src/d/index.ts", "d1", "d2")),
+		importingFile("src/d/index.ts", importFrom("../a", "a1", "a2")),
+		importingFile("src/standalone.ts", importFrom("./format", "formatDate")),
+	}
+}
+
+func TestResolveImport(t *testing.T) {
+	files := map[string]bool{"src/format.ts": true, "src/c/index.ts": true, "lib/util.js": true}
+
+	tests := []struct {
+		from, source, expected string
+	}{
+		{"src/orders.ts", "./format", "src/format.ts"},
+		{"src/orders.ts", "./format.js", "src/format.ts"},
+		{"src/b/index.ts", "../c", "src/c/index.ts"},
+		{"src/orders.ts", "../lib/util.js", "lib/util.js"},
+		{"src/orders.ts", "/lib/util", "lib/util.js"},
+		{"src/orders.ts", `..\lib\util`, "lib/util.js"},
+		{"src/orders.ts", "lodash", ""},
+		{"src/orders.ts", "./missing", ""},
+	}
+
+	for _, tt := range tests {
+		resolved, _ := resolveImport(tt.from, tt.source, files)
+		assert.Equal(t, tt.expected, resolved, "%s from %s", tt.source, tt.from)
+	}
+}
+
+func TestFindImportCycles(t *testing.T) {
+	cycles := findImportCycles(buildImportGraph(cycleTestResults()))
+	require.Len(t, cycles, 2)
+
+	assert.Equal(t, []string{"src/a/index.ts", "src/b/index.ts", "src/c/index.ts", "src/d/index.ts"}, cycles[0].Modules)
+	assert.Equal(t, "src/b/index.ts", cycles[0].weakestEdge().from, "b imports a single symbol from c")
+
+	assert.Equal(t, []string{"src/format.ts", "src/orders.ts"}, cycles[1].Modules)
+	assert.Equal(t, "src/format.ts -> src/orders.ts -> src/format.ts", cycles[1].describe())
+	weakest := cycles[1].weakestEdge()
+	assert.Equal(t, "src/format.ts", weakest.from)
+	assert.Equal(t, []string{"OrderStatus"}, weakest.symbols)
+}
+
+func TestFindImportCycles_Acyclic(t *testing.T) {
+	results := []*ast.ParseResult{
+		importingFile("src/app.ts", importFrom("./util", "helper")),
+		importingFile("src/util.ts"),
+	}
+	assert.Empty(t, findImportCycles(buildImportGraph(results)))
+}
+
+func TestAnalyzeDebt_ImportCyclesOnDashboard(t *testing.T) {
+	metrics, err := NewDebtScorer().AnalyzeDebt(context.Background(), cycleTestResults(), createMockComplexityMetrics(), createMockDuplicationMetrics())
+	require.NoError(t, err)
+
+	var quickWin, longTerm *RemediationItem
+	for i, item := range metrics.Dashboard.QuickWins {
+		if item.ID == "quickwin_import_cycle_2" {
+			quickWin = &metrics.Dashboard.QuickWins[i]
+		}
+	}
+	for i, item := range metrics.Dashboard.LongTermInitiatives {
+		if item.ID == "longterm_import_cycle_1" {
+			longTerm = &metrics.Dashboard.LongTermInitiatives[i]
+		}
+	}
+
+	require.NotNil(t, quickWin, "two-module cycles are quick wins")
+	assert.Equal(t, "Import cycle: src/format.ts -> src/orders.ts -> src/format.ts", quickWin.Description)
+	assert.Equal(t, []string{"src/format.ts", "src/orders.ts"}, quickWin.AffectedFiles)
+	assert.Equal(t, "Invert the import src/format.ts -> src/orders.ts, which only uses OrderStatus", quickWin.RemediationSteps[0])
+
+	require.NotNil(t, longTerm, "four-module cycles are long-term initiatives")
+	assert.Len(t, longTerm.AffectedFiles, 4)
+}
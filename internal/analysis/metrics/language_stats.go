@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"sort"
+	"strings"
+)
+
+// LanguageConfig groups configuration and data files (JSON, YAML, TOML, INI) in language statistics
+const LanguageConfig = "Config"
+
+// languageExtensions maps file extensions to the language they are counted under
+var languageExtensions = map[string]string{
+	".js":   "JavaScript",
+	".mjs":  "JavaScript",
+	".cjs":  "JavaScript",
+	".jsx":  "JSX",
+	".ts":   "TypeScript",
+	".mts":  "TypeScript",
+	".cts":  "TypeScript",
+	".tsx":  "TSX",
+	".py":   "Python",
+	".go":   "Go",
+	".json": LanguageConfig,
+	".yaml": LanguageConfig,
+	".yml":  LanguageConfig,
+	".toml": LanguageConfig,
+	".ini":  LanguageConfig, // pytest.ini, tox.ini; also how test framework detection sees them
+	".cfg":  LanguageConfig,
+}
+
+// LanguageStat counts the files and lines of one language
+type LanguageStat struct {
+	Files   int     `json:"files"`
+	Lines   int     `json:"lines"`
+	Percent float64 `json:"percent"` // share of all counted lines
+}
+
+// LanguageForPath returns the language a file is counted under, judged by its extension
+// alone so files that fail to parse are still counted; "" for unrecognized files
+func LanguageForPath(filePath string) string {
+	return languageExtensions[strings.ToLower(path.Ext(filePath))]
+}
+
+// BuildLanguageStats counts files and lines per language over the analyzed sources in
+// fileContents and the line counts of other recognized files in otherLines, which may be nil
+func BuildLanguageStats(fileContents map[string]string, otherLines map[string]int) map[string]LanguageStat {
+	stats := make(map[string]LanguageStat)
+	total := 0
+	add := func(filePath string, lines int) {
+		language := LanguageForPath(filePath)
+		if language == "" {
+			return
+		}
+		stat := stats[language]
+		stat.Files++
+		stat.Lines += lines
+		stats[language] = stat
+		total += lines
+	}
+
+	for _, file := range sortedKeys(fileContents) {
+		add(file, countSourceLines(fileContents[file]))
+	}
+	for _, file := range sortedKeys(otherLines) {
+		if _, ok := fileContents[file]; !ok {
+			add(file, otherLines[file])
+		}
+	}
+
+	if total > 0 {
+		for language, stat := range stats {
+			stat.Percent = math.Round(float64(stat.Lines)/float64(total)*1000) / 10
+			stats[language] = stat
+		}
+	}
+	return stats
+}
+
+// sortedLanguages orders languages by line count, largest first, then by name
+func sortedLanguages(stats map[string]LanguageStat) []string {
+	languages := sortedKeys(stats)
+	sort.SliceStable(languages, func(i, j int) bool {
+		return stats[languages[i]].Lines > stats[languages[j]].Lines
+	})
+	return languages
+}
+
+// WriteLanguageTable writes stats as a plain text proportion table, largest language first
+func WriteLanguageTable(w io.Writer, stats map[string]LanguageStat) error {
+	const barWidth = 20
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-12s %7s %9s %7s\n", "Language", "Files", "Lines", "Share")
+	for _, language := range sortedLanguages(stats) {
+		stat := stats[language]
+		bar := strings.Repeat("#", int(math.Round(stat.Percent*barWidth/100)))
+		fmt.Fprintf(&b, "%-12s %7d %9d %6.1f%% %s\n", language, stat.Files, stat.Lines, stat.Percent, bar)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write language table: %w", err)
+	}
+	return nil
+}
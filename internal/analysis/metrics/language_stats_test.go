@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageForPath(t *testing.T) {
+	assert.Equal(t, "JavaScript", LanguageForPath("src/index.mjs"))
+	assert.Equal(t, "JSX", LanguageForPath("src/App.jsx"))
+	assert.Equal(t, "TypeScript", LanguageForPath("src/app.ts"))
+	assert.Equal(t, "TSX", LanguageForPath("src/App.TSX"))
+	assert.Equal(t, "Python", LanguageForPath("tools/gen.py"))
+	assert.Equal(t, LanguageConfig, LanguageForPath("tsconfig.json"))
+	assert.Equal(t, "", LanguageForPath("README.md"))
+}
+
+func TestBuildLanguageStats(t *testing.T) {
+	files := map[string]string{
+		"src/a.ts":      strings.Repeat("x\n", 8),
+		"src/b.js":      "x\n",
+		"src/broken.js": "function (", // counted by extension even though it cannot parse
+	}
+	other := map[string]int{"package.json": 10, "README.md": 50}
+
+	stats := BuildLanguageStats(files, other)
+
+	assert.Equal(t, map[string]LanguageStat{
+		"TypeScript":   {Files: 1, Lines: 8, Percent: 40},
+		"JavaScript":   {Files: 2, Lines: 2, Percent: 10},
+		LanguageConfig: {Files: 1, Lines: 10, Percent: 50},
+	}, stats)
+
+	var table bytes.Buffer
+	require.NoError(t, WriteLanguageTable(&table, stats))
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[1], LanguageConfig), "largest language first")
+	assert.Contains(t, lines[1], "50.0% ##########")
+	assert.True(t, strings.HasPrefix(lines[3], "JavaScript"))
+}
+
+func TestGenerateQualityReport_LanguageStats(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{OtherFileLines: map[string]int{"package.json": 2}})
+	report, err := reporter.GenerateQualityReport(context.Background(), map[string]string{
+		"src/a.js":  "function a() {\n  return 1;\n}\n",
+		"src/b.tsx": "export const B = () => <div />;\n",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, LanguageStat{Files: 1, Lines: 3, Percent: 50}, report.LanguageStats["JavaScript"])
+	assert.Equal(t, LanguageStat{Files: 1, Lines: 1, Percent: 16.7}, report.LanguageStats["TSX"])
+	assert.Equal(t, 33.3, report.Summary.Languages[LanguageConfig])
+}
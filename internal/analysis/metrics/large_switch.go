@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// defaultSwitchCaseThreshold is used when DebtScoringConfig.SwitchCaseThreshold is unset
+const defaultSwitchCaseThreshold = 8
+
+// maxLookupTableReports bounds the lookup-table recommendations, largest chains first
+const maxLookupTableReports = 10
+
+// chainDescription names a branch chain for descriptions, e.g. "switch on 'action.type'"
+func chainDescription(chain ast.BranchChainInfo) string {
+	if chain.Kind == "switch" {
+		return fmt.Sprintf("switch on '%s'", chain.Subject)
+	}
+	return fmt.Sprintf("if/else-if chain on '%s'", chain.Subject)
+}
+
+// analyzeLargeSwitches reports switch statements and if/else-if chains with more cases than
+// the threshold. An if/else-if chain is only reported when every condition compares the same
+// expression, since only then does it dispatch on a value a lookup table could be keyed by.
+func (ds *DebtScorer) analyzeLargeSwitches(parseResults []*ast.ParseResult) []TechnicalDebtItem {
+	threshold := ds.config.SwitchCaseThreshold
+	if threshold <= 0 {
+		threshold = defaultSwitchCaseThreshold
+	}
+
+	items := []TechnicalDebtItem{}
+	itemID := 0
+
+	for _, parseResult := range parseResults {
+		for _, function := range parseResult.Functions {
+			for _, chain := range function.BranchChains {
+				if chain.Cases <= threshold || chain.Subject == "" {
+					continue
+				}
+
+				name := function.Name
+				if name == "" {
+					name = "anonymous function"
+				}
+
+				severity := "medium"
+				if chain.Cases > 2*threshold {
+					severity = "high"
+				}
+
+				items = append(items, TechnicalDebtItem{
+					ID:             fmt.Sprintf("large_switch_%d", itemID),
+					Type:           "large_switch",
+					Category:       "Code Smells",
+					FilePath:       parseResult.FilePath,
+					FunctionName:   function.Name,
+					StartLine:      chain.StartLine,
+					EndLine:        chain.EndLine,
+					Description:    fmt.Sprintf("%s in '%s' has %d cases (threshold: %d)", chainDescription(chain), name, chain.Cases, threshold),
+					Severity:       severity,
+					EstimatedHours: 1.0 + float64(chain.Cases)*0.25, // 1 hour plus 15 minutes per case moved into the table
+					RemediationSteps: []string{
+						fmt.Sprintf("Move the value each case produces into an object or Map keyed by %s", chain.Subject),
+						"Map keys to handler functions (a strategy table) where cases run logic rather than return a value",
+						"Handle keys missing from the table where the default case did",
+						"Cover the table with one table-driven test instead of a test per branch",
+					},
+					Metadata: map[string]interface{}{
+						"kind":          chain.Kind,
+						"subject":       chain.Subject,
+						"cases":         chain.Cases,
+						"threshold":     threshold,
+						"function_name": name,
+					},
+				})
+				itemID++
+			}
+		}
+	}
+
+	return items
+}
+
+// generateLookupTableRecommendations recommends replacing each large switch or if/else-if
+// chain the debt analysis found with a lookup table, largest first
+func (qr *QualityReporter) generateLookupTableRecommendations(debt *TechnicalDebtMetrics) []QualityRecommendation {
+	if debt == nil {
+		return nil
+	}
+
+	var chains []TechnicalDebtItem
+	for _, name := range sortedKeys(debt.Categories) {
+		for _, item := range debt.Categories[name].Items {
+			if item.Type == "large_switch" {
+				chains = append(chains, item)
+			}
+		}
+	}
+	sort.SliceStable(chains, func(i, j int) bool {
+		return chains[i].Metadata["cases"].(int) > chains[j].Metadata["cases"].(int)
+	})
+
+	var recommendations []QualityRecommendation
+	for i, item := range chains {
+		if i >= maxLookupTableReports {
+			break
+		}
+
+		kind := "switch"
+		if item.Metadata["kind"] != "switch" {
+			kind = "if/else-if chain"
+		}
+		cases := item.Metadata["cases"].(int)
+		text := qr.recommendationText(RecommendationLookupTable, "", RecommendationContext{
+			Finding:     item,
+			File:        item.FilePath,
+			EffortHours: item.EstimatedHours,
+			Values: map[string]interface{}{
+				"Kind":     kind,
+				"Subject":  item.Metadata["subject"],
+				"Cases":    cases,
+				"Function": item.Metadata["function_name"],
+			},
+		})
+
+		recommendations = append(recommendations, QualityRecommendation{
+			ID:          fmt.Sprintf("LOOKUP-%d", i+1),
+			Title:       text.Title,
+			Description: text.Description,
+			Category:    CategoryQuickWins,
+			Priority:    qr.mapSeverityToPriority(item.Severity),
+			Impact:      ImpactMedium,
+			Effort:      qr.determineEffortLevel(item.EstimatedHours),
+			EffortHours: item.EstimatedHours,
+			ROI:         qr.calculateROI(item.EstimatedHours, float64(cases)),
+			Component:   "technical_debt",
+			Files:       []string{item.FilePath},
+			Actions: []RecommendationAction{
+				{
+					Type:           "refactor",
+					Description:    text.Actions["refactor"],
+					Files:          []string{item.FilePath},
+					EstimatedHours: item.EstimatedHours,
+				},
+			},
+			Benefits:     text.Benefits,
+			Risks:        text.Risks,
+			Dependencies: []string{},
+			Timeline:     qr.estimateTimeline(item.EstimatedHours),
+			References:   text.Links,
+		})
+	}
+
+	return recommendations
+}
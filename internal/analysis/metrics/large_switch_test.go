@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// switchSource returns a function switching on action.type with the given number of cases
+func switchSource(name string, cases int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "function %s(action) {\n  switch (action.type) {\n", name)
+	for i := 0; i < cases; i++ {
+		fmt.Fprintf(&b, "    case 'c%d':\n      return %d;\n", i, i)
+	}
+	b.WriteString("  }\n}\n")
+	return b.String()
+}
+
+func TestAnalyzeLargeSwitches(t *testing.T) {
+	files := map[string]string{"src/reducer.js": switchSource("reduce", 10) + switchSource("small", 3) + `
+function route(a, b) {
+  if (a === 1) { return 1; }
+  else if (b === 2) { return 2; }
+  else if (a === 3) { return 3; }
+  else if (a === 4) { return 4; }
+}
+`}
+	parseResults, _, err := NewQualityReporter(QualityReportConfig{}).parseFiles(files)
+	require.NoError(t, err)
+
+	scorer := NewDebtScorer()
+	scorer.config.SwitchCaseThreshold = 3
+	items := scorer.analyzeLargeSwitches(parseResults)
+	require.Len(t, items, 1, "the 3-case switch is at the threshold and the if chain tests two values")
+
+	item := items[0]
+	assert.Equal(t, "large_switch", item.Type)
+	assert.Equal(t, "reduce", item.FunctionName)
+	assert.Equal(t, 2, item.StartLine)
+	assert.Equal(t, "switch on 'action.type' in 'reduce' has 10 cases (threshold: 3)", item.Description)
+	assert.Equal(t, "high", item.Severity)
+	assert.Equal(t, 3.5, item.EstimatedHours)
+}
+
+func TestGenerateQualityReport_LookupTableRecommendation(t *testing.T) {
+	files := map[string]string{"src/reducer.js": switchSource("reduce", 12)}
+	report, err := NewQualityReporter(QualityReportConfig{SwitchCaseThreshold: 10}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	var lookups []QualityRecommendation
+	for _, recommendation := range report.Recommendations {
+		if strings.HasPrefix(recommendation.ID, "LOOKUP-") {
+			lookups = append(lookups, recommendation)
+		}
+	}
+	require.Len(t, lookups, 1)
+	assert.Equal(t, "Replace the 12-case switch in reduce with a lookup table", lookups[0].Title)
+	assert.Equal(t, "The switch on action.type at src/reducer.js:2 has 12 cases; a map from each action.type value to its result or handler states the same mapping as data", lookups[0].Description)
+	assert.Equal(t, []string{"src/reducer.js"}, lookups[0].Files)
+	assert.Equal(t, 4.0, lookups[0].EffortHours)
+}
@@ -27,6 +27,9 @@ type MaintainabilityConfig struct {
 	LOCWeight        float64 `yaml:"loc_weight" json:"loc_weight"`               // 0.40
 	CommentWeight    float64 `yaml:"comment_weight" json:"comment_weight"`       // 0.25
 
+	// Share of the overall index taken from public API documentation coverage
+	DocumentationWeight float64 `yaml:"documentation_weight" json:"documentation_weight"` // 0.10
+
 	// Analysis settings
 	EnableTrends     bool `yaml:"enable_trends" json:"enable_trends"`
 	TrendPeriods     int  `yaml:"trend_periods" json:"trend_periods"`
@@ -37,7 +40,7 @@ type MaintainabilityConfig struct {
 // MaintainabilityMetrics contains comprehensive maintainability analysis results
 type MaintainabilityMetrics struct {
 	OverallIndex           float64                        `json:"overall_index"`
-	Classification         string                         `json:"classification"` // Good, Fair, Poor
+	Classification         string                         `json:"classification"` // Good, Fair, Poor; the report regrades it on a letter or numeric scale
 	AverageIndex           float64                        `json:"average_index"`
 	TotalFunctions         int                            `json:"total_functions"`
 	TotalFiles             int                            `json:"total_files"`
@@ -49,6 +52,7 @@ type MaintainabilityMetrics struct {
 	ImprovementSuggestions []MaintainabilityImprovement   `json:"improvement_suggestions"`
 	Summary                MaintainabilitySummary         `json:"summary"`
 	BenchmarkComparison    *BenchmarkData                 `json:"benchmark_comparison,omitempty"`
+	DocumentationScore     *float64                       `json:"documentation_score,omitempty"`
 }
 
 // MaintainabilityBreakdown categorizes functions by maintainability level
@@ -78,6 +82,9 @@ type FunctionMaintainability struct {
 	HalsteadMetrics      HalsteadMetrics           `json:"halstead_metrics"`
 	ImprovementFactors   []string                  `json:"improvement_factors"`
 	RecommendedActions   []string                  `json:"recommended_actions"`
+	CommentLines         int                       `json:"comment_lines"`                  // doc and inline comment lines behind Components.CommentRatio
+	CommentedOutLines    int                       `json:"commented_out_lines,omitempty"`  // commented-out code, left out of the ratio
+	UndocumentedComplex  bool                      `json:"undocumented_complex,omitempty"` // complex, yet not a single comment line
 	Metadata             map[string]interface{}    `json:"metadata"`
 }
 
@@ -173,6 +180,8 @@ type MaintainabilitySummary struct {
 	TopRecommendation      string  `json:"top_recommendation"`
 	EstimatedEffortHours   int     `json:"estimated_effort_hours"`
 	PredictedIndexIncrease float64 `json:"predicted_index_increase"`
+
+	UndocumentedComplexFunctions int `json:"undocumented_complex_functions"` // complex functions without a single comment line
 }
 
 // BenchmarkData provides industry comparison context
@@ -207,6 +216,8 @@ func NewMaintainabilityCalculator() *MaintainabilityCalculator {
 			TrendPeriods:     6,
 			ReportTopN:       10,
 			MinFunctionLines: 3,
+
+			DocumentationWeight: 0.10,
 		},
 	}
 }
@@ -223,6 +234,17 @@ func (mc *MaintainabilityCalculator) AnalyzeMaintainability(
 	ctx context.Context,
 	parseResults []*ast.ParseResult,
 	complexityMetrics *ComplexityMetrics,
+) (*MaintainabilityMetrics, error) {
+	return mc.AnalyzeMaintainabilityWithDocumentation(ctx, parseResults, complexityMetrics, nil)
+}
+
+// AnalyzeMaintainabilityWithDocumentation behaves like AnalyzeMaintainability and additionally
+// blends public API documentation coverage into the overall index using DocumentationWeight
+func (mc *MaintainabilityCalculator) AnalyzeMaintainabilityWithDocumentation(
+	ctx context.Context,
+	parseResults []*ast.ParseResult,
+	complexityMetrics *ComplexityMetrics,
+	documentation *DocumentationMetrics,
 ) (*MaintainabilityMetrics, error) {
 	if len(parseResults) == 0 {
 		return &MaintainabilityMetrics{
@@ -269,6 +291,13 @@ func (mc *MaintainabilityCalculator) AnalyzeMaintainability(
 		metrics.AverageIndex = mc.calculateAverageFunctionIndex(metrics.FunctionMetrics)
 	}
 
+	// Blend in documentation coverage; projects without exports have nothing to document
+	if documentation != nil && documentation.PublicAPIs > 0 {
+		score := documentation.Score
+		metrics.DocumentationScore = &score
+		metrics.OverallIndex = metrics.OverallIndex*(1-mc.config.DocumentationWeight) + score*mc.config.DocumentationWeight
+	}
+
 	// Classify overall maintainability
 	metrics.Classification = mc.classifyMaintainability(metrics.OverallIndex)
 
@@ -353,15 +382,16 @@ func (mc *MaintainabilityCalculator) analyzeFunctionMaintainability(
 	// Calculate lines of code
 	linesOfCode := function.EndLine - function.StartLine + 1
 
-	// Calculate comment ratio
-	commentRatio := mc.calculateCommentRatio(function, result)
+	// Comment lines per code line, from the doc comment and the comments in the body
+	comments := countFunctionComments(function, result.Comments)
+	commentRatio := comments.ratio()
 
 	// Calculate maintainability index using Microsoft's formula (adapted)
 	maintainabilityIndex := mc.calculateMaintainabilityIndex(
 		halsteadMetrics.Volume,
 		float64(cyclomaticComplexity),
 		float64(linesOfCode),
-		commentRatio,
+		math.Min(commentRatio, maxRewardedCommentRatio),
 	)
 
 	// Create components breakdown
@@ -374,8 +404,12 @@ func (mc *MaintainabilityCalculator) analyzeFunctionMaintainability(
 	}
 
 	// Generate improvement factors and recommendations
+	undocumentedComplex := comments.commentLines == 0 && cyclomaticComplexity > undocumentedComplexity
 	improvementFactors := mc.identifyImprovementFactors(components, maintainabilityIndex)
 	recommendedActions := mc.generateRecommendedActions(components, function)
+	if undocumentedComplex {
+		improvementFactors = append(improvementFactors, "Complex function without a single comment")
+	}
 
 	return FunctionMaintainability{
 		Name:                 function.Name,
@@ -388,6 +422,9 @@ func (mc *MaintainabilityCalculator) analyzeFunctionMaintainability(
 		HalsteadMetrics:      halsteadMetrics,
 		ImprovementFactors:   improvementFactors,
 		RecommendedActions:   recommendedActions,
+		CommentLines:         comments.commentLines,
+		CommentedOutLines:    comments.commentedOutLines,
+		UndocumentedComplex:  undocumentedComplex,
 		Metadata:             make(map[string]interface{}),
 	}
 }
@@ -495,50 +532,6 @@ func (mc *MaintainabilityCalculator) getCyclomaticComplexity(
 	return 1 + len(function.Parameters)/3
 }
 
-// calculateCommentRatio estimates comment density in a function
-func (mc *MaintainabilityCalculator) calculateCommentRatio(
-	function ast.FunctionInfo,
-	result *ast.ParseResult,
-) float64 {
-	// This is a simplified implementation
-	// In a full implementation, you would parse the actual source code
-	// to count comment lines vs code lines
-
-	functionLines := function.EndLine - function.StartLine + 1
-
-	// Heuristic: estimate comment ratio based on function characteristics
-	var estimatedCommentRatio float64
-
-	// Longer functions tend to have more comments
-	if functionLines > 50 {
-		estimatedCommentRatio = 0.15 // 15% comments for large functions
-	} else if functionLines > 20 {
-		estimatedCommentRatio = 0.10 // 10% comments for medium functions
-	} else {
-		estimatedCommentRatio = 0.05 // 5% comments for small functions
-	}
-
-	// Complex functions (more parameters) might have more documentation
-	paramCount := len(function.Parameters)
-	if paramCount > 5 {
-		estimatedCommentRatio += 0.05
-	} else if paramCount > 2 {
-		estimatedCommentRatio += 0.02
-	}
-
-	// Exported functions might have more documentation
-	if function.IsExported {
-		estimatedCommentRatio += 0.03
-	}
-
-	// Cap at reasonable maximum
-	if estimatedCommentRatio > 0.25 {
-		estimatedCommentRatio = 0.25
-	}
-
-	return estimatedCommentRatio
-}
-
 // calculateMaintainabilityIndex computes the Microsoft Maintainability Index
 func (mc *MaintainabilityCalculator) calculateMaintainabilityIndex(
 	halsteadVolume, cyclomaticComplexity, linesOfCode, commentRatio float64,
@@ -796,7 +789,8 @@ func (mc *MaintainabilityCalculator) calculateOverallComponents(fileMetrics map[
 	totalCommentRatio := 0.0
 	totalWeightedScore := 0.0
 
-	for _, file := range fileMetrics {
+	for _, filePath := range sortedKeys(fileMetrics) {
+		file := fileMetrics[filePath]
 		totalHalstead += file.Components.HalsteadVolume
 		totalComplexity += file.Components.CyclomaticComplexity
 		totalLOC += file.Components.LinesOfCode
@@ -916,7 +910,8 @@ func (mc *MaintainabilityCalculator) generateImprovementSuggestions(
 
 	// Identify documentation opportunities
 	lowDocumentationFiles := []string{}
-	for filePath, file := range fileMetrics {
+	for _, filePath := range sortedKeys(fileMetrics) {
+		file := fileMetrics[filePath]
 		if file.Components.CommentRatio < 0.05 {
 			lowDocumentationFiles = append(lowDocumentationFiles, filePath)
 		}
@@ -972,7 +967,7 @@ func (mc *MaintainabilityCalculator) generateImprovementSuggestions(
 	}
 
 	// Sort by priority and ROI
-	sort.Slice(improvements, func(i, j int) bool {
+	sort.SliceStable(improvements, func(i, j int) bool {
 		priorityOrder := map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3}
 		if priorityOrder[improvements[i].Priority] != priorityOrder[improvements[j].Priority] {
 			return priorityOrder[improvements[i].Priority] < priorityOrder[improvements[j].Priority]
@@ -1022,6 +1017,13 @@ func (mc *MaintainabilityCalculator) createMaintainabilitySummary(metrics *Maint
 	// Predicted index increase
 	predictedIncrease := math.Min(improvementPotential, 25.0) // Cap at realistic improvement
 
+	undocumentedComplex := 0
+	for _, function := range metrics.FunctionMetrics {
+		if function.UndocumentedComplex {
+			undocumentedComplex++
+		}
+	}
+
 	return MaintainabilitySummary{
 		TotalIssues:            totalIssues,
 		CriticalIssues:         criticalIssues,
@@ -1030,6 +1032,8 @@ func (mc *MaintainabilityCalculator) createMaintainabilitySummary(metrics *Maint
 		TopRecommendation:      topRecommendation,
 		EstimatedEffortHours:   effortHours,
 		PredictedIndexIncrease: predictedIncrease,
+
+		UndocumentedComplexFunctions: undocumentedComplex,
 	}
 }
 
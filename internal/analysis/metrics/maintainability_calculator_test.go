@@ -159,58 +159,56 @@ func TestGetCyclomaticComplexity(t *testing.T) {
 	assert.LessOrEqual(t, complexityFallback, 10)
 }
 
-func TestCalculateCommentRatio(t *testing.T) {
-	calculator := NewMaintainabilityCalculator()
-
-	result := &ast.ParseResult{
-		FilePath: "test.js",
-		Language: "javascript",
+func TestCountFunctionComments(t *testing.T) {
+	comments := []ast.CommentInfo{
+		{Text: "// Unrelated header", StartLine: 1, EndLine: 1},
+		{Text: "/**\n * Totals the order.\n *\n * @param order the order\n */", StartLine: 3, EndLine: 7},
+		{Text: "// Discounts apply before tax", StartLine: 10, EndLine: 10},
+		{Text: "// ------------------", StartLine: 11, EndLine: 11},
+		{Text: "// const legacy = total * 2;", StartLine: 14, EndLine: 14},
+		{Text: "// return legacy;", StartLine: 15, EndLine: 15},
 	}
+	function := ast.FunctionInfo{Name: "total", StartLine: 8, EndLine: 20}
 
-	tests := []struct {
-		name     string
-		function ast.FunctionInfo
-		expected float64 // approximate expected range
-	}{
-		{
-			name: "small_function",
-			function: ast.FunctionInfo{
-				Name:       "smallFunc",
-				StartLine:  1,
-				EndLine:    5,
-				Parameters: []ast.ParameterInfo{{Name: "param", Type: "string"}},
-				IsExported: false,
-			},
-			expected: 0.05, // Around 5%
-		},
-		{
-			name: "large_exported_function",
-			function: ast.FunctionInfo{
-				Name:      "largeFunc",
-				StartLine: 1,
-				EndLine:   60,
-				Parameters: []ast.ParameterInfo{
-					{Name: "param1", Type: "string"},
-					{Name: "param2", Type: "object"},
-					{Name: "param3", Type: "array"},
-					{Name: "param4", Type: "boolean"},
-					{Name: "param5", Type: "number"},
-					{Name: "param6", Type: "function"},
-				},
-				IsExported: true,
-			},
-			expected: 0.20, // Around 20%
-		},
-	}
+	counts := countFunctionComments(function, comments)
+	assert.Equal(t, 3, counts.commentLines, "two worded doc comment lines and one inline comment")
+	assert.Equal(t, 2, counts.commentedOutLines)
+	assert.Equal(t, 9, counts.codeLines, "13 lines minus the 4 comment lines in the body")
+	assert.InDelta(t, 3.0/9.0, counts.ratio(), 1e-9)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ratio := calculator.calculateCommentRatio(tt.function, result)
-			assert.GreaterOrEqual(t, ratio, 0.0)
-			assert.LessOrEqual(t, ratio, 0.25)
-			assert.InDelta(t, tt.expected, ratio, 0.10) // Within 10% of expected
-		})
+	bare := countFunctionComments(ast.FunctionInfo{StartLine: 30, EndLine: 39}, comments)
+	assert.Zero(t, bare.commentLines)
+	assert.Zero(t, bare.ratio())
+}
+
+func TestAnalyzeFunctionMaintainability_Comments(t *testing.T) {
+	calculator := NewMaintainabilityCalculator()
+	function := ast.FunctionInfo{Name: "route", StartLine: 2, EndLine: 41}
+	complexity := &ComplexityMetrics{FunctionMetrics: []FunctionComplexity{
+		{Name: "route", FilePath: "test.js", StartLine: 2, EndLine: 41, CyclomaticValue: 14},
+	}}
+
+	bare := calculator.analyzeFunctionMaintainability(function, &ast.ParseResult{FilePath: "test.js"}, complexity)
+	assert.True(t, bare.UndocumentedComplex)
+	assert.Contains(t, bare.ImprovementFactors, "Complex function without a single comment")
+
+	documented := calculator.analyzeFunctionMaintainability(function, &ast.ParseResult{FilePath: "test.js", Comments: []ast.CommentInfo{
+		{Text: "// Route requests by method", StartLine: 1, EndLine: 1},
+		{Text: "// Writes need a session", StartLine: 10, EndLine: 10},
+	}}, complexity)
+	assert.False(t, documented.UndocumentedComplex)
+	assert.Equal(t, 2, documented.CommentLines)
+	assert.Greater(t, documented.MaintainabilityIndex, bare.MaintainabilityIndex)
+
+	// Past the rewarded ratio, more comments no longer raise the index
+	spam := make([]ast.CommentInfo, 0, 30)
+	for line := 3; line < 33; line++ {
+		spam = append(spam, ast.CommentInfo{Text: "// Note", StartLine: line, EndLine: line})
 	}
+	spammed := calculator.analyzeFunctionMaintainability(function, &ast.ParseResult{FilePath: "test.js", Comments: spam}, complexity)
+	capped := calculator.calculateMaintainabilityIndex(spammed.Components.HalsteadVolume, 14, 40, maxRewardedCommentRatio)
+	assert.Greater(t, spammed.Components.CommentRatio, maxRewardedCommentRatio)
+	assert.InDelta(t, capped, spammed.MaintainabilityIndex, 1e-9)
 }
 
 func TestCalculateMaintainabilityIndex(t *testing.T) {
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Thresholds for flagging a file whose maintainability index is falling
+const (
+	degradingMinRuns        = 2   // consecutive declining runs that flag a file
+	degradingSharpDrop      = 5.0 // a single-run drop of this many points also flags it
+	degradingStepEpsilon    = 0.5 // smaller movements count as stable
+	maxDegradingFileReports = 5
+)
+
+// degradingFile is a file whose maintainability index declined run over run
+type degradingFile struct {
+	filePath string
+	from, to float64 // index at the start of the decline and now
+	runs     int     // consecutive declining runs ending with the current one
+}
+
+// findDegradingFiles compares each file's current maintainability index with its history
+// and returns the files that declined in each of the last degradingMinRuns runs, or by at
+// least degradingSharpDrop points in the last run, largest decline first
+func findDegradingFiles(maintainability *MaintainabilityMetrics, history []HistoricalDataPoint, now HistoricalDataPoint) []degradingFile {
+	if maintainability == nil {
+		return nil
+	}
+
+	var snapshots []HistoricalDataPoint
+	for _, point := range history {
+		if !point.Timestamp.After(now.Timestamp) && len(point.FileMaintainability) > 0 {
+			snapshots = append(snapshots, point)
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Timestamp.Before(snapshots[j].Timestamp)
+	})
+
+	var degrading []degradingFile
+	for _, filePath := range sortedKeys(maintainability.FileMetrics) {
+		series := []float64{}
+		for _, snapshot := range snapshots {
+			if index, ok := snapshot.FileMaintainability[filePath]; ok {
+				series = append(series, index)
+			}
+		}
+		current := maintainability.FileMetrics[filePath].OverallIndex
+		series = append(series, current)
+
+		// Count the declining steps ending at the current run
+		runs := 0
+		for i := len(series) - 1; i > 0 && series[i-1]-series[i] > degradingStepEpsilon; i-- {
+			runs++
+		}
+		if runs == 0 {
+			continue
+		}
+
+		lastDrop := series[len(series)-2] - current
+		if runs < degradingMinRuns && lastDrop < degradingSharpDrop {
+			continue
+		}
+
+		degrading = append(degrading, degradingFile{
+			filePath: filePath,
+			from:     series[len(series)-1-runs],
+			to:       current,
+			runs:     runs,
+		})
+	}
+
+	sort.SliceStable(degrading, func(i, j int) bool {
+		return degrading[i].from-degrading[i].to > degrading[j].from-degrading[j].to
+	})
+	return degrading
+}
+
+// generateMaintainabilityTrendRecommendations warns about files whose maintainability
+// index keeps falling, including files still above the threshold that low-index
+// recommendations would not mention yet
+func (qr *QualityReporter) generateMaintainabilityTrendRecommendations(maintainability *MaintainabilityMetrics) []QualityRecommendation {
+	degrading := findDegradingFiles(maintainability, qr.config.History, HistoricalDataPoint{Timestamp: qr.now()})
+
+	var recommendations []QualityRecommendation
+	for i, file := range degrading {
+		if i >= maxDegradingFileReports {
+			break
+		}
+
+		belowThreshold := file.to < qr.maintainabilityCalc.config.FairThreshold
+		priority := PriorityLow
+		if belowThreshold {
+			priority = PriorityMedium
+		}
+		effort := 1.0 // review the recent changes before the decline compounds
+		text := qr.recommendationText(RecommendationMaintainabilityTrend, "", RecommendationContext{
+			Finding:     maintainability.FileMetrics[file.filePath],
+			File:        file.filePath,
+			EffortHours: effort,
+			Values: map[string]interface{}{
+				"From":           file.from,
+				"To":             file.to,
+				"Runs":           file.runs,
+				"BelowThreshold": belowThreshold,
+			},
+		})
+
+		recommendations = append(recommendations, QualityRecommendation{
+			ID:          fmt.Sprintf("MAINT-TREND-%d", i+1),
+			Title:       text.Title,
+			Description: text.Description,
+			Category:    CategoryQuickWins,
+			Priority:    priority,
+			Impact:      ImpactMedium,
+			Effort:      qr.determineEffortLevel(effort),
+			EffortHours: effort,
+			ROI:         qr.calculateROI(effort, file.from-file.to),
+			Component:   "maintainability",
+			Files:       []string{file.filePath},
+			Actions: []RecommendationAction{
+				{
+					Type:           "review",
+					Description:    text.Actions["review"],
+					Files:          []string{file.filePath},
+					EstimatedHours: effort,
+				},
+			},
+			Benefits:     text.Benefits,
+			Risks:        text.Risks,
+			Dependencies: []string{},
+			Timeline:     qr.estimateTimeline(effort),
+			References:   text.Links,
+		})
+	}
+
+	return recommendations
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func maintainabilityWithIndexes(indexes map[string]float64) *MaintainabilityMetrics {
+	metrics := &MaintainabilityMetrics{FileMetrics: make(map[string]FileMaintainability)}
+	for filePath, index := range indexes {
+		metrics.FileMetrics[filePath] = FileMaintainability{FilePath: filePath, OverallIndex: index}
+	}
+	return metrics
+}
+
+func TestFindDegradingFiles(t *testing.T) {
+	now := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	history := []HistoricalDataPoint{
+		{Timestamp: now.AddDate(0, 0, -7), FileMaintainability: map[string]float64{
+			"src/slow.js": 86, "src/sharp.js": 90, "src/steady.js": 80, "src/bounce.js": 70,
+		}},
+		{Timestamp: now.AddDate(0, 0, -14), FileMaintainability: map[string]float64{
+			"src/slow.js": 88, "src/bounce.js": 75,
+		}},
+		{Timestamp: now.AddDate(0, 0, 1), FileMaintainability: map[string]float64{"src/slow.js": 10}}, // after now
+	}
+	current := maintainabilityWithIndexes(map[string]float64{
+		"src/slow.js":   84,   // 88 -> 86 -> 84: declining two runs in a row
+		"src/sharp.js":  82,   // one run, 8 points
+		"src/steady.js": 79.8, // within noise
+		"src/bounce.js": 72,   // 75 -> 70 -> 72: recovered
+		"src/new.js":    50,   // no history
+	})
+
+	degrading := findDegradingFiles(current, history, HistoricalDataPoint{Timestamp: now})
+
+	require.Len(t, degrading, 2)
+	assert.Equal(t, degradingFile{filePath: "src/sharp.js", from: 90, to: 82, runs: 1}, degrading[0])
+	assert.Equal(t, degradingFile{filePath: "src/slow.js", from: 88, to: 84, runs: 2}, degrading[1])
+
+	assert.Empty(t, findDegradingFiles(current, nil, HistoricalDataPoint{Timestamp: now}))
+}
+
+func TestGenerateMaintainabilityTrendRecommendations(t *testing.T) {
+	now := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	reporter := NewQualityReporter(QualityReportConfig{
+		History: []HistoricalDataPoint{
+			{Timestamp: now.AddDate(0, 0, -14), FileMaintainability: map[string]float64{"src/a.js": 90, "src/b.js": 75}},
+			{Timestamp: now.AddDate(0, 0, -7), FileMaintainability: map[string]float64{"src/a.js": 88, "src/b.js": 72}},
+		},
+	})
+	reporter.now = func() time.Time { return now }
+
+	recommendations := reporter.generateMaintainabilityTrendRecommendations(
+		maintainabilityWithIndexes(map[string]float64{"src/a.js": 86, "src/b.js": 68}))
+
+	require.Len(t, recommendations, 2)
+	assert.Equal(t, "MAINT-TREND-1", recommendations[0].ID)
+	assert.Equal(t, []string{"src/b.js"}, recommendations[0].Files)
+	assert.Equal(t, "Maintainability index fell from 75.0 to 68.0 over 2 consecutive runs; now below the maintainability threshold", recommendations[0].Description)
+	assert.Equal(t, PriorityMedium, recommendations[0].Priority)
+
+	assert.Equal(t, "Watch degrading file: src/a.js", recommendations[1].Title)
+	assert.Contains(t, recommendations[1].Description, "still above the threshold, but degrading")
+	assert.Equal(t, PriorityLow, recommendations[1].Priority)
+	assert.Equal(t, "maintainability", recommendations[1].Component)
+}
+
+func TestLoadHistoricalDataPoint_FileMaintainability(t *testing.T) {
+	data, err := json.Marshal(&QualityReport{
+		GeneratedAt: time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC),
+		DetailedMetrics: DetailedMetrics{
+			Maintainability: maintainabilityWithIndexes(map[string]float64{"src/a.js": 81.5}),
+		},
+	})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "previous.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	point, err := LoadHistoricalDataPoint(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"src/a.js": 81.5}, point.FileMaintainability)
+}
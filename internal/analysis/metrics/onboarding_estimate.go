@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Weights of the onboarding estimate, see onboardingFormula
+const (
+	onboardingBaseDays          = 1.0 // setting up, building and running the project
+	onboardingDaysPerSqrtKLOC   = 1.5 // reading grows with the square root of the size, since nobody reads it all
+	onboardingDaysPerComplexity = 0.5 // per point of average cyclomatic complexity
+	onboardingDaysPerDependency = 0.1 // per package declared in package.json
+	onboardingUndocumentedDrag  = 0.5 // extra share of time when nothing is documented
+	onboardingRoundingDays      = 0.5 // the estimate is rounded up to half days
+)
+
+// onboardingFormula states how OnboardingEstimate.Days is computed from its inputs
+var onboardingFormula = fmt.Sprintf("(%g + %g*sqrt(total_lines/1000) + %g*average_complexity + %g*dependencies) * (1 + %g*(1 - documentation_coverage/100))",
+	onboardingBaseDays, onboardingDaysPerSqrtKLOC, onboardingDaysPerComplexity, onboardingDaysPerDependency, onboardingUndocumentedDrag)
+
+// OnboardingEstimate is a rough estimate of the days a developer needs to become productive
+// in the repository. It is composed from metrics the analyzers already produce, and reports
+// the formula, its inputs and what each term contributes so the number can be checked.
+type OnboardingEstimate struct {
+	Days                float64                  `json:"days"`
+	Formula             string                   `json:"formula"`
+	Inputs              OnboardingEstimateInputs `json:"inputs"`
+	Terms               []OnboardingTerm         `json:"terms"`                // days each input adds, before the documentation factor
+	DocumentationFactor float64                  `json:"documentation_factor"` // multiplier for missing documentation, 1 when fully documented
+	Unmeasured          []string                 `json:"unmeasured,omitempty"` // inputs whose analyzer did not run, counted as 0 (documentation as undocumented)
+}
+
+// OnboardingEstimateInputs are the metrics the onboarding estimate is computed from
+type OnboardingEstimateInputs struct {
+	TotalLines            int     `json:"total_lines"`
+	AverageComplexity     float64 `json:"average_complexity"`
+	DocumentationCoverage float64 `json:"documentation_coverage"` // percent of public APIs with a doc comment
+	Dependencies          int     `json:"dependencies"`
+}
+
+// OnboardingTerm is the share of the estimate one input accounts for
+type OnboardingTerm struct {
+	Name string  `json:"name"`
+	Days float64 `json:"days"`
+}
+
+// EstimateOnboarding computes the onboarding estimate of report from its repository summary
+// and detailed metrics. It returns nil for a report without a summary, such as that of an
+// empty repository.
+func EstimateOnboarding(report *QualityReport) *OnboardingEstimate {
+	if report.Summary == nil {
+		return nil
+	}
+
+	estimate := &OnboardingEstimate{
+		Formula: onboardingFormula,
+		Inputs: OnboardingEstimateInputs{
+			TotalLines:   report.Summary.TotalLines,
+			Dependencies: report.Summary.Dependencies,
+		},
+	}
+	if complexity := report.DetailedMetrics.Complexity; complexity != nil {
+		estimate.Inputs.AverageComplexity = complexity.AverageComplexity
+	} else {
+		estimate.Unmeasured = append(estimate.Unmeasured, "average_complexity")
+	}
+	if documentation := report.DetailedMetrics.Documentation; documentation != nil {
+		estimate.Inputs.DocumentationCoverage = documentation.OverallCoverage
+	} else {
+		estimate.Unmeasured = append(estimate.Unmeasured, "documentation_coverage")
+	}
+
+	inputs := estimate.Inputs
+	estimate.Terms = []OnboardingTerm{
+		{Name: "base", Days: onboardingBaseDays},
+		{Name: "total_lines", Days: onboardingDaysPerSqrtKLOC * math.Sqrt(float64(inputs.TotalLines)/1000)},
+		{Name: "average_complexity", Days: onboardingDaysPerComplexity * inputs.AverageComplexity},
+		{Name: "dependencies", Days: onboardingDaysPerDependency * float64(inputs.Dependencies)},
+	}
+	estimate.DocumentationFactor = 1 + onboardingUndocumentedDrag*(1-inputs.DocumentationCoverage/100)
+
+	days := 0.0
+	for i, term := range estimate.Terms {
+		days += term.Days
+		estimate.Terms[i].Days = RoundScore(term.Days, 2)
+	}
+	estimate.Days = math.Ceil(days*estimate.DocumentationFactor/onboardingRoundingDays) * onboardingRoundingDays
+	estimate.DocumentationFactor = RoundScore(estimate.DocumentationFactor, 2)
+
+	return estimate
+}
+
+// WriteOnboardingEstimate prints the estimate with its formula and inputs for --onboard-estimate
+func WriteOnboardingEstimate(w io.Writer, estimate *OnboardingEstimate) error {
+	var b strings.Builder
+	if estimate == nil {
+		b.WriteString("Estimated time to onboard: not available (no source files)\n")
+	} else {
+		measured := func(name string, value string) string {
+			for _, unmeasured := range estimate.Unmeasured {
+				if unmeasured == name {
+					return "not measured"
+				}
+			}
+			return value
+		}
+
+		fmt.Fprintf(&b, "Estimated time to onboard: %g days\n\n", estimate.Days)
+		fmt.Fprintf(&b, "%-24s %s\n", "Input", "Value")
+		fmt.Fprintf(&b, "%-24s %d\n", "total_lines", estimate.Inputs.TotalLines)
+		fmt.Fprintf(&b, "%-24s %s\n", "average_complexity", measured("average_complexity", fmt.Sprintf("%.2f", estimate.Inputs.AverageComplexity)))
+		fmt.Fprintf(&b, "%-24s %s\n", "documentation_coverage", measured("documentation_coverage", fmt.Sprintf("%.1f%%", estimate.Inputs.DocumentationCoverage)))
+		fmt.Fprintf(&b, "%-24s %d\n\n", "dependencies", estimate.Inputs.Dependencies)
+		fmt.Fprintf(&b, "%-24s %s\n", "Term", "Days")
+		for _, term := range estimate.Terms {
+			fmt.Fprintf(&b, "%-24s %.2f\n", term.Name, term.Days)
+		}
+		fmt.Fprintf(&b, "%-24s x%.2f\n\n", "documentation_factor", estimate.DocumentationFactor)
+		fmt.Fprintf(&b, "days = %s, rounded up to half days\n", estimate.Formula)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write onboarding estimate: %w", err)
+	}
+	return nil
+}
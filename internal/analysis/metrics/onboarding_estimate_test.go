@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateOnboarding(t *testing.T) {
+	report := &QualityReport{
+		Summary: &RepositorySummary{TotalLines: 16000, Dependencies: 20},
+		DetailedMetrics: DetailedMetrics{
+			Complexity:    &ComplexityMetrics{AverageComplexity: 4},
+			Documentation: &DocumentationMetrics{OverallCoverage: 40},
+		},
+	}
+
+	estimate := EstimateOnboarding(report)
+	require.NotNil(t, estimate)
+	assert.Equal(t, OnboardingEstimateInputs{TotalLines: 16000, AverageComplexity: 4, DocumentationCoverage: 40, Dependencies: 20}, estimate.Inputs)
+	assert.Equal(t, []OnboardingTerm{
+		{Name: "base", Days: 1},
+		{Name: "total_lines", Days: 6},
+		{Name: "average_complexity", Days: 2},
+		{Name: "dependencies", Days: 2},
+	}, estimate.Terms)
+	assert.Equal(t, 1.3, estimate.DocumentationFactor)
+	assert.Equal(t, 14.5, estimate.Days, "11 days times 1.3, rounded up to half days")
+	assert.Empty(t, estimate.Unmeasured)
+
+	report.DetailedMetrics = DetailedMetrics{}
+	estimate = EstimateOnboarding(report)
+	assert.Equal(t, []string{"average_complexity", "documentation_coverage"}, estimate.Unmeasured)
+	assert.Equal(t, 1.5, estimate.DocumentationFactor, "unmeasured documentation counts as undocumented")
+
+	assert.Nil(t, EstimateOnboarding(&QualityReport{}))
+}
+
+func TestGenerateQualityReport_TimeToOnboard(t *testing.T) {
+	files := map[string]string{"src/math.js": "/** Adds two numbers */\nexport function add(a, b) {\n  return a + b;\n}\n"}
+	report, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	require.NotNil(t, report.TimeToOnboard)
+	assert.Equal(t, report.Summary.TotalLines, report.TimeToOnboard.Inputs.TotalLines)
+	assert.Greater(t, report.TimeToOnboard.Days, 0.0)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteOnboardingEstimate(&out, report.TimeToOnboard))
+	assert.Contains(t, out.String(), "Estimated time to onboard: ")
+	assert.Contains(t, out.String(), "days = "+onboardingFormula)
+}
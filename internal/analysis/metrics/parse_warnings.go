@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// Kinds of parse warnings
+const (
+	ParseWarningUnsupportedSyntax = "unsupported_syntax" // syntax the grammar does not support yet
+	ParseWarningSyntaxError       = "syntax_error"       // the source itself does not parse
+	ParseWarningFailed            = "parse_failed"       // the file was dropped from the analysis
+)
+
+// ParseWarning is a file that did not parse cleanly. Files with syntax errors are still
+// analyzed from the parts that parsed; failed files are left out entirely.
+type ParseWarning struct {
+	File    string `json:"file"`
+	Kind    string `json:"kind"`
+	Line    int    `json:"line,omitempty"`
+	Grammar string `json:"grammar,omitempty"` // grammar the file was parsed with
+	Feature string `json:"feature,omitempty"` // unsupported syntax feature, for unsupported_syntax
+	Message string `json:"message"`
+
+	// Degraded is set when the fallback parse recovered the file leniently: its structure
+	// is analyzed, but the code that did not parse is not
+	Degraded bool `json:"degraded,omitempty"`
+}
+
+// parseWarning turns the first error of a parse result into a warning; files that parsed
+// cleanly have none
+func parseWarning(result *ast.ParseResult) (ParseWarning, bool) {
+	for _, parseError := range result.Errors {
+		warning := ParseWarning{
+			File:    result.FilePath,
+			Kind:    ParseWarningSyntaxError,
+			Line:    parseError.Line,
+			Grammar: parseError.Metadata["grammar"],
+			Message: parseError.Message,
+		}
+		if warning.Grammar == "" {
+			warning.Grammar, _ = result.Metadata["grammar"].(string)
+		}
+		warning.Degraded = parseError.Metadata["parse_status"] == ast.ParseStatusDegraded
+		if parseError.Type == ast.ParseErrorUnsupportedSyntax {
+			warning.Kind = ParseWarningUnsupportedSyntax
+			warning.Feature = parseError.Metadata["feature"]
+		}
+		return warning, true
+	}
+	return ParseWarning{}, false
+}
+
+// ParseWarningCounts counts parse warnings by kind, and the files recovered by the
+// fallback parse under ParseStatusDegraded
+func ParseWarningCounts(warnings []ParseWarning) map[string]int {
+	counts := make(map[string]int)
+	for _, warning := range warnings {
+		counts[warning.Kind]++
+		if warning.Degraded {
+			counts[ast.ParseStatusDegraded]++
+		}
+	}
+	return counts
+}
+
+// ParseFailures counts the files the parser could not read: files dropped from the analysis
+// and files with errors the degraded parse did not recover
+func ParseFailures(warnings []ParseWarning) int {
+	failures := 0
+	for _, warning := range warnings {
+		if !warning.Degraded {
+			failures++
+		}
+	}
+	return failures
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestParseFiles_ParseWarnings(t *testing.T) {
+	files := map[string]string{
+		"src/clean.ts":  "export const answer: number = 42;\n",
+		"src/config.ts": "import data from './data.json' assert { type: 'json' };\nexport default data;\n",
+		"src/broken.ts": "export const total = 1 +;\n",
+		"src/math.js":   "// @flow\nexport function add(a: number, b: number): number { return a + b }\n",
+		"notes.py":      "print('skipped')\n",
+	}
+
+	results, warnings, err := NewQualityReporter(QualityReportConfig{}).parseFiles(files)
+	require.NoError(t, err)
+	assert.Len(t, results, 4, "files with syntax errors are still analyzed")
+
+	byFile := make(map[string]ParseWarning)
+	for _, warning := range warnings {
+		byFile[warning.File] = warning
+	}
+	require.Len(t, byFile, 4)
+	assert.NotContains(t, byFile, "src/clean.ts")
+
+	assert.Equal(t, ParseWarningFailed, byFile["notes.py"].Kind)
+	assert.Equal(t, ParseWarningSyntaxError, byFile["src/broken.ts"].Kind)
+	assert.Equal(t, 1, byFile["src/broken.ts"].Line)
+
+	config := byFile["src/config.ts"]
+	assert.Equal(t, ParseWarningUnsupportedSyntax, config.Kind)
+	assert.Equal(t, "typescript", config.Grammar)
+	assert.Contains(t, config.Feature, "import assertions")
+
+	flow := byFile["src/math.js"]
+	assert.Equal(t, ParseWarningUnsupportedSyntax, flow.Kind)
+	assert.Equal(t, "javascript", flow.Grammar)
+	assert.True(t, flow.Degraded, "the fallback parse recovers the annotated file")
+	assert.False(t, byFile["notes.py"].Degraded)
+
+	assert.Equal(t, map[string]int{
+		ParseWarningFailed:            1,
+		ParseWarningSyntaxError:       1,
+		ParseWarningUnsupportedSyntax: 2,
+		ast.ParseStatusDegraded:       3,
+	}, ParseWarningCounts(warnings))
+	assert.Equal(t, 1, ParseFailures(warnings), "degraded parses are not failures")
+}
+
+func TestParseFiles_PinnedGrammar(t *testing.T) {
+	files := map[string]string{
+		"src/math.js": "export function add(a: number, b: number): number { return a + b }\n",
+	}
+
+	_, warnings, err := NewQualityReporter(QualityReportConfig{Grammars: map[string]string{".js": "typescript"}}).parseFiles(files)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	_, _, err = NewQualityReporter(QualityReportConfig{Grammars: map[string]string{".js": "flow"}}).parseFiles(files)
+	assert.Error(t, err)
+}
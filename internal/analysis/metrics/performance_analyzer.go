@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"path"
 	"sort"
 	"strings"
 
@@ -20,16 +21,26 @@ type PerformanceConfig struct {
 	// Complexity thresholds for performance assessment
 	NestedLoopThreshold    int `yaml:"nested_loop_threshold" default:"2"`
 	QueryPatternThreshold  int `yaml:"query_pattern_threshold" default:"3"`
-	DOMAccessThreshold     int `yaml:"dom_access_threshold" default:"5"`
+	DOMAccessThreshold     int `yaml:"dom_access_threshold" default:"1"` // lookups of one selector per file before it is reported
 	BundleSizeThresholdKB  int `yaml:"bundle_size_threshold_kb" default:"500"`
 	ComponentComplexityMax int `yaml:"component_complexity_max" default:"15"`
 
+	// FunctionSize is shared with the debt scorer; unset values use DefaultFunctionSizeThresholds
+	FunctionSize FunctionSizeThresholds `yaml:"function_size"`
+
+	// SeverityOverrides replaces the detected severity of anti-patterns and bottlenecks by type
+	SeverityOverrides SeverityOverrides `yaml:"severity_overrides"`
+
 	// Performance impact weights
 	AlgorithmicWeight float64 `yaml:"algorithmic_weight" default:"0.35"`
 	MemoryWeight      float64 `yaml:"memory_weight" default:"0.25"`
 	NetworkWeight     float64 `yaml:"network_weight" default:"0.20"`
 	RenderWeight      float64 `yaml:"render_weight" default:"0.15"`
 	BundleWeight      float64 `yaml:"bundle_weight" default:"0.05"`
+
+	// Bundle size estimation
+	DependencySizes    *DependencySizes  `yaml:"-"` // nil uses DefaultDependencySizes
+	DependencyVersions map[string]string `yaml:"-"` // package.json ranges by package name, for version-aware sizes
 }
 
 // PerformanceMetrics contains comprehensive performance analysis results
@@ -44,6 +55,7 @@ type PerformanceMetrics struct {
 	FileAnalysis              []FilePerformanceAnalysis   `json:"file_analysis"`
 	Summary                   PerformanceSummary          `json:"summary"`
 	Recommendations           []PerformanceRecommendation `json:"recommendations"`
+	SuppressedFindings        map[string]int              `json:"suppressed_findings,omitempty"` // anti-patterns silenced by ignore comments, by type
 }
 
 // AntiPattern represents a performance anti-pattern
@@ -112,6 +124,7 @@ type BundleAnalysis struct {
 	HeavyDependencies []HeavyDependency  `json:"heavy_dependencies"`
 	OptimizationTips  []string           `json:"optimization_tips"`
 	TreeShakingIssues []TreeShakingIssue `json:"tree_shaking_issues"`
+	TreeShakeability  *TreeShakeability  `json:"tree_shakeability,omitempty"` // project-wide, for the analyzed modules
 }
 
 // HeavyDependency represents a heavy library dependency
@@ -120,6 +133,7 @@ type HeavyDependency struct {
 	Source          string   `json:"source"`
 	FilePath        string   `json:"file_path"`
 	EstimatedSizeKB int      `json:"estimated_size_kb"`
+	Version         string   `json:"version,omitempty"` // package.json range the size was looked up for
 	ImportType      string   `json:"import_type"`
 	Usage           string   `json:"usage"`
 	Alternatives    []string `json:"alternatives"`
@@ -217,9 +231,10 @@ func NewPerformanceAnalyzer() *PerformanceAnalyzer {
 	return NewPerformanceAnalyzerWithConfig(PerformanceConfig{
 		NestedLoopThreshold:    2,
 		QueryPatternThreshold:  3,
-		DOMAccessThreshold:     5,
+		DOMAccessThreshold:     1,
 		BundleSizeThresholdKB:  500,
 		ComponentComplexityMax: 15,
+		FunctionSize:           DefaultFunctionSizeThresholds(),
 		AlgorithmicWeight:      0.35,
 		MemoryWeight:           0.25,
 		NetworkWeight:          0.20,
@@ -257,6 +272,18 @@ func (pa *PerformanceAnalyzer) AnalyzePerformance(ctx context.Context, parseResu
 	// Perform React-specific analysis if applicable
 	pa.analyzeReactPerformance(parseResults, metrics)
 
+	// Drop anti-patterns silenced by repo-onboarding-ignore comments
+	suppressed := make(map[string]int)
+	metrics.AntiPatterns = newSuppressionIndex(parseResults).filterAntiPatterns(metrics.AntiPatterns, suppressed)
+	metrics.SuppressedFindings = mergeSuppressedCounts(suppressed)
+
+	for i := range metrics.AntiPatterns {
+		metrics.AntiPatterns[i].Severity = pa.config.SeverityOverrides.severity(metrics.AntiPatterns[i].Type, metrics.AntiPatterns[i].Severity)
+	}
+	for i := range metrics.Bottlenecks {
+		metrics.Bottlenecks[i].Severity = pa.config.SeverityOverrides.severity(metrics.Bottlenecks[i].Type, metrics.Bottlenecks[i].Severity)
+	}
+
 	// Generate optimization opportunities
 	pa.generateOptimizationOpportunities(parseResults, complexityMetrics, metrics)
 
@@ -300,11 +327,12 @@ func (pa *PerformanceAnalyzer) detectAntiPatternsAST(parseResults []*ast.ParseRe
 
 // detectNPlusOneQueriesAST identifies N+1 query patterns using AST analysis
 func (pa *PerformanceAnalyzer) detectNPlusOneQueriesAST(result *ast.ParseResult, metrics *PerformanceMetrics) {
+	graph := buildFileCallGraph(result)
 	for _, function := range result.Functions {
 		// Check if function name suggests query operations
 		if pa.containsQueryPattern(function.Name) {
 			// Check if function is likely to be called in loops
-			if pa.isLikelyInLoop(function) {
+			if inLoop, ok := pa.isLikelyInLoop(function, graph); ok {
 				antiPattern := AntiPattern{
 					Type:        "n_plus_one_query",
 					Description: fmt.Sprintf("Potential N+1 query pattern in function '%s'", function.Name),
@@ -312,7 +340,7 @@ func (pa *PerformanceAnalyzer) detectNPlusOneQueriesAST(result *ast.ParseResult,
 					FilePath:    result.FilePath,
 					StartLine:   function.StartLine,
 					EndLine:     function.EndLine,
-					Evidence:    fmt.Sprintf("Function %s contains query patterns and may be called in loops", function.Name),
+					Evidence:    fmt.Sprintf("Function %s contains query patterns and is %s", function.Name, inLoop),
 					Impact: PerformanceImpact{
 						Score:         85,
 						Category:      "database",
@@ -479,14 +507,16 @@ func (pa *PerformanceAnalyzer) detectNestedLoopsAST(result *ast.ParseResult, met
 	}
 }
 
-// detectLargeFunctions identifies functions that are too large and may impact performance
+// detectLargeFunctions identifies functions longer than the critical function size, which
+// may impact performance; twice that size is critical
 func (pa *PerformanceAnalyzer) detectLargeFunctions(result *ast.ParseResult, metrics *PerformanceMetrics) {
+	critical := pa.config.FunctionSize.withDefaults().Critical
 	for _, function := range result.Functions {
-		lineCount := function.EndLine - function.StartLine + 1
+		lineCount := functionLineCount(function)
 
-		if lineCount > 100 {
+		if lineCount > critical {
 			severity := "high"
-			if lineCount > 200 {
+			if lineCount > 2*critical {
 				severity = "critical"
 			}
 
@@ -510,27 +540,39 @@ func (pa *PerformanceAnalyzer) detectLargeFunctions(result *ast.ParseResult, met
 	}
 }
 
-// detectRepeatedDOMQueriesAST identifies potential repeated DOM queries using AST analysis
+// detectRepeatedDOMQueriesAST reports selectors looked up more than DOMAccessThreshold times
+// in one file, based on the querySelector/getElementById-style calls the parser records.
+// Files outside a DOM context are skipped so server code using similar names is never flagged.
 func (pa *PerformanceAnalyzer) detectRepeatedDOMQueriesAST(result *ast.ParseResult, metrics *PerformanceMetrics) {
-	domQueryFunctions := map[string]int{}
+	if len(result.DOMQueries) == 0 || !isDOMContext(result) {
+		return
+	}
 
-	for _, function := range result.Functions {
-		// Check for DOM query-related function names
-		nameLower := strings.ToLower(function.Name)
-		if strings.Contains(nameLower, "query") || strings.Contains(nameLower, "select") ||
-			strings.Contains(nameLower, "element") || strings.Contains(nameLower, "dom") {
-			domQueryFunctions[function.Name]++
-		}
+	lines := map[string][]int{}
+	for _, query := range result.DOMQueries {
+		key := fmt.Sprintf("%s(%s)", query.Method, query.Selector)
+		lines[key] = append(lines[key], query.Line)
 	}
 
-	// Report if we find multiple functions that might be doing similar DOM queries
-	if len(domQueryFunctions) > pa.config.DOMAccessThreshold {
+	for _, key := range sortedKeys(lines) {
+		queryLines := lines[key]
+		if len(queryLines) <= pa.config.DOMAccessThreshold {
+			continue
+		}
+
+		lineList := make([]string, len(queryLines))
+		for i, line := range queryLines {
+			lineList[i] = fmt.Sprintf("%d", line)
+		}
+
 		antiPattern := AntiPattern{
 			Type:        "repeated_dom_queries",
-			Description: fmt.Sprintf("Multiple DOM query functions detected (%d functions)", len(domQueryFunctions)),
+			Description: fmt.Sprintf("%s is queried %d times", key, len(queryLines)),
 			Severity:    "medium",
 			FilePath:    result.FilePath,
-			Evidence:    fmt.Sprintf("Found %d functions with DOM query patterns", len(domQueryFunctions)),
+			StartLine:   queryLines[0],
+			EndLine:     queryLines[len(queryLines)-1],
+			Evidence:    fmt.Sprintf("%s called on lines %s", key, strings.Join(lineList, ", ")),
 			Impact: PerformanceImpact{
 				Score:         45,
 				Category:      "dom",
@@ -542,6 +584,35 @@ func (pa *PerformanceAnalyzer) detectRepeatedDOMQueriesAST(result *ast.ParseResu
 	}
 }
 
+// domContextPackages are imports that mark a file as browser UI code
+var domContextPackages = map[string]bool{
+	"react": true, "react-dom": true, "preact": true, "vue": true, "svelte": true,
+	"solid-js": true, "lit": true, "jquery": true,
+}
+
+// isDOMContext reports whether a file runs against the DOM: it is a JSX/TSX file, imports a
+// UI framework, or queries the browser's global document
+func isDOMContext(result *ast.ParseResult) bool {
+	switch strings.ToLower(path.Ext(result.FilePath)) {
+	case ".jsx", ".tsx":
+		return true
+	}
+
+	for _, imp := range result.Imports {
+		source := strings.ToLower(imp.Source)
+		if strings.HasPrefix(source, "@angular/") || domContextPackages[strings.SplitN(source, "/", 2)[0]] {
+			return true
+		}
+	}
+
+	for _, query := range result.DOMQueries {
+		if query.Receiver == "document" {
+			return true
+		}
+	}
+	return false
+}
+
 // detectStringInefficienciesAST identifies string operation inefficiencies using AST analysis
 func (pa *PerformanceAnalyzer) detectStringInefficienciesAST(result *ast.ParseResult, metrics *PerformanceMetrics) {
 	for _, function := range result.Functions {
@@ -571,29 +642,83 @@ func (pa *PerformanceAnalyzer) detectStringInefficienciesAST(result *ast.ParseRe
 	}
 }
 
-// detectBlockingOperationsAST identifies blocking operations using AST analysis
+// requestHandlerParameters are the leading parameter names of request and route handlers:
+// Node http, Express, Fastify and Next.js (req, res), Koa middleware (ctx, next) and
+// AWS Lambda (event, context)
+var requestHandlerParameters = [][2][]string{
+	{{"req", "request"}, {"res", "response", "reply"}},
+	{{"ctx", "context"}, {"next"}},
+	{{"event"}, {"context"}},
+}
+
+// detectBlockingOperationsAST reports synchronous Node APIs (readFileSync, execSync and the
+// other *Sync functions) called inside request handlers. A handler runs once per request,
+// so each synchronous call stalls the event loop for every client at once.
 func (pa *PerformanceAnalyzer) detectBlockingOperationsAST(result *ast.ParseResult, metrics *PerformanceMetrics) {
+	reported := make(map[int]map[string]bool) // line -> callees, for calls inside nested handlers
 	for _, function := range result.Functions {
-		// Check for functions with blocking patterns but are not async
-		if pa.hasBlockingPattern(function.Name) && !function.IsAsync {
-			antiPattern := AntiPattern{
+		if !isRequestHandler(function) {
+			continue
+		}
+
+		name := function.Name
+		if name == "" {
+			name = "anonymous handler"
+		}
+		for _, call := range result.Calls {
+			if call.Line < function.StartLine || call.Line > function.EndLine || !isSyncCall(call.Callee) {
+				continue
+			}
+			if reported[call.Line][call.Callee] {
+				continue
+			}
+			if reported[call.Line] == nil {
+				reported[call.Line] = make(map[string]bool)
+			}
+			reported[call.Line][call.Callee] = true
+
+			callee := call.Callee
+			if call.Receiver != "" {
+				callee = call.Receiver + "." + call.Callee
+			}
+			metrics.AntiPatterns = append(metrics.AntiPatterns, AntiPattern{
 				Type:        "blocking_operation",
-				Description: fmt.Sprintf("Potential blocking operation in non-async function '%s'", function.Name),
+				Description: fmt.Sprintf("Synchronous %s in request handler '%s'", callee, name),
 				Severity:    "high",
 				FilePath:    result.FilePath,
-				StartLine:   function.StartLine,
-				EndLine:     function.EndLine,
-				Evidence:    fmt.Sprintf("Non-async function %s suggests blocking operations", function.Name),
+				StartLine:   call.Line,
+				EndLine:     call.Line,
+				Evidence:    fmt.Sprintf("%s() at line %d blocks the event loop on every request to the handler at line %d", callee, call.Line, function.StartLine),
 				Impact: PerformanceImpact{
 					Score:         70,
 					Category:      "blocking",
-					Description:   "Blocking operations in main thread cause UI freezes",
-					AffectedAreas: []string{"ui_responsiveness", "user_experience"},
+					Description:   "Synchronous calls in a request handler stall the event loop, so every concurrent request waits",
+					AffectedAreas: []string{"throughput", "latency"},
 				},
-			}
-			metrics.AntiPatterns = append(metrics.AntiPatterns, antiPattern)
+			})
+		}
+	}
+}
+
+// isRequestHandler reports whether function's leading parameters follow a request handler
+// signature, see requestHandlerParameters
+func isRequestHandler(function ast.FunctionInfo) bool {
+	if len(function.Parameters) < 2 {
+		return false
+	}
+	first, second := function.Parameters[0].Name, function.Parameters[1].Name
+	for _, signature := range requestHandlerParameters {
+		if contains(signature[0], first) && contains(signature[1], second) {
+			return true
 		}
 	}
+	return false
+}
+
+// isSyncCall reports whether callee follows Node's naming for blocking variants of async
+// APIs, such as readFileSync or execSync
+func isSyncCall(callee string) bool {
+	return len(callee) > len("Sync") && strings.HasSuffix(callee, "Sync")
 }
 
 // Helper functions for severity calculation
@@ -771,43 +896,26 @@ func (pa *PerformanceAnalyzer) analyzeBundleSize(parseResults []*ast.ParseResult
 	}
 
 	totalImports := 0
-	heavyLibraries := map[string]int{
-		"lodash":      70,
-		"moment":      67,
-		"jquery":      85,
-		"rxjs":        45,
-		"three":       600,
-		"d3":          250,
-		"chart.js":    60,
-		"bootstrap":   150,
-		"material-ui": 340,
-		"antd":        2000,
-		"react":       45,
-		"vue":         35,
-		"angular":     130,
-	}
+	sizes := pa.dependencySizes()
 
 	for _, result := range parseResults {
 		totalImports += len(result.Imports)
 
 		for _, imp := range result.Imports {
-			sourceLower := strings.ToLower(imp.Source)
-
-			// Check for heavy libraries
-			for lib, sizeKB := range heavyLibraries {
-				if strings.Contains(sourceLower, lib) {
-					heavyDep := HeavyDependency{
-						Name:            lib,
-						Source:          imp.Source,
-						FilePath:        result.FilePath,
-						EstimatedSizeKB: sizeKB,
-						ImportType:      imp.ImportType,
-						Usage:           pa.analyzeImportUsage(imp),
-						Alternatives:    pa.suggestAlternatives(lib),
-					}
-					bundleAnalysis.HeavyDependencies = append(bundleAnalysis.HeavyDependencies, heavyDep)
-					bundleAnalysis.EstimatedSizeKB += sizeKB
+			// Check for heavy libraries; others are covered by the per-import estimate below
+			if lib, sizeKB, ok := sizes.Lookup(imp.Source, pa.config.DependencyVersions); ok {
+				heavyDep := HeavyDependency{
+					Name:            lib,
+					Source:          imp.Source,
+					FilePath:        result.FilePath,
+					EstimatedSizeKB: sizeKB,
+					Version:         pa.config.DependencyVersions[lib],
+					ImportType:      imp.ImportType,
+					Usage:           pa.analyzeImportUsage(imp),
+					Alternatives:    pa.suggestAlternatives(lib),
 				}
+				bundleAnalysis.HeavyDependencies = append(bundleAnalysis.HeavyDependencies, heavyDep)
+				bundleAnalysis.EstimatedSizeKB += sizeKB
 			}
 
 			// Check for tree-shaking issues
@@ -827,6 +935,8 @@ func (pa *PerformanceAnalyzer) analyzeBundleSize(parseResults []*ast.ParseResult
 	// Estimate base bundle size from total imports
 	bundleAnalysis.EstimatedSizeKB += totalImports * 2 // Average 2KB per import
 
+	bundleAnalysis.TreeShakeability = analyzeTreeShakeability(parseResults)
+
 	// Generate optimization tips
 	bundleAnalysis.OptimizationTips = pa.generateBundleOptimizationTips(bundleAnalysis)
 
@@ -853,21 +963,18 @@ func (pa *PerformanceAnalyzer) analyzeImportUsage(imp ast.ImportInfo) string {
 
 // suggestAlternatives suggests lighter alternatives for heavy libraries
 func (pa *PerformanceAnalyzer) suggestAlternatives(library string) []string {
-	alternatives := map[string][]string{
-		"lodash":      {"ramda (functional)", "native ES6+ methods", "individual lodash functions"},
-		"moment":      {"date-fns", "dayjs", "luxon"},
-		"jquery":      {"native DOM APIs", "vanilla JS", "micro-libraries"},
-		"three":       {"babylon.js (for specific use cases)", "custom WebGL"},
-		"d3":          {"chart.js (for charts)", "native SVG", "lighter charting libs"},
-		"bootstrap":   {"tailwindcss", "bulma", "custom CSS"},
-		"material-ui": {"chakra-ui", "mantine", "custom components"},
-		"antd":        {"chakra-ui", "mantine", "headless UI"},
+	if size, exists := pa.dependencySizes().Libraries[library]; exists && len(size.Alternatives) > 0 {
+		return size.Alternatives
 	}
+	return []string{"Consider lighter alternatives", "Implement needed functionality manually"}
+}
 
-	if alts, exists := alternatives[library]; exists {
-		return alts
+// dependencySizes returns the configured size table or the built-in one
+func (pa *PerformanceAnalyzer) dependencySizes() *DependencySizes {
+	if pa.config.DependencySizes != nil {
+		return pa.config.DependencySizes
 	}
-	return []string{"Consider lighter alternatives", "Implement needed functionality manually"}
+	return DefaultDependencySizes()
 }
 
 // isTreeShakingLibrary checks if a library supports tree-shaking
@@ -893,9 +1000,9 @@ func (pa *PerformanceAnalyzer) estimateTreeShakingSavings(source string) int {
 	}
 
 	sourceLower := strings.ToLower(source)
-	for lib, saving := range savings {
+	for _, lib := range sortedKeys(savings) {
 		if strings.Contains(sourceLower, lib) {
-			return saving
+			return savings[lib]
 		}
 	}
 	return 10 // Default savings estimate
@@ -918,6 +1025,11 @@ func (pa *PerformanceAnalyzer) generateBundleOptimizationTips(analysis *BundleAn
 		tips = append(tips, "Enable tree-shaking by using named imports instead of default imports")
 	}
 
+	if analysis.TreeShakeability != nil && analysis.TreeShakeability.BlockingModules > 0 {
+		tips = append(tips, fmt.Sprintf("%d modules default-export an object or class; switch them to named exports so consumers can tree-shake them",
+			analysis.TreeShakeability.BlockingModules))
+	}
+
 	// Add general tips
 	tips = append(tips,
 		"Use dynamic imports for code splitting",
@@ -1236,20 +1348,28 @@ func (pa *PerformanceAnalyzer) getImplementationFromAntiPattern(antiPattern Anti
 	return "Implement best practices to resolve the identified performance issue"
 }
 
-// calculatePerformanceScore calculates overall performance score
+// calculatePerformanceScore calculates overall performance score.
+// Findings are penalized per severity bucket with diminishing returns (see severityBucketPenalty),
+// so a long tail of minor issues lowers the score without outweighing a few severe ones.
 func (pa *PerformanceAnalyzer) calculatePerformanceScore(metrics *PerformanceMetrics) {
 	baseScore := 100.0
 
 	// Deduct points for anti-patterns
+	antiPatternCounts := make(map[string]int)
 	for _, antiPattern := range metrics.AntiPatterns {
-		penalty := pa.getAntiPatternPenalty(antiPattern.Severity)
-		baseScore -= penalty
+		antiPatternCounts[antiPattern.Severity]++
+	}
+	for _, severity := range sortedKeys(antiPatternCounts) {
+		baseScore -= severityBucketPenalty(pa.getAntiPatternPenalty(severity), antiPatternCounts[severity])
 	}
 
 	// Deduct points for bottlenecks
+	bottleneckCounts := make(map[string]int)
 	for _, bottleneck := range metrics.Bottlenecks {
-		penalty := pa.getBottleneckPenalty(bottleneck.Severity)
-		baseScore -= penalty
+		bottleneckCounts[bottleneck.Severity]++
+	}
+	for _, severity := range sortedKeys(bottleneckCounts) {
+		baseScore -= severityBucketPenalty(pa.getBottleneckPenalty(severity), bottleneckCounts[severity])
 	}
 
 	// Deduct points for bundle size if analysis is available
@@ -1273,6 +1393,21 @@ func (pa *PerformanceAnalyzer) calculatePerformanceScore(metrics *PerformanceMet
 	metrics.PerformanceGrade = pa.getPerformanceGrade(baseScore)
 }
 
+// severityPenaltyGrowth is the extra share of the base penalty charged each time a
+// severity bucket's finding count doubles
+const severityPenaltyGrowth = 0.5
+
+// severityBucketPenalty returns the penalty for count findings that each carry basePenalty.
+// The first finding costs basePenalty and every doubling adds another half of it:
+// 1 -> 1x, 2 -> 1.5x, 4 -> 2x, 8 -> 2.5x. Eight mediums (5 each) therefore cost 12.5,
+// less than a single critical anti-pattern (15).
+func severityBucketPenalty(basePenalty float64, count int) float64 {
+	if count <= 0 {
+		return 0
+	}
+	return basePenalty * (1 + severityPenaltyGrowth*math.Log2(float64(count)))
+}
+
 // getAntiPatternPenalty returns penalty score for anti-pattern severity
 func (pa *PerformanceAnalyzer) getAntiPatternPenalty(severity string) float64 {
 	penalties := map[string]float64{
@@ -1280,6 +1415,7 @@ func (pa *PerformanceAnalyzer) getAntiPatternPenalty(severity string) float64 {
 		"high":     10.0,
 		"medium":   5.0,
 		"low":      2.0,
+		"info":     0.0,
 	}
 
 	if penalty, exists := penalties[severity]; exists {
@@ -1303,20 +1439,10 @@ func (pa *PerformanceAnalyzer) getBottleneckPenalty(severity string) float64 {
 	return 4.0 // Default penalty
 }
 
-// getPerformanceGrade converts numeric score to letter grade
+// getPerformanceGrade converts numeric score to letter grade; the quality report regrades it
+// on the configured GradeScale
 func (pa *PerformanceAnalyzer) getPerformanceGrade(score float64) string {
-	switch {
-	case score >= 90:
-		return "A"
-	case score >= 80:
-		return "B"
-	case score >= 70:
-		return "C"
-	case score >= 60:
-		return "D"
-	default:
-		return "F"
-	}
+	return Grade(score, GradeLetter, QualityThresholds{})
 }
 
 // generateSummaryAndRecommendations generates summary and recommendations
@@ -1354,13 +1480,14 @@ func (pa *PerformanceAnalyzer) generateSummaryAndRecommendations(metrics *Perfor
 	}
 
 	// Convert map to slice and add recommendations
-	for filePath, analysis := range fileAnalysisMap {
+	for _, filePath := range sortedKeys(fileAnalysisMap) {
+		analysis := fileAnalysisMap[filePath]
 		analysis.Recommendations = pa.generateFileRecommendations(filePath, analysis.IssueCount, analysis.WorstSeverity)
 		metrics.FileAnalysis = append(metrics.FileAnalysis, *analysis)
 	}
 
 	// Sort file analysis by issue count
-	sort.Slice(metrics.FileAnalysis, func(i, j int) bool {
+	sort.SliceStable(metrics.FileAnalysis, func(i, j int) bool {
 		if metrics.FileAnalysis[i].IssueCount != metrics.FileAnalysis[j].IssueCount {
 			return metrics.FileAnalysis[i].IssueCount > metrics.FileAnalysis[j].IssueCount
 		}
@@ -1487,6 +1614,20 @@ func (pa *PerformanceAnalyzer) generatePerformanceRecommendations(metrics *Perfo
 		})
 	}
 
+	// Project-wide tree-shaking
+	if shake := metrics.BundleAnalysis; shake != nil && shake.TreeShakeability != nil && len(shake.TreeShakeability.Offenders) > 0 {
+		top := shake.TreeShakeability.Offenders[0]
+		recommendations = append(recommendations, PerformanceRecommendation{
+			Priority:       "medium",
+			Category:       "bundle_optimization",
+			Title:          "Replace Blocking Default Exports",
+			Description:    fmt.Sprintf("Tree-shaking score %.1f: %d modules default-export an object or class, led by %s (%d consumers)", shake.TreeShakeability.Score, shake.TreeShakeability.BlockingModules, top.FilePath, top.Consumers),
+			Action:         "Convert the listed offenders to named exports and update their consumers to named imports",
+			ExpectedImpact: "Medium",
+			TimeFrame:      "1 week",
+		})
+	}
+
 	// React optimization
 	if metrics.ReactAnalysis != nil && len(metrics.ReactAnalysis.ComponentIssues) > 0 {
 		recommendations = append(recommendations, PerformanceRecommendation{
@@ -41,8 +41,19 @@ func (pa *PerformanceAnalyzer) containsArrayIteration(functionName string) bool
 	return false
 }
 
-// isLikelyInLoop checks if a function is likely to be called within a loop
-func (pa *PerformanceAnalyzer) isLikelyInLoop(function ast.FunctionInfo) bool {
+// isLikelyInLoop reports whether a function runs once per iteration of a loop, with the
+// evidence for it. Callers in the same file settle it through the call graph; a function
+// no code in its file calls is judged by its signature, since its callers live elsewhere.
+func (pa *PerformanceAnalyzer) isLikelyInLoop(function ast.FunctionInfo, graph *fileCallGraph) (string, bool) {
+	if call, ok := graph.loopCalls[function.Name]; ok {
+		return call.evidence(), true
+	}
+	if graph.callSites[function.Name] > 0 {
+		return "", false
+	}
+
+	const guessed = "likely called in loops, judging by its signature"
+
 	// Functions with single parameters are more likely to be called in loops
 	if len(function.Parameters) == 1 {
 		param := function.Parameters[0]
@@ -50,16 +61,16 @@ func (pa *PerformanceAnalyzer) isLikelyInLoop(function ast.FunctionInfo) bool {
 		paramLower := strings.ToLower(param.Name)
 		if strings.Contains(paramLower, "id") || strings.Contains(paramLower, "key") ||
 			strings.Contains(paramLower, "item") || strings.Contains(paramLower, "element") {
-			return true
+			return guessed, true
 		}
 	}
 
 	// Functions with "ById" pattern are typically called in loops
 	if strings.Contains(strings.ToLower(function.Name), "byid") {
-		return true
+		return guessed, true
 	}
 
-	return false
+	return "", false
 }
 
 // hasNestedIterationPattern checks if function name suggests nested iterations
@@ -121,52 +132,23 @@ func (pa *PerformanceAnalyzer) isMemoryIntensiveFunction(function ast.FunctionIn
 	return false
 }
 
-// hasBlockingPattern checks if function name suggests blocking operations
-func (pa *PerformanceAnalyzer) hasBlockingPattern(functionName string) bool {
-	blockingPatterns := []string{
-		"sync", "block", "wait", "sleep", "lock", "mutex", "semaphore",
-		"synchronous", "sequential", "blocking", "freeze",
-	}
-
-	nameLower := strings.ToLower(functionName)
-	for _, pattern := range blockingPatterns {
-		if strings.Contains(nameLower, pattern) {
-			return true
-		}
-	}
-	return false
-}
-
 // analyzeImportPerformanceImpact analyzes imports for performance impact
 func (pa *PerformanceAnalyzer) analyzeImportPerformanceImpact(imports []ast.ImportInfo) []OptimizationOpportunity {
 	var opportunities []OptimizationOpportunity
 
-	heavyLibraries := map[string]struct{}{
-		"lodash":    {},
-		"moment":    {},
-		"jquery":    {},
-		"rxjs":      {},
-		"three":     {},
-		"d3":        {},
-		"chartjs":   {},
-		"bootstrap": {},
-	}
-
+	sizes := pa.dependencySizes()
 	for _, imp := range imports {
-		source := strings.ToLower(imp.Source)
-		for heavyLib := range heavyLibraries {
-			if strings.Contains(source, heavyLib) {
-				opportunities = append(opportunities, OptimizationOpportunity{
-					Type:           "bundle_optimization",
-					Priority:       "medium",
-					Description:    "Heavy library detected",
-					Impact:         "Bundle size reduction",
-					Effort:         "medium",
-					ROI:            75.0,
-					Implementation: "Consider lighter alternatives or tree shaking",
-					Evidence:       "Import: " + imp.Source,
-				})
-			}
+		if _, _, ok := sizes.Lookup(imp.Source, pa.config.DependencyVersions); ok {
+			opportunities = append(opportunities, OptimizationOpportunity{
+				Type:           "bundle_optimization",
+				Priority:       "medium",
+				Description:    "Heavy library detected",
+				Impact:         "Bundle size reduction",
+				Effort:         "medium",
+				ROI:            75.0,
+				Implementation: "Consider lighter alternatives or tree shaking",
+				Evidence:       "Import: " + imp.Source,
+			})
 		}
 	}
 
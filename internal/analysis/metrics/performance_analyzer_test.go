@@ -15,7 +15,7 @@ func TestNewPerformanceAnalyzer(t *testing.T) {
 	assert.NotNil(t, analyzer)
 	assert.Equal(t, 2, analyzer.config.NestedLoopThreshold)
 	assert.Equal(t, 3, analyzer.config.QueryPatternThreshold)
-	assert.Equal(t, 5, analyzer.config.DOMAccessThreshold)
+	assert.Equal(t, 1, analyzer.config.DOMAccessThreshold)
 	assert.Equal(t, 500, analyzer.config.BundleSizeThresholdKB)
 	assert.Equal(t, 15, analyzer.config.ComponentComplexityMax)
 	assert.Equal(t, 0.35, analyzer.config.AlgorithmicWeight)
@@ -61,6 +61,61 @@ func TestAnalyzePerformance_EmptyInput(t *testing.T) {
 	assert.Equal(t, 0, len(metrics.OptimizationOpportunities))
 }
 
+func TestSeverityBucketPenalty_DiminishingReturns(t *testing.T) {
+	assert.Equal(t, 0.0, severityBucketPenalty(5.0, 0))
+	assert.InDelta(t, 5.0, severityBucketPenalty(5.0, 1), 0.001)
+	assert.InDelta(t, 7.5, severityBucketPenalty(5.0, 2), 0.001)
+	assert.InDelta(t, 10.0, severityBucketPenalty(5.0, 4), 0.001)
+	assert.InDelta(t, 12.5, severityBucketPenalty(5.0, 8), 0.001)
+
+	// Each additional finding costs less than the one before it
+	previousStep := severityBucketPenalty(5.0, 1)
+	for count := 2; count <= 64; count++ {
+		step := severityBucketPenalty(5.0, count) - severityBucketPenalty(5.0, count-1)
+		assert.Greater(t, step, 0.0)
+		assert.Less(t, step, previousStep)
+		previousStep = step
+	}
+}
+
+func antiPatternsWithSeverity(severity string, count int) []AntiPattern {
+	antiPatterns := make([]AntiPattern, count)
+	for i := range antiPatterns {
+		antiPatterns[i] = AntiPattern{Severity: severity}
+	}
+	return antiPatterns
+}
+
+func TestCalculatePerformanceScore_SeverityWeighting(t *testing.T) {
+	analyzer := NewPerformanceAnalyzer()
+
+	score := func(antiPatterns ...[]AntiPattern) float64 {
+		metrics := &PerformanceMetrics{}
+		for _, group := range antiPatterns {
+			metrics.AntiPatterns = append(metrics.AntiPatterns, group...)
+		}
+		analyzer.calculatePerformanceScore(metrics)
+		return metrics.OverallScore
+	}
+
+	oneCritical := score(antiPatternsWithSeverity("critical", 1))
+	eightMedium := score(antiPatternsWithSeverity("medium", 8))
+	fiftyLow := score(antiPatternsWithSeverity("low", 50))
+
+	assert.InDelta(t, 85.0, oneCritical, 0.001)
+	assert.Greater(t, eightMedium, oneCritical, "eight medium findings should cost less than one critical")
+	assert.Greater(t, fiftyLow, 80.0, "a long tail of low findings should not tank the score")
+
+	// Severity buckets accumulate independently
+	mixed := score(antiPatternsWithSeverity("critical", 1), antiPatternsWithSeverity("medium", 8))
+	assert.InDelta(t, 100.0-15.0-12.5, mixed, 0.001)
+
+	// Many severe findings still drive the score down, but never below zero
+	assert.Less(t, score(antiPatternsWithSeverity("critical", 20)), 60.0)
+	assert.Equal(t, 0.0, score(antiPatternsWithSeverity("critical", 1), antiPatternsWithSeverity("high", 1<<12),
+		antiPatternsWithSeverity("medium", 1<<12), antiPatternsWithSeverity("low", 1<<12)))
+}
+
 func TestAnalyzePerformance_ValidInput(t *testing.T) {
 	analyzer := NewPerformanceAnalyzer()
 
@@ -145,6 +200,43 @@ func TestDetectSynchronousLoopsAST(t *testing.T) {
 	assert.Contains(t, antiPattern.Description, "processItems")
 }
 
+func TestDetectBlockingOperationsAST(t *testing.T) {
+	analyzer := NewPerformanceAnalyzer()
+	metrics := &PerformanceMetrics{
+		AntiPatterns: []AntiPattern{},
+	}
+
+	handlerParams := []ast.ParameterInfo{{Name: "req"}, {Name: "res"}}
+	result := &ast.ParseResult{
+		FilePath: "server.js",
+		Functions: []ast.FunctionInfo{
+			{StartLine: 1, EndLine: 10, Parameters: handlerParams},
+			{Name: "render", StartLine: 4, EndLine: 6, Parameters: []ast.ParameterInfo{{Name: "data"}}}, // callback inside the handler
+			{Name: "buildAssets", StartLine: 20, EndLine: 30, Parameters: []ast.ParameterInfo{{Name: "dir"}}},
+			{Name: "middleware", StartLine: 40, EndLine: 45, Parameters: []ast.ParameterInfo{{Name: "ctx"}, {Name: "next"}}},
+		},
+		Calls: []ast.CallInfo{
+			{Callee: "readFileSync", Receiver: "fs", Line: 2},
+			{Callee: "send", Receiver: "res", Line: 3},
+			{Callee: "execSync", Line: 5},
+			{Callee: "readFileSync", Receiver: "fs", Line: 22}, // build script, not a handler
+			{Callee: "existsSync", Receiver: "fs", Line: 42},
+		},
+	}
+
+	analyzer.detectBlockingOperationsAST(result, metrics)
+
+	require.Len(t, metrics.AntiPatterns, 3)
+	first := metrics.AntiPatterns[0]
+	assert.Equal(t, "blocking_operation", first.Type)
+	assert.Equal(t, "high", first.Severity)
+	assert.Equal(t, 2, first.StartLine)
+	assert.Equal(t, 2, first.EndLine)
+	assert.Equal(t, "Synchronous fs.readFileSync in request handler 'anonymous handler'", first.Description)
+	assert.Equal(t, 5, metrics.AntiPatterns[1].StartLine, "calls in callbacks inside the handler count")
+	assert.Equal(t, "Synchronous fs.existsSync in request handler 'middleware'", metrics.AntiPatterns[2].Description)
+}
+
 func TestDetectMemoryLeaksAST(t *testing.T) {
 	analyzer := NewPerformanceAnalyzer()
 	metrics := &PerformanceMetrics{
@@ -487,3 +579,63 @@ func createMockComplexityMetricsForPerformance() *ComplexityMetrics {
 		ClassMetrics: []ClassComplexity{},
 	}
 }
+
+func TestDetectRepeatedDOMQueriesAST(t *testing.T) {
+	analyzer := NewPerformanceAnalyzer()
+	repeatedNav := []ast.DOMQueryInfo{
+		{Method: "querySelector", Receiver: "document", Selector: "#nav", Line: 4},
+		{Method: "querySelector", Receiver: "document", Selector: "#nav", Line: 9},
+		{Method: "getElementById", Receiver: "document", Selector: "footer", Line: 12},
+	}
+
+	t.Run("repeated selector in browser code", func(t *testing.T) {
+		metrics := &PerformanceMetrics{AntiPatterns: []AntiPattern{}}
+		analyzer.detectRepeatedDOMQueriesAST(&ast.ParseResult{FilePath: "src/menu.js", DOMQueries: repeatedNav}, metrics)
+
+		require.Len(t, metrics.AntiPatterns, 1)
+		finding := metrics.AntiPatterns[0]
+		assert.Equal(t, "repeated_dom_queries", finding.Type)
+		assert.Equal(t, "querySelector(#nav) is queried 2 times", finding.Description)
+		assert.Equal(t, 4, finding.StartLine)
+		assert.Equal(t, 9, finding.EndLine)
+		assert.Equal(t, "querySelector(#nav) called on lines 4, 9", finding.Evidence)
+	})
+
+	t.Run("query-like function names alone are not flagged", func(t *testing.T) {
+		result := &ast.ParseResult{
+			FilePath: "src/repository.js",
+			Functions: []ast.FunctionInfo{
+				{Name: "querySql"}, {Name: "selectUser"}, {Name: "selectOrders"},
+				{Name: "findElement"}, {Name: "domainQuery"}, {Name: "queryBuilder"}, {Name: "selectAll"},
+			},
+		}
+		metrics := &PerformanceMetrics{AntiPatterns: []AntiPattern{}}
+		analyzer.detectRepeatedDOMQueriesAST(result, metrics)
+		assert.Empty(t, metrics.AntiPatterns)
+	})
+
+	t.Run("server code outside a DOM context", func(t *testing.T) {
+		result := &ast.ParseResult{
+			FilePath: "server/scrape.js",
+			Imports:  []ast.ImportInfo{{Source: "jsdom"}},
+			DOMQueries: []ast.DOMQueryInfo{
+				{Method: "querySelector", Receiver: "dom", Selector: "h1", Line: 3},
+				{Method: "querySelector", Receiver: "dom", Selector: "h1", Line: 5},
+			},
+		}
+		metrics := &PerformanceMetrics{AntiPatterns: []AntiPattern{}}
+		analyzer.detectRepeatedDOMQueriesAST(result, metrics)
+		assert.Empty(t, metrics.AntiPatterns)
+
+		result.FilePath = "src/Header.tsx"
+		analyzer.detectRepeatedDOMQueriesAST(result, metrics)
+		assert.Len(t, metrics.AntiPatterns, 1, "TSX files are UI code")
+	})
+
+	t.Run("configurable threshold", func(t *testing.T) {
+		lenient := NewPerformanceAnalyzerWithConfig(PerformanceConfig{DOMAccessThreshold: 2})
+		metrics := &PerformanceMetrics{AntiPatterns: []AntiPattern{}}
+		lenient.detectRepeatedDOMQueriesAST(&ast.ParseResult{FilePath: "src/menu.js", DOMQueries: repeatedNav}, metrics)
+		assert.Empty(t, metrics.AntiPatterns)
+	})
+}
@@ -0,0 +1,242 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectCommands tells a newcomer how to build, test and run the project: the package.json
+// scripts sorted by purpose, invoked with the package manager the lockfile names, and the
+// commands the CI configuration runs
+type ProjectCommands struct {
+	PackageManager string           `json:"package_manager,omitempty"` // npm, yarn, pnpm or bun
+	Install        string           `json:"install,omitempty"`         // e.g. "npm ci"
+	Build          []ProjectCommand `json:"build,omitempty"`
+	Test           []ProjectCommand `json:"test,omitempty"`
+	Run            []ProjectCommand `json:"run,omitempty"`  // start, dev and serve scripts
+	Lint           []ProjectCommand `json:"lint,omitempty"` // lint, format and type-check scripts
+	Other          []ProjectCommand `json:"other,omitempty"`
+	CI             []CIConfig       `json:"ci,omitempty"`
+}
+
+// ProjectCommand is one package.json script
+type ProjectCommand struct {
+	Name    string `json:"name"`
+	Command string `json:"command"` // how to invoke it, e.g. "npm run build"
+	Script  string `json:"script"`  // what it runs
+}
+
+// CIConfig is one CI configuration file and the shell commands it runs, in file order
+type CIConfig struct {
+	Provider string   `json:"provider"` // github_actions, circleci or gitlab_ci
+	Path     string   `json:"path"`
+	Commands []string `json:"commands,omitempty"`
+	Error    string   `json:"error,omitempty"` // why the file could not be parsed; it lists no commands
+}
+
+// CI providers detected by CIProviderForPath
+const (
+	CIGitHubActions = "github_actions"
+	CICircleCI      = "circleci"
+	CIGitLab        = "gitlab_ci"
+)
+
+// packageManagerLockfiles name the package manager each lockfile belongs to, in the order
+// they are checked
+var packageManagerLockfiles = []struct{ lockfile, manager string }{
+	{"pnpm-lock.yaml", "pnpm"},
+	{"yarn.lock", "yarn"},
+	{"bun.lockb", "bun"},
+	{"package-lock.json", "npm"},
+}
+
+// scriptPurposes sort scripts by the first segment of their name ("test:unit" is a test)
+var scriptPurposes = map[string]string{
+	"build": "build", "compile": "build", "bundle": "build", "dist": "build", "prepare": "build",
+	"test": "test", "tests": "test", "e2e": "test", "coverage": "test", "spec": "test", "cypress": "test", "playwright": "test",
+	"start": "run", "dev": "run", "serve": "run", "preview": "run", "watch": "run", "develop": "run",
+	"lint": "lint", "format": "lint", "fmt": "lint", "prettier": "lint", "eslint": "lint", "typecheck": "lint", "type-check": "lint", "check": "lint",
+}
+
+// CIProviderForPath returns the CI provider whose configuration lives at the slash-separated
+// path relative to the repository root, or "" when it is not a CI configuration file
+func CIProviderForPath(relPath string) string {
+	switch {
+	case path.Dir(relPath) == ".github/workflows" && (path.Ext(relPath) == ".yml" || path.Ext(relPath) == ".yaml"):
+		return CIGitHubActions
+	case relPath == ".circleci/config.yml" || relPath == ".circleci/config.yaml":
+		return CICircleCI
+	case relPath == ".gitlab-ci.yml":
+		return CIGitLab
+	}
+	return ""
+}
+
+// BuildProjectCommands sorts the scripts of packageJSON (nil without one) by purpose, with
+// the invocation for the package manager of the lockfiles present in the root, and lists
+// the run commands of the CI configuration files, keyed by path. A CI file that does not
+// parse is listed with its error. It returns nil when there is neither a script nor a CI
+// configuration.
+func BuildProjectCommands(packageJSON []byte, lockfiles []string, ciConfigs map[string][]byte) (*ProjectCommands, error) {
+	commands := &ProjectCommands{}
+
+	if packageJSON != nil {
+		var manifest struct {
+			Scripts        map[string]string `json:"scripts"`
+			PackageManager string            `json:"packageManager"`
+		}
+		if err := json.Unmarshal(packageJSON, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse package.json: %w", err)
+		}
+
+		commands.PackageManager, commands.Install = packageManager(manifest.PackageManager, lockfiles)
+		for _, name := range sortedKeys(manifest.Scripts) {
+			command := ProjectCommand{Name: name, Command: scriptInvocation(commands.PackageManager, name), Script: manifest.Scripts[name]}
+			switch scriptPurposes[strings.ToLower(strings.SplitN(name, ":", 2)[0])] {
+			case "build":
+				commands.Build = append(commands.Build, command)
+			case "test":
+				commands.Test = append(commands.Test, command)
+			case "run":
+				commands.Run = append(commands.Run, command)
+			case "lint":
+				commands.Lint = append(commands.Lint, command)
+			default:
+				commands.Other = append(commands.Other, command)
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(ciConfigs))
+	for configPath := range ciConfigs {
+		paths = append(paths, configPath)
+	}
+	sort.Strings(paths)
+	for _, configPath := range paths {
+		provider := CIProviderForPath(configPath)
+		if provider == "" {
+			continue
+		}
+		ci := CIConfig{Provider: provider, Path: configPath}
+		// One malformed workflow should not hide the scripts and the other CI files
+		run, err := ciCommands(provider, ciConfigs[configPath])
+		if err != nil {
+			ci.Error = fmt.Sprintf("failed to parse %s: %v", configPath, err)
+		}
+		ci.Commands = run
+		commands.CI = append(commands.CI, ci)
+	}
+
+	if commands.PackageManager == "" && len(commands.CI) == 0 {
+		return nil, nil
+	}
+	return commands, nil
+}
+
+// packageManager picks the package manager from the packageManager field of package.json,
+// such as "pnpm@8.6.0", or else from the lockfiles, and returns it with its install command
+func packageManager(declared string, lockfiles []string) (manager, install string) {
+	present := make(map[string]bool, len(lockfiles))
+	for _, lockfile := range lockfiles {
+		present[lockfile] = true
+	}
+
+	manager = strings.SplitN(declared, "@", 2)[0]
+	if manager == "" {
+		manager = "npm"
+		for _, candidate := range packageManagerLockfiles {
+			if present[candidate.lockfile] {
+				manager = candidate.manager
+				break
+			}
+		}
+	}
+
+	switch manager {
+	case "npm":
+		if present["package-lock.json"] {
+			return manager, "npm ci"
+		}
+		return manager, "npm install"
+	case "yarn":
+		return manager, "yarn install"
+	default:
+		return manager, manager + " install"
+	}
+}
+
+// scriptInvocation returns the command running script name with manager
+func scriptInvocation(manager, name string) string {
+	if manager == "npm" && name != "test" && name != "start" {
+		return "npm run " + name
+	}
+	if manager == "npm" {
+		return "npm " + name
+	}
+	return manager + " run " + name
+}
+
+// ciCommands extracts the shell commands of one CI configuration: the run steps of GitHub
+// Actions and CircleCI jobs and the script lines of GitLab CI jobs, multi-line commands split
+// into lines
+func ciCommands(provider string, data []byte) ([]string, error) {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	var commands []string
+	add := func(value interface{}) {
+		if text, ok := value.(string); ok {
+			for _, line := range strings.Split(text, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					commands = append(commands, line)
+				}
+			}
+		}
+	}
+
+	jobs := config
+	if provider != CIGitLab {
+		jobs, _ = config["jobs"].(map[string]interface{})
+	}
+	for _, name := range sortedKeys(jobs) {
+		job, ok := jobs[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if provider == CIGitLab {
+			for _, key := range []string{"before_script", "script"} {
+				switch script := job[key].(type) {
+				case []interface{}:
+					for _, line := range script {
+						add(line)
+					}
+				default:
+					add(script)
+				}
+			}
+			continue
+		}
+
+		steps, _ := job["steps"].([]interface{})
+		for _, step := range steps {
+			step, ok := step.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			switch run := step["run"].(type) {
+			case map[string]interface{}: // CircleCI's long form: run: {command: ...}
+				add(run["command"])
+			default:
+				add(run)
+			}
+		}
+	}
+	return commands, nil
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const workflowYAML = `name: CI
+on: [push]
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: npm ci
+      - run: |
+          npm run lint
+          npm test
+`
+
+func TestBuildProjectCommands(t *testing.T) {
+	packageJSON := []byte(`{"scripts": {
+		"build": "tsc -p .", "test": "jest", "test:e2e": "playwright test",
+		"start": "node dist/index.js", "dev": "vite", "lint": "eslint .", "release": "semantic-release"
+	}}`)
+	circleci := []byte("version: 2.1\njobs:\n  build:\n    steps:\n      - checkout\n      - run:\n          name: Build\n          command: yarn build\n")
+
+	commands, err := BuildProjectCommands(packageJSON, []string{"yarn.lock"}, map[string][]byte{
+		".github/workflows/ci.yml": []byte(workflowYAML),
+		".circleci/config.yml":     circleci,
+		".github/README.md":        []byte("not a workflow"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "yarn", commands.PackageManager)
+	assert.Equal(t, "yarn install", commands.Install)
+	assert.Equal(t, []ProjectCommand{{Name: "build", Command: "yarn run build", Script: "tsc -p ."}}, commands.Build)
+	assert.Equal(t, []ProjectCommand{
+		{Name: "test", Command: "yarn run test", Script: "jest"},
+		{Name: "test:e2e", Command: "yarn run test:e2e", Script: "playwright test"},
+	}, commands.Test)
+	assert.Equal(t, []ProjectCommand{
+		{Name: "dev", Command: "yarn run dev", Script: "vite"},
+		{Name: "start", Command: "yarn run start", Script: "node dist/index.js"},
+	}, commands.Run)
+	assert.Equal(t, []ProjectCommand{{Name: "lint", Command: "yarn run lint", Script: "eslint ."}}, commands.Lint)
+	assert.Equal(t, []ProjectCommand{{Name: "release", Command: "yarn run release", Script: "semantic-release"}}, commands.Other)
+	assert.Equal(t, []CIConfig{
+		{Provider: CICircleCI, Path: ".circleci/config.yml", Commands: []string{"yarn build"}},
+		{Provider: CIGitHubActions, Path: ".github/workflows/ci.yml", Commands: []string{"npm ci", "npm run lint", "npm test"}},
+	}, commands.CI)
+}
+
+func TestBuildProjectCommands_PackageManager(t *testing.T) {
+	scripts := []byte(`{"scripts": {"test": "jest", "build": "tsc"}}`)
+
+	commands, err := BuildProjectCommands(scripts, []string{"package-lock.json"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "npm ci", commands.Install)
+	assert.Equal(t, "npm test", commands.Test[0].Command)
+	assert.Equal(t, "npm run build", commands.Build[0].Command)
+
+	commands, err = BuildProjectCommands([]byte(`{"packageManager": "pnpm@8.6.0"}`), []string{"package-lock.json"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pnpm", commands.PackageManager, "the declared package manager wins over the lockfile")
+	assert.Equal(t, "pnpm install", commands.Install)
+}
+
+func TestBuildProjectCommands_GitLab(t *testing.T) {
+	gitlab := []byte("stages: [test]\nunit:\n  stage: test\n  before_script:\n    - npm ci\n  script:\n    - npm test\n")
+
+	commands, err := BuildProjectCommands(nil, nil, map[string][]byte{".gitlab-ci.yml": gitlab})
+	require.NoError(t, err)
+	assert.Empty(t, commands.PackageManager, "no package.json")
+	assert.Equal(t, []CIConfig{{Provider: CIGitLab, Path: ".gitlab-ci.yml", Commands: []string{"npm ci", "npm test"}}}, commands.CI)
+}
+
+func TestBuildProjectCommands_Nothing(t *testing.T) {
+	commands, err := BuildProjectCommands(nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, commands)
+
+	_, err = BuildProjectCommands([]byte("{"), nil, nil)
+	assert.ErrorContains(t, err, "failed to parse package.json")
+}
+
+func TestBuildProjectCommands_MalformedCIConfig(t *testing.T) {
+	commands, err := BuildProjectCommands([]byte(`{"scripts": {"build": "tsc"}}`), nil, map[string][]byte{
+		".github/workflows/broken.yml": []byte("jobs: [unclosed"),
+		".github/workflows/ci.yml":     []byte(workflowYAML),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, commands)
+
+	require.Len(t, commands.Build, 1, "the scripts are kept")
+	require.Len(t, commands.CI, 2)
+	assert.Equal(t, ".github/workflows/broken.yml", commands.CI[0].Path)
+	assert.Contains(t, commands.CI[0].Error, "failed to parse .github/workflows/broken.yml")
+	assert.Empty(t, commands.CI[0].Commands)
+	assert.Empty(t, commands.CI[1].Error)
+	assert.NotEmpty(t, commands.CI[1].Commands, "the other CI files are kept")
+}
+
+func TestWriteReportHTML_HowToRun(t *testing.T) {
+	commands, err := BuildProjectCommands([]byte(`{"scripts": {"build": "tsc"}}`), nil, map[string][]byte{".github/workflows/ci.yml": []byte(workflowYAML)})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReportHTML(&out, &QualityReport{ProjectName: "demo", HowToRun: commands}))
+	assert.Contains(t, out.String(), "<h2>How to Build, Test and Run</h2>")
+	assert.Contains(t, out.String(), "<code>npm install</code>")
+	assert.Contains(t, out.String(), "<tr><td>build</td><td><code>npm run build</code></td><td><code>tsc</code></td></tr>")
+	assert.Contains(t, out.String(), "npm run lint\nnpm test\n</pre>")
+}
@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// symbolVisibility records which named symbols of each file belong to the public API.
+// Exported functions and exported classes are public, as are the methods of exported classes.
+type symbolVisibility struct {
+	public      map[string]map[string]bool // file -> symbol name -> exported
+	publicNames map[string]bool            // public symbol names across all files
+	boundaries  map[string]bool            // files exporting anything: the public module boundaries
+}
+
+// newSymbolVisibility collects symbol visibility from parse results
+func newSymbolVisibility(parseResults []*ast.ParseResult) *symbolVisibility {
+	sv := &symbolVisibility{
+		public:      make(map[string]map[string]bool),
+		publicNames: make(map[string]bool),
+		boundaries:  make(map[string]bool),
+	}
+
+	mark := func(filePath, name string, exported bool) {
+		if name == "" {
+			return
+		}
+		if sv.public[filePath] == nil {
+			sv.public[filePath] = make(map[string]bool)
+		}
+		// A name that is public anywhere in the file stays public
+		sv.public[filePath][name] = sv.public[filePath][name] || exported
+		if exported {
+			sv.publicNames[name] = true
+			sv.boundaries[filePath] = true
+		}
+	}
+
+	for _, result := range parseResults {
+		if len(result.Exports) > 0 {
+			sv.boundaries[result.FilePath] = true
+		}
+		for _, function := range result.Functions {
+			mark(result.FilePath, function.Name, function.IsExported)
+		}
+		for _, class := range result.Classes {
+			mark(result.FilePath, class.Name, class.IsExported)
+			for _, method := range class.Methods {
+				mark(result.FilePath, method.Name, class.IsExported)
+			}
+		}
+	}
+
+	return sv
+}
+
+// isPrivate reports whether name is a known, non-exported symbol of filePath.
+// Unknown names (file-level entries, aggregates) are never treated as private.
+func (sv *symbolVisibility) isPrivate(filePath, name string) bool {
+	exported, known := sv.public[filePath][name]
+	return known && !exported
+}
+
+// The public* helpers return their input unchanged when sv is nil (public-only mode off).
+
+// publicComplexity returns a copy of complexity restricted to public functions and classes
+func (sv *symbolVisibility) publicComplexity(complexity *ComplexityMetrics) *ComplexityMetrics {
+	if sv == nil || complexity == nil {
+		return complexity
+	}
+
+	filtered := *complexity
+	filtered.FunctionMetrics = []FunctionComplexity{}
+	for _, function := range complexity.FunctionMetrics {
+		if !sv.isPrivate(function.FilePath, function.Name) {
+			filtered.FunctionMetrics = append(filtered.FunctionMetrics, function)
+		}
+	}
+
+	filtered.Accepted = nil
+	for _, function := range complexity.Accepted {
+		if !sv.isPrivate(function.FilePath, function.Name) {
+			filtered.Accepted = append(filtered.Accepted, function)
+		}
+	}
+
+	filtered.ClassMetrics = []ClassComplexity{}
+	for _, class := range complexity.ClassMetrics {
+		if !sv.isPrivate(class.FilePath, class.Name) {
+			filtered.ClassMetrics = append(filtered.ClassMetrics, class)
+		}
+	}
+
+	// Recommendations only carry function names, so keep names that are public somewhere
+	filtered.Recommendations = []ComplexityRecommendation{}
+	for _, rec := range complexity.Recommendations {
+		functions := []string{}
+		for _, name := range rec.Functions {
+			if sv.publicNames[name] {
+				functions = append(functions, name)
+			}
+		}
+		if len(rec.Functions) > 0 && len(functions) == 0 {
+			continue
+		}
+		rec.Functions = functions
+		filtered.Recommendations = append(filtered.Recommendations, rec)
+	}
+
+	return &filtered
+}
+
+// publicCoverage returns a copy of coverage restricted to public functions
+func (sv *symbolVisibility) publicCoverage(coverage *CoverageMetrics) *CoverageMetrics {
+	if sv == nil || coverage == nil {
+		return coverage
+	}
+
+	filtered := *coverage
+	filtered.FunctionAnalysis = []FunctionTestability{}
+	for _, function := range coverage.FunctionAnalysis {
+		if !sv.isPrivate(function.FilePath, function.Name) {
+			filtered.FunctionAnalysis = append(filtered.FunctionAnalysis, function)
+		}
+	}
+
+	filtered.UntestedPaths = []UntestedPath{}
+	for _, path := range coverage.UntestedPaths {
+		if !sv.isPrivate(path.FilePath, path.FunctionName) {
+			filtered.UntestedPaths = append(filtered.UntestedPaths, path)
+		}
+	}
+
+	filtered.MockRequirements = []MockRequirement{}
+	for _, mock := range coverage.MockRequirements {
+		if !sv.isPrivate(mock.FilePath, mock.FunctionName) {
+			filtered.MockRequirements = append(filtered.MockRequirements, mock)
+		}
+	}
+
+	filtered.TestingRecommendations = []TestingRecommendation{}
+	for _, rec := range coverage.TestingRecommendations {
+		if !sv.isPrivate(rec.FilePath, rec.FunctionName) {
+			filtered.TestingRecommendations = append(filtered.TestingRecommendations, rec)
+		}
+	}
+
+	return &filtered
+}
+
+// publicTechnicalDebt returns a copy of debt whose item listings omit private symbols: the
+// category items, the remediation plan, rebuilt by ds from the public items, and the dashboard's
+// quick wins and long-term initiatives. Import cycles are only listed between public module
+// boundaries. Category scores, hours and the dashboard counts are left as computed over the
+// whole file.
+func (sv *symbolVisibility) publicTechnicalDebt(debt *TechnicalDebtMetrics, ds *DebtScorer) *TechnicalDebtMetrics {
+	if sv == nil || debt == nil {
+		return debt
+	}
+
+	filtered := *debt
+	filtered.Categories = make(map[string]DebtCategory, len(debt.Categories))
+	publicItems := []TechnicalDebtItem{}
+	privateIDs := make(map[string]bool)
+	for _, name := range sortedKeys(debt.Categories) {
+		category := debt.Categories[name]
+		items := []TechnicalDebtItem{}
+		for _, item := range category.Items {
+			if sv.isPrivate(item.FilePath, item.FunctionName) || sv.isPrivate(item.FilePath, item.ClassName) {
+				privateIDs[item.ID] = true
+				continue
+			}
+			items = append(items, item)
+		}
+		category.Items = items
+		filtered.Categories[name] = category
+		publicItems = append(publicItems, items...)
+	}
+
+	if len(privateIDs) > 0 {
+		filtered.RemediationPlan = ds.generateRemediationPlan(publicItems)
+	}
+	filtered.Dashboard.QuickWins = sv.publicRemediation(debt.Dashboard.QuickWins, "quickwin_", privateIDs)
+	filtered.Dashboard.LongTermInitiatives = sv.publicRemediation(debt.Dashboard.LongTermInitiatives, "longterm_", privateIDs)
+
+	return &filtered
+}
+
+// publicRemediation drops the dashboard entries made from a private debt item (their ID is
+// prefix + the item's ID) and the import cycles passing through a module that exports nothing
+func (sv *symbolVisibility) publicRemediation(items []RemediationItem, prefix string, privateIDs map[string]bool) []RemediationItem {
+	filtered := []RemediationItem{}
+	for _, item := range items {
+		id := strings.TrimPrefix(item.ID, prefix)
+		if privateIDs[id] {
+			continue
+		}
+		if strings.HasPrefix(id, "import_cycle_") && !sv.allBoundaries(item.AffectedFiles) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
+// allBoundaries reports whether every file is a public module boundary
+func (sv *symbolVisibility) allBoundaries(files []string) bool {
+	for _, file := range files {
+		if !sv.boundaries[file] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func createPublicOnlyFixture() map[string]string {
+	return map[string]string{
+		"src/parser.js": `
+export function parse(input) {
+  if (!input) {
+    return null;
+  }
+  return normalize(input);
+}
+
+function normalize(input) {
+  let out = '';
+  for (let i = 0; i < input.length; i++) {
+    if (input[i] === ' ') {
+      continue;
+    } else if (input[i] === '\t') {
+      out += ' ';
+    } else {
+      out += input[i];
+    }
+  }
+  return out;
+}
+`,
+	}
+}
+
+func functionNames(functions []FunctionComplexity) []string {
+	names := []string{}
+	for _, function := range functions {
+		names = append(names, function.Name)
+	}
+	return names
+}
+
+func TestGenerateQualityReport_PublicOnly(t *testing.T) {
+	files := createPublicOnlyFixture()
+
+	full, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+	require.Contains(t, functionNames(full.DetailedMetrics.Complexity.FunctionMetrics), "normalize")
+
+	public, err := NewQualityReporter(QualityReportConfig{PublicOnly: true}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	names := functionNames(public.DetailedMetrics.Complexity.FunctionMetrics)
+	assert.Contains(t, names, "parse")
+	assert.NotContains(t, names, "normalize")
+
+	for _, function := range public.DetailedMetrics.Coverage.FunctionAnalysis {
+		assert.NotEqual(t, "normalize", function.Name)
+	}
+	for _, category := range public.DetailedMetrics.TechnicalDebt.Categories {
+		for _, item := range category.Items {
+			assert.NotEqual(t, "normalize", item.FunctionName)
+		}
+	}
+
+	// File-level aggregates still include private symbols
+	assert.Equal(t, full.DetailedMetrics.Complexity.OverallScore, public.DetailedMetrics.Complexity.OverallScore)
+	assert.Equal(t, full.DetailedMetrics.Complexity.FileMetrics, public.DetailedMetrics.Complexity.FileMetrics)
+}
+
+func TestSymbolVisibility_NilIsPassThrough(t *testing.T) {
+	var sv *symbolVisibility
+	complexity := &ComplexityMetrics{FunctionMetrics: []FunctionComplexity{{Name: "helper"}}}
+	assert.Same(t, complexity, sv.publicComplexity(complexity))
+}
+
+func TestPublicTechnicalDebt_RemediationAndDashboard(t *testing.T) {
+	sv := newSymbolVisibility([]*ast.ParseResult{
+		{
+			FilePath:  "src/api.js",
+			Functions: []ast.FunctionInfo{{Name: "handle", IsExported: true}, {Name: "retry"}},
+			Exports:   []ast.ExportInfo{{Name: "handle", ExportType: "named"}},
+		},
+		{FilePath: "src/state.js", Exports: []ast.ExportInfo{{Name: "store", ExportType: "named"}}},
+		{FilePath: "src/internal.js"},
+	})
+
+	public := TechnicalDebtItem{ID: "code_smell_1", Category: "Code Smells", FilePath: "src/api.js", FunctionName: "handle", EstimatedHours: 1, ImpactScore: 6}
+	private := TechnicalDebtItem{ID: "performance_1", Category: "Performance Issues", FilePath: "src/api.js", FunctionName: "retry", EstimatedHours: 1, ImpactScore: 6}
+	ds := NewDebtScorer()
+	debt := &TechnicalDebtMetrics{
+		Categories: map[string]DebtCategory{
+			"Code Smells":        {Items: []TechnicalDebtItem{public}},
+			"Performance Issues": {Items: []TechnicalDebtItem{private}},
+		},
+		RemediationPlan: ds.generateRemediationPlan([]TechnicalDebtItem{public, private}),
+		Dashboard: TechnicalDebtDashboard{
+			QuickWins: []RemediationItem{
+				{ID: "quickwin_code_smell_1"},
+				{ID: "quickwin_performance_1"},
+				{ID: "quickwin_import_cycle_1", AffectedFiles: []string{"src/api.js", "src/state.js"}},
+				{ID: "quickwin_import_cycle_2", AffectedFiles: []string{"src/api.js", "src/internal.js"}},
+			},
+			LongTermInitiatives: []RemediationItem{{ID: "longterm_performance_1"}},
+		},
+	}
+	require.Len(t, debt.RemediationPlan, 2)
+
+	filtered := sv.publicTechnicalDebt(debt, ds)
+
+	require.Len(t, filtered.RemediationPlan, 1)
+	assert.Equal(t, "Code Smells", filtered.RemediationPlan[0].Category)
+
+	ids := []string{}
+	for _, item := range filtered.Dashboard.QuickWins {
+		ids = append(ids, item.ID)
+	}
+	assert.Equal(t, []string{"quickwin_code_smell_1", "quickwin_import_cycle_1"}, ids)
+	assert.Empty(t, filtered.Dashboard.LongTermInitiatives)
+
+	// The unfiltered report is left alone
+	assert.Len(t, debt.RemediationPlan, 2)
+	assert.Len(t, debt.Dashboard.QuickWins, 4)
+}
@@ -2,35 +2,88 @@ package metrics
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"runtime"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
 )
 
 // QualityReporter generates comprehensive quality reports by aggregating all analysis components
 type QualityReporter struct {
-	config              QualityReportConfig
-	complexityAnalyzer  *ComplexityAnalyzer
-	duplicationDetector *DuplicationDetector
-	debtScorer          *DebtScorer
-	coverageAnalyzer    *CoverageAnalyzer
-	performanceAnalyzer *PerformanceAnalyzer
-	maintainabilityCalc *MaintainabilityCalculator
-}
+	config                QualityReportConfig
+	complexityAnalyzer    *ComplexityAnalyzer
+	duplicationDetector   *DuplicationDetector
+	debtScorer            *DebtScorer
+	coverageAnalyzer      *CoverageAnalyzer
+	performanceAnalyzer   *PerformanceAnalyzer
+	maintainabilityCalc   *MaintainabilityCalculator
+	documentationAnalyzer *DocumentationAnalyzer
+	now                   func() time.Time // clock used for report timestamps; replaceable in tests
+	templateFailures      sync.Map         // recommendation template kinds already reported to OnTemplateError
+}
+
+// ErrNoAnalyzableFiles reports that the input contains no JavaScript/TypeScript source.
+// It is returned together with a stub report so callers can treat an empty repository
+// as a non-event rather than a crash.
+var ErrNoAnalyzableFiles = types.ErrNoAnalyzableFiles
+
+// QualityGateNotApplicable marks reports for which no quality gate could be evaluated
+const QualityGateNotApplicable = "n/a"
 
 // QualityReportConfig defines configuration for quality reporting
 type QualityReportConfig struct {
-	ReportFormat            ReportFormat      `yaml:"report_format" json:"report_format"`
-	IncludeExecutiveSummary bool              `yaml:"include_executive_summary" json:"include_executive_summary"`
-	IncludeTrendAnalysis    bool              `yaml:"include_trend_analysis" json:"include_trend_analysis"`
-	MaxRecommendations      int               `yaml:"max_recommendations" json:"max_recommendations"`
-	EffortEstimationModel   string            `yaml:"effort_estimation_model" json:"effort_estimation_model"`
-	RoadmapTimeframe        int               `yaml:"roadmap_timeframe" json:"roadmap_timeframe"` // weeks
-	Thresholds              QualityThresholds `yaml:"thresholds" json:"thresholds"`
-	WeightingFactors        QualityWeights    `yaml:"weighting_factors" json:"weighting_factors"`
+	ReportFormat            ReportFormat             `yaml:"report_format" json:"report_format"`
+	IncludeExecutiveSummary bool                     `yaml:"include_executive_summary" json:"include_executive_summary"`
+	IncludeTrendAnalysis    bool                     `yaml:"include_trend_analysis" json:"include_trend_analysis"`
+	MaxRecommendations      int                      `yaml:"max_recommendations" json:"max_recommendations"`
+	MaxPerComponent         int                      `yaml:"max_per_component" json:"max_per_component"` // recommendations kept per component before MaxRecommendations; 0 = unlimited
+	EffortEstimationModel   string                   `yaml:"effort_estimation_model" json:"effort_estimation_model"`
+	RoadmapTimeframe        int                      `yaml:"roadmap_timeframe" json:"roadmap_timeframe"` // weeks
+	Thresholds              QualityThresholds        `yaml:"thresholds" json:"thresholds"`
+	WeightingFactors        QualityWeights           `yaml:"weighting_factors" json:"weighting_factors"`
+	KeepMissingWeights      bool                     `yaml:"keep_missing_weights" json:"keep_missing_weights"` // score missing components as 0 instead of renormalizing
+	PublicOnly              bool                     `yaml:"public_only" json:"public_only"`                   // report only exported symbols
+	Benchmark               *BenchmarkBaseline       `yaml:"benchmark" json:"benchmark,omitempty"`
+	TodoTags                []string                 `yaml:"todo_tags" json:"todo_tags"`                                 // comment markers collected in the TODO inventory
+	CriticalFiles           []string                 `yaml:"critical_files" json:"critical_files"`                       // globs of files that always get a deep-dive section
+	CoverageGateAdvisory    bool                     `yaml:"coverage_gate_advisory" json:"coverage_gate_advisory"`       // estimated coverage can only warn
+	MeasuredCoverage        *float64                 `yaml:"measured_coverage" json:"measured_coverage,omitempty"`       // line coverage from a real test run, 0-100
+	ScorePrecision          *int                     `yaml:"score_precision" json:"score_precision,omitempty"`           // decimals in emitted scores; nil uses DefaultScorePrecision, negative disables rounding
+	History                 []HistoricalDataPoint    `yaml:"-" json:"-"`                                                 // snapshots of previous runs, any order
+	MaxFindingsPerCategory  int                      `yaml:"max_findings_per_category" json:"max_findings_per_category"` // 0 = unlimited
+	Since                   time.Duration            `yaml:"since" json:"since,omitempty"`                               // change window for technical debt
+	ActiveFiles             map[string]bool          `yaml:"-" json:"-"`                                                 // files changed within Since; nil analyzes all files
+	ParseWorkers            int                      `yaml:"parse_workers" json:"parse_workers"`                         // concurrent file parsers; 0 uses GOMAXPROCS
+	DependencySizes         *DependencySizes         `yaml:"-" json:"-"`                                                 // heavy library sizes; nil uses the built-in table
+	DependencyVersions      map[string]string        `yaml:"-" json:"-"`                                                 // package.json version ranges by package name
+	OtherFileLines          map[string]int           `yaml:"-" json:"-"`                                                 // line counts of recognized non-source files, for LanguageStats
+	DataFiles               []DataFile               `yaml:"-" json:"-"`                                                 // lockfiles, snapshots and fixtures kept out of the code metrics
+	LargeFiles              []LargeFile              `yaml:"-" json:"-"`                                                 // source files above the large-file threshold, not parsed
+	ExcludedFiles           []FileDisposition        `yaml:"-" json:"-"`                                                 // files discovered but left out before parsing, with why
+	FunctionSize            FunctionSizeThresholds   `yaml:"function_size" json:"function_size"`                         // long_method and large_function sizes; unset values use defaults
+	TestingEffort           TestingEffortCalibration `yaml:"testing_effort" json:"testing_effort"`                       // hours per testing difficulty, mock and untested path; unset values use defaults
+	TestFramework           string                   `yaml:"test_framework" json:"test_framework,omitempty"`             // jest, vitest, mocha or pytest; empty detects it from the repository
+	TestFilePatterns        []string                 `yaml:"test_file_patterns" json:"test_file_patterns,omitempty"`     // globs marking test files; empty uses DefaultTestFilePatterns
+	GodFile                 GodFileThresholds        `yaml:"god_file" json:"god_file"`                                   // function, class and line counts of a god file; unset values use defaults
+	SwitchCaseThreshold     int                      `yaml:"switch_case_threshold" json:"switch_case_threshold"`         // switch and if/else-if cases before a lookup table is suggested; 0 uses the default
+	Security                *SecurityMetrics         `yaml:"-" json:"-"`                                                 // dependency vulnerability and secret scan results; nil leaves security unscored
+	PhaseImpact             PhaseImpactMap           `yaml:"phase_impact" json:"phase_impact,omitempty"`                 // expected score gain per component for a roadmap phase; categories left out are derived from their recommendations
+	GateMinConfidence       float64                  `yaml:"gate_min_confidence" json:"gate_min_confidence"`             // debt items below this confidence (0-1) are informational and never affect the quality gate
+	SeverityOverrides       SeverityOverrides        `yaml:"severity_overrides" json:"severity_overrides,omitempty"`     // severity by anti-pattern, bottleneck and debt item type, replacing the detected one
+	Grammars                map[string]string        `yaml:"grammars" json:"grammars,omitempty"`                         // grammar by file extension, e.g. ".js": "typescript"; unset extensions use the default grammar
+	RecommendationTemplates *RecommendationTemplates `yaml:"-" json:"-"`                                                 // recommendation wording; nil uses the built-in templates
+	OnTemplateError         TemplateErrorHandler     `yaml:"-" json:"-"`                                                 // told when a recommendation template fails and the built-in wording is used instead
+	Only                    []SectionName            `yaml:"only" json:"only,omitempty"`                                 // analyzers to report, see SelectableSections; empty reports all
+	RecommendationDetail    RecommendationDetail     `yaml:"recommendation_detail" json:"recommendation_detail"`         // minimal, standard or full; empty means full
+	GradeScale              GradeScale               `yaml:"grade_scale" json:"grade_scale"`                             // descriptive, letter or numeric grades throughout the report; empty means descriptive
+	Focus                   SectionName              `yaml:"-" json:"focus,omitempty"`                                   // component boosted to FocusWeightShare of the weight, its recommendations first; set per run
 }
 
 // QualityThresholds defines quality score thresholds
@@ -41,7 +94,9 @@ type QualityThresholds struct {
 	Poor      float64 `yaml:"poor" json:"poor"`           // < 60
 }
 
-// QualityWeights defines weights for different quality aspects
+// QualityWeights defines weights for different quality aspects. Weights are relative: the
+// overall score divides by the total weight of the components that were scored, so a
+// weight left at 0 (e.g. Security in configs that predate it) drops that component.
 type QualityWeights struct {
 	Complexity      float64 `yaml:"complexity" json:"complexity"`           // 20%
 	Duplication     float64 `yaml:"duplication" json:"duplication"`         // 15%
@@ -49,6 +104,7 @@ type QualityWeights struct {
 	Coverage        float64 `yaml:"coverage" json:"coverage"`               // 20%
 	Performance     float64 `yaml:"performance" json:"performance"`         // 10%
 	Maintainability float64 `yaml:"maintainability" json:"maintainability"` // 10%
+	Security        float64 `yaml:"security" json:"security"`               // 10%, on top of the others
 }
 
 // ReportFormat defines the output format for quality reports
@@ -59,31 +115,95 @@ const (
 	FormatMarkdown ReportFormat = "markdown"
 	FormatHTML     ReportFormat = "html"
 	FormatConsole  ReportFormat = "console"
+	FormatNDJSON   ReportFormat = "ndjson" // one finding per line, see Findings
+	FormatSonar    ReportFormat = "sonar"  // SonarQube generic issue data, see BuildSonarIssues
+	FormatSARIF    ReportFormat = "sarif"  // SARIF 2.1.0 log, see BuildSARIF
+
+	FormatTestingCSV   ReportFormat = "testing-csv"   // testing priority matrix as CSV, see WriteTestingMatrixCSV
+	FormatTestingBoard ReportFormat = "testing-board" // testing priority matrix as a Markdown board, see WriteTestingBoard
+)
+
+// SectionName identifies one analyzer's section of a quality report
+type SectionName string
+
+const (
+	SectionComplexity      SectionName = "complexity"
+	SectionDuplication     SectionName = "duplication"
+	SectionTechnicalDebt   SectionName = "technical_debt"
+	SectionCoverage        SectionName = "coverage"
+	SectionPerformance     SectionName = "performance"
+	SectionDocumentation   SectionName = "documentation"
+	SectionMaintainability SectionName = "maintainability"
+	SectionSecurity        SectionName = "security"
+	SectionAPISurface      SectionName = "api_surface"
+	SectionTodoInventory   SectionName = "todo_inventory"
 )
 
+// ReportSection carries the metrics of a single analyzer as soon as it completes.
+// Metrics is a copy of the analyzer's metrics at that point, the same type as the matching
+// DetailedMetrics field; the report may still adjust its own copy afterwards, e.g. the
+// performance grade and informational debt findings.
+type ReportSection struct {
+	Name        SectionName `json:"name"`
+	CompletedAt time.Time   `json:"completed_at"`
+	Metrics     interface{} `json:"metrics"`
+}
+
+// SectionHandler receives completed report sections during analysis. Each section is the
+// handler's own copy, so it may be kept or handed to another goroutine.
+type SectionHandler func(section ReportSection)
+
 // QualityReport represents the comprehensive quality analysis report
 type QualityReport struct {
-	GeneratedAt      time.Time               `json:"generated_at"`
-	ProjectName      string                  `json:"project_name"`
-	OverallScore     float64                 `json:"overall_score"`
-	QualityGrade     string                  `json:"quality_grade"`
-	ComponentScores  ComponentScores         `json:"component_scores"`
-	Dashboard        QualityDashboard        `json:"dashboard"`
-	Recommendations  []QualityRecommendation `json:"recommendations"`
-	Roadmap          QualityRoadmap          `json:"roadmap"`
-	ExecutiveSummary *ExecutiveSummary       `json:"executive_summary,omitempty"`
-	TrendAnalysis    *QualityTrend           `json:"trend_analysis,omitempty"`
-	DetailedMetrics  DetailedMetrics         `json:"detailed_metrics"`
+	GeneratedAt       time.Time               `json:"generated_at"`
+	ProjectName       string                  `json:"project_name"`
+	Summary           *RepositorySummary      `json:"summary,omitempty"` // onboarding fact sheet
+	LanguageStats     map[string]LanguageStat `json:"language_stats,omitempty"`
+	DirectoryScores   DirectoryScoreMap       `json:"directory_scores,omitempty"` // per-file metrics rolled up by directory
+	QualityGate       string                  `json:"quality_gate,omitempty"`     // pass, warning or fail; n/a without source files
+	Message           string                  `json:"message,omitempty"`
+	OverallScore      float64                 `json:"overall_score"`
+	Focus             *ReportFocus            `json:"focus,omitempty"` // component the overall score is weighted toward for this run
+	QualityGrade      string                  `json:"quality_grade"`
+	ComponentScores   ComponentScores         `json:"component_scores"`
+	Dashboard         QualityDashboard        `json:"dashboard"`
+	Recommendations   []QualityRecommendation `json:"recommendations"`
+	Roadmap           QualityRoadmap          `json:"roadmap"`
+	ExecutiveSummary  *ExecutiveSummary       `json:"executive_summary,omitempty"`
+	TrendAnalysis     *QualityTrend           `json:"trend_analysis,omitempty"`
+	APISurface        *APISurface             `json:"api_surface,omitempty"`
+	TodoInventory     *TodoInventory          `json:"todo_inventory,omitempty"`
+	TruncatedCounts   map[string]int          `json:"truncated_counts,omitempty"` // findings dropped by MaxFindingsPerCategory
+	ChangeWindow      *ChangeWindow           `json:"change_window,omitempty"`
+	OnboardingRisk    *OnboardingRisk         `json:"onboarding_risk,omitempty"`     // bus factor from git history
+	TimeToOnboard     *OnboardingEstimate     `json:"time_to_onboard,omitempty"`     // days to become productive, from size, complexity, docs and dependencies
+	HowToRun          *ProjectCommands        `json:"how_to_run,omitempty"`          // build, test and run commands from package.json scripts and CI config
+	Vulnerabilities   map[string]int          `json:"vulnerabilities,omitempty"`     // OSV advisories against the dependencies by severity; listed, never scored
+	GeneratedFiles    []string                `json:"generated_files,omitempty"`     // excluded from scoring as generated code
+	VendoredCode      []VendoredDirectory     `json:"vendored_code,omitempty"`       // excluded from scoring as third-party code
+	SuppressedCounts  map[string]int          `json:"suppressed_counts,omitempty"`   // findings silenced by repo-onboarding-ignore comments, by rule
+	CriticalFiles     *CriticalFilesSection   `json:"critical_files,omitempty"`      // deep-dive into the configured critical files
+	AnalyzerErrors    map[string]string       `json:"analyzer_errors,omitempty"`     // analyzers that panicked, by section
+	ParseWarnings     []ParseWarning          `json:"parse_warnings,omitempty"`      // files that did not parse cleanly or at all
+	FileDispositions  *FileDispositions       `json:"file_dispositions,omitempty"`   // every discovered file, analyzed or why not
+	ExcludedFromScore []string                `json:"excluded_from_score,omitempty"` // components left out of overall_score, remaining weights renormalized
+	Benchmark         *OrgBenchmark           `json:"benchmark,omitempty"`
+	Regressions       *RegressionReport       `json:"regressions,omitempty"`  // what got worse since a baseline report
+	RunMetadata       *RunMetadata            `json:"run_metadata,omitempty"` // tool version, commit and effective config of this run
+	DetailedMetrics   DetailedMetrics         `json:"detailed_metrics"`
 }
 
 // ComponentScores contains scores for each analysis component
 type ComponentScores struct {
-	Complexity      float64 `json:"complexity"`
-	Duplication     float64 `json:"duplication"`
-	TechnicalDebt   float64 `json:"technical_debt"`
-	Coverage        float64 `json:"coverage"`
-	Performance     float64 `json:"performance"`
-	Maintainability float64 `json:"maintainability"`
+	Complexity      float64  `json:"complexity"`
+	Duplication     float64  `json:"duplication"`
+	TechnicalDebt   float64  `json:"technical_debt"`
+	Coverage        float64  `json:"coverage"`
+	Performance     float64  `json:"performance"`
+	Maintainability float64  `json:"maintainability"`
+	Security        float64  `json:"security"`
+	Unavailable     []string `json:"unavailable,omitempty"` // components without metrics, because their analyzer failed, returned none or was skipped
+	Skipped         []string `json:"skipped,omitempty"`     // unavailable components whose analyzer was not selected by Only
 }
 
 // QualityDashboard provides visual indicators and trend analysis
@@ -147,7 +267,7 @@ type ProgressIndicator struct {
 type QualityRecommendation struct {
 	ID           string                 `json:"id"`
 	Title        string                 `json:"title"`
-	Description  string                 `json:"description"`
+	Description  string                 `json:"description,omitempty"`
 	Category     RecommendationCategory `json:"category"`
 	Priority     Priority               `json:"priority"`
 	Impact       ImpactLevel            `json:"impact"`
@@ -157,10 +277,11 @@ type QualityRecommendation struct {
 	Component    string                 `json:"component"`
 	Files        []string               `json:"files"`
 	Actions      []RecommendationAction `json:"actions"`
-	Benefits     []string               `json:"benefits"`
-	Risks        []string               `json:"risks"`
-	Dependencies []string               `json:"dependencies"`
-	Timeline     string                 `json:"timeline"`
+	Benefits     []string               `json:"benefits,omitempty"`
+	Risks        []string               `json:"risks,omitempty"`
+	Dependencies []string               `json:"dependencies,omitempty"`
+	Timeline     string                 `json:"timeline,omitempty"`
+	References   []string               `json:"references,omitempty"` // playbooks and guides from the recommendation templates
 }
 
 // RecommendationCategory categorizes recommendations
@@ -293,6 +414,7 @@ type ExecutiveSummary struct {
 	InvestmentRequired InvestmentSummary `json:"investment_required"`
 	ExpectedOutcomes   []ExpectedOutcome `json:"expected_outcomes"`
 	NextSteps          []string          `json:"next_steps"`
+	ChangeNarrative    string            `json:"change_narrative,omitempty"` // movement since the previous snapshot
 }
 
 // BusinessImpact describes business implications of quality issues
@@ -344,9 +466,10 @@ type TrendDirection struct {
 
 // HistoricalDataPoint represents a point in quality history
 type HistoricalDataPoint struct {
-	Timestamp time.Time       `json:"timestamp"`
-	Scores    ComponentScores `json:"scores"`
-	Events    []QualityEvent  `json:"events"`
+	Timestamp           time.Time          `json:"timestamp"`
+	Scores              ComponentScores    `json:"scores"`
+	Events              []QualityEvent     `json:"events"`
+	FileMaintainability map[string]float64 `json:"file_maintainability,omitempty"` // maintainability index by file path
 }
 
 // QualityEvent represents events that affected quality
@@ -390,6 +513,17 @@ type DetailedMetrics struct {
 	Coverage        *CoverageMetrics        `json:"coverage,omitempty"`
 	Performance     *PerformanceMetrics     `json:"performance,omitempty"`
 	Maintainability *MaintainabilityMetrics `json:"maintainability,omitempty"`
+	Documentation   *DocumentationMetrics   `json:"documentation,omitempty"`
+	Security        *SecurityMetrics        `json:"security,omitempty"`
+}
+
+// Validate rejects settings that contradict each other, such as a function size smell
+// threshold at or above the critical one
+func (c QualityReportConfig) Validate() error {
+	if err := c.FunctionSize.Validate(); err != nil {
+		return fmt.Errorf("invalid function_size: %w", err)
+	}
+	return nil
 }
 
 // NewQualityReporter creates a new quality reporter with all analyzers
@@ -403,6 +537,9 @@ func NewQualityReporter(config QualityReportConfig) *QualityReporter {
 	if config.EffortEstimationModel == "" {
 		config.EffortEstimationModel = "complexity_based"
 	}
+	if len(config.TodoTags) == 0 {
+		config.TodoTags = DefaultTodoTags
+	}
 
 	// Set default thresholds
 	if config.Thresholds.Excellent == 0 {
@@ -423,24 +560,76 @@ func NewQualityReporter(config QualityReportConfig) *QualityReporter {
 			Coverage:        0.20,
 			Performance:     0.10,
 			Maintainability: 0.10,
+			Security:        0.10,
 		}
 	}
 
+	coverageAnalyzer := NewCoverageAnalyzer()
+	coverageAnalyzer.config.EstimatedGateAdvisory = config.CoverageGateAdvisory
+	coverageAnalyzer.config.MeasuredCoverage = config.MeasuredCoverage
+	coverageAnalyzer.config.TestingEffort = config.TestingEffort.withDefaults()
+	coverageAnalyzer.config.TestFramework = config.TestFramework
+	coverageAnalyzer.config.TestFilePatterns = config.TestFilePatterns
+	coverageAnalyzer.config.Dependencies = config.DependencyVersions
+	coverageAnalyzer.config.ProjectFiles = sortedKeys(config.OtherFileLines)
+
+	complexityAnalyzer := NewComplexityAnalyzer()
+	complexityAnalyzer.config.TestFilePatterns = config.TestFilePatterns
+
+	debtScorer := NewDebtScorer()
+	debtScorer.config.ActiveFiles = config.ActiveFiles
+	debtScorer.config.FunctionSize = config.FunctionSize.withDefaults()
+	debtScorer.config.GodFile = config.GodFile.withDefaults()
+	if config.SwitchCaseThreshold > 0 {
+		debtScorer.config.SwitchCaseThreshold = config.SwitchCaseThreshold
+	}
+	debtScorer.config.SeverityOverrides = config.SeverityOverrides
+
+	performanceAnalyzer := NewPerformanceAnalyzer()
+	performanceAnalyzer.config.DependencySizes = config.DependencySizes
+	performanceAnalyzer.config.DependencyVersions = config.DependencyVersions
+	performanceAnalyzer.config.FunctionSize = config.FunctionSize.withDefaults()
+	performanceAnalyzer.config.SeverityOverrides = config.SeverityOverrides
+
 	return &QualityReporter{
-		config:              config,
-		complexityAnalyzer:  NewComplexityAnalyzer(),
-		duplicationDetector: NewDuplicationDetector(),
-		debtScorer:          NewDebtScorer(),
-		coverageAnalyzer:    NewCoverageAnalyzer(),
-		performanceAnalyzer: NewPerformanceAnalyzer(),
-		maintainabilityCalc: NewMaintainabilityCalculator(),
+		config:                config,
+		complexityAnalyzer:    complexityAnalyzer,
+		duplicationDetector:   NewDuplicationDetector(),
+		debtScorer:            debtScorer,
+		coverageAnalyzer:      coverageAnalyzer,
+		performanceAnalyzer:   performanceAnalyzer,
+		maintainabilityCalc:   NewMaintainabilityCalculator(),
+		documentationAnalyzer: NewDocumentationAnalyzer(),
+		now:                   time.Now,
 	}
 }
 
-// GenerateQualityReport creates a comprehensive quality report
+// GenerateQualityReport performs comprehensive quality analysis and generates a report.
+// With no files it returns a stub report (QualityGate "n/a") along with ErrNoAnalyzableFiles.
 func (qr *QualityReporter) GenerateQualityReport(ctx context.Context, fileContents map[string]string) (*QualityReport, error) {
+	return qr.GenerateQualityReportWithSections(ctx, fileContents, nil)
+}
+
+// GenerateQualityReportWithSections behaves like GenerateQualityReport but hands each
+// analyzer's metrics to onSection as soon as that analyzer finishes, so callers can
+// render partial results while the remaining analyzers are still running.
+// onSection is called sequentially from the analysis goroutine and may be nil.
+func (qr *QualityReporter) GenerateQualityReportWithSections(ctx context.Context, fileContents map[string]string, onSection SectionHandler) (*QualityReport, error) {
+	if err := qr.config.Validate(); err != nil {
+		return nil, err
+	}
 	if len(fileContents) == 0 {
-		return nil, fmt.Errorf("no files provided for analysis")
+		return qr.emptyReport(), fmt.Errorf("%w: repository contains no JavaScript or TypeScript files", ErrNoAnalyzableFiles)
+	}
+
+	started := qr.now()
+	emit := func(name SectionName, data interface{}) {
+		if onSection != nil && ctx.Err() == nil {
+			snapshot := sectionSnapshot(data)
+			qr.roundEmitted(snapshot)
+			qr.applySectionDetail(snapshot)
+			onSection(ReportSection{Name: name, CompletedAt: time.Now(), Metrics: snapshot})
+		}
 	}
 
 	// Run all analysis components in parallel for performance
@@ -451,6 +640,16 @@ func (qr *QualityReporter) GenerateQualityReport(ctx context.Context, fileConten
 		coverage        *CoverageMetrics
 		performance     *PerformanceMetrics
 		maintainability *MaintainabilityMetrics
+		documentation   *DocumentationMetrics
+		apiSurface      *APISurface
+		todoInventory   *TodoInventory
+		summary         *RepositorySummary
+		languageStats   map[string]LanguageStat
+		criticalFiles   *CriticalFilesSection
+		truncated       map[string]int
+		analyzerErrors  map[string]string // panics recovered per section
+		parseWarnings   []ParseWarning
+		phases          []PhaseTiming
 		err             error
 	}
 
@@ -459,53 +658,216 @@ func (qr *QualityReporter) GenerateQualityReport(ctx context.Context, fileConten
 	go func() {
 		defer close(resultChan)
 
-		var result analysisResult
+		result := analysisResult{truncated: make(map[string]int), analyzerErrors: make(map[string]string)}
 
 		// Parse files into parse results
-		parseResults, err := qr.parseFiles(fileContents)
+		parseStart := qr.now()
+		parseResults, parseWarnings, err := qr.parseFiles(fileContents)
+		result.phases = append(result.phases, qr.phaseTiming("parse", parseStart))
+		result.parseWarnings = parseWarnings
 		if err != nil {
 			result.err = fmt.Errorf("failed to parse files: %w", err)
 			resultChan <- result
 			return
 		}
+		result.languageStats = BuildLanguageStats(fileContents, qr.config.OtherFileLines)
+		result.summary = BuildRepositorySummary(fileContents, parseResults, qr.config.DependencyVersions, result.languageStats)
+		result.summary.DataFiles = sortedDataFiles(qr.config.DataFiles)
+		result.summary.LargeFiles = sortedLargeFiles(qr.config.LargeFiles)
+
+		// In public-only mode the analyzers still see every symbol so file-level
+		// aggregates are unchanged; only the per-symbol listings are filtered
+		var visibility *symbolVisibility
+		if qr.config.PublicOnly {
+			visibility = newSymbolVisibility(parseResults)
+		}
 
-		// Run all analyses
-		if result.complexity, err = qr.complexityAnalyzer.AnalyzeComplexity(ctx, parseResults); err != nil {
-			result.err = fmt.Errorf("complexity analysis failed: %w", err)
-			resultChan <- result
-			return
+		// Run all analyses. An analyzer that panics is recorded as unavailable and gets
+		// empty metrics so the rest of the report can still be produced.
+		unavailable := func(name SectionName, err error) bool {
+			if !errors.Is(err, errAnalyzerPanicked) {
+				return false
+			}
+			result.analyzerErrors[string(name)] = err.Error()
+			return true
+		}
+		// timed runs one analyzer under recoverAnalyzer and records how long it took
+		timed := func(name SectionName, analyze func() error) error {
+			start := qr.now()
+			err := recoverAnalyzer(analyze)
+			result.phases = append(result.phases, qr.phaseTiming(string(name), start))
+			return err
+		}
+		// emitSection streams a section unless its analyzer failed
+		emitSection := func(name SectionName, data interface{}) {
+			if _, failed := result.analyzerErrors[string(name)]; !failed {
+				emit(name, data)
+			}
 		}
 
-		if result.duplication, err = qr.duplicationDetector.DetectDuplication(ctx, parseResults); err != nil {
-			result.err = fmt.Errorf("duplication detection failed: %w", err)
-			resultChan <- result
-			return
+		// Analyzers not selected by Only are skipped unless a selected one reads their
+		// metrics; those run but stay out of the report
+		var complexity *ComplexityMetrics
+		if qr.needed(SectionComplexity) {
+			err = timed(SectionComplexity, func() (err error) {
+				complexity, err = qr.complexityAnalyzer.AnalyzeComplexity(ctx, parseResults)
+				return err
+			})
+			if unavailable(SectionComplexity, err) {
+				complexity = &ComplexityMetrics{}
+			} else if err != nil {
+				result.err = fmt.Errorf("complexity analysis failed: %w", err)
+				resultChan <- result
+				return
+			}
+		}
+		if qr.selected(SectionComplexity) {
+			result.complexity = visibility.publicComplexity(complexity)
+			emitSection(SectionComplexity, result.complexity)
 		}
 
-		if result.technicalDebt, err = qr.debtScorer.AnalyzeDebt(ctx, parseResults, result.complexity, result.duplication); err != nil {
-			result.err = fmt.Errorf("technical debt analysis failed: %w", err)
-			resultChan <- result
-			return
+		var duplication *DuplicationMetrics
+		if qr.needed(SectionDuplication) {
+			err = timed(SectionDuplication, func() (err error) {
+				duplication, err = qr.duplicationDetector.DetectDuplication(ctx, parseResults)
+				return err
+			})
+			if unavailable(SectionDuplication, err) {
+				duplication = &DuplicationMetrics{}
+			} else if err != nil {
+				result.err = fmt.Errorf("duplication detection failed: %w", err)
+				resultChan <- result
+				return
+			}
+		}
+		if qr.selected(SectionDuplication) {
+			result.duplication = duplication
+			emitSection(SectionDuplication, result.duplication)
 		}
 
-		if result.coverage, err = qr.coverageAnalyzer.AnalyzeCoverage(ctx, parseResults, result.complexity); err != nil {
-			result.err = fmt.Errorf("coverage analysis failed: %w", err)
-			resultChan <- result
-			return
+		var technicalDebt *TechnicalDebtMetrics
+		if qr.needed(SectionTechnicalDebt) {
+			err = timed(SectionTechnicalDebt, func() (err error) {
+				technicalDebt, err = qr.debtScorer.AnalyzeDebt(ctx, parseResults, complexity, duplication)
+				return err
+			})
+			if unavailable(SectionTechnicalDebt, err) {
+				technicalDebt = &TechnicalDebtMetrics{}
+			} else if err != nil {
+				result.err = fmt.Errorf("technical debt analysis failed: %w", err)
+				resultChan <- result
+				return
+			}
+		}
+		if qr.selected(SectionTechnicalDebt) {
+			result.technicalDebt = visibility.publicTechnicalDebt(technicalDebt, qr.debtScorer)
+			emitSection(SectionTechnicalDebt, result.technicalDebt)
 		}
 
-		if result.performance, err = qr.performanceAnalyzer.AnalyzePerformance(ctx, parseResults, result.complexity); err != nil {
-			result.err = fmt.Errorf("performance analysis failed: %w", err)
-			resultChan <- result
-			return
+		var coverage *CoverageMetrics
+		if qr.needed(SectionCoverage) {
+			err = timed(SectionCoverage, func() (err error) {
+				coverage, err = qr.coverageAnalyzer.AnalyzeCoverage(ctx, parseResults, complexity)
+				return err
+			})
+			if unavailable(SectionCoverage, err) {
+				coverage = &CoverageMetrics{}
+			} else if err != nil {
+				result.err = fmt.Errorf("coverage analysis failed: %w", err)
+				resultChan <- result
+				return
+			}
+		}
+		if qr.selected(SectionCoverage) {
+			result.coverage = limitCoverageFindings(visibility.publicCoverage(coverage), qr.config.MaxFindingsPerCategory, result.truncated)
+			emitSection(SectionCoverage, result.coverage)
 		}
 
-		if result.maintainability, err = qr.maintainabilityCalc.AnalyzeMaintainability(ctx, parseResults, result.complexity); err != nil {
-			result.err = fmt.Errorf("maintainability calculation failed: %w", err)
-			resultChan <- result
-			return
+		var performance *PerformanceMetrics
+		if qr.needed(SectionPerformance) {
+			err = timed(SectionPerformance, func() (err error) {
+				performance, err = qr.performanceAnalyzer.AnalyzePerformance(ctx, parseResults, complexity)
+				return err
+			})
+			if unavailable(SectionPerformance, err) {
+				performance = &PerformanceMetrics{}
+			} else if err != nil {
+				result.err = fmt.Errorf("performance analysis failed: %w", err)
+				resultChan <- result
+				return
+			}
+		}
+		if qr.selected(SectionPerformance) {
+			result.performance = limitPerformanceFindings(performance, qr.config.MaxFindingsPerCategory, result.truncated)
+			emitSection(SectionPerformance, result.performance)
+		}
+
+		// Documentation is not scored on its own, so a panic simply leaves it out
+		var documentation *DocumentationMetrics
+		if qr.needed(SectionDocumentation) {
+			err = timed(SectionDocumentation, func() (err error) {
+				documentation, err = qr.documentationAnalyzer.AnalyzeDocumentation(ctx, parseResults, complexity)
+				return err
+			})
+			if unavailable(SectionDocumentation, err) {
+				documentation = nil
+			} else if err != nil {
+				result.err = fmt.Errorf("documentation analysis failed: %w", err)
+				resultChan <- result
+				return
+			}
+		}
+		if qr.selected(SectionDocumentation) {
+			result.documentation = documentation
+			emitSection(SectionDocumentation, result.documentation)
+		}
+
+		if qr.selected(SectionMaintainability) {
+			err = timed(SectionMaintainability, func() (err error) {
+				result.maintainability, err = qr.maintainabilityCalc.AnalyzeMaintainabilityWithDocumentation(ctx, parseResults, complexity, documentation)
+				return err
+			})
+			if unavailable(SectionMaintainability, err) {
+				result.maintainability = &MaintainabilityMetrics{}
+			} else if err != nil {
+				result.err = fmt.Errorf("maintainability calculation failed: %w", err)
+				resultChan <- result
+				return
+			}
+			qr.gradeMaintainability(result.maintainability)
+			emitSection(SectionMaintainability, result.maintainability)
+		}
+
+		if qr.selected(SectionAPISurface) {
+			err = timed(SectionAPISurface, func() error {
+				result.apiSurface = BuildAPISurface(parseResults, complexity)
+				return nil
+			})
+			unavailable(SectionAPISurface, err)
+			emitSection(SectionAPISurface, result.apiSurface)
 		}
 
+		if qr.selected(SectionTodoInventory) {
+			err = timed(SectionTodoInventory, func() error {
+				result.todoInventory = BuildTodoInventory(parseResults, qr.config.TodoTags)
+				return nil
+			})
+			unavailable(SectionTodoInventory, err)
+			emitSection(SectionTodoInventory, result.todoInventory)
+		}
+
+		// Critical files are read from the unfiltered metrics so finding limits and
+		// public-only mode never hide anything about them
+		result.criticalFiles = BuildCriticalFiles(qr.config.CriticalFiles, sortedKeys(fileContents), DetailedMetrics{
+			Complexity:      complexity,
+			Duplication:     duplication,
+			TechnicalDebt:   technicalDebt,
+			Coverage:        coverage,
+			Performance:     performance,
+			Maintainability: result.maintainability,
+			Documentation:   documentation,
+		})
+
 		resultChan <- result
 	}()
 
@@ -517,44 +879,164 @@ func (qr *QualityReporter) GenerateQualityReport(ctx context.Context, fileConten
 		}
 
 		// Generate comprehensive report
-		return qr.generateReport(
+		reportStart := qr.now()
+		report := qr.generateReport(
 			result.complexity,
 			result.duplication,
 			result.technicalDebt,
 			result.coverage,
 			result.performance,
 			result.maintainability,
-		), nil
+			result.documentation,
+			result.apiSurface,
+			result.todoInventory,
+			result.analyzerErrors,
+		)
+		report.Summary = result.summary
+		report.LanguageStats = result.languageStats
+		report.CriticalFiles = result.criticalFiles
+		report.ParseWarnings = result.parseWarnings
+		report.FileDispositions = buildFileDispositions(fileContents, result.parseWarnings, qr.config.ExcludedFiles)
+		report.TimeToOnboard = EstimateOnboarding(report)
+		qr.applyRecommendationDetail(report)
+		qr.roundEmitted(report)
+		report.RunMetadata.Phases = append(result.phases, qr.phaseTiming("report", reportStart))
+		report.RunMetadata.DurationMillis = qr.now().Sub(started).Milliseconds()
+		if len(result.truncated) > 0 {
+			report.TruncatedCounts = result.truncated
+		}
+		if qr.config.ActiveFiles != nil {
+			files := make([]string, 0, len(fileContents))
+			for _, file := range sortedKeys(fileContents) {
+				files = append(files, ast.NormalizePath(file))
+			}
+			report.ChangeWindow = newChangeWindow(qr.config.Since, report.GeneratedAt.Add(-qr.config.Since), files, qr.config.ActiveFiles)
+		}
+		return report, nil
 
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
 }
 
-// parseFiles converts file contents to parse results
-func (qr *QualityReporter) parseFiles(fileContents map[string]string) ([]*ast.ParseResult, error) {
-	var parseResults []*ast.ParseResult
+// emptyReport returns the stub report used when there is no source to analyze
+func (qr *QualityReporter) emptyReport() *QualityReport {
+	now := qr.now()
+	return &QualityReport{
+		GeneratedAt:      now,
+		RunMetadata:      qr.runMetadata(now),
+		ProjectName:      "Repository Analysis",
+		QualityGrade:     QualityGateNotApplicable,
+		QualityGate:      QualityGateNotApplicable,
+		Message:          "Repository contains no analyzable JavaScript or TypeScript source files; no quality metrics were computed.",
+		Recommendations:  []QualityRecommendation{},
+		FileDispositions: buildFileDispositions(nil, nil, qr.config.ExcludedFiles),
+	}
+}
+
+// coverageDescription labels the coverage key metric with where its value came from
+func coverageDescription(source string) string {
+	if source == CoverageSourceMeasured {
+		return "Measured test coverage percentage"
+	}
+	return "Estimated test coverage percentage (no measured coverage supplied)"
+}
+
+// parseFiles converts file contents to parse results using a pool of ParseWorkers
+// goroutines, each with its own parser. Files that fail to parse are skipped and, like files
+// with syntax errors, returned as parse warnings; results keep path order so every
+// downstream analyzer sees the same input ordering.
+func (qr *QualityReporter) parseFiles(fileContents map[string]string) ([]*ast.ParseResult, []ParseWarning, error) {
+	filenames := sortedKeys(fileContents)
 
-	// Create parser instance
-	parser, err := ast.NewParser()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create parser: %v", err)
+	workers := qr.config.ParseWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(filenames) {
+		workers = len(filenames)
 	}
 
-	for filename, content := range fileContents {
-		result, err := parser.ParseFile(context.Background(), filename, []byte(content))
+	// Parsers are created up front so a setup failure is reported before any work starts
+	parsers := make([]*ast.Parser, 0, workers)
+	defer func() {
+		for _, parser := range parsers {
+			parser.Close()
+		}
+	}()
+	for i := 0; i < workers; i++ {
+		parser, err := ast.NewParser()
 		if err != nil {
-			// Log warning but continue with other files
+			return nil, nil, fmt.Errorf("failed to create parser: %v", err)
+		}
+		parsers = append(parsers, parser)
+		for _, ext := range sortedKeys(qr.config.Grammars) {
+			if err := parser.SetGrammar(ext, ast.Grammar(qr.config.Grammars[ext])); err != nil {
+				return nil, nil, fmt.Errorf("invalid grammar for %s: %w", ext, err)
+			}
+		}
+	}
+
+	results := make([]*ast.ParseResult, len(filenames))
+	failures := make([]error, len(filenames))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for _, parser := range parsers {
+		wg.Add(1)
+		go func(parser *ast.Parser) {
+			defer wg.Done()
+			for i := range indexes {
+				result, err := parser.ParseFile(context.Background(), filenames[i], []byte(fileContents[filenames[i]]))
+				if err != nil {
+					// Skip the file and continue with the others
+					failures[i] = err
+					continue
+				}
+				results[i] = result
+			}
+		}(parser)
+	}
+
+	for i := range filenames {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var parseResults []*ast.ParseResult
+	var warnings []ParseWarning
+	for i, result := range results {
+		if result == nil {
+			warnings = append(warnings, ParseWarning{
+				File:    ast.NormalizePath(filenames[i]),
+				Kind:    ParseWarningFailed,
+				Message: failures[i].Error(),
+			})
 			continue
 		}
 		parseResults = append(parseResults, result)
+		if warning, ok := parseWarning(result); ok {
+			warnings = append(warnings, warning)
+		}
 	}
 
 	if len(parseResults) == 0 {
-		return nil, fmt.Errorf("no files could be parsed")
+		return nil, warnings, fmt.Errorf("%w: no files could be parsed", types.ErrParseFailed)
 	}
 
-	return parseResults, nil
+	return parseResults, warnings, nil
+}
+
+// sortedKeys returns the keys of m in ascending order so that output built from
+// map iteration is identical across runs
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // generateReport creates the comprehensive quality report from all analysis results
@@ -565,23 +1047,57 @@ func (qr *QualityReporter) generateReport(
 	coverage *CoverageMetrics,
 	performance *PerformanceMetrics,
 	maintainability *MaintainabilityMetrics,
+	documentation *DocumentationMetrics,
+	apiSurface *APISurface,
+	todoInventory *TodoInventory,
+	analyzerErrors map[string]string,
 ) *QualityReport {
-	now := time.Now()
+	now := qr.now()
+
+	// Components without metrics are scored from empty placeholders and marked unavailable
+	missing := make(map[string]bool)
+	complexity = orEmptyMetrics(complexity, SectionComplexity, missing)
+	duplication = orEmptyMetrics(duplication, SectionDuplication, missing)
+	technicalDebt = orEmptyMetrics(technicalDebt, SectionTechnicalDebt, missing)
+	coverage = orEmptyMetrics(coverage, SectionCoverage, missing)
+	performance = orEmptyMetrics(performance, SectionPerformance, missing)
+	maintainability = orEmptyMetrics(maintainability, SectionMaintainability, missing)
+	security := orEmptyMetrics(qr.securityMetrics(), SectionSecurity, missing)
+	for component := range analyzerErrors {
+		missing[component] = true
+	}
 
 	// Calculate component scores
-	componentScores := qr.calculateComponentScores(complexity, duplication, technicalDebt, coverage, performance, maintainability)
+	componentScores := qr.calculateComponentScores(complexity, duplication, technicalDebt, coverage, performance, maintainability, security)
+	componentScores.Unavailable = unavailableComponents(missing)
+	componentScores.Skipped = qr.skippedComponents()
+	if len(analyzerErrors) == 0 {
+		analyzerErrors = nil
+	}
 
 	// Calculate overall score
 	overallScore := qr.calculateOverallScore(componentScores)
 
+	// Low-confidence findings are reported but do not affect the gate
+	qr.markInformational(technicalDebt)
+	qualityGate := qr.evaluateQualityGate(technicalDebt, coverage, performance, componentScores)
+
 	// Generate quality grade
-	qualityGrade := qr.determineQualityGrade(overallScore)
+	qualityGrade := qr.grade(overallScore)
+	if performance != nil {
+		performance.PerformanceGrade = qr.grade(performance.OverallScore)
+	}
 
 	// Generate dashboard
 	dashboard := qr.generateDashboard(componentScores, complexity, duplication, technicalDebt, coverage, performance, maintainability)
+	qr.roundDashboard(&dashboard)
 
 	// Generate recommendations
 	recommendations := qr.generateRecommendations(complexity, duplication, technicalDebt, coverage, performance, maintainability)
+	recommendations = append(recommendations, qr.generateDocumentationRecommendations(documentation)...)
+	recommendations = append(recommendations, qr.generateAPISurfaceRecommendations(apiSurface)...)
+	recommendations = append(recommendations, qr.generateMaintainabilityTrendRecommendations(maintainability)...)
+	recommendations = append(recommendations, qr.generateLookupTableRecommendations(technicalDebt)...)
 
 	// Sort and limit recommendations
 	recommendations = qr.rankAndLimitRecommendations(recommendations)
@@ -602,23 +1118,35 @@ func (qr *QualityReporter) generateReport(
 	}
 
 	return &QualityReport{
-		GeneratedAt:      now,
-		ProjectName:      "Repository Analysis", // Could be made configurable
-		OverallScore:     overallScore,
-		QualityGrade:     qualityGrade,
-		ComponentScores:  componentScores,
-		Dashboard:        dashboard,
-		Recommendations:  recommendations,
-		Roadmap:          roadmap,
-		ExecutiveSummary: executiveSummary,
-		TrendAnalysis:    trendAnalysis,
+		GeneratedAt:       now,
+		ProjectName:       "Repository Analysis", // Could be made configurable
+		SuppressedCounts:  mergeSuppressedCounts(technicalDebt.SuppressedFindings, performance.SuppressedFindings),
+		AnalyzerErrors:    analyzerErrors,
+		ExcludedFromScore: qr.excludedFromScore(componentScores),
+		QualityGate:       qualityGate,
+		OverallScore:      overallScore,
+		Focus:             qr.reportFocus(componentScores),
+		QualityGrade:      qualityGrade,
+		ComponentScores:   componentScores,
+		Dashboard:         dashboard,
+		Recommendations:   recommendations,
+		Roadmap:           roadmap,
+		ExecutiveSummary:  executiveSummary,
+		TrendAnalysis:     trendAnalysis,
+		APISurface:        apiSurface,
+		TodoInventory:     todoInventory,
+		Benchmark:         compareWithBaseline(qr.config.Benchmark, overallScore, componentScores),
+		DirectoryScores:   qr.directoryScores(complexity, duplication, technicalDebt, coverage, maintainability, componentScores),
+		RunMetadata:       qr.runMetadata(now),
 		DetailedMetrics: DetailedMetrics{
-			Complexity:      complexity,
-			Duplication:     duplication,
-			TechnicalDebt:   technicalDebt,
-			Coverage:        coverage,
-			Performance:     performance,
-			Maintainability: maintainability,
+			Complexity:      reportedMetrics(qr, complexity, SectionComplexity),
+			Duplication:     reportedMetrics(qr, duplication, SectionDuplication),
+			TechnicalDebt:   reportedMetrics(qr, technicalDebt, SectionTechnicalDebt),
+			Coverage:        reportedMetrics(qr, coverage, SectionCoverage),
+			Performance:     reportedMetrics(qr, performance, SectionPerformance),
+			Maintainability: reportedMetrics(qr, maintainability, SectionMaintainability),
+			Documentation:   documentation,
+			Security:        qr.securityMetrics(),
 		},
 	}
 }
@@ -631,14 +1159,16 @@ func (qr *QualityReporter) calculateComponentScores(
 	coverage *CoverageMetrics,
 	performance *PerformanceMetrics,
 	maintainability *MaintainabilityMetrics,
+	security *SecurityMetrics,
 ) ComponentScores {
 	return ComponentScores{
-		Complexity:      qr.normalizeScore(complexity.OverallScore),
-		Duplication:     qr.normalizeScore(duplication.OverallScore),
-		TechnicalDebt:   qr.normalizeScore(technicalDebt.OverallScore),
-		Coverage:        qr.normalizeScore(coverage.OverallScore),
-		Performance:     qr.normalizeScore(performance.OverallScore),
-		Maintainability: qr.normalizeScore(maintainability.OverallIndex),
+		Complexity:      qr.roundScore(qr.normalizeScore(complexity.OverallScore)),
+		Duplication:     qr.roundScore(qr.normalizeScore(duplication.OverallScore)),
+		TechnicalDebt:   qr.roundScore(qr.normalizeScore(technicalDebt.OverallScore)),
+		Coverage:        qr.roundScore(qr.normalizeScore(coverage.OverallScore)),
+		Performance:     qr.roundScore(qr.normalizeScore(performance.OverallScore)),
+		Maintainability: qr.roundScore(qr.normalizeScore(maintainability.OverallIndex)),
+		Security:        qr.roundScore(qr.normalizeScore(security.OverallScore)),
 	}
 }
 
@@ -653,34 +1183,61 @@ func (qr *QualityReporter) normalizeScore(score float64) float64 {
 	return score
 }
 
-// calculateOverallScore computes weighted overall quality score
+// calculateOverallScore computes weighted overall quality score, weighted toward the focus
+// component when one is set
 func (qr *QualityReporter) calculateOverallScore(scores ComponentScores) float64 {
-	weights := qr.config.WeightingFactors
-
-	overallScore := scores.Complexity*weights.Complexity +
-		scores.Duplication*weights.Duplication +
-		scores.TechnicalDebt*weights.TechnicalDebt +
-		scores.Coverage*weights.Coverage +
-		scores.Performance*weights.Performance +
-		scores.Maintainability*weights.Maintainability
+	return qr.weightedScore(scores, qr.weights())
+}
+
+// weightedScore computes the overall score with weights. Unavailable components are left
+// out, unless KeepMissingWeights scores them as 0, and the score is divided by the total
+// weight of the components kept.
+func (qr *QualityReporter) weightedScore(scores ComponentScores, weights QualityWeights) float64 {
+	components := []struct {
+		name          SectionName
+		score, weight float64
+	}{
+		{SectionComplexity, scores.Complexity, weights.Complexity},
+		{SectionDuplication, scores.Duplication, weights.Duplication},
+		{SectionTechnicalDebt, scores.TechnicalDebt, weights.TechnicalDebt},
+		{SectionCoverage, scores.Coverage, weights.Coverage},
+		{SectionPerformance, scores.Performance, weights.Performance},
+		{SectionMaintainability, scores.Maintainability, weights.Maintainability},
+		{SectionSecurity, scores.Security, weights.Security},
+	}
+
+	excluded := qr.excludedFromScore(scores)
+	overallScore, availableWeight := 0.0, 0.0
+	for _, component := range components {
+		if contains(excluded, string(component.name)) {
+			continue
+		}
+		overallScore += component.score * component.weight
+		availableWeight += component.weight
+	}
+	if availableWeight > 0 {
+		overallScore /= availableWeight
+	}
 
-	return math.Round(overallScore*100) / 100
+	return qr.roundScore(overallScore)
 }
 
-// determineQualityGrade assigns a grade based on overall score
-func (qr *QualityReporter) determineQualityGrade(score float64) string {
-	thresholds := qr.config.Thresholds
-
-	switch {
-	case score >= thresholds.Excellent:
-		return "Excellent"
-	case score >= thresholds.Good:
-		return "Good"
-	case score >= thresholds.Fair:
-		return "Fair"
-	default:
-		return "Poor"
+// excludedFromScore lists the components left out of the overall score. Security is
+// always left out without scan results, since not scanning is not a failed analysis, and
+// so are components skipped by Only.
+func (qr *QualityReporter) excludedFromScore(scores ComponentScores) []string {
+	if !qr.config.KeepMissingWeights {
+		return scores.Unavailable
 	}
+	excluded := append([]string{}, scores.Skipped...)
+	if qr.config.Security == nil {
+		excluded = append(excluded, string(SectionSecurity))
+	}
+	if len(excluded) == 0 {
+		return nil
+	}
+	sort.Strings(excluded)
+	return excluded
 }
 
 // generateDashboard creates visual indicators and trend analysis
@@ -704,13 +1261,23 @@ func (qr *QualityReporter) generateDashboard(
 		"coverage":        qr.createHealthIndicator(scores.Coverage, "Test Coverage"),
 		"performance":     qr.createHealthIndicator(scores.Performance, "Performance"),
 		"maintainability": qr.createHealthIndicator(scores.Maintainability, "Maintainability"),
+		"security":        qr.createHealthIndicator(scores.Security, "Security"),
+	}
+	for _, component := range scores.Unavailable {
+		componentHealth[component] = unavailableHealthIndicator(componentHealth[component].Description)
+	}
+	for _, component := range scores.Skipped {
+		componentHealth[component] = skippedHealthIndicator(componentHealth[component].Description)
+	}
+	if qr.config.Security == nil {
+		componentHealth["security"] = notScannedHealthIndicator("Security")
 	}
 
 	// Generate trend indicators
 	trendIndicators := qr.generateTrendIndicators(scores)
 
 	// Generate alerts and warnings
-	alerts := qr.generateQualityAlerts(scores, complexity, duplication, technicalDebt, coverage, performance, maintainability)
+	alerts := dropUnavailableAlerts(qr.generateQualityAlerts(scores, complexity, duplication, technicalDebt, coverage, performance, maintainability), scores)
 
 	// Generate key metrics
 	keyMetrics := qr.generateKeyMetrics(scores, complexity, duplication, technicalDebt, coverage, performance, maintainability)
@@ -767,7 +1334,8 @@ func (qr *QualityReporter) generateTrendIndicators(scores ComponentScores) []Tre
 	}
 
 	var indicators []TrendIndicator
-	for component, score := range components {
+	for _, component := range sortedKeys(components) {
+		score := components[component]
 		// Simulate trend analysis based on current score
 		trend := "stable"
 		direction := "stable"
@@ -831,6 +1399,16 @@ func (qr *QualityReporter) generateQualityAlerts(
 		})
 	}
 
+	if scores.Security < 60 {
+		alerts = append(alerts, QualityAlert{
+			Severity:       "critical",
+			Component:      "security",
+			Message:        "Vulnerable dependencies or hard-coded secrets detected",
+			Impact:         "high",
+			ActionRequired: "Upgrade vulnerable dependencies and rotate exposed secrets",
+		})
+	}
+
 	// Warnings
 	if scores.Complexity < 60 {
 		alerts = append(alerts, QualityAlert{
@@ -903,6 +1481,14 @@ func (qr *QualityReporter) generateKeyMetrics(
 			Status:      qr.getMetricStatus(duplication.DuplicationRatio*100, 5.0, false),
 			Description: "Percentage of duplicated code",
 		},
+		{
+			Name:        "Eliminable Duplicate Lines",
+			Value:       float64(duplication.EliminableLines),
+			Unit:        "lines",
+			Target:      100.0,
+			Status:      qr.getMetricStatus(float64(duplication.EliminableLines), 100.0, false),
+			Description: "Duplicated lines that consolidating every duplication cluster would remove",
+		},
 		{
 			Name:        "Technical Debt Hours",
 			Value:       technicalDebt.TotalDebtHours,
@@ -913,11 +1499,11 @@ func (qr *QualityReporter) generateKeyMetrics(
 		},
 		{
 			Name:        "Test Coverage",
-			Value:       coverage.EstimatedCoverage,
+			Value:       coverage.Summary.CoveragePercentage,
 			Unit:        "%",
 			Target:      80.0,
-			Status:      qr.getMetricStatus(coverage.EstimatedCoverage, 80.0, true),
-			Description: "Estimated test coverage percentage",
+			Status:      qr.getMetricStatus(coverage.Summary.CoveragePercentage, 80.0, true),
+			Description: coverageDescription(coverage.CoverageSource),
 		},
 		{
 			Name:        "Performance Score",
@@ -980,7 +1566,8 @@ func (qr *QualityReporter) generateProgressIndicators(scores ComponentScores) []
 	}
 
 	var indicators []ProgressIndicator
-	for goal, target := range targets {
+	for _, goal := range sortedKeys(targets) {
+		target := targets[goal]
 		current := currentValues[goal]
 		progress := (current / target) * 100
 		if progress > 100 {
@@ -1048,46 +1635,46 @@ func (qr *QualityReporter) generateComplexityRecommendations(complexity *Complex
 
 	// High complexity functions
 	for _, funcMetric := range complexity.FunctionMetrics {
-		if funcMetric.CyclomaticValue > 15 {
+		if funcMetric.CyclomaticValue > 15 && !funcMetric.Accepted {
 			category := qr.categorizeByComplexity(funcMetric.CyclomaticValue)
 			linesOfCode := funcMetric.EndLine - funcMetric.StartLine + 1
 			effort := qr.estimateRefactoringEffort(funcMetric.CyclomaticValue, linesOfCode)
+			text := qr.recommendationText(RecommendationComplexity, "", RecommendationContext{
+				Finding:     funcMetric,
+				File:        funcMetric.FilePath,
+				EffortHours: effort,
+				Values:      map[string]interface{}{"Threshold": 15},
+			})
 
 			recommendations = append(recommendations, QualityRecommendation{
 				ID:          fmt.Sprintf("COMPLEX-%d", id),
-				Title:       fmt.Sprintf("Refactor high complexity function: %s", funcMetric.Name),
-				Description: fmt.Sprintf("Function has cyclomatic complexity of %d (threshold: 15)", funcMetric.CyclomaticValue),
+				Title:       text.Title,
+				Description: text.Description,
 				Category:    category,
 				Priority:    qr.determinePriority(float64(funcMetric.CyclomaticValue), 15, 25),
 				Impact:      qr.determineImpact(float64(funcMetric.CyclomaticValue), 15),
 				Effort:      qr.determineEffortLevel(effort),
 				EffortHours: effort,
-				ROI:         qr.calculateROI(effort, float64(funcMetric.CyclomaticValue)),
+				ROI:         qr.calculateROI(effort, float64(funcMetric.CyclomaticValue)*qr.complexityAnalyzer.fanInMultiplier(funcMetric)),
 				Component:   "complexity",
 				Files:       []string{funcMetric.FilePath},
 				Actions: []RecommendationAction{
 					{
 						Type:           "refactor",
-						Description:    "Extract methods to reduce complexity",
+						Description:    text.Actions["refactor"],
 						EstimatedHours: effort * 0.6,
 					},
 					{
 						Type:           "test",
-						Description:    "Add unit tests for new extracted methods",
+						Description:    text.Actions["test"],
 						EstimatedHours: effort * 0.4,
 					},
 				},
-				Benefits: []string{
-					"Improved code readability and maintainability",
-					"Easier debugging and testing",
-					"Reduced risk of bugs",
-				},
-				Risks: []string{
-					"Potential introduction of bugs during refactoring",
-					"Temporary code instability",
-				},
+				Benefits:     text.Benefits,
+				Risks:        text.Risks,
 				Dependencies: []string{},
 				Timeline:     qr.estimateTimeline(effort),
+				References:   text.Links,
 			})
 			id++
 		}
@@ -1101,46 +1688,44 @@ func (qr *QualityReporter) generateDuplicationRecommendations(duplication *Dupli
 	var recommendations []QualityRecommendation
 	id := 1
 
-	// Process exact duplicates first (highest priority)
+	// Process exact duplicates first (highest priority); a block copy-pasted across files
+	// ranks above the same repetition within one file
 	for _, duplicate := range duplication.ExactDuplicates {
 		if len(duplicate.Instances) > 1 && duplicate.LineCount > 10 {
+			files := qr.extractFilesFromInstances(duplicate.Instances)
+			weight := qr.duplicationDetector.config.WeightFactors.crossFileWeight(len(files))
 			effort := qr.estimateDuplicationFixEffort(duplicate.LineCount, len(duplicate.Instances))
+			text := qr.recommendationText(RecommendationDuplication, "", RecommendationContext{Finding: duplicate, EffortHours: effort})
 
 			recommendations = append(recommendations, QualityRecommendation{
 				ID:          fmt.Sprintf("DUP-%d", id),
-				Title:       fmt.Sprintf("Consolidate duplicated code: %d lines", duplicate.LineCount),
-				Description: fmt.Sprintf("Found %d instances of duplicated code (%d lines each)", len(duplicate.Instances), duplicate.LineCount),
+				Title:       text.Title,
+				Description: text.Description,
 				Category:    CategoryQuickWins,
-				Priority:    qr.determinePriority(float64(duplicate.LineCount), 10, 50),
-				Impact:      qr.determineImpact(float64(duplicate.LineCount*len(duplicate.Instances)), 100),
+				Priority:    qr.determinePriority(float64(duplicate.LineCount)*weight, 10, 50),
+				Impact:      qr.determineImpact(float64(duplicate.LineCount*len(duplicate.Instances))*weight, 100),
 				Effort:      qr.determineEffortLevel(effort),
 				EffortHours: effort,
-				ROI:         qr.calculateROI(effort, float64(duplicate.LineCount*len(duplicate.Instances))),
+				ROI:         qr.calculateROI(effort, float64(duplicate.LineCount*len(duplicate.Instances))*weight),
 				Component:   "duplication",
-				Files:       qr.extractFilesFromInstances(duplicate.Instances),
+				Files:       files,
 				Actions: []RecommendationAction{
 					{
 						Type:           "extract",
-						Description:    "Extract common functionality into shared utility",
+						Description:    text.Actions["extract"],
 						EstimatedHours: effort * 0.7,
 					},
 					{
 						Type:           "refactor",
-						Description:    "Update all locations to use extracted utility",
+						Description:    text.Actions["refactor"],
 						EstimatedHours: effort * 0.3,
 					},
 				},
-				Benefits: []string{
-					"Reduced maintenance burden",
-					"Consistent behavior across codebase",
-					"Improved code reusability",
-				},
-				Risks: []string{
-					"Risk of breaking existing functionality",
-					"Need for comprehensive testing",
-				},
+				Benefits:     text.Benefits,
+				Risks:        text.Risks,
 				Dependencies: []string{},
 				Timeline:     qr.estimateTimeline(effort),
+				References:   text.Links,
 			})
 			id++
 		}
@@ -1162,7 +1747,8 @@ func (qr *QualityReporter) generateDebtRecommendations(debt *TechnicalDebtMetric
 	}
 
 	var fileDebts []fileDebt
-	for filename, fileMetrics := range debt.FileDebtScores {
+	for _, filename := range sortedKeys(debt.FileDebtScores) {
+		fileMetrics := debt.FileDebtScores[filename]
 		fileDebts = append(fileDebts, fileDebt{
 			filename: filename,
 			score:    fileMetrics.OverallScore,
@@ -1170,7 +1756,7 @@ func (qr *QualityReporter) generateDebtRecommendations(debt *TechnicalDebtMetric
 		})
 	}
 
-	sort.Slice(fileDebts, func(i, j int) bool {
+	sort.SliceStable(fileDebts, func(i, j int) bool {
 		return fileDebts[i].score < fileDebts[j].score // Lower score = more debt
 	})
 
@@ -1181,11 +1767,16 @@ func (qr *QualityReporter) generateDebtRecommendations(debt *TechnicalDebtMetric
 		}
 
 		category := qr.categorizeDebtByScore(fd.score)
+		text := qr.recommendationText(RecommendationTechnicalDebt, "", RecommendationContext{
+			Finding:     debt.FileDebtScores[fd.filename],
+			File:        fd.filename,
+			EffortHours: fd.hours,
+		})
 
 		recommendations = append(recommendations, QualityRecommendation{
 			ID:          fmt.Sprintf("DEBT-%d", id),
-			Title:       fmt.Sprintf("Reduce technical debt in %s", fd.filename),
-			Description: fmt.Sprintf("File has technical debt score of %.1f (estimated %.1f hours)", fd.score, fd.hours),
+			Title:       text.Title,
+			Description: text.Description,
 			Category:    category,
 			Priority:    qr.determinePriority(100-fd.score, 40, 70),
 			Impact:      qr.determineImpact(100-fd.score, 40),
@@ -1197,26 +1788,20 @@ func (qr *QualityReporter) generateDebtRecommendations(debt *TechnicalDebtMetric
 			Actions: []RecommendationAction{
 				{
 					Type:           "refactor",
-					Description:    "Address code smells and anti-patterns",
+					Description:    text.Actions["refactor"],
 					EstimatedHours: fd.hours * 0.6,
 				},
 				{
 					Type:           "test",
-					Description:    "Add comprehensive tests",
+					Description:    text.Actions["test"],
 					EstimatedHours: fd.hours * 0.4,
 				},
 			},
-			Benefits: []string{
-				"Improved code maintainability",
-				"Reduced development time for future changes",
-				"Lower risk of bugs",
-			},
-			Risks: []string{
-				"Potential regression if not properly tested",
-				"Short-term development slowdown",
-			},
+			Benefits:     text.Benefits,
+			Risks:        text.Risks,
 			Dependencies: []string{},
 			Timeline:     qr.estimateTimeline(fd.hours),
+			References:   text.Links,
 		})
 		id++
 	}
@@ -1233,11 +1818,16 @@ func (qr *QualityReporter) generateCoverageRecommendations(coverage *CoverageMet
 	for _, funcAnalysis := range coverage.FunctionAnalysis {
 		if funcAnalysis.TestabilityScore < 70 && funcAnalysis.RiskLevel == "high" {
 			effort := qr.estimateTestingEffort(funcAnalysis.TestingDifficulty, len(funcAnalysis.RequiredMocks))
+			text := qr.recommendationText(RecommendationCoverage, "", RecommendationContext{
+				Finding:     funcAnalysis,
+				File:        funcAnalysis.FilePath,
+				EffortHours: effort,
+			})
 
 			recommendations = append(recommendations, QualityRecommendation{
 				ID:          fmt.Sprintf("TEST-%d", id),
-				Title:       fmt.Sprintf("Add tests for %s", funcAnalysis.Name),
-				Description: fmt.Sprintf("High-priority function with low testability score (%.1f)", funcAnalysis.TestabilityScore),
+				Title:       text.Title,
+				Description: text.Description,
 				Category:    CategoryStrategicImprovements,
 				Priority:    PriorityHigh,
 				Impact:      ImpactHigh,
@@ -1249,26 +1839,20 @@ func (qr *QualityReporter) generateCoverageRecommendations(coverage *CoverageMet
 				Actions: []RecommendationAction{
 					{
 						Type:           "test",
-						Description:    "Create comprehensive unit tests",
+						Description:    text.Actions["test"],
 						EstimatedHours: effort * 0.8,
 					},
 					{
 						Type:           "mock",
-						Description:    "Set up required mocks and test doubles",
+						Description:    text.Actions["mock"],
 						EstimatedHours: effort * 0.2,
 					},
 				},
-				Benefits: []string{
-					"Improved code reliability",
-					"Easier refactoring with confidence",
-					"Better regression detection",
-				},
-				Risks: []string{
-					"Initial time investment",
-					"Maintenance overhead for tests",
-				},
+				Benefits:     text.Benefits,
+				Risks:        text.Risks,
 				Dependencies: []string{},
 				Timeline:     qr.estimateTimeline(effort),
+				References:   text.Links,
 			})
 			id++
 		}
@@ -1286,11 +1870,16 @@ func (qr *QualityReporter) generatePerformanceRecommendations(performance *Perfo
 		if antiPattern.Severity == "high" || antiPattern.Severity == "critical" {
 			effort := qr.estimatePerformanceFixEffort(antiPattern.Type, antiPattern.Impact.Score)
 			category := qr.categorizePerformanceIssue(antiPattern.Severity)
+			text := qr.recommendationText(RecommendationPerformance, antiPattern.Type, RecommendationContext{
+				Finding:     antiPattern,
+				File:        antiPattern.FilePath,
+				EffortHours: effort,
+			})
 
 			recommendations = append(recommendations, QualityRecommendation{
 				ID:          fmt.Sprintf("PERF-%d", id),
-				Title:       fmt.Sprintf("Fix %s anti-pattern", antiPattern.Type),
-				Description: antiPattern.Description,
+				Title:       text.Title,
+				Description: text.Description,
 				Category:    category,
 				Priority:    qr.mapSeverityToPriority(antiPattern.Severity),
 				Impact:      qr.determineImpact(antiPattern.Impact.Score, 70),
@@ -1302,21 +1891,15 @@ func (qr *QualityReporter) generatePerformanceRecommendations(performance *Perfo
 				Actions: []RecommendationAction{
 					{
 						Type:           "optimize",
-						Description:    fmt.Sprintf("Fix %s performance anti-pattern: %s", antiPattern.Type, antiPattern.Impact.Description),
+						Description:    text.Actions["optimize"],
 						EstimatedHours: effort,
 					},
 				},
-				Benefits: []string{
-					"Improved application performance",
-					"Better user experience",
-					"Reduced resource consumption",
-				},
-				Risks: []string{
-					"Potential complexity increase",
-					"Need for performance testing",
-				},
+				Benefits:     text.Benefits,
+				Risks:        text.Risks,
 				Dependencies: []string{},
 				Timeline:     qr.estimateTimeline(effort),
+				References:   text.Links,
 			})
 			id++
 		}
@@ -1337,14 +1920,15 @@ func (qr *QualityReporter) generateMaintainabilityRecommendations(maintainabilit
 	}
 
 	var fileMaintainabilities []fileMaintainability
-	for filename, fileMetrics := range maintainability.FileMetrics {
+	for _, filename := range sortedKeys(maintainability.FileMetrics) {
+		fileMetrics := maintainability.FileMetrics[filename]
 		fileMaintainabilities = append(fileMaintainabilities, fileMaintainability{
 			filename: filename,
 			index:    fileMetrics.OverallIndex,
 		})
 	}
 
-	sort.Slice(fileMaintainabilities, func(i, j int) bool {
+	sort.SliceStable(fileMaintainabilities, func(i, j int) bool {
 		return fileMaintainabilities[i].index < fileMaintainabilities[j].index
 	})
 
@@ -1355,11 +1939,16 @@ func (qr *QualityReporter) generateMaintainabilityRecommendations(maintainabilit
 		}
 
 		effort := qr.estimateMaintainabilityImprovement(fm.index)
+		text := qr.recommendationText(RecommendationMaintainability, "", RecommendationContext{
+			Finding:     maintainability.FileMetrics[fm.filename],
+			File:        fm.filename,
+			EffortHours: effort,
+		})
 
 		recommendations = append(recommendations, QualityRecommendation{
 			ID:          fmt.Sprintf("MAINT-%d", id),
-			Title:       fmt.Sprintf("Improve maintainability of %s", fm.filename),
-			Description: fmt.Sprintf("Low maintainability index: %.1f", fm.index),
+			Title:       text.Title,
+			Description: text.Description,
 			Category:    CategoryLongTermGoals,
 			Priority:    qr.determinePriority(100-fm.index, 30, 50),
 			Impact:      ImpactMedium,
@@ -1371,26 +1960,20 @@ func (qr *QualityReporter) generateMaintainabilityRecommendations(maintainabilit
 			Actions: []RecommendationAction{
 				{
 					Type:           "refactor",
-					Description:    "Improve code structure and documentation",
+					Description:    text.Actions["refactor"],
 					EstimatedHours: effort * 0.7,
 				},
 				{
 					Type:           "document",
-					Description:    "Add comprehensive documentation",
+					Description:    text.Actions["document"],
 					EstimatedHours: effort * 0.3,
 				},
 			},
-			Benefits: []string{
-				"Easier maintenance and modifications",
-				"Improved developer productivity",
-				"Better code understanding",
-			},
-			Risks: []string{
-				"Initial time investment",
-				"Potential for introducing bugs",
-			},
+			Benefits:     text.Benefits,
+			Risks:        text.Risks,
 			Dependencies: []string{},
 			Timeline:     qr.estimateTimeline(effort),
+			References:   text.Links,
 		})
 		id++
 	}
@@ -1398,10 +1981,136 @@ func (qr *QualityReporter) generateMaintainabilityRecommendations(maintainabilit
 	return recommendations
 }
 
-// rankAndLimitRecommendations sorts recommendations by priority and limits the count
+// generateDocumentationRecommendations asks for docs on the most complex undocumented public APIs
+func (qr *QualityReporter) generateDocumentationRecommendations(documentation *DocumentationMetrics) []QualityRecommendation {
+	if documentation == nil {
+		return nil
+	}
+
+	var recommendations []QualityRecommendation
+	for i, api := range documentation.WorstDocumented {
+		if i >= 5 {
+			break
+		}
+
+		effort := 0.25 + float64(api.Complexity)*0.05 // complex APIs need longer explanations
+		priority := PriorityLow
+		if api.Complexity >= 10 {
+			priority = PriorityMedium
+		}
+
+		text := qr.recommendationText(RecommendationDocumentation, "", RecommendationContext{
+			Finding:     api,
+			File:        api.FilePath,
+			EffortHours: effort,
+		})
+
+		recommendations = append(recommendations, QualityRecommendation{
+			ID:          fmt.Sprintf("DOC-%d", i+1),
+			Title:       text.Title,
+			Description: text.Description,
+			Category:    CategoryQuickWins,
+			Priority:    priority,
+			Impact:      qr.determineImpact(float64(api.Complexity), 10),
+			Effort:      qr.determineEffortLevel(effort),
+			EffortHours: effort,
+			ROI:         qr.calculateROI(effort, float64(api.Complexity)*0.5),
+			Component:   "maintainability",
+			Files:       []string{api.FilePath},
+			Actions: []RecommendationAction{
+				{
+					Type:           "document",
+					Description:    text.Actions["document"],
+					Files:          []string{api.FilePath},
+					EstimatedHours: effort,
+				},
+			},
+			Benefits:     text.Benefits,
+			Risks:        text.Risks,
+			Dependencies: []string{},
+			Timeline:     qr.estimateTimeline(effort),
+			References:   text.Links,
+		})
+	}
+
+	return recommendations
+}
+
+// generateAPISurfaceRecommendations flags exported symbols without a doc comment.
+// Missing docs never block anything, so this is always a single low-priority item.
+func (qr *QualityReporter) generateAPISurfaceRecommendations(apiSurface *APISurface) []QualityRecommendation {
+	if apiSurface == nil || apiSurface.UndocumentedCount == 0 {
+		return nil
+	}
+
+	var names []string
+	var files []string
+	seenFiles := make(map[string]bool)
+	for _, entry := range apiSurface.Entries {
+		if entry.Documented {
+			continue
+		}
+		names = append(names, entry.Name)
+		if !seenFiles[entry.FilePath] {
+			seenFiles[entry.FilePath] = true
+			files = append(files, entry.FilePath)
+		}
+	}
+
+	more := 0
+	if len(names) > 10 {
+		names, more = names[:10], len(names)-10
+	}
+
+	effort := float64(apiSurface.UndocumentedCount) * 0.25 // roughly 15 minutes per doc comment
+	undocumentedRatio := float64(apiSurface.UndocumentedCount) / float64(apiSurface.TotalExports)
+	text := qr.recommendationText(RecommendationAPIDocumentation, "", RecommendationContext{
+		Finding:     apiSurface,
+		EffortHours: effort,
+		Values:      map[string]interface{}{"Names": names, "More": more},
+	})
+
+	return []QualityRecommendation{
+		{
+			ID:          "API-DOC-1",
+			Title:       text.Title,
+			Description: text.Description,
+			Category:    CategoryQuickWins,
+			Priority:    PriorityLow,
+			Impact:      ImpactLow,
+			Effort:      qr.determineEffortLevel(effort),
+			EffortHours: effort,
+			ROI:         qr.calculateROI(effort, undocumentedRatio*10),
+			Component:   "maintainability",
+			Files:       files,
+			Actions: []RecommendationAction{
+				{
+					Type:           "document",
+					Description:    text.Actions["document"],
+					Files:          files,
+					EstimatedHours: effort,
+				},
+			},
+			Benefits:     text.Benefits,
+			Risks:        text.Risks,
+			Dependencies: []string{},
+			Timeline:     qr.estimateTimeline(effort),
+			References:   text.Links,
+		},
+	}
+}
+
+// rankAndLimitRecommendations sorts recommendations by priority and limits the count: first
+// to MaxPerComponent per component, then to MaxRecommendations overall. The overall limit
+// keeps the best recommendation of every component before filling the rest by rank, so one
+// prolific analyzer cannot crowd out another's single critical finding.
 func (qr *QualityReporter) rankAndLimitRecommendations(recommendations []QualityRecommendation) []QualityRecommendation {
-	// Sort by ROI (descending), then by Impact, then by Priority
+	// Sort the focus component first, then by ROI (descending), then by Impact, then by Priority
 	sort.Slice(recommendations, func(i, j int) bool {
+		if focus := string(qr.config.Focus); focus != "" && (recommendations[i].Component == focus) != (recommendations[j].Component == focus) {
+			return recommendations[i].Component == focus
+		}
+
 		if recommendations[i].ROI != recommendations[j].ROI {
 			return recommendations[i].ROI > recommendations[j].ROI
 		}
@@ -1413,9 +2122,45 @@ func (qr *QualityReporter) rankAndLimitRecommendations(recommendations []Quality
 		return qr.priorityToScore(recommendations[i].Priority) > qr.priorityToScore(recommendations[j].Priority)
 	})
 
-	// Limit to max recommendations
+	// Limit per component
+	if qr.config.MaxPerComponent > 0 {
+		perComponent := make(map[string]int)
+		kept := recommendations[:0]
+		for _, recommendation := range recommendations {
+			if perComponent[recommendation.Component] < qr.config.MaxPerComponent {
+				perComponent[recommendation.Component]++
+				kept = append(kept, recommendation)
+			}
+		}
+		recommendations = kept
+	}
+
+	// Limit to max recommendations, the best of each component first
 	if len(recommendations) > qr.config.MaxRecommendations {
-		recommendations = recommendations[:qr.config.MaxRecommendations]
+		selected := make([]bool, len(recommendations))
+		represented := make(map[string]bool)
+		slots := qr.config.MaxRecommendations
+		for i, recommendation := range recommendations {
+			if slots > 0 && !represented[recommendation.Component] {
+				represented[recommendation.Component] = true
+				selected[i] = true
+				slots--
+			}
+		}
+		for i := range recommendations {
+			if slots > 0 && !selected[i] {
+				selected[i] = true
+				slots--
+			}
+		}
+
+		kept := recommendations[:0]
+		for i, recommendation := range recommendations {
+			if selected[i] {
+				kept = append(kept, recommendation)
+			}
+		}
+		recommendations = kept
 	}
 
 	return recommendations
@@ -1719,7 +2464,7 @@ func (qr *QualityReporter) generateRoadmap(recommendations []QualityRecommendati
 	timeframeWeeks := qr.config.RoadmapTimeframe
 
 	// Group recommendations into phases
-	phases := qr.createImprovementPhases(recommendations)
+	phases := qr.createImprovementPhases(recommendations, scores)
 
 	// Create milestones
 	milestones := qr.createMilestones(phases, timeframeWeeks)
@@ -1744,48 +2489,33 @@ func (qr *QualityReporter) generateRoadmap(recommendations []QualityRecommendati
 	}
 }
 
-// createImprovementPhases groups recommendations into logical phases
-func (qr *QualityReporter) createImprovementPhases(recommendations []QualityRecommendation) []ImprovementPhase {
+// createImprovementPhases groups recommendations into logical phases. Each phase's expected
+// impact comes from PhaseImpact when configured, otherwise from its recommendations' gains.
+func (qr *QualityReporter) createImprovementPhases(recommendations []QualityRecommendation, scores ComponentScores) []ImprovementPhase {
 	// Group recommendations by category
 	categoryMap := make(map[RecommendationCategory][]QualityRecommendation)
 	for _, rec := range recommendations {
 		categoryMap[rec.Category] = append(categoryMap[rec.Category], rec)
 	}
+	gains := recommendationGains(recommendations, scores)
 
-	var phases []ImprovementPhase
-
-	// Phase 1: Quick Wins (weeks 1-2)
-	if quickWins := categoryMap[CategoryQuickWins]; len(quickWins) > 0 {
-		phases = append(phases, qr.createPhase("Quick Wins", "2 weeks", quickWins, map[string]float64{
-			"duplication": 15.0,
-			"complexity":  10.0,
-		}))
-	}
-
-	// Phase 2: Critical Fixes (weeks 3-6)
-	if criticalFixes := categoryMap[CategoryCriticalFixes]; len(criticalFixes) > 0 {
-		phases = append(phases, qr.createPhase("Critical Fixes", "4 weeks", criticalFixes, map[string]float64{
-			"technical_debt": 25.0,
-			"performance":    20.0,
-			"complexity":     15.0,
-		}))
+	phaseOrder := []struct {
+		category RecommendationCategory
+		name     string
+		duration string
+	}{
+		{CategoryQuickWins, "Quick Wins", "2 weeks"},                         // weeks 1-2
+		{CategoryCriticalFixes, "Critical Fixes", "4 weeks"},                 // weeks 3-6
+		{CategoryStrategicImprovements, "Strategic Improvements", "4 weeks"}, // weeks 7-10
+		{CategoryLongTermGoals, "Long-term Goals", "2 weeks"},                // weeks 11-12
 	}
 
-	// Phase 3: Strategic Improvements (weeks 7-10)
-	if strategic := categoryMap[CategoryStrategicImprovements]; len(strategic) > 0 {
-		phases = append(phases, qr.createPhase("Strategic Improvements", "4 weeks", strategic, map[string]float64{
-			"coverage":        20.0,
-			"maintainability": 15.0,
-			"technical_debt":  10.0,
-		}))
-	}
-
-	// Phase 4: Long-term Goals (weeks 11-12)
-	if longTerm := categoryMap[CategoryLongTermGoals]; len(longTerm) > 0 {
-		phases = append(phases, qr.createPhase("Long-term Goals", "2 weeks", longTerm, map[string]float64{
-			"maintainability": 10.0,
-			"coverage":        5.0,
-		}))
+	var phases []ImprovementPhase
+	for _, phase := range phaseOrder {
+		if recs := categoryMap[phase.category]; len(recs) > 0 {
+			phases = append(phases, qr.createPhase(phase.name, phase.duration, recs,
+				qr.phaseImpact(phase.category, recs, gains)))
+		}
 	}
 
 	return phases
@@ -1829,10 +2559,7 @@ func (qr *QualityReporter) createPhase(name, duration string, recommendations []
 		}
 	}
 
-	var specialists []string
-	for specialist := range specialistsMap {
-		specialists = append(specialists, specialist)
-	}
+	specialists := sortedKeys(specialistsMap)
 
 	return ImprovementPhase{
 		Name:            name,
@@ -1851,7 +2578,7 @@ func (qr *QualityReporter) createPhase(name, duration string, recommendations []
 func (qr *QualityReporter) createMilestones(phases []ImprovementPhase, timeframeWeeks int) []QualityMilestone {
 	var milestones []QualityMilestone
 	currentWeek := 0
-	now := time.Now()
+	now := qr.now()
 
 	for i, phase := range phases {
 		var duration int
@@ -1888,7 +2615,8 @@ func (qr *QualityReporter) createMilestones(phases []ImprovementPhase, timeframe
 func (qr *QualityReporter) createMilestoneGoals(phase ImprovementPhase) []string {
 	var goals []string
 
-	for component, improvement := range phase.ExpectedImpact {
+	for _, component := range sortedKeys(phase.ExpectedImpact) {
+		improvement := phase.ExpectedImpact[component]
 		goals = append(goals, fmt.Sprintf("Improve %s score by %.1f points", component, improvement))
 	}
 
@@ -1933,7 +2661,8 @@ func (qr *QualityReporter) createMilestoneDeliverables(phase ImprovementPhase) [
 func (qr *QualityReporter) createSuccessCriteria(phase ImprovementPhase) []string {
 	var criteria []string
 
-	for component, improvement := range phase.ExpectedImpact {
+	for _, component := range sortedKeys(phase.ExpectedImpact) {
+		improvement := phase.ExpectedImpact[component]
 		criteria = append(criteria, fmt.Sprintf("%s improvement of %.1f%% achieved", component, improvement))
 	}
 
@@ -1991,10 +2720,7 @@ func (qr *QualityReporter) calculateResourcePlan(recommendations []QualityRecomm
 		}
 	}
 
-	var skillsNeeded []string
-	for skill := range skillsMap {
-		skillsNeeded = append(skillsNeeded, skill)
-	}
+	skillsNeeded := sortedKeys(skillsMap)
 
 	// Estimate team size (assuming 40 hours per week per person)
 	totalHours := totalDeveloperHours + totalQAHours + totalReviewHours
@@ -2173,6 +2899,7 @@ func (qr *QualityReporter) generateExecutiveSummary(
 		InvestmentRequired: investment,
 		ExpectedOutcomes:   outcomes,
 		NextSteps:          nextSteps,
+		ChangeNarrative:    qr.generateChangeNarrative(scores),
 	}
 }
 
@@ -2474,61 +3201,79 @@ func (qr *QualityReporter) defineNextSteps(recommendations []QualityRecommendati
 		nextSteps = append(nextSteps, fmt.Sprintf("Implement %d quick wins in the next 2 weeks", quickWinCount))
 	}
 
-	nextSteps = append(nextSteps, "Review and approve the quality improvement roadmap")
-	nextSteps = append(nextSteps, "Allocate development resources for quality improvements")
-	nextSteps = append(nextSteps, "Establish quality gates and monitoring processes")
-	nextSteps = append(nextSteps, "Schedule regular quality assessment reviews")
+	if qr.config.RecommendationDetail.genericAdvice() {
+		nextSteps = append(nextSteps, "Review and approve the quality improvement roadmap")
+		nextSteps = append(nextSteps, "Allocate development resources for quality improvements")
+		nextSteps = append(nextSteps, "Establish quality gates and monitoring processes")
+		nextSteps = append(nextSteps, "Schedule regular quality assessment reviews")
+	}
 
 	return nextSteps
 }
 
 // generateOverallAssessment creates overall quality assessment
 func (qr *QualityReporter) generateOverallAssessment(overallScore float64, qualityGrade string, scores ComponentScores) string {
-	assessment := fmt.Sprintf("The codebase has an overall quality score of %.1f (%s grade). ", overallScore, qualityGrade)
-
-	// Identify strongest and weakest areas
-	componentMap := map[string]float64{
-		"complexity":      scores.Complexity,
-		"duplication":     scores.Duplication,
-		"technical debt":  scores.TechnicalDebt,
-		"coverage":        scores.Coverage,
-		"performance":     scores.Performance,
-		"maintainability": scores.Maintainability,
+	assessment := fmt.Sprintf("The codebase has an overall quality score of %.1f (grade %s). ", overallScore, qualityGrade)
+	if focus := qr.reportFocus(scores); focus != nil {
+		assessment += fmt.Sprintf("The score is weighted toward %s for this run; with the default weights it is %.1f. ",
+			strings.ReplaceAll(string(focus.Component), "_", " "), focus.DefaultScore)
+	}
+
+	// Identify strongest and weakest areas among the components that were scored; skipped
+	// and unavailable ones hold a placeholder 0. Components are listed alphabetically and
+	// only a strictly better score replaces the current pick, so ties always resolve
+	// to the alphabetically first component and the summary text is stable.
+	notScored := make(map[string]bool)
+	for _, name := range append(append([]string{}, scores.Unavailable...), scores.Skipped...) {
+		notScored[name] = true
+	}
+	type component struct {
+		name  string
+		score float64
+	}
+	var components []component
+	for _, candidate := range []struct {
+		section SectionName
+		score   float64
+	}{
+		{SectionComplexity, scores.Complexity},
+		{SectionCoverage, scores.Coverage},
+		{SectionDuplication, scores.Duplication},
+		{SectionMaintainability, scores.Maintainability},
+		{SectionPerformance, scores.Performance},
+		{SectionSecurity, scores.Security},
+		{SectionTechnicalDebt, scores.TechnicalDebt},
+	} {
+		if !notScored[string(candidate.section)] {
+			components = append(components, component{strings.ReplaceAll(string(candidate.section), "_", " "), candidate.score})
+		}
 	}
 
-	var strongest, weakest string
-	var highestScore, lowestScore float64
-	first := true
-
-	for component, score := range componentMap {
-		if first {
-			strongest = component
-			weakest = component
-			highestScore = score
-			lowestScore = score
-			first = false
-		} else {
-			if score > highestScore {
-				strongest = component
-				highestScore = score
+	switch len(components) {
+	case 0:
+	case 1:
+		assessment += fmt.Sprintf("The only area scored is %s (%.1f). ", components[0].name, components[0].score)
+	default:
+		strongest, weakest := components[0], components[0]
+		for _, c := range components[1:] {
+			if c.score > strongest.score {
+				strongest = c
 			}
-			if score < lowestScore {
-				weakest = component
-				lowestScore = score
+			if c.score < weakest.score {
+				weakest = c
 			}
 		}
+		assessment += fmt.Sprintf("The strongest area is %s (%.1f), while %s (%.1f) requires the most attention. ",
+			strongest.name, strongest.score, weakest.name, weakest.score)
 	}
 
-	assessment += fmt.Sprintf("The strongest area is %s (%.1f), while %s (%.1f) requires the most attention. ",
-		strongest, highestScore, weakest, lowestScore)
-
-	// Add recommendation based on grade
-	switch qualityGrade {
-	case "Excellent":
+	// Add recommendation based on the grade's tier, which does not depend on the grade scale
+	switch gradeTier(overallScore, qr.config.Thresholds) {
+	case 0:
 		assessment += "Continue current practices and focus on maintaining quality standards."
-	case "Good":
+	case 1:
 		assessment += "Minor improvements recommended to achieve excellence."
-	case "Fair":
+	case 2:
 		assessment += "Moderate quality improvements needed to reduce technical risk."
 	default: // Poor
 		assessment += "Significant quality improvements required to ensure project success."
@@ -2542,7 +3287,7 @@ func (qr *QualityReporter) generateTrendAnalysis(scores ComponentScores) *Qualit
 	// For MVP implementation, create mock trend data
 	// In production, this would analyze historical data
 
-	now := time.Now()
+	now := qr.now()
 
 	// Create sample historical data points (last 4 weeks)
 	var historicalData []HistoricalDataPoint
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RecommendationDetail controls how much of each recommendation the report keeps
+type RecommendationDetail string
+
+const (
+	// DetailFull keeps every recommendation field and the generic process advice
+	DetailFull RecommendationDetail = "full"
+	// DetailStandard drops the advice every report carries whatever was found: the generic
+	// executive next steps and the technical debt management process recommendation
+	DetailStandard RecommendationDetail = "standard"
+	// DetailMinimal also strips recommendations down to title, files, effort and actions
+	DetailMinimal RecommendationDetail = "minimal"
+)
+
+// RecommendationDetails are the accepted recommendation detail levels, least detailed first
+var RecommendationDetails = []RecommendationDetail{DetailMinimal, DetailStandard, DetailFull}
+
+// ParseRecommendationDetail parses a recommendation detail level such as "minimal"
+func ParseRecommendationDetail(value string) (RecommendationDetail, error) {
+	detail := RecommendationDetail(strings.ToLower(strings.TrimSpace(value)))
+	for _, d := range RecommendationDetails {
+		if d == detail {
+			return detail, nil
+		}
+	}
+	return "", fmt.Errorf("unknown recommendation detail %q: expected minimal, standard or full", value)
+}
+
+// genericAdvice reports whether the report keeps the advice that does not depend on the
+// findings; it does at full detail, the default
+func (detail RecommendationDetail) genericAdvice() bool {
+	return detail == "" || detail == DetailFull
+}
+
+// applyRecommendationDetail trims the recommendations of report to the configured detail
+// level. It runs on the finished report, so the roadmap and scores still see every field.
+func (qr *QualityReporter) applyRecommendationDetail(report *QualityReport) {
+	detail := qr.config.RecommendationDetail
+	if detail.genericAdvice() {
+		return
+	}
+
+	qr.applySectionDetail(report.DetailedMetrics.TechnicalDebt)
+
+	if detail != DetailMinimal {
+		return
+	}
+	for i := range report.Recommendations {
+		recommendation := &report.Recommendations[i]
+		recommendation.Description = ""
+		recommendation.Benefits = nil
+		recommendation.Risks = nil
+		recommendation.Dependencies = nil
+		recommendation.Timeline = ""
+		recommendation.References = nil
+	}
+}
+
+// applySectionDetail trims the recommendations a section carries, the technical debt
+// process advice, to the configured detail level; streamed sections go through it too, so
+// they match the finished report
+func (qr *QualityReporter) applySectionDetail(metrics interface{}) {
+	debt, ok := metrics.(*TechnicalDebtMetrics)
+	if !ok || debt == nil || qr.config.RecommendationDetail.genericAdvice() {
+		return
+	}
+	recommendations := make([]DebtRecommendation, 0, len(debt.Recommendations))
+	for _, recommendation := range debt.Recommendations {
+		if recommendation.Type != "process" {
+			recommendations = append(recommendations, recommendation)
+		}
+	}
+	debt.Recommendations = recommendations
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecommendationDetail(t *testing.T) {
+	detail, err := ParseRecommendationDetail(" Minimal")
+	require.NoError(t, err)
+	assert.Equal(t, DetailMinimal, detail)
+
+	_, err = ParseRecommendationDetail("terse")
+	assert.ErrorContains(t, err, `unknown recommendation detail "terse"`)
+}
+
+func TestGenerateQualityReport_RecommendationDetail(t *testing.T) {
+	files := map[string]string{
+		"src/a.js": "function a(x) {\n  if (x) {\n    return 1;\n  }\n  return 2;\n}\n",
+	}
+	generate := func(detail RecommendationDetail) *QualityReport {
+		report, err := NewQualityReporter(QualityReportConfig{IncludeExecutiveSummary: true, RecommendationDetail: detail}).
+			GenerateQualityReport(context.Background(), files)
+		require.NoError(t, err)
+		return report
+	}
+	processRecommendations := func(report *QualityReport) int {
+		count := 0
+		for _, recommendation := range report.DetailedMetrics.TechnicalDebt.Recommendations {
+			if recommendation.Type == "process" {
+				count++
+			}
+		}
+		return count
+	}
+
+	full := generate("")
+	require.NotEmpty(t, full.Recommendations)
+	assert.Contains(t, full.ExecutiveSummary.NextSteps, "Establish quality gates and monitoring processes")
+	assert.Equal(t, 1, processRecommendations(full))
+	assert.NotEmpty(t, full.Recommendations[0].Benefits)
+
+	var streamed *TechnicalDebtMetrics
+	_, err := NewQualityReporter(QualityReportConfig{RecommendationDetail: DetailStandard}).
+		GenerateQualityReportWithSections(context.Background(), files, func(section ReportSection) {
+			if section.Name == SectionTechnicalDebt {
+				streamed = section.Metrics.(*TechnicalDebtMetrics)
+			}
+		})
+	require.NoError(t, err)
+	require.NotNil(t, streamed)
+	for _, recommendation := range streamed.Recommendations {
+		assert.NotEqual(t, "process", recommendation.Type, "streamed sections are trimmed like the report")
+	}
+
+	standard := generate(DetailStandard)
+	assert.NotContains(t, standard.ExecutiveSummary.NextSteps, "Establish quality gates and monitoring processes")
+	assert.Zero(t, processRecommendations(standard))
+	assert.Equal(t, full.Recommendations, standard.Recommendations)
+
+	minimal := generate(DetailMinimal)
+	require.Len(t, minimal.Recommendations, len(full.Recommendations))
+	assert.Equal(t, full.OverallScore, minimal.OverallScore)
+	for i, recommendation := range minimal.Recommendations {
+		assert.Equal(t, full.Recommendations[i].Title, recommendation.Title)
+		assert.Equal(t, full.Recommendations[i].Files, recommendation.Files)
+		assert.Equal(t, full.Recommendations[i].EffortHours, recommendation.EffortHours)
+		assert.Equal(t, full.Recommendations[i].Actions, recommendation.Actions)
+		assert.Empty(t, recommendation.Description)
+		assert.Nil(t, recommendation.Benefits)
+		assert.Nil(t, recommendation.Risks)
+		assert.Empty(t, recommendation.Timeline)
+	}
+}
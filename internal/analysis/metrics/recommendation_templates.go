@@ -0,0 +1,287 @@
+package metrics
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/recommendation_templates.yaml
+var defaultRecommendationTemplatesYAML []byte
+
+// Finding types whose recommendation wording comes from templates
+const (
+	RecommendationComplexity           = "complexity"
+	RecommendationDuplication          = "duplication"
+	RecommendationTechnicalDebt        = "technical_debt"
+	RecommendationCoverage             = "coverage"
+	RecommendationPerformance          = "performance"
+	RecommendationMaintainability      = "maintainability"
+	RecommendationMaintainabilityTrend = "maintainability_trend"
+	RecommendationDocumentation        = "documentation"
+	RecommendationAPIDocumentation     = "api_documentation"
+	RecommendationLookupTable          = "lookup_table"
+)
+
+// RecommendationTemplate is the wording of one type of recommendation. Every string is a
+// Go text/template executed with a RecommendationContext. In an override file, fields left
+// out keep the built-in wording.
+type RecommendationTemplate struct {
+	Title       string            `yaml:"title"`
+	Description string            `yaml:"description"`
+	Actions     map[string]string `yaml:"actions"` // description by action type: refactor, test, ...
+	Benefits    []string          `yaml:"benefits"`
+	Risks       []string          `yaml:"risks"`
+	Links       []string          `yaml:"links"` // playbooks and guides, listed as the recommendation's references
+}
+
+// RecommendationContext is the data a recommendation template is executed with
+type RecommendationContext struct {
+	Finding     interface{}            // analyzer result the recommendation is about, e.g. a FunctionComplexity
+	File        string                 // file the recommendation targets; empty when it spans files
+	EffortHours float64                // estimated effort
+	Values      map[string]interface{} // values derived for the finding type, see the built-in templates
+}
+
+// RecommendationTemplates holds the parsed template of every finding type
+type RecommendationTemplates struct {
+	definitions map[string]RecommendationTemplate
+	parsed      map[string]*template.Template
+}
+
+// recommendationText is a rendered recommendation template
+type recommendationText struct {
+	Title       string
+	Description string
+	Actions     map[string]string
+	Benefits    []string
+	Risks       []string
+	Links       []string
+}
+
+var recommendationTemplateFuncs = template.FuncMap{"join": strings.Join}
+
+var (
+	defaultRecommendationTemplatesOnce sync.Once
+	defaultRecommendationTemplates     *RecommendationTemplates
+)
+
+// DefaultRecommendationTemplates returns the built-in templates, shipped as
+// data/recommendation_templates.yaml. Callers must not modify them.
+func DefaultRecommendationTemplates() *RecommendationTemplates {
+	defaultRecommendationTemplatesOnce.Do(func() {
+		var definitions map[string]RecommendationTemplate
+		if err := yaml.Unmarshal(defaultRecommendationTemplatesYAML, &definitions); err != nil {
+			panic(fmt.Sprintf("invalid embedded recommendation templates: %v", err))
+		}
+		templates, err := newRecommendationTemplates(definitions)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded recommendation templates: %v", err))
+		}
+		defaultRecommendationTemplates = templates
+	})
+	return defaultRecommendationTemplates
+}
+
+// LoadRecommendationTemplates reads every *.yaml and *.yml file in dir, in name order, and
+// merges them over the built-in templates. Each file maps finding types to templates; a
+// field set in a file replaces that field of the type, and actions are replaced by type.
+// performance.<anti-pattern type> entries word the recommendations of one anti-pattern.
+func LoadRecommendationTemplates(dir string) (*RecommendationTemplates, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to read recommendation templates: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.y*ml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recommendation templates: %w", err)
+	}
+	sort.Strings(paths)
+
+	definitions := make(map[string]RecommendationTemplate)
+	for kind, definition := range DefaultRecommendationTemplates().definitions {
+		definitions[kind] = definition
+	}
+	for _, path := range paths {
+		if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recommendation templates: %w", err)
+		}
+		var overrides map[string]RecommendationTemplate
+		if err := yaml.Unmarshal(data, &overrides); err != nil {
+			return nil, fmt.Errorf("invalid recommendation templates in %s: %w", path, err)
+		}
+		for _, kind := range sortedKeys(overrides) {
+			base, ok := definitions[kind]
+			if !ok && !strings.HasPrefix(kind, RecommendationPerformance+".") {
+				return nil, fmt.Errorf("invalid recommendation templates in %s: unknown finding type %q", path, kind)
+			}
+			if !ok {
+				base = definitions[RecommendationPerformance]
+			}
+			definitions[kind] = mergeRecommendationTemplate(base, overrides[kind])
+		}
+	}
+
+	templates, err := newRecommendationTemplates(definitions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recommendation templates in %s: %w", dir, err)
+	}
+	return templates, nil
+}
+
+// mergeRecommendationTemplate lays the fields set in override over base
+func mergeRecommendationTemplate(base, override RecommendationTemplate) RecommendationTemplate {
+	merged := base
+	if override.Title != "" {
+		merged.Title = override.Title
+	}
+	if override.Description != "" {
+		merged.Description = override.Description
+	}
+	if override.Actions != nil {
+		merged.Actions = make(map[string]string, len(base.Actions))
+		for actionType, description := range base.Actions {
+			merged.Actions[actionType] = description
+		}
+		for actionType, description := range override.Actions {
+			merged.Actions[actionType] = description
+		}
+	}
+	if override.Benefits != nil {
+		merged.Benefits = override.Benefits
+	}
+	if override.Risks != nil {
+		merged.Risks = override.Risks
+	}
+	if override.Links != nil {
+		merged.Links = override.Links
+	}
+	return merged
+}
+
+// newRecommendationTemplates parses every template string of definitions
+func newRecommendationTemplates(definitions map[string]RecommendationTemplate) (*RecommendationTemplates, error) {
+	templates := &RecommendationTemplates{
+		definitions: definitions,
+		parsed:      make(map[string]*template.Template),
+	}
+	for _, kind := range sortedKeys(definitions) {
+		definition := definitions[kind]
+		root := template.New(kind).Funcs(recommendationTemplateFuncs).Option("missingkey=error")
+		add := func(name, text string) error {
+			if _, err := root.New(name).Parse(text); err != nil {
+				return fmt.Errorf("%s %s: %w", kind, name, err)
+			}
+			return nil
+		}
+
+		if err := add("title", definition.Title); err != nil {
+			return nil, err
+		}
+		if err := add("description", definition.Description); err != nil {
+			return nil, err
+		}
+		for _, actionType := range sortedKeys(definition.Actions) {
+			if err := add("actions."+actionType, definition.Actions[actionType]); err != nil {
+				return nil, err
+			}
+		}
+		for i, text := range definition.Benefits {
+			if err := add(fmt.Sprintf("benefits.%d", i), text); err != nil {
+				return nil, err
+			}
+		}
+		for i, text := range definition.Risks {
+			if err := add(fmt.Sprintf("risks.%d", i), text); err != nil {
+				return nil, err
+			}
+		}
+		for i, text := range definition.Links {
+			if err := add(fmt.Sprintf("links.%d", i), text); err != nil {
+				return nil, err
+			}
+		}
+		templates.parsed[kind] = root
+	}
+	return templates, nil
+}
+
+// render executes the templates of a finding type. A performance anti-pattern type with
+// its own entry uses it over the generic one.
+func (rt *RecommendationTemplates) render(kind, specific string, context RecommendationContext) (recommendationText, error) {
+	if _, ok := rt.definitions[kind+"."+specific]; specific != "" && ok {
+		kind = kind + "." + specific
+	}
+	definition, ok := rt.definitions[kind]
+	if !ok {
+		return recommendationText{}, fmt.Errorf("no recommendation template for %q", kind)
+	}
+	root := rt.parsed[kind]
+
+	var err error
+	execute := func(name string) string {
+		if err != nil {
+			return ""
+		}
+		var out bytes.Buffer
+		if execErr := root.ExecuteTemplate(&out, name, context); execErr != nil {
+			err = fmt.Errorf("%s %s: %w", kind, name, execErr)
+		}
+		return out.String()
+	}
+	list := func(prefix string, count int) []string {
+		items := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			items = append(items, execute(fmt.Sprintf("%s.%d", prefix, i)))
+		}
+		return items
+	}
+
+	text := recommendationText{
+		Title:       execute("title"),
+		Description: execute("description"),
+		Actions:     make(map[string]string, len(definition.Actions)),
+		Benefits:    list("benefits", len(definition.Benefits)),
+		Risks:       list("risks", len(definition.Risks)),
+		Links:       list("links", len(definition.Links)),
+	}
+	for _, actionType := range sortedKeys(definition.Actions) {
+		text.Actions[actionType] = execute("actions." + actionType)
+	}
+	return text, err
+}
+
+// TemplateErrorHandler is told about a configured recommendation template that failed to
+// execute, once per finding type; err names the template
+type TemplateErrorHandler func(kind string, err error)
+
+// recommendationText words a recommendation with the configured templates. A template
+// that fails on the finding's data, such as one naming a field the finding does not have,
+// falls back to the built-in wording and is reported to OnTemplateError.
+func (qr *QualityReporter) recommendationText(kind, specific string, context RecommendationContext) recommendationText {
+	if templates := qr.config.RecommendationTemplates; templates != nil {
+		text, err := templates.render(kind, specific, context)
+		if err == nil {
+			return text
+		}
+		if _, reported := qr.templateFailures.LoadOrStore(kind, true); !reported && qr.config.OnTemplateError != nil {
+			qr.config.OnTemplateError(kind, err)
+		}
+	}
+	text, err := DefaultRecommendationTemplates().render(kind, "", context)
+	if err != nil {
+		panic(fmt.Sprintf("built-in recommendation template failed: %v", err))
+	}
+	return text
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func complexFunction() *ComplexityMetrics {
+	return &ComplexityMetrics{FunctionMetrics: []FunctionComplexity{
+		{Name: "route", FilePath: "src/router.js", StartLine: 1, EndLine: 40, CyclomaticValue: 22, FanIn: 3},
+	}}
+}
+
+func TestRecommendationTemplates_DefaultWording(t *testing.T) {
+	qr := NewQualityReporter(QualityReportConfig{})
+
+	recommendations := qr.generateComplexityRecommendations(complexFunction())
+	require.Len(t, recommendations, 1)
+	assert.Equal(t, "Refactor high complexity function: route", recommendations[0].Title)
+	assert.Equal(t, "Function has cyclomatic complexity of 22 (threshold: 15); called from 3 places", recommendations[0].Description)
+	assert.Equal(t, "Extract methods to reduce complexity", recommendations[0].Actions[0].Description)
+	assert.Len(t, recommendations[0].Benefits, 3)
+	assert.Empty(t, recommendations[0].References)
+
+	apiSurface := &APISurface{TotalExports: 14, UndocumentedCount: 12}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+		apiSurface.Entries = append(apiSurface.Entries, APIEntry{Name: name, FilePath: "src/index.js"})
+	}
+	api := qr.generateAPISurfaceRecommendations(apiSurface)
+	require.Len(t, api, 1)
+	assert.Equal(t, "12 of 14 exports have no preceding doc comment: a, b, c, d, e, f, g, h, i, j and 2 more", api[0].Description)
+	assert.Equal(t, []string{}, api[0].Risks)
+}
+
+func TestLoadRecommendationTemplates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "team.yaml"), []byte(`
+complexity:
+  description: '{{.Finding.Name}} has complexity {{.Finding.CyclomaticValue}}, see the playbook'
+  actions:
+    refactor: 'Split {{.Finding.Name}} along its branches'
+  links:
+    - 'https://wiki.example.com/playbooks/{{.Finding.Name}}'
+performance.nested_loops:
+  title: 'Flatten the loops in {{.File}}'
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a template"), 0o644))
+
+	templates, err := LoadRecommendationTemplates(dir)
+	require.NoError(t, err)
+	qr := NewQualityReporter(QualityReportConfig{RecommendationTemplates: templates})
+
+	recommendation := qr.generateComplexityRecommendations(complexFunction())[0]
+	assert.Equal(t, "Refactor high complexity function: route", recommendation.Title, "fields left out keep the built-in wording")
+	assert.Equal(t, "route has complexity 22, see the playbook", recommendation.Description)
+	assert.Equal(t, "Split route along its branches", recommendation.Actions[0].Description)
+	assert.Equal(t, "Add unit tests for new extracted methods", recommendation.Actions[1].Description)
+	assert.Equal(t, []string{"https://wiki.example.com/playbooks/route"}, recommendation.References)
+
+	performance := &PerformanceMetrics{AntiPatterns: []AntiPattern{
+		{Type: "nested_loops", Severity: "high", FilePath: "src/search.js", Description: "Nested loops"},
+		{Type: "memory_leak", Severity: "high", FilePath: "src/cache.js", Description: "Leaking listener"},
+	}}
+	perf := qr.generatePerformanceRecommendations(performance)
+	require.Len(t, perf, 2)
+	assert.Equal(t, "Flatten the loops in src/search.js", perf[0].Title)
+	assert.Equal(t, "Nested loops", perf[0].Description, "a performance.<type> entry starts from the performance template")
+	assert.Equal(t, "Fix memory_leak anti-pattern", perf[1].Title)
+}
+
+func TestLoadRecommendationTemplates_Errors(t *testing.T) {
+	_, err := LoadRecommendationTemplates(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "typo.yaml"), []byte("complexty:\n  title: 'x'\n"), 0o644))
+	_, err = LoadRecommendationTemplates(dir)
+	assert.ErrorContains(t, err, `unknown finding type "complexty"`)
+
+	dir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("complexity:\n  title: '{{.Finding.Name'\n"), 0o644))
+	_, err = LoadRecommendationTemplates(dir)
+	assert.ErrorContains(t, err, "complexity title")
+}
+
+func TestRecommendationTemplates_FallBackOnMissingField(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "team.yaml"), []byte("complexity:\n  title: 'Refactor {{.Finding.Owner}}'\n"), 0o644))
+	templates, err := LoadRecommendationTemplates(dir)
+	require.NoError(t, err)
+
+	var failures []string
+	qr := NewQualityReporter(QualityReportConfig{
+		RecommendationTemplates: templates,
+		OnTemplateError: func(kind string, err error) {
+			failures = append(failures, kind+": "+err.Error())
+		},
+	})
+	recommendation := qr.generateComplexityRecommendations(complexFunction())[0]
+	assert.Equal(t, "Refactor high complexity function: route", recommendation.Title)
+
+	qr.generateComplexityRecommendations(complexFunction())
+	require.Len(t, failures, 1, "a failing template is reported once")
+	assert.Contains(t, failures[0], "complexity title")
+}
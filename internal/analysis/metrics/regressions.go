@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ScoreDrop is a score that fell below its baseline value
+type ScoreDrop struct {
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+	Change   float64 `json:"change"` // always negative
+}
+
+// RegressionReport lists what got worse since a baseline report: the findings the baseline
+// did not have and the scores that dropped. Recommendations are advice rather than
+// findings and are not compared.
+type RegressionReport struct {
+	ProjectName         string               `json:"project_name"`
+	GeneratedAt         time.Time            `json:"generated_at"`
+	BaselineGeneratedAt time.Time            `json:"baseline_generated_at"`
+	ScoreDrops          map[string]ScoreDrop `json:"score_drops"` // by component, "overall" for the overall score
+	NewFindings         []Finding            `json:"new_findings"`
+}
+
+// HasRegressions reports whether anything got worse
+func (r *RegressionReport) HasRegressions() bool {
+	return len(r.ScoreDrops) > 0 || len(r.NewFindings) > 0
+}
+
+// FindRegressions compares report with an earlier baseline report of the same repository.
+// Findings are matched by kind, category, file and message rather than by ID or line, so
+// unrelated edits that shift lines or renumber findings are not regressions. A finding
+// present n times in the baseline only counts as new from its n+1th occurrence. Like the
+// gate, informational and accepted debt items are never regressions. The overall scores are
+// compared with the configured weights, so a --focus run does not regress by reweighting.
+func FindRegressions(report, baseline *QualityReport) *RegressionReport {
+	regressions := &RegressionReport{
+		ProjectName:         report.ProjectName,
+		GeneratedAt:         report.GeneratedAt,
+		BaselineGeneratedAt: baseline.GeneratedAt,
+		ScoreDrops:          make(map[string]ScoreDrop),
+		NewFindings:         []Finding{},
+	}
+
+	scored := report.QualityGate != QualityGateNotApplicable && baseline.QualityGate != QualityGateNotApplicable
+	if scored {
+		addScoreDrop(regressions.ScoreDrops, "overall", baseline.DefaultWeightedScore(), report.DefaultWeightedScore())
+		for _, component := range benchmarkComponents {
+			if !report.ComponentScores.isAvailable(component.key) || !baseline.ComponentScores.isAvailable(component.key) {
+				continue
+			}
+			addScoreDrop(regressions.ScoreDrops, component.key,
+				component.score(baseline.ComponentScores), component.score(report.ComponentScores))
+		}
+	}
+
+	known := make(map[string]int)
+	for _, finding := range Findings(baseline) {
+		if canRegress(finding) {
+			known[findingFingerprint(finding)]++
+		}
+	}
+	for _, finding := range Findings(report) {
+		if !canRegress(finding) {
+			continue
+		}
+		if fingerprint := findingFingerprint(finding); known[fingerprint] > 0 {
+			known[fingerprint]--
+			continue
+		}
+		regressions.NewFindings = append(regressions.NewFindings, finding)
+	}
+	return regressions
+}
+
+// canRegress reports whether a finding can be a regression: recommendations are advice,
+// and debt items that do not count toward the gate are not held against a change either
+func canRegress(finding Finding) bool {
+	if finding.Kind == FindingRecommendation {
+		return false
+	}
+	if item, ok := finding.Data.(TechnicalDebtItem); ok {
+		return item.gating()
+	}
+	return true
+}
+
+// addScoreDrop records component when current is below baseline at the default precision
+func addScoreDrop(drops map[string]ScoreDrop, component string, baseline, current float64) {
+	change := RoundScore(current-baseline, DefaultScorePrecision)
+	if change < 0 {
+		drops[component] = ScoreDrop{Baseline: baseline, Current: current, Change: change}
+	}
+}
+
+// findingFingerprint identifies a finding across reports
+func findingFingerprint(finding Finding) string {
+	return finding.Kind + "\x00" + finding.Category + "\x00" + finding.File + "\x00" + finding.Message
+}
+
+// WriteRegressionsJSON writes the regression report as an indented JSON document
+func WriteRegressionsJSON(w io.Writer, regressions *RegressionReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(regressions); err != nil {
+		return fmt.Errorf("failed to write regressions: %w", err)
+	}
+	return nil
+}
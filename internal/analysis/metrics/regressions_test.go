@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// regressionFixture is a report with the given debt items and scores
+func regressionFixture(overall float64, scores ComponentScores, items ...TechnicalDebtItem) *QualityReport {
+	return &QualityReport{
+		OverallScore:    overall,
+		ComponentScores: scores,
+		Recommendations: []QualityRecommendation{{ID: "COMPLEX-1", Title: "Reduce complexity"}},
+		DetailedMetrics: DetailedMetrics{TechnicalDebt: &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+			"Code Smells": {Items: items},
+		}}},
+	}
+}
+
+func TestFindRegressions(t *testing.T) {
+	longMethod := TechnicalDebtItem{ID: "code_smell_0", Category: "Code Smells", FilePath: "src/a.js", StartLine: 10, Description: "Function 'load' is too long"}
+	baseline := regressionFixture(80, ComponentScores{Complexity: 70, Coverage: 60, Unavailable: []string{"security"}}, longMethod)
+
+	moved := longMethod
+	moved.ID, moved.StartLine = "code_smell_3", 42
+	added := TechnicalDebtItem{ID: "code_smell_0", Category: "Code Smells", FilePath: "src/b.js", Description: "Function 'save' has too many parameters"}
+	report := regressionFixture(78.5, ComponentScores{Complexity: 72, Coverage: 55, Security: 10}, added, moved, longMethod)
+
+	regressions := FindRegressions(report, baseline)
+	assert.True(t, regressions.HasRegressions())
+	assert.Equal(t, map[string]ScoreDrop{
+		"overall":  {Baseline: 80, Current: 78.5, Change: -1.5},
+		"coverage": {Baseline: 60, Current: 55, Change: -5},
+	}, regressions.ScoreDrops, "security had no baseline score to drop from")
+
+	require.Len(t, regressions.NewFindings, 2, "a moved finding is not new, a second copy of it is")
+	assert.Equal(t, "src/b.js", regressions.NewFindings[0].File)
+	assert.Equal(t, "src/a.js", regressions.NewFindings[1].File)
+}
+
+func TestFindRegressions_None(t *testing.T) {
+	item := TechnicalDebtItem{Category: "Code Smells", FilePath: "src/a.js", Description: "Function 'load' is too long"}
+	baseline := regressionFixture(80, ComponentScores{Complexity: 70}, item)
+	report := regressionFixture(80.04, ComponentScores{Complexity: 69.96})
+
+	assert.False(t, FindRegressions(report, baseline).HasRegressions(), "fixed findings and rounding noise are not regressions")
+}
+
+func TestFindRegressions_IgnoresNonGatingDebt(t *testing.T) {
+	baseline := regressionFixture(80, ComponentScores{Complexity: 70})
+	report := regressionFixture(80, ComponentScores{Complexity: 70},
+		TechnicalDebtItem{Category: "Code Smells", FilePath: "src/a.js", Severity: "critical", Description: "Low-confidence smell", Informational: true},
+		TechnicalDebtItem{Category: "Code Smells", FilePath: "src/b.js", Severity: "critical", Description: "Accepted parser complexity", Accepted: true},
+	)
+
+	assert.False(t, FindRegressions(report, baseline).HasRegressions(), "informational and accepted items do not count, as in the gate")
+
+	report.DetailedMetrics.TechnicalDebt.Categories["Code Smells"].Items[0].Informational = false
+	regressions := FindRegressions(report, baseline)
+	require.Len(t, regressions.NewFindings, 1)
+	assert.Equal(t, "src/a.js", regressions.NewFindings[0].File)
+}
+
+func TestFindRegressions_ComparesDefaultWeightedScore(t *testing.T) {
+	baseline := regressionFixture(80, ComponentScores{Complexity: 70})
+	report := regressionFixture(72, ComponentScores{Complexity: 70})
+	report.Focus = &ReportFocus{Component: SectionCoverage, Weight: FocusWeightShare, DefaultScore: 80}
+
+	assert.False(t, FindRegressions(report, baseline).HasRegressions(), "a focus reweighting alone is not a regression")
+
+	report.Focus.DefaultScore = 78
+	assert.Equal(t, ScoreDrop{Baseline: 80, Current: 78, Change: -2}, FindRegressions(report, baseline).ScoreDrops["overall"])
+}
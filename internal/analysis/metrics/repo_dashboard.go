@@ -0,0 +1,249 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Score trends of a repository between its two most recent reports
+const (
+	TrendUp   = "up"
+	TrendDown = "down"
+	TrendFlat = "flat"
+)
+
+// RepoDashboard summarizes the latest report of every repository in a directory of JSON
+// reports, such as the output of analyzing many repositories
+type RepoDashboard struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Entries     []RepoDashboardEntry `json:"entries"`           // sorted by name
+	Skipped     []string             `json:"skipped,omitempty"` // JSON files that are not reports
+}
+
+// RepoDashboardEntry is one repository of the dashboard, from its most recent report
+type RepoDashboardEntry struct {
+	Name         string    `json:"name"`
+	ReportPath   string    `json:"report_path"` // HTML report next to the JSON when there is one
+	GeneratedAt  time.Time `json:"generated_at"`
+	Grade        string    `json:"grade"`
+	OverallScore float64   `json:"overall_score"`
+	DebtHours    float64   `json:"debt_hours"`
+	Trend        string    `json:"trend,omitempty"`        // up, down or flat; empty with a single report
+	ScoreChange  float64   `json:"score_change,omitempty"` // overall score change since the previous report
+	Scored       bool      `json:"scored"`                 // false for stub reports of repositories without source
+}
+
+// LoadRepoDashboard reads every *.json report in dir. Reports are grouped by project name;
+// the latest of each is listed and the one before it gives the trend. Files that do not
+// parse as a report are listed as skipped.
+func LoadRepoDashboard(dir string, now time.Time) (*RepoDashboard, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	dashboard := &RepoDashboard{GeneratedAt: now, Entries: []RepoDashboardEntry{}}
+	byProject := make(map[string][]RepoDashboardEntry)
+	for _, path := range paths {
+		report, ok := loadDashboardReport(path)
+		if !ok {
+			dashboard.Skipped = append(dashboard.Skipped, path)
+			continue
+		}
+		entry := repoDashboardEntry(path, report)
+		byProject[entry.Name] = append(byProject[entry.Name], entry)
+	}
+
+	for _, name := range sortedKeys(byProject) {
+		reports := byProject[name]
+		sort.Slice(reports, func(i, j int) bool { return reports[i].GeneratedAt.After(reports[j].GeneratedAt) })
+
+		latest := reports[0]
+		for _, previous := range reports[1:] {
+			if !latest.Scored || !previous.Scored {
+				continue
+			}
+			latest.ScoreChange = RoundScore(latest.OverallScore-previous.OverallScore, DefaultScorePrecision)
+			latest.Trend = scoreTrend(latest.ScoreChange)
+			break
+		}
+		dashboard.Entries = append(dashboard.Entries, latest)
+	}
+	return dashboard, nil
+}
+
+// reportOnlyFields are written by every quality report, stubs included, and by none of the
+// other JSON outputs such as regressions, baselines or the dashboard itself
+var reportOnlyFields = []string{"generated_at", "overall_score", "quality_grade", "component_scores"}
+
+// loadDashboardReport reads path as a quality report; ok is false for unreadable files and
+// for JSON that lacks any of reportOnlyFields
+func loadDashboardReport(path string) (report *QualityReport, ok bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, false
+	}
+	for _, field := range reportOnlyFields {
+		if _, present := fields[field]; !present {
+			return nil, false
+		}
+	}
+
+	report = &QualityReport{}
+	if err := json.Unmarshal(data, report); err != nil || report.GeneratedAt.IsZero() {
+		return nil, false
+	}
+	return report, true
+}
+
+// repoDashboardEntry summarizes one report. Reports of analyses run outside the
+// orchestrator keep the generic project name and are named after their file instead.
+func repoDashboardEntry(path string, report *QualityReport) RepoDashboardEntry {
+	name := report.ProjectName
+	if name == "" || name == "Repository Analysis" {
+		name = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+
+	reportPath := path
+	if html := strings.TrimSuffix(path, ".json") + ReportExtension(FormatHTML); fileExists(html) {
+		reportPath = html
+	}
+
+	entry := RepoDashboardEntry{
+		Name:         name,
+		ReportPath:   reportPath,
+		GeneratedAt:  report.GeneratedAt,
+		Grade:        report.QualityGrade,
+		OverallScore: report.DefaultWeightedScore(), // comparable across runs with and without a focus
+		Scored:       report.QualityGate != QualityGateNotApplicable,
+	}
+	if report.DetailedMetrics.TechnicalDebt != nil {
+		entry.DebtHours = RoundScore(report.DetailedMetrics.TechnicalDebt.TotalDebtHours, DefaultScorePrecision)
+	}
+	return entry
+}
+
+// scoreTrend classifies an overall score change
+func scoreTrend(change float64) string {
+	switch {
+	case change > 0:
+		return TrendUp
+	case change < 0:
+		return TrendDown
+	default:
+		return TrendFlat
+	}
+}
+
+// fileExists reports whether path is an existing regular file
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// trendArrows are the symbols shown for each trend
+var trendArrows = map[string]string{
+	TrendUp:   "▲",
+	TrendDown: "▼",
+	TrendFlat: "▬",
+}
+
+// htmlRepoRow is one row of the dashboard table
+type htmlRepoRow struct {
+	RepoDashboardEntry
+	Link  string
+	Arrow string
+}
+
+const repoDashboardTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Repository Quality Dashboard</title>
+<style>
+body { font-family: Arial, sans-serif; margin: 20px; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ddd; padding: 6px 12px; text-align: left; }
+th { background: #f5f5f5; cursor: pointer; }
+td.value { text-align: right; font-variant-numeric: tabular-nums; }
+.up { color: #2e7d32; }
+.down { color: #c62828; }
+</style>
+</head>
+<body>
+<h1>Repository Quality Dashboard</h1>
+<p>{{len .Rows}} repositories. Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}. Click a column to sort.</p>
+<table id="repos">
+<thead><tr><th>Repository</th><th>Grade</th><th>Overall score</th><th>Debt (hours)</th><th>Trend</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr><td><a href="{{.Link}}">{{.Name}}</a></td><td>{{.Grade}}</td>
+{{- if .Scored}}<td class="value">{{.OverallScore}}</td>{{else}}<td class="value" data-sort="-1">n/a</td>{{end}}
+<td class="value">{{.DebtHours}}</td><td class="{{.Trend}}" data-sort="{{.ScoreChange}}">{{.Arrow}}{{if .Trend}} {{.ScoreChange}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+<script>
+document.querySelectorAll("#repos th").forEach(function (th, column) {
+  th.addEventListener("click", function () {
+    var body = document.querySelector("#repos tbody");
+    var ascending = th.dataset.order !== "asc";
+    th.dataset.order = ascending ? "asc" : "desc";
+    var value = function (row) {
+      var cell = row.cells[column];
+      var text = cell.dataset.sort !== undefined ? cell.dataset.sort : cell.textContent.trim();
+      var number = parseFloat(text);
+      return isNaN(number) ? text.toLowerCase() : number;
+    };
+    Array.from(body.rows).sort(function (a, b) {
+      var x = value(a), y = value(b);
+      var order = x < y ? -1 : x > y ? 1 : 0;
+      return ascending ? order : -order;
+    }).forEach(function (row) { body.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`
+
+// WriteRepoDashboardHTML renders the dashboard as a standalone HTML page with a sortable
+// table. Report links are made relative to baseDir, the directory the page is written to.
+func WriteRepoDashboardHTML(w io.Writer, dashboard *RepoDashboard, baseDir string) error {
+	tmpl, err := template.New("dashboard").Parse(repoDashboardTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse dashboard template: %w", err)
+	}
+
+	data := struct {
+		GeneratedAt time.Time
+		Rows        []htmlRepoRow
+	}{GeneratedAt: dashboard.GeneratedAt}
+	for _, entry := range dashboard.Entries {
+		link, err := filepath.Rel(baseDir, entry.ReportPath)
+		if err != nil {
+			link = entry.ReportPath
+		}
+		data.Rows = append(data.Rows, htmlRepoRow{
+			RepoDashboardEntry: entry,
+			Link:               filepath.ToSlash(link),
+			Arrow:              trendArrows[entry.Trend],
+		})
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render dashboard HTML: %w", err)
+	}
+	return nil
+}
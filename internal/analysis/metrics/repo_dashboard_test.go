@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDashboardReport saves a minimal report as dir/file
+func writeDashboardReport(t *testing.T, dir, file string, report *QualityReport) {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, WriteReportJSON(&out, report, true))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, file), out.Bytes(), 0644))
+}
+
+func TestLoadRepoDashboard_FocusedReport(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	writeDashboardReport(t, dir, "api-old.json", &QualityReport{
+		ProjectName: "api", GeneratedAt: now.AddDate(0, 0, -7), OverallScore: 70, QualityGrade: "C",
+	})
+	writeDashboardReport(t, dir, "api.json", &QualityReport{
+		ProjectName: "api", GeneratedAt: now, OverallScore: 60, QualityGrade: "D",
+		Focus: &ReportFocus{Component: SectionCoverage, Weight: FocusWeightShare, DefaultScore: 71},
+	})
+
+	dashboard, err := LoadRepoDashboard(dir, now)
+	require.NoError(t, err)
+	require.Len(t, dashboard.Entries, 1)
+	assert.Equal(t, 71.0, dashboard.Entries[0].OverallScore, "the score with the configured weights, like the other runs")
+	assert.Equal(t, 1.0, dashboard.Entries[0].ScoreChange)
+}
+
+func TestLoadRepoDashboard(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	writeDashboardReport(t, dir, "api-old.json", &QualityReport{
+		ProjectName: "https://github.com/acme/api.git", GeneratedAt: now.AddDate(0, 0, -7), OverallScore: 70, QualityGrade: "C",
+	})
+	writeDashboardReport(t, dir, "api.json", &QualityReport{
+		ProjectName: "https://github.com/acme/api.git", GeneratedAt: now, OverallScore: 74.5, QualityGrade: "C",
+		DetailedMetrics: DetailedMetrics{TechnicalDebt: &TechnicalDebtMetrics{TotalDebtHours: 42.25}},
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api.html"), []byte("<html></html>"), 0644))
+	writeDashboardReport(t, dir, "web.json", &QualityReport{
+		ProjectName: "Repository Analysis", GeneratedAt: now, QualityGrade: QualityGateNotApplicable, QualityGate: QualityGateNotApplicable,
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "baseline.json"), []byte(`{"name": "acme"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "regressions.json"), []byte(`{"generated_at": "2024-05-01T00:00:00Z", "regressions": []}`), 0644))
+
+	dashboard, err := LoadRepoDashboard(dir, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(dir, "baseline.json"), filepath.Join(dir, "regressions.json")}, dashboard.Skipped,
+		"JSON with a timestamp but no scores is not a report")
+	require.Len(t, dashboard.Entries, 2)
+
+	api := dashboard.Entries[0]
+	assert.Equal(t, "https://github.com/acme/api.git", api.Name)
+	assert.Equal(t, filepath.Join(dir, "api.html"), api.ReportPath, "the HTML report is preferred")
+	assert.Equal(t, TrendUp, api.Trend)
+	assert.Equal(t, 4.5, api.ScoreChange)
+	assert.Equal(t, 42.3, api.DebtHours)
+
+	web := dashboard.Entries[1]
+	assert.Equal(t, "web", web.Name, "reports with the generic project name are named after their file")
+	assert.False(t, web.Scored)
+	assert.Empty(t, web.Trend)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteRepoDashboardHTML(&out, dashboard, dir))
+	html := out.String()
+	assert.Contains(t, html, `<a href="api.html">https://github.com/acme/api.git</a>`)
+	assert.Contains(t, html, "▲ 4.5")
+	assert.Contains(t, html, `data-sort="-1">n/a</td>`)
+}
@@ -0,0 +1,214 @@
+package metrics
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultDebtChartTopN is the number of files shown in the debt bar chart when no explicit limit is given
+const DefaultDebtChartTopN = 10
+
+// Chart file names written by WriteReportCharts
+const (
+	ComponentScoresChartFile = "component_scores.svg"
+	DebtFilesChartFile       = "debt_files.svg"
+)
+
+// chartAxis is one spoke of the component score radar chart
+type chartAxis struct {
+	label string
+	score float64 // 0-100
+}
+
+// componentScoreAxes lists the component scores in the order they go around the radar
+// chart. Components that were not scanned or whose analyzer failed are left out rather
+// than charted at 0.
+func componentScoreAxes(scores ComponentScores) []chartAxis {
+	all := []struct {
+		component SectionName
+		axis      chartAxis
+	}{
+		{SectionComplexity, chartAxis{"Complexity", scores.Complexity}},
+		{SectionDuplication, chartAxis{"Duplication", scores.Duplication}},
+		{SectionTechnicalDebt, chartAxis{"Technical Debt", scores.TechnicalDebt}},
+		{SectionCoverage, chartAxis{"Coverage", scores.Coverage}},
+		{SectionPerformance, chartAxis{"Performance", scores.Performance}},
+		{SectionMaintainability, chartAxis{"Maintainability", scores.Maintainability}},
+		{SectionSecurity, chartAxis{"Security", scores.Security}},
+	}
+
+	axes := []chartAxis{}
+	for _, entry := range all {
+		if scores.isAvailable(string(entry.component)) {
+			axes = append(axes, entry.axis)
+		}
+	}
+	return axes
+}
+
+// Radar chart layout, in pixels
+const (
+	radarSize   = 480
+	radarRadius = 150
+)
+
+// WriteComponentScoresSVG renders the component scores as a standalone SVG radar chart,
+// with rings at every 25 points
+func WriteComponentScoresSVG(w io.Writer, scores ComponentScores) error {
+	axes := componentScoreAxes(scores)
+	center := float64(radarSize) / 2
+
+	// point returns the position of score on the spoke of axis i, starting straight up
+	point := func(i int, score float64) (float64, float64) {
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(len(axes))
+		r := radarRadius * math.Max(0, math.Min(score, 100)) / 100
+		return center + r*math.Cos(angle), center + r*math.Sin(angle)
+	}
+	polygon := func(score func(i int) float64) string {
+		points := make([]string, len(axes))
+		for i := range axes {
+			x, y := point(i, score(i))
+			points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+		return strings.Join(points, " ")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="Arial, sans-serif" font-size="12">`+"\n", radarSize, radarSize)
+	fmt.Fprintf(&b, `<text x="%.0f" y="20" text-anchor="middle" font-size="16">Component Scores</text>`+"\n", center)
+
+	for _, ring := range []float64{25, 50, 75, 100} {
+		fmt.Fprintf(&b, `<polygon points="%s" fill="none" stroke="#dddddd"/>`+"\n", polygon(func(int) float64 { return ring }))
+	}
+	for i, axis := range axes {
+		x, y := point(i, 100)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#dddddd"/>`+"\n", center, center, x, y)
+
+		lx, ly := point(i, 118)
+		anchor := "middle"
+		if lx < center-1 {
+			anchor = "end"
+		} else if lx > center+1 {
+			anchor = "start"
+		}
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="%s" dominant-baseline="middle">%s (%.0f)</text>`+"\n",
+			lx, ly, anchor, axis.label, axis.score)
+	}
+
+	fmt.Fprintf(&b, `<polygon points="%s" fill="#3498db" fill-opacity="0.35" stroke="#2171b5" stroke-width="2"/>`+"\n",
+		polygon(func(i int) float64 { return axes[i].score }))
+	b.WriteString("</svg>\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write component score chart: %w", err)
+	}
+	return nil
+}
+
+// Bar chart layout, in pixels
+const (
+	debtChartLabelWidth = 360
+	debtChartBarWidth   = 300
+	debtChartRowHeight  = 24
+)
+
+// WriteDebtFilesSVG renders the topN files with the most technical debt hours as a standalone
+// SVG bar chart. A topN of zero or less uses DefaultDebtChartTopN.
+func WriteDebtFilesSVG(w io.Writer, debt *TechnicalDebtMetrics, topN int) error {
+	if topN <= 0 {
+		topN = DefaultDebtChartTopN
+	}
+
+	var files []FileDebt
+	if debt != nil {
+		for _, filePath := range sortedKeys(debt.FileDebtScores) {
+			if file := debt.FileDebtScores[filePath]; file.DebtHours > 0 {
+				file.FilePath = filePath
+				files = append(files, file)
+			}
+		}
+	}
+	// Most debt first; file path order is kept for ties
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].DebtHours > files[j].DebtHours
+	})
+	if len(files) > topN {
+		files = files[:topN]
+	}
+
+	maxHours := 0.0
+	for _, file := range files {
+		maxHours = math.Max(maxHours, file.DebtHours)
+	}
+
+	width := debtChartLabelWidth + debtChartBarWidth + 80
+	height := debtChartRowHeight * (len(files) + 2)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="Arial, sans-serif" font-size="12">`+"\n", width, height)
+	fmt.Fprintf(&b, `<text x="4" y="%d" font-size="16" dominant-baseline="middle">Top Technical Debt Files (hours)</text>`+"\n", debtChartRowHeight/2)
+
+	if len(files) == 0 {
+		fmt.Fprintf(&b, `<text x="4" y="%d" dominant-baseline="middle">No technical debt found</text>`+"\n", debtChartRowHeight+debtChartRowHeight/2)
+	}
+	for i, file := range files {
+		y := (i + 1) * debtChartRowHeight
+		barWidth := int(math.Round(float64(debtChartBarWidth) * file.DebtHours / maxHours))
+
+		// File paths come from the analyzed repository, so text content is always escaped
+		fmt.Fprintf(&b, `<text x="4" y="%d" dominant-baseline="middle">%s</text>`+"\n",
+			y+debtChartRowHeight/2, template.HTMLEscapeString(file.FilePath))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+			debtChartLabelWidth, y+3, barWidth, debtChartRowHeight-6, heatmapColor(file.DebtHours/maxHours))
+		fmt.Fprintf(&b, `<text x="%d" y="%d" dominant-baseline="middle">%.1f</text>`+"\n",
+			debtChartLabelWidth+barWidth+6, y+debtChartRowHeight/2, file.DebtHours)
+	}
+	b.WriteString("</svg>\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write debt chart: %w", err)
+	}
+	return nil
+}
+
+// WriteReportCharts writes the component score radar chart and the debt bar chart of report
+// into dir, creating it if needed, and returns the paths written
+func WriteReportCharts(dir string, report *QualityReport) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create chart directory: %w", err)
+	}
+
+	charts := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{ComponentScoresChartFile, func(w io.Writer) error { return WriteComponentScoresSVG(w, report.ComponentScores) }},
+		{DebtFilesChartFile, func(w io.Writer) error {
+			return WriteDebtFilesSVG(w, report.DetailedMetrics.TechnicalDebt, DefaultDebtChartTopN)
+		}},
+	}
+
+	var paths []string
+	for _, chart := range charts {
+		path := filepath.Join(dir, chart.name)
+		file, err := os.Create(path)
+		if err != nil {
+			return paths, fmt.Errorf("failed to create chart file: %w", err)
+		}
+		err = chart.write(file)
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write %s: %w", path, closeErr)
+		}
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertWellFormedXML fails when svg does not parse as XML
+func assertWellFormedXML(t *testing.T, svg string) {
+	t.Helper()
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			assert.Equal(t, "EOF", err.Error())
+			return
+		}
+	}
+}
+
+func TestWriteComponentScoresSVG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteComponentScoresSVG(&buf, ComponentScores{
+		Complexity: 80, Duplication: 95, TechnicalDebt: 60, Coverage: 40, Performance: 100, Maintainability: 120,
+		Unavailable: []string{string(SectionSecurity)},
+	}))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, "<svg"))
+	assertWellFormedXML(t, output)
+	assert.Equal(t, 5, strings.Count(output, "<polygon"), "four rings and the score polygon")
+	assert.Equal(t, 6, strings.Count(output, "<line"), "unscanned security is not charted")
+	assert.Contains(t, output, "Coverage (40)")
+	// The top spoke is complexity at 80: 150px * 0.8 above the center
+	assert.Contains(t, output, `points="240.0,120.0 `)
+}
+
+func TestWriteDebtFilesSVG(t *testing.T) {
+	debt := &TechnicalDebtMetrics{FileDebtScores: map[string]FileDebt{
+		"src/a.js":   {FilePath: "src/a.js", DebtHours: 2},
+		"src/<b>.js": {FilePath: "src/<b>.js", DebtHours: 8},
+		"src/c.js":   {FilePath: "src/c.js", DebtHours: 4},
+		"src/d.js":   {FilePath: "src/d.js"},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDebtFilesSVG(&buf, debt, 2))
+	output := buf.String()
+
+	assertWellFormedXML(t, output)
+	assert.Equal(t, 2, strings.Count(output, "<rect"))
+	assert.Contains(t, output, "src/&lt;b&gt;.js")
+	assert.Less(t, strings.Index(output, "src/&lt;b&gt;.js"), strings.Index(output, "src/c.js"), "most debt first")
+	assert.NotContains(t, output, "src/a.js")
+	assert.Contains(t, output, `width="300"`, "the largest bar spans the full width")
+
+	buf.Reset()
+	require.NoError(t, WriteDebtFilesSVG(&buf, nil, 0))
+	assert.Contains(t, buf.String(), "No technical debt found")
+	assertWellFormedXML(t, buf.String())
+}
+
+func TestWriteReportCharts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "charts")
+	report := &QualityReport{ComponentScores: ComponentScores{Complexity: 70}}
+
+	paths, err := WriteReportCharts(dir, report)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, ComponentScoresChartFile),
+		filepath.Join(dir, DebtFilesChartFile),
+	}, paths)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), "<svg"))
+	}
+}
+
+func TestComponentScoreAxes_SkipsUnavailable(t *testing.T) {
+	axes := componentScoreAxes(ComponentScores{
+		Complexity: 80, Coverage: 0, Performance: 70,
+		Unavailable: []string{string(SectionCoverage), string(SectionSecurity)},
+	})
+
+	labels := []string{}
+	for _, axis := range axes {
+		labels = append(labels, axis.label)
+	}
+	assert.Equal(t, []string{"Complexity", "Duplication", "Technical Debt", "Performance", "Maintainability"}, labels)
+}
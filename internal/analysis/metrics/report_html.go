@@ -0,0 +1,327 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"path"
+	"strconv"
+)
+
+// htmlComponentScore is one row of the component score table
+type htmlComponentScore struct {
+	Label string
+	Score float64
+}
+
+// htmlDirectoryRow is one directory of the directory tree, indented by depth; scores are
+// preformatted so components without data show as a dash
+type htmlDirectoryRow struct {
+	Name      string
+	Indent    int // pixels
+	Files     int
+	Overall   float64
+	Scores    []string // in htmlDirectoryComponents order
+	DebtHours float64
+}
+
+// htmlDirectoryComponents are the DirectoryScore components shown in the directory tree
+var htmlDirectoryComponents = []SectionName{SectionComplexity, SectionDuplication, SectionCoverage, SectionMaintainability}
+
+// htmlProjectCommand is one row of the how-to-run table
+type htmlProjectCommand struct {
+	Purpose string
+	ProjectCommand
+}
+
+// htmlLanguageRow is one row of the language proportion table, largest language first
+type htmlLanguageRow struct {
+	Language string
+	LanguageStat
+	Bar int // pixels
+}
+
+// htmlReportData is what the HTML report template renders
+type htmlReportData struct {
+	Report      *QualityReport
+	Components  []htmlComponentScore
+	Chart       template.HTML // component score radar chart, inline SVG
+	Findings    []Finding
+	Directories []htmlDirectoryRow
+	Languages   []htmlLanguageRow
+	Commands    []htmlProjectCommand // package.json scripts, build first
+}
+
+const reportHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Quality Report: {{.Report.ProjectName}}</title>
+<style>
+body { font-family: Arial, sans-serif; margin: 20px; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ddd; padding: 6px 12px; text-align: left; }
+th { background: #f5f5f5; }
+td.value { text-align: right; font-variant-numeric: tabular-nums; }
+.summary { font-size: 18px; }
+</style>
+</head>
+<body>
+<h1>Quality Report: {{.Report.ProjectName}}</h1>
+<p class="summary">Overall score <strong>{{.Report.OverallScore}}</strong> (grade {{.Report.QualityGrade}})
+{{- if .Report.QualityGate}}, quality gate {{.Report.QualityGate}}{{end}}. Generated {{.Report.GeneratedAt.Format "2006-01-02 15:04 MST"}}.</p>
+{{- with .Report.Focus}}
+<p>Focus on <strong>{{.Component}}</strong>: the overall score is weighted toward it for this run; with the default weights it is {{.DefaultScore}}.</p>
+{{- end}}
+
+{{- with .Report.Summary}}
+<h2>Repository at a Glance</h2>
+<table>
+<tbody>
+<tr><th>Files</th><td class="value">{{.TotalFiles}}</td><td>{{.ParsedFiles}} parsed</td></tr>
+<tr><th>Lines</th><td class="value">{{.TotalLines}}</td><td></td></tr>
+<tr><th>Functions</th><td class="value">{{.Functions}}</td><td>including class methods</td></tr>
+<tr><th>Classes</th><td class="value">{{.Classes}}</td><td></td></tr>
+<tr><th>Dependencies</th><td class="value">{{.Dependencies}}</td><td>{{.ImportedPackages}} external packages imported</td></tr>
+</tbody>
+</table>
+{{- if .Directories}}
+<table>
+<thead><tr><th>Directory</th><th>Files</th><th>Lines</th></tr></thead>
+<tbody>
+{{- range .Directories}}
+<tr><td>{{.Path}}</td><td class="value">{{.Files}}</td><td class="value">{{.Lines}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .DataFiles}}
+<p>Not counted as source: {{range $i, $file := .DataFiles}}{{if $i}}, {{end}}{{.Path}} ({{.Kind}}){{end}}.</p>
+{{- end}}
+{{- if .LargeFiles}}
+<p>Too large to parse: {{range $i, $file := .LargeFiles}}{{if $i}}, {{end}}{{.Path}} ({{.Lines}} lines){{end}}.</p>
+{{- end}}
+{{- end}}
+
+{{- if .Languages}}
+<h2>Languages</h2>
+<table>
+<thead><tr><th>Language</th><th>Files</th><th>Lines</th><th>Share</th><th></th></tr></thead>
+<tbody>
+{{- range .Languages}}
+<tr><td>{{.Language}}</td><td class="value">{{.Files}}</td><td class="value">{{.Lines}}</td><td class="value">{{.Percent}}%</td><td><span style="display: inline-block; width: {{.Bar}}px; height: 10px; background: #4a90d9"></span></td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+<h2>Component Scores</h2>
+<table>
+<thead><tr><th>Component</th><th>Score</th></tr></thead>
+<tbody>
+{{- range .Components}}
+<tr><td>{{.Label}}</td><td class="value">{{.Score}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{.Chart}}
+
+{{- with .Report.HowToRun}}
+<h2>How to Build, Test and Run</h2>
+{{- if .Install}}
+<p>Install dependencies with <code>{{.Install}}</code>.</p>
+{{- end}}
+{{- if $.Commands}}
+<table>
+<thead><tr><th>Purpose</th><th>Command</th><th>Runs</th></tr></thead>
+<tbody>
+{{- range $.Commands}}
+<tr><td>{{.Purpose}}</td><td><code>{{.Command}}</code></td><td><code>{{.Script}}</code></td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- range .CI}}
+{{- if .Error}}
+<p>CI ({{.Provider}}, {{.Path}}) could not be read: {{.Error}}</p>
+{{- else}}
+<p>CI ({{.Provider}}, {{.Path}}) runs:</p>
+{{- end}}
+{{- if .Commands}}
+<pre>{{range .Commands}}{{.}}
+{{end}}</pre>
+{{- end}}
+{{- end}}
+{{- end}}
+
+{{- if .Report.Recommendations}}
+<h2>Recommendations</h2>
+<table>
+<thead><tr><th>ID</th><th>Priority</th><th>Title</th><th>Effort (hours)</th><th>ROI</th></tr></thead>
+<tbody>
+{{- range .Report.Recommendations}}
+<tr><td>{{.ID}}</td><td>{{.Priority}}</td><td>{{.Title}}</td><td class="value">{{.EffortHours}}</td><td class="value">{{.ROI}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+{{- with .Report.DetailedMetrics.TechnicalDebt}}{{with .NewInChange}}
+<h2>New Debt in This Change</h2>
+<p>{{len .Items}} debt items ({{.DebtHours}} hours) on lines changed in {{.Commits}} commits since {{.SinceRef}}.</p>
+{{- if .Items}}
+<table>
+<thead><tr><th>ID</th><th>Severity</th><th>Location</th><th>Description</th><th>Effort (hours)</th></tr></thead>
+<tbody>
+{{- range .Items}}
+<tr><td>{{.ID}}</td><td>{{.Severity}}</td><td>{{.FilePath}}:{{.StartLine}}</td><td>{{.Description}}</td><td class="value">{{.EstimatedHours}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- end}}{{end}}
+
+{{- if .Directories}}
+<h2>Directory Scores</h2>
+<table>
+<thead><tr><th>Directory</th><th>Files</th><th>Overall</th><th>Complexity</th><th>Duplication</th><th>Coverage</th><th>Maintainability</th><th>Debt (hours)</th></tr></thead>
+<tbody>
+{{- range .Directories}}
+<tr><td style="padding-left: {{.Indent}}px">{{.Name}}</td><td class="value">{{.Files}}</td><td class="value">{{.Overall}}</td>
+{{- range .Scores}}<td class="value">{{.}}</td>{{end}}<td class="value">{{.DebtHours}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+{{- with .Report.DetailedMetrics.Duplication}}{{if .RankedClusters}}
+<h2>Duplication Clusters</h2>
+<p><strong>{{.EliminableLines}}</strong> duplicated lines could be eliminated by consolidating duplicated code. The clusters wasting the most lines:</p>
+<table>
+<thead><tr><th>Cluster</th><th>Type</th><th>Lines</th><th>Wasted Lines</th><th>Instances</th></tr></thead>
+<tbody>
+{{- range .RankedClusters}}
+<tr><td>{{.ClusterID}}</td><td>{{.Type}}{{with .CopyPaste}}, {{.}}{{end}}</td><td class="value">{{.LineCount}}</td><td class="value">{{.WastedLines}}</td><td>
+{{- range $i, $instance := .Instances}}{{if $i}}<br>{{end}}{{.FilePath}}:{{.StartLine}}-{{.EndLine}}{{with .FunctionName}} ({{.}}){{end}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}{{end}}
+
+{{- with .Report.DetailedMetrics.Performance}}{{with .BundleAnalysis}}{{with .TreeShakeability}}
+<h2>Tree-Shaking</h2>
+<p>Tree-shaking score <strong>{{.Score}}</strong>: {{.DefaultImports}} default, {{.NamedImports}} named and {{.NamespaceImports}} namespace imports; {{.DefaultExports}} default and {{.NamedExports}} named exports. {{.BlockingModules}} modules default-export an object or class.</p>
+{{- if .Offenders}}
+<table>
+<thead><tr><th>Module</th><th>Default Export</th><th>Consumers</th><th>Suggestion</th></tr></thead>
+<tbody>
+{{- range .Offenders}}
+<tr><td>{{.FilePath}}:{{.StartLine}}</td><td>{{.DefaultKind}}</td><td class="value">{{.Consumers}}</td><td>{{.Suggestion}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- end}}{{end}}{{end}}
+
+{{- if .Report.ParseWarnings}}
+<h2>Parse Warnings</h2>
+<p>{{len .Report.ParseWarnings}} files did not parse cleanly. Unsupported syntax is newer than the parser grammar supports; see run_metadata.grammars for the feature level of each grammar.</p>
+<table>
+<thead><tr><th>File</th><th>Kind</th><th>Grammar</th><th>Message</th></tr></thead>
+<tbody>
+{{- range .Report.ParseWarnings}}
+<tr><td>{{.File}}{{if .Line}}:{{.Line}}{{end}}</td><td>{{.Kind}}{{if .Degraded}} (degraded parse){{end}}</td><td>{{.Grammar}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+{{- if .Findings}}
+<h2>Findings</h2>
+<table>
+<thead><tr><th>ID</th><th>Kind</th><th>Severity</th><th>Location</th><th>Message</th></tr></thead>
+<tbody>
+{{- range .Findings}}
+<tr><td>{{.ID}}</td><td>{{.Kind}}</td><td>{{.Severity}}</td><td>{{.File}}{{if .Line}}:{{.Line}}{{end}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</body>
+</html>
+`
+
+// WriteReportHTML renders the report as a standalone HTML page: the overall score, the
+// repository fact sheet, the language proportions, the component scores with the radar
+// chart, how to build, test and run the project, the recommendations, the debt new in this
+// change, the directory score tree, the largest duplication clusters, the tree-shaking
+// offenders, the parse warnings and every finding except recommendations
+func WriteReportHTML(w io.Writer, report *QualityReport) error {
+	tmpl, err := template.New("report").Parse(reportHTMLTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse report template: %w", err)
+	}
+
+	var chart bytes.Buffer
+	if err := WriteComponentScoresSVG(&chart, report.ComponentScores); err != nil {
+		return err
+	}
+
+	data := htmlReportData{
+		Report: report,
+		Chart:  template.HTML(chart.String()), // generated from numbers and fixed labels only
+	}
+	for _, axis := range componentScoreAxes(report.ComponentScores) {
+		data.Components = append(data.Components, htmlComponentScore{Label: axis.label, Score: axis.score})
+	}
+	if commands := report.HowToRun; commands != nil {
+		for _, group := range []struct {
+			purpose  string
+			commands []ProjectCommand
+		}{{"build", commands.Build}, {"test", commands.Test}, {"run", commands.Run}, {"lint", commands.Lint}, {"other", commands.Other}} {
+			for _, command := range group.commands {
+				data.Commands = append(data.Commands, htmlProjectCommand{Purpose: group.purpose, ProjectCommand: command})
+			}
+		}
+	}
+	for _, language := range sortedLanguages(report.LanguageStats) {
+		stat := report.LanguageStats[language]
+		data.Languages = append(data.Languages, htmlLanguageRow{Language: language, LanguageStat: stat, Bar: int(math.Round(stat.Percent * 2))})
+	}
+	for _, dir := range DirectoryTree(report.DirectoryScores) {
+		data.Directories = append(data.Directories, htmlDirectory(dir))
+	}
+	for _, finding := range Findings(report) {
+		if finding.Kind != FindingRecommendation {
+			data.Findings = append(data.Findings, finding)
+		}
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render report HTML: %w", err)
+	}
+	return nil
+}
+
+// htmlDirectory lays out one directory of the tree: nested directories show their last
+// path segment under their parent
+func htmlDirectory(dir DirectoryScore) htmlDirectoryRow {
+	row := htmlDirectoryRow{
+		Name:      path.Base(dir.Path),
+		Indent:    12 + 20*dir.Depth,
+		Files:     dir.Files,
+		Overall:   dir.OverallScore,
+		DebtHours: dir.DebtHours,
+	}
+	for _, component := range htmlDirectoryComponents {
+		score, ok := dir.Scores[string(component)]
+		if !ok {
+			row.Scores = append(row.Scores, "-")
+			continue
+		}
+		row.Scores = append(row.Scores, strconv.FormatFloat(score, 'f', -1, 64))
+	}
+	return row
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// WriteReportJSON writes the report as a single JSON document: indented by default, or on
+// one line when compact is set. Struct fields keep their declaration order and map-valued
+// fields such as component_health, category_breakdown and component_trends are written
+// with sorted keys, so identical reports always produce identical bytes.
+func WriteReportJSON(w io.Writer, report *QualityReport, compact bool) error {
+	encoder := json.NewEncoder(w)
+	if !compact {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// LoadReport reads a JSON report written by WriteReportJSON
+func LoadReport(path string) (*QualityReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	var report QualityReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReportJSON(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{})
+	reporter.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	report, err := reporter.GenerateQualityReport(context.Background(), createQualityReportFixture())
+	require.NoError(t, err)
+
+	var pretty, again, compact bytes.Buffer
+	require.NoError(t, WriteReportJSON(&pretty, report, false))
+	require.NoError(t, WriteReportJSON(&again, report, false))
+	require.NoError(t, WriteReportJSON(&compact, report, true))
+
+	assert.Equal(t, pretty.String(), again.String(), "output is byte-for-byte stable")
+	assert.Contains(t, pretty.String(), "\n  \"overall_score\"")
+	assert.Equal(t, 1, strings.Count(compact.String(), "\n"), "compact output is a single line")
+
+	health := compact.String()[strings.Index(compact.String(), `"component_health":`):]
+	var positions []int
+	for _, component := range sortedKeys(report.Dashboard.ComponentHealth) {
+		positions = append(positions, strings.Index(health, `"`+component+`":`))
+	}
+	assert.IsIncreasing(t, positions, "component_health keys are sorted")
+}
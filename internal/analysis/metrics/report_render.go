@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// reportExtensions are the formats RenderReport writes, with the file extension each gets
+// when several formats are written side by side
+var reportExtensions = map[ReportFormat]string{
+	FormatJSON:   ".json",
+	FormatNDJSON: ".ndjson",
+	FormatSonar:  ".sonar.json",
+	FormatHTML:   ".html",
+	FormatSARIF:  ".sarif",
+
+	FormatTestingCSV:   ".testing.csv",
+	FormatTestingBoard: ".testing.md",
+}
+
+// OutputFormats lists the formats RenderReport supports, sorted by name
+func OutputFormats() []ReportFormat {
+	formats := make([]ReportFormat, 0, len(reportExtensions))
+	for format := range reportExtensions {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i] < formats[j] })
+	return formats
+}
+
+// ReportExtension returns the file extension for format, "" for unsupported formats
+func ReportExtension(format ReportFormat) string {
+	return reportExtensions[format]
+}
+
+// ParseReportFormats parses a comma-separated list of output formats such as "json,html",
+// rejecting unsupported and repeated formats
+func ParseReportFormats(value string) ([]ReportFormat, error) {
+	var formats []ReportFormat
+	seen := make(map[ReportFormat]bool)
+	for _, name := range strings.Split(value, ",") {
+		format := ReportFormat(strings.ToLower(strings.TrimSpace(name)))
+		if _, ok := reportExtensions[format]; !ok {
+			supported := make([]string, 0, len(reportExtensions))
+			for _, f := range OutputFormats() {
+				supported = append(supported, string(f))
+			}
+			return nil, fmt.Errorf("unsupported format %q: expected %s", format, strings.Join(supported, ", "))
+		}
+		if seen[format] {
+			return nil, fmt.Errorf("format %q listed twice", format)
+		}
+		seen[format] = true
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+// RenderReport writes report to w in format. compact only affects JSON. Rendering works
+// from the finished report alone, so one analysis can be written in every format.
+func RenderReport(w io.Writer, report *QualityReport, format ReportFormat, compact bool) error {
+	switch format {
+	case FormatJSON:
+		return WriteReportJSON(w, report, compact)
+	case FormatNDJSON:
+		return WriteFindingsNDJSON(w, report)
+	case FormatSonar:
+		return WriteSonarIssues(w, report)
+	case FormatHTML:
+		return WriteReportHTML(w, report)
+	case FormatSARIF:
+		return WriteSARIF(w, report)
+	case FormatTestingCSV:
+		return WriteTestingMatrixCSV(w, report)
+	case FormatTestingBoard:
+		return WriteTestingBoard(w, report)
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReportFormats(t *testing.T) {
+	formats, err := ParseReportFormats("json, HTML,sarif")
+	require.NoError(t, err)
+	assert.Equal(t, []ReportFormat{FormatJSON, FormatHTML, FormatSARIF}, formats)
+
+	_, err = ParseReportFormats("json,pdf")
+	assert.ErrorContains(t, err, `unsupported format "pdf"`)
+
+	_, err = ParseReportFormats("json,json")
+	assert.ErrorContains(t, err, "listed twice")
+}
+
+func TestRenderReport_AllFormats(t *testing.T) {
+	report := explainFixture()
+	report.ProjectName = "<demo>"
+	report.OverallScore = 72.5
+
+	for _, format := range OutputFormats() {
+		var out bytes.Buffer
+		require.NoError(t, RenderReport(&out, report, format, false), format)
+		assert.NotEmpty(t, out.String(), format)
+		assert.NotEmpty(t, ReportExtension(format), format)
+	}
+
+	assert.Error(t, RenderReport(&bytes.Buffer{}, report, FormatMarkdown, false))
+}
+
+func TestWriteReportHTML(t *testing.T) {
+	report := explainFixture()
+	report.ProjectName = "<script>alert(1)</script>"
+	report.OverallScore = 72.5
+	report.QualityGrade = "C"
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReportHTML(&out, report))
+	html := out.String()
+
+	assert.Contains(t, html, "Overall score <strong>72.5</strong> (grade C)")
+	assert.Contains(t, html, "<svg")
+	assert.Contains(t, html, "COMPLEX-3")
+	assert.Contains(t, html, "src/orders.js:10")
+	assert.NotContains(t, html, "<script>", "repository data is escaped")
+}
+
+func TestWriteReportHTML_Summary(t *testing.T) {
+	report := explainFixture()
+	report.Summary = &RepositorySummary{
+		TotalFiles:  12,
+		TotalLines:  3400,
+		ParsedFiles: 11,
+		Languages:   map[string]float64{"JavaScript": 75, "TypeScript": 25},
+		Functions:   140,
+		Directories: []DirectorySummary{{Path: "src", Files: 10, Lines: 3000}},
+		DataFiles:   []DataFile{{Path: "package-lock.json", Kind: "lockfile"}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReportHTML(&out, report))
+	html := out.String()
+
+	assert.Contains(t, html, "Repository at a Glance")
+	assert.Contains(t, html, `<tr><th>Files</th><td class="value">12</td><td>11 parsed</td></tr>`)
+	assert.Contains(t, html, `<tr><td>src</td><td class="value">10</td><td class="value">3000</td></tr>`)
+	assert.Contains(t, html, "Not counted as source: package-lock.json (lockfile).")
+	assert.Less(t, strings.Index(html, "Repository at a Glance"), strings.Index(html, "Component Scores"), "the fact sheet comes first")
+}
+
+func TestWriteReportHTML_LanguageStats(t *testing.T) {
+	report := explainFixture()
+	report.LanguageStats = map[string]LanguageStat{
+		"TypeScript": {Files: 3, Lines: 500, Percent: 25},
+		"JavaScript": {Files: 9, Lines: 1500, Percent: 75},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReportHTML(&out, report))
+	html := out.String()
+
+	assert.Contains(t, html, "<h2>Languages</h2>")
+	assert.Contains(t, html, `<tr><td>JavaScript</td><td class="value">9</td><td class="value">1500</td><td class="value">75%</td><td><span style="display: inline-block; width: 150px;`)
+	assert.Less(t, strings.Index(html, "<td>JavaScript</td>"), strings.Index(html, "<td>TypeScript</td>"), "largest language first")
+}
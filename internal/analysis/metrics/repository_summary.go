@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// RepositorySummary is the onboarding fact sheet at the top of a report: how big the
+// repository is, what it is written in and how it is laid out
+type RepositorySummary struct {
+	TotalFiles       int                `json:"total_files"`
+	TotalLines       int                `json:"total_lines"`
+	ParsedFiles      int                `json:"parsed_files"` // files the parser could analyze
+	Languages        map[string]float64 `json:"languages"`    // share of lines by language, percent; see LanguageStats
+	Functions        int                `json:"functions"`    // includes class methods
+	Classes          int                `json:"classes"`
+	Dependencies     int                `json:"dependencies"`          // declared in the root package.json
+	ImportedPackages int                `json:"imported_packages"`     // distinct external packages imported by the source
+	Directories      []DirectorySummary `json:"directories"`           // top-level layout, "." for files in the root
+	DataFiles        []DataFile         `json:"data_files,omitempty"`  // excluded from all counts above, largest first
+	LargeFiles       []LargeFile        `json:"large_files,omitempty"` // source too large to parse, only in Languages; largest first
+}
+
+// DirectorySummary counts the analyzed files and lines under one top-level directory
+type DirectorySummary struct {
+	Path  string `json:"path"`
+	Files int    `json:"files"`
+	Lines int    `json:"lines"`
+}
+
+// BuildRepositorySummary summarizes fileContents, including files that failed to parse,
+// together with the symbols found in parseResults and the language shares of languages.
+// dependencies are the package.json version ranges by package name and may be nil.
+func BuildRepositorySummary(fileContents map[string]string, parseResults []*ast.ParseResult, dependencies map[string]string, languages map[string]LanguageStat) *RepositorySummary {
+	summary := &RepositorySummary{
+		TotalFiles:   len(fileContents),
+		ParsedFiles:  len(parseResults),
+		Languages:    make(map[string]float64),
+		Dependencies: len(dependencies),
+		Directories:  []DirectorySummary{},
+	}
+
+	directories := make(map[string]*DirectorySummary)
+	for _, file := range sortedKeys(fileContents) {
+		lines := countSourceLines(fileContents[file])
+		summary.TotalLines += lines
+
+		path := ast.NormalizePath(file)
+		top := "."
+		if i := strings.Index(path, "/"); i > 0 {
+			top = path[:i]
+		}
+		dir, ok := directories[top]
+		if !ok {
+			dir = &DirectorySummary{Path: top}
+			directories[top] = dir
+		}
+		dir.Files++
+		dir.Lines += lines
+	}
+	for _, top := range sortedKeys(directories) {
+		summary.Directories = append(summary.Directories, *directories[top])
+	}
+
+	packages := make(map[string]bool)
+	for _, result := range parseResults {
+		summary.Classes += len(result.Classes)
+		summary.Functions += len(result.Functions)
+		for _, class := range result.Classes {
+			summary.Functions += len(class.Methods)
+		}
+		for _, imp := range result.Imports {
+			if name := packageName(imp.Source); name != "" {
+				packages[name] = true
+			}
+		}
+	}
+	summary.ImportedPackages = len(packages)
+
+	for language, stat := range languages {
+		summary.Languages[language] = stat.Percent
+	}
+
+	sort.SliceStable(summary.Directories, func(i, j int) bool {
+		return summary.Directories[i].Files > summary.Directories[j].Files
+	})
+	return summary
+}
+
+// countSourceLines counts physical lines the same way the parser does: a trailing newline
+// does not start a new line
+func countSourceLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	lines := strings.Count(content, "\n")
+	if !strings.HasSuffix(content, "\n") {
+		lines++
+	}
+	return lines
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestBuildRepositorySummary(t *testing.T) {
+	files := map[string]string{
+		"src/app.ts":        "import React from 'react';\nimport { x } from './x';\nexport class App {}\n",
+		"src/lib/util.js":   "import get from 'lodash/get';\nfunction a() {}\n",
+		"index.js":          "function main() {}",
+		"scripts/broken.js": "function (",
+	}
+	parseResults := []*ast.ParseResult{
+		{
+			FilePath:  "src/app.ts",
+			Language:  "typescript",
+			LineCount: 3,
+			Imports:   []ast.ImportInfo{{Source: "react"}, {Source: "./x"}},
+			Classes:   []ast.ClassInfo{{Name: "App", Methods: []ast.FunctionInfo{{Name: "render"}}}},
+		},
+		{
+			FilePath:  "src/lib/util.js",
+			Language:  "javascript",
+			LineCount: 2,
+			Imports:   []ast.ImportInfo{{Source: "lodash/get"}},
+			Functions: []ast.FunctionInfo{{Name: "a"}},
+		},
+		{FilePath: "index.js", Language: "javascript", LineCount: 1, Functions: []ast.FunctionInfo{{Name: "main"}}},
+	}
+
+	summary := BuildRepositorySummary(files, parseResults, map[string]string{"react": "^18.0.0", "lodash": "^4.17.21"}, BuildLanguageStats(files, nil))
+
+	assert.Equal(t, 4, summary.TotalFiles)
+	assert.Equal(t, 7, summary.TotalLines, "files that fail to parse still count")
+	assert.Equal(t, 3, summary.ParsedFiles)
+	assert.Equal(t, map[string]float64{"TypeScript": 42.9, "JavaScript": 57.1}, summary.Languages)
+	assert.Equal(t, 3, summary.Functions, "class methods are counted as functions")
+	assert.Equal(t, 1, summary.Classes)
+	assert.Equal(t, 2, summary.Dependencies)
+	assert.Equal(t, 2, summary.ImportedPackages)
+	assert.Equal(t, []DirectorySummary{
+		{Path: "src", Files: 2, Lines: 5},
+		{Path: ".", Files: 1, Lines: 1},
+		{Path: "scripts", Files: 1, Lines: 1},
+	}, summary.Directories)
+}
+
+func TestGenerateQualityReport_Summary(t *testing.T) {
+	report, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), map[string]string{
+		"src/a.js": "function a() {\n  return 1;\n}\n",
+		"src/b.ts": "export class B {}\n",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, report.Summary)
+
+	assert.Equal(t, 2, report.Summary.TotalFiles)
+	assert.Equal(t, 4, report.Summary.TotalLines)
+	assert.Equal(t, []DirectorySummary{{Path: "src", Files: 2, Lines: 4}}, report.Summary.Directories)
+	assert.Equal(t, 0, report.Summary.Dependencies)
+}
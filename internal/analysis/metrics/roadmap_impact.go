@@ -0,0 +1,63 @@
+package metrics
+
+import "math"
+
+// PhaseImpactMap sets the expected score gain per component (complexity, coverage, ...)
+// for the roadmap phase of each recommendation category
+type PhaseImpactMap map[RecommendationCategory]map[string]float64
+
+// recommendationGains estimates how many score points each recommendation can win for its
+// component. A component can gain at most its headroom (100 minus its score); that
+// headroom is split across the component's recommendations in proportion to their effort,
+// so the gains of all phases together never promise more than the component can improve.
+// Recommendations for components without a score (e.g. "overall") gain nothing.
+func recommendationGains(recommendations []QualityRecommendation, scores ComponentScores) map[string]float64 {
+	headroom := make(map[string]float64)
+	for _, component := range benchmarkComponents {
+		if scores.isAvailable(component.key) {
+			headroom[component.key] = math.Max(0, 100-component.score(scores))
+		}
+	}
+
+	effort := make(map[string]float64)
+	count := make(map[string]int)
+	for _, rec := range recommendations {
+		effort[rec.Component] += rec.EffortHours
+		count[rec.Component]++
+	}
+
+	gains := make(map[string]float64, len(recommendations))
+	for _, rec := range recommendations {
+		share := 1 / float64(count[rec.Component])
+		if effort[rec.Component] > 0 {
+			share = rec.EffortHours / effort[rec.Component]
+		}
+		gains[rec.ID] = headroom[rec.Component] * share
+	}
+	return gains
+}
+
+// phaseImpact returns the expected score gain per component for the phase holding the
+// category's recommendations: the configured mapping when there is one, otherwise the
+// sum of the recommendations' gains
+func (qr *QualityReporter) phaseImpact(category RecommendationCategory, recommendations []QualityRecommendation, gains map[string]float64) map[string]float64 {
+	impact := make(map[string]float64)
+	if configured, ok := qr.config.PhaseImpact[category]; ok {
+		for component, gain := range configured {
+			impact[component] = gain
+		}
+		return impact
+	}
+
+	for _, rec := range recommendations {
+		impact[rec.Component] += gains[rec.ID]
+	}
+	for component, gain := range impact {
+		if gain <= 0 {
+			delete(impact, component)
+			continue
+		}
+		impact[component] = math.Round(gain*10) / 10
+	}
+	return impact
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateImprovementPhases_ImpactFromRecommendations(t *testing.T) {
+	recommendations := []QualityRecommendation{
+		{ID: "rec_1", Category: CategoryQuickWins, Component: "duplication", EffortHours: 4},
+		{ID: "rec_2", Category: CategoryCriticalFixes, Component: "duplication", EffortHours: 12},
+		{ID: "rec_3", Category: CategoryCriticalFixes, Component: "coverage", EffortHours: 8},
+		{ID: "rec_4", Category: CategoryLongTermGoals, Component: "overall", EffortHours: 40},
+	}
+	scores := ComponentScores{Duplication: 60, Coverage: 90, Unavailable: []string{"performance"}}
+
+	phases := NewQualityReporter(QualityReportConfig{}).createImprovementPhases(recommendations, scores)
+	require.Len(t, phases, 3)
+
+	assert.Equal(t, "Quick Wins", phases[0].Name)
+	assert.Equal(t, map[string]float64{"duplication": 10}, phases[0].ExpectedImpact,
+		"a quarter of the duplication effort wins a quarter of its 40 points of headroom")
+	assert.Equal(t, map[string]float64{"duplication": 30, "coverage": 10}, phases[1].ExpectedImpact)
+	assert.Empty(t, phases[2].ExpectedImpact, "components without a score have no gain to promise")
+}
+
+func TestCreateImprovementPhases_ConfiguredImpact(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{PhaseImpact: PhaseImpactMap{
+		CategoryQuickWins: {"complexity": 5},
+	}})
+	recommendations := []QualityRecommendation{
+		{ID: "rec_1", Category: CategoryQuickWins, Component: "duplication", EffortHours: 4},
+		{ID: "rec_2", Category: CategoryStrategicImprovements, Component: "coverage"},
+		{ID: "rec_3", Category: CategoryStrategicImprovements, Component: "coverage"},
+	}
+
+	phases := reporter.createImprovementPhases(recommendations, ComponentScores{Duplication: 50, Coverage: 40})
+	require.Len(t, phases, 2)
+	assert.Equal(t, map[string]float64{"complexity": 5}, phases[0].ExpectedImpact)
+	assert.Equal(t, map[string]float64{"coverage": 60}, phases[1].ExpectedImpact,
+		"recommendations without effort estimates share the headroom equally")
+}
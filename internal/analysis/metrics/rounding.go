@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// DefaultScorePrecision is the number of decimals emitted scores are rounded to when
+// QualityReportConfig.ScorePrecision is unset
+const DefaultScorePrecision = 1
+
+// RoundScore rounds value to precision decimal places; a negative precision leaves it as is
+func RoundScore(value float64, precision int) float64 {
+	if precision < 0 {
+		return value
+	}
+	scale := math.Pow(10, float64(precision))
+	return math.Round(value*scale) / scale
+}
+
+// roundScore rounds a score to the configured precision. Every score the report emits goes
+// through it, so JSON, markdown and console output agree on the same values.
+func (qr *QualityReporter) roundScore(value float64) float64 {
+	return RoundScore(value, qr.scorePrecision())
+}
+
+// scorePrecision returns the configured precision, DefaultScorePrecision when unset
+func (qr *QualityReporter) scorePrecision() int {
+	if qr.config.ScorePrecision != nil {
+		return *qr.config.ScorePrecision
+	}
+	return DefaultScorePrecision
+}
+
+// roundEmitted rounds, in place, every score and hours estimate reachable from metrics, such
+// as the file_analysis overall_score of coverage or the developer_hours of the executive
+// summary, which the analyzers leave at full float precision
+func (qr *QualityReporter) roundEmitted(metrics interface{}) {
+	if precision := qr.scorePrecision(); precision >= 0 && metrics != nil {
+		roundFields(reflect.ValueOf(metrics), precision)
+	}
+}
+
+// roundFields walks v, rounding the float fields and float map values roundedField selects.
+// Map values are not addressable, so struct values in maps are rounded on a copy and stored back.
+func roundFields(v reflect.Value, precision int) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			roundFields(v.Elem(), precision)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field, value := v.Type().Field(i), v.Field(i)
+			if !value.CanSet() {
+				continue
+			}
+			switch {
+			case value.Kind() == reflect.Float64:
+				if roundedField(field) {
+					value.SetFloat(RoundScore(value.Float(), precision))
+				}
+			case value.Kind() == reflect.Map && value.Type().Elem().Kind() == reflect.Float64:
+				if roundedField(field) {
+					iter := value.MapRange()
+					for iter.Next() {
+						value.SetMapIndex(iter.Key(), reflect.ValueOf(RoundScore(iter.Value().Float(), precision)).Convert(value.Type().Elem()))
+					}
+				}
+			default:
+				roundFields(value, precision)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			roundFields(v.Index(i), precision)
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			for _, key := range v.MapKeys() {
+				roundFields(v.MapIndex(key), precision)
+			}
+			return
+		}
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			roundFields(value, precision)
+			v.SetMapIndex(key, value)
+		}
+	}
+}
+
+// roundedField reports whether a float field holds a score or an hours estimate, judged by
+// its JSON name; ratios, weights and measured percentages keep their precision
+func roundedField(field reflect.StructField) bool {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	name = strings.ToLower(name)
+	return strings.Contains(name, "score") || strings.Contains(name, "hours")
+}
+
+// roundDashboard rounds the measured values shown next to the health indicators, which
+// come straight from the analyzers rather than from the already rounded component scores
+func (qr *QualityReporter) roundDashboard(dashboard *QualityDashboard) {
+	for i := range dashboard.KeyMetrics {
+		dashboard.KeyMetrics[i].Value = qr.roundScore(dashboard.KeyMetrics[i].Value)
+	}
+	for i := range dashboard.ProgressIndicators {
+		dashboard.ProgressIndicators[i].Current = qr.roundScore(dashboard.ProgressIndicators[i].Current)
+		dashboard.ProgressIndicators[i].Progress = qr.roundScore(dashboard.ProgressIndicators[i].Progress)
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundScore(t *testing.T) {
+	assert.Equal(t, 72.3, RoundScore(72.34000000001, 1))
+	assert.Equal(t, 72.35, RoundScore(72.345678, 2))
+	assert.Equal(t, 72.0, RoundScore(71.5, 0))
+	assert.Equal(t, 72.34000000001, RoundScore(72.34000000001, -1), "negative precision disables rounding")
+}
+
+func TestGenerateQualityReport_ScorePrecision(t *testing.T) {
+	files := map[string]string{
+		"src/a.js": "function a(x) {\n  if (x) {\n    return 1;\n  }\n  return 2;\n}\n",
+		"src/b.js": "function b(items) {\n  for (const item of items) {\n    if (item) { console.log(item); }\n  }\n}\n",
+	}
+	isRounded := func(value float64, precision int) bool {
+		return value == RoundScore(value, precision)
+	}
+
+	for _, precision := range []int{0, DefaultScorePrecision, 2} {
+		config := QualityReportConfig{}
+		if precision != DefaultScorePrecision {
+			config.ScorePrecision = &precision
+		}
+		report, err := NewQualityReporter(config).GenerateQualityReport(context.Background(), files)
+		require.NoError(t, err)
+
+		scores := report.ComponentScores
+		for _, score := range []float64{report.OverallScore, scores.Complexity, scores.Duplication,
+			scores.TechnicalDebt, scores.Coverage, scores.Performance, scores.Maintainability} {
+			assert.True(t, isRounded(score, precision), "score %v at precision %d", score, precision)
+		}
+		assert.Equal(t, report.OverallScore, report.Dashboard.OverallHealth.Score)
+		for _, metric := range report.Dashboard.KeyMetrics {
+			assert.True(t, isRounded(metric.Value, precision), "%s = %v at precision %d", metric.Name, metric.Value, precision)
+		}
+	}
+}
+
+func TestRoundEmitted_NestedFields(t *testing.T) {
+	qr := NewQualityReporter(QualityReportConfig{})
+	report := &QualityReport{
+		DetailedMetrics: DetailedMetrics{
+			Coverage: &CoverageMetrics{
+				FileAnalysis:     map[string]FileTestability{"src/a.js": {OverallScore: 78.80000000000001}},
+				FunctionAnalysis: []FunctionTestability{{Name: "parse", TestabilityScore: 61.33333333333333, EstimatedCoverage: 61.33333333333333}},
+			},
+			TechnicalDebt: &TechnicalDebtMetrics{
+				FileDebtScores: map[string]FileDebt{"src/a.js": {OverallScore: 12.350000000000001, DebtHours: 3.0000000000000004}},
+			},
+		},
+		DirectoryScores: DirectoryScoreMap{"src": {Scores: map[string]float64{"complexity": 78.80000000000001}}},
+	}
+
+	qr.roundEmitted(report)
+
+	assert.Equal(t, 78.8, report.DetailedMetrics.Coverage.FileAnalysis["src/a.js"].OverallScore)
+	function := report.DetailedMetrics.Coverage.FunctionAnalysis[0]
+	assert.Equal(t, 61.3, function.TestabilityScore)
+	assert.Equal(t, 61.33333333333333, function.EstimatedCoverage, "only scores and hours are rounded")
+	assert.Equal(t, 12.4, report.DetailedMetrics.TechnicalDebt.FileDebtScores["src/a.js"].OverallScore)
+	assert.Equal(t, 3.0, report.DetailedMetrics.TechnicalDebt.FileDebtScores["src/a.js"].DebtHours)
+	assert.Equal(t, 78.8, report.DirectoryScores["src"].Scores["complexity"])
+
+	precision := -1
+	unrounded := &FunctionTestability{TestabilityScore: 61.33333333333333}
+	NewQualityReporter(QualityReportConfig{ScorePrecision: &precision}).roundEmitted(unrounded)
+	assert.Equal(t, 61.33333333333333, unrounded.TestabilityScore, "negative precision disables rounding")
+}
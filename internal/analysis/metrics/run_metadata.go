@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// RunMetadata records how a report was produced, so an archived report can still be
+// explained and reproduced months later
+type RunMetadata struct {
+	ToolVersion      string              `json:"tool_version,omitempty"`
+	BuildDate        string              `json:"build_date,omitempty"`
+	GoVersion        string              `json:"go_version"` // Go runtime the tool was built with
+	AnalyzedAt       time.Time           `json:"analyzed_at"`
+	CommitSHA        string              `json:"commit_sha,omitempty"`        // HEAD of the analyzed repository; empty without git history
+	ExcludedPatterns []string            `json:"excluded_patterns,omitempty"` // directories and file globs never analyzed
+	MaxFileSize      int64               `json:"max_file_size,omitempty"`     // bytes; larger files are skipped
+	LargeFileSize    int64               `json:"large_file_size,omitempty"`   // bytes; larger files are line-counted only
+	MaxDepth         int                 `json:"max_depth,omitempty"`         // directories below the root walked; deeper ones are skipped
+	Grammars         []ast.GrammarInfo   `json:"grammars"`                    // parser grammars and the language feature level they support
+	GitDataMillis    int64               `json:"git_data_ms,omitempty"`       // wall time spent reading git history and blame
+	Enrichments      []EnrichmentStatus  `json:"enrichments,omitempty"`       // network lookups attempted and whether they ran
+	DurationMillis   int64               `json:"duration_ms"`                 // wall time of the whole analysis
+	Phases           []PhaseTiming       `json:"phases,omitempty"`            // wall time of each phase and analyzer, in run order
+	Config           QualityReportConfig `json:"config"`                      // effective configuration, defaults applied
+}
+
+// Enrichment outcomes
+const (
+	EnrichmentRan     = "ran"
+	EnrichmentSkipped = "skipped"
+)
+
+// EnrichmentStatus records whether a network-dependent enrichment, such as the OSV
+// vulnerability lookup, ran; a skipped one says why and leaves its data out of the report
+type EnrichmentStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`           // ran or skipped
+	Reason string `json:"reason,omitempty"` // why it was skipped: offline mode, a timeout, an unreachable service
+}
+
+// PhaseTiming is the wall time one phase of the run took, such as parsing or an analyzer
+type PhaseTiming struct {
+	Name   string `json:"name"`
+	Millis int64  `json:"ms"`
+}
+
+// WriteTimingTable writes the duration of each phase of the run and of the whole analysis as
+// a text table, longest share marked with a bar
+func WriteTimingTable(w io.Writer, metadata *RunMetadata) error {
+	const barWidth = 20
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-18s %10s %7s\n", "Phase", "Time", "Share")
+	for _, phase := range metadata.Phases {
+		share := 0.0
+		if metadata.DurationMillis > 0 {
+			share = float64(phase.Millis) * 100 / float64(metadata.DurationMillis)
+		}
+		bar := strings.Repeat("#", int(math.Round(share*barWidth/100)))
+		fmt.Fprintf(&b, "%-18s %10s %6.1f%% %s\n", phase.Name, formatMillis(phase.Millis), share, bar)
+	}
+	fmt.Fprintf(&b, "%-18s %10s\n", "total", formatMillis(metadata.DurationMillis))
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write timing table: %w", err)
+	}
+	return nil
+}
+
+// formatMillis formats a duration in milliseconds as seconds, e.g. 1.2s
+func formatMillis(millis int64) string {
+	return fmt.Sprintf("%.1fs", float64(millis)/1000)
+}
+
+// phaseTiming records the time since start on the reporter's clock as the named phase
+func (qr *QualityReporter) phaseTiming(name string, start time.Time) PhaseTiming {
+	return PhaseTiming{Name: name, Millis: qr.now().Sub(start).Milliseconds()}
+}
+
+// runMetadata starts the metadata of a report generated at analyzedAt; the caller that
+// collected the files fills in the tool, commit and exclusion details
+func (qr *QualityReporter) runMetadata(analyzedAt time.Time) *RunMetadata {
+	return &RunMetadata{
+		GoVersion:  runtime.Version(),
+		AnalyzedAt: analyzedAt,
+		Grammars:   ast.Grammars(),
+		Config:     qr.config,
+	}
+}
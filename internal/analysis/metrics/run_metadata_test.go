@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTimingTable(t *testing.T) {
+	metadata := &RunMetadata{
+		DurationMillis: 5000,
+		Phases:         []PhaseTiming{{Name: "parse", Millis: 1200}, {Name: "coverage", Millis: 3400}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteTimingTable(&out, metadata))
+	assert.Equal(t, "Phase                    Time   Share\n"+
+		"parse                    1.2s   24.0% #####\n"+
+		"coverage                 3.4s   68.0% ##############\n"+
+		"total                    5.0s\n", out.String())
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SARIF document constants
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// sarifLevels maps finding severities to SARIF result levels; unknown ones are warnings
+var sarifLevels = map[string]string{
+	"critical": "error",
+	"high":     "error",
+	"medium":   "warning",
+	"low":      "note",
+	"info":     "note", // informational and accepted debt items, which never gate
+}
+
+// SARIFLog is a SARIF 2.1.0 log, as read by GitHub code scanning and most CI viewers
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun holds the results of one analysis run
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes this tool and the rules its results refer to
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the analysis tool itself
+type SARIFDriver struct {
+	Name  string      `json:"name"`
+	Rules []SARIFRule `json:"rules"`
+}
+
+// SARIFRule is one rule, with the description explain prints for it
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+// SARIFResult is one finding
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+// SARIFMessage is a plain text message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation places a result in a file
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is a file and an optional line region
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is a repository-relative file path
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is the 1-based line span of a result
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// BuildSARIF converts the report's performance anti-patterns and technical debt items into
+// a SARIF log. As with BuildSonarIssues, entries without a file path are skipped.
+func BuildSARIF(report *QualityReport) SARIFLog {
+	run := SARIFRun{
+		Tool:    SARIFTool{Driver: SARIFDriver{Name: SonarEngineID, Rules: []SARIFRule{}}},
+		Results: []SARIFResult{},
+	}
+	rules := make(map[string]bool)
+	add := func(rule, severity, message, filePath string, startLine, endLine int) {
+		if filePath == "" {
+			return
+		}
+		rules[rule] = true
+
+		location := SARIFLocation{PhysicalLocation: SARIFPhysicalLocation{
+			ArtifactLocation: SARIFArtifactLocation{URI: filePath},
+		}}
+		if startLine > 0 {
+			location.PhysicalLocation.Region = &SARIFRegion{StartLine: startLine}
+			if endLine > startLine {
+				location.PhysicalLocation.Region.EndLine = endLine
+			}
+		}
+
+		level, ok := sarifLevels[severity]
+		if !ok {
+			level = "warning"
+		}
+		run.Results = append(run.Results, SARIFResult{
+			RuleID:    rule,
+			Level:     level,
+			Message:   SARIFMessage{Text: message},
+			Locations: []SARIFLocation{location},
+		})
+	}
+
+	details := report.DetailedMetrics
+	if details.Performance != nil {
+		for _, antiPattern := range details.Performance.AntiPatterns {
+			add(antiPattern.Type, antiPattern.Severity, antiPattern.Description,
+				antiPattern.FilePath, antiPattern.StartLine, antiPattern.EndLine)
+		}
+	}
+	if details.TechnicalDebt != nil {
+		for _, name := range sortedKeys(details.TechnicalDebt.Categories) {
+			for _, item := range details.TechnicalDebt.Categories[name].Items {
+				add(item.Type, item.reportedSeverity(), item.Description, item.FilePath, item.StartLine, item.EndLine)
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(rules) {
+		description := ruleDescriptions[id]
+		if description == "" {
+			description = id
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, SARIFRule{ID: id, ShortDescription: SARIFMessage{Text: description}})
+	}
+
+	return SARIFLog{Version: SARIFVersion, Schema: SARIFSchema, Runs: []SARIFRun{run}}
+}
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log
+func WriteSARIF(w io.Writer, report *QualityReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(BuildSARIF(report)); err != nil {
+		return fmt.Errorf("failed to write SARIF log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSARIF(t *testing.T) {
+	report := &QualityReport{
+		DetailedMetrics: DetailedMetrics{
+			Performance: &PerformanceMetrics{AntiPatterns: []AntiPattern{
+				{Type: "nested_loops", Description: "Nested loop", Severity: "high", FilePath: "src/a.js", StartLine: 12, EndLine: 20},
+				{Type: "bundle_optimization", Description: "No file", Severity: "medium"},
+			}},
+			TechnicalDebt: &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+				"Code Smells": {Items: []TechnicalDebtItem{
+					{Type: "long_method", FilePath: "src/b.js", Severity: "low", Description: "Long method"},
+				}},
+			}},
+		},
+	}
+
+	log := BuildSARIF(report)
+	assert.Equal(t, SARIFVersion, log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+
+	require.Len(t, run.Results, 2, "entries without a file are skipped")
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, &SARIFRegion{StartLine: 12, EndLine: 20}, run.Results[0].Locations[0].PhysicalLocation.Region)
+	assert.Equal(t, "note", run.Results[1].Level)
+	assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation.Region)
+
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "long_method", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, ruleDescriptions["nested_loops"], run.Tool.Driver.Rules[1].ShortDescription.Text)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteSARIF(&out, &QualityReport{}))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, SARIFSchema, decoded["$schema"])
+	run := decoded["runs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, run["results"])
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import "reflect"
+
+// sectionSnapshot returns a deep copy of a section's metrics for a SectionHandler. The
+// reporter keeps working on the original after the section is streamed (grading
+// performance, marking informational findings, rounding), so handing it out would let a
+// handler that keeps the section see half-finished values or race with the report.
+func sectionSnapshot(metrics interface{}) interface{} {
+	if metrics == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(metrics)).Interface()
+}
+
+// deepCopy copies v and everything it points to. Unexported struct fields are copied
+// shallowly; the metrics types only hold exported data and values such as time.Time.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		dst := reflect.New(v.Type().Elem())
+		dst.Elem().Set(deepCopy(v.Elem()))
+		return dst
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		dst := reflect.New(v.Type()).Elem()
+		dst.Set(deepCopy(v.Elem()))
+		return dst
+	case reflect.Struct:
+		dst := reflect.New(v.Type()).Elem()
+		dst.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				dst.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return dst
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		dst := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			dst.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return dst
+	case reflect.Array:
+		dst := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			dst.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return dst
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		dst := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return dst
+	}
+	return v
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSectionSnapshot_IsIndependent(t *testing.T) {
+	item := TechnicalDebtItem{ID: "a", Severity: "high", Metadata: map[string]interface{}{"lines": 10}}
+	debt := &TechnicalDebtMetrics{Categories: map[string]DebtCategory{"Code Smells": {Items: []TechnicalDebtItem{item}}}}
+
+	snapshot := sectionSnapshot(debt).(*TechnicalDebtMetrics)
+	require.Equal(t, debt, snapshot)
+
+	debt.Categories["Code Smells"].Items[0].Severity = "info"
+	debt.Categories["Code Smells"].Items[0].Metadata["lines"] = 20
+	assert.Equal(t, "high", snapshot.Categories["Code Smells"].Items[0].Severity)
+	assert.Equal(t, 10, snapshot.Categories["Code Smells"].Items[0].Metadata["lines"], "metadata keeps its types")
+	assert.Nil(t, sectionSnapshot(nil))
+}
+
+// Run with -race: a handler handing sections to another goroutine must not race with the
+// reporter finishing the report
+func TestGenerateQualityReportWithSections_HandlerMayKeepSections(t *testing.T) {
+	files := map[string]string{
+		"src/a.js": "function a(items) {\n  for (const item of items) {\n    document.querySelector('#x').append(item);\n  }\n  // TODO: batch\n}\n",
+		"src/b.js": "export function b(x) {\n  if (x > 1) { return 1; }\n  if (x > 2) { return 2; }\n  return 0;\n}\n",
+	}
+
+	sections := make(chan ReportSection, len(SelectableSections)+2)
+	var wg sync.WaitGroup
+	var kept []ReportSection
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for section := range sections {
+			_, err := json.Marshal(section.Metrics) // reads every field while the report is finished
+			assert.NoError(t, err)
+			kept = append(kept, section)
+		}
+	}()
+
+	report, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReportWithSections(context.Background(), files, func(section ReportSection) {
+		sections <- section
+	})
+	close(sections)
+	wg.Wait()
+	require.NoError(t, err)
+
+	require.NotEmpty(t, kept)
+	for _, section := range kept {
+		if section.Name == SectionPerformance {
+			assert.NotSame(t, report.DetailedMetrics.Performance, section.Metrics)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import "math"
+
+// Score points deducted per security finding
+var securityPenalties = map[string]float64{
+	"critical": 25,
+	"high":     10,
+	"medium":   4,
+	"low":      1,
+}
+
+// securitySecretPenalty is deducted per hard-coded secret; a leaked credential is as
+// serious as a critical vulnerability
+const securitySecretPenalty = 25
+
+// SecurityMetrics summarizes the dependency vulnerability and secret scans of a
+// repository. Callers that ran the scans supply it through QualityReportConfig.Security;
+// OverallScore is computed by the reporter.
+type SecurityMetrics struct {
+	OverallScore    float64        `json:"overall_score"`
+	Vulnerabilities map[string]int `json:"vulnerabilities,omitempty"` // vulnerable dependencies by severity
+	Secrets         int            `json:"secrets"`                   // hard-coded credentials found in the source
+}
+
+// scoreSecurity starts from 100 and deducts a penalty per vulnerability, weighted by
+// severity, and per secret. Unknown severities count as medium.
+func scoreSecurity(security SecurityMetrics) float64 {
+	penalty := float64(security.Secrets) * securitySecretPenalty
+	for severity, count := range security.Vulnerabilities {
+		perFinding, ok := securityPenalties[severity]
+		if !ok {
+			perFinding = securityPenalties["medium"]
+		}
+		penalty += perFinding * float64(count)
+	}
+	return math.Max(0, 100-penalty)
+}
+
+// securityMetrics returns the configured scan results with their score, or nil when no
+// scan was run
+func (qr *QualityReporter) securityMetrics() *SecurityMetrics {
+	if qr.config.Security == nil {
+		return nil
+	}
+	security := *qr.config.Security
+	security.OverallScore = scoreSecurity(security)
+	return &security
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreSecurity(t *testing.T) {
+	assert.Equal(t, 100.0, scoreSecurity(SecurityMetrics{}))
+	assert.Equal(t, 61.0, scoreSecurity(SecurityMetrics{Vulnerabilities: map[string]int{"high": 2, "medium": 4, "low": 3}}))
+	assert.Equal(t, 46.0, scoreSecurity(SecurityMetrics{Vulnerabilities: map[string]int{"critical": 1, "unknown": 1}, Secrets: 1}),
+		"unknown severities count as medium")
+	assert.Equal(t, 0.0, scoreSecurity(SecurityMetrics{Secrets: 5}))
+}
+
+func TestGenerateQualityReport_Security(t *testing.T) {
+	files := map[string]string{"src/a.js": "function a() {\n  return 1;\n}\n"}
+
+	report, err := NewQualityReporter(QualityReportConfig{Security: &SecurityMetrics{
+		Vulnerabilities: map[string]int{"high": 3},
+	}}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	scores := report.ComponentScores
+	assert.Equal(t, 70.0, scores.Security)
+	assert.NotContains(t, scores.Unavailable, string(SectionSecurity))
+	require.NotNil(t, report.DetailedMetrics.Security)
+	assert.Equal(t, 70.0, report.DetailedMetrics.Security.OverallScore)
+	assert.Equal(t, "Security", report.Dashboard.ComponentHealth["security"].Description)
+
+	expected := (scores.Complexity*0.20 + scores.Duplication*0.15 + scores.TechnicalDebt*0.25 + scores.Coverage*0.20 +
+		scores.Performance*0.10 + scores.Maintainability*0.10 + scores.Security*0.10) / 1.10
+	assert.InDelta(t, RoundScore(expected, DefaultScorePrecision), report.OverallScore, 1e-9)
+
+	var chart bytes.Buffer
+	require.NoError(t, WriteComponentScoresSVG(&chart, scores))
+	assert.Contains(t, chart.String(), "Security (70)")
+}
+
+func TestGenerateQualityReport_SecurityNotScanned(t *testing.T) {
+	files := map[string]string{"src/a.js": "function a() {\n  return 1;\n}\n"}
+
+	// Custom weights from before the security component leave it at 0
+	weights := QualityWeights{Complexity: 0.5, Duplication: 0.5}
+	report, err := NewQualityReporter(QualityReportConfig{WeightingFactors: weights}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	assert.Contains(t, report.ComponentScores.Unavailable, string(SectionSecurity))
+	assert.Nil(t, report.DetailedMetrics.Security)
+	assert.Equal(t, "Security (not scanned)", report.Dashboard.ComponentHealth["security"].Description)
+	for _, alert := range report.Dashboard.AlertsAndWarnings {
+		assert.NotEqual(t, "security", alert.Component)
+	}
+
+	scores := report.ComponentScores
+	expected := scores.Complexity*0.5 + scores.Duplication*0.5
+	assert.InDelta(t, RoundScore(expected, DefaultScorePrecision), report.OverallScore, 1e-9)
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import "fmt"
+
+// Severities a finding can be overridden to, most severe first. Informational findings are
+// reported without any score penalty and never affect the quality gate.
+var overrideSeverities = []string{"critical", "high", "medium", "low", "info"}
+
+// findingTypes are the anti-pattern, bottleneck and debt item types detectors emit; a test
+// checks the list against every literal of those types in the package
+var findingTypes = map[string]bool{
+	// performance anti-patterns
+	"n_plus_one_query":             true,
+	"sequential_async_queries":     true,
+	"sync_in_loop":                 true,
+	"nested_iteration":             true,
+	"potential_memory_leak":        true,
+	"event_listener_risk":          true,
+	"large_function":               true,
+	"repeated_dom_queries":         true,
+	"string_concatenation_in_loop": true,
+	"blocking_operation":           true,
+	"bundle_optimization":          true,
+	// performance bottlenecks
+	"high_complexity_function": true,
+	"high_coupling":            true,
+	// technical debt items
+	"long_method":         true,
+	"too_many_parameters": true,
+	"large_class":         true,
+	"too_many_methods":    true,
+	"circular_dependency": true,
+	"tight_coupling":      true,
+	"layering_violation":  true,
+	"nested_loops":        true,
+	"sync_in_async":       true,
+	"memory_leak_risk":    true,
+	"excessive_imports":   true,
+	"high_complexity":     true,
+	"exact_duplication":   true,
+	"god_object":          true,
+	"callback_pyramid":    true,
+	"large_switch":        true,
+	"commented_out_code":  true,
+	"todo_comment":        true,
+	"unhandled_async":     true,
+	"mixed_async_styles":  true,
+}
+
+// SeverityOverrides replaces the severity detectors assign to findings, keyed by finding
+// Type (e.g. "large_function": "critical"). Overrides apply to anti-patterns, bottlenecks and
+// debt items before they are scored, so penalties, priorities and the quality gate all see them.
+type SeverityOverrides map[string]string
+
+// Validate rejects severities outside overrideSeverities and returns the finding types no
+// detector emits, sorted, so callers can warn about likely typos
+func (o SeverityOverrides) Validate() ([]string, error) {
+	var unknown []string
+	for _, findingType := range sortedKeys(o) {
+		if !contains(overrideSeverities, o[findingType]) {
+			return nil, fmt.Errorf("invalid severity %q for %s: expected one of %v", o[findingType], findingType, overrideSeverities)
+		}
+		if !findingTypes[findingType] {
+			unknown = append(unknown, findingType)
+		}
+	}
+	return unknown, nil
+}
+
+// severity returns the overridden severity of findingType, or detected when not overridden
+func (o SeverityOverrides) severity(findingType, detected string) string {
+	if severity, ok := o[findingType]; ok {
+		return severity
+	}
+	return detected
+}
@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestSeverityOverrides_Validate(t *testing.T) {
+	unknown, err := SeverityOverrides{
+		"large_function":               "critical",
+		"string_concatenation_in_loop": "info",
+		"large_fuction":                "high",
+	}.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"large_fuction"}, unknown)
+
+	_, err = SeverityOverrides{"large_function": "urgent"}.Validate()
+	assert.Error(t, err)
+}
+
+func TestAnalyzeDebt_SeverityOverrides(t *testing.T) {
+	parseResults := []*ast.ParseResult{fileWith("src/app.js", 40, 0, 800)}
+
+	scorer := NewDebtScorer()
+	metrics, err := scorer.AnalyzeDebt(context.Background(), parseResults, &ComplexityMetrics{}, &DuplicationMetrics{})
+	require.NoError(t, err)
+	detected := metrics.Categories["Architecture Violations"].Items[0]
+	require.Equal(t, "god_object", detected.Type)
+
+	scorer.config.SeverityOverrides = SeverityOverrides{"god_object": "info"}
+	metrics, err = scorer.AnalyzeDebt(context.Background(), parseResults, &ComplexityMetrics{}, &DuplicationMetrics{})
+	require.NoError(t, err)
+	overridden := metrics.Categories["Architecture Violations"].Items[0]
+	assert.Equal(t, "info", overridden.Severity)
+	assert.Less(t, overridden.DebtScore, detected.DebtScore, "the override is applied before scoring")
+}
+
+func TestAnalyzePerformance_SeverityOverrides(t *testing.T) {
+	parseResults := []*ast.ParseResult{{
+		FilePath:  "src/api.js",
+		Functions: []ast.FunctionInfo{{Name: "findUserById", StartLine: 1, EndLine: 5}},
+	}}
+
+	analyzer := NewPerformanceAnalyzer()
+	metrics, err := analyzer.AnalyzePerformance(context.Background(), parseResults, &ComplexityMetrics{})
+	require.NoError(t, err)
+	require.NotEmpty(t, metrics.AntiPatterns)
+	detectedScore := metrics.OverallScore
+
+	analyzer.config.SeverityOverrides = SeverityOverrides{"n_plus_one_query": "info"}
+	metrics, err = analyzer.AnalyzePerformance(context.Background(), parseResults, &ComplexityMetrics{})
+	require.NoError(t, err)
+	assert.Equal(t, "info", metrics.AntiPatterns[0].Severity)
+	assert.Greater(t, metrics.OverallScore, detectedScore, "informational anti-patterns carry no penalty")
+
+	reporter := NewQualityReporter(QualityReportConfig{})
+	metrics.AntiPatterns[0].Severity = "critical"
+	assert.Equal(t, QualityGateFail, reporter.evaluateQualityGate(&TechnicalDebtMetrics{}, &CoverageMetrics{}, metrics, ComponentScores{}),
+		"a critical anti-pattern fails the gate")
+}
+
+func TestAnalyzePerformance_BottleneckSeverityOverrides(t *testing.T) {
+	result := &ast.ParseResult{FilePath: "src/app.js"}
+	for i := 0; i < 31; i++ {
+		result.Imports = append(result.Imports, ast.ImportInfo{Source: fmt.Sprintf("./module%d", i)})
+	}
+
+	analyzer := NewPerformanceAnalyzer()
+	analyzer.config.SeverityOverrides = SeverityOverrides{"high_coupling": "high"}
+	metrics, err := analyzer.AnalyzePerformance(context.Background(), []*ast.ParseResult{result}, &ComplexityMetrics{})
+	require.NoError(t, err)
+	require.Len(t, metrics.Bottlenecks, 1)
+	assert.Equal(t, "high_coupling", metrics.Bottlenecks[0].Type)
+	assert.Equal(t, "high", metrics.Bottlenecks[0].Severity)
+}
+
+func TestFindingTypes_CoverEmittedTypes(t *testing.T) {
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	overridable := map[string]bool{"AntiPattern": true, "PerformanceBottleneck": true, "TechnicalDebtItem": true}
+	emitted := map[string]bool{}
+	for _, file := range packages["metrics"].Files {
+		goast.Inspect(file, func(node goast.Node) bool {
+			literal, ok := node.(*goast.CompositeLit)
+			if !ok {
+				return true
+			}
+			if name, ok := literal.Type.(*goast.Ident); !ok || !overridable[name.Name] {
+				return true
+			}
+			for _, element := range literal.Elts {
+				field, ok := element.(*goast.KeyValueExpr)
+				if !ok || field.Key.(*goast.Ident).Name != "Type" {
+					continue
+				}
+				if value, ok := field.Value.(*goast.BasicLit); ok && value.Kind == token.STRING {
+					findingType, err := strconv.Unquote(value.Value)
+					require.NoError(t, err)
+					emitted[findingType] = true
+				}
+			}
+			return true
+		})
+	}
+
+	require.NotEmpty(t, emitted)
+	for findingType := range emitted {
+		assert.True(t, findingTypes[findingType], "%s is emitted but cannot be overridden", findingType)
+	}
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SonarEngineID identifies this tool as the engine of imported SonarQube issues
+const SonarEngineID = "repo-onboarding-copilot"
+
+// SonarQube issue types
+const (
+	SonarTypeBug           = "BUG"
+	SonarTypeVulnerability = "VULNERABILITY"
+	SonarTypeCodeSmell     = "CODE_SMELL"
+)
+
+// sonarSeverities maps finding severities to SonarQube severities; unknown ones are MINOR
+var sonarSeverities = map[string]string{
+	"critical": "CRITICAL",
+	"high":     "MAJOR",
+	"medium":   "MINOR",
+	"low":      "INFO",
+	"info":     "INFO", // informational and accepted debt items, which never gate
+}
+
+// sonarIssueTypes maps rules that indicate defects rather than maintainability problems;
+// every other rule is imported as a code smell
+var sonarIssueTypes = map[string]string{
+	"memory_leak_risk":      SonarTypeBug,
+	"potential_memory_leak": SonarTypeBug,
+	"event_listener_risk":   SonarTypeBug,
+}
+
+// SonarIssues is SonarQube's generic issue import format
+// (sonar.externalIssuesReportPaths)
+type SonarIssues struct {
+	Issues []SonarIssue `json:"issues"`
+}
+
+// SonarIssue is one imported issue
+type SonarIssue struct {
+	EngineID        string        `json:"engineId"`
+	RuleID          string        `json:"ruleId"`
+	Severity        string        `json:"severity"`
+	Type            string        `json:"type"`
+	PrimaryLocation SonarLocation `json:"primaryLocation"`
+	EffortMinutes   int           `json:"effortMinutes,omitempty"`
+}
+
+// SonarLocation places an issue in a file; a nil TextRange marks a file-level issue
+type SonarLocation struct {
+	Message   string          `json:"message"`
+	FilePath  string          `json:"filePath"`
+	TextRange *SonarTextRange `json:"textRange,omitempty"`
+}
+
+// SonarTextRange is the 1-based line span of an issue
+type SonarTextRange struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// BuildSonarIssues converts the report's performance anti-patterns and technical debt items
+// into SonarQube issues. Entries without a file path cannot be imported and are skipped.
+func BuildSonarIssues(report *QualityReport) SonarIssues {
+	issues := SonarIssues{Issues: []SonarIssue{}}
+	add := func(rule, severity, message, filePath string, startLine, endLine int, hours float64) {
+		if filePath == "" {
+			return
+		}
+
+		issue := SonarIssue{
+			EngineID: SonarEngineID,
+			RuleID:   rule,
+			Severity: sonarSeverity(severity),
+			Type:     SonarTypeCodeSmell,
+			PrimaryLocation: SonarLocation{
+				Message:  message,
+				FilePath: filePath,
+			},
+			EffortMinutes: int(math.Round(hours * 60)),
+		}
+		if issueType, ok := sonarIssueTypes[rule]; ok {
+			issue.Type = issueType
+		}
+		if startLine > 0 {
+			issue.PrimaryLocation.TextRange = &SonarTextRange{StartLine: startLine}
+			if endLine > startLine {
+				issue.PrimaryLocation.TextRange.EndLine = endLine
+			}
+		}
+		issues.Issues = append(issues.Issues, issue)
+	}
+
+	details := report.DetailedMetrics
+	if details.Performance != nil {
+		for _, antiPattern := range details.Performance.AntiPatterns {
+			add(antiPattern.Type, antiPattern.Severity, antiPattern.Description,
+				antiPattern.FilePath, antiPattern.StartLine, antiPattern.EndLine, 0)
+		}
+	}
+	if details.TechnicalDebt != nil {
+		for _, name := range sortedKeys(details.TechnicalDebt.Categories) {
+			for _, item := range details.TechnicalDebt.Categories[name].Items {
+				add(item.Type, item.reportedSeverity(), item.Description,
+					item.FilePath, item.StartLine, item.EndLine, item.EstimatedHours)
+			}
+		}
+	}
+	return issues
+}
+
+// sonarSeverity maps a finding severity to a SonarQube severity
+func sonarSeverity(severity string) string {
+	if mapped, ok := sonarSeverities[severity]; ok {
+		return mapped
+	}
+	return "MINOR"
+}
+
+// WriteSonarIssues writes the report as a SonarQube generic issue data document
+func WriteSonarIssues(w io.Writer, report *QualityReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(BuildSonarIssues(report)); err != nil {
+		return fmt.Errorf("failed to write SonarQube issues: %w", err)
+	}
+	return nil
+}
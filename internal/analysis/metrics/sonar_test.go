@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSonarIssues(t *testing.T) {
+	report := &QualityReport{
+		Recommendations: []QualityRecommendation{{ID: "rec_1", Title: "Not an issue"}},
+		DetailedMetrics: DetailedMetrics{
+			Performance: &PerformanceMetrics{AntiPatterns: []AntiPattern{
+				{Type: "nested_loops", Description: "Nested loop", Severity: "high", FilePath: "src/a.js", StartLine: 12, EndLine: 20},
+				{Type: "potential_memory_leak", Description: "Leak", Severity: "critical", FilePath: "src/a.js", StartLine: 30, EndLine: 30},
+				{Type: "bundle_optimization", Description: "No file", Severity: "medium"},
+			}},
+			TechnicalDebt: &TechnicalDebtMetrics{Categories: map[string]DebtCategory{
+				"Code Smells": {Items: []TechnicalDebtItem{
+					{Type: "long_method", FilePath: "src/b.js", Severity: "medium", Description: "Long method", EstimatedHours: 1.5},
+				}},
+			}},
+		},
+	}
+
+	issues := BuildSonarIssues(report).Issues
+	require.Len(t, issues, 3, "recommendations and entries without a file are not imported")
+
+	assert.Equal(t, SonarIssue{
+		EngineID: SonarEngineID,
+		RuleID:   "nested_loops",
+		Severity: "MAJOR",
+		Type:     SonarTypeCodeSmell,
+		PrimaryLocation: SonarLocation{
+			Message:   "Nested loop",
+			FilePath:  "src/a.js",
+			TextRange: &SonarTextRange{StartLine: 12, EndLine: 20},
+		},
+	}, issues[0])
+
+	assert.Equal(t, "CRITICAL", issues[1].Severity)
+	assert.Equal(t, SonarTypeBug, issues[1].Type)
+	assert.Equal(t, &SonarTextRange{StartLine: 30}, issues[1].PrimaryLocation.TextRange)
+
+	assert.Equal(t, "MINOR", issues[2].Severity)
+	assert.Equal(t, 90, issues[2].EffortMinutes)
+	assert.Nil(t, issues[2].PrimaryLocation.TextRange, "file-level issue without a line")
+}
+
+func TestWriteSonarIssues(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteSonarIssues(&out, &QualityReport{}))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, []interface{}{}, decoded["issues"], "an empty report still has an issues array")
+}
@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// ignoreDirectivePattern matches "repo-onboarding-ignore: rule[, rule...]" in a comment.
+// "all" suppresses every rule.
+var ignoreDirectivePattern = regexp.MustCompile(`repo-onboarding-ignore:\s*([\w\-*]+(?:\s*,\s*[\w\-*]+)*)`)
+
+// suppression silences rules for the lines a directive comment covers
+type suppression struct {
+	rules              map[string]bool
+	startLine, endLine int
+}
+
+// suppressionIndex holds the ignore directives of every analyzed file
+type suppressionIndex map[string][]suppression
+
+// newSuppressionIndex collects ignore directives from the comments of parseResults. A
+// directive covers the line after the comment, or the whole function or class starting
+// there, and also the comment's own line so trailing comments work.
+func newSuppressionIndex(parseResults []*ast.ParseResult) suppressionIndex {
+	index := suppressionIndex{}
+	for _, result := range parseResults {
+		for _, comment := range result.Comments {
+			match := ignoreDirectivePattern.FindStringSubmatch(comment.Text)
+			if match == nil {
+				continue
+			}
+
+			rules := make(map[string]bool)
+			for _, rule := range strings.Split(match[1], ",") {
+				rules[strings.TrimSpace(rule)] = true
+			}
+
+			target := comment.EndLine + 1
+			end := target
+			for _, function := range result.Functions {
+				if function.StartLine == target && function.EndLine > end {
+					end = function.EndLine
+				}
+			}
+			for _, class := range result.Classes {
+				if class.StartLine == target && class.EndLine > end {
+					end = class.EndLine
+				}
+			}
+
+			index[result.FilePath] = append(index[result.FilePath],
+				suppression{rules: rules, startLine: comment.StartLine, endLine: end})
+		}
+	}
+	return index
+}
+
+// suppresses reports whether a directive silences rule at line of filePath
+func (index suppressionIndex) suppresses(filePath string, line int, rule string) bool {
+	for _, s := range index[filePath] {
+		if line >= s.startLine && line <= s.endLine && (s.rules[rule] || s.rules["all"]) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterDebtItems drops suppressed debt items, counting them by type into suppressed
+func (index suppressionIndex) filterDebtItems(items []TechnicalDebtItem, suppressed map[string]int) []TechnicalDebtItem {
+	if len(index) == 0 {
+		return items
+	}
+
+	kept := items[:0]
+	for _, item := range items {
+		if index.suppresses(item.FilePath, item.StartLine, item.Type) {
+			suppressed[item.Type]++
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// filterAntiPatterns drops suppressed anti-patterns, counting them by type into suppressed
+func (index suppressionIndex) filterAntiPatterns(patterns []AntiPattern, suppressed map[string]int) []AntiPattern {
+	if len(index) == 0 {
+		return patterns
+	}
+
+	kept := patterns[:0]
+	for _, pattern := range patterns {
+		if index.suppresses(pattern.FilePath, pattern.StartLine, pattern.Type) {
+			suppressed[pattern.Type]++
+			continue
+		}
+		kept = append(kept, pattern)
+	}
+	return kept
+}
+
+// mergeSuppressedCounts adds the per-rule counts of every analyzer; nil when nothing was suppressed
+func mergeSuppressedCounts(counts ...map[string]int) map[string]int {
+	var merged map[string]int
+	for _, count := range counts {
+		for rule, n := range count {
+			if merged == nil {
+				merged = make(map[string]int)
+			}
+			merged[rule] += n
+		}
+	}
+	return merged
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestSuppressionIndex(t *testing.T) {
+	index := newSuppressionIndex([]*ast.ParseResult{{
+		FilePath: "src/grid.js",
+		Comments: []ast.CommentInfo{
+			{Text: "// repo-onboarding-ignore: nested_loops, long_method", StartLine: 4, EndLine: 4},
+			{Text: "/* repo-onboarding-ignore: all */", StartLine: 30, EndLine: 30},
+			{Text: "// repo-onboarding-ignore nested_loops", StartLine: 40, EndLine: 40}, // missing colon
+		},
+		Functions: []ast.FunctionInfo{{Name: "render", StartLine: 5, EndLine: 20}},
+	}})
+
+	assert.True(t, index.suppresses("src/grid.js", 5, "nested_loops"))
+	assert.True(t, index.suppresses("src/grid.js", 12, "nested_loops"), "covers the function below the comment")
+	assert.True(t, index.suppresses("src/grid.js", 5, "long_method"))
+	assert.False(t, index.suppresses("src/grid.js", 5, "too_many_parameters"))
+	assert.False(t, index.suppresses("src/grid.js", 21, "nested_loops"))
+	assert.False(t, index.suppresses("src/other.js", 5, "nested_loops"))
+
+	assert.True(t, index.suppresses("src/grid.js", 31, "callback_pyramid"))
+	assert.False(t, index.suppresses("src/grid.js", 32, "callback_pyramid"), "outside a function only the next line is covered")
+	assert.False(t, index.suppresses("src/grid.js", 41, "nested_loops"))
+
+	suppressed := map[string]int{}
+	items := index.filterDebtItems([]TechnicalDebtItem{
+		{Type: "nested_loops", FilePath: "src/grid.js", StartLine: 5},
+		{Type: "too_many_parameters", FilePath: "src/grid.js", StartLine: 5},
+	}, suppressed)
+	require.Len(t, items, 1)
+	assert.Equal(t, "too_many_parameters", items[0].Type)
+	assert.Equal(t, map[string]int{"nested_loops": 1}, suppressed)
+}
+
+func TestGenerateQualityReport_IgnoreComments(t *testing.T) {
+	loops := `function %s(items) {
+  const result = [];
+  for (let i = 0; i < items.length; i++) {
+    for (let j = 0; j < items.length; j++) {
+      result.push([items[i], items[j]]);
+    }
+  }
+  return result;
+}
+`
+	files := map[string]string{
+		"src/pairs.js": "// repo-onboarding-ignore: nested_loops\n" + fmt.Sprintf(loops, "crossPairs"),
+		"src/grid.js":  fmt.Sprintf(loops, "gridPairs"),
+	}
+	report, err := NewQualityReporter(QualityReportConfig{}).GenerateQualityReport(context.Background(), files)
+	require.NoError(t, err)
+
+	flagged := map[string]bool{}
+	for _, pattern := range report.DetailedMetrics.Performance.AntiPatterns {
+		if pattern.Type == "nested_loops" {
+			flagged[pattern.FilePath] = true
+		}
+	}
+	assert.False(t, flagged["src/pairs.js"], "suppressed by the ignore comment")
+	assert.True(t, flagged["src/grid.js"], "files without the comment are still flagged")
+	assert.Equal(t, 1, report.SuppressedCounts["nested_loops"])
+}
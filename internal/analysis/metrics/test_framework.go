@@ -0,0 +1,152 @@
+package metrics
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// Test frameworks the coverage analyzer can detect and recommend
+const (
+	TestFrameworkJest   = "jest"
+	TestFrameworkVitest = "vitest"
+	TestFrameworkMocha  = "mocha"
+	TestFrameworkPytest = "pytest"
+)
+
+// Sources of the test framework in CoverageMetrics
+const (
+	TestFrameworkConfigured = "configured" // CoverageConfig.TestFramework
+	TestFrameworkDetected   = "detected"   // found in config files, dependencies or test imports
+	TestFrameworkDefault    = "default"    // nothing found; Jest is assumed for JavaScript
+)
+
+// DefaultTestFilePatterns are the globs that mark test, spec and story files. A pattern
+// without a slash matches the file name at any depth and "**" matches any number of
+// directories, as in --critical-files.
+var DefaultTestFilePatterns = []string{
+	"*.test.*", "*.spec.*", "*.stories.*",
+	"**/__tests__/**", "**/__mocks__/**", "**/test/**", "**/tests/**",
+	"test_*.py", "*_test.py", "conftest.py",
+}
+
+// testFrameworkSignals lists, in order of precedence, how each framework announces itself:
+// config file name globs, then package.json dependencies and imports in test files.
+// Vitest comes first because Vitest projects often keep Jest-compatible packages around.
+var testFrameworkSignals = []struct {
+	name         string
+	configFiles  []string
+	dependencies []string
+}{
+	{TestFrameworkVitest, []string{"vitest.config.*", "vitest.workspace.*"}, []string{"vitest"}},
+	{TestFrameworkJest, []string{"jest.config.*"}, []string{"jest", "@jest/globals", "ts-jest", "babel-jest"}},
+	{TestFrameworkMocha, []string{".mocharc.*"}, []string{"mocha"}},
+	{TestFrameworkPytest, []string{"pytest.ini", "conftest.py"}, []string{}},
+}
+
+// testFrameworkMockLibraries is the mocking library that goes with each framework
+var testFrameworkMockLibraries = map[string]string{
+	TestFrameworkJest:   "jest",
+	TestFrameworkVitest: "vitest",
+	TestFrameworkMocha:  "sinon",
+	TestFrameworkPytest: "unittest.mock",
+}
+
+// TestFramework is the framework testing recommendations are written for
+type TestFramework struct {
+	Name     string `json:"name"`
+	Source   string `json:"source"`             // configured, detected or default
+	Evidence string `json:"evidence,omitempty"` // what the detection was based on
+}
+
+// DetectTestFramework picks the test framework of a repository from framework config
+// files among files, then from package.json dependencies, then from the packages that
+// test files (matched by testPatterns, nil for DefaultTestFilePatterns) import. It returns
+// a zero TestFramework when there is no sign of any framework.
+func DetectTestFramework(files []string, dependencies map[string]string, parseResults []*ast.ParseResult, testPatterns []string) TestFramework {
+	for _, signal := range testFrameworkSignals {
+		for _, file := range files {
+			for _, pattern := range signal.configFiles {
+				if ok, _ := path.Match(pattern, path.Base(ast.ToSlash(file))); ok {
+					return TestFramework{Name: signal.name, Source: TestFrameworkDetected, Evidence: "config file " + file}
+				}
+			}
+		}
+	}
+
+	for _, signal := range testFrameworkSignals {
+		for _, dependency := range signal.dependencies {
+			if _, ok := dependencies[dependency]; ok {
+				return TestFramework{Name: signal.name, Source: TestFrameworkDetected, Evidence: "package.json dependency " + dependency}
+			}
+		}
+	}
+
+	for _, signal := range testFrameworkSignals {
+		for _, result := range parseResults {
+			if !isTestFile(result.FilePath, testPatterns) {
+				continue
+			}
+			for _, imported := range result.Imports {
+				for _, dependency := range signal.dependencies {
+					if imported.Source == dependency {
+						return TestFramework{Name: signal.name, Source: TestFrameworkDetected,
+							Evidence: fmt.Sprintf("%s imports %s", result.FilePath, dependency)}
+					}
+				}
+			}
+		}
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file, ".py") && isTestFile(file, testPatterns) {
+			return TestFramework{Name: TestFrameworkPytest, Source: TestFrameworkDetected, Evidence: "Python test file " + file}
+		}
+	}
+
+	return TestFramework{}
+}
+
+// testFramework resolves the framework for an analysis: the configured preference, else
+// the detected one, else Jest
+func (ca *CoverageAnalyzer) testFramework(parseResults []*ast.ParseResult) TestFramework {
+	if ca.config.TestFramework != "" {
+		return TestFramework{Name: ca.config.TestFramework, Source: TestFrameworkConfigured}
+	}
+
+	files := make([]string, 0, len(parseResults)+len(ca.config.ProjectFiles))
+	for _, result := range parseResults {
+		files = append(files, result.FilePath)
+	}
+	files = append(files, ca.config.ProjectFiles...)
+
+	if detected := DetectTestFramework(files, ca.config.Dependencies, parseResults, ca.config.TestFilePatterns); detected.Name != "" {
+		return detected
+	}
+	return TestFramework{Name: TestFrameworkJest, Source: TestFrameworkDefault}
+}
+
+// mockLibrary returns the mocking library that goes with framework
+func mockLibrary(framework string) string {
+	if library, ok := testFrameworkMockLibraries[framework]; ok {
+		return library
+	}
+	return framework
+}
+
+// isTestFile reports whether filePath matches one of patterns, or of
+// DefaultTestFilePatterns when patterns is empty
+func isTestFile(filePath string, patterns []string) bool {
+	if len(patterns) == 0 {
+		patterns = DefaultTestFilePatterns
+	}
+	filePath = ast.ToSlash(filePath)
+	for _, pattern := range patterns {
+		if matchFileGlob(pattern, filePath) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestDetectTestFramework(t *testing.T) {
+	testImporting := func(source string) []*ast.ParseResult {
+		return []*ast.ParseResult{
+			{FilePath: "src/app.js", Imports: []ast.ImportInfo{{Source: "vitest"}}}, // not a test file
+			{FilePath: "src/app.test.js", Imports: []ast.ImportInfo{{Source: source}}},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		files        []string
+		dependencies map[string]string
+		parseResults []*ast.ParseResult
+		want         TestFramework
+	}{
+		{
+			name:         "config file wins over dependencies",
+			files:        []string{"src/app.js", "vitest.config.ts"},
+			dependencies: map[string]string{"jest": "^29.0.0"},
+			want:         TestFramework{Name: TestFrameworkVitest, Source: TestFrameworkDetected, Evidence: "config file vitest.config.ts"},
+		},
+		{
+			name:         "dev dependency",
+			dependencies: map[string]string{"mocha": "^10.0.0", "chai": "^4.0.0"},
+			want:         TestFramework{Name: TestFrameworkMocha, Source: TestFrameworkDetected, Evidence: "package.json dependency mocha"},
+		},
+		{
+			name:         "import in a test file",
+			parseResults: testImporting("@jest/globals"),
+			want:         TestFramework{Name: TestFrameworkJest, Source: TestFrameworkDetected, Evidence: "src/app.test.js imports @jest/globals"},
+		},
+		{
+			name:  "python tests",
+			files: []string{"scripts/test_release.py"},
+			want:  TestFramework{Name: TestFrameworkPytest, Source: TestFrameworkDetected, Evidence: "Python test file scripts/test_release.py"},
+		},
+		{
+			name:         "nothing found",
+			files:        []string{"src/app.js"},
+			parseResults: testImporting("assert"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectTestFramework(tt.files, tt.dependencies, tt.parseResults, nil))
+		})
+	}
+}
+
+func TestAnalyzeCoverage_TestFramework(t *testing.T) {
+	parseResults := []*ast.ParseResult{{
+		FilePath:  "src/app.js",
+		Functions: []ast.FunctionInfo{{Name: "load", StartLine: 1, EndLine: 5, IsAsync: true}},
+	}}
+
+	analyzer := NewCoverageAnalyzer()
+	analyzer.config.Dependencies = map[string]string{"vitest": "^1.0.0"}
+	metrics, err := analyzer.AnalyzeCoverage(context.Background(), parseResults, nil)
+	require.NoError(t, err)
+	assert.Equal(t, TestFrameworkVitest, metrics.TestFramework.Name)
+	assert.Equal(t, "vitest_with_comprehensive_mocking", metrics.TestingStrategy.RecommendedFramework)
+
+	analyzer.config.TestFramework = TestFrameworkMocha
+	metrics, err = analyzer.AnalyzeCoverage(context.Background(), parseResults, nil)
+	require.NoError(t, err)
+	assert.Equal(t, TestFramework{Name: TestFrameworkMocha, Source: TestFrameworkConfigured}, metrics.TestFramework)
+	assert.Equal(t, "mocha_with_comprehensive_mocking", metrics.TestingStrategy.RecommendedFramework)
+
+	metrics, err = NewCoverageAnalyzer().AnalyzeCoverage(context.Background(), parseResults, nil)
+	require.NoError(t, err)
+	assert.Equal(t, TestFramework{Name: TestFrameworkJest, Source: TestFrameworkDefault}, metrics.TestFramework)
+}
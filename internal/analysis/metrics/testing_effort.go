@@ -0,0 +1,54 @@
+package metrics
+
+// TestingEffortCalibration converts a function's testability into hours of testing work.
+// A function's estimate is the base hours of its testing difficulty plus one increment per
+// required mock, per untested path and per coverage gap. The increments add up linearly
+// and are never multiplied: with the defaults a difficult function needing two mocks, with
+// three untested paths and one gap, costs 6 + 2*1 + 3*1 + 1*1 = 12 hours.
+type TestingEffortCalibration struct {
+	BaseHours            map[string]int `yaml:"base_hours" json:"base_hours"`                                     // by difficulty: easy, moderate, difficult, very_difficult
+	HoursPerMock         *int           `yaml:"hours_per_mock" json:"hours_per_mock,omitempty"`                   // per dependency that has to be mocked
+	HoursPerUntestedPath *int           `yaml:"hours_per_untested_path" json:"hours_per_untested_path,omitempty"` // per branch, loop or error path
+	HoursPerCoverageGap  *int           `yaml:"hours_per_coverage_gap" json:"hours_per_coverage_gap,omitempty"`   // per other gap, such as missing edge cases
+}
+
+// DefaultTestingEffortCalibration returns the hours used when no calibration is configured
+func DefaultTestingEffortCalibration() TestingEffortCalibration {
+	hour := func() *int {
+		hours := 1
+		return &hours
+	}
+	return TestingEffortCalibration{
+		BaseHours: map[string]int{
+			"easy":           2,
+			"moderate":       4,
+			"difficult":      6,
+			"very_difficult": 8,
+		},
+		HoursPerMock:         hour(),
+		HoursPerUntestedPath: hour(),
+		HoursPerCoverageGap:  hour(),
+	}
+}
+
+// withDefaults fills unset hours from DefaultTestingEffortCalibration, so a calibration may
+// override a single difficulty or increment. Zero is a valid setting, e.g. to stop mocks
+// adding effort; only difficulties left out of BaseHours and nil increments are unset.
+func (c TestingEffortCalibration) withDefaults() TestingEffortCalibration {
+	calibrated := DefaultTestingEffortCalibration()
+	for difficulty, hours := range c.BaseHours {
+		if hours >= 0 {
+			calibrated.BaseHours[difficulty] = hours
+		}
+	}
+	if c.HoursPerMock != nil && *c.HoursPerMock >= 0 {
+		calibrated.HoursPerMock = c.HoursPerMock
+	}
+	if c.HoursPerUntestedPath != nil && *c.HoursPerUntestedPath >= 0 {
+		calibrated.HoursPerUntestedPath = c.HoursPerUntestedPath
+	}
+	if c.HoursPerCoverageGap != nil && *c.HoursPerCoverageGap >= 0 {
+		calibrated.HoursPerCoverageGap = c.HoursPerCoverageGap
+	}
+	return calibrated
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// testingMatrixColumns are the columns of the testing priority matrix exports
+var testingMatrixColumns = []string{"priority", "file", "function", "testing_type", "risk_score", "effort_hours", "roi"}
+
+// testingBucket is one priority tier of the testing matrix
+type testingBucket struct {
+	Priority string
+	Items    []TestingItem
+}
+
+// testingBuckets returns the tiers of matrix most urgent first, each sorted by ROI descending,
+// then by file and function so equal ROIs keep a stable order
+func testingBuckets(matrix TestingPriorityMatrix) []testingBucket {
+	buckets := []testingBucket{
+		{Priority: "critical", Items: matrix.CriticalPriority},
+		{Priority: "high", Items: matrix.HighPriority},
+		{Priority: "medium", Items: matrix.MediumPriority},
+		{Priority: "low", Items: matrix.LowPriority},
+	}
+	for i := range buckets {
+		items := append([]TestingItem{}, buckets[i].Items...)
+		sort.SliceStable(items, func(a, b int) bool {
+			if items[a].ROI != items[b].ROI {
+				return items[a].ROI > items[b].ROI
+			}
+			if items[a].FilePath != items[b].FilePath {
+				return items[a].FilePath < items[b].FilePath
+			}
+			return items[a].FunctionName < items[b].FunctionName
+		})
+		buckets[i].Items = items
+	}
+	return buckets
+}
+
+// reportTestingBuckets returns the testing matrix tiers of report, nil without coverage analysis
+func reportTestingBuckets(report *QualityReport) []testingBucket {
+	if report.DetailedMetrics.Coverage == nil {
+		return nil
+	}
+	return testingBuckets(report.DetailedMetrics.Coverage.PriorityMatrix)
+}
+
+// WriteTestingMatrixCSV writes the testing priority matrix as CSV, one function per row,
+// critical items first. A report without coverage analysis yields the header only.
+func WriteTestingMatrixCSV(w io.Writer, report *QualityReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(testingMatrixColumns); err != nil {
+		return fmt.Errorf("failed to write testing matrix: %w", err)
+	}
+	for _, bucket := range reportTestingBuckets(report) {
+		for _, item := range bucket.Items {
+			record := []string{
+				bucket.Priority,
+				item.FilePath,
+				item.FunctionName,
+				item.TestingType,
+				strconv.FormatFloat(item.RiskScore, 'f', 1, 64),
+				strconv.Itoa(item.EffortEstimate),
+				strconv.FormatFloat(item.ROI, 'f', 2, 64),
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write testing matrix: %w", err)
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write testing matrix: %w", err)
+	}
+	return nil
+}
+
+// WriteTestingBoard writes the testing priority matrix as a Markdown board: a section per
+// priority tier, each function to test a table row with an unchecked box to track it
+func WriteTestingBoard(w io.Writer, report *QualityReport) error {
+	var b strings.Builder
+	b.WriteString("# Testing Priority Board\n")
+
+	buckets := reportTestingBuckets(report)
+	if buckets == nil {
+		b.WriteString("\nThe report has no coverage analysis.\n")
+	}
+	for _, bucket := range buckets {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", strings.ToUpper(bucket.Priority[:1])+bucket.Priority[1:], len(bucket.Items))
+		if len(bucket.Items) == 0 {
+			b.WriteString("_Nothing to test._\n")
+			continue
+		}
+		b.WriteString("| Done | File | Function | Testing type | Risk score | Effort (h) | ROI |\n")
+		b.WriteString("|------|------|----------|--------------|-----------:|-----------:|----:|\n")
+		for _, item := range bucket.Items {
+			fmt.Fprintf(&b, "| ☐ | %s | %s | %s | %.1f | %d | %.2f |\n",
+				markdownCell(item.FilePath), markdownCell(item.FunctionName), markdownCell(item.TestingType),
+				item.RiskScore, item.EffortEstimate, item.ROI)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write testing board: %w", err)
+	}
+	return nil
+}
+
+// markdownCell escapes the characters that would break a Markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testingMatrixFixture is a report whose buckets are out of ROI order
+func testingMatrixFixture() *QualityReport {
+	report := explainFixture()
+	report.DetailedMetrics.Coverage = &CoverageMetrics{PriorityMatrix: TestingPriorityMatrix{
+		CriticalPriority: []TestingItem{
+			{FilePath: "src/b.js", FunctionName: "save", TestingType: "integration", RiskScore: 85, EffortEstimate: 8, ROI: 1.5},
+			{FilePath: "src/a.js", FunctionName: "load", TestingType: "unit", RiskScore: 82.5, EffortEstimate: 2, ROI: 9.25},
+		},
+		HighPriority: []TestingItem{
+			{FilePath: "src/c.js", FunctionName: "parse|split", TestingType: "unit", RiskScore: 65, EffortEstimate: 4, ROI: 4},
+		},
+	}}
+	return report
+}
+
+func TestWriteTestingMatrixCSV(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteTestingMatrixCSV(&out, testingMatrixFixture()))
+
+	assert.Equal(t, strings.Join([]string{
+		"priority,file,function,testing_type,risk_score,effort_hours,roi",
+		"critical,src/a.js,load,unit,82.5,2,9.25",
+		"critical,src/b.js,save,integration,85.0,8,1.50",
+		"high,src/c.js,parse|split,unit,65.0,4,4.00",
+	}, "\n")+"\n", out.String())
+
+	out.Reset()
+	require.NoError(t, WriteTestingMatrixCSV(&out, explainFixture()))
+	assert.Equal(t, "priority,file,function,testing_type,risk_score,effort_hours,roi\n", out.String(), "no coverage analysis")
+}
+
+func TestWriteTestingBoard(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteTestingBoard(&out, testingMatrixFixture()))
+	board := out.String()
+
+	assert.Contains(t, board, "## Critical (2)")
+	assert.Less(t, strings.Index(board, "| ☐ | src/a.js | load |"), strings.Index(board, "| ☐ | src/b.js | save |"), "highest ROI first")
+	assert.Contains(t, board, `| ☐ | src/c.js | parse\|split | unit | 65.0 | 4 | 4.00 |`)
+	assert.Contains(t, board, "## Low (0)\n\n_Nothing to test._")
+}
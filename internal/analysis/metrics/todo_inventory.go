@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// DefaultTodoTags are the comment markers collected when no tags are configured
+var DefaultTodoTags = []string{"TODO", "FIXME", "HACK", "XXX"}
+
+// TodoInventory maps known-incomplete areas of the codebase from marker comments
+type TodoInventory struct {
+	Total int            `json:"total"`
+	ByTag map[string]int `json:"by_tag"`
+	Items []TodoItem     `json:"items"`
+}
+
+// TodoItem is a single marker comment
+type TodoItem struct {
+	Tag      string `json:"tag"`
+	Text     string `json:"text"`
+	FilePath string `json:"file_path"`
+	Line     int    `json:"line"`
+}
+
+// BuildTodoInventory collects marker comments for tags from parseResults, in file and line order
+func BuildTodoInventory(parseResults []*ast.ParseResult, tags []string) *TodoInventory {
+	inventory := &TodoInventory{
+		ByTag: make(map[string]int),
+		Items: extractTodoItems(parseResults, tags),
+	}
+
+	for _, item := range inventory.Items {
+		inventory.ByTag[item.Tag]++
+	}
+	inventory.Total = len(inventory.Items)
+
+	return inventory
+}
+
+// extractTodoItems scans every comment line for one of tags. Tags are matched as whole,
+// case-sensitive words, optionally followed by an owner in parentheses: "TODO(alice): ...".
+func extractTodoItems(parseResults []*ast.ParseResult, tags []string) []TodoItem {
+	items := []TodoItem{}
+	pattern := todoPattern(tags)
+	if pattern == nil {
+		return items
+	}
+
+	for _, parseResult := range parseResults {
+		for _, comment := range parseResult.Comments {
+			for offset, line := range strings.Split(comment.Text, "\n") {
+				match := pattern.FindStringSubmatch(line)
+				if match == nil {
+					continue
+				}
+
+				text := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(match[2]), "*/"))
+				if text == "" {
+					text = strings.TrimSpace(line)
+				}
+
+				items = append(items, TodoItem{
+					Tag:      match[1],
+					Text:     text,
+					FilePath: parseResult.FilePath,
+					Line:     comment.StartLine + offset,
+				})
+			}
+		}
+	}
+
+	return items
+}
+
+// todoPattern compiles a matcher for tags; it returns nil when no tags are given
+func todoPattern(tags []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			quoted = append(quoted, regexp.QuoteMeta(tag))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	return regexp.MustCompile(`(?:^|[^\w])(` + strings.Join(quoted, "|") + `)\b(?:\([^)]*\))?[:\-\s]*(.*)`)
+}
+
+// analyzeTodoComments turns marker comments for the configured debt tags (FIXME and HACK
+// by default) into low-severity debt items; plain TODOs stay in the inventory only
+func (ds *DebtScorer) analyzeTodoComments(parseResults []*ast.ParseResult) []TechnicalDebtItem {
+	items := []TechnicalDebtItem{}
+
+	for i, todo := range extractTodoItems(parseResults, ds.config.TodoDebtTags) {
+		items = append(items, TechnicalDebtItem{
+			ID:             fmt.Sprintf("todo_comment_%d", i),
+			Type:           "todo_comment",
+			Category:       "Code Smells",
+			FilePath:       todo.FilePath,
+			StartLine:      todo.Line,
+			EndLine:        todo.Line,
+			Description:    fmt.Sprintf("%s: %s", todo.Tag, todo.Text),
+			Severity:       "low",
+			EstimatedHours: 1.0,
+			RemediationSteps: []string{
+				"Decide whether the workaround is still needed",
+				"Fix the underlying issue or track it in the issue tracker",
+				"Remove the marker comment once resolved",
+			},
+			Metadata: map[string]interface{}{
+				"tag": todo.Tag,
+			},
+		})
+	}
+
+	return items
+}
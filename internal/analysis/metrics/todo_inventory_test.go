@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func todoParseResults() []*ast.ParseResult {
+	return []*ast.ParseResult{
+		{
+			FilePath: "src/api.js",
+			Comments: []ast.CommentInfo{
+				{Text: "// TODO(alice): paginate results", StartLine: 3, EndLine: 3},
+				{Text: "// Nothing to do here", StartLine: 5, EndLine: 5},
+				{Text: "/*\n * FIXME: retries are not idempotent\n * HACK - sleep until the cache warms\n */", StartLine: 10, EndLine: 13},
+			},
+		},
+		{
+			FilePath: "src/util.js",
+			Comments: []ast.CommentInfo{
+				{Text: "// XXX", StartLine: 7, EndLine: 7},
+				{Text: "// todos are tracked elsewhere", StartLine: 8, EndLine: 8},
+			},
+		},
+	}
+}
+
+func TestBuildTodoInventory(t *testing.T) {
+	inventory := BuildTodoInventory(todoParseResults(), DefaultTodoTags)
+
+	require.Len(t, inventory.Items, 4)
+	assert.Equal(t, 4, inventory.Total)
+	assert.Equal(t, map[string]int{"TODO": 1, "FIXME": 1, "HACK": 1, "XXX": 1}, inventory.ByTag)
+
+	assert.Equal(t, TodoItem{Tag: "TODO", Text: "paginate results", FilePath: "src/api.js", Line: 3}, inventory.Items[0])
+	assert.Equal(t, TodoItem{Tag: "FIXME", Text: "retries are not idempotent", FilePath: "src/api.js", Line: 11}, inventory.Items[1])
+	assert.Equal(t, TodoItem{Tag: "HACK", Text: "sleep until the cache warms", FilePath: "src/api.js", Line: 12}, inventory.Items[2])
+	assert.Equal(t, "// XXX", inventory.Items[3].Text) // bare marker keeps the whole line
+}
+
+func TestBuildTodoInventory_CustomTags(t *testing.T) {
+	inventory := BuildTodoInventory(todoParseResults(), []string{"FIXME"})
+	require.Len(t, inventory.Items, 1)
+	assert.Equal(t, "FIXME", inventory.Items[0].Tag)
+
+	assert.Empty(t, BuildTodoInventory(todoParseResults(), nil).Items)
+}
+
+func TestAnalyzeTodoComments_DebtTags(t *testing.T) {
+	items := NewDebtScorer().analyzeTodoComments(todoParseResults())
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "todo_comment", items[0].Type)
+	assert.Equal(t, "low", items[0].Severity)
+	assert.Equal(t, "FIXME: retries are not idempotent", items[0].Description)
+	assert.Equal(t, "HACK", items[1].Metadata["tag"])
+}
+
+func TestGenerateQualityReport_IncludesTodoInventory(t *testing.T) {
+	reporter := NewQualityReporter(QualityReportConfig{})
+
+	report, err := reporter.GenerateQualityReport(context.Background(), map[string]string{
+		"src/app.js": "// TODO: handle errors\nfunction main(a) { return a; }\n",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, report.TodoInventory)
+	require.Len(t, report.TodoInventory.Items, 1)
+	assert.Equal(t, "handle errors", report.TodoInventory.Items[0].Text)
+	assert.Equal(t, 1, report.TodoInventory.Items[0].Line)
+}
@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// maxTreeShakingOffenders caps the modules listed as tree-shaking offenders
+const maxTreeShakingOffenders = 10
+
+// blockingDefaultKinds are default exports that bundle members into one value, so a
+// consumer pulls in every member even when it uses one
+var blockingDefaultKinds = map[string]string{
+	"object": "default-exported object bundles its members; unused members cannot be dropped",
+	"class":  "default-exported class keeps every method; unused methods cannot be dropped",
+}
+
+// TreeShakeability summarizes how well the project's own modules tree-shake. Named imports
+// and exports let bundlers drop unused members; default-exported objects and classes do not.
+type TreeShakeability struct {
+	Score              float64               `json:"score"` // 0-100, higher tree-shakes better
+	DefaultImports     int                   `json:"default_imports"`
+	NamedImports       int                   `json:"named_imports"`
+	NamespaceImports   int                   `json:"namespace_imports"`
+	DefaultImportRatio float64               `json:"default_import_ratio"` // default imports / default, named and namespace imports
+	DefaultExports     int                   `json:"default_exports"`
+	NamedExports       int                   `json:"named_exports"`
+	DefaultExportRatio float64               `json:"default_export_ratio"` // default exports / default and named exports
+	BlockingModules    int                   `json:"blocking_modules"`     // modules whose default export blocks tree-shaking
+	Offenders          []TreeShakingOffender `json:"offenders,omitempty"`  // the worst blocking modules, most consumers first
+}
+
+// TreeShakingOffender is an analyzed module whose default export blocks tree-shaking
+type TreeShakingOffender struct {
+	FilePath    string   `json:"file_path"`
+	StartLine   int      `json:"start_line"`
+	DefaultKind string   `json:"default_kind"`
+	Members     []string `json:"members,omitempty"` // properties of a default-exported object
+	Consumers   int      `json:"consumers"`         // analyzed files importing the default export
+	Issue       string   `json:"issue"`
+	Suggestion  string   `json:"suggestion"`
+}
+
+// analyzeTreeShakeability counts default and named imports and exports across parseResults
+// and lists the modules whose default export blocks tree-shaking, weighted by how many
+// analyzed files import that default. It returns nil when the project has no ES imports
+// or exports to judge.
+func analyzeTreeShakeability(parseResults []*ast.ParseResult) *TreeShakeability {
+	files := make(map[string]bool, len(parseResults))
+	for _, result := range parseResults {
+		files[result.FilePath] = true
+	}
+
+	analysis := &TreeShakeability{}
+	consumers := make(map[string]map[string]bool) // module -> files importing its default
+	for _, result := range parseResults {
+		for _, imp := range result.Imports {
+			switch imp.ImportType {
+			case "default":
+				analysis.DefaultImports++
+				if target, ok := resolveImport(result.FilePath, imp.Source, files); ok && target != result.FilePath {
+					if consumers[target] == nil {
+						consumers[target] = make(map[string]bool)
+					}
+					consumers[target][result.FilePath] = true
+				}
+			case "named":
+				analysis.NamedImports++
+			case "namespace":
+				analysis.NamespaceImports++
+			}
+		}
+	}
+
+	exportingModules := 0
+	for _, result := range parseResults {
+		exporting := false
+		for _, export := range result.Exports {
+			switch export.ExportType {
+			case "default":
+				analysis.DefaultExports++
+				exporting = true
+				reason, blocking := blockingDefaultKinds[export.DefaultKind]
+				if !blocking {
+					continue
+				}
+				analysis.BlockingModules++
+				analysis.Offenders = append(analysis.Offenders, TreeShakingOffender{
+					FilePath:    result.FilePath,
+					StartLine:   export.StartLine,
+					DefaultKind: export.DefaultKind,
+					Members:     append([]string{}, export.Specifiers...),
+					Consumers:   len(consumers[result.FilePath]),
+					Issue:       reason,
+					Suggestion:  treeShakingSuggestion(export),
+				})
+			case "named", "all":
+				analysis.NamedExports++
+				exporting = true
+			}
+		}
+		if exporting {
+			exportingModules++
+		}
+	}
+
+	imports := analysis.DefaultImports + analysis.NamedImports + analysis.NamespaceImports
+	exports := analysis.DefaultExports + analysis.NamedExports
+	if imports == 0 && exports == 0 {
+		return nil
+	}
+
+	// Half the score from imports (namespace imports count half) and half from the share
+	// of exporting modules without a blocking default export
+	importScore, exportScore := 1.0, 1.0
+	if imports > 0 {
+		importScore = (float64(analysis.NamedImports) + 0.5*float64(analysis.NamespaceImports)) / float64(imports)
+		analysis.DefaultImportRatio = RoundScore(float64(analysis.DefaultImports)/float64(imports), 2)
+	}
+	if exports > 0 {
+		analysis.DefaultExportRatio = RoundScore(float64(analysis.DefaultExports)/float64(exports), 2)
+	}
+	if exportingModules > 0 {
+		exportScore = 1 - float64(analysis.BlockingModules)/float64(exportingModules)
+	}
+	analysis.Score = RoundScore(50*importScore+50*exportScore, 1)
+
+	sort.SliceStable(analysis.Offenders, func(i, j int) bool {
+		a, b := analysis.Offenders[i], analysis.Offenders[j]
+		if a.Consumers != b.Consumers {
+			return a.Consumers > b.Consumers
+		}
+		if len(a.Members) != len(b.Members) {
+			return len(a.Members) > len(b.Members)
+		}
+		return a.FilePath < b.FilePath
+	})
+	if len(analysis.Offenders) > maxTreeShakingOffenders {
+		analysis.Offenders = analysis.Offenders[:maxTreeShakingOffenders]
+	}
+
+	return analysis
+}
+
+// treeShakingSuggestion describes how to make a blocking default export tree-shakable
+func treeShakingSuggestion(export ast.ExportInfo) string {
+	if export.DefaultKind == "object" && len(export.Specifiers) > 0 {
+		return fmt.Sprintf("Export %s as named exports and import them by name", strings.Join(export.Specifiers, ", "))
+	}
+	if export.DefaultKind == "object" {
+		return "Export the object's members as named exports and import them by name"
+	}
+	return "Export standalone functions instead of static or rarely used methods, or split the class"
+}
@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestAnalyzeTreeShakeability(t *testing.T) {
+	parseResults := []*ast.ParseResult{
+		{
+			FilePath: "src/utils.js",
+			Exports:  []ast.ExportInfo{{ExportType: "default", DefaultKind: "object", Specifiers: []string{"format", "parse"}, StartLine: 12}},
+		},
+		{
+			FilePath: "src/api.js",
+			Exports:  []ast.ExportInfo{{ExportType: "default", DefaultKind: "class", StartLine: 3}},
+		},
+		{
+			FilePath: "src/button.js",
+			Exports:  []ast.ExportInfo{{ExportType: "default", DefaultKind: "function", StartLine: 1}},
+		},
+		{
+			FilePath: "src/dates.js",
+			Exports:  []ast.ExportInfo{{ExportType: "named", Specifiers: []string{"today"}}},
+		},
+		{
+			FilePath: "src/app.js",
+			Imports: []ast.ImportInfo{
+				{Source: "./utils", ImportType: "default"},
+				{Source: "./api", ImportType: "default"},
+				{Source: "./dates", ImportType: "named"},
+			},
+		},
+		{
+			FilePath: "src/page.js",
+			Imports: []ast.ImportInfo{
+				{Source: "./utils.js", ImportType: "default"},
+				{Source: "./button", ImportType: "default"},
+				{Source: "react", ImportType: "namespace"},
+				{Source: "./styles.css", ImportType: "side-effect"},
+			},
+		},
+	}
+
+	analysis := analyzeTreeShakeability(parseResults)
+	require.NotNil(t, analysis)
+
+	assert.Equal(t, 4, analysis.DefaultImports)
+	assert.Equal(t, 1, analysis.NamedImports)
+	assert.Equal(t, 1, analysis.NamespaceImports)
+	assert.Equal(t, 0.67, analysis.DefaultImportRatio)
+	assert.Equal(t, 3, analysis.DefaultExports)
+	assert.Equal(t, 1, analysis.NamedExports)
+	assert.Equal(t, 0.75, analysis.DefaultExportRatio)
+	assert.Equal(t, 2, analysis.BlockingModules)
+	// imports: (1 + 0.5) / 6 named; exports: 2 of 4 modules unblocked
+	assert.Equal(t, 37.5, analysis.Score)
+
+	require.Len(t, analysis.Offenders, 2)
+	assert.Equal(t, "src/utils.js", analysis.Offenders[0].FilePath, "most consumers first")
+	assert.Equal(t, 2, analysis.Offenders[0].Consumers)
+	assert.Equal(t, []string{"format", "parse"}, analysis.Offenders[0].Members)
+	assert.Equal(t, 12, analysis.Offenders[0].StartLine)
+	assert.Contains(t, analysis.Offenders[0].Suggestion, "format, parse")
+	assert.Equal(t, "src/api.js", analysis.Offenders[1].FilePath)
+	assert.Equal(t, "class", analysis.Offenders[1].DefaultKind)
+	assert.Equal(t, 1, analysis.Offenders[1].Consumers)
+}
+
+func TestAnalyzeTreeShakeability_NoModules(t *testing.T) {
+	assert.Nil(t, analyzeTreeShakeability([]*ast.ParseResult{{FilePath: "src/main.js"}}))
+
+	analysis := analyzeTreeShakeability([]*ast.ParseResult{{
+		FilePath: "src/index.js",
+		Imports:  []ast.ImportInfo{{Source: "lodash-es", ImportType: "named"}},
+	}})
+	require.NotNil(t, analysis)
+	assert.Equal(t, 100.0, analysis.Score)
+	assert.Empty(t, analysis.Offenders)
+}
+
+func TestAnalyzePerformance_TreeShakeability(t *testing.T) {
+	parseResults := []*ast.ParseResult{
+		{
+			FilePath: "src/helpers.js",
+			Exports:  []ast.ExportInfo{{ExportType: "default", DefaultKind: "object", Specifiers: []string{"a"}}},
+		},
+		{
+			FilePath: "src/app.js",
+			Imports:  []ast.ImportInfo{{Source: "./helpers", ImportType: "default"}},
+		},
+	}
+
+	metrics, err := NewPerformanceAnalyzer().AnalyzePerformance(context.Background(), parseResults, &ComplexityMetrics{})
+	require.NoError(t, err)
+
+	require.NotNil(t, metrics.BundleAnalysis.TreeShakeability)
+	assert.Equal(t, 1, metrics.BundleAnalysis.TreeShakeability.BlockingModules)
+	assert.Contains(t, metrics.BundleAnalysis.OptimizationTips, "1 modules default-export an object or class; switch them to named exports so consumers can tree-shake them")
+
+	var found bool
+	for _, recommendation := range metrics.Recommendations {
+		if recommendation.Title == "Replace Blocking Default Exports" {
+			found = true
+			assert.Contains(t, recommendation.Description, "src/helpers.js (1 consumers)")
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestWriteReportHTML_TreeShaking(t *testing.T) {
+	report := explainFixture()
+	report.DetailedMetrics.Performance = &PerformanceMetrics{BundleAnalysis: &BundleAnalysis{TreeShakeability: &TreeShakeability{
+		Score:           62.5,
+		BlockingModules: 1,
+		Offenders:       []TreeShakingOffender{{FilePath: "src/utils.js", StartLine: 4, DefaultKind: "object", Consumers: 3, Suggestion: "Export format as named exports"}},
+	}}}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReportHTML(&out, report))
+	html := out.String()
+
+	assert.Contains(t, html, "Tree-shaking score <strong>62.5</strong>")
+	assert.Contains(t, html, `<tr><td>src/utils.js:4</td><td>object</td><td class="value">3</td>`)
+}
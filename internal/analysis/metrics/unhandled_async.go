@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+// analyzeUnhandledAsync reports each await outside a try/catch and each promise chain
+// without a rejection handler, at the line of the call. An unhandled rejection either
+// crashes the process or is silently dropped, depending on the runtime.
+func (ds *DebtScorer) analyzeUnhandledAsync(parseResults []*ast.ParseResult) []TechnicalDebtItem {
+	items := []TechnicalDebtItem{}
+	itemID := 0
+
+	for _, parseResult := range parseResults {
+		for _, function := range parseResult.Functions {
+			name := function.Name
+			if name == "" {
+				name = "anonymous function"
+			}
+
+			for _, call := range function.UnhandledAsync {
+				description := fmt.Sprintf("'%s' awaits %s without handling a rejection", name, asyncCallTarget(call))
+				steps := []string{
+					"Wrap the await in a try/catch that handles or rethrows the error with context",
+					"Or attach .catch() to the awaited promise",
+					"Add a test where the awaited call rejects",
+				}
+				if call.Kind == "then" {
+					description = fmt.Sprintf("'%s' chains .then() on %s without a .catch()", name, asyncCallTarget(call))
+					steps = []string{
+						"Append .catch() to the chain, or pass a rejection handler as the second argument of .then()",
+						"Or return the promise so the caller can handle the rejection",
+						"Add a test where the chained call rejects",
+					}
+				}
+
+				items = append(items, TechnicalDebtItem{
+					ID:               fmt.Sprintf("unhandled_async_%d", itemID),
+					Type:             "unhandled_async",
+					Category:         "Code Smells",
+					FilePath:         parseResult.FilePath,
+					FunctionName:     function.Name,
+					StartLine:        call.Line,
+					EndLine:          call.Line,
+					Description:      description,
+					Severity:         "medium",
+					EstimatedHours:   0.5,
+					RemediationSteps: steps,
+					Metadata: map[string]interface{}{
+						"kind":          call.Kind,
+						"callee":        call.Callee,
+						"function_name": name,
+					},
+				})
+				itemID++
+			}
+		}
+	}
+
+	return items
+}
+
+// asyncCallTarget names the awaited or chained call for a description
+func asyncCallTarget(call ast.AsyncCallInfo) string {
+	if call.Callee == "" {
+		return "an expression"
+	}
+	return fmt.Sprintf("'%s'", call.Callee)
+}
+
+// unhandledAsyncCondition is the untested path condition for an unhandled rejection
+func unhandledAsyncCondition(call ast.AsyncCallInfo) string {
+	if call.Callee == "" {
+		return fmt.Sprintf("%s_rejection", call.Kind)
+	}
+	return fmt.Sprintf("%s_rejection: %s", call.Kind, call.Callee)
+}
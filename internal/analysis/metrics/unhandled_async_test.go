@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+)
+
+func TestAnalyzeUnhandledAsync(t *testing.T) {
+	parseResults := []*ast.ParseResult{{
+		FilePath: "src/load.js",
+		Functions: []ast.FunctionInfo{
+			{Name: "load", StartLine: 1, EndLine: 20, IsAsync: true, UnhandledAsync: []ast.AsyncCallInfo{
+				{Kind: "await", Callee: "db.get", Line: 2},
+				{Kind: "then", Callee: "fetch", Line: 9},
+			}},
+			{Name: "safe", StartLine: 22, EndLine: 30, IsAsync: true},
+		},
+	}}
+
+	items := NewDebtScorer().analyzeUnhandledAsync(parseResults)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "unhandled_async_0", items[0].ID)
+	assert.Equal(t, "unhandled_async", items[0].Type)
+	assert.Equal(t, 2, items[0].StartLine)
+	assert.Equal(t, 2, items[0].EndLine)
+	assert.Equal(t, "'load' awaits 'db.get' without handling a rejection", items[0].Description)
+
+	assert.Equal(t, 9, items[1].StartLine)
+	assert.Equal(t, "'load' chains .then() on 'fetch' without a .catch()", items[1].Description)
+	assert.Equal(t, "then", items[1].Metadata["kind"])
+}
+
+func TestAnalyzeAsyncPaths_UnhandledCalls(t *testing.T) {
+	parseResult := &ast.ParseResult{FilePath: "src/load.js"}
+	analyzer := NewCoverageAnalyzer()
+
+	metrics := &CoverageMetrics{}
+	pathID := 0
+	analyzer.analyzeAsyncPaths(ast.FunctionInfo{Name: "safe", StartLine: 1, EndLine: 10, IsAsync: true}, parseResult, metrics, &pathID)
+	require.Len(t, metrics.UntestedPaths, 1, "an async function with handled rejections only has a success path")
+	assert.Equal(t, "async_success_scenario", metrics.UntestedPaths[0].Condition)
+
+	metrics = &CoverageMetrics{}
+	analyzer.analyzeAsyncPaths(ast.FunctionInfo{Name: "load", StartLine: 1, EndLine: 10, IsAsync: true, UnhandledAsync: []ast.AsyncCallInfo{
+		{Kind: "await", Callee: "db.get", Line: 4},
+	}}, parseResult, metrics, &pathID)
+	require.Len(t, metrics.UntestedPaths, 2)
+	errorPath := metrics.UntestedPaths[1]
+	assert.Equal(t, 4, errorPath.StartLine)
+	assert.Equal(t, 4, errorPath.EndLine)
+	assert.Equal(t, "await_rejection: db.get", errorPath.Condition)
+	assert.Equal(t, "high", errorPath.RiskLevel)
+}
@@ -0,0 +1,127 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/httpclient"
+)
+
+// defaultEnrichmentTimeout bounds each network enrichment when Options.EnrichmentTimeout is 0
+const defaultEnrichmentTimeout = 15 * time.Second
+
+// Network enrichments, by the name recorded in the run metadata
+const enrichmentOSV = "osv_vulnerabilities"
+
+// osvBaseURL is the OSV API the vulnerability enrichment queries; tests point it at a fake
+var osvBaseURL = analysis.DefaultOSVURL
+
+// errNotApplicable marks an enrichment with nothing to look up, skipped without touching
+// the network and without a warning
+type errNotApplicable string
+
+func (e errNotApplicable) Error() string { return string(e) }
+
+// enrich runs the network enrichments the caller opted into ahead of the analysis and
+// returns their status for the run metadata, along with the vulnerabilities the OSV lookup
+// found by severity. An enrichment that fails or times out is skipped and its data left out;
+// it never fails the analysis. Their data is listed in the report but never scored, so the
+// scores do not depend on whether the network was reachable.
+func enrich(ctx context.Context, root string, opts Options) ([]metrics.EnrichmentStatus, map[string]int) {
+	timeout := opts.EnrichmentTimeout
+	if timeout <= 0 {
+		timeout = defaultEnrichmentTimeout
+	}
+	config := httpclient.DefaultConfig()
+	config.Timeout = timeout
+	config.Offline = opts.Offline
+	client := httpclient.New(config)
+
+	var vulnerabilities map[string]int
+	statuses := []metrics.EnrichmentStatus{
+		runEnrichment(ctx, opts, enrichmentOSV, timeout, func(ctx context.Context) error {
+			if !opts.OSVLookup {
+				return errNotApplicable("not enabled")
+			}
+			versions := installedVersions(root, opts.ReportConfig.DependencyVersions)
+			if len(versions) == 0 {
+				return errNotApplicable("no package.json dependencies with a locked or pinned version")
+			}
+			found, err := scanDependencies(ctx, analysis.NewOSVSource(client, osvBaseURL), versions)
+			if err != nil {
+				return err
+			}
+			vulnerabilities = found
+			return nil
+		}),
+	}
+	return statuses, vulnerabilities
+}
+
+// runEnrichment runs one enrichment within timeout. In offline mode its HTTP client serves
+// from the cache only, so a lookup that needs the network is skipped as offline.
+func runEnrichment(ctx context.Context, opts Options, name string, timeout time.Duration, run func(context.Context) error) metrics.EnrichmentStatus {
+	enrichCtx, cancel := context.WithTimeout(ctx, timeout)
+	err := run(enrichCtx)
+	cancel()
+
+	var notApplicable errNotApplicable
+	switch {
+	case err == nil:
+		return metrics.EnrichmentStatus{Name: name, Status: metrics.EnrichmentRan}
+	case errors.As(err, &notApplicable):
+		return metrics.EnrichmentStatus{Name: name, Status: metrics.EnrichmentSkipped, Reason: notApplicable.Error()}
+	case errors.Is(err, httpclient.ErrOffline):
+		return metrics.EnrichmentStatus{Name: name, Status: metrics.EnrichmentSkipped, Reason: "offline mode"}
+	case errors.Is(err, context.DeadlineExceeded):
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	if opts.Logger != nil {
+		opts.Logger.WithFields(map[string]interface{}{
+			"enrichment": name,
+			"error":      err.Error(),
+		}).Warn("Network enrichment skipped; the report leaves its data out")
+	}
+	return metrics.EnrichmentStatus{Name: name, Status: metrics.EnrichmentSkipped, Reason: err.Error()}
+}
+
+// scanDependencies looks the dependencies up in OSV and counts their vulnerabilities by
+// severity; one without a CVSS score counts as unknown
+func scanDependencies(ctx context.Context, osv *analysis.OSVSource, versions map[string]string) (map[string]int, error) {
+	ids, err := osv.QueryBatch(ctx, versions)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(ids))
+	for name := range ids {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	severities := make(map[string]string) // by vulnerability ID, fetched once however many packages it affects
+	vulnerabilities := make(map[string]int)
+	for _, name := range names {
+		for _, id := range ids[name] {
+			severity, ok := severities[id]
+			if !ok {
+				vuln, err := osv.GetVulnerability(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				if severity = vuln.Severity; severity == "" {
+					severity = "unknown"
+				}
+				severities[id] = severity
+			}
+			vulnerabilities[severity]++
+		}
+	}
+	return vulnerabilities, nil
+}
@@ -0,0 +1,150 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+)
+
+// fakeOSV points the vulnerability enrichment at a test server running handler and counts its requests
+func fakeOSV(t *testing.T, handler http.HandlerFunc) *int32 {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	previous := osvBaseURL
+	osvBaseURL = server.URL
+	t.Cleanup(func() { osvBaseURL = previous })
+	return &requests
+}
+
+// osvHandler answers like OSV, with lodash affected by one high and one unscored vulnerability
+func osvHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/querybatch":
+			var batch struct {
+				Queries []struct {
+					Package struct{ Name string } `json:"package"`
+					Version string                `json:"version"`
+				} `json:"queries"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			results := make([]map[string]interface{}, len(batch.Queries))
+			for i, query := range batch.Queries {
+				results[i] = map[string]interface{}{}
+				if query.Package.Name == "lodash" {
+					assert.Equal(t, "4.17.21", query.Version, "the locked version, not the range's lower bound")
+					results[i]["vulns"] = []map[string]string{{"id": "GHSA-high"}, {"id": "GHSA-unscored"}}
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		case "/vulns/GHSA-high":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "GHSA-high", "severity": []map[string]string{{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N"}}})
+		case "/vulns/GHSA-unscored":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "GHSA-unscored"})
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func writeDependentProject(t *testing.T) string {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "export const app = 1;\n")
+	writeTestFile(t, root, "package.json", `{"dependencies": {"lodash": "^4.17.20", "left-pad": "latest"}}`)
+	writeTestFile(t, root, "package-lock.json", `{"lockfileVersion": 3, "packages": {"": {}, "node_modules/lodash": {"version": "4.17.21"}}}`)
+	return root
+}
+
+func TestAnalyzeDirectory_OSVEnrichment(t *testing.T) {
+	fakeOSV(t, osvHandler(t))
+
+	report, err := AnalyzeDirectory(context.Background(), writeDependentProject(t), Options{OSVLookup: true})
+	require.NoError(t, err)
+	assert.Equal(t, []metrics.EnrichmentStatus{{Name: enrichmentOSV, Status: metrics.EnrichmentRan}}, report.RunMetadata.Enrichments)
+	assert.Equal(t, map[string]int{"high": 1, "unknown": 1}, report.Vulnerabilities)
+	assert.Nil(t, report.DetailedMetrics.Security, "OSV findings are listed, not scored")
+}
+
+func TestAnalyzeDirectory_OSVLookupIsOptIn(t *testing.T) {
+	requests := fakeOSV(t, osvHandler(t))
+
+	report, err := AnalyzeDirectory(context.Background(), writeDependentProject(t), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []metrics.EnrichmentStatus{{Name: enrichmentOSV, Status: metrics.EnrichmentSkipped, Reason: "not enabled"}}, report.RunMetadata.Enrichments)
+	assert.Nil(t, report.Vulnerabilities)
+	assert.Zero(t, atomic.LoadInt32(requests), "a default run never touches the network")
+}
+
+func TestAnalyzeDirectory_ScoreIgnoresLookupOutcome(t *testing.T) {
+	fakeOSV(t, osvHandler(t))
+	found, err := AnalyzeDirectory(context.Background(), writeDependentProject(t), Options{OSVLookup: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, found.Vulnerabilities)
+
+	fakeOSV(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	failed, err := AnalyzeDirectory(context.Background(), writeDependentProject(t), Options{OSVLookup: true})
+	require.NoError(t, err)
+	require.Empty(t, failed.Vulnerabilities)
+
+	assert.Equal(t, found.OverallScore, failed.OverallScore)
+	assert.Equal(t, found.ComponentScores, failed.ComponentScores)
+}
+
+func TestAnalyzeDirectory_Offline(t *testing.T) {
+	requests := fakeOSV(t, osvHandler(t))
+
+	report, err := AnalyzeDirectory(context.Background(), writeDependentProject(t), Options{OSVLookup: true, Offline: true})
+	require.NoError(t, err)
+	assert.Equal(t, []metrics.EnrichmentStatus{{Name: enrichmentOSV, Status: metrics.EnrichmentSkipped, Reason: "offline mode"}}, report.RunMetadata.Enrichments)
+	assert.Nil(t, report.Vulnerabilities)
+	assert.Zero(t, atomic.LoadInt32(requests), "offline mode never touches the network")
+}
+
+func TestAnalyzeDirectory_EnrichmentDegrades(t *testing.T) {
+	fakeOSV(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	report, err := AnalyzeDirectory(context.Background(), writeDependentProject(t), Options{OSVLookup: true})
+	require.NoError(t, err, "a failed enrichment never aborts the analysis")
+	assert.Equal(t, []metrics.EnrichmentStatus{{Name: enrichmentOSV, Status: metrics.EnrichmentSkipped, Reason: "OSV API returned status: 503"}}, report.RunMetadata.Enrichments)
+	assert.Nil(t, report.Vulnerabilities)
+
+	release := make(chan struct{})
+	defer close(release)
+	fakeOSV(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	report, err = AnalyzeDirectory(context.Background(), writeDependentProject(t), Options{OSVLookup: true, EnrichmentTimeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, "timed out after 50ms", report.RunMetadata.Enrichments[0].Reason)
+}
+
+func TestEnrich_NothingToLookUp(t *testing.T) {
+	requests := fakeOSV(t, osvHandler(t))
+
+	opts := Options{OSVLookup: true}
+	opts.ReportConfig.DependencyVersions = map[string]string{"internal": "workspace:*", "lodash": "^4.17.20"}
+	statuses, vulnerabilities := enrich(context.Background(), t.TempDir(), opts)
+	assert.Equal(t, []metrics.EnrichmentStatus{{Name: enrichmentOSV, Status: metrics.EnrichmentSkipped, Reason: "no package.json dependencies with a locked or pinned version"}}, statuses)
+	assert.Nil(t, vulnerabilities)
+	assert.Zero(t, atomic.LoadInt32(requests))
+}
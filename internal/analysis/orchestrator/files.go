@@ -0,0 +1,334 @@
+package orchestrator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/ast"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+)
+
+const defaultMaxFileSize = 10 * 1024 * 1024 // 10MB
+
+// defaultLargeFileSize is the size above which source files are line-counted but not parsed.
+// Multi-megabyte sources are almost always bundles or generated code, and parsing them
+// costs far more memory than their content: the source is held as a string, copied for
+// the parser, and expanded into a syntax tree.
+const defaultLargeFileSize = 1024 * 1024 // 1MB
+
+// defaultMaxDepth bounds how many directories deep the file walk goes when Options.MaxDepth
+// is 0; real repositories stay far above it
+const defaultMaxDepth = 64
+
+// defaultExcludePatterns mirrors the directories skipped by the AST analyzer; bundles
+// (*.min.js, *.bundle.js) are reported as generated files instead
+var defaultExcludePatterns = []string{
+	"node_modules", ".git", ".vscode", "dist", "build",
+	"coverage", ".nyc_output",
+}
+
+// walkSourceFiles walks root and calls visit for every supported source file up to
+// maxFileSize outside the excluded directories, with its slash-separated relative path.
+// The detector holds the .gitattributes rules of the directories visited so far. Symbolic
+// links are followed only within root and directories only down to maxDepth; onSkip, when
+// set, receives the links and directories left out. onExclude, when set, receives the
+// excluded directories and the files left out for their size or language.
+func walkSourceFiles(root string, maxFileSize int64, maxDepth int, onSkip func(skippedPath), onExclude func(metrics.FileDisposition), visit func(path, relPath string, info os.FileInfo, detector *generatedDetector) error) error {
+	parser, err := ast.NewParser()
+	if err != nil {
+		return fmt.Errorf("failed to create parser: %w", err)
+	}
+	defer parser.Close()
+
+	detector := &generatedDetector{}
+	return walkRepository(root, maxDepth, onSkip, func(path string, info os.FileInfo) error {
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve relative path for %s: %w", path, err)
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if info.IsDir() {
+			if path != root && isExcluded(path) {
+				// Every clone has a .git directory; listing it would only be noise
+				if info.Name() != ".git" {
+					exclude(onExclude, relPath, metrics.DispositionExcludedByPattern, fmt.Sprintf("directory matches the excluded pattern %q", excludedPattern(path)))
+				}
+				return filepath.SkipDir
+			}
+			if err := detector.loadAttributes(path, relPath); err != nil {
+				return fmt.Errorf("failed to read .gitattributes in %s: %w", path, err)
+			}
+			return nil
+		}
+
+		switch {
+		case !parser.IsSupported(path):
+			exclude(onExclude, relPath, metrics.DispositionUnsupportedLanguage, metrics.LanguageForPath(path))
+			return nil
+		case isExcluded(path):
+			exclude(onExclude, relPath, metrics.DispositionExcludedByPattern, fmt.Sprintf("matches the excluded pattern %q", excludedPattern(path)))
+			return nil
+		case info.Size() > maxFileSize:
+			exclude(onExclude, relPath, metrics.DispositionTooLarge, fmt.Sprintf("%d bytes, above the %d-byte file size limit", info.Size(), maxFileSize))
+			return nil
+		}
+		return visit(path, relPath, info, detector)
+	})
+}
+
+// largeFile line-counts a file above the large-file threshold, reading only its head to
+// check for a generated-code header; ok is false for generated files
+func largeFile(path, relPath string, info os.FileInfo, detector *generatedDetector) (file metrics.LargeFile, ok bool, err error) {
+	// The generated-code header sits at the top, so the head of the file is enough
+	head, err := readHead(path, generatedHeaderBytes)
+	if err != nil {
+		return metrics.LargeFile{}, false, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	if detector.isGenerated(relPath, head) {
+		return metrics.LargeFile{}, false, nil
+	}
+
+	count, err := countLines(path)
+	if err != nil {
+		return metrics.LargeFile{}, false, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	return metrics.LargeFile{Path: relPath, Bytes: info.Size(), Lines: count}, true, nil
+}
+
+// collectSourceFiles walks root and returns supported source files keyed by slash-separated
+// relative path, along with the sorted paths of generated files left out of the analysis.
+// Files above largeFileSize are never loaded: their lines are counted by streaming and they
+// are returned as large files instead. A largeFileSize of 0 or less loads every file up to
+// maxFileSize. Every other file stays in memory for the whole analysis, since duplication
+// and import cycle detection compare files with each other, so largeFileSize is what bounds
+// the memory taken by file contents. onExclude, when set, receives every file and directory
+// left out, with why.
+func collectSourceFiles(root string, maxFileSize, largeFileSize int64, maxDepth int, onSkip func(skippedPath), onExclude func(metrics.FileDisposition)) (map[string]string, []string, []metrics.LargeFile, error) {
+	fileContents := make(map[string]string)
+	generated := []string{}
+	largeFiles := []metrics.LargeFile{}
+	err := walkSourceFiles(root, maxFileSize, maxDepth, onSkip, onExclude, func(path, relPath string, info os.FileInfo, detector *generatedDetector) error {
+		if largeFileSize > 0 && info.Size() > largeFileSize {
+			file, ok, err := largeFile(path, relPath, info, detector)
+			switch {
+			case err != nil:
+				return err
+			case ok:
+				largeFiles = append(largeFiles, file)
+				exclude(onExclude, relPath, metrics.DispositionTooLarge, fmt.Sprintf("%d bytes, above the %d-byte large-file threshold; line-counted only", info.Size(), largeFileSize))
+			default:
+				generated = append(generated, relPath)
+				exclude(onExclude, relPath, metrics.DispositionGenerated, "")
+			}
+			return nil
+		}
+
+		content, err := readSource(path, info.Size())
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		if detector.isGenerated(relPath, []byte(content[:min(len(content), generatedHeaderBytes)])) {
+			generated = append(generated, relPath)
+			exclude(onExclude, relPath, metrics.DispositionGenerated, "")
+			return nil
+		}
+
+		fileContents[relPath] = content
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return fileContents, generated, largeFiles, nil
+}
+
+// selectSourceFiles applies the same filters as collectSourceFiles without loading any
+// file: only the head of each file is read, for the generated-code check
+func selectSourceFiles(root string, maxFileSize, largeFileSize int64, maxDepth int, onSkip func(skippedPath)) (*FileSelection, error) {
+	selection := &FileSelection{
+		Files:      []SelectedFile{},
+		LargeFiles: []metrics.LargeFile{},
+		Generated:  []string{},
+	}
+
+	err := walkSourceFiles(root, maxFileSize, maxDepth, onSkip, nil, func(path, relPath string, info os.FileInfo, detector *generatedDetector) error {
+		if largeFileSize > 0 && info.Size() > largeFileSize {
+			file, ok, err := largeFile(path, relPath, info, detector)
+			switch {
+			case err != nil:
+				return err
+			case ok:
+				selection.LargeFiles = append(selection.LargeFiles, file)
+			default:
+				selection.Generated = append(selection.Generated, relPath)
+			}
+			return nil
+		}
+
+		head, err := readHead(path, generatedHeaderBytes)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		if detector.isGenerated(relPath, head) {
+			selection.Generated = append(selection.Generated, relPath)
+			return nil
+		}
+
+		selection.Files = append(selection.Files, SelectedFile{Path: relPath, Bytes: info.Size()})
+		selection.TotalBytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return selection, nil
+}
+
+// exclude passes a file or directory left out of the analysis to onExclude, when set
+func exclude(onExclude func(metrics.FileDisposition), relPath, disposition, detail string) {
+	if onExclude != nil {
+		onExclude(metrics.FileDisposition{Path: relPath, Disposition: disposition, Detail: detail})
+	}
+}
+
+// readSource reads a file straight into a string of the expected size, avoiding the byte
+// slice plus string copy that os.ReadFile followed by a conversion would hold at once
+func readSource(path string, size int64) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var b strings.Builder
+	b.Grow(int(size))
+	if _, err := io.Copy(&b, file); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// readHead returns up to n bytes from the start of a file
+func readHead(path string, n int) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	head := make([]byte, n)
+	read, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	return head[:read], nil
+}
+
+// countOtherFiles walks root and returns the line counts of files that are counted in the
+// language statistics but not parsed, such as config files and other languages. Data files
+// (lockfiles, snapshots, JSON fixtures) are returned separately and left out of the counts.
+// Symbolic links and deep directories are skipped like in walkSourceFiles but not reported
+// again, collectSourceFiles walks the same tree.
+func countOtherFiles(root string, maxFileSize int64, maxDepth int) (map[string]int, []metrics.DataFile, error) {
+	parser, err := ast.NewParser()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create parser: %w", err)
+	}
+	defer parser.Close()
+
+	lines := make(map[string]int)
+	dataFiles := []metrics.DataFile{}
+	err = walkRepository(root, maxDepth, nil, func(path string, info os.FileInfo) error {
+		if info.IsDir() {
+			if path != root && isExcluded(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if parser.IsSupported(path) || isExcluded(path) {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve relative path for %s: %w", path, err)
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		// Data files are listed whatever their size, so giant lockfiles still show up
+		if kind := metrics.ClassifyDataFile(relPath, info.Size()); kind != "" {
+			count, err := countLines(path)
+			if err != nil {
+				return fmt.Errorf("failed to read file %s: %w", path, err)
+			}
+			dataFiles = append(dataFiles, metrics.DataFile{Path: relPath, Kind: kind, Bytes: info.Size(), Lines: count})
+			return nil
+		}
+
+		if metrics.LanguageForPath(path) == "" || info.Size() > maxFileSize {
+			return nil
+		}
+		count, err := countLines(path)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		lines[relPath] = count
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return lines, dataFiles, nil
+}
+
+// countLines counts the lines of a file without loading it whole; a last line without a
+// trailing newline counts too
+func countLines(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, 64*1024)
+	count, last := 0, byte('\n')
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			count += bytes.Count(buf[:n], []byte("\n"))
+			last = buf[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if last != '\n' {
+		count++
+	}
+	return count, nil
+}
+
+// isExcluded reports whether a path matches one of the default exclusion patterns
+func isExcluded(path string) bool {
+	return excludedPattern(path) != ""
+}
+
+// excludedPattern returns the default exclusion pattern path matches, or ""
+func excludedPattern(path string) string {
+	base := filepath.Base(path)
+	for _, pattern := range defaultExcludePatterns {
+		if matched, _ := filepath.Match(pattern, base); matched {
+			return pattern
+		}
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package orchestrator
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// generatedHeaderLines is how many leading lines are searched for a generated-code marker
+const generatedHeaderLines = 5
+
+// generatedHeaderBytes bounds how much of a file is read for the header check; it matches
+// the longest line bufio.Scanner accepts, so the check sees what it would in the full file
+const generatedHeaderBytes = bufio.MaxScanTokenSize
+
+// generatedHeaderPattern matches the usual markers emitted by code generators, e.g.
+// "// Code generated by protoc-gen-ts. DO NOT EDIT." or "/* @generated */"
+var generatedHeaderPattern = regexp.MustCompile(`(?i)(code generated .*do not edit|@generated\b|auto-?generated .*do not (edit|modify))`)
+
+// generatedFilePatterns are bundle outputs treated as generated regardless of content
+var generatedFilePatterns = []string{"*.min.js", "*.bundle.js"}
+
+// attributeRule is one linguist-generated line from a .gitattributes file
+type attributeRule struct {
+	base      string // directory holding the .gitattributes file, slash-separated, "" for the root
+	pattern   string
+	generated bool // false when the line unsets the attribute
+}
+
+// generatedDetector decides which files are generated, from .gitattributes
+// linguist-generated markers and from file names and headers
+type generatedDetector struct {
+	rules []attributeRule
+}
+
+// loadAttributes reads the linguist-generated rules of dir/.gitattributes, if present.
+// relDir is dir relative to the analysis root; rules from deeper directories are loaded
+// later and so take precedence, as in git.
+func (gd *generatedDetector) loadAttributes(dir, relDir string) error {
+	file, err := os.Open(filepath.Join(dir, ".gitattributes"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	base := filepath.ToSlash(relDir)
+	if base == "." {
+		base = ""
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attribute := range fields[1:] {
+			switch attribute {
+			case "linguist-generated", "linguist-generated=true":
+				gd.rules = append(gd.rules, attributeRule{base: base, pattern: fields[0], generated: true})
+			case "-linguist-generated", "linguist-generated=false", "!linguist-generated":
+				gd.rules = append(gd.rules, attributeRule{base: base, pattern: fields[0], generated: false})
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// isGenerated reports whether relPath (slash-separated, relative to the root) is generated
+func (gd *generatedDetector) isGenerated(relPath string, content []byte) bool {
+	generated := false
+	matched := false
+	for _, rule := range gd.rules {
+		if rule.matches(relPath) {
+			generated, matched = rule.generated, true
+		}
+	}
+	if matched {
+		return generated // an explicit attribute overrides the heuristics
+	}
+
+	base := path.Base(relPath)
+	for _, pattern := range generatedFilePatterns {
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return hasGeneratedHeader(content)
+}
+
+// matches applies gitattributes pattern rules: a pattern without a slash matches the file
+// name at any depth, otherwise it is anchored to the .gitattributes directory. "**/" and
+// "/**" match any number of directories.
+func (r attributeRule) matches(relPath string) bool {
+	if r.base != "" {
+		if !strings.HasPrefix(relPath, r.base+"/") {
+			return false
+		}
+		relPath = strings.TrimPrefix(relPath, r.base+"/")
+	}
+
+	pattern := r.pattern
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		ok, _ := path.Match(pattern, path.Base(relPath))
+		return ok
+	}
+
+	pattern = strings.TrimPrefix(pattern, "/")
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return strings.HasPrefix(relPath, prefix+"/")
+	}
+	if suffix, ok := strings.CutPrefix(pattern, "**/"); ok {
+		segments := strings.Split(relPath, "/")
+		for i := range segments {
+			if ok, _ := path.Match(suffix, strings.Join(segments[i:], "/")); ok {
+				return true
+			}
+		}
+		return false
+	}
+	ok, _ := path.Match(pattern, relPath)
+	return ok
+}
+
+// hasGeneratedHeader reports whether one of the first lines carries a generated-code marker
+func hasGeneratedHeader(content []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for i := 0; i < generatedHeaderLines && scanner.Scan(); i++ {
+		if generatedHeaderPattern.MatchString(scanner.Text()) {
+			return true
+		}
+	}
+	return false
+}
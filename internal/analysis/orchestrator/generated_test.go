@@ -0,0 +1,98 @@
+package orchestrator
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectSourceFiles_SkipsLinguistGenerated(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, ".gitattributes", "# generated protobuf clients\n*.pb.ts linguist-generated\nsrc/gen/** linguist-generated=true\n")
+	writeTestFile(t, root, "src/api/user.pb.ts", "export interface User { id: number }\n")
+	writeTestFile(t, root, "src/gen/client.ts", "export const client = {};\n")
+	writeTestFile(t, root, "src/app.ts", "export const app = 1;\n")
+
+	files, generated, _, err := collectSourceFiles(root, defaultMaxFileSize, defaultLargeFileSize, defaultMaxDepth, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"src/app.ts"}, sortedFileKeys(files))
+	assert.Equal(t, []string{"src/api/user.pb.ts", "src/gen/client.ts"}, generated)
+}
+
+func TestCollectSourceFiles_GeneratedHeader(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/schema.ts", "// Code generated by graphql-codegen. DO NOT EDIT.\nexport type Query = {};\n")
+	writeTestFile(t, root, "src/flow.js", "/**\n * @generated\n */\nmodule.exports = {};\n")
+	writeTestFile(t, root, "src/notes.js", "// This file is hand-written.\n\n\n\n\n// Code generated by hand. DO NOT EDIT.\n")
+
+	files, generated, _, err := collectSourceFiles(root, defaultMaxFileSize, defaultLargeFileSize, defaultMaxDepth, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"src/notes.js"}, sortedFileKeys(files), "markers past the header are ignored")
+	assert.Equal(t, []string{"src/flow.js", "src/schema.ts"}, generated)
+}
+
+func TestCollectSourceFiles_AttributesOverrideHeuristics(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, ".gitattributes", "*.pb.ts linguist-generated\n")
+	writeTestFile(t, root, "web/.gitattributes", "legacy.min.js -linguist-generated\nkept.pb.ts linguist-generated=false\n")
+	writeTestFile(t, root, "web/legacy.min.js", "var a = 1;\n")
+	writeTestFile(t, root, "web/kept.pb.ts", "export const kept = 1;\n")
+	writeTestFile(t, root, "web/other.pb.ts", "export const other = 1;\n")
+
+	files, generated, _, err := collectSourceFiles(root, defaultMaxFileSize, defaultLargeFileSize, defaultMaxDepth, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"web/kept.pb.ts", "web/legacy.min.js"}, sortedFileKeys(files))
+	assert.Equal(t, []string{"web/other.pb.ts"}, generated)
+}
+
+func TestAttributeRuleMatches(t *testing.T) {
+	tests := []struct {
+		rule     attributeRule
+		path     string
+		expected bool
+	}{
+		{attributeRule{pattern: "*.pb.ts"}, "src/api/user.pb.ts", true},
+		{attributeRule{pattern: "/gen/*.ts"}, "gen/a.ts", true},
+		{attributeRule{pattern: "/gen/*.ts"}, "src/gen/a.ts", false},
+		{attributeRule{pattern: "gen/**"}, "gen/deep/a.ts", true},
+		{attributeRule{pattern: "**/schema.ts"}, "a/b/schema.ts", true},
+		{attributeRule{base: "web", pattern: "*.js"}, "web/app.js", true},
+		{attributeRule{base: "web", pattern: "*.js"}, "api/app.js", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.rule.matches(tt.path), "%s/%s against %s", tt.rule.base, tt.rule.pattern, tt.path)
+	}
+}
+
+func TestAnalyzeDirectory_ListGeneratedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, ".gitattributes", "*.pb.ts linguist-generated\n")
+	writeTestFile(t, root, "src/api/user.pb.ts", "export function decode(a, b) { if (a) { return b; } return a; }\n")
+	writeTestFile(t, root, "src/app.ts", "export function main() { return 1; }\n")
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{})
+	require.NoError(t, err)
+	assert.Nil(t, report.GeneratedFiles)
+	assert.NotContains(t, report.DetailedMetrics.Complexity.FileMetrics, "src/api/user.pb.ts")
+
+	report, err = AnalyzeDirectory(context.Background(), root, Options{ListGeneratedFiles: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src/api/user.pb.ts"}, report.GeneratedFiles)
+}
+
+// sortedFileKeys returns the collected file paths in order
+func sortedFileKeys(files map[string]string) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,196 @@
+package orchestrator
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pinnedVersionPattern matches a plain semver version, as a lockfile records it or as
+// package.json pins it exactly (optionally written =1.2.3 or v1.2.3)
+var pinnedVersionPattern = regexp.MustCompile(`^[=v]?(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?)$`)
+
+// yarnVersionPattern finds the version line of a yarn.lock entry, in the classic
+// (version "1.2.3") and the berry (version: 1.2.3) format
+var yarnVersionPattern = regexp.MustCompile(`^\s+version:?\s+"?([^"\s]+)"?`)
+
+// installedVersions resolves the version installed for each declared dependency from the
+// root lockfile: package-lock.json, yarn.lock or pnpm-lock.yaml, the first one present. A
+// dependency pinned to an exact version needs no lockfile entry; a range without one is left
+// out rather than guessed from its lower bound, which the lockfile may have moved past.
+func installedVersions(root string, declared map[string]string) map[string]string {
+	locked := readLockfile(root)
+	versions := make(map[string]string, len(declared))
+	for name, spec := range declared {
+		if version, ok := locked.version(name, spec); ok {
+			versions[name] = version
+		} else if match := pinnedVersionPattern.FindStringSubmatch(spec); match != nil {
+			versions[name] = match[1]
+		}
+	}
+	return versions
+}
+
+// lockfile holds the versions a lockfile pins: direct dependencies by name (npm, pnpm) or
+// every requested range by name@range (yarn, which keys its entries that way)
+type lockfile map[string]string
+
+// version returns the locked version of the dependency declared as name at spec
+func (l lockfile) version(name, spec string) (string, bool) {
+	if version, ok := l[name+"@"+spec]; ok {
+		return version, true
+	}
+	version, ok := l[name]
+	return version, ok
+}
+
+// set records a locked version, keeping only plain semver ones; git URLs, tarballs and
+// workspace links cannot be looked up
+func (l lockfile) set(key, version string) {
+	if match := pinnedVersionPattern.FindStringSubmatch(version); match != nil {
+		l[key] = match[1]
+	}
+}
+
+// readLockfile parses the first lockfile in root; nil without one or when it is unreadable,
+// so only exact pins are resolved
+func readLockfile(root string) lockfile {
+	parsers := []struct {
+		name  string
+		parse func([]byte) (lockfile, error)
+	}{
+		{"package-lock.json", parseNPMLockfile},
+		{"yarn.lock", parseYarnLockfile},
+		{"pnpm-lock.yaml", parsePNPMLockfile},
+	}
+	for _, parser := range parsers {
+		data, err := os.ReadFile(filepath.Join(root, parser.name))
+		if err != nil {
+			continue
+		}
+		locked, err := parser.parse(data)
+		if err != nil {
+			return nil
+		}
+		return locked
+	}
+	return nil
+}
+
+// parseNPMLockfile reads the top-level node_modules entries of a v2/v3 package-lock.json,
+// or the top-level dependencies of a v1 one
+func parseNPMLockfile(data []byte) (lockfile, error) {
+	type entry struct {
+		Version string `json:"version"`
+	}
+	var lock struct {
+		Packages     map[string]entry `json:"packages"`
+		Dependencies map[string]entry `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	locked := make(lockfile)
+	for path, pkg := range lock.Packages {
+		name, ok := strings.CutPrefix(path, "node_modules/")
+		if ok && !strings.Contains(name, "/node_modules/") { // nested copies belong to other packages
+			locked.set(name, pkg.Version)
+		}
+	}
+	if len(lock.Packages) == 0 {
+		for name, dependency := range lock.Dependencies {
+			locked.set(name, dependency.Version)
+		}
+	}
+	return locked, nil
+}
+
+// parseYarnLockfile reads a yarn.lock, classic or berry, keying each entry's version by
+// every name@range its header lists
+func parseYarnLockfile(data []byte) (lockfile, error) {
+	locked := make(lockfile)
+	var keys []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case !strings.HasPrefix(line, " "):
+			keys = yarnEntryKeys(line)
+		default:
+			if match := yarnVersionPattern.FindStringSubmatch(line); match != nil {
+				for _, key := range keys {
+					locked.set(key, match[1])
+				}
+				keys = nil
+			}
+		}
+	}
+	return locked, scanner.Err()
+}
+
+// yarnEntryKeys splits a yarn.lock entry header such as "a@^1.0.0", "a@^1.1.0": into its
+// name@range keys, dropping berry's npm: protocol
+func yarnEntryKeys(header string) []string {
+	var keys []string
+	for _, key := range strings.Split(strings.TrimSuffix(header, ":"), ",") {
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		if at := strings.LastIndex(key, "@"); at > 0 {
+			keys = append(keys, key[:at+1]+strings.TrimPrefix(key[at+1:], "npm:"))
+		}
+	}
+	return keys
+}
+
+// pnpmDependency is a direct dependency in pnpm-lock.yaml: a bare version in lockfile v5,
+// a specifier and version mapping from v6 on
+type pnpmDependency struct {
+	Version string `yaml:"version"`
+}
+
+// UnmarshalYAML accepts both forms
+func (d *pnpmDependency) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		d.Version = node.Value
+		return nil
+	}
+	type plain pnpmDependency
+	return node.Decode((*plain)(d))
+}
+
+// parsePNPMLockfile reads the root importer's direct dependencies from a pnpm-lock.yaml,
+// dropping the peer suffix pnpm appends, e.g. 18.2.0(react@18.2.0) or 18.2.0_react@18.2.0
+func parsePNPMLockfile(data []byte) (lockfile, error) {
+	type dependencies struct {
+		Dependencies         map[string]pnpmDependency `yaml:"dependencies"`
+		DevDependencies      map[string]pnpmDependency `yaml:"devDependencies"`
+		OptionalDependencies map[string]pnpmDependency `yaml:"optionalDependencies"`
+	}
+	var lock struct {
+		dependencies `yaml:",inline"`
+		Importers    map[string]dependencies `yaml:"importers"`
+	}
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	direct := lock.dependencies
+	if importer, ok := lock.Importers["."]; ok {
+		direct = importer
+	}
+	locked := make(lockfile)
+	for _, group := range []map[string]pnpmDependency{direct.DevDependencies, direct.OptionalDependencies, direct.Dependencies} {
+		for name, dependency := range group {
+			version, _, _ := strings.Cut(dependency.Version, "(")
+			version, _, _ = strings.Cut(version, "_")
+			locked.set(name, version)
+		}
+	}
+	return locked, nil
+}
@@ -0,0 +1,101 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstalledVersions(t *testing.T) {
+	declared := map[string]string{
+		"lodash":     "^4.17.20",
+		"@scope/kit": "~1.2.0",
+		"left-pad":   "1.3.0", // exact pin, resolvable without a lockfile
+		"unlocked":   "^2.0.0",
+	}
+
+	tests := []struct {
+		name     string
+		lockfile string
+		content  string
+	}{
+		{"package-lock v3", "package-lock.json", `{"lockfileVersion": 3, "packages": {
+			"": {"name": "app"},
+			"node_modules/lodash": {"version": "4.17.21"},
+			"node_modules/@scope/kit": {"version": "1.2.5"},
+			"node_modules/@scope/kit/node_modules/lodash": {"version": "3.10.1"}
+		}}`},
+		{"package-lock v1", "package-lock.json", `{"lockfileVersion": 1, "dependencies": {
+			"lodash": {"version": "4.17.21"},
+			"@scope/kit": {"version": "1.2.5"}
+		}}`},
+		{"yarn classic", "yarn.lock", `# yarn lockfile v1
+
+lodash@^4.17.0, lodash@^4.17.20:
+  version "4.17.21"
+  resolved "https://registry.yarnpkg.com/lodash/-/lodash-4.17.21.tgz"
+
+lodash@^3.0.0:
+  version "3.10.1"
+
+"@scope/kit@~1.2.0":
+  version "1.2.5"
+`},
+		{"yarn berry", "yarn.lock", `__metadata:
+  version: 6
+
+"lodash@npm:^4.17.20":
+  version: 4.17.21
+  resolution: "lodash@npm:4.17.21"
+
+"@scope/kit@npm:~1.2.0":
+  version: 1.2.5
+`},
+		{"pnpm v6", "pnpm-lock.yaml", `lockfileVersion: '6.0'
+dependencies:
+  lodash:
+    specifier: ^4.17.20
+    version: 4.17.21
+  '@scope/kit':
+    specifier: ~1.2.0
+    version: 1.2.5(react@18.2.0)
+`},
+		{"pnpm v9 importers", "pnpm-lock.yaml", `lockfileVersion: '9.0'
+importers:
+  .:
+    dependencies:
+      lodash:
+        specifier: ^4.17.20
+        version: 4.17.21
+    devDependencies:
+      '@scope/kit':
+        specifier: ~1.2.0
+        version: 1.2.5
+`},
+		{"pnpm v5", "pnpm-lock.yaml", `lockfileVersion: 5.4
+dependencies:
+  lodash: 4.17.21
+  '@scope/kit': 1.2.5_react@18.2.0
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeTestFile(t, root, tt.lockfile, tt.content)
+			assert.Equal(t, map[string]string{
+				"lodash":     "4.17.21",
+				"@scope/kit": "1.2.5",
+				"left-pad":   "1.3.0",
+			}, installedVersions(root, declared))
+		})
+	}
+}
+
+func TestInstalledVersions_SkipsRangesWithoutALockfile(t *testing.T) {
+	assert.Equal(t, map[string]string{"left-pad": "1.3.0"}, installedVersions(t.TempDir(), map[string]string{
+		"lodash":   "^4.17.20",
+		"left-pad": "=1.3.0",
+		"tagged":   "latest",
+		"git":      "github:owner/repo#v1.0.0",
+	}))
+}
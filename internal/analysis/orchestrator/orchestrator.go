@@ -0,0 +1,507 @@
+// Package orchestrator coordinates the end-to-end analysis workflow: it
+// acquires a repository in a sandbox, collects its source files and runs
+// the quality reporter over them.
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/security/sandbox"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/security/validator"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+// Options configures a single analysis run
+type Options struct {
+	RepoURL      string                      `json:"repo_url"`
+	ReportConfig metrics.QualityReportConfig `json:"report_config"`
+	MaxFileSize  int64                       `json:"max_file_size"` // bytes
+	// LargeFileSize is the size in bytes above which source files are line-counted but not
+	// loaded or parsed; 0 uses 1MB and a negative value parses every file up to MaxFileSize
+	LargeFileSize int64 `json:"large_file_size,omitempty"`
+	// MaxDepth is how many directories below the root the file walk enters; deeper ones
+	// are skipped and logged. 0 uses 64.
+	MaxDepth    int            `json:"max_depth,omitempty"`
+	MaxRepoSize int64          `json:"max_repo_size"` // bytes of cloned or extracted source; 0 keeps the sandbox default
+	Ownership   bool           `json:"ownership"`     // add a bus-factor onboarding-risk section from git history
+	SinceRef    string         `json:"since_ref"`     // git ref of the change's base; debt introduced after it is listed as new
+	Logger      *logger.Logger `json:"-"`
+
+	// RepoCache, when its Dir is set, keeps clones across runs and fetches them instead of
+	// cloning again
+	RepoCache sandbox.RepoCache `json:"repo_cache"`
+
+	// GitCache configures the git history cache shared by the run's history and blame lookups
+	GitCache metrics.GitDataCacheConfig `json:"git_cache"`
+
+	// OnSection, when set, receives each analyzer's metrics as soon as it completes
+	OnSection metrics.SectionHandler `json:"-"`
+
+	// ListGeneratedFiles lists the generated files excluded from scoring in the report
+	ListGeneratedFiles bool `json:"list_generated_files"`
+
+	// IncludeVendored analyzes likely-vendored third-party directories like the host code
+	// instead of excluding them and listing them under vendored_code
+	IncludeVendored bool `json:"include_vendored"`
+
+	// OSVLookup looks the package.json dependencies up in the OSV vulnerability database and
+	// lists what it finds under vulnerabilities. Off by default, so a run makes no
+	// network calls unless asked to.
+	OSVLookup bool `json:"osv_lookup"`
+
+	// Offline keeps every network enrichment off the network: their HTTP client serves from
+	// its cache only, and the run metadata lists a lookup that needed the network as skipped
+	Offline bool `json:"offline"`
+
+	// EnrichmentTimeout bounds each network enrichment; one that times out or fails is
+	// skipped instead of failing the analysis. 0 uses 15s.
+	EnrichmentTimeout time.Duration `json:"enrichment_timeout,omitempty"`
+
+	// ToolVersion and BuildDate identify the build that produced the report in its run metadata
+	ToolVersion string `json:"tool_version,omitempty"`
+	BuildDate   string `json:"build_date,omitempty"`
+}
+
+// Analyze validates and clones the repository, then produces a quality report for it.
+// It is the library entry point shared by the CLI and the HTTP server. Failures are
+// *types.AnalysisError values wrapping one of the types.Err* kinds.
+func Analyze(ctx context.Context, opts Options) (*metrics.QualityReport, error) {
+	if opts.Logger == nil {
+		opts.Logger = logger.New()
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = defaultMaxFileSize
+	}
+
+	if err := validateReportConfig(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	root, name, cleanup, err := cloneRepository(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	clone := phase("clone", started)
+
+	report, err := AnalyzeDirectory(ctx, root, opts)
+	if report == nil {
+		return nil, err
+	}
+	recordPhases(report, started, clone)
+
+	report.ProjectName = name
+	return report, err
+}
+
+// AnalyzeArchive validates and extracts a local source archive (.tar.gz, .tgz, .tar or .zip),
+// then produces a quality report for its contents. Archives carry no git history, so
+// history-based features are skipped.
+func AnalyzeArchive(ctx context.Context, archivePath string, opts Options) (*metrics.QualityReport, error) {
+	if opts.Logger == nil {
+		opts.Logger = logger.New()
+	}
+
+	if err := validateReportConfig(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	root, name, cleanup, err := extractArchive(ctx, archivePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	extract := phase("extract", started)
+
+	report, err := AnalyzeDirectory(ctx, root, opts)
+	if report == nil {
+		return nil, err
+	}
+	recordPhases(report, started, extract)
+
+	report.ProjectName = name
+	return report, err
+}
+
+// validateReportConfig rejects a report configuration that contradicts itself before any
+// repository is cloned or extracted
+func validateReportConfig(ctx context.Context, opts Options) error {
+	if err := opts.ReportConfig.Validate(); err != nil {
+		return stageError(ctx, types.StageValidate, types.ErrInvalidInput, fmt.Errorf("invalid report configuration: %w", err))
+	}
+	return nil
+}
+
+// cloneRepository validates opts.RepoURL and clones it into the sandbox, returning the
+// clone's path, the project name and a cleanup function to call once done with it
+func cloneRepository(ctx context.Context, opts Options) (string, string, func(), error) {
+	repoURL, err := validator.New().ValidateRepositoryURL(opts.RepoURL)
+	if err != nil {
+		return "", "", nil, stageError(ctx, types.StageValidate, types.ErrInvalidInput, fmt.Errorf("invalid repository URL: %w", err))
+	}
+
+	gitHandler, err := sandbox.NewGitHandler(opts.Logger)
+	if err != nil {
+		return "", "", nil, stageError(ctx, types.StageClone, types.ErrCloneFailed, fmt.Errorf("failed to initialize git handler: %w", err))
+	}
+	if opts.MaxRepoSize > 0 {
+		gitHandler.MaxRepoSize = opts.MaxRepoSize
+	}
+	gitHandler.FullHistory = opts.ReportConfig.Since > 0 || opts.Ownership || opts.SinceRef != ""
+
+	var cloneResult *sandbox.GitCloneResult
+	if opts.RepoCache.Dir != "" {
+		cloneResult, err = gitHandler.CachedClone(ctx, repoURL.Raw, opts.RepoCache)
+	} else {
+		cloneResult, err = gitHandler.CloneRepository(ctx, repoURL.Raw)
+	}
+	if err != nil {
+		gitHandler.Cleanup()
+		return "", "", nil, stageError(ctx, types.StageClone, types.ErrCloneFailed, err)
+	}
+	return cloneResult.LocalPath, repoURL.Raw, func() { gitHandler.Cleanup() }, nil
+}
+
+// extractArchive validates and extracts archivePath into the sandbox, returning the
+// extracted path, the project name and a cleanup function to call once done with it
+func extractArchive(ctx context.Context, archivePath string, opts Options) (string, string, func(), error) {
+	archive, err := validator.New().ValidateArchive(archivePath)
+	if err != nil {
+		return "", "", nil, stageError(ctx, types.StageValidate, types.ErrInvalidInput, fmt.Errorf("invalid archive: %w", err))
+	}
+
+	archiveHandler, err := sandbox.NewArchiveHandler(opts.Logger)
+	if err != nil {
+		return "", "", nil, stageError(ctx, types.StageExtract, types.ErrExtractFailed, fmt.Errorf("failed to initialize archive handler: %w", err))
+	}
+	if opts.MaxRepoSize > 0 {
+		archiveHandler.MaxExtractedSize = opts.MaxRepoSize
+	}
+
+	extractResult, err := archiveHandler.ExtractArchive(ctx, archive)
+	if err != nil {
+		archiveHandler.Cleanup()
+		return "", "", nil, stageError(ctx, types.StageExtract, types.ErrExtractFailed, err)
+	}
+	return extractResult.LocalPath, filepath.Base(archive.Path), func() { archiveHandler.Cleanup() }, nil
+}
+
+// AnalyzeDirectory produces a quality report for source files already present on disk.
+// A directory without supported source files yields a stub report together with an
+// error wrapping metrics.ErrNoAnalyzableFiles; Analyze and AnalyzeArchive pass both through.
+func AnalyzeDirectory(ctx context.Context, root string, opts Options) (*metrics.QualityReport, error) {
+	if err := validateReportConfig(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = defaultMaxFileSize
+	}
+
+	if opts.LargeFileSize == 0 {
+		opts.LargeFileSize = defaultLargeFileSize
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = defaultMaxDepth
+	}
+
+	var excluded []metrics.FileDisposition
+	fileContents, generated, largeFiles, err := collectSourceFiles(root, opts.MaxFileSize, opts.LargeFileSize, opts.MaxDepth, skippedPathLogger(opts.Logger), func(file metrics.FileDisposition) {
+		excluded = append(excluded, file)
+	})
+	if err != nil {
+		return nil, stageError(ctx, types.StageCollect, types.ErrAnalysisFailed, fmt.Errorf("failed to collect source files: %w", err))
+	}
+	opts.ReportConfig.LargeFiles = largeFiles
+
+	var vendored []metrics.VendoredDirectory
+	if !opts.IncludeVendored {
+		kept, dirs := excludeVendored(fileContents)
+		excluded = append(excluded, vendoredDispositions(fileContents, dirs)...)
+		fileContents, vendored = kept, dirs
+	}
+	opts.ReportConfig.ExcludedFiles = excluded
+
+	// Every git lookup of the run reads the history through one cache
+	gitData := metrics.NewGitDataCacheWithConfig(root, opts.GitCache)
+
+	if opts.ReportConfig.Since > 0 && opts.ReportConfig.ActiveFiles == nil {
+		opts.ReportConfig.ActiveFiles = changedFiles(ctx, gitData, opts)
+	}
+
+	if opts.ReportConfig.DependencyVersions == nil {
+		opts.ReportConfig.DependencyVersions = dependencyVersions(root, opts)
+	}
+
+	if opts.ReportConfig.OtherFileLines == nil {
+		otherLines, dataFiles, err := countOtherFiles(root, opts.MaxFileSize, opts.MaxDepth)
+		if err != nil {
+			return nil, stageError(ctx, types.StageCollect, types.ErrAnalysisFailed, fmt.Errorf("failed to count other files: %w", err))
+		}
+		for _, file := range largeFiles {
+			otherLines[file.Path] = file.Lines // still counted in the language shares
+		}
+		opts.ReportConfig.OtherFileLines = otherLines
+		opts.ReportConfig.DataFiles = dataFiles
+	}
+
+	collect := phase("collect", started)
+
+	enrichStart := time.Now()
+	enrichments, vulnerabilities := enrich(ctx, root, opts)
+	enrichment := phase("enrichments", enrichStart)
+
+	if opts.ReportConfig.OnTemplateError == nil {
+		opts.ReportConfig.OnTemplateError = templateErrorLogger(opts.Logger)
+	}
+	reporter := metrics.NewQualityReporter(opts.ReportConfig)
+	report, err := reporter.GenerateQualityReportWithSections(ctx, fileContents, opts.OnSection)
+	if report != nil {
+		describeRun(ctx, root, gitData, report.RunMetadata, enrichments, opts)
+	}
+	switch {
+	case err == nil:
+		if opts.Ownership {
+			report.OnboardingRisk = onboardingRisk(ctx, gitData, fileContents, opts)
+		}
+		if opts.SinceRef != "" && report.DetailedMetrics.TechnicalDebt != nil {
+			report.DetailedMetrics.TechnicalDebt.NewInChange = newDebt(ctx, gitData, report.DetailedMetrics.TechnicalDebt, opts)
+		}
+		if report.RunMetadata != nil {
+			report.RunMetadata.GitDataMillis = gitData.Elapsed().Milliseconds() // now including ownership and blame
+		}
+		if opts.ListGeneratedFiles {
+			report.GeneratedFiles = generated
+		}
+		report.VendoredCode = vendored
+		report.Vulnerabilities = vulnerabilities
+		report.HowToRun = projectCommands(root, opts)
+		recordPhases(report, started, collect, enrichment)
+		return report, nil
+	case errors.Is(err, types.ErrNoAnalyzableFiles):
+		if opts.ListGeneratedFiles {
+			report.GeneratedFiles = generated
+		}
+		report.VendoredCode = vendored
+		report.Vulnerabilities = vulnerabilities
+		report.HowToRun = projectCommands(root, opts)
+		recordPhases(report, started, collect, enrichment)
+		return report, stageError(ctx, types.StageCollect, types.ErrNoAnalyzableFiles, err)
+	case errors.Is(err, types.ErrParseFailed):
+		return nil, stageError(ctx, types.StageAnalyze, types.ErrParseFailed, err)
+	default:
+		return nil, stageError(ctx, types.StageAnalyze, types.ErrAnalysisFailed, fmt.Errorf("quality analysis failed: %w", err))
+	}
+}
+
+// phase records the time since start as the named phase of the run
+func phase(name string, start time.Time) metrics.PhaseTiming {
+	return metrics.PhaseTiming{Name: name, Millis: time.Since(start).Milliseconds()}
+}
+
+// recordPhases puts phases that ran before the reporter's ahead of them in the run metadata
+// and extends the run's duration back to started
+func recordPhases(report *metrics.QualityReport, started time.Time, phases ...metrics.PhaseTiming) {
+	if report.RunMetadata == nil {
+		return
+	}
+	report.RunMetadata.Phases = append(phases, report.RunMetadata.Phases...)
+	report.RunMetadata.DurationMillis = time.Since(started).Milliseconds()
+}
+
+// describeRun completes the run metadata with what only the caller that collected the files
+// knows: the tool build, the analyzed commit, the exclusion rules, the time spent on git and
+// the network enrichments attempted
+func describeRun(ctx context.Context, root string, gitData *metrics.GitDataCache, metadata *metrics.RunMetadata, enrichments []metrics.EnrichmentStatus, opts Options) {
+	if metadata == nil {
+		return
+	}
+	metadata.ToolVersion = opts.ToolVersion
+	metadata.BuildDate = opts.BuildDate
+	metadata.MaxFileSize = opts.MaxFileSize
+	metadata.LargeFileSize = opts.LargeFileSize
+	metadata.MaxDepth = opts.MaxDepth
+	metadata.ExcludedPatterns = append(append([]string{}, defaultExcludePatterns...), generatedFilePatterns...)
+	metadata.Enrichments = enrichments
+
+	// Archives and plain directories have no git history; the SHA is left empty
+	if _, err := os.Stat(filepath.Join(root, ".git")); err == nil {
+		if sha, err := gitData.HeadCommit(ctx); err == nil {
+			metadata.CommitSHA = sha
+		} else if opts.Logger != nil {
+			opts.Logger.WithFields(map[string]interface{}{
+				"error": err.Error(),
+			}).Warn("Could not read the analyzed commit; run metadata has no commit SHA")
+		}
+	}
+	metadata.GitDataMillis = gitData.Elapsed().Milliseconds()
+}
+
+// templateErrorLogger warns about a recommendation template that failed on a finding, which
+// is then worded with the built-in template
+func templateErrorLogger(log *logger.Logger) metrics.TemplateErrorHandler {
+	if log == nil {
+		return nil
+	}
+	return func(kind string, err error) {
+		log.WithFields(map[string]interface{}{
+			"template": kind,
+			"error":    err.Error(),
+		}).Warn("Recommendation template failed; using the built-in wording")
+	}
+}
+
+// changedFiles lists files committed within the --since window. Without git history
+// (archives, or git unavailable) it returns nil and debt covers every file.
+func changedFiles(ctx context.Context, gitData *metrics.GitDataCache, opts Options) map[string]bool {
+	changes, err := gitData.ChangedSince(ctx, time.Now().Add(-opts.ReportConfig.Since))
+	if err != nil {
+		if opts.Logger != nil {
+			opts.Logger.WithFields(map[string]interface{}{
+				"since": metrics.FormatWindow(opts.ReportConfig.Since),
+				"error": err.Error(),
+			}).Warn("Git history unavailable; technical debt covers all files")
+		}
+		return nil
+	}
+
+	active := make(map[string]bool, len(changes))
+	for file := range changes {
+		active[file] = true
+	}
+	return active
+}
+
+// dependencyVersions reads the version ranges declared in the root package.json, used to
+// size heavy dependencies by major version. Without a readable package.json it returns nil
+// and sizes fall back to the library defaults.
+func dependencyVersions(root string, opts Options) map[string]string {
+	data, err := os.ReadFile(filepath.Join(root, "package.json"))
+	if err != nil {
+		return nil
+	}
+
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		if opts.Logger != nil {
+			opts.Logger.WithFields(map[string]interface{}{
+				"error": err.Error(),
+			}).Warn("Invalid package.json; dependency sizes ignore declared versions")
+		}
+		return nil
+	}
+
+	versions := make(map[string]string, len(manifest.Dependencies)+len(manifest.DevDependencies))
+	for name, version := range manifest.DevDependencies {
+		versions[name] = version
+	}
+	for name, version := range manifest.Dependencies {
+		versions[name] = version
+	}
+	return versions
+}
+
+// projectCommands gathers how to build, test and run the project from the root package.json,
+// its lockfiles and the CI configuration files. Without either it returns nil and the section
+// is omitted; an unreadable file is logged and the section left out.
+func projectCommands(root string, opts Options) *metrics.ProjectCommands {
+	packageJSON, _ := os.ReadFile(filepath.Join(root, "package.json")) // nil without one
+
+	var lockfiles []string
+	for _, lockfile := range []string{"package-lock.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb"} {
+		if _, err := os.Stat(filepath.Join(root, lockfile)); err == nil {
+			lockfiles = append(lockfiles, lockfile)
+		}
+	}
+
+	candidates, _ := filepath.Glob(filepath.Join(root, ".github", "workflows", "*"))
+	candidates = append(candidates,
+		filepath.Join(root, ".circleci", "config.yml"),
+		filepath.Join(root, ".circleci", "config.yaml"),
+		filepath.Join(root, ".gitlab-ci.yml"))
+	ciConfigs := make(map[string][]byte)
+	for _, candidate := range candidates {
+		rel, err := filepath.Rel(root, candidate)
+		if err != nil || metrics.CIProviderForPath(filepath.ToSlash(rel)) == "" {
+			continue
+		}
+		if data, err := os.ReadFile(candidate); err == nil {
+			ciConfigs[filepath.ToSlash(rel)] = data
+		}
+	}
+
+	commands, err := metrics.BuildProjectCommands(packageJSON, lockfiles, ciConfigs)
+	if err != nil {
+		if opts.Logger != nil {
+			opts.Logger.WithFields(map[string]interface{}{
+				"error": err.Error(),
+			}).Warn("Could not read the project commands; the how-to-run section is omitted")
+		}
+		return nil
+	}
+	return commands
+}
+
+// onboardingRisk measures author concentration of the analyzed files. Without git
+// history it returns nil and the section is omitted.
+func onboardingRisk(ctx context.Context, gitData *metrics.GitDataCache, fileContents map[string]string, opts Options) *metrics.OnboardingRisk {
+	files := make([]string, 0, len(fileContents))
+	for file := range fileContents {
+		files = append(files, file)
+	}
+
+	risk, err := metrics.NewBusFactorAnalyzerWithCache(gitData).AnalyzeBusFactor(ctx, files)
+	if err != nil {
+		if opts.Logger != nil {
+			opts.Logger.WithFields(map[string]interface{}{
+				"error": err.Error(),
+			}).Warn("Git history unavailable; skipping ownership analysis")
+		}
+		return nil
+	}
+	return risk
+}
+
+// newDebt blames the debt items to find those introduced since opts.SinceRef. Without git
+// history or with an unknown ref it returns nil and the subsection is omitted.
+func newDebt(ctx context.Context, gitData *metrics.GitDataCache, technicalDebt *metrics.TechnicalDebtMetrics, opts Options) *metrics.DebtChange {
+	change, err := gitData.NewDebtSince(ctx, opts.SinceRef, technicalDebt)
+	if err != nil {
+		if opts.Logger != nil {
+			opts.Logger.WithFields(map[string]interface{}{
+				"since_ref": opts.SinceRef,
+				"error":     err.Error(),
+			}).Warn("Git history unavailable; skipping new debt highlighting")
+		}
+		return nil
+	}
+	return change
+}
+
+// stageError wraps err in a types.AnalysisError of the given kind. Deadlines and
+// cancellation take precedence over kind, since they explain why the stage failed.
+func stageError(ctx context.Context, stage string, kind error, err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		kind = types.ErrAnalysisTimeout
+	case errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled):
+		kind = types.ErrAnalysisCanceled
+	case errors.Is(err, types.ErrRepositoryTooLarge):
+		kind = types.ErrRepositoryTooLarge
+	}
+	return &types.AnalysisError{Stage: stage, Kind: kind, Err: err}
+}
@@ -0,0 +1,460 @@
+package orchestrator
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+func writeTestFile(t *testing.T, root, relPath, content string) {
+	t.Helper()
+	path := filepath.Join(root, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestCollectSourceFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main() { return 1; }\n")
+	writeTestFile(t, root, "src/types.ts", "export interface User { id: number }\n")
+	writeTestFile(t, root, "src/vendor.min.js", "var a=1;\n")
+	writeTestFile(t, root, "node_modules/lib/index.js", "module.exports = {};\n")
+	writeTestFile(t, root, "README.md", "# readme\n")
+
+	files, generated, _, err := collectSourceFiles(root, defaultMaxFileSize, defaultLargeFileSize, defaultMaxDepth, nil, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, files, 2)
+	assert.Contains(t, files, "src/app.js")
+	assert.Contains(t, files, "src/types.ts")
+	assert.Equal(t, []string{"src/vendor.min.js"}, generated)
+}
+
+func TestCollectSourceFiles_SkipsOversizedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "small.js", "const a = 1;\n")
+	writeTestFile(t, root, "large.js", "const b = 'this file is larger than the limit';\n")
+
+	files, _, _, err := collectSourceFiles(root, 20, 0, defaultMaxDepth, nil, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, files, 1)
+	assert.Contains(t, files, "small.js")
+}
+
+func TestCollectSourceFiles_StreamsLargeFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "small.js", "const a = 1;\n")
+	writeTestFile(t, root, "big.js", "const b = 1;\nconst c = 2;\nconst d = 3;\n")
+	writeTestFile(t, root, "big.gen.js", "// Code generated by protoc. DO NOT EDIT.\nconst e = 1;\n")
+
+	files, generated, largeFiles, err := collectSourceFiles(root, defaultMaxFileSize, 20, defaultMaxDepth, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"small.js": "const a = 1;\n"}, files)
+	assert.Equal(t, []string{"big.gen.js"}, generated, "the header check still runs on large files")
+	assert.Equal(t, []metrics.LargeFile{{Path: "big.js", Bytes: 39, Lines: 3}}, largeFiles)
+}
+
+func TestSelectFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main() { return 1; }\n")
+	writeTestFile(t, root, "src/big.js", "const b = 1;\nconst c = 2;\nconst d = 3;\nconst e = 4;\n")
+	writeTestFile(t, root, "src/vendor.min.js", "var a=1;\n")
+	writeTestFile(t, root, "node_modules/lib/index.js", "module.exports = {};\n")
+	writeTestFile(t, root, "README.md", "# readme\n")
+
+	selection, err := SelectFiles(context.Background(), root, Options{LargeFileSize: 40})
+	require.NoError(t, err)
+
+	assert.Equal(t, []SelectedFile{{Path: "src/app.js", Bytes: 30}}, selection.Files)
+	assert.Equal(t, int64(30), selection.TotalBytes)
+	assert.Equal(t, []metrics.LargeFile{{Path: "src/big.js", Bytes: 52, Lines: 4}}, selection.LargeFiles)
+	assert.Equal(t, []string{"src/vendor.min.js"}, selection.Generated)
+
+	files, generated, largeFiles, err := collectSourceFiles(root, defaultMaxFileSize, 40, defaultMaxDepth, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, files, len(selection.Files), "the selection matches what an analysis reads")
+	assert.Contains(t, files, "src/app.js")
+	assert.Equal(t, generated, selection.Generated)
+	assert.Equal(t, largeFiles, selection.LargeFiles)
+}
+
+func TestCountOtherFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main() { return 1; }\n")
+	writeTestFile(t, root, "package.json", "{\n  \"name\": \"app\"\n}")
+	writeTestFile(t, root, "tools/gen.py", "print(1)\n")
+	writeTestFile(t, root, "pytest.ini", "[pytest]\ntestpaths = tests\n")
+	writeTestFile(t, root, "node_modules/lib/package.json", "{}\n")
+	writeTestFile(t, root, "README.md", "# readme\n")
+	writeTestFile(t, root, "package-lock.json", "{\n  \"lockfileVersion\": 3\n}\n")
+	writeTestFile(t, root, "test/__snapshots__/app.test.js.snap", "exports[`app 1`] = `1`;\n")
+	writeTestFile(t, root, "test/fixtures/users.json", "[]\n")
+
+	lines, dataFiles, err := countOtherFiles(root, defaultMaxFileSize, defaultMaxDepth)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"package.json": 3, "pytest.ini": 2, "tools/gen.py": 1}, lines)
+	assert.Equal(t, []metrics.DataFile{
+		{Path: "package-lock.json", Kind: metrics.DataFileLockfile, Bytes: 27, Lines: 3},
+		{Path: "test/__snapshots__/app.test.js.snap", Kind: metrics.DataFileSnapshot, Bytes: 24, Lines: 1},
+		{Path: "test/fixtures/users.json", Kind: metrics.DataFileFixture, Bytes: 3, Lines: 1},
+	}, dataFiles)
+}
+
+func TestAnalyzeDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/math.js", `
+export function add(a, b) {
+  return a + b;
+}
+
+export class Calculator {
+  multiply(a, b) {
+    return a * b;
+  }
+}
+`)
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{})
+	require.NoError(t, err)
+	require.NotNil(t, report)
+
+	assert.GreaterOrEqual(t, report.OverallScore, 0.0)
+	assert.NotNil(t, report.DetailedMetrics.Complexity)
+}
+
+func TestAnalyzeDirectory_DetectsPytestConfig(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/math.js", "export function add(a, b) {\n  return a + b;\n}\n")
+	writeTestFile(t, root, "pytest.ini", "[pytest]\ntestpaths = tests\n")
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{})
+	require.NoError(t, err)
+	require.NotNil(t, report.DetailedMetrics.Coverage)
+	assert.Equal(t, metrics.TestFrameworkPytest, report.DetailedMetrics.Coverage.TestFramework.Name)
+	assert.Equal(t, "config file pytest.ini", report.DetailedMetrics.Coverage.TestFramework.Evidence)
+}
+
+func TestAnalyzeDirectory_RejectsInvertedFunctionSize(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/math.js", "export function add(a, b) {\n  return a + b;\n}\n")
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{
+		ReportConfig: metrics.QualityReportConfig{FunctionSize: metrics.FunctionSizeThresholds{Smell: 120, Critical: 60}},
+	})
+	assert.Nil(t, report)
+	assert.ErrorIs(t, err, types.ErrInvalidInput)
+}
+
+func TestAnalyzeDirectory_FileDispositions(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "export function main() { return 1; }\n")
+	writeTestFile(t, root, "src/bundle.min.js", "var a=1;\n")
+	writeTestFile(t, root, "src/big.js", "export const big = '"+strings.Repeat("x", 64)+"';\n")
+	writeTestFile(t, root, "third_party/pad/index.js", "export function pad() {}\n")
+	writeTestFile(t, root, "node_modules/lib/index.js", "module.exports = {};\n")
+	writeTestFile(t, root, "README.md", "# readme\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{LargeFileSize: 64})
+	require.NoError(t, err)
+	require.NotNil(t, report.FileDispositions)
+
+	dispositions := make(map[string]string)
+	for _, file := range report.FileDispositions.Files {
+		dispositions[file.Path] = file.Disposition
+	}
+	assert.Equal(t, map[string]string{
+		"README.md":                metrics.DispositionUnsupportedLanguage,
+		"node_modules":             metrics.DispositionExcludedByPattern,
+		"src/app.js":               metrics.DispositionAnalyzed,
+		"src/big.js":               metrics.DispositionTooLarge,
+		"src/bundle.min.js":        metrics.DispositionGenerated,
+		"third_party/pad/index.js": metrics.DispositionExcludedByPattern,
+	}, dispositions, "the .git directory is left out")
+	assert.Equal(t, map[string]int{
+		metrics.DispositionAnalyzed:            1,
+		metrics.DispositionExcludedByPattern:   2,
+		metrics.DispositionGenerated:           1,
+		metrics.DispositionTooLarge:            1,
+		metrics.DispositionUnsupportedLanguage: 1,
+	}, report.FileDispositions.Counts)
+}
+
+func TestAnalyzeDirectory_StreamsSections(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main(a) { if (a) { return 1; } return 2; }\n")
+
+	var sections []metrics.ReportSection
+	report, err := AnalyzeDirectory(context.Background(), root, Options{
+		OnSection: func(section metrics.ReportSection) {
+			sections = append(sections, section)
+		},
+	})
+	require.NoError(t, err)
+
+	names := make([]metrics.SectionName, 0, len(sections))
+	for _, section := range sections {
+		names = append(names, section.Name)
+	}
+	assert.Equal(t, []metrics.SectionName{
+		metrics.SectionComplexity,
+		metrics.SectionDuplication,
+		metrics.SectionTechnicalDebt,
+		metrics.SectionCoverage,
+		metrics.SectionPerformance,
+		metrics.SectionDocumentation,
+		metrics.SectionMaintainability,
+		metrics.SectionAPISurface,
+		metrics.SectionTodoInventory,
+	}, names)
+
+	// Streamed sections are copies of the data that ends up in the final report
+	assert.Equal(t, report.DetailedMetrics.Complexity, sections[0].Metrics)
+	assert.NotSame(t, report.DetailedMetrics.Complexity, sections[0].Metrics)
+	assert.Equal(t, report.DetailedMetrics.Maintainability, sections[6].Metrics)
+	assert.Equal(t, report.APISurface, sections[7].Metrics)
+	assert.Equal(t, report.TodoInventory, sections[8].Metrics)
+}
+
+func TestAnalyzeDirectory_NoSourceFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "README.md", "# docs only\n")
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{})
+	require.ErrorIs(t, err, metrics.ErrNoAnalyzableFiles)
+	require.NotNil(t, report)
+	assert.Equal(t, metrics.QualityGateNotApplicable, report.QualityGate)
+}
+
+func TestAnalyzeArchive_NoSourceFiles(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("snapshot/README.md")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("# docs only\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	archivePath := filepath.Join(t.TempDir(), "docs.zip")
+	require.NoError(t, os.WriteFile(archivePath, buf.Bytes(), 0644))
+
+	report, err := AnalyzeArchive(context.Background(), archivePath, Options{})
+	require.ErrorIs(t, err, metrics.ErrNoAnalyzableFiles)
+	require.NotNil(t, report)
+	assert.Equal(t, "docs.zip", report.ProjectName)
+}
+
+func TestAnalyzeArchive(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("snapshot/src/app.js")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("export function main(a) { return a ? 1 : 2; }\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	archivePath := filepath.Join(t.TempDir(), "snapshot.zip")
+	require.NoError(t, os.WriteFile(archivePath, buf.Bytes(), 0644))
+
+	report, err := AnalyzeArchive(context.Background(), archivePath, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot.zip", report.ProjectName)
+	assert.NotNil(t, report.DetailedMetrics.Complexity)
+}
+
+func TestListArchiveFiles(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("snapshot/src/app.js")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("export function main() {}\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	archivePath := filepath.Join(t.TempDir(), "snapshot.zip")
+	require.NoError(t, os.WriteFile(archivePath, buf.Bytes(), 0644))
+
+	selection, err := ListArchiveFiles(context.Background(), archivePath, Options{})
+	require.NoError(t, err)
+	require.Len(t, selection.Files, 1)
+	assert.True(t, strings.HasSuffix(selection.Files[0].Path, "src/app.js"))
+	assert.Equal(t, int64(26), selection.TotalBytes)
+}
+
+func TestAnalyzeArchive_InvalidArchive(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	require.NoError(t, os.WriteFile(archivePath, []byte("not an archive"), 0644))
+
+	_, err := AnalyzeArchive(context.Background(), archivePath, Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid archive")
+	assert.ErrorIs(t, err, types.ErrInvalidInput)
+}
+
+func TestAnalyze_InvalidURL(t *testing.T) {
+	_, err := Analyze(context.Background(), Options{RepoURL: "file:///etc/passwd"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, types.ErrInvalidInput)
+
+	var analysisErr *types.AnalysisError
+	require.ErrorAs(t, err, &analysisErr)
+	assert.Equal(t, types.StageValidate, analysisErr.Stage)
+}
+
+func TestAnalyzeDirectory_CanceledContext(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main() { return 1; }\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := AnalyzeDirectory(ctx, root, Options{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, types.ErrAnalysisCanceled)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAnalyzeDirectory_Ownership(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main() { return 1; }\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.email=alice@example.com", "-c", "user.name=alice", "commit", "-q", "-m", "init"},
+	} {
+		output, err := exec.Command("git", append([]string{"-C", root}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{Ownership: true})
+	require.NoError(t, err)
+	require.NotNil(t, report.OnboardingRisk)
+	require.Len(t, report.OnboardingRisk.Modules, 1)
+	assert.Equal(t, "src", report.OnboardingRisk.Modules[0].Module)
+	assert.True(t, report.OnboardingRisk.Modules[0].OnboardingRisk)
+
+	// Without git history the section is omitted rather than failing the run
+	plain := t.TempDir()
+	writeTestFile(t, plain, "src/app.js", "function main() { return 1; }\n")
+	report, err = AnalyzeDirectory(context.Background(), plain, Options{Ownership: true})
+	require.NoError(t, err)
+	assert.Nil(t, report.OnboardingRisk)
+}
+
+func TestAnalyzeDirectory_RunMetadata(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main() { return 1; }\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.email=alice@example.com", "-c", "user.name=alice", "commit", "-q", "-m", "init"},
+	} {
+		output, err := exec.Command("git", append([]string{"-C", root}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	head, err := exec.Command("git", "-C", root, "rev-parse", "HEAD").Output()
+	require.NoError(t, err)
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{ToolVersion: "1.4.0", BuildDate: "2026-01-02"})
+	require.NoError(t, err)
+
+	metadata := report.RunMetadata
+	require.NotNil(t, metadata)
+	assert.Equal(t, "1.4.0", metadata.ToolVersion)
+	assert.Equal(t, "2026-01-02", metadata.BuildDate)
+	assert.Equal(t, runtime.Version(), metadata.GoVersion)
+	assert.Equal(t, strings.TrimSpace(string(head)), metadata.CommitSHA)
+	assert.Equal(t, report.GeneratedAt, metadata.AnalyzedAt)
+	assert.Contains(t, metadata.ExcludedPatterns, "node_modules")
+	assert.Contains(t, metadata.ExcludedPatterns, "*.min.js")
+	assert.Equal(t, int64(defaultMaxFileSize), metadata.MaxFileSize)
+	assert.Equal(t, 0.25, metadata.Config.WeightingFactors.TechnicalDebt, "defaults are recorded as applied")
+
+	// Without git history there is no commit to record
+	plain := t.TempDir()
+	writeTestFile(t, plain, "src/app.js", "function main() { return 1; }\n")
+	report, err = AnalyzeDirectory(context.Background(), plain, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, report.RunMetadata.CommitSHA)
+}
+
+func TestDependencyVersions(t *testing.T) {
+	root := t.TempDir()
+	assert.Nil(t, dependencyVersions(root, Options{}), "no package.json")
+
+	writeTestFile(t, root, "package.json", `{
+  "dependencies": {"antd": "^4.24.0", "react": "^18.2.0"},
+  "devDependencies": {"antd": "^5.0.0", "jest": "^29.0.0"}
+}`)
+	assert.Equal(t, map[string]string{
+		"antd":  "^4.24.0",
+		"react": "^18.2.0",
+		"jest":  "^29.0.0",
+	}, dependencyVersions(root, Options{}))
+
+	writeTestFile(t, root, "package.json", "{not json")
+	assert.Nil(t, dependencyVersions(root, Options{}))
+}
+
+func TestAnalyzeDirectory_HowToRun(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "export const app = 1;\n")
+	writeTestFile(t, root, "package.json", `{"scripts": {"build": "tsc", "test": "jest"}}`)
+	writeTestFile(t, root, "package-lock.json", "{}")
+	writeTestFile(t, root, ".github/workflows/ci.yml", "jobs:\n  test:\n    steps:\n      - run: npm ci\n      - run: npm test\n")
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{})
+	require.NoError(t, err)
+	require.NotNil(t, report.HowToRun)
+	assert.Equal(t, "npm ci", report.HowToRun.Install)
+	assert.Equal(t, "npm run build", report.HowToRun.Build[0].Command)
+	assert.Equal(t, "npm test", report.HowToRun.Test[0].Command)
+	assert.Equal(t, []metrics.CIConfig{{Provider: metrics.CIGitHubActions, Path: ".github/workflows/ci.yml", Commands: []string{"npm ci", "npm test"}}}, report.HowToRun.CI)
+
+	writeTestFile(t, root, "package.json", "{not json")
+	report, err = AnalyzeDirectory(context.Background(), root, Options{})
+	require.NoError(t, err)
+	assert.Nil(t, report.HowToRun, "an unreadable package.json leaves the section out")
+}
+
+func TestAnalyzeDirectory_PhaseTimings(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main() { return 1; }\n")
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{})
+	require.NoError(t, err)
+
+	var names []string
+	var sum int64
+	for _, phase := range report.RunMetadata.Phases {
+		names = append(names, phase.Name)
+		sum += phase.Millis
+	}
+	assert.Equal(t, []string{
+		"collect", "enrichments", "parse", "complexity", "duplication", "technical_debt", "coverage",
+		"performance", "documentation", "maintainability", "api_surface", "todo_inventory", "report",
+	}, names)
+	assert.GreaterOrEqual(t, report.RunMetadata.DurationMillis, sum, "the duration covers every phase")
+}
@@ -0,0 +1,76 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+// FileSelection is what an analysis would read: the result of every exclusion, size and
+// generated-code filter, computed without loading or analyzing any file
+type FileSelection struct {
+	Files      []SelectedFile      `json:"files"`       // parsed and analyzed, by path
+	TotalBytes int64               `json:"total_bytes"` // size of Files
+	LargeFiles []metrics.LargeFile `json:"large_files"` // above the large-file size: line-counted only
+	Generated  []string            `json:"generated"`   // excluded as generated code
+}
+
+// SelectedFile is one source file an analysis would parse
+type SelectedFile struct {
+	Path  string `json:"path"` // slash-separated, relative to the repository root
+	Bytes int64  `json:"bytes"`
+}
+
+// SelectFiles resolves the files AnalyzeDirectory would analyze under root
+func SelectFiles(ctx context.Context, root string, opts Options) (*FileSelection, error) {
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = defaultMaxFileSize
+	}
+	if opts.LargeFileSize == 0 {
+		opts.LargeFileSize = defaultLargeFileSize
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = defaultMaxDepth
+	}
+
+	selection, err := selectSourceFiles(root, opts.MaxFileSize, opts.LargeFileSize, opts.MaxDepth, skippedPathLogger(opts.Logger))
+	if err != nil {
+		return nil, stageError(ctx, types.StageCollect, types.ErrAnalysisFailed, fmt.Errorf("failed to collect source files: %w", err))
+	}
+	return selection, nil
+}
+
+// ListFiles validates and clones the repository like Analyze, then resolves the files
+// it would analyze without running any analyzer
+func ListFiles(ctx context.Context, opts Options) (*FileSelection, error) {
+	if opts.Logger == nil {
+		opts.Logger = logger.New()
+	}
+
+	root, _, cleanup, err := cloneRepository(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	return SelectFiles(ctx, root, opts)
+}
+
+// ListArchiveFiles validates and extracts the archive like AnalyzeArchive, then resolves
+// the files it would analyze without running any analyzer
+func ListArchiveFiles(ctx context.Context, archivePath string, opts Options) (*FileSelection, error) {
+	if opts.Logger == nil {
+		opts.Logger = logger.New()
+	}
+
+	root, _, cleanup, err := extractArchive(ctx, archivePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	return SelectFiles(ctx, root, opts)
+}
@@ -0,0 +1,158 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+)
+
+// skippedPath is a symbolic link the file walk did not follow, or a directory below its
+// depth limit
+type skippedPath struct {
+	Path    string // slash-separated, relative to the repository root
+	Target  string // resolved target, empty when the link could not be resolved
+	Reason  string
+	TooDeep bool // a directory below the depth limit rather than a link
+}
+
+// repoWalker walks a repository in lexical order like filepath.Walk, without following
+// symbolic links. A link leaving the root could expose files outside the clone, and one
+// staying inside it is a second name for files the walk reaches anyway: following it would
+// analyze them twice, inflating line counts and faking cross-file duplicates. Either kind,
+// and broken links, are reported and skipped. Directories nested deeper than maxDepth are
+// skipped too, so a pathologically deep tree cannot run the walk away.
+type repoWalker struct {
+	root     string
+	realRoot string // root with its own symbolic links resolved
+	maxDepth int    // directories below the root entered at most; the root's children are at depth 1
+	onSkip   func(skippedPath)
+	visit    func(path string, info os.FileInfo) error
+}
+
+// walkRepository calls visit for root and every file and directory below it, down to
+// maxDepth directories (0 uses defaultMaxDepth); visit may return filepath.SkipDir for a
+// directory to leave it out. onSkip, when set, receives every skipped link and directory.
+func walkRepository(root string, maxDepth int, onSkip func(skippedPath), visit func(path string, info os.FileInfo) error) error {
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxDepth
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(realRoot)
+	if err != nil {
+		return err
+	}
+
+	w := &repoWalker{root: root, realRoot: realRoot, maxDepth: maxDepth, onSkip: onSkip, visit: visit}
+	if err := w.walk(root, info, 0); err != nil && err != filepath.SkipDir {
+		return err
+	}
+	return nil
+}
+
+// walk visits path, depth directories below the root, and descends into it when it is a
+// directory
+func (w *repoWalker) walk(path string, info os.FileInfo, depth int) error {
+	if info.IsDir() && depth > w.maxDepth {
+		w.skipDir(path)
+		return nil
+	}
+	if err := w.visit(path, info); err != nil || !info.IsDir() {
+		return err
+	}
+
+	entries, err := os.ReadDir(path) // sorted by name
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		child := filepath.Join(path, entry.Name())
+		if entry.Type()&os.ModeSymlink != 0 {
+			w.skipLink(child)
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if err := w.walk(child, info, depth+1); err != nil && err != filepath.SkipDir {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipLink reports the link at path as skipped, with why: where its target lies
+func (w *repoWalker) skipLink(path string) {
+	target, err := filepath.EvalSymlinks(path)
+	switch {
+	case err != nil:
+		// Also covers links resolving through each other in a loop
+		w.skip(path, "", "broken link")
+	case !withinRoot(w.realRoot, target):
+		w.skip(path, target, "points outside the repository")
+	default:
+		w.skip(path, target, "target already in the repository")
+	}
+}
+
+// skip reports a link left out of the walk
+func (w *repoWalker) skip(path, target, reason string) {
+	if w.onSkip != nil {
+		w.onSkip(skippedPath{Path: w.relPath(path), Target: target, Reason: reason})
+	}
+}
+
+// skipDir reports a directory below the depth limit, left out with everything in it
+func (w *repoWalker) skipDir(path string) {
+	if w.onSkip != nil {
+		w.onSkip(skippedPath{Path: w.relPath(path), Reason: fmt.Sprintf("deeper than %d directories", w.maxDepth), TooDeep: true})
+	}
+}
+
+// relPath returns path relative to the root, slash-separated
+func (w *repoWalker) relPath(path string) string {
+	relPath, err := filepath.Rel(w.root, path)
+	if err != nil {
+		relPath = path
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// withinRoot reports whether the resolved path lies inside the resolved root
+func withinRoot(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// skippedPathLogger logs every link and directory the file walk leaves out as a warning;
+// nil without a logger
+func skippedPathLogger(log *logger.Logger) func(skippedPath) {
+	if log == nil {
+		return nil
+	}
+	return func(skipped skippedPath) {
+		if skipped.TooDeep {
+			log.WithFields(map[string]interface{}{
+				"path":   skipped.Path,
+				"reason": skipped.Reason,
+			}).Warn("Skipping directory")
+			return
+		}
+		log.WithFields(map[string]interface{}{
+			"path":   skipped.Path,
+			"target": skipped.Target,
+			"reason": skipped.Reason,
+		}).Warn("Skipping symbolic link")
+	}
+}
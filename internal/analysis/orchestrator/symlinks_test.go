@@ -0,0 +1,93 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectSourceFiles_Symlinks(t *testing.T) {
+	outside := t.TempDir()
+	writeTestFile(t, outside, "secret.js", "const secret = 'outside the repo';\n")
+
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "function main() { return 1; }\n")
+	writeTestFile(t, root, "lib/util.js", "export const util = 1;\n")
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.js"), filepath.Join(root, "src/secret.js")))
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "external")))
+	require.NoError(t, os.Symlink("../lib", filepath.Join(root, "src/shared")))
+	require.NoError(t, os.Symlink("..", filepath.Join(root, "src/loop")))
+	require.NoError(t, os.Symlink("missing.js", filepath.Join(root, "src/broken.js")))
+
+	var skipped []skippedPath
+	files, _, _, err := collectSourceFiles(root, defaultMaxFileSize, defaultLargeFileSize, defaultMaxDepth, func(link skippedPath) {
+		skipped = append(skipped, link)
+	}, nil)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"lib/util.js", "src/app.js"}, keys(files), "files are analyzed once, under their own path")
+
+	reasons := make(map[string]string)
+	for _, link := range skipped {
+		reasons[link.Path] = link.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"external":      "points outside the repository",
+		"src/secret.js": "points outside the repository",
+		"src/shared":    "target already in the repository",
+		"src/loop":      "target already in the repository",
+		"src/broken.js": "broken link",
+	}, reasons)
+}
+
+func TestCollectSourceFiles_LinkedDirectoryAnalyzedOnce(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "packages/core/index.js", "export function core() { return 1; }\n")
+	// Sorts before its target, so the link is reached first
+	require.NoError(t, os.Symlink("packages/core", filepath.Join(root, "alias")))
+
+	files, _, _, err := collectSourceFiles(root, defaultMaxFileSize, defaultLargeFileSize, defaultMaxDepth, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"packages/core/index.js"}, keys(files))
+}
+
+func TestCountOtherFiles_SkipsEscapingSymlinks(t *testing.T) {
+	outside := t.TempDir()
+	writeTestFile(t, outside, "secret.py", "print(1)\n")
+
+	root := t.TempDir()
+	writeTestFile(t, root, "tools/gen.py", "print(1)\n")
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.py"), filepath.Join(root, "secret.py")))
+
+	lines, _, err := countOtherFiles(root, defaultMaxFileSize, defaultMaxDepth)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"tools/gen.py": 1}, lines)
+}
+
+func TestCollectSourceFiles_MaxDepth(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "app.js", "function main() { return 1; }\n")
+	writeTestFile(t, root, "a/b/shallow.js", "export const shallow = 1;\n")
+	writeTestFile(t, root, "a/b/c/deep.js", "export const deep = 1;\n")
+
+	var skipped []skippedPath
+	files, _, _, err := collectSourceFiles(root, defaultMaxFileSize, defaultLargeFileSize, 2, func(dir skippedPath) {
+		skipped = append(skipped, dir)
+	}, nil)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"app.js", "a/b/shallow.js"}, keys(files))
+	assert.Equal(t, []skippedPath{{Path: "a/b/c", Reason: "deeper than 2 directories", TooDeep: true}}, skipped,
+		"the directory is reported once, not every file below it")
+}
+
+func keys(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+)
+
+// vendorDirNames are directory names third-party code is conventionally copied into
+var vendorDirNames = map[string]bool{
+	"vendor": true, "vendors": true, "vendored": true,
+	"third_party": true, "third-party": true, "thirdparty": true, "3rdparty": true,
+	"external": true, "externals": true,
+}
+
+// licenseHeaderLines is how many leading lines are searched for a license header
+const licenseHeaderLines = 20
+
+// licenseHeaderPattern matches the copyright and license banners third-party sources carry
+var licenseHeaderPattern = regexp.MustCompile(`(?i)(copyright (\(c\)|©|\d{4})|SPDX-License-Identifier|@license\b|licensed under|permission is hereby granted)`)
+
+// relativeImportPattern matches relative import and require specifiers in JavaScript and
+// TypeScript, e.g. from '../lib/util' or require('./parse')
+var relativeImportPattern = regexp.MustCompile(`(?:\bfrom\s*|\bimport\s*\(?\s*|\brequire\s*\(\s*)['"](\.\.?/[^'"]+)['"]`)
+
+// Thresholds of the vendored-code heuristic
+const (
+	maxVendoredOutwardImports = 0.1 // share of relative imports a vendored directory may make into the host code
+	minVendoredLicenseShare   = 0.8 // share of files with a license header for a directory not named like a vendor directory
+	maxHostLicenseShare       = 0.5 // license headers elsewhere above this share are the host's own convention
+	maxVendoredLineShare      = 0.5 // a license-headed directory holding more of the lines than this is the host code itself
+)
+
+// directoryEvidence is what the heuristic measures for one directory, over the files below it
+type directoryEvidence struct {
+	files, lines    int
+	licensed        int // files with a license header
+	relativeImports int
+	outwardImports  int // relative imports resolving outside the directory
+	namedLikeVendor bool
+}
+
+// excludeVendored splits the likely-vendored third-party directories off fileContents. A
+// directory is vendored when its files do not import the host code, judged by the share of
+// their relative imports leading out of the directory, and either its name is a vendor
+// directory name or nearly all its files carry a license header the rest of the repository
+// does not use; a directory holding most of the code is never taken for vendored on its
+// license headers alone. The topmost qualifying directory is reported; its files are returned apart.
+func excludeVendored(fileContents map[string]string) (map[string]string, []metrics.VendoredDirectory) {
+	if len(fileContents) == 0 {
+		return fileContents, nil
+	}
+
+	totalLines, totalLicensed := 0, 0
+	licensed := make(map[string]bool, len(fileContents))
+	lines := make(map[string]int, len(fileContents))
+	for file, content := range fileContents {
+		lines[file] = strings.Count(content, "\n")
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			lines[file]++ // a last line without a trailing newline, as in countLines
+		}
+		licensed[file] = hasLicenseHeader(content)
+		totalLines += lines[file]
+		if licensed[file] {
+			totalLicensed++
+		}
+	}
+
+	// Measure every directory that holds files, directly or below
+	evidence := make(map[string]*directoryEvidence)
+	for file, content := range fileContents {
+		imports := relativeImportPattern.FindAllStringSubmatch(content, -1)
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			e, ok := evidence[dir]
+			if !ok {
+				e = &directoryEvidence{namedLikeVendor: vendorDirNames[strings.ToLower(path.Base(dir))]}
+				evidence[dir] = e
+			}
+			e.files++
+			e.lines += lines[file]
+			if licensed[file] {
+				e.licensed++
+			}
+			for _, match := range imports {
+				e.relativeImports++
+				if target := path.Join(path.Dir(file), match[1]); !strings.HasPrefix(target, dir+"/") {
+					e.outwardImports++
+				}
+			}
+		}
+	}
+
+	dirs := make([]string, 0, len(evidence))
+	for dir := range evidence {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var vendored []metrics.VendoredDirectory
+	for _, dir := range dirs {
+		if len(vendored) > 0 && strings.HasPrefix(dir, vendored[len(vendored)-1].Path+"/") {
+			continue // inside a directory already reported
+		}
+		e := evidence[dir]
+		if reasons := vendoredReasons(e, totalLicensed, len(fileContents), totalLines); reasons != nil {
+			vendored = append(vendored, metrics.VendoredDirectory{Path: dir, Files: e.files, Lines: e.lines, Reasons: reasons})
+		}
+	}
+	if len(vendored) == 0 {
+		return fileContents, nil
+	}
+
+	kept := make(map[string]string, len(fileContents))
+	for file, content := range fileContents {
+		if !inVendoredDirectory(file, vendored) {
+			kept[file] = content
+		}
+	}
+	return kept, vendored
+}
+
+// vendoredDispositions records the files of fileContents below the vendored directories as
+// excluded, naming the directory in the detail
+func vendoredDispositions(fileContents map[string]string, vendored []metrics.VendoredDirectory) []metrics.FileDisposition {
+	var dispositions []metrics.FileDisposition
+	for _, dir := range vendored {
+		for file := range fileContents {
+			if strings.HasPrefix(file, dir.Path+"/") {
+				dispositions = append(dispositions, metrics.FileDisposition{
+					Path:        file,
+					Disposition: metrics.DispositionExcludedByPattern,
+					Detail:      fmt.Sprintf("in vendored directory %s (%s)", dir.Path, strings.Join(dir.Reasons, ", ")),
+				})
+			}
+		}
+	}
+	return dispositions
+}
+
+// vendoredReasons returns why a directory looks vendored, or nil when it does not
+func vendoredReasons(e *directoryEvidence, totalLicensed, totalFiles, totalLines int) []string {
+	outward := 0.0
+	if e.relativeImports > 0 {
+		outward = float64(e.outwardImports) / float64(e.relativeImports)
+	}
+	if outward > maxVendoredOutwardImports {
+		return nil
+	}
+
+	var reasons []string
+	if e.namedLikeVendor {
+		reasons = append(reasons, "vendor directory name")
+	}
+	licenseShare := float64(e.licensed) / float64(e.files)
+	hostLicenseShare := 0.0
+	if others := totalFiles - e.files; others > 0 {
+		hostLicenseShare = float64(totalLicensed-e.licensed) / float64(others)
+	}
+	hostCode := float64(e.lines) > maxVendoredLineShare*float64(totalLines)
+	if licenseShare >= minVendoredLicenseShare && hostLicenseShare <= maxHostLicenseShare && !hostCode {
+		reasons = append(reasons, "license headers unlike the rest of the repository")
+	}
+	if reasons == nil {
+		return nil
+	}
+	return append(reasons, "no imports of the host code")
+}
+
+// inVendoredDirectory reports whether file lies below one of the vendored directories
+func inVendoredDirectory(file string, vendored []metrics.VendoredDirectory) bool {
+	for _, dir := range vendored {
+		if strings.HasPrefix(file, dir.Path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLicenseHeader reports whether one of the first lines carries a copyright or license banner
+func hasLicenseHeader(content string) bool {
+	for i, line := range strings.SplitN(content, "\n", licenseHeaderLines+1) {
+		if i == licenseHeaderLines {
+			break
+		}
+		if licenseHeaderPattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+)
+
+const mitHeader = "/*!\n * left-pad v1.3.0\n * Copyright (c) 2016 Someone Else\n * Licensed under the MIT license\n */\n"
+
+func TestExcludeVendored(t *testing.T) {
+	files := map[string]string{
+		"src/app.js":                     "import { pad } from './vendor/leftpad/index.js';\nexport const app = pad('a');\n",
+		"src/orders/list.js":             "import { app } from '../app.js';\nexport const list = [];\n",
+		"src/vendor/leftpad/index.js":    mitHeader + "import { repeat } from './repeat.js';\nexport function pad(s) { return repeat(s); }\n",
+		"src/vendor/leftpad/repeat.js":   mitHeader + "export function repeat(s) { return s; }\n",
+		"src/shop/vendors/list.js":       "import { app } from '../../app.js';\nexport const vendors = [];\n", // the host's own vendors feature
+		"lib/qs/parse.js":                mitHeader + "module.exports = require('./utils');\n",
+		"lib/qs/utils.js":                mitHeader + "module.exports = {};\n",
+		"lib/own/format.js":              "export const format = 1;\n",
+		"third_party/d3/scale/linear.js": "export function linear() {}\n",
+	}
+
+	kept, vendored := excludeVendored(files)
+	assert.Equal(t, []string{"lib/own/format.js", "src/app.js", "src/orders/list.js", "src/shop/vendors/list.js"}, sortedFileKeys(kept))
+	assert.Equal(t, []metrics.VendoredDirectory{
+		{Path: "lib/qs", Files: 2, Lines: 12, Reasons: []string{"license headers unlike the rest of the repository", "no imports of the host code"}},
+		{Path: "src/vendor", Files: 2, Lines: 13, Reasons: []string{"vendor directory name", "license headers unlike the rest of the repository", "no imports of the host code"}},
+		{Path: "third_party", Files: 1, Lines: 1, Reasons: []string{"vendor directory name", "no imports of the host code"}},
+	}, vendored)
+}
+
+func TestExcludeVendored_HostLicenseHeaders(t *testing.T) {
+	header := "// Copyright 2024 Acme Inc. All rights reserved.\n"
+	files := map[string]string{
+		"src/a.js":      header + "export const a = 1;\n",
+		"src/util/b.js": header + "export const b = 1;\n",
+		"test/a.js":     header + "import { a } from '../src/a.js';\n",
+	}
+
+	kept, vendored := excludeVendored(files)
+	assert.Len(t, kept, 3, "license headers every file carries are the host's own")
+	assert.Empty(t, vendored)
+}
+
+func TestAnalyzeDirectory_VendoredCode(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "src/app.js", "export function add(a, b) {\n  return a + b;\n}\n")
+	writeTestFile(t, root, "vendor/lodash.js", mitHeader+"export function chunk(items) {\n  return [items];\n}\n")
+
+	report, err := AnalyzeDirectory(context.Background(), root, Options{})
+	require.NoError(t, err)
+	require.Len(t, report.VendoredCode, 1)
+	assert.Equal(t, "vendor", report.VendoredCode[0].Path)
+	assert.Equal(t, 1, report.Summary.TotalFiles)
+
+	report, err = AnalyzeDirectory(context.Background(), root, Options{IncludeVendored: true})
+	require.NoError(t, err)
+	assert.Empty(t, report.VendoredCode)
+	assert.Equal(t, 2, report.Summary.TotalFiles)
+}
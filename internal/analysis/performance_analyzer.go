@@ -11,6 +11,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/httpclient"
 )
 
 // MemoryCache provides simple in-memory caching with TTL
@@ -242,11 +244,15 @@ type DeviceProfile struct {
 
 // NewPerformanceAnalyzer creates a new performance analyzer
 func NewPerformanceAnalyzer() *PerformanceAnalyzer {
+	return NewPerformanceAnalyzerWithClient(httpclient.New(httpclient.DefaultConfig()))
+}
+
+// NewPerformanceAnalyzerWithClient creates a performance analyzer that looks up package
+// sizes through client
+func NewPerformanceAnalyzerWithClient(client *http.Client) *PerformanceAnalyzer {
 	return &PerformanceAnalyzer{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		cache: NewMemoryCache(), // 1 hour cache
+		client: client,
+		cache:  NewMemoryCache(), // 1 hour cache
 		bundlerConfig: &BundlerConfig{
 			Type:                "webpack", // default
 			TreeShakingEnabled:  true,
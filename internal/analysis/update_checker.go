@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"regexp"
 	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/httpclient"
 )
 
 // UpdateChecker analyzes package versions and provides update recommendations
@@ -160,9 +162,11 @@ type updateCacheEntry struct {
 
 // NewUpdateChecker creates a new update checker
 func NewUpdateChecker() (*UpdateChecker, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	return NewUpdateCheckerWithClient(httpclient.New(httpclient.DefaultConfig()))
+}
+
+// NewUpdateCheckerWithClient creates an update checker that queries the registry through client
+func NewUpdateCheckerWithClient(client *http.Client) (*UpdateChecker, error) {
 
 	cache := &MemoryUpdateCache{
 		cache: make(map[string]updateCacheEntry),
@@ -239,8 +239,12 @@ func getTopVulnerablePackages(vulnerabilities []Vulnerability, limit int) []stri
 		packages = append(packages, packageCount{name: name, count: count})
 	}
 
+	// Most vulnerabilities first, ties by name so the limit does not depend on map order
 	sort.Slice(packages, func(i, j int) bool {
-		return packages[i].count > packages[j].count
+		if packages[i].count != packages[j].count {
+			return packages[i].count > packages[j].count
+		}
+		return packages[i].name < packages[j].name
 	})
 
 	// Return top packages
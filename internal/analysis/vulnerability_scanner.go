@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/httpclient"
 )
 
 // VulnerabilityDatabase manages vulnerability data from multiple sources
@@ -58,6 +60,14 @@ type OSVSource struct {
 	lastUpdate time.Time
 }
 
+// DefaultOSVURL is the base URL of the public OSV API
+const DefaultOSVURL = "https://api.osv.dev/v1"
+
+// NewOSVSource creates an OSV source querying the API at baseURL through client
+func NewOSVSource(client *http.Client, baseURL string) *OSVSource {
+	return &OSVSource{baseURL: baseURL, client: client}
+}
+
 // VulnerabilityMatch represents a vulnerability matched to a specific package version
 type VulnerabilityMatch struct {
 	Vulnerability  Vulnerability `json:"vulnerability"`
@@ -107,9 +117,12 @@ type vulnCacheEntry struct {
 
 // NewVulnerabilityDatabase creates a new vulnerability database with multiple sources
 func NewVulnerabilityDatabase() (*VulnerabilityDatabase, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	return NewVulnerabilityDatabaseWithClient(httpclient.New(httpclient.DefaultConfig()))
+}
+
+// NewVulnerabilityDatabaseWithClient creates a vulnerability database whose sources
+// query through client, typically one shared with the other integrations
+func NewVulnerabilityDatabaseWithClient(client *http.Client) (*VulnerabilityDatabase, error) {
 
 	// Initialize vulnerability sources
 	sources := []VulnerabilitySource{
@@ -121,10 +134,7 @@ func NewVulnerabilityDatabase() (*VulnerabilityDatabase, error) {
 			baseURL: "https://api.github.com/graphql",
 			client:  client,
 		},
-		&OSVSource{
-			baseURL: "https://api.osv.dev/v1",
-			client:  client,
-		},
+		NewOSVSource(client, DefaultOSVURL),
 	}
 
 	cache := &MemoryVulnerabilityCache{
@@ -593,3 +593,16 @@ func TestVulnerabilityDatabaseIntegration(t *testing.T) {
 		assert.NotEmpty(t, alert.RemediationSteps)
 	}
 }
+
+func TestGetTopVulnerablePackages_TiesByName(t *testing.T) {
+	var vulnerabilities []Vulnerability
+	for _, pkg := range []string{"zod", "axios", "lodash", "lodash", "minimist", "express"} {
+		vulnerabilities = append(vulnerabilities, Vulnerability{Metadata: map[string]string{"package_name": pkg}})
+	}
+
+	// Map iteration order varies per run, so repeat to catch an order that leaks through
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []string{"lodash (2 vulnerabilities)", "axios (1 vulnerabilities)", "express (1 vulnerabilities)"},
+			getTopVulnerablePackages(vulnerabilities, 3))
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 )
@@ -359,6 +361,101 @@ func (os *OSVSource) QueryVulnerabilities(ctx context.Context, packageName, vers
 	return vulnerabilities, nil
 }
 
+// QueryBatch looks up many npm packages in one request and returns the IDs of the
+// vulnerabilities affecting each, keyed by package name; versions maps names to exact versions.
+// Use GetVulnerability for the details of an ID.
+func (os *OSVSource) QueryBatch(ctx context.Context, versions map[string]string) (map[string][]string, error) {
+	type query struct {
+		Package struct {
+			Name      string `json:"name"`
+			Ecosystem string `json:"ecosystem"`
+		} `json:"package"`
+		Version string `json:"version"`
+	}
+
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	batch := struct {
+		Queries []query `json:"queries"`
+	}{Queries: make([]query, len(names))}
+	for i, name := range names {
+		batch.Queries[i].Package.Name = name
+		batch.Queries[i].Package.Ecosystem = "npm"
+		batch.Queries[i].Version = versions[name]
+	}
+
+	var response struct {
+		Results []struct {
+			Vulns []struct {
+				ID string `json:"id"`
+			} `json:"vulns"`
+		} `json:"results"`
+	}
+	if err := os.do(ctx, "POST", "/querybatch", batch, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Results) != len(names) {
+		return nil, fmt.Errorf("OSV returned %d results for %d queries", len(response.Results), len(names))
+	}
+
+	ids := make(map[string][]string)
+	for i, result := range response.Results {
+		for _, vuln := range result.Vulns {
+			ids[names[i]] = append(ids[names[i]], vuln.ID)
+		}
+	}
+	os.lastUpdate = time.Now()
+	return ids, nil
+}
+
+// GetVulnerability fetches one vulnerability by its OSV ID
+func (os *OSVSource) GetVulnerability(ctx context.Context, id string) (Vulnerability, error) {
+	var osvVuln OSVVulnerability
+	if err := os.do(ctx, "GET", "/vulns/"+url.PathEscape(id), nil, &osvVuln); err != nil {
+		return Vulnerability{}, err
+	}
+	return convertOSVVulnerabilityToVulnerability(osvVuln), nil
+}
+
+// do sends a request with an optional JSON body to the OSV API and decodes the JSON response
+func (os *OSVSource) do(ctx context.Context, method, endpoint string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal OSV request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, os.baseURL+endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create OSV request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", "repo-onboarding-copilot/1.0")
+
+	resp, err := os.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute OSV request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("OSV API returned status: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse OSV response: %w", err)
+	}
+	return nil
+}
+
 func (os *OSVSource) UpdateCache(ctx context.Context) error {
 	// OSV data is queried per-package, no explicit cache update needed
 	return nil
@@ -0,0 +1,298 @@
+// Package api exposes the analysis pipeline over HTTP so that internal tools
+// can trigger on-demand repository analysis without shelling out to the CLI.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/orchestrator"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+// AuthHeader is the request header carrying the shared authentication token
+const AuthHeader = "X-Copilot-Token"
+
+// AnalyzeFunc runs a single analysis; it matches orchestrator.Analyze
+type AnalyzeFunc func(ctx context.Context, opts orchestrator.Options) (*metrics.QualityReport, error)
+
+// ServerConfig configures the analysis HTTP server
+type ServerConfig struct {
+	Addr           string        `yaml:"addr" json:"addr"`
+	AuthToken      string        `yaml:"-" json:"-"`
+	MaxConcurrent  int           `yaml:"max_concurrent" json:"max_concurrent"`
+	RequestTimeout time.Duration `yaml:"request_timeout" json:"request_timeout"`
+	ShutdownGrace  time.Duration `yaml:"shutdown_grace" json:"shutdown_grace"`
+	MaxRepoSize    int64         `yaml:"max_repo_size" json:"max_repo_size"` // bytes per clone
+
+	// ToolVersion and BuildDate are recorded in the run metadata of every report
+	ToolVersion string `yaml:"-" json:"-"`
+	BuildDate   string `yaml:"-" json:"-"`
+}
+
+// DefaultServerMaxRepoSize is stricter than the CLI default because the server clones untrusted URLs
+const DefaultServerMaxRepoSize = 2 * 1024 * 1024 * 1024 // 2GB
+
+// Server serves on-demand analysis requests
+type Server struct {
+	config  ServerConfig
+	analyze AnalyzeFunc
+	slots   chan struct{}
+	logger  *logger.Logger
+}
+
+// AnalyzeRequest is the body accepted by POST /analyze
+type AnalyzeRequest struct {
+	RepoURL     string `json:"repo_url"`
+	Format      string `json:"format"`
+	MaxFindings int    `json:"max_findings,omitempty"` // cap per finding array; 0 = unlimited
+}
+
+// ErrorResponse is returned for every failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewServer creates a server backed by orchestrator.Analyze
+func NewServer(config ServerConfig, log *logger.Logger) (*Server, error) {
+	return NewServerWithAnalyzer(config, log, orchestrator.Analyze)
+}
+
+// NewServerWithAnalyzer creates a server backed by a custom analysis function
+func NewServerWithAnalyzer(config ServerConfig, log *logger.Logger, analyze AnalyzeFunc) (*Server, error) {
+	if config.AuthToken == "" {
+		return nil, fmt.Errorf("auth token cannot be empty")
+	}
+	if analyze == nil {
+		return nil, fmt.Errorf("analyze function cannot be nil")
+	}
+	if log == nil {
+		log = logger.New()
+	}
+
+	if config.Addr == "" {
+		config.Addr = ":8080"
+	}
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = 2
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = 10 * time.Minute
+	}
+	if config.ShutdownGrace <= 0 {
+		config.ShutdownGrace = 30 * time.Second
+	}
+	if config.MaxRepoSize <= 0 {
+		config.MaxRepoSize = DefaultServerMaxRepoSize
+	}
+
+	return &Server{
+		config:  config,
+		analyze: analyze,
+		slots:   make(chan struct{}, config.MaxConcurrent),
+		logger:  log,
+	}, nil
+}
+
+// Handler returns the HTTP routes served by the server
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.Handle("/analyze", s.requireToken(http.HandlerFunc(s.handleAnalyze)))
+	return mux
+}
+
+// ListenAndServe serves until ctx is cancelled, then shuts down gracefully
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:              s.config.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		s.logger.WithFields(map[string]interface{}{
+			"addr":           s.config.Addr,
+			"max_concurrent": s.config.MaxConcurrent,
+			"timeout":        s.config.RequestTimeout.String(),
+		}).Info("Analysis server listening")
+		errChan <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownGrace)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
+
+// handleHealth reports liveness; it does not require authentication
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleAnalyze runs an analysis for the requested repository
+func (s *Server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req AnalyzeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64*1024)).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.RepoURL == "" {
+		s.writeError(w, http.StatusBadRequest, "repo_url is required")
+		return
+	}
+	if req.Format != "" && metrics.ReportFormat(req.Format) != metrics.FormatJSON {
+		s.writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported format: %s", req.Format))
+		return
+	}
+
+	if req.MaxFindings < 0 {
+		s.writeError(w, http.StatusBadRequest, "max_findings must not be negative")
+		return
+	}
+
+	// Bound concurrent analyses; reject rather than queue so callers can retry elsewhere
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	default:
+		w.Header().Set("Retry-After", "30")
+		s.writeError(w, http.StatusServiceUnavailable, "analysis capacity exhausted, retry later")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.RequestTimeout)
+	defer cancel()
+
+	startTime := time.Now()
+	report, err := s.analyze(ctx, orchestrator.Options{
+		RepoURL:     req.RepoURL,
+		Logger:      s.logger,
+		MaxRepoSize: s.config.MaxRepoSize,
+		ToolVersion: s.config.ToolVersion,
+		BuildDate:   s.config.BuildDate,
+		ReportConfig: metrics.QualityReportConfig{
+			ReportFormat:            metrics.FormatJSON,
+			IncludeExecutiveSummary: true,
+			MaxFindingsPerCategory:  req.MaxFindings,
+		},
+	})
+	// An empty repository still yields a stub report; answer it like any other result
+	if errors.Is(err, metrics.ErrNoAnalyzableFiles) && report != nil {
+		err = nil
+	}
+	if err != nil {
+		status := statusForError(err)
+
+		s.logger.WithFields(map[string]interface{}{
+			"repo_url": req.RepoURL,
+			"duration": time.Since(startTime).Seconds(),
+			"error":    err.Error(),
+		}).Error("Analysis request failed")
+		s.writeError(w, status, messageForError(err))
+		return
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"repo_url": req.RepoURL,
+		"duration": time.Since(startTime).Seconds(),
+	}).Info("Analysis request completed")
+	s.writeJSON(w, http.StatusOK, report)
+}
+
+// statusForError maps a pipeline error to the HTTP status returned to the caller
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, types.ErrAnalysisTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, types.ErrAnalysisCanceled):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, types.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, types.ErrRepositoryTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, types.ErrCloneFailed), errors.Is(err, types.ErrExtractFailed):
+		return http.StatusBadGateway
+	case errors.Is(err, types.ErrNoAnalyzableFiles), errors.Is(err, types.ErrParseFailed):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorKinds are the failure kinds a caller is told about, most specific first
+var errorKinds = []error{
+	types.ErrAnalysisTimeout,
+	types.ErrAnalysisCanceled,
+	types.ErrInvalidInput,
+	types.ErrRepositoryTooLarge,
+	types.ErrCloneFailed,
+	types.ErrExtractFailed,
+	types.ErrNoAnalyzableFiles,
+	types.ErrParseFailed,
+}
+
+// messageForError is the error text returned to the caller: the kind of failure only, since
+// the wrapped detail can name sandbox paths and other server internals. The detail is logged.
+func messageForError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return types.ErrAnalysisTimeout.Error()
+	}
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind) {
+			return kind.Error()
+		}
+	}
+	return types.ErrAnalysisFailed.Error()
+}
+
+// requireToken rejects requests that do not carry the shared token
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(AuthHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.config.AuthToken)) != 1 {
+			s.writeError(w, http.StatusUnauthorized, "missing or invalid auth token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON encodes a response body as JSON
+func (s *Server) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error(fmt.Sprintf("failed to encode response: %v", err))
+	}
+}
+
+// writeError writes a JSON error response
+func (s *Server) writeError(w http.ResponseWriter, status int, message string) {
+	s.writeJSON(w, status, ErrorResponse{Error: message})
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/metrics"
+	"github.com/yenhunghuang/repo-onboarding-copilot/internal/analysis/orchestrator"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+const testToken = "secret-token"
+
+func newTestServer(t *testing.T, config ServerConfig, analyze AnalyzeFunc) *Server {
+	t.Helper()
+	config.AuthToken = testToken
+	server, err := NewServerWithAnalyzer(config, nil, analyze)
+	require.NoError(t, err)
+	return server
+}
+
+func postAnalyze(handler http.Handler, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(body))
+	if token != "" {
+		req.Header.Set(AuthHeader, token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewServer_RequiresToken(t *testing.T) {
+	_, err := NewServer(ServerConfig{}, nil)
+	assert.Error(t, err)
+}
+
+func TestServer_Healthz(t *testing.T) {
+	server := newTestServer(t, ServerConfig{}, func(ctx context.Context, opts orchestrator.Options) (*metrics.QualityReport, error) {
+		return nil, nil
+	})
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"ok"`)
+}
+
+func TestServer_Analyze(t *testing.T) {
+	var received orchestrator.Options
+	server := newTestServer(t, ServerConfig{ToolVersion: "1.2.3", BuildDate: "2026-01-02"}, func(ctx context.Context, opts orchestrator.Options) (*metrics.QualityReport, error) {
+		received = opts
+		return &metrics.QualityReport{ProjectName: opts.RepoURL, OverallScore: 82.5}, nil
+	})
+
+	rec := postAnalyze(server.Handler(), `{"repo_url":"https://github.com/owner/repo.git","format":"json","max_findings":50}`, testToken)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "https://github.com/owner/repo.git", received.RepoURL)
+	assert.Equal(t, 50, received.ReportConfig.MaxFindingsPerCategory)
+	assert.Equal(t, "1.2.3", received.ToolVersion, "the server build is recorded in the run metadata")
+	assert.Equal(t, "2026-01-02", received.BuildDate)
+
+	var report metrics.QualityReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, 82.5, report.OverallScore)
+}
+
+func TestServer_AnalyzeRejectsBadRequests(t *testing.T) {
+	server := newTestServer(t, ServerConfig{}, func(ctx context.Context, opts orchestrator.Options) (*metrics.QualityReport, error) {
+		t.Fatal("analysis should not run")
+		return nil, nil
+	})
+
+	tests := []struct {
+		name   string
+		body   string
+		token  string
+		status int
+	}{
+		{name: "missing token", body: `{"repo_url":"https://github.com/o/r"}`, status: http.StatusUnauthorized},
+		{name: "wrong token", body: `{"repo_url":"https://github.com/o/r"}`, token: "nope", status: http.StatusUnauthorized},
+		{name: "malformed body", body: `{`, token: testToken, status: http.StatusBadRequest},
+		{name: "missing repo url", body: `{}`, token: testToken, status: http.StatusBadRequest},
+		{name: "unsupported format", body: `{"repo_url":"https://github.com/o/r","format":"pdf"}`, token: testToken, status: http.StatusBadRequest},
+		{name: "negative max findings", body: `{"repo_url":"https://github.com/o/r","max_findings":-1}`, token: testToken, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postAnalyze(server.Handler(), tt.body, tt.token)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestServer_AnalyzeBoundsConcurrency(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := newTestServer(t, ServerConfig{MaxConcurrent: 1}, func(ctx context.Context, opts orchestrator.Options) (*metrics.QualityReport, error) {
+		close(started)
+		<-release
+		return &metrics.QualityReport{}, nil
+	})
+	handler := server.Handler()
+
+	done := make(chan int)
+	go func() {
+		done <- postAnalyze(handler, `{"repo_url":"https://github.com/o/r"}`, testToken).Code
+	}()
+	<-started
+
+	rec := postAnalyze(handler, `{"repo_url":"https://github.com/o/r"}`, testToken)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+}
+
+func TestServer_AnalyzeTimeout(t *testing.T) {
+	server := newTestServer(t, ServerConfig{RequestTimeout: 10 * time.Millisecond}, func(ctx context.Context, opts orchestrator.Options) (*metrics.QualityReport, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	rec := postAnalyze(server.Handler(), `{"repo_url":"https://github.com/o/r"}`, testToken)
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+}
+
+func TestStatusForError(t *testing.T) {
+	tests := []struct {
+		kind   error
+		status int
+	}{
+		{types.ErrInvalidInput, http.StatusBadRequest},
+		{types.ErrCloneFailed, http.StatusBadGateway},
+		{types.ErrExtractFailed, http.StatusBadGateway},
+		{types.ErrRepositoryTooLarge, http.StatusRequestEntityTooLarge},
+		{types.ErrParseFailed, http.StatusUnprocessableEntity},
+		{types.ErrAnalysisTimeout, http.StatusGatewayTimeout},
+		{types.ErrAnalysisFailed, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.Error(), func(t *testing.T) {
+			err := &types.AnalysisError{Stage: types.StageAnalyze, Kind: tt.kind, Err: errors.New("boom")}
+			assert.Equal(t, tt.status, statusForError(err))
+		})
+	}
+}
+
+func TestServer_AnalyzeHidesErrorDetail(t *testing.T) {
+	server := newTestServer(t, ServerConfig{}, func(ctx context.Context, opts orchestrator.Options) (*metrics.QualityReport, error) {
+		return nil, &types.AnalysisError{Stage: types.StageClone, Kind: types.ErrCloneFailed, Err: errors.New("git clone into /tmp/sandbox-123/repo: exit status 128")}
+	})
+
+	rec := postAnalyze(server.Handler(), `{"repo_url":"https://github.com/o/r"}`, testToken)
+
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, types.ErrCloneFailed.Error(), response.Error)
+}
+
+func TestMessageForError(t *testing.T) {
+	assert.Equal(t, "analysis timed out", messageForError(context.DeadlineExceeded))
+	assert.Equal(t, "analysis failed", messageForError(errors.New("open /var/lib/copilot/cache: permission denied")))
+}
+
+func TestServer_AnalyzeEmptyRepository(t *testing.T) {
+	server := newTestServer(t, ServerConfig{}, func(ctx context.Context, opts orchestrator.Options) (*metrics.QualityReport, error) {
+		return &metrics.QualityReport{QualityGate: metrics.QualityGateNotApplicable, Message: "no source"}, metrics.ErrNoAnalyzableFiles
+	})
+
+	rec := postAnalyze(server.Handler(), `{"repo_url":"https://github.com/owner/empty.git"}`, testToken)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var report metrics.QualityReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, metrics.QualityGateNotApplicable, report.QualityGate)
+}
@@ -0,0 +1,268 @@
+// Package httpclient provides the HTTP client shared by external integrations such as
+// vulnerability databases and package registries. Responses are cached for a TTL, up to a
+// bounded number of entries, and requests are rate limited with a token bucket so batch
+// runs do not get throttled.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrOffline is returned for requests that are not cached while offline mode is enabled;
+// integrations treat it like any other lookup failure and skip the data
+var ErrOffline = errors.New("offline mode: response not cached")
+
+// Config controls caching, rate limiting and offline behavior
+type Config struct {
+	Timeout           time.Duration `yaml:"timeout" json:"timeout"`                         // per request
+	CacheTTL          time.Duration `yaml:"cache_ttl" json:"cache_ttl"`                     // 0 disables caching
+	RequestsPerSecond float64       `yaml:"requests_per_second" json:"requests_per_second"` // 0 disables rate limiting
+	Burst             int           `yaml:"burst" json:"burst"`                             // requests allowed at once before limiting starts
+	Offline           bool          `yaml:"offline" json:"offline"`                         // serve from cache only, never touch the network
+	MaxCacheEntries   int           `yaml:"max_cache_entries" json:"max_cache_entries"`     // 0 uses DefaultMaxCacheEntries
+}
+
+// DefaultMaxCacheEntries bounds the cache when Config.MaxCacheEntries is unset
+const DefaultMaxCacheEntries = 1000
+
+// DefaultConfig returns the settings used when an integration is created without a client
+func DefaultConfig() Config {
+	return Config{
+		Timeout:           30 * time.Second,
+		CacheTTL:          time.Hour,
+		RequestsPerSecond: 5,
+		Burst:             10,
+		MaxCacheEntries:   DefaultMaxCacheEntries,
+	}
+}
+
+// New returns an http.Client whose transport caches and rate limits according to config
+func New(config Config) *http.Client {
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: NewTransport(http.DefaultTransport, config),
+	}
+}
+
+// Transport is an http.RoundTripper that serves repeated requests from a cache and
+// throttles the ones that reach base
+type Transport struct {
+	base    http.RoundTripper
+	config  Config
+	limiter *tokenBucket
+	now     func() time.Time // clock for cache expiry; replaceable in tests
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// NewTransport wraps base, or http.DefaultTransport when nil
+func NewTransport(base http.RoundTripper, config Config) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &Transport{
+		base:   base,
+		config: config,
+		now:    time.Now,
+		cache:  make(map[string]cachedResponse),
+	}
+	if t.config.MaxCacheEntries <= 0 {
+		t.config.MaxCacheEntries = DefaultMaxCacheEntries
+	}
+	if config.RequestsPerSecond > 0 {
+		t.limiter = newTokenBucket(config.RequestsPerSecond, config.Burst)
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, err := cacheKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := t.lookup(key); ok {
+		return cached.response(req), nil
+	}
+	if t.config.Offline {
+		return nil, fmt.Errorf("%w: %s %s", ErrOffline, req.Method, req.URL)
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || t.config.CacheTTL <= 0 || !cacheable(resp.StatusCode) {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	cached := cachedResponse{
+		status:  resp.StatusCode,
+		header:  resp.Header.Clone(),
+		body:    body,
+		expires: t.now().Add(t.config.CacheTTL),
+	}
+	t.store(key, cached)
+
+	return cached.response(req), nil
+}
+
+// lookup returns the cached response for key. Offline mode also serves expired entries,
+// since stale data beats none when the network is off limits.
+func (t *Transport) lookup(key string) (cachedResponse, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cached, ok := t.cache[key]
+	if !ok {
+		return cachedResponse{}, false
+	}
+	if !t.config.Offline && !t.now().Before(cached.expires) {
+		delete(t.cache, key)
+		return cachedResponse{}, false
+	}
+	return cached, true
+}
+
+// store caches an entry under key. A full cache first drops its expired entries and, when
+// that frees nothing, the entry closest to expiry, so the cache never exceeds MaxCacheEntries.
+func (t *Transport) store(key string, cached cachedResponse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.cache[key]; !ok && len(t.cache) >= t.config.MaxCacheEntries {
+		now := t.now()
+		var oldest string
+		for k, entry := range t.cache {
+			if !now.Before(entry.expires) {
+				delete(t.cache, k)
+			} else if oldest == "" || entry.expires.Before(t.cache[oldest].expires) {
+				oldest = k
+			}
+		}
+		if len(t.cache) >= t.config.MaxCacheEntries {
+			delete(t.cache, oldest)
+		}
+	}
+	t.cache[key] = cached
+}
+
+// response builds a fresh http.Response for req from the cached entry
+func (c cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.status, http.StatusText(c.status)),
+		StatusCode:    c.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
+
+// cacheable reports whether a response with status is safe to replay; throttling and
+// server errors are always retried
+func cacheable(status int) bool {
+	return status >= 200 && status < 300 || status == http.StatusNotFound
+}
+
+// cacheKey identifies a request by method and URL, plus a hash of the body for queries
+// sent as POST (npm audit, OSV). The body is restored so the request can still be sent.
+func cacheKey(req *http.Request) (string, error) {
+	key := req.Method + " " + req.URL.String()
+	if req.Body == nil || req.Body == http.NoBody {
+		return key, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	sum := sha256.Sum256(body)
+	return key + " " + hex.EncodeToString(sum[:]), nil
+}
+
+// tokenBucket allows burst requests at once and refills at rate tokens per second
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait blocks until a token is available or ctx is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise returns how long until one is
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
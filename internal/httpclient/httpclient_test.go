@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingServer answers every request with its body echoed back and counts the hits
+func countingServer(t *testing.T, status int) (*httptest.Server, *int32) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		w.Write([]byte(r.Method + ":" + string(body)))
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestTransport_CachesByURLAndBody(t *testing.T) {
+	server, hits := countingServer(t, http.StatusOK)
+	client := New(Config{CacheTTL: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		status, body := get(t, client, server.URL+"/pkg")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "GET:", body)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+
+	post := func(payload string) string {
+		resp, err := client.Post(server.URL+"/query", "application/json", strings.NewReader(payload))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	assert.Equal(t, "POST:a", post("a"))
+	assert.Equal(t, "POST:a", post("a"))
+	assert.Equal(t, "POST:b", post("b"), "a different body is a different query")
+	assert.Equal(t, int32(3), atomic.LoadInt32(hits))
+}
+
+func TestTransport_ExpiresAndSkipsUncacheable(t *testing.T) {
+	server, hits := countingServer(t, http.StatusOK)
+	transport := NewTransport(nil, Config{CacheTTL: time.Minute})
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	client := &http.Client{Transport: transport}
+
+	get(t, client, server.URL)
+	now = now.Add(2 * time.Minute)
+	get(t, client, server.URL)
+	assert.Equal(t, int32(2), atomic.LoadInt32(hits), "expired entries are fetched again")
+
+	throttled, throttledHits := countingServer(t, http.StatusTooManyRequests)
+	get(t, client, throttled.URL)
+	get(t, client, throttled.URL)
+	assert.Equal(t, int32(2), atomic.LoadInt32(throttledHits), "429 responses are never cached")
+}
+
+func TestTransport_BoundsCacheEntries(t *testing.T) {
+	server, hits := countingServer(t, http.StatusOK)
+	transport := NewTransport(nil, Config{CacheTTL: time.Minute, MaxCacheEntries: 2})
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	client := &http.Client{Transport: transport}
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		get(t, client, server.URL+path)
+		now = now.Add(time.Second)
+	}
+	assert.Len(t, transport.cache, 2)
+
+	get(t, client, server.URL+"/c")
+	assert.Equal(t, int32(3), atomic.LoadInt32(hits), "the newest entries stay cached")
+	get(t, client, server.URL+"/a")
+	assert.Equal(t, int32(4), atomic.LoadInt32(hits), "the entry closest to expiry was evicted")
+	assert.Len(t, transport.cache, 2)
+}
+
+func TestTransport_Offline(t *testing.T) {
+	server, hits := countingServer(t, http.StatusOK)
+	transport := NewTransport(nil, Config{CacheTTL: time.Minute})
+	client := &http.Client{Transport: transport}
+	get(t, client, server.URL+"/cached")
+
+	transport.config.Offline = true
+	transport.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+	_, body := get(t, client, server.URL+"/cached")
+	assert.Equal(t, "GET:", body, "stale entries are served while offline")
+
+	_, err := client.Get(server.URL + "/other")
+	require.ErrorIs(t, err, ErrOffline)
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(10, 2)
+
+	assert.Zero(t, bucket.reserve())
+	assert.Zero(t, bucket.reserve())
+	delay := bucket.reserve()
+	assert.Greater(t, delay, time.Duration(0), "burst exhausted")
+	assert.LessOrEqual(t, delay, 100*time.Millisecond)
+
+	slow := newTokenBucket(0.001, 1)
+	require.Zero(t, slow.reserve())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, slow.wait(ctx), context.Canceled)
+}
@@ -0,0 +1,635 @@
+// Package query evaluates a small jq-like expression language over decoded JSON, so
+// reports can be scripted against in environments without jq.
+//
+// Supported syntax:
+//
+//	.                          the input
+//	.field  .["field"]         object field (null on null input)
+//	.[0]  .[-1]                array element
+//	.[]                        every array element or object value
+//	a | b                      feed every output of a into b
+//	select(cond)               keep the input when cond is true
+//	a == b, !=, <, <=, >, >=   comparisons
+//	a and b, a or b, not       boolean logic
+//	length, keys               array/object/string helpers
+//	"str", 12.5, true, null    literals
+package query
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// filter maps one input value to a stream of outputs
+type filter func(input interface{}) ([]interface{}, error)
+
+// Query is a compiled expression
+type Query struct {
+	source string
+	root   filter
+}
+
+// Compile parses expression
+func Compile(expression string) (*Query, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.peek().text, p.peek().offset)
+	}
+	return &Query{source: expression, root: root}, nil
+}
+
+// Run evaluates the query against input, a value decoded by encoding/json into
+// interface{}, and returns every output
+func (q *Query) Run(input interface{}) ([]interface{}, error) {
+	results, err := q.root(input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", q.source, err)
+	}
+	return results, nil
+}
+
+// Evaluate compiles and runs expression against input
+func Evaluate(expression string, input interface{}) ([]interface{}, error) {
+	q, err := Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", expression, err)
+	}
+	return q.Run(input)
+}
+
+// Tokens
+
+type tokenKind int
+
+const (
+	tokenPunct tokenKind = iota // . [ ] ( ) | and comparison operators
+	tokenIdent
+	tokenString
+	tokenNumber
+)
+
+type token struct {
+	kind   tokenKind
+	text   string // punctuation, identifier or unquoted string
+	number float64
+	offset int
+}
+
+// tokenize splits expression into tokens
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case strings.ContainsRune(".[]()|", rune(c)):
+			tokens = append(tokens, token{kind: tokenPunct, text: string(c), offset: i})
+			i++
+
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			op := string(c)
+			if i+1 < len(expression) && expression[i+1] == '=' {
+				op += "="
+			}
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("unexpected %q at offset %d (use == or !=)", op, i)
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: op, offset: i})
+			i += len(op)
+
+		case c == '"':
+			end := i + 1
+			for end < len(expression) && expression[end] != '"' {
+				if expression[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expression) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			text, err := strconv.Unquote(expression[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at offset %d: %w", i, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, offset: i})
+			i = end + 1
+
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(expression) && (expression[end] == '.' || (expression[end] >= '0' && expression[end] <= '9')) {
+				end++
+			}
+			number, err := strconv.ParseFloat(expression[i:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at offset %d", expression[i:end], i)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, number: number, text: expression[i:end], offset: i})
+			i = end
+
+		case c == '_' || unicode.IsLetter(rune(c)):
+			end := i + 1
+			for end < len(expression) && (expression[end] == '_' || unicode.IsLetter(rune(expression[end])) || unicode.IsDigit(rune(expression[end]))) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: expression[i:end], offset: i})
+			i = end
+
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", string(c), i)
+		}
+	}
+	return tokens, nil
+}
+
+// Parser
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokenPunct, text: "end of expression", offset: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+// accept consumes the next token when it is the punctuation or keyword text
+func (p *parser) accept(text string) bool {
+	if !p.done() && (p.peek().kind == tokenPunct || p.peek().kind == tokenIdent) && p.peek().text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return fmt.Errorf("expected %q, got %q", text, p.peek().text)
+	}
+	return nil
+}
+
+// parsePipe: or ('|' or)*
+func (p *parser) parsePipe() (filter, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("|") {
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		left = pipe(left, right)
+	}
+	return left, nil
+}
+
+// parseOr: and ('or' and)*
+func (p *parser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binary(left, right, func(a, b interface{}) (interface{}, error) { return truthy(a) || truthy(b), nil })
+	}
+	return left, nil
+}
+
+// parseAnd: comparison ('and' comparison)*
+func (p *parser) parseAnd() (filter, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = binary(left, right, func(a, b interface{}) (interface{}, error) { return truthy(a) && truthy(b), nil })
+	}
+	return left, nil
+}
+
+// parseComparison: postfix (op postfix)?
+func (p *parser) parseComparison() (filter, error) {
+	left, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.accept(op) {
+			right, err := p.parsePostfix()
+			if err != nil {
+				return nil, err
+			}
+			return binary(left, right, func(a, b interface{}) (interface{}, error) { return compare(op, a, b) }), nil
+		}
+	}
+	return left, nil
+}
+
+// parsePostfix: primary ('.' field | '[' ... ']')*
+func (p *parser) parsePostfix() (filter, error) {
+	current, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() {
+		switch {
+		case p.peek().text == "." && p.peek().kind == tokenPunct:
+			p.pos++
+			next, err := p.parsePathAfterDot()
+			if err != nil {
+				return nil, err
+			}
+			current = pipe(current, next)
+		case p.peek().text == "[" && p.peek().kind == tokenPunct:
+			next, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			current = pipe(current, next)
+		default:
+			return current, nil
+		}
+	}
+	return current, nil
+}
+
+// parsePrimary parses a path, literal, function call or parenthesized expression
+func (p *parser) parsePrimary() (filter, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	tok := p.peek()
+
+	switch tok.kind {
+	case tokenString:
+		p.pos++
+		return literal(tok.text), nil
+	case tokenNumber:
+		p.pos++
+		return literal(tok.number), nil
+	case tokenIdent:
+		p.pos++
+		switch tok.text {
+		case "true":
+			return literal(true), nil
+		case "false":
+			return literal(false), nil
+		case "null":
+			return literal(nil), nil
+		case "not":
+			return func(input interface{}) ([]interface{}, error) { return []interface{}{!truthy(input)}, nil }, nil
+		case "length":
+			return unary(length), nil
+		case "keys":
+			return unary(keys), nil
+		case "select":
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			cond, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return selectFilter(cond), nil
+		}
+		return nil, fmt.Errorf("unknown function %q at offset %d", tok.text, tok.offset)
+	}
+
+	switch tok.text {
+	case "(":
+		p.pos++
+		inner, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case ".":
+		p.pos++
+		// A bare "." is the identity; ".field" and ".[...]" continue the path
+		if !p.done() && (p.peek().kind == tokenIdent || p.peek().kind == tokenString) {
+			return p.parsePathAfterDot()
+		}
+		if !p.done() && p.peek().text == "[" && p.peek().kind == tokenPunct {
+			return p.parseBracket()
+		}
+		return identity, nil
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.offset)
+}
+
+// parsePathAfterDot parses the field name following a "."
+func (p *parser) parsePathAfterDot() (filter, error) {
+	tok := p.peek()
+	if tok.kind == tokenIdent || tok.kind == tokenString {
+		p.pos++
+		return field(tok.text), nil
+	}
+	if tok.text == "[" && tok.kind == tokenPunct {
+		return p.parseBracket()
+	}
+	return nil, fmt.Errorf("expected a field name after \".\", got %q", tok.text)
+}
+
+// parseBracket parses "[]", "[n]" or "[\"key\"]"
+func (p *parser) parseBracket() (filter, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	if p.accept("]") {
+		return iterate, nil
+	}
+
+	tok := p.peek()
+	var result filter
+	switch tok.kind {
+	case tokenNumber:
+		if tok.number != math.Trunc(tok.number) {
+			return nil, fmt.Errorf("array index %s is not an integer", tok.text)
+		}
+		result = index(int(tok.number))
+	case tokenString:
+		result = field(tok.text)
+	default:
+		return nil, fmt.Errorf("expected an index or key inside [], got %q", tok.text)
+	}
+	p.pos++
+
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Filters
+
+func identity(input interface{}) ([]interface{}, error) { return []interface{}{input}, nil }
+
+func literal(value interface{}) filter {
+	return func(interface{}) ([]interface{}, error) { return []interface{}{value}, nil }
+}
+
+func pipe(left, right filter) filter {
+	return func(input interface{}) ([]interface{}, error) {
+		lefts, err := left(input)
+		if err != nil {
+			return nil, err
+		}
+		var results []interface{}
+		for _, value := range lefts {
+			rights, err := right(value)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, rights...)
+		}
+		return results, nil
+	}
+}
+
+// binary combines every output of left with every output of right
+func binary(left, right filter, op func(a, b interface{}) (interface{}, error)) filter {
+	return func(input interface{}) ([]interface{}, error) {
+		lefts, err := left(input)
+		if err != nil {
+			return nil, err
+		}
+		rights, err := right(input)
+		if err != nil {
+			return nil, err
+		}
+		var results []interface{}
+		for _, a := range lefts {
+			for _, b := range rights {
+				value, err := op(a, b)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, value)
+			}
+		}
+		return results, nil
+	}
+}
+
+func unary(fn func(interface{}) (interface{}, error)) filter {
+	return func(input interface{}) ([]interface{}, error) {
+		value, err := fn(input)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{value}, nil
+	}
+}
+
+func selectFilter(cond filter) filter {
+	return func(input interface{}) ([]interface{}, error) {
+		values, err := cond(input)
+		if err != nil {
+			return nil, err
+		}
+		var results []interface{}
+		for _, value := range values {
+			if truthy(value) {
+				results = append(results, input)
+			}
+		}
+		return results, nil
+	}
+}
+
+func field(name string) filter {
+	return func(input interface{}) ([]interface{}, error) {
+		switch value := input.(type) {
+		case nil:
+			return []interface{}{nil}, nil
+		case map[string]interface{}:
+			return []interface{}{value[name]}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with %q", typeName(input), name)
+		}
+	}
+}
+
+func index(i int) filter {
+	return func(input interface{}) ([]interface{}, error) {
+		switch value := input.(type) {
+		case nil:
+			return []interface{}{nil}, nil
+		case []interface{}:
+			at := i
+			if at < 0 {
+				at += len(value) // negative indexes count from the end
+			}
+			if at < 0 || at >= len(value) {
+				return []interface{}{nil}, nil
+			}
+			return []interface{}{value[at]}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with a number", typeName(input))
+		}
+	}
+}
+
+func iterate(input interface{}) ([]interface{}, error) {
+	switch value := input.(type) {
+	case []interface{}:
+		return append([]interface{}{}, value...), nil
+	case map[string]interface{}:
+		var results []interface{}
+		for _, key := range sortedKeys(value) {
+			results = append(results, value[key])
+		}
+		return results, nil
+	default:
+		return nil, fmt.Errorf("cannot iterate over %s", typeName(input))
+	}
+}
+
+func length(input interface{}) (interface{}, error) {
+	switch value := input.(type) {
+	case nil:
+		return 0.0, nil
+	case string:
+		return float64(len([]rune(value))), nil
+	case []interface{}:
+		return float64(len(value)), nil
+	case map[string]interface{}:
+		return float64(len(value)), nil
+	case float64:
+		return math.Abs(value), nil
+	default:
+		return nil, fmt.Errorf("%s has no length", typeName(input))
+	}
+}
+
+func keys(input interface{}) (interface{}, error) {
+	value, ok := input.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s has no keys", typeName(input))
+	}
+	var results []interface{}
+	for _, key := range sortedKeys(value) {
+		results = append(results, key)
+	}
+	return results, nil
+}
+
+// compare applies a comparison operator; ordering is defined for numbers and strings
+func compare(op string, a, b interface{}) (interface{}, error) {
+	switch op {
+	case "==":
+		return reflect.DeepEqual(a, b), nil
+	case "!=":
+		return !reflect.DeepEqual(a, b), nil
+	}
+
+	var order int
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare number with %s", typeName(b))
+		}
+		if x < y {
+			order = -1
+		} else if x > y {
+			order = 1
+		}
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare string with %s", typeName(b))
+		}
+		order = strings.Compare(x, y)
+	default:
+		return nil, fmt.Errorf("cannot order %s", typeName(a))
+	}
+
+	switch op {
+	case "<":
+		return order < 0, nil
+	case "<=":
+		return order <= 0, nil
+	case ">":
+		return order > 0, nil
+	default:
+		return order >= 0, nil
+	}
+}
+
+// truthy follows jq: only false and null are false
+func truthy(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	if b, ok := value.(bool); ok {
+		return b
+	}
+	return true
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
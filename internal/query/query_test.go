@@ -0,0 +1,87 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReport = `{
+  "overall_score": 72.5,
+  "component_scores": {"complexity": 81.2, "coverage": 40},
+  "recommendations": [
+    {"id": "COMPLEX-1", "priority": "critical", "effort_hours": 6, "files": ["src/a.js"]},
+    {"id": "DUP-1", "priority": "medium", "effort_hours": 2, "files": []},
+    {"id": "COMPLEX-2", "priority": "critical", "effort_hours": 1, "files": ["src/b.js", "src/c.js"]}
+  ],
+  "benchmark": null
+}`
+
+func decodeTestReport(t *testing.T) interface{} {
+	t.Helper()
+	var report interface{}
+	require.NoError(t, json.Unmarshal([]byte(testReport), &report))
+	return report
+}
+
+func TestEvaluate(t *testing.T) {
+	report := decodeTestReport(t)
+
+	tests := []struct {
+		expression string
+		expected   []interface{}
+	}{
+		{".component_scores.complexity", []interface{}{81.2}},
+		{`.["overall_score"]`, []interface{}{72.5}},
+		{".component_scores | keys", []interface{}{[]interface{}{"complexity", "coverage"}}},
+		{".recommendations[0].id", []interface{}{"COMPLEX-1"}},
+		{".recommendations[-1].id", []interface{}{"COMPLEX-2"}},
+		{".recommendations[9]", []interface{}{nil}},
+		{".recommendations | length", []interface{}{3.0}},
+		{".recommendations[].files[]", []interface{}{"src/a.js", "src/b.js", "src/c.js"}},
+		{`.recommendations[] | select(.priority=="critical") | .id`, []interface{}{"COMPLEX-1", "COMPLEX-2"}},
+		{`.recommendations[] | select(.priority == "critical" and .effort_hours > 2) | .id`, []interface{}{"COMPLEX-1"}},
+		{`.recommendations[] | select((.files | length) == 0 or .effort_hours >= 6) | .id`, []interface{}{"COMPLEX-1", "DUP-1"}},
+		{`.recommendations[] | select(.priority != "critical" | not) | .effort_hours`, []interface{}{6.0, 1.0}},
+		{".benchmark.delta", []interface{}{nil}},
+		{".component_scores.coverage < 50", []interface{}{true}},
+		{".", []interface{}{report}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			results, err := Evaluate(tt.expression, report)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, results)
+		})
+	}
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	report := decodeTestReport(t)
+
+	for _, expression := range []string{
+		".recommendations.id",       // arrays have no fields
+		".overall_score[]",          // numbers cannot be iterated
+		".overall_score < \"high\"", // mixed comparison
+		".component_scores | length == 2 | keys",
+	} {
+		_, err := Evaluate(expression, report)
+		assert.Error(t, err, expression)
+	}
+
+	for _, expression := range []string{
+		"",
+		".a =",
+		"select(.a",
+		".[1.5]",
+		`."unterminated`,
+		"unknown",
+		".a )",
+	} {
+		_, err := Compile(expression)
+		assert.Error(t, err, expression)
+	}
+}
@@ -0,0 +1,288 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+// ArchiveHandler extracts source archives into a sandboxed temporary directory
+type ArchiveHandler struct {
+	MaxExtractedSize int64 // in bytes, total across all entries
+	MaxEntries       int
+	TempDir          string
+	AuditLogger      *logger.Logger
+	tempDirCreated   bool
+}
+
+// ArchiveExtractResult represents the result of an archive extraction
+type ArchiveExtractResult struct {
+	LocalPath       string
+	ExtractedSize   int64
+	FileCount       int
+	SkippedEntries  int // symlinks, devices and other non-regular entries
+	ExtractDuration time.Duration
+}
+
+// NewArchiveHandler creates a new ArchiveHandler with secure defaults
+func NewArchiveHandler(auditLogger *logger.Logger) (*ArchiveHandler, error) {
+	if auditLogger == nil {
+		return nil, fmt.Errorf("audit logger cannot be nil")
+	}
+
+	tempDir, err := createSecureTempDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secure temp directory: %w", err)
+	}
+
+	return &ArchiveHandler{
+		MaxExtractedSize: DefaultMaxRepoSize, // matching GitHandler
+		MaxEntries:       500000,
+		TempDir:          tempDir,
+		AuditLogger:      auditLogger,
+		tempDirCreated:   true,
+	}, nil
+}
+
+// ExtractArchive extracts a validated archive, refusing entries that would escape the target directory
+func (ah *ArchiveHandler) ExtractArchive(ctx context.Context, archive *types.ArchiveFile) (*ArchiveExtractResult, error) {
+	if archive == nil {
+		return nil, fmt.Errorf("archive cannot be nil")
+	}
+
+	startTime := time.Now()
+	ah.AuditLogger.WithFields(map[string]interface{}{
+		"operation":    "archive_extract_start",
+		"archive":      filepath.Base(archive.Path),
+		"format":       archive.Format,
+		"archive_size": archive.Size,
+		"timestamp":    startTime.Unix(),
+	}).Info("Starting archive extraction")
+
+	extractDir := filepath.Join(ah.TempDir, fmt.Sprintf("extract-%d", startTime.UnixNano()))
+	if err := os.MkdirAll(extractDir, 0700); err != nil {
+		err = fmt.Errorf("failed to create extraction directory: %w", err)
+		ah.logExtractFailure(archive, startTime, err)
+		return nil, err
+	}
+
+	result := &ArchiveExtractResult{LocalPath: extractDir}
+
+	var err error
+	switch archive.Format {
+	case types.ArchiveFormatTarGz, types.ArchiveFormatTar:
+		err = ah.extractTar(ctx, archive, extractDir, result)
+	case types.ArchiveFormatZip:
+		err = ah.extractZip(ctx, archive, extractDir, result)
+	default:
+		err = fmt.Errorf("unsupported archive format: %s", archive.Format)
+	}
+	if err != nil {
+		os.RemoveAll(extractDir)
+		ah.logExtractFailure(archive, startTime, err)
+		return nil, err
+	}
+
+	result.ExtractDuration = time.Since(startTime)
+
+	ah.AuditLogger.WithFields(map[string]interface{}{
+		"operation":        "archive_extract_success",
+		"archive":          filepath.Base(archive.Path),
+		"local_path":       extractDir,
+		"extracted_size":   result.ExtractedSize,
+		"file_count":       result.FileCount,
+		"skipped_entries":  result.SkippedEntries,
+		"extract_duration": result.ExtractDuration.Seconds(),
+		"timestamp":        time.Now().Unix(),
+	}).Info("Archive extraction completed successfully")
+
+	return result, nil
+}
+
+// extractTar extracts plain and gzip-compressed tar archives
+func (ah *ArchiveHandler) extractTar(ctx context.Context, archive *types.ArchiveFile, destDir string, result *ArchiveExtractResult) error {
+	file, err := os.Open(archive.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if archive.Format == types.ArchiveFormatTarGz {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("failed to read gzip stream: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry: %w", err)
+		}
+
+		target, err := safeExtractPath(destDir, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", header.Name, err)
+			}
+		case tar.TypeReg:
+			if err := ah.writeEntry(target, tarReader, header.Size, result); err != nil {
+				return err
+			}
+		default:
+			// Links and special files could point outside the sandbox; never materialize them
+			result.SkippedEntries++
+		}
+	}
+}
+
+// extractZip extracts zip archives
+func (ah *ArchiveHandler) extractZip(ctx context.Context, archive *types.ArchiveFile, destDir string, result *ArchiveExtractResult) error {
+	zipReader, err := zip.OpenReader(archive.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	defer zipReader.Close()
+
+	for _, entry := range zipReader.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		target, err := safeExtractPath(destDir, entry.Name)
+		if err != nil {
+			return err
+		}
+
+		mode := entry.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", entry.Name, err)
+			}
+		case mode.IsRegular():
+			entryReader, err := entry.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open zip entry %s: %w", entry.Name, err)
+			}
+			err = ah.writeEntry(target, entryReader, int64(entry.UncompressedSize64), result)
+			entryReader.Close()
+			if err != nil {
+				return err
+			}
+		default:
+			result.SkippedEntries++
+		}
+	}
+
+	return nil
+}
+
+// writeEntry copies a single regular file while enforcing entry-count and size limits.
+// The declared size is not trusted: at most the remaining budget plus one byte is read.
+func (ah *ArchiveHandler) writeEntry(target string, reader io.Reader, declaredSize int64, result *ArchiveExtractResult) error {
+	if result.FileCount >= ah.MaxEntries {
+		return fmt.Errorf("archive contains more than %d files", ah.MaxEntries)
+	}
+
+	remaining := ah.MaxExtractedSize - result.ExtractedSize
+	if declaredSize > remaining {
+		return fmt.Errorf("%w: archive contents exceed %d bytes", types.ErrRepositoryTooLarge, ah.MaxExtractedSize)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", target, err)
+	}
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", target, err)
+	}
+	defer out.Close()
+
+	written, err := io.Copy(out, io.LimitReader(reader, remaining+1))
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", target, err)
+	}
+	if written > remaining {
+		return fmt.Errorf("%w: archive contents exceed %d bytes", types.ErrRepositoryTooLarge, ah.MaxExtractedSize)
+	}
+
+	result.ExtractedSize += written
+	result.FileCount++
+	return nil
+}
+
+// safeExtractPath resolves an archive entry name inside destDir, rejecting absolute
+// paths and any name that would escape the destination (zip-slip)
+func safeExtractPath(destDir, name string) (string, error) {
+	normalized := strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(normalized, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("archive entry has an absolute path: %s", name)
+	}
+
+	target := filepath.Join(destDir, filepath.FromSlash(normalized))
+	rel, err := filepath.Rel(destDir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive entry escapes extraction directory: %s", name)
+	}
+
+	return target, nil
+}
+
+// logExtractFailure logs extraction failure without exposing the full archive path
+func (ah *ArchiveHandler) logExtractFailure(archive *types.ArchiveFile, startTime time.Time, err error) {
+	ah.AuditLogger.WithFields(map[string]interface{}{
+		"operation":        "archive_extract_failure",
+		"archive":          filepath.Base(archive.Path),
+		"error":            err.Error(),
+		"failure_duration": time.Since(startTime).Seconds(),
+		"timestamp":        time.Now().Unix(),
+	}).Error("Archive extraction failed")
+}
+
+// Cleanup removes temporary files and directories
+func (ah *ArchiveHandler) Cleanup() error {
+	if !ah.tempDirCreated || ah.TempDir == "" {
+		return nil
+	}
+
+	ah.AuditLogger.WithFields(map[string]interface{}{
+		"operation": "archive_handler_cleanup",
+		"temp_dir":  ah.TempDir,
+		"timestamp": time.Now().Unix(),
+	}).Info("Cleaning up ArchiveHandler temporary directory")
+
+	if err := os.RemoveAll(ah.TempDir); err != nil {
+		return fmt.Errorf("failed to cleanup temp directory: %w", err)
+	}
+
+	ah.tempDirCreated = false
+	return nil
+}
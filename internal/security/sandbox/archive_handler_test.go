@@ -0,0 +1,179 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+type testArchiveEntry struct {
+	name     string
+	body     string
+	typeflag byte
+	linkname string
+}
+
+func createTestTarGz(t *testing.T, entries []testArchiveEntry) *types.ArchiveFile {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		typeflag := entry.typeflag
+		if typeflag == 0 {
+			typeflag = tar.TypeReg
+		}
+		header := &tar.Header{Name: entry.name, Mode: 0644, Typeflag: typeflag, Linkname: entry.linkname}
+		if typeflag == tar.TypeReg {
+			header.Size = int64(len(entry.body))
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		if typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(entry.body))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	path := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	return &types.ArchiveFile{Path: path, Format: types.ArchiveFormatTarGz, Size: int64(buf.Len())}
+}
+
+func createTestZip(t *testing.T, entries []testArchiveEntry) *types.ArchiveFile {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		w, err := zw.Create(entry.name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(entry.body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	path := filepath.Join(t.TempDir(), "snapshot.zip")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	return &types.ArchiveFile{Path: path, Format: types.ArchiveFormatZip, Size: int64(buf.Len())}
+}
+
+func newTestArchiveHandler(t *testing.T) *ArchiveHandler {
+	t.Helper()
+	ah, err := NewArchiveHandler(logger.New())
+	require.NoError(t, err)
+	t.Cleanup(func() { ah.Cleanup() })
+	return ah
+}
+
+func TestNewArchiveHandler(t *testing.T) {
+	_, err := NewArchiveHandler(nil)
+	assert.Error(t, err)
+
+	ah := newTestArchiveHandler(t)
+	stat, err := os.Stat(ah.TempDir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), stat.Mode().Perm())
+}
+
+func TestArchiveHandler_ExtractArchive(t *testing.T) {
+	entries := []testArchiveEntry{
+		{name: "src/index.js", body: "export const a = 1;\n"},
+		{name: "src/util/math.ts", body: "export const b = 2;\n"},
+	}
+
+	tests := []struct {
+		name    string
+		archive func(t *testing.T) *types.ArchiveFile
+	}{
+		{name: "tar.gz", archive: func(t *testing.T) *types.ArchiveFile { return createTestTarGz(t, entries) }},
+		{name: "zip", archive: func(t *testing.T) *types.ArchiveFile { return createTestZip(t, entries) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ah := newTestArchiveHandler(t)
+
+			result, err := ah.ExtractArchive(context.Background(), tt.archive(t))
+			require.NoError(t, err)
+
+			assert.Equal(t, 2, result.FileCount)
+			content, err := os.ReadFile(filepath.Join(result.LocalPath, "src", "util", "math.ts"))
+			require.NoError(t, err)
+			assert.Equal(t, "export const b = 2;\n", string(content))
+		})
+	}
+}
+
+func TestArchiveHandler_RejectsPathTraversal(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive func(t *testing.T) *types.ArchiveFile
+	}{
+		{name: "tar parent traversal", archive: func(t *testing.T) *types.ArchiveFile {
+			return createTestTarGz(t, []testArchiveEntry{{name: "../../evil.js", body: "x"}})
+		}},
+		{name: "tar absolute path", archive: func(t *testing.T) *types.ArchiveFile {
+			return createTestTarGz(t, []testArchiveEntry{{name: "/tmp/evil.js", body: "x"}})
+		}},
+		{name: "zip nested traversal", archive: func(t *testing.T) *types.ArchiveFile {
+			return createTestZip(t, []testArchiveEntry{{name: "src/../../evil.js", body: "x"}})
+		}},
+		{name: "zip backslash traversal", archive: func(t *testing.T) *types.ArchiveFile {
+			return createTestZip(t, []testArchiveEntry{{name: "..\\evil.js", body: "x"}})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ah := newTestArchiveHandler(t)
+
+			_, err := ah.ExtractArchive(context.Background(), tt.archive(t))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "archive entry")
+
+			_, statErr := os.Stat(filepath.Join(filepath.Dir(ah.TempDir), "evil.js"))
+			assert.True(t, os.IsNotExist(statErr))
+		})
+	}
+}
+
+func TestArchiveHandler_SkipsLinks(t *testing.T) {
+	ah := newTestArchiveHandler(t)
+	archive := createTestTarGz(t, []testArchiveEntry{
+		{name: "index.js", body: "1;"},
+		{name: "passwd", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"},
+	})
+
+	result, err := ah.ExtractArchive(context.Background(), archive)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.FileCount)
+	assert.Equal(t, 1, result.SkippedEntries)
+	_, err = os.Lstat(filepath.Join(result.LocalPath, "passwd"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestArchiveHandler_EnforcesLimits(t *testing.T) {
+	ah := newTestArchiveHandler(t)
+	ah.MaxExtractedSize = 4
+	_, err := ah.ExtractArchive(context.Background(), createTestZip(t, []testArchiveEntry{{name: "big.js", body: "0123456789"}}))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, types.ErrRepositoryTooLarge)
+
+	ah = newTestArchiveHandler(t)
+	ah.MaxEntries = 1
+	_, err = ah.ExtractArchive(context.Background(), createTestTarGz(t, []testArchiveEntry{{name: "a.js", body: "1"}, {name: "b.js", body: "2"}}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "more than 1 files")
+}
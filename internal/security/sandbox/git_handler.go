@@ -3,6 +3,7 @@
 package sandbox
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -13,15 +14,22 @@ import (
 	"time"
 
 	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
 )
 
+// DefaultMaxRepoSize is the size limit for cloned and extracted source when none is configured
+const DefaultMaxRepoSize = 10 * 1024 * 1024 * 1024 // 10GB
+
 // GitHandler manages secure Git repository operations with sandboxing
 type GitHandler struct {
-	CloneTimeout   time.Duration
-	MaxRepoSize    int64 // in bytes
-	TempDir        string
-	AuditLogger    *logger.Logger
-	tempDirCreated bool
+	CloneTimeout      time.Duration
+	MaxRepoSize       int64         // in bytes
+	SizeCheckInterval time.Duration // how often the working tree is measured during clone
+	FullHistory       bool          // clone every commit (without old file contents) for history-based analysis
+	TempDir           string
+	AuditLogger       *logger.Logger
+	tempDirCreated    bool
+	cacheLock         *os.File // held on the cached clone in use, see CachedClone
 }
 
 // GitCloneResult represents the result of a Git clone operation
@@ -46,11 +54,12 @@ func NewGitHandler(auditLogger *logger.Logger) (*GitHandler, error) {
 	}
 
 	return &GitHandler{
-		CloneTimeout:   30 * time.Minute,        // Default 30 minute timeout
-		MaxRepoSize:    10 * 1024 * 1024 * 1024, // 10GB limit
-		TempDir:        tempDir,
-		AuditLogger:    auditLogger,
-		tempDirCreated: true,
+		CloneTimeout:      30 * time.Minute, // Default 30 minute timeout
+		MaxRepoSize:       DefaultMaxRepoSize,
+		SizeCheckInterval: time.Second,
+		TempDir:           tempDir,
+		AuditLogger:       auditLogger,
+		tempDirCreated:    true,
 	}, nil
 }
 
@@ -130,15 +139,32 @@ func (gh *GitHandler) CloneRepository(ctx context.Context, repoURL string) (*Git
 	return result, nil
 }
 
-// performClone executes the actual Git clone operation
-func (gh *GitHandler) performClone(ctx context.Context, repoURL, cloneDir string) (*GitCloneResult, error) {
-	// Use git clone with specific options for security
-	cmd := exec.CommandContext(ctx, "git", "clone",
-		"--depth=1",       // Shallow clone to reduce size
+// cloneArgs builds the git clone arguments. History-based analysis needs every commit but
+// not old file contents, so FullHistory swaps the shallow clone for a blob-less one.
+func (gh *GitHandler) cloneArgs(repoURL, cloneDir string) []string {
+	history := "--depth=1" // Shallow clone to reduce size
+	if gh.FullHistory {
+		history = "--filter=blob:none"
+	}
+
+	return []string{"clone",
+		history,
 		"--single-branch", // Only clone the default branch
 		"--no-hardlinks",  // Prevent hardlink issues
 		repoURL,
-		cloneDir)
+		cloneDir,
+	}
+}
+
+// performClone executes the actual Git clone operation. The clone directory is measured
+// every SizeCheckInterval while git runs, and the clone is killed as soon as it grows
+// past MaxRepoSize; a failed or oversized clone never leaves files behind.
+func (gh *GitHandler) performClone(ctx context.Context, repoURL, cloneDir string) (*GitCloneResult, error) {
+	cloneCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Use git clone with specific options for security
+	cmd := exec.CommandContext(cloneCtx, "git", gh.cloneArgs(repoURL, cloneDir)...)
 
 	// Set environment variables to prevent credential prompting
 	cmd.Env = append(os.Environ(),
@@ -146,22 +172,53 @@ func (gh *GitHandler) performClone(ctx context.Context, repoURL, cloneDir string
 		"GIT_ASKPASS=echo",
 	)
 
-	// Execute the clone command
-	output, err := cmd.CombinedOutput()
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(cloneDir)
+		return nil, fmt.Errorf("git clone failed to start: %w", err)
+	}
+
+	// Watch the working tree while git writes it
+	oversized := make(chan int64, 1)
+	watchDone := make(chan struct{})
+	go func() {
+		defer close(watchDone)
+		gh.watchCloneSize(cloneCtx, cloneDir, oversized, cancel)
+	}()
+
+	err := cmd.Wait()
+	cancel()
+	<-watchDone
+
+	select {
+	case size := <-oversized:
+		os.RemoveAll(cloneDir)
+		return nil, fmt.Errorf("%w: clone aborted at %d bytes (limit %d bytes)", types.ErrRepositoryTooLarge, size, gh.MaxRepoSize)
+	default:
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("git clone failed: %w, output: %s", err, string(output))
+		os.RemoveAll(cloneDir)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("git clone interrupted: %w", ctxErr)
+		}
+		return nil, fmt.Errorf("git clone failed: %w, output: %s", err, output.String())
 	}
 
 	// Validate repository size after clone
 	repoSize, err := calculateDirectorySize(cloneDir)
 	if err != nil {
+		os.RemoveAll(cloneDir)
 		return nil, fmt.Errorf("failed to calculate repository size: %w", err)
 	}
 
 	if repoSize > gh.MaxRepoSize {
 		// Clean up oversized repository immediately
 		os.RemoveAll(cloneDir)
-		return nil, fmt.Errorf("repository size (%d bytes) exceeds maximum allowed size (%d bytes)", repoSize, gh.MaxRepoSize)
+		return nil, fmt.Errorf("%w: repository size (%d bytes) exceeds limit (%d bytes)", types.ErrRepositoryTooLarge, repoSize, gh.MaxRepoSize)
 	}
 
 	return &GitCloneResult{
@@ -170,6 +227,33 @@ func (gh *GitHandler) performClone(ctx context.Context, repoURL, cloneDir string
 	}, nil
 }
 
+// watchCloneSize periodically measures dir until ctx is done. When the size exceeds
+// MaxRepoSize it reports the size on oversized and calls abort to stop the clone.
+func (gh *GitHandler) watchCloneSize(ctx context.Context, dir string, oversized chan<- int64, abort func()) {
+	interval := gh.SizeCheckInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Files may vanish while git renames them; a partial measurement is still a lower bound
+			size, _ := calculateDirectorySize(dir)
+			if size > gh.MaxRepoSize {
+				oversized <- size
+				abort()
+				return
+			}
+		}
+	}
+}
+
 // calculateDirectorySize calculates the total size of a directory
 func calculateDirectorySize(dirPath string) (int64, error) {
 	var totalSize int64
@@ -215,6 +299,7 @@ func sanitizeURL(url string) string {
 
 // Cleanup removes temporary files and directories
 func (gh *GitHandler) Cleanup() error {
+	gh.releaseCacheLock()
 	if !gh.tempDirCreated || gh.TempDir == "" {
 		return nil
 	}
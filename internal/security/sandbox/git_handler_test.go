@@ -3,6 +3,7 @@ package sandbox
 import (
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
 )
 
 func TestNewGitHandler(t *testing.T) {
@@ -271,3 +273,54 @@ func TestGitHandlerDefaults(t *testing.T) {
 	assert.NotNil(t, gh.AuditLogger)
 	assert.True(t, gh.tempDirCreated)
 }
+
+func TestCloneArgs(t *testing.T) {
+	gh := &GitHandler{}
+	assert.Contains(t, gh.cloneArgs("https://example.com/repo.git", "/tmp/clone"), "--depth=1")
+
+	gh.FullHistory = true
+	args := gh.cloneArgs("https://example.com/repo.git", "/tmp/clone")
+	assert.Contains(t, args, "--filter=blob:none")
+	assert.NotContains(t, args, "--depth=1")
+	assert.Equal(t, []string{"https://example.com/repo.git", "/tmp/clone"}, args[len(args)-2:])
+}
+
+func TestPerformCloneEnforcesMaxRepoSize(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	// Build a small local repository to clone from
+	source := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(source, "data.bin"), make([]byte, 64*1024), 0644))
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.email=test@example.com", "-c", "user.name=test", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", source}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	gh, err := NewGitHandler(logger.New())
+	require.NoError(t, err)
+	defer gh.Cleanup()
+	gh.MaxRepoSize = 1024
+	gh.SizeCheckInterval = 10 * time.Millisecond
+
+	cloneDir := filepath.Join(gh.TempDir, "clone")
+	_, err = gh.performClone(context.Background(), "file://"+source, cloneDir)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, types.ErrRepositoryTooLarge)
+
+	// The partial clone is removed
+	_, statErr := os.Stat(cloneDir)
+	assert.True(t, os.IsNotExist(statErr))
+
+	// Within the limit the clone succeeds
+	gh.MaxRepoSize = 10 * 1024 * 1024
+	result, err := gh.performClone(context.Background(), "file://"+source, cloneDir)
+	require.NoError(t, err)
+	assert.Greater(t, result.RepoSize, int64(64*1024))
+}
@@ -0,0 +1,234 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+// cacheStampFile marks when a cached clone was last cloned or fetched, inside its .git directory
+const cacheStampFile = "repo-onboarding-fetched"
+
+// cacheLockPoll is how often a run waiting for a cached clone checks whether it is free
+const cacheLockPoll = 200 * time.Millisecond
+
+// RepoCache keeps clones in a directory across runs, one per repository URL, and updates
+// them with git fetch instead of cloning again
+type RepoCache struct {
+	Dir string        // cache directory, created with owner-only permissions
+	TTL time.Duration // a clone fetched within TTL is reused without contacting the remote; 0 always fetches
+}
+
+// cacheKey names the cached clone of repoURL: a digest, so URLs never become paths
+func cacheKey(repoURL string) string {
+	sum := sha256.Sum256([]byte(repoURL))
+	return hex.EncodeToString(sum[:12])
+}
+
+// CachedClone returns a clone of repoURL from cache, cloning it on the first use and
+// fetching the default branch once it is older than cache.TTL. A cached clone that cannot
+// be updated, or that is shallow when FullHistory needs every commit, is cloned again.
+// The clone belongs to the cache: Cleanup leaves it in place for the next run. Concurrent
+// runs take turns: the clone stays locked to this handler until Cleanup, so no other run
+// fetches or replaces it while it is being analyzed.
+func (gh *GitHandler) CachedClone(ctx context.Context, repoURL string, cache RepoCache) (result *GitCloneResult, err error) {
+	startTime := time.Now()
+	if err := os.MkdirAll(cache.Dir, 0700); err != nil {
+		err = fmt.Errorf("failed to create repository cache directory: %w", err)
+		gh.logCloneFailure(repoURL, startTime, err)
+		return &GitCloneResult{Error: err}, err
+	}
+	cloneDir := filepath.Join(cache.Dir, cacheKey(repoURL))
+
+	gh.releaseCacheLock()
+	if err := gh.lockCachedClone(ctx, repoURL, cloneDir); err != nil {
+		gh.logCloneFailure(repoURL, startTime, err)
+		return &GitCloneResult{Error: err}, err
+	}
+	defer func() {
+		if err != nil {
+			gh.releaseCacheLock()
+		}
+	}()
+
+	cloneCtx, cancel := context.WithTimeout(ctx, gh.CloneTimeout)
+	defer cancel()
+
+	operation := "git_cache_hit"
+	if _, err := os.Stat(filepath.Join(cloneDir, ".git")); err == nil {
+		if err := gh.refreshCachedClone(cloneCtx, cloneDir, cache.TTL, &operation); err != nil {
+			gh.AuditLogger.WithFields(map[string]interface{}{
+				"operation": "git_cache_refresh_failure",
+				"repo_url":  sanitizeURL(repoURL),
+				"error":     err.Error(),
+			}).Warn("Cached clone could not be updated, cloning again")
+			if err := os.RemoveAll(cloneDir); err != nil {
+				err = fmt.Errorf("failed to remove stale cached clone: %w", err)
+				gh.logCloneFailure(repoURL, startTime, err)
+				return &GitCloneResult{Error: err}, err
+			}
+		}
+	}
+
+	if _, err := os.Stat(cloneDir); os.IsNotExist(err) {
+		operation = "git_cache_clone"
+		if err := gh.cloneIntoCache(cloneCtx, repoURL, cloneDir); err != nil {
+			gh.logCloneFailure(repoURL, startTime, err)
+			return &GitCloneResult{Error: err}, err
+		}
+	}
+
+	repoSize, err := calculateDirectorySize(cloneDir)
+	if err != nil {
+		err = fmt.Errorf("failed to calculate repository size: %w", err)
+		gh.logCloneFailure(repoURL, startTime, err)
+		return &GitCloneResult{Error: err}, err
+	}
+	if repoSize > gh.MaxRepoSize {
+		err = fmt.Errorf("%w: repository size (%d bytes) exceeds limit (%d bytes)", types.ErrRepositoryTooLarge, repoSize, gh.MaxRepoSize)
+		gh.logCloneFailure(repoURL, startTime, err)
+		return &GitCloneResult{Error: err}, err
+	}
+
+	result = &GitCloneResult{
+		LocalPath:     cloneDir,
+		RepoSize:      repoSize,
+		CloneDuration: time.Since(startTime),
+		Success:       true,
+	}
+	gh.AuditLogger.WithFields(map[string]interface{}{
+		"operation":      operation,
+		"repo_url":       sanitizeURL(repoURL),
+		"local_path":     cloneDir,
+		"repo_size":      repoSize,
+		"clone_duration": result.CloneDuration.Seconds(),
+		"timestamp":      time.Now().Unix(),
+	}).Info("Cached repository ready")
+
+	return result, nil
+}
+
+// lockCachedClone waits until no other run holds the cached clone in cloneDir, then locks
+// it for this handler; ctx bounds the wait
+func (gh *GitHandler) lockCachedClone(ctx context.Context, repoURL, cloneDir string) error {
+	lock, err := os.OpenFile(cloneDir+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open repository cache lock: %w", err)
+	}
+	for waiting := false; ; waiting = true {
+		locked, err := tryLockFile(lock)
+		if err != nil {
+			lock.Close()
+			return fmt.Errorf("failed to lock cached clone: %w", err)
+		}
+		if locked {
+			gh.cacheLock = lock
+			return nil
+		}
+		if !waiting {
+			gh.AuditLogger.WithFields(map[string]interface{}{
+				"operation": "git_cache_wait",
+				"repo_url":  sanitizeURL(repoURL),
+			}).Info("Waiting for another run to finish with the cached clone")
+		}
+		select {
+		case <-ctx.Done():
+			lock.Close()
+			return fmt.Errorf("waiting for cached clone: %w", ctx.Err())
+		case <-time.After(cacheLockPoll):
+		}
+	}
+}
+
+// releaseCacheLock lets other runs use the cached clone this handler holds, if any
+func (gh *GitHandler) releaseCacheLock() {
+	if gh.cacheLock != nil {
+		gh.cacheLock.Close()
+		gh.cacheLock = nil
+	}
+}
+
+// cloneIntoCache clones repoURL next to cloneDir and moves it into place once complete, so
+// an interrupted clone never looks like a cached one
+func (gh *GitHandler) cloneIntoCache(ctx context.Context, repoURL, cloneDir string) error {
+	partialDir := fmt.Sprintf("%s.partial-%d", cloneDir, time.Now().UnixNano())
+	if _, err := gh.performClone(ctx, repoURL, partialDir); err != nil {
+		return err
+	}
+	if err := touchCacheStamp(partialDir); err != nil {
+		os.RemoveAll(partialDir)
+		return err
+	}
+	if err := os.Rename(partialDir, cloneDir); err != nil {
+		os.RemoveAll(partialDir)
+		return fmt.Errorf("failed to move clone into the repository cache: %w", err)
+	}
+	return nil
+}
+
+// refreshCachedClone brings a cached clone up to date with the remote default branch when
+// its last fetch is older than ttl, setting operation to what it did
+func (gh *GitHandler) refreshCachedClone(ctx context.Context, cloneDir string, ttl time.Duration, operation *string) error {
+	shallow, err := runGit(ctx, cloneDir, "rev-parse", "--is-shallow-repository")
+	if err != nil {
+		return err
+	}
+	if gh.FullHistory && shallow == "true" {
+		return fmt.Errorf("cached clone is shallow but full history is needed")
+	}
+
+	if info, err := os.Stat(filepath.Join(cloneDir, ".git", cacheStampFile)); err == nil && time.Since(info.ModTime()) < ttl {
+		return nil
+	}
+
+	fetch := []string{"fetch", "--quiet", "--prune", "origin"}
+	if shallow == "true" {
+		fetch = []string{"fetch", "--quiet", "--prune", "--depth=1", "origin"}
+	}
+	for _, args := range [][]string{
+		fetch,
+		{"reset", "--quiet", "--hard", "FETCH_HEAD"},
+		{"clean", "--quiet", "-ffdx"},
+	} {
+		if _, err := runGit(ctx, cloneDir, args...); err != nil {
+			return err
+		}
+	}
+	*operation = "git_cache_fetch"
+	return touchCacheStamp(cloneDir)
+}
+
+// runGit runs git in dir without credential prompts, returning its trimmed output
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_ASKPASS=echo",
+	)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w, output: %s", args[0], err, output.String())
+	}
+	return strings.TrimSpace(output.String()), nil
+}
+
+// touchCacheStamp records that the clone in dir was just cloned or fetched
+func touchCacheStamp(dir string) error {
+	if err := os.WriteFile(filepath.Join(dir, ".git", cacheStampFile), nil, 0600); err != nil {
+		return fmt.Errorf("failed to stamp cached clone: %w", err)
+	}
+	now := time.Now()
+	return os.Chtimes(filepath.Join(dir, ".git", cacheStampFile), now, now)
+}
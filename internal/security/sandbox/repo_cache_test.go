@@ -0,0 +1,109 @@
+package sandbox
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/logger"
+)
+
+// sourceRepository creates a git repository with a.js committed, returning its path and a
+// function committing another file to it
+func sourceRepository(t *testing.T) (string, func(file, content string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	source := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", source, "-c", "user.email=test@example.com", "-c", "user.name=test"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	commit := func(file, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(source, file), []byte(content), 0644))
+		git("add", ".")
+		git("commit", "-q", "-m", file)
+	}
+	git("init", "-q")
+	commit("a.js", "export const a = 1;\n")
+	return source, commit
+}
+
+func TestCachedClone(t *testing.T) {
+	source, commit := sourceRepository(t)
+
+	gh, err := NewGitHandler(logger.New())
+	require.NoError(t, err)
+	defer gh.Cleanup()
+
+	cache := RepoCache{Dir: filepath.Join(t.TempDir(), "cache"), TTL: time.Hour}
+	repoURL := "file://" + source
+
+	first, err := gh.CachedClone(context.Background(), repoURL, cache)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cache.Dir, cacheKey(repoURL)), first.LocalPath)
+	assert.FileExists(t, filepath.Join(first.LocalPath, "a.js"))
+
+	// Within the TTL the clone is reused as is
+	commit("b.js", "export const b = 2;\n")
+	second, err := gh.CachedClone(context.Background(), repoURL, cache)
+	require.NoError(t, err)
+	assert.Equal(t, first.LocalPath, second.LocalPath)
+	assert.NoFileExists(t, filepath.Join(second.LocalPath, "b.js"))
+
+	// Once stale it is fetched, and stray files are cleaned
+	require.NoError(t, os.WriteFile(filepath.Join(second.LocalPath, "stray.js"), nil, 0644))
+	cache.TTL = 0
+	third, err := gh.CachedClone(context.Background(), repoURL, cache)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(third.LocalPath, "b.js"))
+	assert.NoFileExists(t, filepath.Join(third.LocalPath, "stray.js"))
+
+	// Cleanup leaves the cache in place
+	require.NoError(t, gh.Cleanup())
+	assert.DirExists(t, third.LocalPath)
+
+	// A shallow clone is replaced when full history is needed
+	gh, err = NewGitHandler(logger.New())
+	require.NoError(t, err)
+	defer gh.Cleanup()
+	gh.FullHistory = true
+	full, err := gh.CachedClone(context.Background(), repoURL, cache)
+	require.NoError(t, err)
+	shallow, err := runGit(context.Background(), full.LocalPath, "rev-parse", "--is-shallow-repository")
+	require.NoError(t, err)
+	assert.Equal(t, "false", shallow)
+}
+
+func TestCachedClone_ConcurrentRunsTakeTurns(t *testing.T) {
+	source, _ := sourceRepository(t)
+	cache := RepoCache{Dir: filepath.Join(t.TempDir(), "cache"), TTL: time.Hour}
+	repoURL := "file://" + source
+
+	first, err := NewGitHandler(logger.New())
+	require.NoError(t, err)
+	defer first.Cleanup()
+	_, err = first.CachedClone(context.Background(), repoURL, cache)
+	require.NoError(t, err)
+
+	second, err := NewGitHandler(logger.New())
+	require.NoError(t, err)
+	defer second.Cleanup()
+	ctx, cancel := context.WithTimeout(context.Background(), 3*cacheLockPoll)
+	defer cancel()
+	_, err = second.CachedClone(ctx, repoURL, cache)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the clone is in use until the first run cleans up")
+
+	require.NoError(t, first.Cleanup())
+	result, err := second.CachedClone(context.Background(), repoURL, cache)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(result.LocalPath, "a.js"))
+}
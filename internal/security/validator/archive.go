@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+// archiveExtensions maps recognized file suffixes to archive formats
+var archiveExtensions = []struct {
+	suffix string
+	format string
+}{
+	{".tar.gz", types.ArchiveFormatTarGz},
+	{".tgz", types.ArchiveFormatTarGz},
+	{".tar", types.ArchiveFormatTar},
+	{".zip", types.ArchiveFormatZip},
+}
+
+// IsArchivePath reports whether the input names a file with a supported archive extension.
+// It does not touch the filesystem; use ValidateArchive before extracting.
+func IsArchivePath(input string) bool {
+	return archiveFormatFromExtension(input) != ""
+}
+
+// ValidateArchive validates a local archive file by extension and content sniffing
+func (v *URLValidator) ValidateArchive(path string) (*types.ArchiveFile, error) {
+	sanitizedPath := v.sanitizeInput(path)
+
+	if len(sanitizedPath) > v.maxLength {
+		return nil, fmt.Errorf("archive path exceeds maximum length of %d characters", v.maxLength)
+	}
+
+	format := archiveFormatFromExtension(sanitizedPath)
+	if format == "" {
+		return nil, fmt.Errorf("unsupported archive extension: %s", filepath.Base(sanitizedPath))
+	}
+
+	info, err := os.Stat(sanitizedPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access archive: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("archive must be a regular file")
+	}
+
+	sniffed, err := sniffArchiveFormat(sanitizedPath)
+	if err != nil {
+		return nil, err
+	}
+	if sniffed != format {
+		return nil, fmt.Errorf("archive content does not match its extension: expected %s, found %s", format, sniffed)
+	}
+
+	absPath, err := filepath.Abs(sanitizedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve archive path: %w", err)
+	}
+
+	return &types.ArchiveFile{
+		Path:   absPath,
+		Format: format,
+		Size:   info.Size(),
+	}, nil
+}
+
+// archiveFormatFromExtension returns the archive format implied by the file suffix
+func archiveFormatFromExtension(path string) string {
+	lowerPath := strings.ToLower(path)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(lowerPath, ext.suffix) {
+			return ext.format
+		}
+	}
+	return ""
+}
+
+// sniffArchiveFormat identifies an archive from its leading magic bytes
+func sniffArchiveFormat(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot open archive: %w", err)
+	}
+	defer file.Close()
+
+	// The tar "ustar" magic lives at offset 257, so read the full first header block
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("cannot read archive header: %w", err)
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return types.ArchiveFormatTarGz, nil
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")), bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return types.ArchiveFormatZip, nil
+	case len(header) >= 262 && bytes.Equal(header[257:262], []byte("ustar")):
+		return types.ArchiveFormatTar, nil
+	}
+
+	return "", fmt.Errorf("file content is not a recognized archive")
+}
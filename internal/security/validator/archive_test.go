@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yenhunghuang/repo-onboarding-copilot/pkg/types"
+)
+
+func writeTarGz(t *testing.T, path string) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "index.js", Mode: 0644, Size: 2, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("1;"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func writeZip(t *testing.T, path string) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("index.js")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("1;"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func TestIsArchivePath(t *testing.T) {
+	assert.True(t, IsArchivePath("snapshot.tar.gz"))
+	assert.True(t, IsArchivePath("snapshot.TGZ"))
+	assert.True(t, IsArchivePath("/tmp/snapshot.tar"))
+	assert.True(t, IsArchivePath("snapshot.zip"))
+	assert.False(t, IsArchivePath("https://github.com/owner/repo.git"))
+	assert.False(t, IsArchivePath("snapshot.gz"))
+}
+
+func TestURLValidator_ValidateArchive(t *testing.T) {
+	validator := New()
+	dir := t.TempDir()
+
+	tarGzPath := filepath.Join(dir, "snapshot.tgz")
+	writeTarGz(t, tarGzPath)
+	zipPath := filepath.Join(dir, "snapshot.zip")
+	writeZip(t, zipPath)
+
+	archive, err := validator.ValidateArchive(tarGzPath)
+	require.NoError(t, err)
+	assert.Equal(t, types.ArchiveFormatTarGz, archive.Format)
+	assert.True(t, filepath.IsAbs(archive.Path))
+	assert.Greater(t, archive.Size, int64(0))
+
+	archive, err = validator.ValidateArchive(zipPath)
+	require.NoError(t, err)
+	assert.Equal(t, types.ArchiveFormatZip, archive.Format)
+}
+
+func TestURLValidator_ValidateArchive_Rejects(t *testing.T) {
+	validator := New()
+	dir := t.TempDir()
+
+	// A zip disguised as a tarball must not pass content sniffing
+	disguised := filepath.Join(dir, "disguised.tar.gz")
+	writeZip(t, disguised)
+
+	notArchive := filepath.Join(dir, "notes.zip")
+	require.NoError(t, os.WriteFile(notArchive, []byte("plain text"), 0644))
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "folder.zip"), 0755))
+
+	tests := []struct {
+		name   string
+		path   string
+		errMsg string
+	}{
+		{name: "unsupported extension", path: filepath.Join(dir, "snapshot.rar"), errMsg: "unsupported archive extension"},
+		{name: "missing file", path: filepath.Join(dir, "missing.zip"), errMsg: "cannot access archive"},
+		{name: "directory", path: filepath.Join(dir, "folder.zip"), errMsg: "regular file"},
+		{name: "mismatched content", path: disguised, errMsg: "does not match its extension"},
+		{name: "not an archive", path: notArchive, errMsg: "not a recognized archive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateArchive(tt.path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
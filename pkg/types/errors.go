@@ -0,0 +1,58 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors classifying analysis pipeline failures. Pipeline errors wrap
+// exactly one of these, so callers can branch with errors.Is regardless of the
+// underlying cause.
+var (
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrCloneFailed        = errors.New("repository clone failed")
+	ErrExtractFailed      = errors.New("archive extraction failed")
+	ErrRepositoryTooLarge = errors.New("repository exceeds maximum allowed size")
+	ErrNoAnalyzableFiles  = errors.New("no analyzable source files")
+	ErrParseFailed        = errors.New("source parsing failed")
+	ErrAnalysisFailed     = errors.New("analysis failed")
+	ErrAnalysisTimeout    = errors.New("analysis timed out")
+	ErrAnalysisCanceled   = errors.New("analysis canceled")
+)
+
+// Pipeline stages reported in AnalysisError
+const (
+	StageValidate = "validate"
+	StageClone    = "clone"
+	StageExtract  = "extract"
+	StageCollect  = "collect"
+	StageAnalyze  = "analyze"
+)
+
+// AnalysisError records the pipeline stage that failed, the failure kind
+// (one of the sentinel errors above) and the underlying cause
+type AnalysisError struct {
+	Stage string
+	Kind  error
+	Err   error
+}
+
+// Error implements the error interface
+func (e *AnalysisError) Error() string {
+	switch {
+	case e.Err == nil:
+		return fmt.Sprintf("%s: %v", e.Stage, e.Kind)
+	case errors.Is(e.Err, e.Kind):
+		return fmt.Sprintf("%s: %v", e.Stage, e.Err) // the cause already names the kind
+	default:
+		return fmt.Sprintf("%s: %v: %v", e.Stage, e.Kind, e.Err)
+	}
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is and errors.As
+func (e *AnalysisError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
@@ -10,6 +10,20 @@ type RepositoryURL struct {
 	Path   string `json:"path"`
 }
 
+// Supported source archive formats
+const (
+	ArchiveFormatTarGz = "tar.gz"
+	ArchiveFormatTar   = "tar"
+	ArchiveFormatZip   = "zip"
+)
+
+// ArchiveFile represents a validated local source archive
+type ArchiveFile struct {
+	Path   string `json:"path"`
+	Format string `json:"format"` // tar.gz, tar, zip
+	Size   int64  `json:"size"`
+}
+
 // ValidationError represents input validation errors
 type ValidationError struct {
 	Field   string `json:"field"`
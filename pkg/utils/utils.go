@@ -4,9 +4,32 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// byteSizeUnits maps size suffixes to multipliers; units are binary (1KB = 1024 bytes)
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// durationUnits maps calendar suffixes, which time.ParseDuration lacks, to their length
+var durationUnits = []struct {
+	suffix string
+	length time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+}
+
 // StringInSlice checks if a string exists in a slice of strings
 func StringInSlice(str string, slice []string) bool {
 	for _, s := range slice {
@@ -26,3 +49,52 @@ func TrimWhitespace(s string) string {
 func FormatError(operation string, err error) error {
 	return fmt.Errorf("%s: %w", operation, err)
 }
+
+// ParseByteSize parses sizes such as "500MB", "2GB" or "1048576" into bytes
+func ParseByteSize(s string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	multiplier := int64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size %q: expected a number with an optional B, KB, MB, GB or TB suffix", s)
+	}
+
+	return int64(number * float64(multiplier)), nil
+}
+
+// ParseDuration parses day and week durations such as "90d" or "2w" in addition to
+// the time.ParseDuration forms ("36h", "90m"). Negative durations are rejected.
+func ParseDuration(s string) (time.Duration, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	for _, unit := range durationUnits {
+		if number, ok := strings.CutSuffix(value, unit.suffix); ok {
+			count, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration %q: expected a number of days (d) or weeks (w), or a Go duration", s)
+			}
+			return time.Duration(count * float64(unit.length)), nil
+		}
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration %q: expected a number of days (d) or weeks (w), or a Go duration", s)
+	}
+	return duration, nil
+}
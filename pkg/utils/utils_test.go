@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -95,3 +96,62 @@ func TestFormatError(t *testing.T) {
 	// Test that it wraps the original error
 	assert.ErrorIs(t, formattedErr, originalErr)
 }
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{input: "1048576", expected: 1048576},
+		{input: "512B", expected: 512},
+		{input: "10KB", expected: 10 * 1024},
+		{input: "500mb", expected: 500 * 1024 * 1024},
+		{input: "1.5GB", expected: 3 * 512 * 1024 * 1024},
+		{input: " 2 TB ", expected: 2 * 1024 * 1024 * 1024 * 1024},
+		{input: "", wantErr: true},
+		{input: "lots", wantErr: true},
+		{input: "-1GB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			size, err := ParseByteSize(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, size)
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{input: "90d", expected: 90 * 24 * time.Hour},
+		{input: "2w", expected: 14 * 24 * time.Hour},
+		{input: " 1.5D ", expected: 36 * time.Hour},
+		{input: "36h", expected: 36 * time.Hour},
+		{input: "", wantErr: true},
+		{input: "soon", wantErr: true},
+		{input: "-3d", wantErr: true},
+		{input: "-1h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			duration, err := ParseDuration(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, duration)
+		})
+	}
+}